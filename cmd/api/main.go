@@ -1,5 +1,7 @@
 package main
 
+import "homeinsight-properties/internal/app"
+
 // @title HomeInsight Properties API
 // @version 1.0
 // @description A comprehensive property management API for real estate data
@@ -21,9 +23,9 @@ package main
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
-	cfg := LoadConfiguration()
-	app := NewApp(cfg)
-	defer app.cleanup()
-	app.InitializeServer()
-	app.StartServer()
+	cfg := app.LoadConfiguration()
+	a := app.New(cfg)
+	defer a.Close()
+	a.InitializeServer()
+	a.StartServer()
 }
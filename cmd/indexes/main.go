@@ -0,0 +1,38 @@
+// Command indexes builds the MongoDB indexes the API relies on and exits, so new indexes on large
+// collections can be built ahead of a deploy instead of at startup. With -rebuild, indexes whose
+// definition changed are dropped and built again.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"homeinsight-properties/internal/app"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/logger"
+)
+
+func main() {
+	rebuild := flag.Bool("rebuild", false, "drop and build again the indexes whose definition changed")
+	flag.Parse()
+
+	cfg := app.LoadConfiguration()
+	if err := database.InitDB(cfg); err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize database: %v", err)
+		os.Exit(1)
+	}
+	defer database.CloseDB()
+
+	report, err := database.EnsureIndexes(context.Background(), database.DB, database.EnsureIndexOptions{Rebuild: *rebuild})
+	for _, result := range report.Indexes {
+		if result.Status != database.IndexStatusExists {
+			logger.GlobalLogger.Printf("%s.%s: %s %s", result.Collection, result.Name, result.Status, result.Error)
+		}
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to ensure indexes: %v", err)
+		database.CloseDB()
+		os.Exit(1)
+	}
+}
@@ -24,893 +24,8221 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/login": {
-            "post": {
-                "description": "Authenticate user and return JWT token",
-                "consumes": [
-                    "application/json"
+        "/admin/audit-events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Page through the security audit log, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Login user",
+                "summary": "List audit events",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "credentials",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Start, as an RFC 3339 timestamp or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End, as an RFC 3339 timestamp or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User who caused the events",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.TokenResponse"
+                            "$ref": "#/definitions/models.AuditEventsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/properties": {
-            "get": {
+        "/admin/cache/flush": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of all properties",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Drop all cached data",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Properties"
-                ],
-                "summary": "Get all properties with pagination",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset for pagination",
-                        "name": "offset",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit for pagination",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Admin"
                 ],
+                "summary": "Flush the cache",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.PaginatedPropertiesResponse"
+                            "type": "object",
+                            "properties": {
+                                "deleted": {
+                                    "type": "integer"
+                                }
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/cache/properties/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new property record",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Drop the cached copies of one property and the search results containing it",
                 "tags": [
-                    "Properties"
+                    "Admin"
                 ],
-                "summary": "Create a new property",
+                "summary": "Invalidate a cached property",
                 "parameters": [
                     {
-                        "description": "Property data",
-                        "name": "property",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Property"
-                        }
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/models.Property"
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/cache/search": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Drop all cached search, full-text and list results",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Clear cached searches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "properties": {
+                                "deleted": {
+                                    "type": "integer"
+                                }
                             }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/properties/property-search": {
+        "/admin/data-quality": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Search for properties based on query string",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Report the average completeness score of the organization's properties and how many have each issue",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Properties"
-                ],
-                "summary": "Search for a specific property",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Search query",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "Get data quality report",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Property"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.DataQualityReport"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/properties/{id}": {
+        "/admin/deprecations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a single property by its ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Report deprecated endpoints and parameters and which clients still use them",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Properties"
-                ],
-                "summary": "Get property by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Property ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Admin"
                 ],
+                "summary": "List deprecations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Property"
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.DeprecationReport"
+                                    }
+                                }
+                            }
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/errors.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/register": {
-            "post": {
-                "description": "Register a new user account",
-                "consumes": [
-                    "application/json"
+        "/admin/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List the candidate pairs found by the latest duplicate scan",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "List duplicate candidates",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.User"
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.TokenResponse"
+                            "$ref": "#/definitions/models.DuplicatesResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/duplicates/scan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a scan for properties sharing a normalized address or CLIP and return the job to poll",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Scan for duplicates",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/explain/{query}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run MongoDB's explain on a named property query with the same filter, sort, q and limit parameters as the endpoint that sends it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Explain a property query",
+                "parameters": [
+                    {
+                        "enum": [
+                            "properties.list",
+                            "properties.cursor",
+                            "properties.search"
+                        ],
+                        "type": "string",
+                        "description": "Query name",
+                        "name": "query",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search text, for properties.search",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated field:asc or field:desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "FloodZone matches FEMA flood zones, such as AE or a comma-separated list like AE,VE",
+                        "name": "floodZone",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxAssessedValue",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxBaths",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxBeds",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "maxWildfireRisk",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxYearBuilt",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minAssessedValue",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minBaths",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minBeds",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "minWildfireRisk",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minYearBuilt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 10,
+                        "type": "string",
+                        "name": "zipCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueryExplanation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/indexes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a background build of the indexes the API relies on and return the job to poll",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Ensure indexes",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Drop and build again the indexes whose definition changed",
+                        "name": "rebuild",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/migrations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the migrations admins can launch, each with its latest run",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List migrations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.MigrationInfo"
+                                    }
+                                }
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/migrations/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the latest runs of a migration with their progress, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get migration runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Migration name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.MigrationRun"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Launch a migration in the background, resuming its last run if that one failed, and return the run to poll",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Start a migration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Migration name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.MigrationRun"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/properties/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Merge one property into another and move the merged one to the trash",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Merge properties",
+                "parameters": [
+                    {
+                        "description": "Properties to merge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergePropertiesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MergePropertiesResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reindex": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the index build jobs, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List index builds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.ReindexJob"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a background index build and return the job to poll",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Start an index build",
+                "parameters": [
+                    {
+                        "description": "Index definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReindexRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReindexJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reindex/{jobId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get an index build job with its progress",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get an index build",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "jobId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReindexJob"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List deleted properties that can still be restored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List deleted properties",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedPropertiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/trash/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently remove a property from the trash",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Purge a deleted property",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export daily request and CoreLogic fetch counts per organization and API key, as JSON or, with ?format=csv, a CSV file",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Export usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "organization",
+                            "api_key"
+                        ],
+                        "type": "string",
+                        "description": "Kind of subject",
+                        "name": "subjectType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization or API key",
+                        "name": "subject",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oauth/{provider}": {
+            "post": {
+                "description": "Exchange an identity provider ID token for our tokens. New users are created, and existing users are linked by verified email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login with Google or Apple",
+                "parameters": [
+                    {
+                        "enum": [
+                            "google",
+                            "apple"
+                        ],
+                        "type": "string",
+                        "description": "Identity provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Provider ID token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OAuthLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through the organization's feed runs, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "List feed runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed provider",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedRunsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/runs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a feed run with its progress and the errors of the records it couldn't apply",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Get a feed run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedRun"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/{provider}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a provider's CSV or XML file, sent as the raw request body, to be applied to properties, and return the feed run to poll",
+                "consumes": [
+                    "text/csv",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Upload a provider feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xml"
+                        ],
+                        "type": "string",
+                        "description": "File format; defaults to the Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "File name; defaults to the Content-Disposition header",
+                        "name": "fileName",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Feed file",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedRun"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the feed run"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report the status of a background job, and its result once it has succeeded",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get a background job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/states": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the states with properties, each with its property count",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "List states",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{state}/cities": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the cities of a state with properties, each with its property count",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "List cities",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Two-letter state code",
+                        "name": "state",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/locations/{state}/{city}/zips": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the zip codes of a city with properties, each with its property count",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "List zip codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Two-letter state code",
+                        "name": "state",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the access token used for this request, and the session's refresh tokens when one is sent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/markets/{zipCode}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the median sale price, average price per square foot, days-since-last-sale distribution and year-built histogram of a zip code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Markets"
+                ],
+                "summary": "Get zip code market statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Zip code",
+                        "name": "zipCode",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MarketStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through all organizations. Admin only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "List organizations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an organization. Admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Create an organization",
+                "parameters": [
+                    {
+                        "description": "Organization",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/current": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the organization of the signed-in user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get the current organization",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through the members of an organization",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "List members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MembersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Place a registered user in the organization; they see its data once their access token is refreshed. Users in another organization than the default one can only be moved by platform admins",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Add a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Member"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{id}/members/{userId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from the organization",
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Remove a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/owners/{entityId}/portfolio": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through the properties held by an owner entity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Owners"
+                ],
+                "summary": "Get owner portfolio",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner entity ID",
+                        "name": "entityId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OwnerPortfolioResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/forgot": {
+            "post": {
+                "description": "Email a single-use password reset link; the response is the same whether or not the email is registered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "Set a new password using a reset token; all existing sessions are signed out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through properties matching the filters, by offset or, with ?cursor=, by cursor in street address order. Sort can't be combined with a cursor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "List properties",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page; an empty cursor starts cursor pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated field:asc or field:desc, such as yearBuilt:desc",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated property fields to return, such as address,building.summary",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "FloodZone matches FEMA flood zones, such as AE or a comma-separated list like AE,VE",
+                        "name": "floodZone",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxAssessedValue",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxBaths",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxBeds",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "maxWildfireRisk",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxYearBuilt",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minAssessedValue",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minBaths",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minBeds",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "minWildfireRisk",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minYearBuilt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 10,
+                        "type": "string",
+                        "name": "zipCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedPropertiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new property record. Send an Idempotency-Key header to make retries safe",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Create a new property",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Property data",
+                        "name": "property",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/bulk-delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move every property matching a filter to the trash in the background and return the job to poll, or with dryRun report how many would go. Admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Bulk delete properties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Filter and options",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkPreview"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/bulk-update": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write the field mask of an update to every property matching a filter in the background and return the job to poll, or with dryRun report how many would change. Admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Bulk update properties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Filter, update and field mask",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkPreview"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/by-owner": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through the properties of the owners whose name starts with ?name=. Privileged roles only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Owners"
+                ],
+                "summary": "Search properties by owner name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner name prefix, such as SMITH JOHN",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OwnerSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue properties to be created in bulk; poll the returned job for the outcome. Admin only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Import properties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key that makes retries of this request safe",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Properties to import",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ImportPropertiesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/jobs.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/nearby": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through the properties within a radius of a point, nearest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Find nearby properties",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated property fields to return, such as address,building.summary",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NearbyPropertiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/property-detail/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single property by its ID, with its media",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Get property by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated property fields to return, such as address,building.summary",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed: listing, media, transactions, valuation",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace a whole property record",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Replace a property",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Property data",
+                        "name": "property",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a property to the trash, from which it can be restored",
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Delete a property",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/property-search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Find the property at an address, fetching it from the data provider when it isn't stored yet. With ?multi=true, every property the address could mean is returned, best match first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Search a property by address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address to search for",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return every matching property",
+                        "name": "multi",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of matches with multi, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated property fields to return, such as address,building.summary",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed: listing, media, transactions, valuation",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        },
+                        "headers": {
+                            "X-Match-Confidence": {
+                                "type": "string",
+                                "description": "How closely the address matched, from 1 down"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search properties by free text across address, owner, subdivision and school district",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Full-text search properties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text, up to 200 characters",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated property fields to return, such as address,building.summary",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedPropertiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write every property matching the filters as newline-delimited JSON",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Stream properties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated property fields to return, such as address,building.summary",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 100,
+                        "type": "string",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 50,
+                        "type": "string",
+                        "description": "FloodZone matches FEMA flood zones, such as AE or a comma-separated list like AE,VE",
+                        "name": "floodZone",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxAssessedValue",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxBaths",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxBeds",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "maxWildfireRisk",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "maxYearBuilt",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minAssessedValue",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minBaths",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minBeds",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 5,
+                        "minimum": 1,
+                        "type": "integer",
+                        "name": "minWildfireRisk",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "name": "minYearBuilt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "maxLength": 10,
+                        "type": "string",
+                        "name": "zipCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/within": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Page through the properties inside a polygon or bounding box; request the next page with the same body and the returned cursor",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Find properties within an area",
+                "parameters": [
+                    {
+                        "description": "Polygon or bounding box",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WithinRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Page size, up to 500",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated property fields to return, such as address,building.summary",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PaginatedPropertiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change individual fields of a property with a JSON merge patch (RFC 7386); null removes a field",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Patch a property",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/changes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List what each refresh from a data provider changed in a property, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Get provider refresh changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyDiffsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/demographics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the income, population and housing statistics of the census tract a property lies in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Get neighborhood demographics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Demographics"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/documents": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Store a document, such as an inspection report, for a property",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload a document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption",
+                        "name": "caption",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyMedia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List who changed a property, when, and which fields changed, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Get property change history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/listings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all listings of a property, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "List listings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.Listing"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List a property for sale. A property can have one active or pending listing at a time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Create a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Listing data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ListingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/listings/{listingId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one listing of a property",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Get a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Listing ID",
+                        "name": "listingId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the price, status and details of a listing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Update a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Listing ID",
+                        "name": "listingId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Listing data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ListingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a listing of a property",
+                "tags": [
+                    "Listings"
+                ],
+                "summary": "Delete a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Listing ID",
+                        "name": "listingId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/media/{mediaId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a photo or document of a property",
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Delete a photo or document",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Media ID",
+                        "name": "mediaId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/photos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Store an image for a property",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Media"
+                ],
+                "summary": "Upload a photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to upload",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Caption",
+                        "name": "caption",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PropertyMedia"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/related": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the other properties held by the owner of a property",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Owners"
+                ],
+                "summary": "Get related properties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "owner"
+                        ],
+                        "type": "string",
+                        "default": "owner",
+                        "description": "Relation",
+                        "name": "by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RelatedPropertiesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring a deleted property back out of the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Restore a property",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Property"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/share": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's share links to a property",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "List share links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.ShareLinkResponse"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a public, expiring link to a read-only view of a property",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "Create a share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Link lifetime",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateShareLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShareLinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/share/{linkId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a share link so it stops working",
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "Revoke a share link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share link ID",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/tax-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the yearly tax assessments of a property, oldest year first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Get tax history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.TaxAssessment"
+                                    }
+                                },
+                                "propertyId": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the recorded sales and transfers of a property, most recent first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Get property transactions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest sale date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Latest sale date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TransactionHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/properties/{id}/valuation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the estimated value, confidence score and value range of a property",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Properties"
+                ],
+                "summary": "Get property valuation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Property ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Valuation"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's saved searches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "List saved searches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.SavedSearch"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save a search; new properties matching it are reported as matches and notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "Create a saved search",
+                "parameters": [
+                    {
+                        "description": "Search criteria",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSavedSearchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete one of the current user's saved searches",
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "Delete a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/saved-searches/{id}/matches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the properties a saved search has matched, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Saved Searches"
+                ],
+                "summary": "List saved search matches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Saved search ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SavedSearchMatchesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shared/properties/{token}": {
+            "get": {
+                "description": "Get the read-only view of a property behind a share link; no authentication is needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sharing"
+                ],
+                "summary": "View a shared property",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SharedPropertyView"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token; the refresh token is rotated on every use",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notification-preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current user's notification preferences",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreference"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the current user's notification channels and digest frequency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateNotificationPreferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationPreference"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's in-app notifications, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "List notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.NotificationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notifications/read-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark all of the current user's notifications read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all notifications read",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "updated": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/notifications/{notificationId}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark one of the current user's notifications read",
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark a notification read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "notificationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the current user's active sessions with device, IP and issued/last-used times; the session making the request is marked current",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "List signed-in devices",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/sessions/{sessionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sign out one of the current user's sessions; its refresh token stops working and its access tokens are rejected",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Sign out a device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the registered webhooks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/models.Webhook"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a callback URL for property events. The response carries the signing secret, which is not shown again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stop sending events to a webhook",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/errors.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "errors.ErrorBody": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {},
+                "message": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                }
+            }
+        },
+        "errors.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/errors.ErrorBody"
+                }
+            }
+        },
+        "handlers.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "password123"
+                }
+            }
+        },
+        "handlers.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wEXAMPLEr8Lk0rV1Zr2m6pQ..."
+                }
+            }
+        },
+        "handlers.OAuthLoginRequest": {
+            "type": "object",
+            "required": [
+                "id_token"
+            ],
+            "properties": {
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "John Doe"
+                },
+                "id_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsImtpZCI6IjFlOWdkazcifQ..."
+                },
+                "nonce": {
+                    "type": "string",
+                    "example": "n-0S6_WzA2Mj"
+                }
+            }
+        },
+        "handlers.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wEXAMPLEr8Lk0rV1Zr2m6pQ..."
+                }
+            }
+        },
+        "handlers.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "full_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "full_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 6,
+                    "example": "password123"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 15,
+                    "example": "123-456-7890"
+                }
+            }
+        },
+        "handlers.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 6,
+                    "example": "newpassword123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "Zm9vYmFyEXAMPLEc2VjcmV0dG9rZW4..."
+                }
+            }
+        },
+        "handlers.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "string",
+                    "example": "3599"
+                },
+                "refresh_expires_in": {
+                    "type": "string",
+                    "example": "2592000"
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "3q2-7wEXAMPLEr8Lk0rV1Zr2m6pQ..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token_type": {
+                    "type": "string",
+                    "example": "Bearer"
+                }
+            }
+        },
+        "jobs.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "maxAttempts": {
+                    "type": "integer"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "progress": {},
+                "result": {},
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddMemberRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "agent@acme-realty.com"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "member"
+                    ],
+                    "example": "member"
+                }
+            }
+        },
+        "models.Address": {
+            "type": "object",
+            "required": [
+                "city",
+                "state",
+                "streetAddress",
+                "zipCode"
+            ],
+            "properties": {
+                "carrierRoute": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "county": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "streetAddress": {
+                    "type": "string"
+                },
+                "streetAddressParsed": {
+                    "$ref": "#/definitions/models.StreetAddressParsed"
+                },
+                "zipCode": {
+                    "type": "string"
+                },
+                "zipPlus4": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AirConditioning": {
+            "type": "object",
+            "properties": {
+                "typeCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AssessedValue": {
+            "type": "object",
+            "properties": {
+                "improvementValue": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "improvementValuePercentage": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "landValue": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "totalValue": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.AssessedValueChange": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "integer"
+                },
+                "changePercent": {
+                    "type": "number"
+                },
+                "current": {
+                    "type": "integer"
+                },
+                "previous": {
+                    "type": "integer"
+                },
+                "previousYear": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "actorId": {
+                    "type": "string"
+                },
+                "actorRole": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "occurredAt": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "targetId": {
+                    "type": "string"
+                },
+                "targetType": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditEventsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditEvent"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.Basement": {
+            "type": "object",
+            "properties": {
+                "typeCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Building": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "$ref": "#/definitions/models.BuildingDetails"
+                },
+                "summary": {
+                    "$ref": "#/definitions/models.BuildingSummary"
+                }
+            }
+        },
+        "models.BuildingDetails": {
+            "type": "object",
+            "properties": {
+                "classification": {
+                    "$ref": "#/definitions/models.Classification"
+                },
+                "construction": {
+                    "$ref": "#/definitions/models.Construction"
+                },
+                "exterior": {
+                    "$ref": "#/definitions/models.Exterior"
+                },
+                "interior": {
+                    "$ref": "#/definitions/models.Interior"
+                },
+                "structureId": {
+                    "$ref": "#/definitions/models.StructureID"
+                },
+                "verticalProfile": {
+                    "$ref": "#/definitions/models.VerticalProfile"
+                }
+            }
+        },
+        "models.BuildingSummary": {
+            "type": "object",
+            "properties": {
+                "bathroomFixturesCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "bathroomsCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "bedroomsCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "buildingsCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "familyRoomsCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "fireplacesCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "fullBathroomsCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "halfBathroomsCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "kitchensCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "livingAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "livingRoomsCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "totalAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.BulkDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "filter": {
+                    "$ref": "#/definitions/models.PropertyFilter"
+                }
+            }
+        },
+        "models.BulkPreview": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkPropertyError"
+                    }
+                },
+                "fieldMask": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "maxAllowed": {
+                    "type": "integer"
+                },
+                "sampleIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BulkPropertyError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "propertyId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BulkUpdateRequest": {
+            "type": "object",
+            "required": [
+                "fieldMask"
+            ],
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "fieldMask": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "filter": {
+                    "$ref": "#/definitions/models.PropertyFilter"
+                },
+                "update": {}
+            }
+        },
+        "models.Buyer": {
+            "type": "object",
+            "properties": {
+                "firstNameAndMiddleInitial": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CBSA": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CensusTract": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Classification": {
+            "type": "object",
+            "properties": {
+                "buildingTypeCode": {
+                    "type": "string"
+                },
+                "gradeTypeCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Construction": {
+            "type": "object",
+            "properties": {
+                "buildingImprovementConditionCode": {
+                    "type": "string"
+                },
+                "buildingQualityTypeCode": {
+                    "type": "string"
+                },
+                "effectiveYearBuilt": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "foundationTypeCode": {
+                    "type": "string"
+                },
+                "frameTypeCode": {
+                    "type": "string"
+                },
+                "yearBuilt": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.Coordinates": {
+            "type": "object",
+            "properties": {
+                "block": {
+                    "$ref": "#/definitions/models.CoordinatesPoint"
+                },
+                "parcel": {
+                    "$ref": "#/definitions/models.CoordinatesPoint"
+                }
+            }
+        },
+        "models.CoordinatesPoint": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "lng": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "models.CreateOrganizationRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "slug"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Acme Realty"
+                },
+                "slug": {
+                    "type": "string",
+                    "maxLength": 63,
+                    "example": "acme-realty"
+                }
+            }
+        },
+        "models.CreateSavedSearchRequest": {
+            "type": "object",
+            "required": [
+                "criteria",
+                "name"
+            ],
+            "properties": {
+                "criteria": {
+                    "$ref": "#/definitions/models.SavedSearchCriteria"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Downtown condos"
+                }
+            }
+        },
+        "models.CreateShareLinkRequest": {
+            "type": "object",
+            "properties": {
+                "expires_in_hours": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 72
+                }
+            }
+        },
+        "models.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "property.updated"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/hooks/properties"
+                }
+            }
+        },
+        "models.DataQuality": {
+            "type": "object",
+            "properties": {
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DataQualityReport": {
+            "type": "object",
+            "properties": {
+                "averageScore": {
+                    "type": "number"
+                },
+                "issues": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unscored": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Demographics": {
+            "type": "object",
+            "properties": {
+                "censusTract": {
+                    "description": "CensusTract is the tract's 11-digit GEOID: state, county and tract number",
+                    "type": "string"
+                },
+                "households": {
+                    "type": "integer"
+                },
+                "housing": {
+                    "$ref": "#/definitions/models.DemographicsHousing"
+                },
+                "income": {
+                    "$ref": "#/definitions/models.DemographicsIncome"
+                },
+                "medianAge": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "population": {
+                    "type": "integer"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DemographicsHousing": {
+            "type": "object",
+            "properties": {
+                "medianGrossRent": {
+                    "type": "integer"
+                },
+                "medianHomeValue": {
+                    "type": "integer"
+                },
+                "medianYearBuilt": {
+                    "type": "integer"
+                },
+                "occupied": {
+                    "type": "integer"
+                },
+                "ownerOccupied": {
+                    "type": "integer"
+                },
+                "ownerOccupiedRate": {
+                    "type": "number"
+                },
+                "renterOccupied": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "integer"
+                },
+                "vacancyRate": {
+                    "type": "number"
+                },
+                "vacant": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DemographicsIncome": {
+            "type": "object",
+            "properties": {
+                "medianHousehold": {
+                    "type": "integer"
+                },
+                "perCapita": {
+                    "type": "integer"
+                },
+                "povertyRate": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.DeprecationClientUsage": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "client": {
+                    "type": "string",
+                    "example": "user:64f1c2a9e4b0a1b2c3d4e5f6"
+                },
+                "lastSeen": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeprecationReport": {
+            "type": "object",
+            "properties": {
+                "clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeprecationClientUsage"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "properties-offset-pagination"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "param": {
+                    "description": "empty deprecates the whole endpoint",
+                    "type": "string",
+                    "example": "offset"
+                },
+                "replacement": {
+                    "type": "string",
+                    "example": "GET /api/properties?cursor="
+                },
+                "route": {
+                    "type": "string",
+                    "example": "/api/properties"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "sunset": {
+                    "type": "string"
+                },
+                "totalCalls": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DuplicateCandidate": {
+            "type": "object",
+            "properties": {
+                "detectedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "propertyIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.DuplicatesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DuplicateCandidate"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.Exterior": {
+            "type": "object",
+            "properties": {
+                "parking": {
+                    "$ref": "#/definitions/models.Parking"
+                },
+                "patios": {
+                    "$ref": "#/definitions/models.Patios"
+                },
+                "pool": {
+                    "$ref": "#/definitions/models.Pool"
+                },
+                "porches": {
+                    "$ref": "#/definitions/models.Porches"
+                },
+                "roof": {
+                    "$ref": "#/definitions/models.Roof"
+                },
+                "walls": {
+                    "$ref": "#/definitions/models.Walls"
+                }
+            }
+        },
+        "models.Features": {
+            "type": "object",
+            "properties": {
+                "airConditioning": {
+                    "$ref": "#/definitions/models.AirConditioning"
+                },
+                "fireplaces": {
+                    "$ref": "#/definitions/models.Fireplaces"
+                },
+                "heating": {
+                    "$ref": "#/definitions/models.Heating"
+                }
+            }
+        },
+        "models.FeedRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FeedRun": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jobId": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "rowErrors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeedRowError"
+                    }
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "sizeBytes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedRunsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeedRun"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.FieldChange": {
+            "type": "object",
+            "properties": {
+                "new": {},
+                "old": {},
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Fireplaces": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "typeCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Flooring": {
+            "type": "object",
+            "properties": {
+                "coverTypeCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GeoJSONPolygon": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "array",
+                            "items": {
+                                "type": "number"
+                            }
+                        }
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HazardRisk": {
+            "type": "object",
+            "properties": {
+                "floodRiskScore": {
+                    "type": "integer"
+                },
+                "floodZone": {
+                    "description": "FloodZone is the FEMA flood zone designation, such as AE, VE or X",
+                    "type": "string"
+                },
+                "floodZoneSubtype": {
+                    "type": "string"
+                },
+                "retrievedAt": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "specialFloodHazardArea": {
+                    "description": "SpecialFloodHazardArea is set in zones with a 1% annual chance of flooding, where federally\nbacked mortgages require flood insurance",
+                    "type": "boolean"
+                },
+                "wildfireRiskScore": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Heating": {
+            "type": "object",
+            "properties": {
+                "typeCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HistogramBucket": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "90-179"
+                },
+                "max": {
+                    "type": "integer"
+                },
+                "min": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportPropertiesRequest": {
+            "type": "object",
+            "required": [
+                "properties"
+            ],
+            "properties": {
+                "properties": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.Property"
+                    }
+                }
+            }
+        },
+        "models.IndexKey": {
+            "type": "object",
+            "required": [
+                "field",
+                "type"
+            ],
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "address.zipCode"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "asc",
+                        "desc",
+                        "text",
+                        "2dsphere",
+                        "hashed"
+                    ],
+                    "example": "asc"
+                }
+            }
+        },
+        "models.Interior": {
+            "type": "object",
+            "properties": {
+                "area": {
+                    "$ref": "#/definitions/models.InteriorArea"
+                },
+                "basement": {
+                    "$ref": "#/definitions/models.Basement"
+                },
+                "features": {
+                    "$ref": "#/definitions/models.Features"
+                },
+                "flooring": {
+                    "$ref": "#/definitions/models.Flooring"
+                },
+                "walls": {
+                    "$ref": "#/definitions/models.Walls"
+                }
+            }
+        },
+        "models.InteriorArea": {
+            "type": "object",
+            "properties": {
+                "aboveGradeAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "aboveGroundFloorAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "basementAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "buildingAdditionsAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "groundFloorAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "livingAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "unfinishedBasementAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "universalBuildingAreaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.LandUseAndZoning": {
+            "type": "object",
+            "properties": {
+                "landUseCode": {
+                    "type": "string"
+                },
+                "propertyTypeCode": {
+                    "type": "string"
+                },
+                "stateLandUseCode": {
+                    "type": "string"
+                },
+                "stateLandUseDescription": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LastMarketSale": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "bookNumber": {
+                    "type": "string"
+                },
+                "buyers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Buyer"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                },
+                "documentNumber": {
+                    "type": "string"
+                },
+                "documentTypeCode": {
+                    "type": "string"
+                },
+                "isMortgagePurchase": {
+                    "type": "boolean"
+                },
+                "isResale": {
+                    "type": "boolean"
+                },
+                "multiOrSplitParcelCode": {
+                    "type": "string"
+                },
+                "pageNumber": {
+                    "type": "string"
+                },
+                "recordingDate": {
+                    "type": "string"
+                },
+                "sellers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Seller"
+                    }
+                },
+                "titleCompany": {
+                    "$ref": "#/definitions/models.TitleCompany"
+                }
+            }
+        },
+        "models.Legal": {
+            "type": "object",
+            "properties": {
+                "subdivisionName": {
+                    "type": "string"
+                },
+                "subdivisionPlatBookNumber": {
+                    "type": "string"
+                },
+                "subdivisionPlatPageNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Listing": {
+            "type": "object",
+            "properties": {
+                "agent": {
+                    "$ref": "#/definitions/models.ListingAgent"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "listPrice": {
+                    "type": "integer",
+                    "example": 450000
+                },
+                "listedAt": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "soldAt": {
+                    "type": "string"
+                },
+                "soldPrice": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ListingAgent": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "brokerage": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "licenseNumber": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ListingRequest": {
+            "type": "object",
+            "required": [
+                "agent",
+                "listPrice",
+                "status"
+            ],
+            "properties": {
+                "agent": {
+                    "$ref": "#/definitions/models.ListingAgent"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "listPrice": {
+                    "type": "integer",
+                    "example": 450000
+                },
+                "listedAt": {
+                    "type": "string"
+                },
+                "soldPrice": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "models.Location": {
+            "type": "object",
+            "properties": {
+                "cbsa": {
+                    "$ref": "#/definitions/models.CBSA"
+                },
+                "censusTract": {
+                    "$ref": "#/definitions/models.CensusTract"
+                },
+                "coordinates": {
+                    "$ref": "#/definitions/models.Coordinates"
+                },
+                "legal": {
+                    "$ref": "#/definitions/models.Legal"
+                }
+            }
+        },
+        "models.LocationCount": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "SAN DIEGO"
+                },
+                "propertyCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LocationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LocationCount"
+                    }
+                }
+            }
+        },
+        "models.Lot": {
+            "type": "object",
+            "properties": {
+                "areaAcres": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "areaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "areaSquareFeetUsable": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "topographyType": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MailingAddress": {
+            "type": "object",
+            "properties": {
+                "carrierRoute": {
+                    "type": "string"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "streetAddress": {
+                    "type": "string"
+                },
+                "zipCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MarketStats": {
+            "type": "object",
+            "properties": {
+                "averagePricePerSqft": {
+                    "type": "number",
+                    "example": 412.5
+                },
+                "computedAt": {
+                    "type": "string"
+                },
+                "daysSinceLastSale": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HistogramBucket"
+                    }
+                },
+                "medianSalePrice": {
+                    "type": "number",
+                    "example": 615000
+                },
+                "propertyCount": {
+                    "type": "integer"
+                },
+                "salesCount": {
+                    "type": "integer"
+                },
+                "yearBuilt": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HistogramBucket"
+                    }
+                },
+                "zipCode": {
+                    "type": "string",
+                    "example": "92101"
+                }
+            }
+        },
+        "models.Member": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "joinedAt": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MembersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Member"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.MergePropertiesRequest": {
+            "type": "object",
+            "required": [
+                "keepId",
+                "mergeId"
+            ],
+            "properties": {
+                "keepId": {
+                    "type": "string"
+                },
+                "mergeId": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MergePropertiesResult": {
+            "type": "object",
+            "properties": {
+                "conflicts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "filledFields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mergedId": {
+                    "type": "string"
+                },
+                "property": {
+                    "$ref": "#/definitions/models.Property"
+                },
+                "remapped": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.MigrationInfo": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "latestRun": {
+                    "$ref": "#/definitions/models.MigrationRun"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MigrationRun": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jobId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "recentErrors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "startedBy": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NearbyPropertiesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NearbyProperty"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "query": {
+                    "$ref": "#/definitions/models.NearbyQuery"
+                }
+            }
+        },
+        "models.NearbyProperty": {
+            "type": "object",
+            "required": [
+                "propertyId"
+            ],
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "address": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "avmPropertyId": {
+                    "type": "string"
+                },
+                "building": {
+                    "$ref": "#/definitions/models.Building"
+                },
+                "dataQuality": {
+                    "description": "DataQuality is derived from the other fields whenever the property is written",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DataQuality"
+                        }
+                    ]
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "distanceMeters": {
+                    "type": "number"
+                },
+                "hazard": {
+                    "description": "Hazard is kept from the last successful hazard lookup when a refresh can't look it up again",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HazardRisk"
+                        }
+                    ]
+                },
+                "landUseAndZoning": {
+                    "$ref": "#/definitions/models.LandUseAndZoning"
+                },
+                "lastMarketSale": {
+                    "$ref": "#/definitions/models.LastMarketSale"
+                },
+                "listing": {
+                    "description": "Listing is the property's latest listing, included on request with ?include=listing. Sent when\ncreating a property, it is created as the property's first listing.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    ]
+                },
+                "location": {
+                    "$ref": "#/definitions/models.Location"
+                },
+                "lot": {
+                    "$ref": "#/definitions/models.Lot"
+                },
+                "media": {
+                    "description": "Media is read from the property_media collection when a single property is returned, and for\nsearch results on request with ?include=media",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PropertyMedia"
+                    }
+                },
+                "neighborhood": {
+                    "description": "Neighborhood is kept section by section from the last successful lookup, like Hazard",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Neighborhood"
+                        }
+                    ]
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "ownership": {
+                    "$ref": "#/definitions/models.Ownership"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "schemaVersion": {
+                    "type": "integer"
+                },
+                "taxAssessment": {
+                    "description": "TaxAssessment is the latest year of TaxAssessments, kept at the top level for filters and indexes",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaxAssessment"
+                        }
+                    ]
+                },
+                "taxAssessments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaxAssessment"
+                    }
+                },
+                "transactions": {
+                    "description": "RecentTransactions are the property's latest transactions, included on request with\n?include=transactions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Transaction"
                     }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "utilities": {
+                    "$ref": "#/definitions/models.Utilities"
+                },
+                "valuation": {
+                    "description": "Valuation is the property's current valuation, included on request with ?include=valuation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Valuation"
+                        }
+                    ]
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.LoginRequest": {
+        },
+        "models.NearbyQuery": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "user@example.com"
+                "lat": {
+                    "type": "number"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                "lng": {
+                    "type": "number"
+                },
+                "radiusMeters": {
+                    "type": "number"
                 }
             }
         },
-        "handlers.TokenResponse": {
+        "models.Neighborhood": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "walkability": {
+                    "$ref": "#/definitions/models.Walkability"
                 }
             }
         },
-        "models.Address": {
+        "models.Notification": {
             "type": "object",
             "properties": {
-                "carrierRoute": {
+                "body": {
                     "type": "string"
                 },
-                "city": {
+                "createdAt": {
                     "type": "string"
                 },
-                "county": {
+                "id": {
                     "type": "string"
                 },
-                "state": {
+                "propertyId": {
                     "type": "string"
                 },
-                "streetAddress": {
+                "readAt": {
                     "type": "string"
                 },
-                "streetAddressParsed": {
-                    "$ref": "#/definitions/models.StreetAddressParsed"
-                },
-                "zipCode": {
+                "title": {
                     "type": "string"
                 },
-                "zipPlus4": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "models.AirConditioning": {
+        "models.NotificationPreference": {
             "type": "object",
             "properties": {
-                "typeCode": {
+                "digestFrequency": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "models.AssessedValue": {
+        "models.NotificationsResponse": {
             "type": "object",
             "properties": {
-                "improvementValue": {
-                    "type": "integer"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Notification"
+                    }
                 },
-                "improvementValuePercentage": {
-                    "type": "integer"
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
                 },
-                "landValue": {
+                "unreadCount": {
                     "type": "integer"
+                }
+            }
+        },
+        "models.Organization": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
                 },
-                "totalValue": {
-                    "type": "integer"
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
                 }
             }
         },
-        "models.Basement": {
+        "models.OrganizationsResponse": {
             "type": "object",
             "properties": {
-                "typeCode": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Organization"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.Owner": {
+            "type": "object",
+            "properties": {
+                "firstName": {
+                    "type": "string"
+                },
+                "fullName": {
+                    "type": "string"
+                },
+                "isCorporate": {
+                    "type": "boolean"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "middleName": {
                     "type": "string"
+                },
+                "sequenceNumber": {
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "models.Building": {
+        "models.OwnerEntity": {
             "type": "object",
             "properties": {
-                "details": {
-                    "$ref": "#/definitions/models.BuildingDetails"
+                "_id": {
+                    "type": "string"
                 },
-                "summary": {
-                    "$ref": "#/definitions/models.BuildingSummary"
+                "entityId": {
+                    "type": "string"
+                },
+                "isCorporate": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "propertyIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OwnerPortfolioResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Property"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "owner": {
+                    "$ref": "#/definitions/models.OwnerEntity"
+                }
+            }
+        },
+        "models.OwnerSearchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Property"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                },
+                "owners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OwnerEntity"
+                    }
+                }
+            }
+        },
+        "models.Ownership": {
+            "type": "object",
+            "properties": {
+                "currentOwners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Owner"
+                    }
+                },
+                "mailingAddress": {
+                    "$ref": "#/definitions/models.MailingAddress"
+                },
+                "occupancyCode": {
+                    "type": "string"
+                },
+                "relationshipTypeCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PaginatedPropertiesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Property"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Parking": {
+            "type": "object",
+            "properties": {
+                "parkingSpacesCount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "typeCode": {
+                    "type": "string"
                 }
             }
         },
-        "models.BuildingDetails": {
+        "models.Patios": {
             "type": "object",
             "properties": {
-                "classification": {
-                    "$ref": "#/definitions/models.Classification"
-                },
-                "construction": {
-                    "$ref": "#/definitions/models.Construction"
-                },
-                "exterior": {
-                    "$ref": "#/definitions/models.Exterior"
-                },
-                "interior": {
-                    "$ref": "#/definitions/models.Interior"
+                "areaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "structureId": {
-                    "$ref": "#/definitions/models.StructureID"
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "verticalProfile": {
-                    "$ref": "#/definitions/models.VerticalProfile"
+                "typeCode": {
+                    "type": "string"
                 }
             }
         },
-        "models.BuildingSummary": {
+        "models.PointsOfInterest": {
             "type": "object",
             "properties": {
-                "bathroomFixturesCount": {
-                    "type": "integer"
-                },
-                "bathroomsCount": {
+                "grocery": {
                     "type": "integer"
                 },
-                "buildingsCount": {
+                "healthcare": {
                     "type": "integer"
                 },
-                "fireplacesCount": {
+                "parks": {
                     "type": "integer"
                 },
-                "fullBathroomsCount": {
+                "restaurants": {
                     "type": "integer"
                 },
-                "halfBathroomsCount": {
+                "schools": {
                     "type": "integer"
                 },
-                "livingAreaSquareFeet": {
+                "shopping": {
                     "type": "integer"
                 },
-                "totalAreaSquareFeet": {
+                "transit": {
                     "type": "integer"
                 }
             }
         },
-        "models.Buyer": {
+        "models.Pool": {
             "type": "object",
             "properties": {
-                "firstNameAndMiddleInitial": {
-                    "type": "string"
-                },
-                "fullName": {
-                    "type": "string"
+                "areaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "lastName": {
+                "typeCode": {
                     "type": "string"
                 }
             }
         },
-        "models.CBSA": {
+        "models.Porches": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string"
+                "areaSquareFeet": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "type": {
+                "count": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "typeCode": {
                     "type": "string"
                 }
             }
         },
-        "models.CensusTract": {
+        "models.Property": {
             "type": "object",
+            "required": [
+                "propertyId"
+            ],
             "properties": {
-                "id": {
+                "_id": {
                     "type": "string"
-                }
-            }
-        },
-        "models.Classification": {
-            "type": "object",
-            "properties": {
-                "buildingTypeCode": {
+                },
+                "address": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "avmPropertyId": {
                     "type": "string"
                 },
-                "gradeTypeCode": {
+                "building": {
+                    "$ref": "#/definitions/models.Building"
+                },
+                "dataQuality": {
+                    "description": "DataQuality is derived from the other fields whenever the property is written",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.DataQuality"
+                        }
+                    ]
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "hazard": {
+                    "description": "Hazard is kept from the last successful hazard lookup when a refresh can't look it up again",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HazardRisk"
+                        }
+                    ]
+                },
+                "landUseAndZoning": {
+                    "$ref": "#/definitions/models.LandUseAndZoning"
+                },
+                "lastMarketSale": {
+                    "$ref": "#/definitions/models.LastMarketSale"
+                },
+                "listing": {
+                    "description": "Listing is the property's latest listing, included on request with ?include=listing. Sent when\ncreating a property, it is created as the property's first listing.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Listing"
+                        }
+                    ]
+                },
+                "location": {
+                    "$ref": "#/definitions/models.Location"
+                },
+                "lot": {
+                    "$ref": "#/definitions/models.Lot"
+                },
+                "media": {
+                    "description": "Media is read from the property_media collection when a single property is returned, and for\nsearch results on request with ?include=media",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PropertyMedia"
+                    }
+                },
+                "neighborhood": {
+                    "description": "Neighborhood is kept section by section from the last successful lookup, like Hazard",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Neighborhood"
+                        }
+                    ]
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "ownership": {
+                    "$ref": "#/definitions/models.Ownership"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "schemaVersion": {
+                    "type": "integer"
+                },
+                "taxAssessment": {
+                    "description": "TaxAssessment is the latest year of TaxAssessments, kept at the top level for filters and indexes",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TaxAssessment"
+                        }
+                    ]
+                },
+                "taxAssessments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaxAssessment"
+                    }
+                },
+                "transactions": {
+                    "description": "RecentTransactions are the property's latest transactions, included on request with\n?include=transactions",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Transaction"
+                    }
+                },
+                "updatedAt": {
                     "type": "string"
+                },
+                "utilities": {
+                    "$ref": "#/definitions/models.Utilities"
+                },
+                "valuation": {
+                    "description": "Valuation is the property's current valuation, included on request with ?include=valuation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Valuation"
+                        }
+                    ]
                 }
             }
         },
-        "models.Construction": {
+        "models.PropertyAuditEntry": {
             "type": "object",
             "properties": {
-                "buildingImprovementConditionCode": {
+                "action": {
                     "type": "string"
                 },
-                "buildingQualityTypeCode": {
+                "actor": {
                     "type": "string"
                 },
-                "effectiveYearBuilt": {
-                    "type": "integer"
+                "actorRole": {
+                    "type": "string"
                 },
-                "foundationTypeCode": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "id": {
                     "type": "string"
                 },
-                "frameTypeCode": {
+                "propertyId": {
                     "type": "string"
                 },
-                "yearBuilt": {
-                    "type": "integer"
+                "timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "models.Coordinates": {
+        "models.PropertyDiff": {
             "type": "object",
             "properties": {
-                "block": {
-                    "$ref": "#/definitions/models.CoordinatesPoint"
+                "assessedValue": {
+                    "description": "AssessedValue is set when the total assessed value changed, including with a new tax year",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AssessedValueChange"
+                        }
+                    ]
                 },
-                "parcel": {
-                    "$ref": "#/definitions/models.CoordinatesPoint"
+                "building": {
+                    "description": "Building lists the changed building fields, such as area, rooms or year built",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "newSale": {
+                    "description": "NewSale is set when a sale more recent than the last one known was recorded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SaleChange"
+                        }
+                    ]
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "refreshedAt": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
                 }
             }
         },
-        "models.CoordinatesPoint": {
+        "models.PropertyDiffsResponse": {
             "type": "object",
             "properties": {
-                "lat": {
-                    "type": "number"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PropertyDiff"
+                    }
                 },
-                "lng": {
-                    "type": "number"
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
                 }
             }
         },
-        "models.Exterior": {
+        "models.PropertyFilter": {
             "type": "object",
             "properties": {
-                "patios": {
-                    "$ref": "#/definitions/models.Patios"
+                "city": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "pool": {
-                    "$ref": "#/definitions/models.Pool"
+                "floodZone": {
+                    "description": "FloodZone matches FEMA flood zones, such as AE or a comma-separated list like AE,VE",
+                    "type": "string",
+                    "maxLength": 50
                 },
-                "porches": {
-                    "$ref": "#/definitions/models.Porches"
+                "maxAssessedValue": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "roof": {
-                    "$ref": "#/definitions/models.Roof"
+                "maxBaths": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "walls": {
-                    "$ref": "#/definitions/models.Walls"
-                }
-            }
-        },
-        "models.Features": {
-            "type": "object",
-            "properties": {
-                "airConditioning": {
-                    "$ref": "#/definitions/models.AirConditioning"
+                "maxBeds": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "fireplaces": {
-                    "$ref": "#/definitions/models.Fireplaces"
+                "maxPrice": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "heating": {
-                    "$ref": "#/definitions/models.Heating"
-                }
-            }
-        },
-        "models.Fireplaces": {
-            "type": "object",
-            "properties": {
-                "count": {
-                    "type": "integer"
+                "maxWildfireRisk": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
                 },
-                "typeCode": {
+                "maxYearBuilt": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minAssessedValue": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minBaths": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minBeds": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minPrice": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minWildfireRisk": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1
+                },
+                "minYearBuilt": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "state": {
                     "type": "string"
+                },
+                "zipCode": {
+                    "type": "string",
+                    "maxLength": 10
                 }
             }
         },
-        "models.Flooring": {
+        "models.PropertyHistoryResponse": {
             "type": "object",
             "properties": {
-                "coverTypeCode": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PropertyAuditEntry"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
                 }
             }
         },
-        "models.Heating": {
+        "models.PropertyMedia": {
             "type": "object",
             "properties": {
-                "typeCode": {
+                "caption": {
+                    "type": "string"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "models.Interior": {
-            "type": "object",
-            "properties": {
-                "area": {
-                    "$ref": "#/definitions/models.InteriorArea"
                 },
-                "basement": {
-                    "$ref": "#/definitions/models.Basement"
+                "kind": {
+                    "type": "string"
                 },
-                "features": {
-                    "$ref": "#/definitions/models.Features"
+                "propertyId": {
+                    "type": "string"
                 },
-                "flooring": {
-                    "$ref": "#/definitions/models.Flooring"
+                "size": {
+                    "type": "integer"
                 },
-                "walls": {
-                    "$ref": "#/definitions/models.Walls"
+                "thumbnailUrl": {
+                    "type": "string"
+                },
+                "uploadedBy": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "models.InteriorArea": {
+        "models.QueryExplanation": {
             "type": "object",
             "properties": {
-                "aboveGradeAreaSquareFeet": {
-                    "type": "integer"
+                "collection": {
+                    "type": "string"
                 },
-                "aboveGroundFloorAreaSquareFeet": {
+                "executionTimeMillis": {
                     "type": "integer"
                 },
-                "basementAreaSquareFeet": {
-                    "type": "integer"
+                "hint": {
+                    "type": "string"
                 },
-                "buildingAdditionsAreaSquareFeet": {
-                    "type": "integer"
+                "indexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "groundFloorAreaSquareFeet": {
+                "nReturned": {
                     "type": "integer"
                 },
-                "livingAreaSquareFeet": {
-                    "type": "integer"
+                "query": {
+                    "type": "string"
                 },
-                "unfinishedBasementAreaSquareFeet": {
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "totalDocsExamined": {
                     "type": "integer"
                 },
-                "universalBuildingAreaSquareFeet": {
+                "totalKeysExamined": {
                     "type": "integer"
-                }
+                },
+                "winningPlan": {}
             }
         },
-        "models.LandUseAndZoning": {
+        "models.ReindexJob": {
             "type": "object",
             "properties": {
-                "landUseCode": {
+                "collection": {
                     "type": "string"
                 },
-                "propertyTypeCode": {
+                "completedAt": {
                     "type": "string"
                 },
-                "stateLandUseCode": {
+                "createdAt": {
                     "type": "string"
                 },
-                "stateLandUseDescription": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.LastMarketSale": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "integer"
+                "dropIndexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "bookNumber": {
+                "error": {
                     "type": "string"
                 },
-                "buyers": {
+                "hintFor": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Buyer"
+                        "type": "string"
                     }
                 },
-                "date": {
+                "id": {
                     "type": "string"
                 },
-                "documentNumber": {
+                "indexName": {
                     "type": "string"
                 },
-                "documentTypeCode": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IndexKey"
+                    }
+                },
+                "progress": {
+                    "type": "number"
+                },
+                "requestedBy": {
                     "type": "string"
                 },
-                "isMortgagePurchase": {
-                    "type": "boolean"
+                "status": {
+                    "type": "string"
                 },
-                "isResale": {
+                "unique": {
                     "type": "boolean"
                 },
-                "multiOrSplitParcelCode": {
+                "updatedAt": {
                     "type": "string"
+                }
+            }
+        },
+        "models.ReindexRequest": {
+            "type": "object",
+            "required": [
+                "collection",
+                "indexName",
+                "keys"
+            ],
+            "properties": {
+                "collection": {
+                    "type": "string",
+                    "example": "properties"
                 },
-                "pageNumber": {
-                    "type": "string"
+                "dropIndexes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "streetAddress_1__id_1"
+                    ]
                 },
-                "recordingDate": {
-                    "type": "string"
+                "hintFor": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "properties.list"
+                    ]
                 },
-                "sellers": {
+                "indexName": {
+                    "type": "string",
+                    "example": "zip_street"
+                },
+                "keys": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/models.Seller"
+                        "$ref": "#/definitions/models.IndexKey"
                     }
                 },
-                "titleCompany": {
-                    "$ref": "#/definitions/models.TitleCompany"
+                "unique": {
+                    "type": "boolean"
                 }
             }
         },
-        "models.Legal": {
+        "models.RelatedPropertiesResponse": {
             "type": "object",
             "properties": {
-                "subdivisionName": {
+                "by": {
                     "type": "string"
                 },
-                "subdivisionPlatBookNumber": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Property"
+                    }
                 },
-                "subdivisionPlatPageNumber": {
+                "owners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OwnerEntity"
+                    }
+                },
+                "propertyId": {
                     "type": "string"
                 }
             }
         },
-        "models.Location": {
+        "models.Roof": {
             "type": "object",
             "properties": {
-                "cbsa": {
-                    "$ref": "#/definitions/models.CBSA"
-                },
-                "censusTract": {
-                    "$ref": "#/definitions/models.CensusTract"
-                },
-                "coordinates": {
-                    "$ref": "#/definitions/models.Coordinates"
+                "coverTypeCode": {
+                    "type": "string"
                 },
-                "legal": {
-                    "$ref": "#/definitions/models.Legal"
+                "typeCode": {
+                    "type": "string"
                 }
             }
         },
-        "models.Lot": {
+        "models.SaleChange": {
             "type": "object",
             "properties": {
-                "areaAcres": {
-                    "type": "number"
-                },
-                "areaSquareFeet": {
+                "amount": {
                     "type": "integer"
                 },
-                "areaSquareFeetUsable": {
+                "date": {
+                    "type": "string"
+                },
+                "previousAmount": {
                     "type": "integer"
                 },
-                "topographyType": {
+                "previousDate": {
                     "type": "string"
                 }
             }
         },
-        "models.MailingAddress": {
+        "models.SavedSearch": {
             "type": "object",
             "properties": {
-                "carrierRoute": {
+                "createdAt": {
                     "type": "string"
                 },
-                "city": {
+                "criteria": {
+                    "$ref": "#/definitions/models.SavedSearchCriteria"
+                },
+                "id": {
                     "type": "string"
                 },
-                "state": {
+                "lastRunAt": {
                     "type": "string"
                 },
-                "streetAddress": {
+                "matchCount": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "zipCode": {
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "models.Owner": {
+        "models.SavedSearchCriteria": {
             "type": "object",
             "properties": {
-                "firstName": {
-                    "type": "string"
+                "address": {
+                    "type": "string",
+                    "example": "main st"
                 },
-                "fullName": {
-                    "type": "string"
+                "city": {
+                    "type": "string",
+                    "example": "Austin"
                 },
-                "isCorporate": {
-                    "type": "boolean"
+                "maxPrice": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 500000
                 },
-                "lastName": {
-                    "type": "string"
+                "minPrice": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 250000
                 },
-                "middleName": {
-                    "type": "string"
+                "state": {
+                    "type": "string",
+                    "example": "TX"
                 },
-                "sequenceNumber": {
-                    "type": "integer"
+                "zipCode": {
+                    "type": "string",
+                    "example": "78701"
                 }
             }
         },
-        "models.Ownership": {
+        "models.SavedSearchMatch": {
             "type": "object",
             "properties": {
-                "currentOwners": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Owner"
-                    }
+                "address": {
+                    "$ref": "#/definitions/models.Address"
                 },
-                "mailingAddress": {
-                    "$ref": "#/definitions/models.MailingAddress"
+                "id": {
+                    "type": "string"
                 },
-                "occupancyCode": {
+                "matchedAt": {
                     "type": "string"
                 },
-                "relationshipTypeCode": {
+                "price": {
+                    "type": "integer"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "savedSearchId": {
+                    "type": "string"
+                },
+                "userId": {
                     "type": "string"
                 }
             }
         },
-        "models.PaginatedPropertiesResponse": {
+        "models.SavedSearchMatchesResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.PropertyResponse"
+                        "$ref": "#/definitions/models.SavedSearchMatch"
                     }
                 },
                 "metadata": {
@@ -918,141 +8246,138 @@ const docTemplate = `{
                 }
             }
         },
-        "models.PaginationMeta": {
+        "models.SchoolDistrict": {
             "type": "object",
             "properties": {
-                "limit": {
-                    "type": "integer"
-                },
-                "next": {
+                "code": {
                     "type": "string"
                 },
-                "offset": {
-                    "type": "integer"
-                },
-                "prev": {
+                "name": {
                     "type": "string"
-                },
-                "total": {
-                    "type": "integer"
                 }
             }
         },
-        "models.Patios": {
+        "models.Seller": {
             "type": "object",
             "properties": {
-                "areaSquareFeet": {
-                    "type": "integer"
-                },
-                "count": {
-                    "type": "integer"
-                },
-                "typeCode": {
+                "fullName": {
                     "type": "string"
                 }
             }
         },
-        "models.Pool": {
+        "models.Session": {
             "type": "object",
             "properties": {
-                "areaSquareFeet": {
-                    "type": "integer"
+                "createdAt": {
+                    "type": "string"
                 },
-                "typeCode": {
+                "current": {
+                    "type": "boolean"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "userAgent": {
                     "type": "string"
                 }
             }
         },
-        "models.Porches": {
+        "models.SessionsResponse": {
             "type": "object",
             "properties": {
-                "areaSquareFeet": {
-                    "type": "integer"
-                },
-                "count": {
-                    "type": "integer"
-                },
-                "typeCode": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Session"
+                    }
                 }
             }
         },
-        "models.Property": {
+        "models.ShareLinkResponse": {
             "type": "object",
             "properties": {
                 "_id": {
                     "type": "string"
                 },
-                "address": {
-                    "$ref": "#/definitions/models.Address"
+                "accessCount": {
+                    "type": "integer"
                 },
-                "avmPropertyId": {
+                "createdAt": {
                     "type": "string"
                 },
-                "building": {
-                    "$ref": "#/definitions/models.Building"
-                },
-                "landUseAndZoning": {
-                    "$ref": "#/definitions/models.LandUseAndZoning"
-                },
-                "lastMarketSale": {
-                    "$ref": "#/definitions/models.LastMarketSale"
+                "createdBy": {
+                    "type": "string"
                 },
-                "location": {
-                    "$ref": "#/definitions/models.Location"
+                "expiresAt": {
+                    "type": "string"
                 },
-                "lot": {
-                    "$ref": "#/definitions/models.Lot"
+                "lastAccessedAt": {
+                    "type": "string"
                 },
-                "ownership": {
-                    "$ref": "#/definitions/models.Ownership"
+                "linkId": {
+                    "type": "string"
                 },
                 "propertyId": {
                     "type": "string"
                 },
-                "taxAssessment": {
-                    "$ref": "#/definitions/models.TaxAssessment"
-                },
-                "utilities": {
-                    "$ref": "#/definitions/models.Utilities"
-                }
-            }
-        },
-        "models.PropertyResponse": {
-            "type": "object",
-            "properties": {
-                "property": {
-                    "$ref": "#/definitions/models.Property"
-                }
-            }
-        },
-        "models.Roof": {
-            "type": "object",
-            "properties": {
-                "coverTypeCode": {
+                "revokedAt": {
                     "type": "string"
                 },
-                "typeCode": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "models.SchoolDistrict": {
+        "models.SharedPropertyView": {
             "type": "object",
             "properties": {
-                "code": {
+                "address": {
+                    "$ref": "#/definitions/models.Address"
+                },
+                "assessedValue": {
+                    "$ref": "#/definitions/models.AssessedValue"
+                },
+                "buildingSummary": {
+                    "$ref": "#/definitions/models.BuildingSummary"
+                },
+                "coordinates": {
+                    "$ref": "#/definitions/models.CoordinatesPoint"
+                },
+                "expiresAt": {
                     "type": "string"
                 },
-                "name": {
+                "landUseAndZoning": {
+                    "$ref": "#/definitions/models.LandUseAndZoning"
+                },
+                "lastSaleAmount": {
+                    "type": "integer"
+                },
+                "lastSaleDate": {
                     "type": "string"
-                }
-            }
-        },
-        "models.Seller": {
-            "type": "object",
-            "properties": {
-                "fullName": {
+                },
+                "lot": {
+                    "$ref": "#/definitions/models.Lot"
+                },
+                "propertyId": {
                     "type": "string"
+                },
+                "taxYear": {
+                    "type": "integer"
+                },
+                "yearBuilt": {
+                    "type": "integer"
                 }
             }
         },
@@ -1080,7 +8405,8 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "sequenceNumber": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
@@ -1091,7 +8417,8 @@ const docTemplate = `{
                     "$ref": "#/definitions/models.AssessedValue"
                 },
                 "countyTaxAmount": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "schoolDistrict": {
                     "$ref": "#/definitions/models.SchoolDistrict"
@@ -1100,10 +8427,12 @@ const docTemplate = `{
                     "$ref": "#/definitions/models.TaxRoll"
                 },
                 "totalTaxAmount": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
                 "year": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
@@ -1129,22 +8458,136 @@ const docTemplate = `{
                 }
             }
         },
-        "models.User": {
+        "models.Transaction": {
             "type": "object",
             "properties": {
-                "_id": {
+                "amount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "bookNumber": {
                     "type": "string"
                 },
-                "email": {
+                "buyers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Buyer"
+                    }
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "full_name": {
+                "date": {
                     "type": "string"
                 },
-                "password": {
+                "documentNumber": {
                     "type": "string"
                 },
-                "phone": {
+                "documentTypeCode": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isMortgagePurchase": {
+                    "type": "boolean"
+                },
+                "isResale": {
+                    "type": "boolean"
+                },
+                "multiOrSplitParcelCode": {
+                    "type": "string"
+                },
+                "pageNumber": {
+                    "type": "string"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "recordingDate": {
+                    "type": "string"
+                },
+                "sellers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Seller"
+                    }
+                },
+                "titleCompany": {
+                    "$ref": "#/definitions/models.TitleCompany"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TransactionHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Transaction"
+                    }
+                },
+                "metadata": {
+                    "$ref": "#/definitions/models.PaginationMeta"
+                }
+            }
+        },
+        "models.UpdateNotificationPreferenceRequest": {
+            "type": "object",
+            "required": [
+                "digestFrequency"
+            ],
+            "properties": {
+                "digestFrequency": {
+                    "type": "string",
+                    "enum": [
+                        "instant",
+                        "hourly",
+                        "daily"
+                    ],
+                    "example": "daily"
+                }
+            }
+        },
+        "models.UsageRecord": {
+            "type": "object",
+            "properties": {
+                "corelogicCalls": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "subjectType": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UsageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.UsageRecord"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
                     "type": "string"
                 }
             }
@@ -1169,10 +8612,83 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Valuation": {
+            "type": "object",
+            "properties": {
+                "confidenceScore": {
+                    "type": "number",
+                    "example": 87
+                },
+                "estimatedValue": {
+                    "type": "number",
+                    "example": 441000
+                },
+                "forecastStandardDeviation": {
+                    "type": "number"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "propertyId": {
+                    "type": "string"
+                },
+                "retrievedAt": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "CORELOGIC_AVM"
+                },
+                "valuationDate": {
+                    "type": "string",
+                    "example": "2026-09-30"
+                },
+                "valueRange": {
+                    "$ref": "#/definitions/models.ValueRange"
+                }
+            }
+        },
+        "models.ValueRange": {
+            "type": "object",
+            "properties": {
+                "high": {
+                    "type": "number",
+                    "example": 472000
+                },
+                "low": {
+                    "type": "number",
+                    "example": 410000
+                }
+            }
+        },
         "models.VerticalProfile": {
             "type": "object",
             "properties": {
                 "storiesCount": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.Walkability": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "pointsOfInterest": {
+                    "$ref": "#/definitions/models.PointsOfInterest"
+                },
+                "radiusMeters": {
+                    "type": "integer"
+                },
+                "retrievedAt": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "walkScore": {
                     "type": "integer"
                 }
             }
@@ -1184,6 +8700,58 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastDeliveryAt": {
+                    "type": "string"
+                },
+                "lastDeliveryError": {
+                    "type": "string"
+                },
+                "lastDeliveryStatus": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WithinRequest": {
+            "type": "object",
+            "properties": {
+                "bbox": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "polygon": {
+                    "$ref": "#/definitions/models.GeoJSONPolygon"
+                }
+            }
         }
     },
     "securityDefinitions": {
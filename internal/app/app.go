@@ -0,0 +1,571 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"homeinsight-properties/internal/auth"
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/handlers"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/internal/usage"
+	"homeinsight-properties/internal/validators"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/census"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/corelogic"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/events"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/hazard"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/mailer"
+	"homeinsight-properties/pkg/metrics"
+	"homeinsight-properties/pkg/neighborhood"
+	"homeinsight-properties/pkg/notifications"
+	"homeinsight-properties/pkg/providers"
+	"homeinsight-properties/pkg/scheduler"
+	"homeinsight-properties/pkg/standardization"
+	"homeinsight-properties/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+type App struct {
+	Config              *config.Config
+	Router              *gin.Engine
+	PropertyHandler     *handlers.PropertyHandler
+	UserHandler         *handlers.UserHandler
+	OwnerHandler        *handlers.OwnerHandler
+	ShareHandler        *handlers.ShareHandler
+	EmbedHandler        *handlers.EmbedHandler
+	NotificationHandler *handlers.NotificationHandler
+	ReindexHandler      *handlers.ReindexHandler
+	SavedSearchHandler  *handlers.SavedSearchHandler
+	DeprecationHandler  *handlers.DeprecationHandler
+	WebhookHandler      *handlers.WebhookHandler
+	ValuationHandler    *handlers.ValuationHandler
+	DemographicsHandler *handlers.DemographicsHandler
+	HistoryHandler      *handlers.PropertyHistoryHandler
+	CacheAdminHandler   *handlers.CacheAdminHandler
+	AuditEventHandler   *handlers.AuditEventHandler
+	JobHandler          *handlers.JobHandler
+	MediaHandler        *handlers.PropertyMediaHandler
+	ListingHandler      *handlers.ListingHandler
+	MarketHandler       *handlers.MarketHandler
+	LocationHandler     *handlers.LocationHandler
+	TransactionHandler  *handlers.TransactionHandler
+	OrganizationHandler *handlers.OrganizationHandler
+	UsageHandler        *handlers.UsageHandler
+	MigrationHandler    *handlers.MigrationHandler
+	FeedHandler         *handlers.FeedHandler
+	DuplicateHandler    *handlers.DuplicateHandler
+	JWKSHandler         *handlers.JWKSHandler
+	HealthHandler       *handlers.HealthHandler
+	Scheduler           *scheduler.Scheduler
+	JobQueue            *jobs.Queue
+	PIICipher           fieldcrypt.Cipher
+	PIIIndex            fieldcrypt.BlindIndex
+	EventPublisher      events.Publisher
+	Server              *http.Server
+	// DefaultOrgID is the organization users and data without one belong to
+	DefaultOrgID        string
+	stopConfigWatch     func()
+}
+
+// create and initialize a new App instance
+func New(cfg *config.Config) *App {
+	app := &App{Config: cfg}
+
+	// Initialize infrastructure
+	app.initializeDatabase()
+	app.initializeCache()
+	app.initializeMetrics()
+	app.initializeEncryption()
+	app.initializeSigningKeys()
+	app.initializeRequestCost()
+	app.initializeUsage()
+
+	// Initialize business logic
+	app.initializeDependencies()
+	app.initializeConfigWatch()
+
+	// Initialize web layer
+	app.initializeRouter()
+
+	return app
+}
+
+// reload TTLs, rate limits and other reloadable settings when the config file changes
+func (a *App) initializeConfigWatch() {
+	stop, err := config.Watch(configFilePath())
+	if err != nil {
+		logger.GlobalLogger.Warnf("Config hot reload disabled: %v", err)
+		return
+	}
+	a.stopConfigWatch = stop
+}
+
+// database connection
+func (a *App) initializeDatabase() {
+	if err := database.InitDB(a.Config); err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize database: %v", err)
+		os.Exit(1)
+	}
+	// A new index on a large collection can outlast the startup timeout; build it ahead of the deploy
+	// with the indexes command or POST /api/admin/indexes
+	if _, err := database.EnsureIndexes(context.Background(), database.DB, database.EnsureIndexOptions{Timeout: 10 * time.Second}); err != nil {
+		logger.GlobalLogger.Errorf("Failed to create database indexes: %v", err)
+		os.Exit(1)
+	}
+	if err := database.ApplySchemaValidator(database.DB, "properties", database.JSONSchema(models.Property{}), a.Config.Database.SchemaValidation); err != nil {
+		logger.GlobalLogger.Errorf("Failed to set properties schema validator: %v", err)
+		os.Exit(1)
+	}
+}
+
+// Redis cache
+func (a *App) initializeCache() {
+	if err := cache.InitRedis(a.Config); err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize Redis: %v", err)
+		os.Exit(1)
+	}
+}
+
+// field-level encryption for owner PII
+func (a *App) initializeEncryption() {
+	pii, err := fieldcrypt.New(fieldcrypt.StaticKeyProvider{
+		ActiveKeyID: a.Config.PIIEncryption.ActiveKeyID,
+		EncodedKeys: a.Config.PIIEncryption.Keys,
+	})
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize PII encryption: %v", err)
+		os.Exit(1)
+	}
+	a.PIICipher = pii
+	index, err := fieldcrypt.NewBlindIndex(a.Config.PIIEncryption.IndexKey)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize PII blind index: %v", err)
+		os.Exit(1)
+	}
+	a.PIIIndex = index
+}
+
+// keys access tokens are signed and verified with
+func (a *App) initializeSigningKeys() {
+	keys, err := auth.LoadKeySet(a.Config.JWT.ActiveKeyID, a.Config.JWT.Keys, a.Config.JWT.Secret)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to load JWT signing keys: %v", err)
+		os.Exit(1)
+	}
+	auth.SetKeys(keys)
+}
+
+// Prometheus metrics
+func (a *App) initializeMetrics() {
+	metrics.Init()
+}
+
+// request cost weights
+func (a *App) initializeRequestCost() {
+	cost.Configure(a.Config.RequestCost.CacheReadUnits, a.Config.RequestCost.MongoQueryUnits, a.Config.RequestCost.CoreLogicCallUnits)
+}
+
+// daily usage quotas
+func (a *App) initializeUsage() {
+	usage.Configure(a.Config)
+}
+
+// set up all dependencies
+func (a *App) initializeDependencies() {
+	// Repositories
+	propertyRepo := repositories.NewPropertyRepository(a.PIICipher)
+	transactor := repositories.NewTransactor(a.Config)
+	cacheTTL := cache.NewAdaptiveTTL(a.Config)
+	config.OnReload(cacheTTL.SetBounds)
+	var staleWindow time.Duration
+	if a.Config.CacheTTL.StaleWhileRevalidate.Enabled {
+		staleWindow = time.Duration(a.Config.CacheTTL.StaleWhileRevalidate.StaleMinutes) * time.Minute
+	}
+	var localCache *cache.LocalCache
+	if a.Config.LocalCache.Enabled {
+		localCache = cache.NewLocalCache(a.Config.LocalCache.MaxEntries, time.Duration(a.Config.LocalCache.TTLSeconds)*time.Second)
+		go cache.SubscribeInvalidations(localCache)
+	}
+	codec, err := cache.NewCodec(a.Config.Redis.Codec)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize cache codec: %v", err)
+		os.Exit(1)
+	}
+	propertyCache := repositories.NewPropertyCache(a.PIICipher, cacheTTL, staleWindow, localCache, codec)
+	userRepo := repositories.NewUserRepository()
+	refreshTokenRepo := repositories.NewRefreshTokenRepository()
+	sessionRepo := repositories.NewSessionRepository()
+	ownerRepo := repositories.NewOwnerEntityRepository(a.PIICipher, a.PIIIndex)
+	shareLinkRepo := repositories.NewShareLinkRepository()
+	notificationPrefRepo := repositories.NewNotificationPreferenceRepository()
+	notificationRepo := repositories.NewNotificationRepository()
+	propertyAlertRepo := repositories.NewPropertyAlertRepository()
+	reindexJobRepo := repositories.NewReindexJobRepository()
+	indexHintRepo := repositories.NewIndexHintRepository()
+	savedSearchRepo := repositories.NewSavedSearchRepository()
+	savedSearchMatchRepo := repositories.NewSavedSearchMatchRepository()
+	webhookRepo := repositories.NewWebhookRepository()
+	valuationRepo := repositories.NewValuationRepository()
+	propertyAuditRepo := repositories.NewPropertyAuditRepository(a.PIICipher)
+	propertyDiffRepo := repositories.NewPropertyDiffRepository(a.PIICipher)
+	auditEventRepo := repositories.NewAuditEventRepository()
+	eventOutboxRepo := repositories.NewEventOutboxRepository(a.PIICipher)
+	propertyMediaRepo := repositories.NewPropertyMediaRepository()
+	listingRepo := repositories.NewListingRepository()
+	marketStatsRepo := repositories.NewMarketStatsRepository()
+	locationRepo := repositories.NewLocationRepository()
+	transactionRepo := repositories.NewTransactionRepository()
+	organizationRepo := repositories.NewOrganizationRepository()
+	membershipRepo := repositories.NewMembershipRepository()
+	usageRepo := repositories.NewUsageRepository()
+	migrationRepo := repositories.NewMigrationRepository()
+	feedRunRepo := repositories.NewFeedRunRepository()
+	duplicateRepo := repositories.NewDuplicateRepository()
+
+	// Transformers
+	addrTrans := transformers.NewAddressTransformer()
+	propTrans := transformers.NewPropertyTransformer()
+	ownerTrans := transformers.NewOwnerTransformer()
+
+	// Validators
+	propertyValidator := validators.NewPropertyValidator()
+	userValidator := validators.NewUserValidator()
+	listingValidator := validators.NewListingValidator()
+
+	// Identity providers for social login
+	idTokenVerifier := auth.NewIDTokenVerifier(map[string][]string{
+		"google": a.Config.OAuth.Google.ClientIDs,
+		"apple":  a.Config.OAuth.Apple.ClientIDs,
+	}, time.Duration(a.Config.OAuth.TimeoutSeconds)*time.Second)
+
+	// CoreLogic client
+	corelogicClient := corelogic.NewClient(
+		a.Config.CoreLogic.ClientKey,
+		a.Config.CoreLogic.ClientSecret,
+		a.Config.CoreLogic.DeveloperEmail,
+		time.Duration(a.Config.CoreLogic.TimeoutSeconds)*time.Second,
+		corelogic.NewCircuitBreaker(a.Config.CoreLogic.CircuitBreaker.FailureThreshold, time.Duration(a.Config.CoreLogic.CircuitBreaker.CooldownSeconds)*time.Second),
+		corelogic.NewDailyQuota(a.Config.CoreLogic.DailyRequestLimit),
+	)
+
+	// External property data providers, in fallback order
+	propertySources, err := providers.New(a.Config, corelogicClient)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize property data providers: %v", err)
+		os.Exit(1)
+	}
+
+	// Address standardization; nil when no provider is configured, and in sandbox mode
+	var standardizer standardization.Standardizer
+	if a.Config.Sandbox.Enabled {
+		logger.GlobalLogger.Warnf("Sandbox mode: property data and valuations are served from fixtures in %s", a.Config.Sandbox.FixturesDir)
+	} else if standardizer, err = standardization.New(a.Config); err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize address standardization: %v", err)
+		os.Exit(1)
+	}
+
+	// Flood and wildfire risk; nil when no provider is configured, and in sandbox mode
+	var hazardProvider hazard.Provider
+	if !a.Config.Sandbox.Enabled {
+		if hazardProvider, err = hazard.New(a.Config); err != nil {
+			logger.GlobalLogger.Errorf("Failed to initialize hazard provider: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// Points of interest and walk scores; nil when no provider is configured, and in sandbox mode
+	var walkabilityProvider neighborhood.Provider
+	if !a.Config.Sandbox.Enabled {
+		if walkabilityProvider, err = neighborhood.New(a.Config); err != nil {
+			logger.GlobalLogger.Errorf("Failed to initialize neighborhood provider: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// Census tract demographics; nil when turned off, and in sandbox mode
+	var censusProvider census.Provider
+	if !a.Config.Sandbox.Enabled {
+		censusProvider = census.New(a.Config)
+	}
+
+	// Object storage for property photos and documents
+	var mediaStorage storage.ObjectStorage
+	if a.Config.Media.Enabled {
+		if mediaStorage, err = storage.New(a.Config); err != nil {
+			logger.GlobalLogger.Errorf("Failed to initialize media storage: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// Storage for uploaded feed files, kept until their run has been processed
+	var feedStorage storage.ObjectStorage = mediaStorage
+	if a.Config.Feeds.Storage != "media" {
+		if feedStorage, err = storage.NewLocalStorage(a.Config.Feeds.SpoolDir); err != nil {
+			logger.GlobalLogger.Errorf("Failed to initialize feed storage: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	// Event broker for property change events
+	if a.Config.Events.Enabled {
+		publisher, err := events.New(a.Config)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to initialize event publisher: %v", err)
+			os.Exit(1)
+		}
+		a.EventPublisher = publisher
+	}
+
+	// Background job queue; services register their job types as they are created
+	a.JobQueue = jobs.New(a.Config, a.PIICipher)
+
+	// Services
+	auditEventService := services.NewAuditEventService(auditEventRepo)
+	organizationService := services.NewOrganizationService(organizationRepo, membershipRepo, userRepo, auditEventService)
+	if err := organizationService.EnsureDefault(context.Background()); err != nil {
+		logger.GlobalLogger.Errorf("Failed to initialize default organization: %v", err)
+		os.Exit(1)
+	}
+	a.DefaultOrgID = organizationService.DefaultOrgID()
+	for i := range a.Config.Embed.Partners {
+		if a.Config.Embed.Partners[i].OrgID == "" {
+			a.Config.Embed.Partners[i].OrgID = organizationService.DefaultOrgID()
+		}
+	}
+	ownerService := services.NewOwnerService(ownerRepo, propertyRepo, ownerTrans, a.PIIIndex)
+	webhookService := services.NewWebhookService(webhookRepo, a.JobQueue, a.Config)
+	auditService := services.NewPropertyAuditService(propertyAuditRepo)
+	diffService := services.NewPropertyDiffService(propertyDiffRepo)
+	searchIndexService := services.NewSearchIndexService(propertyRepo, a.JobQueue, a.PIICipher, a.Config)
+	eventService := services.NewEventService(eventOutboxRepo, a.EventPublisher, searchIndexService, a.Config)
+	standardizationService := services.NewAddressStandardizationService(standardizer, addrTrans)
+	enrichers := []services.PropertyEnricher{
+		services.NewHazardService(hazardProvider),
+		services.NewNeighborhoodService(walkabilityProvider),
+	}
+	propertyService := services.NewPropertyService(propertyRepo, propertyCache, propTrans, addrTrans, propertyValidator, corelogicClient, ownerService, webhookService, auditService, auditEventService, eventService, standardizationService, a.JobQueue, transactor, a.Config)
+	transactionService := services.NewTransactionService(transactionRepo, propertyCache)
+	notificationSender := notifications.NewSender(mailer.New(a.Config))
+	notificationService := services.NewNotificationService(notificationPrefRepo, propertyAlertRepo, notificationRepo, services.NewEmailNotifier(userRepo, notificationSender), notificationSender, a.Config)
+	ownershipService := services.NewOwnershipChangeService(savedSearchMatchRepo, notificationService, webhookService, eventService, ownerTrans)
+	searchService := services.NewPropertySearchService(propertyRepo, propertyCache, addrTrans, propTrans, propertyValidator, propertySources, enrichers, ownerService, webhookService, auditService, eventService, searchIndexService, standardizationService, transactionService, ownershipService, diffService, a.JobQueue, a.Config)
+	userService := services.NewUserService(userRepo, refreshTokenRepo, sessionRepo, idTokenVerifier, userValidator, notificationService, organizationService, auditEventService)
+	shareService := services.NewShareService(shareLinkRepo, propertyService, a.Config)
+	embedService := services.NewEmbedService(propertyService)
+	reindexService := services.NewReindexService(reindexJobRepo, indexHintRepo, propertyRepo, a.JobQueue)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, savedSearchMatchRepo, propertyRepo, notificationService, a.Config)
+	deprecationService := services.NewDeprecationService()
+	valuationService := services.NewValuationService(valuationRepo, propertyCache, propertyService, providers.NewValuationProvider(a.Config, corelogicClient), a.Config)
+	demographicsService := services.NewDemographicsService(censusProvider, propertyService)
+	cacheAdminService := services.NewCacheAdminService(propertyCache, auditEventService)
+	jobService := services.NewJobService(a.JobQueue)
+	var mediaService *services.PropertyMediaService
+	if mediaStorage != nil {
+		mediaService = services.NewPropertyMediaService(propertyMediaRepo, propertyRepo, propertyCache, mediaStorage, a.Config)
+	}
+	listingService := services.NewListingService(listingRepo, propertyCache, propertyRepo, propertyService, listingValidator, transactor)
+	includeService := services.NewPropertyIncludeService(listingService, mediaService, transactionService, valuationService)
+	marketStatsService := services.NewMarketStatsService(marketStatsRepo, a.Config)
+	locationService := services.NewLocationService(locationRepo, a.Config)
+	usageService := services.NewUsageService(usageRepo)
+	migrationService := services.NewMigrationService(migrationRepo, a.JobQueue, a.Config)
+	migrationService.Add(services.UppercaseAddressesMigration(propertyRepo, addrTrans))
+	migrationService.Add(services.ReindexOwnersMigration(propertyRepo, ownerService))
+	feedService := services.NewFeedService(feedRunRepo, propertyService, feedStorage, a.JobQueue, a.Config, transformers.NewMLSFeedTransformer(), transformers.NewAssessorFeedTransformer())
+	duplicateService := services.NewDuplicateService(duplicateRepo, propertyRepo, propertyService, auditService, a.JobQueue, transactor)
+	healthService := services.NewHealthService(corelogicClient, a.JobQueue, a.Config)
+
+	// Backfill derived indexes for properties stored before they existed, on one instance at a time
+	go runExclusive("backfill:owner-index", ownerService.RebuildIndexIfEmpty)
+	go runExclusive("backfill:search-index", searchIndexService.Prepare)
+	go runExclusive("backfill:geo-points", searchService.BackfillGeoPoints)
+
+	// Preload the cache so a cold start doesn't hit MongoDB for every read
+	if a.Config.CacheWarmup.Enabled {
+		if err := propertyService.EnqueueCacheWarmup(context.Background()); err != nil {
+			logger.GlobalLogger.Warnf("Failed to queue cache warm-up: %v", err)
+		}
+	}
+
+	// Invalidate cached properties on any write to the collection, including out-of-band ones
+	if a.Config.ChangeStream.Enabled {
+		go services.NewCacheInvalidationWatcher(propertyRepo, propertyCache, a.Config).Run(context.Background())
+	}
+
+	// Load query hints set by earlier reindex jobs
+	if err := reindexService.RefreshHints(context.Background()); err != nil {
+		logger.GlobalLogger.Warnf("Failed to load index hints: %v", err)
+	}
+
+	// Background jobs, each run by a single instance
+	a.Scheduler = scheduler.New()
+	a.Scheduler.SetRunGuard(runScheduledOnce)
+	a.Scheduler.Every("hourly-notification-digest", time.Hour, func(ctx context.Context) error {
+		return notificationService.RunDigest(ctx, models.DigestHourly)
+	})
+	a.Scheduler.DailyAt("daily-notification-digest", a.Config.Notifications.DailyDigestHourUTC, func(ctx context.Context) error {
+		return notificationService.RunDigest(ctx, models.DigestDaily)
+	})
+	a.Scheduler.Every("saved-search-run", time.Duration(a.Config.SavedSearches.RunIntervalMinutes)*time.Minute, savedSearchService.RunSavedSearches)
+	a.Scheduler.Every("index-hint-refresh", services.HintRefreshInterval, reindexService.RefreshHints)
+	a.Scheduler.DailyAt("market-stats-refresh", a.Config.Markets.StatsRefreshHourUTC, marketStatsService.RefreshAll)
+	a.Scheduler.Every("usage-rollup", time.Duration(a.Config.Usage.RollupIntervalMinutes)*time.Minute, usageService.RollUp)
+	if a.Config.CacheTTL.Adaptive {
+		a.Scheduler.Every("cache-ttl-tuning", time.Duration(a.Config.CacheTTL.TuneIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+			cacheTTL.Tune()
+			return nil
+		})
+	}
+	if a.EventPublisher != nil {
+		a.Scheduler.Every("event-outbox-relay", time.Duration(a.Config.Events.RelayIntervalSeconds)*time.Second, eventService.Relay)
+	}
+	if a.PIICipher.Enabled() {
+		a.Scheduler.DailyAt("pii-key-rotation", 3, func(ctx context.Context) error {
+			rotated, err := propertyRepo.RotatePIIEncryption(ctx)
+			if err != nil {
+				return err
+			}
+			logger.GlobalLogger.Printf("PII encryption rotated: properties=%d", rotated)
+			return nil
+		})
+	}
+	if a.Config.Reconciliation.Enabled {
+		reconciliationService := services.NewReconciliationService(propertyRepo, propertyCache, searchService, marketStatsService, a.Config)
+		if err := a.Scheduler.Cron("reconciliation", a.Config.Reconciliation.Schedule, reconciliationService.Run); err != nil {
+			logger.GlobalLogger.Errorf("Invalid reconciliation schedule: %v", err)
+			os.Exit(1)
+		}
+	}
+	a.Scheduler.Start()
+	a.JobQueue.Start()
+
+	// Handlers
+	a.PropertyHandler = handlers.NewPropertyHandler(propertyService, searchService, listingService, includeService)
+	a.UserHandler = handlers.NewUserHandler(userService)
+	a.OwnerHandler = handlers.NewOwnerHandler(ownerService)
+	a.ShareHandler = handlers.NewShareHandler(shareService)
+	a.EmbedHandler = handlers.NewEmbedHandler(embedService, a.Config.Embed.CacheMaxAgeSeconds)
+	a.NotificationHandler = handlers.NewNotificationHandler(notificationService)
+	a.ReindexHandler = handlers.NewReindexHandler(reindexService)
+	a.SavedSearchHandler = handlers.NewSavedSearchHandler(savedSearchService)
+	a.DeprecationHandler = handlers.NewDeprecationHandler(deprecationService)
+	a.WebhookHandler = handlers.NewWebhookHandler(webhookService)
+	a.ValuationHandler = handlers.NewValuationHandler(valuationService)
+	a.DemographicsHandler = handlers.NewDemographicsHandler(demographicsService)
+	a.HistoryHandler = handlers.NewPropertyHistoryHandler(auditService, diffService)
+	a.CacheAdminHandler = handlers.NewCacheAdminHandler(cacheAdminService)
+	a.AuditEventHandler = handlers.NewAuditEventHandler(auditEventService)
+	a.JobHandler = handlers.NewJobHandler(jobService)
+	a.MediaHandler = handlers.NewPropertyMediaHandler(mediaService, a.Config.Media.MaxUploadMB)
+	a.ListingHandler = handlers.NewListingHandler(listingService)
+	a.MarketHandler = handlers.NewMarketHandler(marketStatsService)
+	a.LocationHandler = handlers.NewLocationHandler(locationService)
+	a.TransactionHandler = handlers.NewTransactionHandler(transactionService)
+	a.OrganizationHandler = handlers.NewOrganizationHandler(organizationService)
+	a.UsageHandler = handlers.NewUsageHandler(usageService)
+	a.MigrationHandler = handlers.NewMigrationHandler(migrationService)
+	a.FeedHandler = handlers.NewFeedHandler(feedService)
+	a.DuplicateHandler = handlers.NewDuplicateHandler(duplicateService)
+	a.HealthHandler = handlers.NewHealthHandler(healthService)
+	a.JWKSHandler = handlers.NewJWKSHandler(auth.Keys())
+}
+
+// Gin router with middleware and routes
+// func (a *App) initializeRouter() {
+// 	a.Router = gin.New()
+// 	a.setupMiddleware()
+// 	a.setupRoutes()
+// }
+func (a *App) initializeRouter() {
+	a.Router = gin.New()
+	// Handlers pass their gin context down as the context of database, cache and CoreLogic calls;
+	// with the fallback it reports the request's cancellation and deadline, so work for a client
+	// that went away, or whose budget ran out, stops
+	a.Router.ContextWithFallback = true
+	// c.ClientIP() keys rate limits, login lockouts and audit records, so X-Forwarded-For and
+	// X-Real-IP are only believed from the configured proxies; with none, the peer address is used
+	if err := a.Router.SetTrustedProxies(a.Config.Server.TrustedProxies); err != nil {
+		logger.GlobalLogger.Errorf("Failed to set trusted proxies: %v", err)
+		os.Exit(1)
+	}
+	a.setupMiddleware()
+	a.setupRoutes()
+
+	// Add this to handle "/"
+	a.Router.GET("/", func(c *gin.Context) {
+		c.JSON(200, gin.H{"message": "Welcome to HomeInsight API"})
+	})
+}
+
+// schedulerLockTTL is how long an instance that stopped mid-run keeps others from running the job.
+const schedulerLockTTL = time.Minute
+
+// runScheduledOnce runs a scheduled job on whichever instance claims the slot first. A run that
+// overruns into the next slot holds that one back too, rather than run alongside it.
+func runScheduledOnce(ctx context.Context, job string, slot, next time.Time, run scheduler.JobFunc) error {
+	claim := next.Sub(slot)
+	if claim < time.Minute {
+		claim = time.Minute
+	}
+	claimed, err := cache.Claim(ctx, fmt.Sprintf("scheduler:%s:%d", job, slot.Unix()), claim)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return scheduler.ErrSkipped
+	}
+	err = cache.WithLock(ctx, "scheduler:"+job, schedulerLockTTL, func(ctx context.Context, _ *cache.Lock) error {
+		return run(ctx)
+	})
+	if err == cache.ErrLockHeld {
+		logger.GlobalLogger.Warnf("Scheduled job still running from an earlier slot, skipping: job=%s", job)
+		return scheduler.ErrSkipped
+	}
+	return err
+}
+
+// runExclusive runs a startup task under the named lock, skipping it while another instance runs it.
+func runExclusive(name string, task func(ctx context.Context)) {
+	err := cache.WithLock(context.Background(), name, schedulerLockTTL, func(ctx context.Context, _ *cache.Lock) error {
+		task(ctx)
+		return nil
+	})
+	if err == cache.ErrLockHeld {
+		logger.GlobalLogger.Printf("Startup task running on another instance, skipping: task=%s", name)
+	} else if err != nil {
+		logger.GlobalLogger.Warnf("Startup task not run: task=%s, error=%v", name, err)
+	}
+}
+
+// cleanup operations
+func (a *App) Close() {
+	if a.stopConfigWatch != nil {
+		a.stopConfigWatch()
+	}
+	if a.Scheduler != nil {
+		a.Scheduler.Stop()
+	}
+	if a.JobQueue != nil {
+		a.JobQueue.Stop()
+	}
+	if a.EventPublisher != nil {
+		if err := a.EventPublisher.Close(); err != nil {
+			logger.GlobalLogger.Warnf("Failed to close event publisher: %v", err)
+		}
+	}
+	database.CloseDB()
+	cache.CloseRedis()
+}
@@ -1,4 +1,4 @@
-package main
+package app
 
 import (
 	"os"
@@ -23,14 +23,17 @@ func loadEnvironment() {
 	}
 }
 
-// load the application configuration from a YAML file
-func loadConfigFile() *config.Config {
-	configPath := os.Getenv("CONFIG_PATH")
-	if configPath == "" {
-		configPath = "configs/config.yaml"
+// path of the YAML configuration file
+func configFilePath() string {
+	if configPath := os.Getenv("CONFIG_PATH"); configPath != "" {
+		return configPath
 	}
+	return "configs/config.yaml"
+}
 
-	cfg, err := config.LoadConfig(configPath)
+// load the application configuration from a YAML file and make it the current one
+func loadConfigFile() *config.Config {
+	cfg, err := config.Load(configFilePath())
 	if err != nil {
 		logger.GlobalLogger.Errorf("Failed to load config: %v", err)
 		os.Exit(1)
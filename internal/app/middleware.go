@@ -0,0 +1,96 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/middleware"
+	"homeinsight-properties/internal/openapi"
+	"homeinsight-properties/internal/validators"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/requestid"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// configure all middleware for the router
+func (a *App) setupMiddleware() {
+	// Report binding errors by JSON field name
+	validators.UseJSONFieldNames()
+
+	// CORS middleware
+	if len(a.Config.CORS.AllowedOrigins) > 0 {
+		a.Router.Use(setupCORS(a.Config))
+	}
+
+	// Other middleware
+	a.Router.Use(middleware.RequestIDMiddleware())
+	a.Router.Use(middleware.MetricsMiddleware())
+	a.Router.Use(middleware.LoggingMiddleware())
+	a.Router.Use(middleware.RequestCostMiddleware())
+	a.Router.Use(middleware.RequestDeadlineMiddleware(time.Duration(a.Config.Server.RequestBudgetMS)*time.Millisecond, "/api/properties/stream"))
+	a.Router.Use(middleware.SecureHeaders())
+	a.Router.Use(middleware.BaseURLMiddleware(a.Config))
+	a.Router.Use(middleware.DeprecationMiddleware())
+	if a.Config.Server.Compression.Enabled {
+		a.Router.Use(middleware.CompressionMiddleware(middleware.CompressionOptions{
+			Level:        a.Config.Server.Compression.Level,
+			BrotliLevel:  a.Config.Server.Compression.BrotliLevel,
+			MinSizeBytes: a.Config.Server.Compression.MinSizeBytes,
+			ContentTypes: a.Config.Server.Compression.ContentTypes,
+		}))
+	}
+	a.Router.Use(middleware.ErrorHandler())
+	a.Router.Use(middleware.Recovery())
+	a.Router.Use(middleware.BodyLimitMiddleware(int64(a.Config.Server.MaxBodyKB)<<10, map[string]int64{
+		"/api/properties/import": int64(a.Config.Server.MaxImportBodyMB) << 20,
+		// Uploads are limited by media.max_upload_mb in their handler
+		"/api/properties/:id/photos":    0,
+		"/api/properties/:id/documents": 0,
+		"/api/feeds/:provider":          int64(a.Config.Feeds.MaxUploadMB) << 20,
+	}))
+	a.Router.Use(middleware.HTTPCacheMiddleware(a.Config))
+	a.Router.NoRoute(middleware.RouteNotFound)
+
+	if a.Config.OpenAPIValidation.Enabled {
+		validator, err := openapi.Load()
+		if err != nil {
+			logger.GlobalLogger.Errorf("OpenAPI validation disabled: %v", err)
+			return
+		}
+		a.Router.Use(middleware.OpenAPIValidationMiddleware(validator, a.Config.OpenAPIValidation.Responses))
+	}
+}
+
+// configure CORS middleware for the origins, methods and headers in config; the headers the API
+// itself reads are always allowed
+func setupCORS(cfg *config.Config) gin.HandlerFunc {
+    corsConfig := cors.DefaultConfig()
+    if slices.Contains(cfg.CORS.AllowedOrigins, "*") {
+        corsConfig.AllowAllOrigins = true
+    } else {
+        corsConfig.AllowOrigins = cfg.CORS.AllowedOrigins
+        corsConfig.AllowWildcard = true
+    }
+
+    corsConfig.AllowMethods = cfg.CORS.AllowedMethods
+    corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "Accept", "X-Requested-With", "If-None-Match", requestid.Header, middleware.IdempotencyKeyHeader}
+    corsConfig.AddAllowHeaders(cfg.CORS.AllowedHeaders...)
+    corsConfig.AllowCredentials = cfg.CORS.AllowCredentials
+    corsConfig.ExposeHeaders = []string{"Content-Length", middleware.RequestCostHeader, requestid.Header, "Deprecation", "Sunset", "Link", middleware.IdempotentReplayedHeader,
+        middleware.RateLimitLimitHeader, middleware.RateLimitRemainingHeader, middleware.RateLimitResetHeader, middleware.RetryAfterHeader, "ETag"}
+    corsConfig.MaxAge = time.Duration(cfg.CORS.MaxAgeSeconds) * time.Second
+
+    handler := cors.New(corsConfig)
+    return func(c *gin.Context) {
+        // Embed routes check partner origins themselves
+        if strings.HasPrefix(c.Request.URL.Path, "/embed/") {
+            c.Next()
+            return
+        }
+        handler(c)
+    }
+}
@@ -0,0 +1,258 @@
+package app
+
+import (
+	"net/http"
+	"os"
+	"time"
+
+	"homeinsight-properties/internal/middleware"
+	"homeinsight-properties/internal/models"
+
+	_ "homeinsight-properties/docs"
+	_ "net/http/pprof"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// configure all API routes
+func (a *App) setupRoutes() {
+	a.setupStaticRoutes()
+	a.setupHealthCheck()
+	a.setupKeyRoutes()
+	a.setupAPIRoutes()
+	a.setupEmbedRoutes()
+}
+
+// static routes and documentation endpoints
+func (a *App) setupStaticRoutes() {
+	// Serve Redoc UI
+	a.Router.Static("/redoc", "./static/redoc")
+	a.Router.StaticFile("/favicon.ico", "./static/redoc/favicon.ico")
+	a.Router.GET("/redoc", func(c *gin.Context) {
+		c.Redirect(http.StatusMovedPermanently, "/redoc/index.html")
+	})
+
+	// Serve Swagger UI
+	a.Router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Serve swagger.json
+	a.Router.StaticFile("/swagger.json", "./docs/swagger.json")
+
+	// Expose pprof profiling endpoints (disable in production)
+	if os.Getenv("ENV") != "production" {
+		a.Router.GET("/debug/pprof/*any", gin.WrapH(http.DefaultServeMux))
+	}
+
+	// Expose Prometheus metrics endpoint
+	a.Router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
+
+// health check endpoints: /healthz for liveness probes, /readyz for readiness probes and status
+// pages; /health is kept for existing monitors and reports readiness
+func (a *App) setupHealthCheck() {
+	a.Router.GET("/healthz", a.HealthHandler.Liveness)
+	a.Router.GET("/readyz", a.HealthHandler.Readiness)
+	a.Router.GET("/health", a.HealthHandler.Readiness)
+}
+
+// public keys for verifying access tokens
+func (a *App) setupKeyRoutes() {
+	a.Router.GET("/.well-known/jwks.json", a.JWKSHandler.Keys)
+}
+
+// API routes for user and property operations
+func (a *App) setupAPIRoutes() {
+    api := a.Router.Group("/api")
+    api.Use(middleware.RequestSigningMiddleware(a.Config))
+    {
+        // Authentication routes
+        auth := api.Group("/auth")
+        auth.Use(middleware.RateLimitMiddleware(a.Config, "auth"))
+        {
+            auth.POST("/register", a.UserHandler.Register)
+            auth.POST("/login", a.UserHandler.Login)
+            auth.POST("/oauth/:provider", a.UserHandler.OAuthLogin)
+        }
+
+        token := api.Group("/token")
+        token.Use(middleware.RateLimitMiddleware(a.Config, "token"))
+        {
+            token.POST("/refresh", a.UserHandler.Refresh)
+        }
+
+        logout := api.Group("/logout")
+        logout.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "logout"))
+        {
+            logout.POST("", a.UserHandler.Logout)
+        }
+
+        password := api.Group("/password")
+        password.Use(middleware.RateLimitMiddleware(a.Config, "password"))
+        {
+            password.POST("/forgot", a.UserHandler.ForgotPassword)
+            password.POST("/reset", a.UserHandler.ResetPassword)
+        }
+
+        // Protected routes
+        protected := api.Group("/properties")
+        protected.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "properties"), middleware.UsageMiddleware())
+        {
+            protected.GET("", a.PropertyHandler.GetProperties)
+            protected.GET("/stream", a.PropertyHandler.StreamProperties)
+            protected.GET("/property-search", a.PropertyHandler.SearchProperty)
+            protected.GET("/search", a.PropertyHandler.FullTextSearch)
+            protected.GET("/nearby", a.PropertyHandler.FindNearby)
+            protected.POST("/within", a.PropertyHandler.FindWithin)
+            protected.GET("/by-owner", middleware.RequireAnyRole(a.Config.OwnerSearch.Roles...), a.OwnerHandler.SearchByOwnerName)
+            protected.GET("/property-detail/:id", a.PropertyHandler.GetPropertyByID)
+            protected.POST("", middleware.IdempotencyMiddleware(time.Duration(a.Config.Idempotency.TTLHours)*time.Hour), a.PropertyHandler.CreateProperty)
+            protected.POST("/import", middleware.RequireRole(models.RoleAdmin), middleware.IdempotencyMiddleware(time.Duration(a.Config.Idempotency.TTLHours)*time.Hour), a.PropertyHandler.ImportProperties)
+            protected.POST("/bulk-delete", middleware.RequireRole(models.RoleAdmin), middleware.IdempotencyMiddleware(time.Duration(a.Config.Idempotency.TTLHours)*time.Hour), a.PropertyHandler.BulkDelete)
+            protected.POST("/bulk-update", middleware.RequireRole(models.RoleAdmin), middleware.IdempotencyMiddleware(time.Duration(a.Config.Idempotency.TTLHours)*time.Hour), a.PropertyHandler.BulkUpdate)
+            protected.PUT("/property-detail/:id", a.PropertyHandler.UpdateProperty)
+            protected.PATCH("/:id", a.PropertyHandler.PatchProperty)
+            protected.DELETE("/property-detail/:id", a.PropertyHandler.DeleteProperty)
+            protected.GET("/:id/related", a.OwnerHandler.GetRelatedProperties)
+            protected.GET("/:id/valuation", a.ValuationHandler.GetValuation)
+            if a.Config.Census.Enabled {
+                protected.GET("/:id/demographics", a.DemographicsHandler.GetDemographics)
+            }
+            protected.GET("/:id/history", a.HistoryHandler.GetHistory)
+            protected.GET("/:id/changes", a.HistoryHandler.GetChanges)
+            protected.GET("/:id/tax-history", a.PropertyHandler.GetTaxHistory)
+            protected.GET("/:id/transactions", a.TransactionHandler.GetTransactions)
+            protected.POST("/:id/restore", a.PropertyHandler.RestoreProperty)
+            protected.POST("/:id/share", a.ShareHandler.CreateShareLink)
+            protected.GET("/:id/share", a.ShareHandler.ListShareLinks)
+            protected.DELETE("/:id/share/:linkId", a.ShareHandler.RevokeShareLink)
+            protected.POST("/:id/listings", a.ListingHandler.CreateListing)
+            protected.GET("/:id/listings", a.ListingHandler.ListListings)
+            protected.GET("/:id/listings/:listingId", a.ListingHandler.GetListing)
+            protected.PUT("/:id/listings/:listingId", a.ListingHandler.UpdateListing)
+            protected.DELETE("/:id/listings/:listingId", a.ListingHandler.DeleteListing)
+            if a.Config.Media.Enabled {
+                protected.POST("/:id/photos", a.MediaHandler.UploadPhoto)
+                protected.POST("/:id/documents", a.MediaHandler.UploadDocument)
+                protected.DELETE("/:id/media/:mediaId", a.MediaHandler.DeleteMedia)
+            }
+        }
+
+        // Public share link routes (authorized by the signed token)
+        shared := api.Group("/shared")
+        shared.Use(middleware.RateLimitMiddleware(a.Config, "shared"))
+        {
+            shared.GET("/properties/:token", a.ShareHandler.GetSharedProperty)
+        }
+
+        owners := api.Group("/owners")
+        owners.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "owners"), middleware.UsageMiddleware())
+        {
+            owners.GET("/:entityId/portfolio", a.OwnerHandler.GetPortfolio)
+        }
+
+        markets := api.Group("/markets")
+        markets.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "markets"), middleware.UsageMiddleware())
+        {
+            markets.GET("/:zipCode/stats", a.MarketHandler.GetZipStats)
+        }
+
+        locations := api.Group("/locations")
+        locations.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "locations"), middleware.UsageMiddleware())
+        {
+            locations.GET("/states", a.LocationHandler.ListStates)
+            locations.GET("/:state/cities", a.LocationHandler.ListCities)
+            locations.GET("/:state/:city/zips", a.LocationHandler.ListZips)
+        }
+
+        users := api.Group("/users")
+        users.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "users"), middleware.UsageMiddleware())
+        {
+            users.GET("/me/notification-preferences", a.NotificationHandler.GetPreferences)
+            users.PUT("/me/notification-preferences", a.NotificationHandler.UpdatePreferences)
+            users.GET("/me/notifications", a.NotificationHandler.ListNotifications)
+            users.POST("/me/notifications/read-all", a.NotificationHandler.MarkAllRead)
+            users.POST("/me/notifications/:notificationId/read", a.NotificationHandler.MarkRead)
+            users.GET("/me/sessions", a.UserHandler.ListSessions)
+            users.DELETE("/me/sessions/:sessionId", a.UserHandler.RevokeSession)
+        }
+
+        savedSearches := api.Group("/saved-searches")
+        savedSearches.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "saved_searches"), middleware.UsageMiddleware())
+        {
+            savedSearches.POST("", a.SavedSearchHandler.CreateSavedSearch)
+            savedSearches.GET("", a.SavedSearchHandler.ListSavedSearches)
+            savedSearches.DELETE("/:id", a.SavedSearchHandler.DeleteSavedSearch)
+            savedSearches.GET("/:id/matches", a.SavedSearchHandler.ListMatches)
+        }
+
+        admin := api.Group("/admin")
+        admin.Use(middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin), middleware.RateLimitMiddleware(a.Config, "admin"), middleware.UsageMiddleware())
+        {
+            admin.POST("/reindex", a.ReindexHandler.StartReindex)
+            admin.GET("/reindex", a.ReindexHandler.ListJobs)
+            admin.GET("/reindex/:jobId", a.ReindexHandler.GetJob)
+            admin.GET("/explain/:query", a.ReindexHandler.ExplainQuery)
+            admin.POST("/indexes", a.ReindexHandler.EnsureIndexes)
+            admin.GET("/deprecations", a.DeprecationHandler.ListDeprecations)
+            admin.GET("/trash", a.PropertyHandler.ListTrash)
+            admin.DELETE("/trash/:id", a.PropertyHandler.PurgeProperty)
+            admin.GET("/data-quality", a.PropertyHandler.GetDataQuality)
+            admin.DELETE("/cache/properties/:id", a.CacheAdminHandler.InvalidateProperty)
+            admin.DELETE("/cache/search", a.CacheAdminHandler.ClearSearches)
+            admin.POST("/cache/flush", a.CacheAdminHandler.Flush)
+            admin.GET("/audit-events", a.AuditEventHandler.ListEvents)
+            admin.GET("/usage", a.UsageHandler.GetUsage)
+            admin.GET("/migrations", a.MigrationHandler.ListMigrations)
+            admin.POST("/migrations/:name", a.MigrationHandler.StartMigration)
+            admin.GET("/migrations/:name", a.MigrationHandler.GetMigration)
+            admin.GET("/duplicates", a.DuplicateHandler.ListDuplicates)
+            admin.POST("/duplicates/scan", a.DuplicateHandler.ScanDuplicates)
+            admin.POST("/properties/merge", a.DuplicateHandler.MergeProperties)
+        }
+
+        feeds := api.Group("/feeds")
+        feeds.Use(middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin), middleware.RateLimitMiddleware(a.Config, "admin"), middleware.UsageMiddleware())
+        {
+            feeds.POST("/:provider", a.FeedHandler.UploadFeed)
+            feeds.GET("/runs", a.FeedHandler.ListRuns)
+            feeds.GET("/runs/:id", a.FeedHandler.GetRun)
+        }
+
+        organizations := api.Group("/organizations")
+        organizations.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "organizations"), middleware.UsageMiddleware())
+        {
+            organizations.POST("", middleware.RequireRole(models.RoleAdmin), a.OrganizationHandler.CreateOrganization)
+            organizations.GET("", middleware.RequireRole(models.RoleAdmin), a.OrganizationHandler.ListOrganizations)
+            organizations.GET("/current", a.OrganizationHandler.GetCurrentOrganization)
+            organizations.GET("/:id/members", a.OrganizationHandler.ListMembers)
+            organizations.POST("/:id/members", a.OrganizationHandler.AddMember)
+            organizations.DELETE("/:id/members/:userId", a.OrganizationHandler.RemoveMember)
+        }
+
+        jobs := api.Group("/jobs")
+        jobs.Use(middleware.AuthMiddleware(), middleware.RateLimitMiddleware(a.Config, "jobs"), middleware.UsageMiddleware())
+        {
+            jobs.GET("/:id", a.JobHandler.GetJob)
+        }
+
+        webhooks := api.Group("/webhooks")
+        webhooks.Use(middleware.AuthMiddleware(), middleware.RequireRole(models.RoleAdmin), middleware.RateLimitMiddleware(a.Config, "webhooks"), middleware.UsageMiddleware())
+        {
+            webhooks.POST("", a.WebhookHandler.CreateWebhook)
+            webhooks.GET("", a.WebhookHandler.ListWebhooks)
+            webhooks.DELETE("/:id", a.WebhookHandler.DeleteWebhook)
+        }
+    }
+}
+
+// public widget routes for partner sites, authorized by partner API key
+func (a *App) setupEmbedRoutes() {
+	embed := a.Router.Group("/embed")
+	embed.Use(middleware.EmbedMiddleware(a.Config.Embed.Partners), middleware.UsageMiddleware())
+	{
+		embed.GET("/properties/:id", a.EmbedHandler.GetPropertyEmbed)
+	}
+}
@@ -1,55 +1,74 @@
 package auth
 
 import (
+    "crypto/rand"
+    "encoding/hex"
     "fmt"
     "time"
 
     "github.com/golang-jwt/jwt/v5"
 )
 
+// AccessTokenTTL is how long an access token is valid for.
+const AccessTokenTTL = 24 * time.Hour
+
 type Claims struct {
-    UserID   string `json:"user_id"`
-    FullName string `json:"full_name"`
-    Email    string `json:"email"`
-    Phone    string `json:"phone"`
+    UserID    string `json:"user_id"`
+    FullName  string `json:"full_name"`
+    Email     string `json:"email"`
+    Phone     string `json:"phone"`
+    Role      string `json:"role,omitempty"`
+    OrgID     string `json:"org_id"`
+    SessionID string `json:"sid,omitempty"` // the signed-in device, whose tokens are revoked together
     jwt.RegisteredClaims
 }
 
 type TokenDetails struct {
-    Token     string `json:"token"`
-    ExpiresIn string `json:"expires_in"`
-    TokenType string `json:"token_type"`
+    Token            string `json:"token"`
+    ExpiresIn        string `json:"expires_in"`
+    TokenType        string `json:"token_type"`
+    RefreshToken     string `json:"refresh_token,omitempty"`
+    RefreshExpiresIn string `json:"refresh_expires_in,omitempty"`
 }
 
-func GenerateJWT(userID, fullName, email, phone, secret string) (*TokenDetails, error) {
-    if secret == "" {
-        return nil, fmt.Errorf("secret key cannot be empty")
+func GenerateJWT(userID, fullName, email, phone, role, orgID, sessionID string, keys *KeySet) (*TokenDetails, error) {
+    if keys == nil {
+        return nil, fmt.Errorf("signing keys not configured")
     }
     if userID == "" {
         return nil, fmt.Errorf("user ID cannot be empty")
     }
 
-    expirationTime := time.Now().Add(24 * time.Hour)
+    // A unique jti lets a single token be revoked on logout
+    jti := make([]byte, 16)
+    if _, err := rand.Read(jti); err != nil {
+        return nil, fmt.Errorf("failed to generate token id: %v", err)
+    }
+
+    expirationTime := time.Now().Add(AccessTokenTTL)
     claims := &Claims{
-        UserID:   userID,
-        FullName: fullName,
-        Email:    email,
-        Phone:    phone,
+        UserID:    userID,
+        FullName:  fullName,
+        Email:     email,
+        Phone:     phone,
+        Role:      role,
+        OrgID:     orgID,
+        SessionID: sessionID,
         RegisteredClaims: jwt.RegisteredClaims{
+            ID:        hex.EncodeToString(jti),
             ExpiresAt: jwt.NewNumericDate(expirationTime),
             IssuedAt:  jwt.NewNumericDate(time.Now()),
             NotBefore: jwt.NewNumericDate(time.Now()),
         },
     }
 
-    token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-    tokenString, err := token.SignedString([]byte(secret))
+    tokenString, err := keys.sign(claims)
     if err != nil {
         return nil, fmt.Errorf("failed to sign token: %v", err)
     }
 
     // Calculate expires_in in seconds
-    expiresIn := int64(24 * time.Hour / time.Second) // 86400 seconds
+    expiresIn := int64(AccessTokenTTL / time.Second) // 86400 seconds
     return &TokenDetails{
         Token:     tokenString,
         ExpiresIn: fmt.Sprintf("%d", expiresIn),
@@ -57,21 +76,16 @@ func GenerateJWT(userID, fullName, email, phone, secret string) (*TokenDetails,
     }, nil
 }
 
-func ValidateJWT(tokenString, secret string) (*Claims, error) {
-    if secret == "" {
-        return nil, fmt.Errorf("secret key cannot be empty")
+func ValidateJWT(tokenString string, keys *KeySet) (*Claims, error) {
+    if keys == nil {
+        return nil, fmt.Errorf("signing keys not configured")
     }
     if tokenString == "" {
         return nil, fmt.Errorf("token string cannot be empty")
     }
 
     claims := &Claims{}
-    token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-        if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-            return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-        }
-        return []byte(secret), nil
-    })
+    token, err := jwt.ParseWithClaims(tokenString, claims, keys.keyFunc)
     if err != nil {
         return nil, fmt.Errorf("failed to parse token: %v", err)
     }
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for signing or verifying tokens.
+const minRSAKeyBits = 2048
+
+// KeySet holds the keys access tokens are signed and verified with. The active key signs new tokens
+// and every key verifies, selected by the token's kid header, so a key can be rotated out without
+// ending sessions. RSA keys sign with RS256 and Ed25519 keys with EdDSA; a key given only as a public
+// key verifies but can't be made active. Tokens without a kid were signed with the HS256 shared
+// secret, which still verifies them until they expire when set.
+type KeySet struct {
+	activeKeyID string
+	keys        map[string]verificationKey
+	secret      []byte
+}
+
+type verificationKey struct {
+	method  jwt.SigningMethod
+	public  crypto.PublicKey
+	private crypto.Signer // nil for keys that only verify
+}
+
+var defaultKeys atomic.Pointer[KeySet]
+
+// SetKeys makes keys the set AuthMiddleware verifies tokens with and Keys returns.
+func SetKeys(keys *KeySet) {
+	defaultKeys.Store(keys)
+}
+
+// Keys returns the set registered with SetKeys, or nil before it is called.
+func Keys() *KeySet {
+	return defaultKeys.Load()
+}
+
+// LoadKeySet reads PEM-encoded keys from the files given by key id. With no key files, tokens are
+// signed with the HS256 secret instead.
+func LoadKeySet(activeKeyID string, keyFiles map[string]string, secret string) (*KeySet, error) {
+	pemKeys := make(map[string][]byte, len(keyFiles))
+	for id, path := range keyFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: %v", id, err)
+		}
+		pemKeys[id] = data
+	}
+	return NewKeySet(activeKeyID, pemKeys, secret)
+}
+
+// NewKeySet builds a key set from PEM-encoded PKCS#8 or PKCS#1 private keys, or PKIX public keys,
+// by key id.
+func NewKeySet(activeKeyID string, pemKeys map[string][]byte, secret string) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]verificationKey, len(pemKeys))}
+	if secret != "" {
+		ks.secret = []byte(secret)
+	}
+	for id, data := range pemKeys {
+		if id == "" {
+			return nil, fmt.Errorf("signing key id cannot be empty")
+		}
+		key, err := parseKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %q: %v", id, err)
+		}
+		ks.keys[id] = key
+	}
+
+	if len(ks.keys) == 0 {
+		if ks.secret == nil {
+			return nil, fmt.Errorf("a signing key or secret is required")
+		}
+		return ks, nil
+	}
+	active, ok := ks.keys[activeKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active signing key %q not found", activeKeyID)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active signing key %q has no private key", activeKeyID)
+	}
+	ks.activeKeyID = activeKeyID
+	return ks, nil
+}
+
+func parseKey(data []byte) (verificationKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return verificationKey{}, fmt.Errorf("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return verificationKey{}, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return verificationKey{}, fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		return verificationKey{method: jwt.SigningMethodRS256, public: &k.PublicKey, private: k}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return verificationKey{}, fmt.Errorf("RSA keys must be at least %d bits", minRSAKeyBits)
+		}
+		return verificationKey{method: jwt.SigningMethodRS256, public: k}, nil
+	case ed25519.PrivateKey:
+		return verificationKey{method: jwt.SigningMethodEdDSA, public: k.Public(), private: k}, nil
+	case ed25519.PublicKey:
+		return verificationKey{method: jwt.SigningMethodEdDSA, public: k}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %T, expected RSA or Ed25519", parsed)
+	}
+}
+
+// sign signs claims with the active key, or the HS256 secret when no keys are configured.
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	if ks.activeKeyID == "" {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.secret)
+	}
+	key := ks.keys[ks.activeKeyID]
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = ks.activeKeyID
+	return token.SignedString(key.private)
+}
+
+// keyFunc selects the key a token is verified with by its kid, refusing any algorithm other than the
+// one that key signs with.
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok || ks.secret == nil {
+			return nil, fmt.Errorf("token has no key id")
+		}
+		return ks.secret, nil
+	}
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
+// JWK is one public key of a JSON Web Key Set.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JWKS is the JSON Web Key Set other services verify access tokens with.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public half of every key, ordered by key id. The HS256 secret is never published,
+// so the set is empty until signing keys are configured.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(ks.keys))}
+	for id, key := range ks.keys {
+		jwk := JWK{KeyID: id, Use: "sig", Algorithm: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
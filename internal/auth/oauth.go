@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Provider key sets are refetched after providerKeysTTL, and at most every providerKeysMinRefresh when
+// a token names a key that isn't cached yet, since providers rotate keys without notice.
+const (
+	providerKeysTTL        = time.Hour
+	providerKeysMinRefresh = time.Minute
+)
+
+// OAuthProvider describes where an identity provider publishes its signing keys and which issuers its
+// ID tokens name.
+type OAuthProvider struct {
+	Issuers []string
+	JWKSURL string
+}
+
+// OAuthProviders lists the identity providers ID tokens are accepted from, by the name used in the
+// login route.
+var OAuthProviders = map[string]OAuthProvider{
+	"google": {
+		Issuers: []string{"https://accounts.google.com", "accounts.google.com"},
+		JWKSURL: "https://www.googleapis.com/oauth2/v3/certs",
+	},
+	"apple": {
+		Issuers: []string{"https://appleid.apple.com"},
+		JWKSURL: "https://appleid.apple.com/auth/keys",
+	},
+}
+
+// ExternalIdentity is the account an ID token was issued for.
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type idTokenClaims struct {
+	Email string `json:"email"`
+	// Google sends a boolean and Apple the string "true" or "false"
+	EmailVerified interface{} `json:"email_verified"`
+	Name          string      `json:"name"`
+	Nonce         string      `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// IDTokenVerifier checks ID tokens issued by the configured identity providers to our client IDs.
+type IDTokenVerifier struct {
+	providers map[string]*oauthProvider
+}
+
+type oauthProvider struct {
+	OAuthProvider
+	audiences []string
+	keys      *providerKeySet
+}
+
+// NewIDTokenVerifier accepts ID tokens from each provider in OAuthProviders that has client IDs
+// configured, issued to one of those client IDs.
+func NewIDTokenVerifier(clientIDs map[string][]string, timeout time.Duration) *IDTokenVerifier {
+	client := &http.Client{Timeout: timeout}
+	v := &IDTokenVerifier{providers: make(map[string]*oauthProvider)}
+	for name, provider := range OAuthProviders {
+		if len(clientIDs[name]) == 0 {
+			continue
+		}
+		v.providers[name] = &oauthProvider{
+			OAuthProvider: provider,
+			audiences:     clientIDs[name],
+			keys:          &providerKeySet{url: provider.JWKSURL, client: client},
+		}
+	}
+	return v
+}
+
+// Verify checks an ID token's signature, issuer, audience and expiry, and its nonce when the client
+// sent one, returning the identity it was issued for.
+func (v *IDTokenVerifier) Verify(ctx context.Context, providerName, idToken, nonce string) (*ExternalIdentity, error) {
+	provider, ok := v.providers[providerName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported oauth provider: %s", providerName)
+	}
+
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return provider.keys.key(ctx, kid)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %v", err)
+	}
+	if !slices.Contains(provider.Issuers, claims.Issuer) {
+		return nil, fmt.Errorf("invalid id token: unexpected issuer %q", claims.Issuer)
+	}
+	if !slices.ContainsFunc(provider.audiences, func(audience string) bool { return slices.Contains(claims.Audience, audience) }) {
+		return nil, fmt.Errorf("invalid id token: unexpected audience %v", claims.Audience)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("invalid id token: no subject")
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, fmt.Errorf("invalid id token: nonce mismatch")
+	}
+
+	verified := false
+	switch value := claims.EmailVerified.(type) {
+	case bool:
+		verified = value
+	case string:
+		verified = value == "true"
+	}
+	return &ExternalIdentity{
+		Provider:      providerName,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: verified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// providerKeySet caches the RSA keys an identity provider signs ID tokens with.
+type providerKeySet struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func (s *providerKeySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	age := time.Since(s.fetchedAt)
+	key, ok := s.keys[kid]
+	if ok && age < providerKeysTTL {
+		return key, nil
+	}
+	if s.keys == nil || age >= providerKeysMinRefresh {
+		if err := s.fetch(ctx); err != nil {
+			// A provider outage shouldn't lock out tokens signed with a key already known
+			if ok {
+				return key, nil
+			}
+			return nil, err
+		}
+		if key, ok = s.keys[kid]; ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (s *providerKeySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return fmt.Errorf("provider keys request failed: %v", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("provider keys request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("provider keys request failed: status %d", resp.StatusCode)
+	}
+
+	var set JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("provider keys response invalid: %v", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.KeyType != "RSA" {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil {
+			continue
+		}
+		keys[jwk.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
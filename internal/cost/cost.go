@@ -0,0 +1,93 @@
+package cost
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Operation is a billable unit of backend work performed while serving a request.
+type Operation string
+
+const (
+	CacheRead     Operation = "cache_read"
+	MongoQuery    Operation = "mongo_query"
+	CoreLogicCall Operation = "corelogic_call"
+)
+
+// contextKey is where the per-request Meter lives on the gin context.
+const contextKey = "request_cost"
+
+var (
+	mu      sync.RWMutex
+	weights = map[Operation]int64{
+		CacheRead:     1,
+		MongoQuery:    5,
+		CoreLogicCall: 100,
+	}
+)
+
+// Configure overrides the default unit weights; non-positive values keep the default.
+func Configure(cacheRead, mongoQuery, coreLogicCall int64) {
+	mu.Lock()
+	defer mu.Unlock()
+	for op, units := range map[Operation]int64{CacheRead: cacheRead, MongoQuery: mongoQuery, CoreLogicCall: coreLogicCall} {
+		if units > 0 {
+			weights[op] = units
+		}
+	}
+}
+
+func weight(op Operation) int64 {
+	mu.RLock()
+	defer mu.RUnlock()
+	return weights[op]
+}
+
+// Meter accumulates the cost units consumed by a single request.
+type Meter struct {
+	units atomic.Int64
+}
+
+func NewMeter() *Meter {
+	return &Meter{}
+}
+
+func (m *Meter) Add(op Operation) {
+	m.units.Add(weight(op))
+}
+
+func (m *Meter) Units() int64 {
+	return m.units.Load()
+}
+
+// Attach stores a new Meter on the gin context and returns it.
+func Attach(c *gin.Context) *Meter {
+	meter := NewMeter()
+	c.Set(contextKey, meter)
+	return meter
+}
+
+// FromContext returns the request's Meter, or nil outside of a metered request. Contexts derived from
+// the request's, such as a transaction's, share its meter.
+func FromContext(ctx context.Context) *Meter {
+	ginCtx, _ := ctx.Value(gin.ContextKey).(*gin.Context)
+	if ginCtx == nil {
+		return nil
+	}
+	value, exists := ginCtx.Get(contextKey)
+	if !exists {
+		return nil
+	}
+	meter, _ := value.(*Meter)
+	return meter
+}
+
+// Record charges an operation to the request carried by ctx; it is a no-op for background work.
+func Record(ctx context.Context, op Operation) {
+	if meter := FromContext(ctx); meter != nil {
+		meter.Add(op)
+	}
+}
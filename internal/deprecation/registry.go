@@ -0,0 +1,53 @@
+package deprecation
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"homeinsight-properties/internal/models"
+)
+
+// registry lists every deprecated endpoint and query parameter. Routes use gin patterns so they
+// match c.FullPath(). Add an entry here before announcing a removal, and remove it together with
+// the code it describes once the sunset date has passed and no clients report usage.
+var registry = []models.Deprecation{
+	{
+		ID:          "properties-offset-pagination",
+		Method:      http.MethodGet,
+		Route:       "/api/properties",
+		Param:       "offset",
+		Since:       date("2026-10-16"),
+		Sunset:      date("2027-04-30"),
+		Replacement: "GET /api/properties?cursor= (cursor pagination)",
+	},
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		panic("deprecation: invalid date " + s)
+	}
+	return t
+}
+
+// All returns every registered deprecation.
+func All() []models.Deprecation {
+	return append([]models.Deprecation(nil), registry...)
+}
+
+// Match returns the deprecations that apply to a request for the given route pattern. Parameter
+// deprecations only match when the client actually sends the parameter.
+func Match(method, route string, query url.Values) []models.Deprecation {
+	var matched []models.Deprecation
+	for _, d := range registry {
+		if d.Method != method || d.Route != route {
+			continue
+		}
+		if d.Param != "" && !query.Has(d.Param) {
+			continue
+		}
+		matched = append(matched, d)
+	}
+	return matched
+}
@@ -0,0 +1,68 @@
+package errors
+
+import "net/http"
+
+// CatalogEntry documents an error code: the HTTP status it's returned with and when clients see it.
+type CatalogEntry struct {
+	Code        string `json:"code"`
+	HTTPStatus  int    `json:"status"`
+	Description string `json:"description"`
+}
+
+// Catalog lists every error code the API returns. Clients should branch on the code, not the
+// message; codes are stable while messages may be reworded.
+var Catalog = []CatalogEntry{
+	// Requests
+	{ErrCodeInvalidParameters, http.StatusBadRequest, "The request body or parameters are malformed or out of range; details lists the offending fields when known."},
+	{ErrCodeInvalidAddress, http.StatusBadRequest, "An address is missing its street or city."},
+	{ErrCodeSchemaViolation, http.StatusUnprocessableEntity, "The request doesn't match the API spec; details lists each violation as a JSON pointer. Only returned where spec validation is enabled."},
+	{ErrCodeRouteNotFound, http.StatusNotFound, "No endpoint exists at this path."},
+	{ErrCodeRequestTooLarge, http.StatusRequestEntityTooLarge, "The request body exceeds the size limit for the endpoint."},
+	{ErrCodeRateLimited, http.StatusTooManyRequests, "Too many requests in a short time; retry after the Retry-After header."},
+	{ErrCodeQuotaExceeded, http.StatusTooManyRequests, "The organization used up its daily usage quota."},
+	{ErrCodeIdempotencyKeyReused, http.StatusUnprocessableEntity, "The Idempotency-Key was already used with a different request body."},
+	{ErrCodeIdempotencyInProgress, http.StatusConflict, "A request with the same Idempotency-Key is still running."},
+
+	// Authentication and authorization
+	{ErrCodeUnauthorized, http.StatusUnauthorized, "The access or refresh token is missing, invalid, expired or revoked."},
+	{ErrCodeInvalidCredentials, http.StatusUnauthorized, "The email or password is incorrect."},
+	{ErrCodeAccountLocked, http.StatusTooManyRequests, "Password sign-in is locked after repeated failures; retry after the Retry-After header."},
+	{ErrCodeEmailRegistered, http.StatusConflict, "An account with this email already exists."},
+	{ErrCodeForbidden, http.StatusForbidden, "The signed-in user's role doesn't allow this action."},
+	{ErrCodeInvalidAPIKey, http.StatusUnauthorized, "The embed API key is missing or unknown."},
+	{ErrCodeOriginNotAllowed, http.StatusForbidden, "The embedding site isn't allowed for this API key."},
+	{ErrCodeInvalidSignature, http.StatusUnauthorized, "A partner request signature is missing, invalid or outside the timestamp tolerance."},
+	{ErrCodeReplayedRequest, http.StatusConflict, "A signed partner request reused a nonce."},
+
+	// Resources
+	{ErrCodePropertyNotFound, http.StatusNotFound, "No property matches the ID or address, locally or at the data provider."},
+	{ErrCodePropertyExists, http.StatusConflict, "A property already exists at this address."},
+	{ErrCodeOwnerNotFound, http.StatusNotFound, "No owner matches the identifier."},
+	{ErrCodeShareLinkNotFound, http.StatusNotFound, "The share link doesn't exist."},
+	{ErrCodeShareLinkExpired, http.StatusGone, "The share link expired or was revoked."},
+	{ErrCodeSavedSearchNotFound, http.StatusNotFound, "The saved search doesn't exist or belongs to another user."},
+	{ErrCodeWebhookNotFound, http.StatusNotFound, "The webhook doesn't exist."},
+	{ErrCodeMediaNotFound, http.StatusNotFound, "The photo or document doesn't exist."},
+	{ErrCodeListingNotFound, http.StatusNotFound, "The listing doesn't exist."},
+	{ErrCodeListingExists, http.StatusConflict, "The property already has an active or pending listing."},
+	{ErrCodeNotificationNotFound, http.StatusNotFound, "The notification doesn't exist."},
+	{ErrCodeSessionNotFound, http.StatusNotFound, "The session doesn't exist, belongs to another user or was already signed out."},
+	{ErrCodeOrganizationNotFound, http.StatusNotFound, "The organization doesn't exist."},
+	{ErrCodeOrganizationExists, http.StatusConflict, "Another organization uses this slug."},
+	{ErrCodeMemberNotFound, http.StatusNotFound, "The user isn't registered or isn't a member of the organization."},
+	{ErrCodeMemberOfOtherOrg, http.StatusConflict, "The user already belongs to another organization, which has to remove them first."},
+	{ErrCodeJobNotFound, http.StatusNotFound, "The job doesn't exist or finished too long ago to be kept."},
+	{ErrCodeDemographicsNotFound, http.StatusNotFound, "The property has no census tract or coordinates, or the census survey has no estimates for its tract."},
+
+	// Admin operations
+	{ErrCodeReindexJobNotFound, http.StatusNotFound, "The reindex job doesn't exist."},
+	{ErrCodeReindexInProgress, http.StatusConflict, "A reindex of the collection is already running."},
+	{ErrCodeMigrationNotFound, http.StatusNotFound, "No migration is registered under this name."},
+	{ErrCodeMigrationRunning, http.StatusConflict, "The migration is already running."},
+	{ErrCodeFeedProviderNotFound, http.StatusNotFound, "No data feed transformer is registered for the provider."},
+	{ErrCodeFeedRunNotFound, http.StatusNotFound, "The feed run doesn't exist."},
+
+	// Server
+	{ErrCodeServiceUnavailable, http.StatusServiceUnavailable, "A dependency such as the property data provider or the Census API is unavailable; retry later."},
+	{ErrCodeInternal, http.StatusInternalServerError, "An unexpected error; quote the requestId when reporting it."},
+}
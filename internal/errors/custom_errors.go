@@ -11,6 +11,7 @@ type AppError struct {
 	Code            string
 	HTTPStatus      int
 	OriginalError   error  
+	Details         interface{}
 }
 
 // Error implements the error interface.
@@ -34,11 +35,57 @@ func NewAppError(technicalMessage, userMessage, code string, status int, origina
 	}
 }
 
-// Common error codes
+// WithDetails attaches machine-readable details, such as the offending fields, returned to the client
+// alongside the message.
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	e.Details = details
+	return e
+}
+
+// Common error codes; Catalog documents what each means and the status it's returned with.
 const (
-	ErrCodeInvalidAddress      = "INVALID_ADDRESS"
-	ErrCodePropertyNotFound    = "PROPERTY_NOT_FOUND"
-	ErrCodeServiceUnavailable  = "SERVICE_UNAVAILABLE"
-	ErrCodeRateLimited         = "RATE_LIMITED"
-	ErrCodeInvalidParameters   = "INVALID_PARAMETERS"
+	ErrCodeInvalidAddress        = "INVALID_ADDRESS"
+	ErrCodePropertyNotFound      = "PROPERTY_NOT_FOUND"
+	ErrCodePropertyExists        = "PROPERTY_EXISTS"
+	ErrCodeServiceUnavailable    = "SERVICE_UNAVAILABLE"
+	ErrCodeRateLimited           = "RATE_LIMITED"
+	ErrCodeInvalidParameters     = "INVALID_PARAMETERS"
+	ErrCodeOwnerNotFound         = "OWNER_NOT_FOUND"
+	ErrCodeShareLinkNotFound     = "SHARE_LINK_NOT_FOUND"
+	ErrCodeShareLinkExpired      = "SHARE_LINK_EXPIRED"
+	ErrCodeInvalidAPIKey         = "INVALID_API_KEY"
+	ErrCodeOriginNotAllowed      = "ORIGIN_NOT_ALLOWED"
+	ErrCodeForbidden             = "FORBIDDEN"
+	ErrCodeReindexJobNotFound    = "REINDEX_JOB_NOT_FOUND"
+	ErrCodeReindexInProgress     = "REINDEX_IN_PROGRESS"
+	ErrCodeInvalidSignature      = "INVALID_SIGNATURE"
+	ErrCodeReplayedRequest       = "REPLAYED_REQUEST"
+	ErrCodeSavedSearchNotFound   = "SAVED_SEARCH_NOT_FOUND"
+	ErrCodeWebhookNotFound       = "WEBHOOK_NOT_FOUND"
+	ErrCodeIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	ErrCodeIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+	ErrCodeJobNotFound           = "JOB_NOT_FOUND"
+	ErrCodeMediaNotFound         = "MEDIA_NOT_FOUND"
+	ErrCodeListingNotFound       = "LISTING_NOT_FOUND"
+	ErrCodeListingExists         = "LISTING_EXISTS"
+	ErrCodeNotificationNotFound  = "NOTIFICATION_NOT_FOUND"
+	ErrCodeSessionNotFound       = "SESSION_NOT_FOUND"
+	ErrCodeOrganizationNotFound  = "ORGANIZATION_NOT_FOUND"
+	ErrCodeOrganizationExists    = "ORGANIZATION_EXISTS"
+	ErrCodeMemberNotFound        = "MEMBER_NOT_FOUND"
+	ErrCodeMemberOfOtherOrg      = "MEMBER_OF_OTHER_ORGANIZATION"
+	ErrCodeQuotaExceeded         = "QUOTA_EXCEEDED"
+	ErrCodeMigrationNotFound     = "MIGRATION_NOT_FOUND"
+	ErrCodeMigrationRunning      = "MIGRATION_RUNNING"
+	ErrCodeFeedProviderNotFound  = "FEED_PROVIDER_NOT_FOUND"
+	ErrCodeFeedRunNotFound       = "FEED_RUN_NOT_FOUND"
+	ErrCodeDemographicsNotFound  = "DEMOGRAPHICS_NOT_FOUND"
+	ErrCodeSchemaViolation       = "SCHEMA_VIOLATION"
+	ErrCodeInternal              = "INTERNAL_ERROR"
+	ErrCodeUnauthorized          = "UNAUTHORIZED"
+	ErrCodeInvalidCredentials    = "INVALID_CREDENTIALS"
+	ErrCodeAccountLocked         = "ACCOUNT_LOCKED"
+	ErrCodeEmailRegistered       = "EMAIL_ALREADY_REGISTERED"
+	ErrCodeRouteNotFound         = "ROUTE_NOT_FOUND"
+	ErrCodeRequestTooLarge       = "REQUEST_TOO_LARGE"
 )
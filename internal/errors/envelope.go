@@ -0,0 +1,115 @@
+package errors
+
+import (
+	stderrors "errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrorBody is what every failed request returns under the "error" key. Details is set for errors
+// about specific fields; RequestID lets support find the request in the logs.
+type ErrorBody struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"requestId,omitempty"`
+}
+
+// ErrorResponse is the error envelope: {"error": {"code", "message", "details", "requestId"}}.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// FieldError is a detail naming a request field that failed validation. Field is a JSON pointer.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// NewErrorResponse builds the envelope for appErr. Binding validation failures get their fields
+// listed as details when appErr doesn't carry its own.
+func NewErrorResponse(appErr *AppError, requestID string) ErrorResponse {
+	details := appErr.Details
+	if details == nil {
+		if fields := FieldErrors(appErr.OriginalError); len(fields) > 0 {
+			details = fields
+		}
+	}
+	return ErrorResponse{Error: ErrorBody{
+		Code:      appErr.Code,
+		Message:   appErr.UserMessage,
+		Details:   details,
+		RequestID: requestID,
+	}}
+}
+
+// FieldErrors lists the fields that failed validator rules, or nil if err isn't a validation error.
+func FieldErrors(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if err == nil || !stderrors.As(err, &validationErrs) {
+		return nil
+	}
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, FieldError{
+			Field:   fieldPointer(fieldErr.Namespace()),
+			Message: fieldMessage(fieldErr),
+		})
+	}
+	return fields
+}
+
+// fieldPointer turns a validator namespace such as RegisterRequest.email into /email.
+func fieldPointer(namespace string) string {
+	segments := strings.Split(namespace, ".")
+	if len(segments) > 1 {
+		segments = segments[1:]
+	}
+	for i, segment := range segments {
+		// Slice and map elements appear as items[2]
+		segment = strings.ReplaceAll(segment, "[", "/")
+		segments[i] = strings.ReplaceAll(segment, "]", "")
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "min", "gte":
+		return "must be at least " + fieldErr.Param() + lengthUnit(fieldErr)
+	case "max", "lte":
+		return "must be at most " + fieldErr.Param() + lengthUnit(fieldErr)
+	case "oneof":
+		return "must be one of: " + fieldErr.Param()
+	case "zipcode":
+		return "must be a 5-digit ZIP code"
+	case "usstate":
+		return "must be a two-letter US state code"
+	}
+	if fieldErr.Param() != "" {
+		return "failed " + fieldErr.Tag() + "=" + fieldErr.Param()
+	}
+	return "failed " + fieldErr.Tag()
+}
+
+// String formats a field error for logs, e.g. "/address/zipCode must be a 5-digit ZIP code".
+func (f FieldError) String() string {
+	return f.Field + " " + f.Message
+}
+
+// lengthUnit qualifies min/max bounds, which limit the length of strings and collections.
+func lengthUnit(fieldErr validator.FieldError) string {
+	switch fieldErr.Kind() {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+	return ""
+}
@@ -20,6 +20,22 @@ func MapError(err error) *AppError {
 
 	// Map specific error patterns to user-friendly errors
 	switch {
+	case strings.Contains(technicalMessage, "usage quota exceeded"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgQuotaExceeded,
+			Code:             ErrCodeQuotaExceeded,
+			HTTPStatus:       http.StatusTooManyRequests,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "not found by data provider"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgPropertyNotFound,
+			Code:             ErrCodePropertyNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
 	case strings.Contains(technicalMessage, "CoreLogic") && (strings.Contains(technicalMessage, "404 Not Found") || strings.Contains(technicalMessage, "Clip not found")):
 		return &AppError{
 			TechnicalMessage: technicalMessage,
@@ -28,7 +44,7 @@ func MapError(err error) *AppError {
 			HTTPStatus:       http.StatusNotFound,
 			OriginalError:    err,
 		}
-	case strings.Contains(technicalMessage, "CoreLogic"):
+	case strings.Contains(technicalMessage, "CoreLogic") || strings.Contains(technicalMessage, "property data provider"):
 		return &AppError{
 			TechnicalMessage: technicalMessage,
 			UserMessage:      MsgServiceUnavailable,
@@ -44,6 +60,22 @@ func MapError(err error) *AppError {
 			HTTPStatus:       http.StatusBadRequest,
 			OriginalError:    err,
 		}
+	case strings.Contains(technicalMessage, "invalid filter") || strings.Contains(technicalMessage, "invalid patch") || strings.Contains(technicalMessage, "invalid listing") || strings.Contains(technicalMessage, "invalid merge") || strings.Contains(technicalMessage, "invalid feed") || strings.Contains(technicalMessage, "invalid boundary") || strings.Contains(technicalMessage, "invalid bulk update"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgInvalidParameters,
+			Code:             ErrCodeInvalidParameters,
+			HTTPStatus:       http.StatusBadRequest,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "property already exists"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgPropertyExists,
+			Code:             ErrCodePropertyExists,
+			HTTPStatus:       http.StatusConflict,
+			OriginalError:    err,
+		}
 	case strings.Contains(technicalMessage, "database query failed"):
 		return &AppError{
 			TechnicalMessage: technicalMessage,
@@ -60,11 +92,179 @@ func MapError(err error) *AppError {
 			HTTPStatus:       http.StatusNotFound,
 			OriginalError:    err,
 		}
+	case strings.Contains(technicalMessage, "owner entity not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgOwnerNotFound,
+			Code:             ErrCodeOwnerNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "invalid share link"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgShareLinkNotFound,
+			Code:             ErrCodeShareLinkNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "share link expired") || strings.Contains(technicalMessage, "share link revoked"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgShareLinkExpired,
+			Code:             ErrCodeShareLinkExpired,
+			HTTPStatus:       http.StatusGone,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "reindex job not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgReindexJobNotFound,
+			Code:             ErrCodeReindexJobNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "job not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgJobNotFound,
+			Code:             ErrCodeJobNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "saved search not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgSavedSearchNotFound,
+			Code:             ErrCodeSavedSearchNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "listing not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgListingNotFound,
+			Code:             ErrCodeListingNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "open listing already exists"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgListingExists,
+			Code:             ErrCodeListingExists,
+			HTTPStatus:       http.StatusConflict,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "notification not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgNotificationNotFound,
+			Code:             ErrCodeNotificationNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "session not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgSessionNotFound,
+			Code:             ErrCodeSessionNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "organization not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgOrganizationNotFound,
+			Code:             ErrCodeOrganizationNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "organization already exists"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgOrganizationExists,
+			Code:             ErrCodeOrganizationExists,
+			HTTPStatus:       http.StatusConflict,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "member not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgMemberNotFound,
+			Code:             ErrCodeMemberNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "media not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgMediaNotFound,
+			Code:             ErrCodeMediaNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "migration not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgMigrationNotFound,
+			Code:             ErrCodeMigrationNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "migration already running"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgMigrationRunning,
+			Code:             ErrCodeMigrationRunning,
+			HTTPStatus:       http.StatusConflict,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "feed provider not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgFeedProviderNotFound,
+			Code:             ErrCodeFeedProviderNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "demographics not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgDemographicsNotFound,
+			Code:             ErrCodeDemographicsNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "census data unavailable"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgServiceUnavailable,
+			Code:             ErrCodeServiceUnavailable,
+			HTTPStatus:       http.StatusServiceUnavailable,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "feed run not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgFeedRunNotFound,
+			Code:             ErrCodeFeedRunNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
+	case strings.Contains(technicalMessage, "webhook not found"):
+		return &AppError{
+			TechnicalMessage: technicalMessage,
+			UserMessage:      MsgWebhookNotFound,
+			Code:             ErrCodeWebhookNotFound,
+			HTTPStatus:       http.StatusNotFound,
+			OriginalError:    err,
+		}
 	default:
 		return &AppError{
 			TechnicalMessage: technicalMessage,
 			UserMessage:      MsgInternalError,
-			Code:             "INTERNAL_ERROR",
+			Code:             ErrCodeInternal,
 			HTTPStatus:       http.StatusInternalServerError,
 			OriginalError:    err,
 		}
@@ -2,10 +2,53 @@ package errors
 
 // User-friendly error messages
 const (
-	MsgInvalidAddress     = "The provided address is incomplete or incorrectly formatted. Please include street, city, state, and zip code."
-	MsgPropertyNotFound   = "Property not found. Please try a different address."
-	MsgServiceUnavailable = "We're unable to retrieve property information right now. Please try again in a few minutes."
-	MsgRateLimited        = "You're searching too quickly! Please wait a moment and try again."
-	MsgInvalidParameters  = "The provided parameters are invalid. Please check your input and try again."
-	MsgInternalError      = "Something went wrong on our end. Please try again later."
+	MsgInvalidAddress        = "The provided address is incomplete or incorrectly formatted. Please include street, city, state, and zip code."
+	MsgPropertyNotFound      = "Property not found. Please try a different address."
+	MsgPropertyExists        = "A property already exists at this address."
+	MsgServiceUnavailable    = "We're unable to retrieve property information right now. Please try again in a few minutes."
+	MsgRateLimited           = "You're searching too quickly! Please wait a moment and try again."
+	MsgInvalidParameters     = "The provided parameters are invalid. Please check your input and try again."
+	MsgInternalError         = "Something went wrong on our end. Please try again later."
+	MsgOwnerNotFound         = "Owner not found. Please check the owner identifier and try again."
+	MsgShareLinkNotFound     = "This share link is invalid. Please ask the sender for a new link."
+	MsgShareLinkExpired      = "This share link has expired or been revoked. Please ask the sender for a new link."
+	MsgInvalidAPIKey         = "A valid API key is required to access this resource."
+	MsgOriginNotAllowed      = "This site is not authorized to embed property widgets."
+	MsgForbidden             = "You do not have permission to perform this action."
+	MsgReindexJobNotFound    = "Reindex job not found."
+	MsgReindexInProgress     = "A reindex is already running for this collection. Please wait for it to finish."
+	MsgInvalidSignature      = "The request signature is missing, invalid, or expired."
+	MsgReplayedRequest       = "This request has already been processed. Please sign each request with a new nonce."
+	MsgSavedSearchNotFound   = "Saved search not found."
+	MsgWebhookNotFound       = "Webhook not found."
+	MsgIdempotencyKeyReused  = "This Idempotency-Key was already used for a different request. Please use a new key."
+	MsgIdempotencyInProgress = "A request with this Idempotency-Key is still being processed. Please retry shortly."
+	MsgJobNotFound           = "Job not found. Finished jobs are kept for a limited time."
+	MsgMediaNotFound         = "Photo or document not found."
+	MsgListingNotFound       = "Listing not found."
+	MsgListingExists         = "This property already has an active or pending listing. Update it or mark it sold first."
+	MsgNotificationNotFound  = "Notification not found."
+	MsgSessionNotFound       = "Session not found. It may already have been signed out."
+	MsgOrganizationNotFound  = "Organization not found."
+	MsgOrganizationExists    = "An organization with this slug already exists. Please choose another slug."
+	MsgMemberNotFound        = "This user is not a member of the organization."
+	MsgQuotaExceeded         = "Your organization has used up today's usage quota. Usage resets at midnight UTC."
+	MsgMigrationNotFound     = "Migration not found. Please check the migration name."
+	MsgMigrationRunning      = "This migration is already running. Please wait for it to finish."
+	MsgFeedProviderNotFound  = "Unknown data feed provider. Please check the provider name."
+	MsgFeedRunNotFound       = "Feed run not found."
+	MsgDemographicsNotFound  = "Census demographics are not available for this property's location."
+	MsgSchemaViolation       = "The request does not match the API schema. Please check the listed fields."
+	MsgUnauthorized          = "Please sign in to access this resource."
+	MsgSessionExpired        = "Your session has expired. Please sign in again."
+	MsgInvalidCredentials    = "The email or password is incorrect."
+	MsgAccountLocked         = "Too many failed sign-in attempts. Please try again later or reset your password."
+	MsgEmailRegistered       = "An account with this email already exists. Please sign in instead."
+	MsgInvalidResetToken     = "This password reset link is invalid or has expired. Please request a new one."
+	MsgInvalidIDToken        = "We couldn't verify your sign-in with this provider. Please try again."
+	MsgOAuthEmailUnverified  = "Your email address isn't verified with this provider. Please verify it or sign in with your password."
+	MsgOAuthAccountLinked    = "This account is already linked to a different login with this provider."
+	MsgOAuthUnsupported      = "Sign-in with this provider isn't available."
+	MsgRouteNotFound         = "The requested endpoint does not exist."
+	MsgRequestTooLarge       = "The request body is too large."
 )
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditEventHandler struct {
+	auditEventService *services.AuditEventService
+}
+
+func NewAuditEventHandler(auditEventService *services.AuditEventService) *AuditEventHandler {
+	return &AuditEventHandler{
+		auditEventService: auditEventService,
+	}
+}
+
+// ListEvents pages through the security audit log, newest first, between ?from= and ?to= (RFC 3339
+// timestamps or YYYY-MM-DD days), optionally for one ?type= of event or ?actorId=.
+// @Summary List audit events
+// @Description Page through the security audit log, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start, as an RFC 3339 timestamp or YYYY-MM-DD"
+// @Param to query string false "End, as an RFC 3339 timestamp or YYYY-MM-DD"
+// @Param type query string false "Event type"
+// @Param actorId query string false "User who caused the events"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.AuditEventsResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/audit-events [get]
+func (h *AuditEventHandler) ListEvents(c *gin.Context) {
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	filter := models.AuditEventFilter{
+		From:    c.Query("from"),
+		To:      c.Query("to"),
+		Type:    c.Query("type"),
+		ActorID: c.Query("actorId"),
+	}
+
+	response, err := h.auditEventService.List(c, filter, offset, limit, pageURL(c, c.Request.URL.Path), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list audit events",
+			"from", filter.From,
+			"to", filter.To,
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
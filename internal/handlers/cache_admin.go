@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CacheAdminHandler struct {
+	cacheAdminService *services.CacheAdminService
+}
+
+func NewCacheAdminHandler(cacheAdminService *services.CacheAdminService) *CacheAdminHandler {
+	return &CacheAdminHandler{
+		cacheAdminService: cacheAdminService,
+	}
+}
+
+// InvalidateProperty drops the cached copies of one property and the search results containing it.
+// @Summary Invalidate a cached property
+// @Description Drop the cached copies of one property and the search results containing it
+// @Tags Admin
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/cache/properties/{id} [delete]
+func (h *CacheAdminHandler) InvalidateProperty(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.cacheAdminService.InvalidateProperty(c, id, c.GetString("user_id")); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "invalidate property cache", "id", id))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ClearSearches drops all cached search, full-text and list results.
+// @Summary Clear cached searches
+// @Description Drop all cached search, full-text and list results
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{deleted=int}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/cache/search [delete]
+func (h *CacheAdminHandler) ClearSearches(c *gin.Context) {
+	deleted, err := h.cacheAdminService.ClearSearches(c, c.GetString("user_id"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "clear search cache", "deleted", deleted))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": deleted})
+}
+
+// Flush drops all cached data.
+// @Summary Flush the cache
+// @Description Drop all cached data
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{deleted=int}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/cache/flush [post]
+func (h *CacheAdminHandler) Flush(c *gin.Context) {
+	deleted, err := h.cacheAdminService.Flush(c, c.GetString("user_id"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "flush cache", "deleted", deleted))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": deleted})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DemographicsHandler struct {
+	demographicsService *services.DemographicsService
+}
+
+func NewDemographicsHandler(demographicsService *services.DemographicsService) *DemographicsHandler {
+	return &DemographicsHandler{
+		demographicsService: demographicsService,
+	}
+}
+
+// GetDemographics returns the income, population and housing statistics of the census tract a
+// property lies in.
+// @Summary Get neighborhood demographics
+// @Description Get the income, population and housing statistics of the census tract a property lies in
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 200 {object} models.Demographics
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/demographics [get]
+func (h *DemographicsHandler) GetDemographics(c *gin.Context) {
+	id := c.Param("id")
+	c.Set("property_id", id)
+
+	demographics, err := h.demographicsService.GetDemographics(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get demographics", "propertyID", id))
+		return
+	}
+	c.JSON(http.StatusOK, demographics)
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DeprecationHandler struct {
+	deprecationService *services.DeprecationService
+}
+
+func NewDeprecationHandler(deprecationService *services.DeprecationService) *DeprecationHandler {
+	return &DeprecationHandler{
+		deprecationService: deprecationService,
+	}
+}
+
+// ListDeprecations reports deprecated endpoints and parameters and which clients still use them.
+// @Summary List deprecations
+// @Description Report deprecated endpoints and parameters and which clients still use them
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{data=[]models.DeprecationReport}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/deprecations [get]
+func (h *DeprecationHandler) ListDeprecations(c *gin.Context) {
+	reports, err := h.deprecationService.Report(c)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list deprecations"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": reports})
+}
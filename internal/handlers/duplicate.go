@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DuplicateHandler struct {
+	duplicateService *services.DuplicateService
+}
+
+func NewDuplicateHandler(duplicateService *services.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateService: duplicateService,
+	}
+}
+
+// ListDuplicates returns the candidate pairs found by the latest duplicate scan.
+// @Summary List duplicate candidates
+// @Description List the candidate pairs found by the latest duplicate scan
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.DuplicatesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/duplicates [get]
+func (h *DuplicateHandler) ListDuplicates(c *gin.Context) {
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	candidates, total, err := h.duplicateService.List(c, offset, limit)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list duplicates",
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	c.JSON(http.StatusOK, models.DuplicatesResponse{
+		Data:     candidates,
+		Metadata: models.PaginationMeta{Total: total, Offset: offset, Limit: limit},
+	})
+}
+
+// ScanDuplicates queues a scan for properties sharing a normalized address or CLIP and returns the
+// job to poll. The scan replaces the previous candidate pairs.
+// @Summary Scan for duplicates
+// @Description Queue a scan for properties sharing a normalized address or CLIP and return the job to poll
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 202 {object} jobs.Job
+// @Header 202 {string} Location "URL of the job"
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /admin/duplicates/scan [post]
+func (h *DuplicateHandler) ScanDuplicates(c *gin.Context) {
+	job, err := h.duplicateService.Scan(c, c.GetString("user_id"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "scan duplicates"))
+		return
+	}
+	c.Header("Location", "/api/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// MergeProperties merges one property into another and moves the merged one to the trash.
+// @Summary Merge properties
+// @Description Merge one property into another and move the merged one to the trash
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.MergePropertiesRequest true "Properties to merge"
+// @Success 200 {object} models.MergePropertiesResult
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /admin/properties/merge [post]
+func (h *DuplicateHandler) MergeProperties(c *gin.Context) {
+	var req models.MergePropertiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid merge request: error=%v", err)
+		c.Error(appErr)
+		return
+	}
+
+	result, err := h.duplicateService.Merge(c, &req)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "merge properties", "keepId", req.KeepID, "mergeId", req.MergeID))
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+var embedSnippetTemplate = template.Must(template.New("embed").Parse(`<div class="homeinsight-property" data-property-id="{{.PropertyID}}">
+  <div class="homeinsight-property__address">{{.StreetAddress}}, {{.City}}, {{.State}} {{.ZipCode}}</div>
+  <ul class="homeinsight-property__facts">
+    <li>{{.BedroomsCount}} bd</li>
+    <li>{{.BathroomsCount}} ba</li>
+    <li>{{.LivingAreaSquareFeet}} sqft</li>
+    {{if .YearBuilt}}<li>Built {{.YearBuilt}}</li>{{end}}
+  </ul>
+  {{if .LastSaleAmount}}<div class="homeinsight-property__sale">Last sold {{.LastSaleDate}} for ${{.LastSaleAmount}}</div>{{end}}
+</div>
+`))
+
+type EmbedHandler struct {
+	embedService *services.EmbedService
+	maxAge       int
+}
+
+func NewEmbedHandler(embedService *services.EmbedService, maxAgeSeconds int) *EmbedHandler {
+	return &EmbedHandler{
+		embedService: embedService,
+		maxAge:       maxAgeSeconds,
+	}
+}
+
+func (h *EmbedHandler) GetPropertyEmbed(c *gin.Context) {
+	id := c.Param("id")
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "html" {
+		appErr := errors.NewAppError(
+			"unsupported embed format: "+format,
+			"Embed format must be json or html",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Unsupported embed format: id=%s, format=%s", id, format)
+		c.Error(appErr)
+		return
+	}
+
+	summary, err := h.embedService.GetPropertySummary(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get property embed", "id", id))
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(h.maxAge))
+
+	if format == "html" {
+		var buf bytes.Buffer
+		if err := embedSnippetTemplate.Execute(&buf, summary); err != nil {
+			c.Error(utils.LogAndMapError(c, err, "render property embed", "id", id))
+			return
+		}
+		c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	// JSONP for legacy partner widgets that load the payload via a script tag
+	if c.Query("callback") != "" {
+		c.JSONP(http.StatusOK, summary)
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/feeds"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	feedService *services.FeedService
+}
+
+func NewFeedHandler(feedService *services.FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// UploadFeed accepts a provider's CSV or XML file as the raw request body and queues it to be applied
+// to properties. The format is taken from ?format= or else the Content-Type, and the file name from
+// ?fileName= or a Content-Disposition header. Returns the feed run to poll.
+// @Summary Upload a provider feed
+// @Description Queue a provider's CSV or XML file, sent as the raw request body, to be applied to properties, and return the feed run to poll
+// @Tags Feeds
+// @Accept text/csv,xml
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Feed provider"
+// @Param format query string false "File format; defaults to the Content-Type" Enums(csv, xml)
+// @Param fileName query string false "File name; defaults to the Content-Disposition header"
+// @Param file body string true "Feed file"
+// @Success 202 {object} models.FeedRun
+// @Header 202 {string} Location "URL of the feed run"
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 413 {object} errors.ErrorResponse
+// @Router /feeds/{provider} [post]
+func (h *FeedHandler) UploadFeed(c *gin.Context) {
+	provider := c.Param("provider")
+	format := c.Query("format")
+	if format == "" {
+		format = feeds.FormatFromContentType(c.GetHeader("Content-Type"))
+	}
+	fileName := c.Query("fileName")
+	if fileName == "" {
+		if _, params, err := mime.ParseMediaType(c.GetHeader("Content-Disposition")); err == nil {
+			fileName = params["filename"]
+		}
+	}
+
+	run, err := h.feedService.Upload(c, provider, format, fileName, c.Request.Body, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "upload feed", "provider", provider, "format", format, "fileName", fileName))
+		return
+	}
+	c.Header("Location", "/api/feeds/runs/"+run.ID.Hex())
+	c.JSON(http.StatusAccepted, run)
+}
+
+// ListRuns pages through the organization's feed runs, newest first, optionally for one ?provider=.
+// @Summary List feed runs
+// @Description Page through the organization's feed runs, newest first
+// @Tags Feeds
+// @Produce json
+// @Security BearerAuth
+// @Param provider query string false "Feed provider"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.FeedRunsResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /feeds/runs [get]
+func (h *FeedHandler) ListRuns(c *gin.Context) {
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	provider := c.Query("provider")
+
+	response, err := h.feedService.ListRuns(c, provider, offset, limit, pageURL(c, c.Request.URL.Path), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list feed runs", "provider", provider, "offset", offset, "limit", limit))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRun returns a feed run with its progress and the errors of the records it couldn't apply.
+// @Summary Get a feed run
+// @Description Get a feed run with its progress and the errors of the records it couldn't apply
+// @Tags Feeds
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Feed run ID"
+// @Success 200 {object} models.FeedRun
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /feeds/runs/{id} [get]
+func (h *FeedHandler) GetRun(c *gin.Context) {
+	id := c.Param("id")
+
+	run, err := h.feedService.GetRun(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get feed run", "id", id))
+		return
+	}
+	c.JSON(http.StatusOK, run)
+}
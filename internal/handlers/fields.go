@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parseFields reads the optional ?fields= sparse fieldset for property responses.
+func parseFields(c *gin.Context) (models.PropertyFields, bool) {
+	raw := c.Query("fields")
+	fields, err := models.ParsePropertyFields(raw)
+	if err != nil {
+		appErr := errors.NewAppError(
+			err.Error(),
+			"Fields must be a comma-separated list of property fields, such as address,building.summary",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid fields: value=%s, error=%v", raw, err)
+		c.Error(appErr)
+		return nil, false
+	}
+	return fields, true
+}
+
+// parseInclude reads the optional ?include= list of related resources to embed in property responses.
+func parseInclude(c *gin.Context) (map[string]bool, bool) {
+	raw := c.Query("include")
+	include := map[string]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(services.Includable, name) {
+			appErr := errors.NewAppError(
+				fmt.Sprintf("invalid include: %s", name),
+				"Include must be a comma-separated list of related resources: "+strings.Join(services.Includable, ", "),
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				nil,
+			)
+			logger.GlobalLogger.Errorf("Invalid include: value=%s", raw)
+			c.Error(appErr)
+			return nil, false
+		}
+		include[name] = true
+	}
+	return include, true
+}
+
+// writeProperty responds with a single property trimmed to the selected fields.
+func writeProperty(c *gin.Context, fields models.PropertyFields, property interface{}) {
+	projected, err := fields.Project(property)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, projected)
+}
+
+// writeProperties responds with a page of properties, each trimmed to the selected fields.
+func writeProperties(c *gin.Context, fields models.PropertyFields, response *models.PaginatedPropertiesResponse) {
+	setPaginationLinks(c, response.Metadata)
+	if len(fields) == 0 {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	data := make([]interface{}, 0, len(response.Data))
+	for i := range response.Data {
+		projected, err := fields.Project(&response.Data[i])
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		data = append(data, projected)
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data, "metadata": response.Metadata})
+}
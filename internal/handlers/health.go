@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	healthService *services.HealthService
+}
+
+func NewHealthHandler(healthService *services.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// Liveness answers as long as the process can serve requests; it checks no dependencies, so an
+// outage elsewhere doesn't get every instance restarted.
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": models.HealthOK})
+}
+
+// Readiness checks the instance's dependencies. It answers 503 while a critical one is down, so the
+// instance is taken out of load balancing, and 200 when ok or degraded.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	report := h.healthService.Ready(c.Request.Context())
+	status := http.StatusOK
+	if report.Status == models.HealthDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	jobService *services.JobService
+}
+
+func NewJobHandler(jobService *services.JobService) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+	}
+}
+
+// GetJob reports the status of a background job, and its result once it has succeeded.
+// @Summary Get a background job
+// @Description Report the status of a background job, and its result once it has succeeded
+// @Tags Jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} jobs.Job
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	job, err := h.jobService.GetJob(c, jobID, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get job", "job_id", jobID))
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
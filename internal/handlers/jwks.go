@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"homeinsight-properties/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jwksMaxAgeSeconds is how long verifiers may cache the key set. A new key should be published at
+// least this long before it is made active.
+const jwksMaxAgeSeconds = 300
+
+type JWKSHandler struct {
+	keys *auth.KeySet
+}
+
+func NewJWKSHandler(keys *auth.KeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+// Keys returns the public keys access tokens are verified with, for other services to verify them.
+func (h *JWKSHandler) Keys(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(jwksMaxAgeSeconds))
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
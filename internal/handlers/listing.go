@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ListingHandler struct {
+	listingService *services.ListingService
+}
+
+func NewListingHandler(listingService *services.ListingService) *ListingHandler {
+	return &ListingHandler{
+		listingService: listingService,
+	}
+}
+
+// CreateListing lists a property for sale. A property can have one active or pending listing at a time.
+// @Summary Create a listing
+// @Description List a property for sale. A property can have one active or pending listing at a time
+// @Tags Listings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param request body models.ListingRequest true "Listing data"
+// @Success 201 {object} models.Listing
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /properties/{id}/listings [post]
+func (h *ListingHandler) CreateListing(c *gin.Context) {
+	id := c.Param("id")
+	req, ok := bindListingRequest(c)
+	if !ok {
+		return
+	}
+
+	listing, err := h.listingService.CreateListing(c, id, c.GetString("user_id"), req)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "create listing", "propertyID", id))
+		return
+	}
+	c.JSON(http.StatusCreated, listing)
+}
+
+// ListListings returns all listings of a property, newest first.
+// @Summary List listings
+// @Description List all listings of a property, newest first
+// @Tags Listings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 200 {object} object{data=[]models.Listing}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/listings [get]
+func (h *ListingHandler) ListListings(c *gin.Context) {
+	id := c.Param("id")
+
+	listings, err := h.listingService.ListListings(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list listings", "propertyID", id))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": listings})
+}
+
+// GetListing godoc
+// @Summary Get a listing
+// @Description Get one listing of a property
+// @Tags Listings
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param listingId path string true "Listing ID"
+// @Success 200 {object} models.Listing
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/listings/{listingId} [get]
+func (h *ListingHandler) GetListing(c *gin.Context) {
+	id := c.Param("id")
+	listingID := c.Param("listingId")
+
+	listing, err := h.listingService.GetListing(c, id, listingID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get listing", "propertyID", id, "listingID", listingID))
+		return
+	}
+	c.JSON(http.StatusOK, listing)
+}
+
+// UpdateListing godoc
+// @Summary Update a listing
+// @Description Replace the price, status and details of a listing
+// @Tags Listings
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param listingId path string true "Listing ID"
+// @Param request body models.ListingRequest true "Listing data"
+// @Success 200 {object} models.Listing
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /properties/{id}/listings/{listingId} [put]
+func (h *ListingHandler) UpdateListing(c *gin.Context) {
+	id := c.Param("id")
+	listingID := c.Param("listingId")
+	req, ok := bindListingRequest(c)
+	if !ok {
+		return
+	}
+
+	listing, err := h.listingService.UpdateListing(c, id, listingID, req)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "update listing", "propertyID", id, "listingID", listingID))
+		return
+	}
+	c.JSON(http.StatusOK, listing)
+}
+
+// DeleteListing godoc
+// @Summary Delete a listing
+// @Description Delete a listing of a property
+// @Tags Listings
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param listingId path string true "Listing ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/listings/{listingId} [delete]
+func (h *ListingHandler) DeleteListing(c *gin.Context) {
+	id := c.Param("id")
+	listingID := c.Param("listingId")
+
+	if err := h.listingService.DeleteListing(c, id, listingID); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "delete listing", "propertyID", id, "listingID", listingID))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func bindListingRequest(c *gin.Context) (*models.ListingRequest, bool) {
+	var req models.ListingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			"The provided listing data is invalid",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid listing data: error=%v", err)
+		c.Error(appErr)
+		return nil, false
+	}
+	return &req, true
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationHandler struct {
+	locationService *services.LocationService
+}
+
+func NewLocationHandler(locationService *services.LocationService) *LocationHandler {
+	return &LocationHandler{
+		locationService: locationService,
+	}
+}
+
+// ListStates returns the states with properties, each with its property count.
+// @Summary List states
+// @Description List the states with properties, each with its property count
+// @Tags Locations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.LocationsResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /locations/states [get]
+func (h *LocationHandler) ListStates(c *gin.Context) {
+	states, err := h.locationService.States(c)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list location states"))
+		return
+	}
+	c.JSON(http.StatusOK, models.LocationsResponse{Data: states})
+}
+
+// ListCities returns the cities of a state with properties, each with its property count.
+// @Summary List cities
+// @Description List the cities of a state with properties, each with its property count
+// @Tags Locations
+// @Produce json
+// @Security BearerAuth
+// @Param state path string true "Two-letter state code"
+// @Success 200 {object} models.LocationsResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /locations/{state}/cities [get]
+func (h *LocationHandler) ListCities(c *gin.Context) {
+	state := c.Param("state")
+
+	cities, err := h.locationService.Cities(c, state)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list location cities", "state", state))
+		return
+	}
+	c.JSON(http.StatusOK, models.LocationsResponse{Data: cities})
+}
+
+// ListZips returns the zip codes of a city with properties, each with its property count.
+// @Summary List zip codes
+// @Description List the zip codes of a city with properties, each with its property count
+// @Tags Locations
+// @Produce json
+// @Security BearerAuth
+// @Param state path string true "Two-letter state code"
+// @Param city path string true "City"
+// @Success 200 {object} models.LocationsResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /locations/{state}/{city}/zips [get]
+func (h *LocationHandler) ListZips(c *gin.Context) {
+	state := c.Param("state")
+	city := c.Param("city")
+
+	zips, err := h.locationService.Zips(c, state, city)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list location zips", "state", state, "city", city))
+		return
+	}
+	c.JSON(http.StatusOK, models.LocationsResponse{Data: zips})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MarketHandler struct {
+	marketStatsService *services.MarketStatsService
+}
+
+func NewMarketHandler(marketStatsService *services.MarketStatsService) *MarketHandler {
+	return &MarketHandler{
+		marketStatsService: marketStatsService,
+	}
+}
+
+// GetZipStats returns the median sale price, average price per square foot, days-since-last-sale
+// distribution and year-built histogram of a zip code's properties.
+// @Summary Get zip code market statistics
+// @Description Get the median sale price, average price per square foot, days-since-last-sale distribution and year-built histogram of a zip code
+// @Tags Markets
+// @Produce json
+// @Security BearerAuth
+// @Param zipCode path string true "Zip code"
+// @Success 200 {object} models.MarketStats
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /markets/{zipCode}/stats [get]
+func (h *MarketHandler) GetZipStats(c *gin.Context) {
+	zipCode := c.Param("zipCode")
+
+	stats, err := h.marketStatsService.GetZipStats(c, zipCode)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get market stats", "zipCode", zipCode))
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
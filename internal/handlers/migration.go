@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MigrationHandler struct {
+	migrationService *services.MigrationService
+}
+
+func NewMigrationHandler(migrationService *services.MigrationService) *MigrationHandler {
+	return &MigrationHandler{
+		migrationService: migrationService,
+	}
+}
+
+// ListMigrations returns the migrations admins can launch, each with its latest run.
+// @Summary List migrations
+// @Description List the migrations admins can launch, each with its latest run
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{data=[]models.MigrationInfo}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/migrations [get]
+func (h *MigrationHandler) ListMigrations(c *gin.Context) {
+	migrations, err := h.migrationService.List(c)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list migrations"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": migrations})
+}
+
+// StartMigration launches a migration in the background, resuming its last run if that one failed,
+// and returns the run to poll.
+// @Summary Start a migration
+// @Description Launch a migration in the background, resuming its last run if that one failed, and return the run to poll
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Migration name"
+// @Success 202 {object} models.MigrationRun
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /admin/migrations/{name} [post]
+func (h *MigrationHandler) StartMigration(c *gin.Context) {
+	name := c.Param("name")
+
+	run, err := h.migrationService.Start(c, name, c.GetString("user_id"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "start migration", "name", name))
+		return
+	}
+	c.JSON(http.StatusAccepted, run)
+}
+
+// GetMigration returns the latest runs of a migration with their progress, newest first.
+// @Summary Get migration runs
+// @Description List the latest runs of a migration with their progress, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Migration name"
+// @Success 200 {object} object{data=[]models.MigrationRun}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /admin/migrations/{name} [get]
+func (h *MigrationHandler) GetMigration(c *gin.Context) {
+	name := c.Param("name")
+
+	runs, err := h.migrationService.Runs(c, name)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get migration", "name", name))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": runs})
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultNearbyRadiusMeters = 1000.0
+	maxNearbyRadiusMeters     = 50000.0
+)
+
+// parseCoordinate reads a required float query parameter within [min, max].
+func parseCoordinate(c *gin.Context, name string, min, max float64) (float64, bool) {
+	raw := c.Query(name)
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < min || value > max {
+		appErr := errors.NewAppError(
+			"invalid "+name+" parameter",
+			"Query parameters lat and lng must be valid coordinates",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid %s: value=%s", name, raw)
+		c.Error(appErr)
+		return 0, false
+	}
+	return value, true
+}
+
+// FindNearby godoc
+// @Summary Find nearby properties
+// @Description Page through the properties within a radius of a point, nearest first
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param lat query number true "Latitude"
+// @Param lng query number true "Longitude"
+// @Param radius query number false "Radius in meters"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Param fields query string false "Comma-separated property fields to return, such as address,building.summary"
+// @Success 200 {object} models.NearbyPropertiesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /properties/nearby [get]
+func (h *PropertyHandler) FindNearby(c *gin.Context) {
+	lat, ok := parseCoordinate(c, "lat", -90, 90)
+	if !ok {
+		return
+	}
+	lng, ok := parseCoordinate(c, "lng", -180, 180)
+	if !ok {
+		return
+	}
+
+	radius := defaultNearbyRadiusMeters
+	if raw := c.Query("radius"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value <= 0 || value > maxNearbyRadiusMeters {
+			appErr := errors.NewAppError(
+				"invalid radius parameter",
+				"Radius must be between 1 and 50000 meters",
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				err,
+			)
+			logger.GlobalLogger.Errorf("Invalid radius: value=%s", raw)
+			c.Error(appErr)
+			return
+		}
+		radius = value
+	}
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.searchService.FindNearby(c, lat, lng, radius, offset, limit, pageURL(c, "/api/properties/nearby"), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "find nearby properties",
+			"lat", lat,
+			"lng", lng,
+			"radius", radius))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	if len(fields) == 0 {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	data := make([]interface{}, 0, len(response.Data))
+	for i := range response.Data {
+		projected, err := fields.Project(&response.Data[i])
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		data = append(data, projected)
+	}
+	c.JSON(http.StatusOK, gin.H{"query": response.Query, "data": data, "metadata": response.Metadata})
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// GetPreferences godoc
+// @Summary Get notification preferences
+// @Description Get the current user's notification preferences
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.NotificationPreference
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /users/me/notification-preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	pref, err := h.notificationService.GetPreferences(c, userID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get notification preferences", "user_id", userID))
+		return
+	}
+	c.JSON(http.StatusOK, pref)
+}
+
+// UpdatePreferences godoc
+// @Summary Update notification preferences
+// @Description Change the current user's notification channels and digest frequency
+// @Tags Notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.UpdateNotificationPreferenceRequest true "Preferences"
+// @Success 200 {object} models.NotificationPreference
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /users/me/notification-preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req models.UpdateNotificationPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			"Digest frequency must be one of: instant, hourly, daily",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid notification preference request: user_id=%s, error=%v", userID, err)
+		c.Error(appErr)
+		return
+	}
+
+	pref, err := h.notificationService.UpdatePreferences(c, userID, &req)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "update notification preferences", "user_id", userID))
+		return
+	}
+	c.JSON(http.StatusOK, pref)
+}
+
+// ListNotifications returns the user's in-app notifications, newest first. ?unread=true lists only
+// unread ones.
+// @Summary List notifications
+// @Description List the current user's in-app notifications, newest first
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread notifications"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.NotificationsResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /users/me/notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	unreadOnly := false
+	if raw := c.Query("unread"); raw != "" {
+		var err error
+		if unreadOnly, err = strconv.ParseBool(raw); err != nil {
+			appErr := errors.NewAppError(
+				"invalid unread parameter",
+				"unread must be true or false",
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				err,
+			)
+			logger.GlobalLogger.Errorf("Invalid unread: value=%s, error=%v", raw, err)
+			c.Error(appErr)
+			return
+		}
+	}
+
+	response, err := h.notificationService.ListNotifications(c, userID, unreadOnly, offset, limit)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list notifications", "user_id", userID))
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// MarkRead godoc
+// @Summary Mark a notification read
+// @Description Mark one of the current user's notifications read
+// @Tags Notifications
+// @Security BearerAuth
+// @Param notificationId path string true "Notification ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /users/me/notifications/{notificationId}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID := c.GetString("user_id")
+	id := c.Param("notificationId")
+
+	if err := h.notificationService.MarkRead(c, userID, id); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "mark notification read", "user_id", userID, "id", id))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications read
+// @Description Mark all of the current user's notifications read
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{updated=int}
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /users/me/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	updated, err := h.notificationService.MarkAllRead(c, userID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "mark all notifications read", "user_id", userID))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	organizationService *services.OrganizationService
+}
+
+func NewOrganizationHandler(organizationService *services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+	}
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Create an organization. Admin only
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateOrganizationRequest true "Organization"
+// @Success 201 {object} models.Organization
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req models.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid organization request: user_id=%s, error=%v", userID, err)
+		c.Error(appErr)
+		return
+	}
+
+	org, err := h.organizationService.Create(c, &req, userID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "create organization", "slug", req.Slug))
+		return
+	}
+	c.JSON(http.StatusCreated, org)
+}
+
+// ListOrganizations godoc
+// @Summary List organizations
+// @Description Page through all organizations. Admin only
+// @Tags Organizations
+// @Produce json
+// @Security BearerAuth
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.OrganizationsResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	orgs, total, err := h.organizationService.List(c, offset, limit)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list organizations", "offset", offset, "limit", limit))
+		return
+	}
+	c.JSON(http.StatusOK, models.OrganizationsResponse{
+		Data:     orgs,
+		Metadata: models.PaginationMeta{Total: total, Offset: offset, Limit: limit},
+	})
+}
+
+// GetCurrentOrganization returns the organization of the signed-in user.
+// @Summary Get the current organization
+// @Description Get the organization of the signed-in user
+// @Tags Organizations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.Organization
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /organizations/current [get]
+func (h *OrganizationHandler) GetCurrentOrganization(c *gin.Context) {
+	org, err := h.organizationService.Current(c)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get current organization", "userID", c.GetString("user_id")))
+		return
+	}
+	c.JSON(http.StatusOK, org)
+}
+
+// ListMembers godoc
+// @Summary List members
+// @Description Page through the members of an organization
+// @Tags Organizations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.MembersResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /organizations/{id}/members [get]
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID := c.Param("id")
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	members, total, err := h.organizationService.ListMembers(c, orgID, c.GetString("user_id"), c.GetString("role"), offset, limit)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list organization members",
+			"orgID", orgID,
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	c.JSON(http.StatusOK, models.MembersResponse{
+		Data:     members,
+		Metadata: models.PaginationMeta{Total: total, Offset: offset, Limit: limit},
+	})
+}
+
+// AddMember places a registered user in the organization. The user sees the organization's data
+// once their access token is refreshed.
+// @Summary Add a member
+// @Description Place a registered user in the organization; they see its data once their access token is refreshed. Users in another organization than the default one can only be moved by platform admins
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param request body models.AddMemberRequest true "Member"
+// @Success 201 {object} models.Member
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /organizations/{id}/members [post]
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	orgID := c.Param("id")
+	userID := c.GetString("user_id")
+
+	var req models.AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid member request: user_id=%s, error=%v", userID, err)
+		c.Error(appErr)
+		return
+	}
+
+	member, err := h.organizationService.AddMember(c, orgID, userID, c.GetString("role"), &req)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "add organization member", "orgID", orgID))
+		return
+	}
+	c.JSON(http.StatusCreated, member)
+}
+
+// RemoveMember godoc
+// @Summary Remove a member
+// @Description Remove a user from the organization
+// @Tags Organizations
+// @Security BearerAuth
+// @Param id path string true "Organization ID"
+// @Param userId path string true "User ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /organizations/{id}/members/{userId} [delete]
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	orgID := c.Param("id")
+	memberID := c.Param("userId")
+
+	if err := h.organizationService.RemoveMember(c, orgID, memberID, c.GetString("user_id"), c.GetString("role")); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "remove organization member", "orgID", orgID, "userID", memberID))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
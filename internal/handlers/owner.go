@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OwnerHandler struct {
+	ownerService *services.OwnerService
+}
+
+func NewOwnerHandler(ownerService *services.OwnerService) *OwnerHandler {
+	return &OwnerHandler{
+		ownerService: ownerService,
+	}
+}
+
+// GetPortfolio godoc
+// @Summary Get owner portfolio
+// @Description Page through the properties held by an owner entity
+// @Tags Owners
+// @Produce json
+// @Security BearerAuth
+// @Param entityId path string true "Owner entity ID"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.OwnerPortfolioResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /owners/{entityId}/portfolio [get]
+func (h *OwnerHandler) GetPortfolio(c *gin.Context) {
+	entityID := c.Param("entityId")
+	if entityID == "" {
+		appErr := errors.NewAppError(
+			"entityId parameter missing",
+			"Owner ID is required",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Missing entityId parameter: path=%s", c.Request.URL.Path)
+		c.Error(appErr)
+		return
+	}
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.ownerService.GetPortfolio(c, entityID, offset, limit, pageURL(c, c.Request.URL.Path), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get owner portfolio", "entityId", entityID))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
+
+// SearchByOwnerName pages through the properties of the owners whose name starts with ?name=, such
+// as "SMITH JOHN", for title research. Owner names are PII, so only privileged roles may search them.
+// @Summary Search properties by owner name
+// @Description Page through the properties of the owners whose name starts with ?name=. Privileged roles only
+// @Tags Owners
+// @Produce json
+// @Security BearerAuth
+// @Param name query string true "Owner name prefix, such as SMITH JOHN"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.OwnerSearchResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /properties/by-owner [get]
+func (h *OwnerHandler) SearchByOwnerName(c *gin.Context) {
+	name := c.Query("name")
+	if strings.TrimSpace(name) == "" {
+		appErr := errors.NewAppError(
+			"name parameter missing",
+			"Owner name is required",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Missing name parameter: path=%s", c.Request.URL.Path)
+		c.Error(appErr)
+		return
+	}
+	if len(name) > 100 {
+		appErr := errors.NewAppError(
+			"name parameter too long",
+			"Owner name exceeds maximum length of 100 characters",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Owner name too long: length=%d", len(name))
+		c.Error(appErr)
+		return
+	}
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.ownerService.SearchByOwnerName(c, name, offset, limit, pageURL(c, c.Request.URL.Path), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "search by owner name", "offset", offset, "limit", limit))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRelatedProperties godoc
+// @Summary Get related properties
+// @Description List the other properties held by the owner of a property
+// @Tags Owners
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param by query string false "Relation" Enums(owner) default(owner)
+// @Success 200 {object} models.RelatedPropertiesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/related [get]
+func (h *OwnerHandler) GetRelatedProperties(c *gin.Context) {
+	id := c.Param("id")
+	by := c.DefaultQuery("by", "owner")
+	if by != "owner" {
+		appErr := errors.NewAppError(
+			"unsupported related lookup: by="+by,
+			"Related properties can only be looked up by owner",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Unsupported related lookup: id=%s, by=%s", id, by)
+		c.Error(appErr)
+		return
+	}
+
+	response, err := h.ownerService.GetRelatedProperties(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get related properties", "id", id, "by", by))
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
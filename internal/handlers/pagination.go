@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePagination reads and validates offset/limit query parameters, recording an
+// AppError on the context and returning ok=false when either is invalid.
+func parsePagination(c *gin.Context) (offset, limit int, ok bool) {
+	offsetStr := c.DefaultQuery("offset", "0")
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		appErr := errors.NewAppError(
+			"invalid offset parameter",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid offset: value=%s, error=%v", offsetStr, appErr.TechnicalMessage)
+		c.Error(appErr)
+		return 0, 0, false
+	}
+
+	limit, ok = parseLimit(c)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return offset, limit, true
+}
+
+// parseLimit reads and validates the limit query parameter on its own, for cursor pagination.
+func parseLimit(c *gin.Context) (int, bool) {
+	limitStr := c.DefaultQuery("limit", "10")
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 || limit > 100 {
+		appErr := errors.NewAppError(
+			"invalid limit parameter",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid limit: value=%s, error=%v", limitStr, appErr.TechnicalMessage)
+		c.Error(appErr)
+		return 0, false
+	}
+	return limit, true
+}
+
+// pageURL makes an API path absolute, for pagination links clients can follow as they are.
+func pageURL(c *gin.Context, path string) string {
+	return c.GetString("base_url") + path
+}
+
+// setPaginationLinks sets an RFC 5988 Link header to the next and previous pages in meta, for
+// clients that follow links rather than read the response's metadata.
+func setPaginationLinks(c *gin.Context, meta models.PaginationMeta) {
+	var links []string
+	if meta.Next != nil {
+		links = append(links, "<"+*meta.Next+`>; rel="next"`)
+	}
+	if meta.Prev != nil {
+		links = append(links, "<"+*meta.Prev+`>; rel="prev"`)
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
@@ -4,6 +4,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"homeinsight-properties/internal/errors"
 	"homeinsight-properties/internal/models"
@@ -14,60 +15,143 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// MatchConfidenceHeader reports how closely the address of a searched property matches the query,
+// from 1 (the same spelling) down; below 0.95 the property was found by a fuzzy match.
+const MatchConfidenceHeader = "X-Match-Confidence"
+
 type PropertyHandler struct {
 	propertyService *services.PropertyService
 	searchService   *services.PropertySearchService
+	listingService  *services.ListingService
+	includeService  *services.PropertyIncludeService
 }
 
-func NewPropertyHandler(propertyService *services.PropertyService, searchService *services.PropertySearchService) *PropertyHandler {
+func NewPropertyHandler(propertyService *services.PropertyService, searchService *services.PropertySearchService, listingService *services.ListingService, includeService *services.PropertyIncludeService) *PropertyHandler {
 	return &PropertyHandler{
 		propertyService: propertyService,
 		searchService:   searchService,
+		listingService:  listingService,
+		includeService:  includeService,
 	}
 }
 
+// GetProperties godoc
+// @Summary List properties
+// @Description Page through properties matching the filters, by offset or, with ?cursor=, by cursor in street address order. Sort can't be combined with a cursor
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Param cursor query string false "Cursor from the previous page; an empty cursor starts cursor pagination"
+// @Param sort query string false "Comma-separated field:asc or field:desc, such as yearBuilt:desc"
+// @Param fields query string false "Comma-separated property fields to return, such as address,building.summary"
+// @Param filter query models.PropertyFilter false "Property filters"
+// @Success 200 {object} models.PaginatedPropertiesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /properties [get]
 func (h *PropertyHandler) GetProperties(c *gin.Context) {
-	offsetStr := c.DefaultQuery("offset", "0")
-	limitStr := c.DefaultQuery("limit", "10")
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
 
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil || offset < 0 {
+	var filter models.PropertyFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
 		appErr := errors.NewAppError(
-			"invalid offset parameter",
+			"invalid filter parameters",
 			errors.MsgInvalidParameters,
 			errors.ErrCodeInvalidParameters,
 			http.StatusBadRequest,
 			err,
 		)
-		logger.GlobalLogger.Errorf("Invalid offset: value=%s, error=%v", offsetStr, appErr.TechnicalMessage)
+		logger.GlobalLogger.Errorf("Invalid property filter: query=%s, error=%v", c.Request.URL.RawQuery, err)
 		c.Error(appErr)
 		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 || limit > 100 {
+	// Presence of ?cursor= (even empty, for the first page) switches to cursor pagination, which always
+	// runs in street address order, so a sort is refused rather than silently ignored
+	if cursor, cursorMode := c.GetQuery("cursor"); cursorMode {
+		if sort := c.Query("sort"); sort != "" {
+			appErr := errors.NewAppError(
+				"sort is not supported with cursor pagination",
+				"Sort can't be combined with cursor; use offset pagination to sort",
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				nil,
+			)
+			logger.GlobalLogger.Errorf("Invalid sort with cursor: value=%s", sort)
+			c.Error(appErr)
+			return
+		}
+		limit, ok := parseLimit(c)
+		if !ok {
+			return
+		}
+		response, err := h.searchService.ListPropertiesByCursor(c, &filter, cursor, fields, limit, pageURL(c, "/api/properties"), c.Request.URL.Query())
+		if err != nil {
+			c.Error(utils.LogAndMapError(c, err, "get properties",
+				"cursor", cursor,
+				"limit", limit,
+				"filter", filter.String()))
+			return
+		}
+		writeProperties(c, fields, response)
+		return
+	}
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	sort, err := models.ParsePropertySort(c.Query("sort"))
+	if err != nil {
 		appErr := errors.NewAppError(
-			"invalid limit parameter",
-			errors.MsgInvalidParameters,
+			err.Error(),
+			"Sort must be a comma-separated list of field:asc or field:desc using sortable fields",
 			errors.ErrCodeInvalidParameters,
 			http.StatusBadRequest,
 			err,
 		)
-		logger.GlobalLogger.Errorf("Invalid limit: value=%s, error=%v", limitStr, appErr.TechnicalMessage)
+		logger.GlobalLogger.Errorf("Invalid sort: value=%s, error=%v", c.Query("sort"), err)
 		c.Error(appErr)
 		return
 	}
 
-	response, err := h.searchService.ListProperties(c, offset, limit, "/api/properties", c.Request.URL.Query())
+	response, err := h.searchService.ListProperties(c, &filter, sort, fields, offset, limit, pageURL(c, "/api/properties"), c.Request.URL.Query())
 	if err != nil {
 		c.Error(utils.LogAndMapError(c, err, "get properties",
 			"offset", offset,
-			"limit", limit))
+			"limit", limit,
+			"filter", filter.String(),
+			"sort", sort.String()))
 		return
 	}
-	c.JSON(http.StatusOK, response)
+	writeProperties(c, fields, response)
 }
 
+// SearchProperty godoc
+// @Summary Search a property by address
+// @Description Find the property at an address, fetching it from the data provider when it isn't stored yet. With ?multi=true, every property the address could mean is returned, best match first
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Address to search for"
+// @Param multi query bool false "Return every matching property"
+// @Param limit query int false "Number of matches with multi, up to 100" default(10)
+// @Param fields query string false "Comma-separated property fields to return, such as address,building.summary"
+// @Param include query string false "Comma-separated related resources to embed: listing, media, transactions, valuation"
+// @Success 200 {object} models.Property
+// @Header 200 {string} X-Match-Confidence "How closely the address matched, from 1 down"
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /properties/property-search [get]
 func (h *PropertyHandler) SearchProperty(c *gin.Context) {
 	query := c.Query("q")
 	if query == "" {
@@ -95,15 +179,158 @@ func (h *PropertyHandler) SearchProperty(c *gin.Context) {
 		return
 	}
 
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+	include, ok := parseInclude(c)
+	if !ok {
+		return
+	}
+
+	multi := false
+	if raw := c.Query("multi"); raw != "" {
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			appErr := errors.NewAppError(
+				"invalid multi parameter",
+				"Multi must be true or false",
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				err,
+			)
+			logger.GlobalLogger.Errorf("Invalid multi: value=%s", raw)
+			c.Error(appErr)
+			return
+		}
+		multi = value
+	}
+
 	req := &models.SearchRequest{Search: query}
+	if multi {
+		h.searchMatchingProperties(c, req, fields, include)
+		return
+	}
 	property, err := h.searchService.SearchSpecificProperty(c, req)
 	if err != nil {
 		c.Error(utils.LogAndMapError(c, err, "search specific property", "query", query))
 		return
 	}
-	c.JSON(http.StatusOK, property)
+	if confidence, ok := c.Get("match_confidence"); ok {
+		c.Header(MatchConfidenceHeader, strconv.FormatFloat(confidence.(float64), 'f', 2, 64))
+	}
+	h.includeService.Attach(c, property, include)
+	writeProperty(c, fields, property)
+}
+
+// searchMatchingProperties answers a property search with ?multi=true: every property the address
+// could mean, best match first, each with its matchConfidence.
+func (h *PropertyHandler) searchMatchingProperties(c *gin.Context, req *models.SearchRequest, fields models.PropertyFields, include map[string]bool) {
+	limit, ok := parseLimit(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.searchService.SearchMatchingProperties(c, req, limit)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "search matching properties", "query", req.Search))
+		return
+	}
+	for i := range response.Data {
+		h.includeService.Attach(c, &response.Data[i].Property, include)
+	}
+	if len(fields) == 0 {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	data := make([]interface{}, 0, len(response.Data))
+	for i := range response.Data {
+		projected, err := fields.Project(&response.Data[i])
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		data = append(data, projected)
+	}
+	c.JSON(http.StatusOK, gin.H{"query": response.Query, "data": data, "metadata": response.Metadata})
+}
+
+// FullTextSearch godoc
+// @Summary Full-text search properties
+// @Description Search properties by free text across address, owner, subdivision and school district
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text, up to 200 characters"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Param fields query string false "Comma-separated property fields to return, such as address,building.summary"
+// @Success 200 {object} models.PaginatedPropertiesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /properties/search [get]
+func (h *PropertyHandler) FullTextSearch(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		appErr := errors.NewAppError(
+			"query parameter missing",
+			"Search query is required",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Missing query parameter: path=%s", c.Request.URL.Path)
+		c.Error(appErr)
+		return
+	}
+	if len(query) > 200 {
+		appErr := errors.NewAppError(
+			"query parameter too long",
+			"Search query exceeds maximum length of 200 characters",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Query too long: query=%s", query)
+		c.Error(appErr)
+		return
+	}
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.searchService.FullTextSearch(c, query, offset, limit, pageURL(c, "/api/properties/search"), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "full-text search",
+			"query", query,
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	writeProperties(c, fields, response)
 }
 
+// GetPropertyByID godoc
+// @Summary Get property by ID
+// @Description Get a single property by its ID, with its media
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param fields query string false "Comma-separated property fields to return, such as address,building.summary"
+// @Param include query string false "Comma-separated related resources to embed: listing, media, transactions, valuation"
+// @Success 200 {object} models.Property
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/property-detail/{id} [get]
 func (h *PropertyHandler) GetPropertyByID(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -119,14 +346,41 @@ func (h *PropertyHandler) GetPropertyByID(c *gin.Context) {
 		return
 	}
 
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+	include, ok := parseInclude(c)
+	if !ok {
+		return
+	}
+
 	property, err := h.propertyService.GetPropertyByID(c, id)
 	if err != nil {
 		c.Error(utils.LogAndMapError(c, err, "get property by ID", "id", id))
 		return
 	}
-	c.JSON(http.StatusOK, property)
+	// A single property comes with its media whether or not they were asked for
+	include[services.IncludeMedia] = true
+	h.includeService.Attach(c, property, include)
+	writeProperty(c, fields, property)
 }
 
+// CreateProperty godoc
+// @Summary Create a new property
+// @Description Create a new property record. Send an Idempotency-Key header to make retries safe
+// @Tags Properties
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Param property body models.Property true "Property data"
+// @Success 201 {object} models.Property
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /properties [post]
 func (h *PropertyHandler) CreateProperty(c *gin.Context) {
 	var property models.Property
 	if err := c.ShouldBindJSON(&property); err != nil {
@@ -142,6 +396,16 @@ func (h *PropertyHandler) CreateProperty(c *gin.Context) {
 		return
 	}
 
+	// A property sent with a listing is created together with it
+	if property.Listing != nil {
+		if _, err := h.listingService.CreatePropertyWithListing(c, &property, c.GetString("user_id")); err != nil {
+			c.Error(utils.LogAndMapError(c, err, "create property with listing"))
+			return
+		}
+		c.JSON(http.StatusCreated, property)
+		return
+	}
+
 	if err := h.propertyService.CreateProperty(c, &property); err != nil {
 		c.Error(utils.LogAndMapError(c, err, "create property"))
 		return
@@ -149,6 +413,61 @@ func (h *PropertyHandler) CreateProperty(c *gin.Context) {
 	c.JSON(http.StatusCreated, property)
 }
 
+// ImportProperties queues properties to be created in bulk and returns the job to poll for the outcome.
+// @Summary Import properties
+// @Description Queue properties to be created in bulk; poll the returned job for the outcome. Admin only
+// @Tags Properties
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Param request body models.ImportPropertiesRequest true "Properties to import"
+// @Success 202 {object} jobs.Job
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /properties/import [post]
+func (h *PropertyHandler) ImportProperties(c *gin.Context) {
+	var req models.ImportPropertiesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			"The provided property data is invalid",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid property import: error=%v", err)
+		c.Error(appErr)
+		return
+	}
+
+	job, err := h.propertyService.ImportProperties(c, &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "import properties", "count", len(req.Properties)))
+		return
+	}
+	c.Header("Location", "/api/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// UpdateProperty godoc
+// @Summary Replace a property
+// @Description Replace a whole property record
+// @Tags Properties
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param property body models.Property true "Property data"
+// @Success 200 {object} models.Property
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /properties/property-detail/{id} [put]
 func (h *PropertyHandler) UpdateProperty(c *gin.Context) {
 	var property models.Property
 	if err := c.ShouldBindJSON(&property); err != nil {
@@ -171,6 +490,71 @@ func (h *PropertyHandler) UpdateProperty(c *gin.Context) {
 	c.JSON(http.StatusOK, property)
 }
 
+// PatchProperty applies a JSON merge patch (RFC 7386) so clients can change individual fields without
+// sending, and possibly overwriting, the whole document.
+// @Summary Patch a property
+// @Description Change individual fields of a property with a JSON merge patch (RFC 7386); null removes a field
+// @Tags Properties
+// @Accept application/merge-patch+json,json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param patch body object true "Merge patch"
+// @Success 200 {object} models.Property
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 415 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /properties/{id} [patch]
+func (h *PropertyHandler) PatchProperty(c *gin.Context) {
+	id := c.Param("id")
+	if contentType := c.ContentType(); contentType != "application/merge-patch+json" && contentType != "application/json" {
+		appErr := errors.NewAppError(
+			"unsupported patch content type: "+contentType,
+			"PATCH requests must use Content-Type application/merge-patch+json",
+			errors.ErrCodeInvalidParameters,
+			http.StatusUnsupportedMediaType,
+			nil,
+		)
+		logger.GlobalLogger.Errorf("Unsupported patch content type: id=%s, contentType=%s", id, contentType)
+		c.Error(appErr)
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		appErr := errors.NewAppError(
+			"failed to read request body",
+			"The provided property data is invalid",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Failed to read patch body: id=%s, error=%v", id, err)
+		c.Error(appErr)
+		return
+	}
+
+	property, err := h.propertyService.PatchProperty(c, id, body)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "patch property", "id", id))
+		return
+	}
+	c.JSON(http.StatusOK, property)
+}
+
+// DeleteProperty godoc
+// @Summary Delete a property
+// @Description Move a property to the trash, from which it can be restored
+// @Tags Properties
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 204
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/property-detail/{id} [delete]
 func (h *PropertyHandler) DeleteProperty(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
@@ -192,3 +576,115 @@ func (h *PropertyHandler) DeleteProperty(c *gin.Context) {
 	}
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// RestoreProperty brings a deleted property back out of the trash.
+// @Summary Restore a property
+// @Description Bring a deleted property back out of the trash
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 200 {object} models.Property
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/restore [post]
+func (h *PropertyHandler) RestoreProperty(c *gin.Context) {
+	id := c.Param("id")
+	property, err := h.propertyService.RestoreProperty(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "restore property", "id", id))
+		return
+	}
+	c.JSON(http.StatusOK, property)
+}
+
+// GetTaxHistory returns the yearly tax assessments of a property, oldest year first.
+// @Summary Get tax history
+// @Description List the yearly tax assessments of a property, oldest year first
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 200 {object} object{propertyId=string,data=[]models.TaxAssessment}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/tax-history [get]
+func (h *PropertyHandler) GetTaxHistory(c *gin.Context) {
+	id := c.Param("id")
+	timeline, err := h.propertyService.GetTaxHistory(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get tax history", "id", id))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"propertyId": id, "data": timeline})
+}
+
+// ListTrash lists deleted properties that can still be restored.
+// @Summary List deleted properties
+// @Description List deleted properties that can still be restored
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.PaginatedPropertiesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/trash [get]
+func (h *PropertyHandler) ListTrash(c *gin.Context) {
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.propertyService.ListTrash(c, offset, limit, pageURL(c, "/api/admin/trash"), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list trash",
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDataQuality reports the data quality of the organization's properties: the average completeness
+// score and how many properties have each issue.
+// @Summary Get data quality report
+// @Description Report the average completeness score of the organization's properties and how many have each issue
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.DataQualityReport
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/data-quality [get]
+func (h *PropertyHandler) GetDataQuality(c *gin.Context) {
+	report, err := h.propertyService.DataQualityReport(c)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get data quality report"))
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// PurgeProperty permanently removes a property from the trash.
+// @Summary Purge a deleted property
+// @Description Permanently remove a property from the trash
+// @Tags Admin
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /admin/trash/{id} [delete]
+func (h *PropertyHandler) PurgeProperty(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.propertyService.PurgeProperty(c, id); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "purge property", "id", id))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
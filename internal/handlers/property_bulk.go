@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkDelete moves every property matching a filter to the trash in the background and returns the
+// job to poll, or with "dryRun" only reports how many properties would go.
+// @Summary Bulk delete properties
+// @Description Move every property matching a filter to the trash in the background and return the job to poll, or with dryRun report how many would go. Admin only
+// @Tags Properties
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Param request body models.BulkDeleteRequest true "Filter and options"
+// @Success 200 {object} models.BulkPreview
+// @Success 202 {object} jobs.Job
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /properties/bulk-delete [post]
+func (h *PropertyHandler) BulkDelete(c *gin.Context) {
+	var req models.BulkDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			"The bulk delete request is invalid",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid bulk delete: error=%v", err)
+		c.Error(appErr)
+		return
+	}
+
+	if req.DryRun {
+		preview, err := h.propertyService.PreviewBulkDelete(c, &req)
+		if err != nil {
+			c.Error(utils.LogAndMapError(c, err, "preview bulk delete", "filter", req.Filter.String()))
+			return
+		}
+		c.JSON(http.StatusOK, preview)
+		return
+	}
+
+	job, err := h.propertyService.BulkDelete(c, &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "bulk delete", "filter", req.Filter.String()))
+		return
+	}
+	c.Header("Location", "/api/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// BulkUpdate writes the field mask of an update to every property matching a filter in the
+// background and returns the job to poll, or with "dryRun" only reports how many properties would
+// change.
+// @Summary Bulk update properties
+// @Description Write the field mask of an update to every property matching a filter in the background and return the job to poll, or with dryRun report how many would change. Admin only
+// @Tags Properties
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Idempotency-Key header string false "Key that makes retries of this request safe"
+// @Param request body models.BulkUpdateRequest true "Filter, update and field mask"
+// @Success 200 {object} models.BulkPreview
+// @Success 202 {object} jobs.Job
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /properties/bulk-update [post]
+func (h *PropertyHandler) BulkUpdate(c *gin.Context) {
+	var req models.BulkUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			"The bulk update request is invalid",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid bulk update: error=%v", err)
+		c.Error(appErr)
+		return
+	}
+
+	if req.DryRun {
+		preview, err := h.propertyService.PreviewBulkUpdate(c, &req)
+		if err != nil {
+			c.Error(utils.LogAndMapError(c, err, "preview bulk update", "filter", req.Filter.String()))
+			return
+		}
+		c.JSON(http.StatusOK, preview)
+		return
+	}
+
+	job, err := h.propertyService.BulkUpdate(c, &req, c.GetString("user_id"), c.GetString("role"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "bulk update", "filter", req.Filter.String()))
+		return
+	}
+	c.Header("Location", "/api/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
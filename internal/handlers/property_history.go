@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PropertyHistoryHandler struct {
+	auditService *services.PropertyAuditService
+	diffService  *services.PropertyDiffService
+}
+
+func NewPropertyHistoryHandler(auditService *services.PropertyAuditService, diffService *services.PropertyDiffService) *PropertyHistoryHandler {
+	return &PropertyHistoryHandler{
+		auditService: auditService,
+		diffService:  diffService,
+	}
+}
+
+// GetHistory lists who changed a property, when, and which fields changed, newest first.
+// @Summary Get property change history
+// @Description List who changed a property, when, and which fields changed, newest first
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.PropertyHistoryResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/history [get]
+func (h *PropertyHistoryHandler) GetHistory(c *gin.Context) {
+	id := c.Param("id")
+	c.Set("property_id", id)
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.auditService.History(c, id, offset, limit, pageURL(c, c.Request.URL.Path), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get property history",
+			"propertyID", id,
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetChanges lists what each refresh from a data provider changed in a property, newest first.
+// @Summary Get provider refresh changes
+// @Description List what each refresh from a data provider changed in a property, newest first
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.PropertyDiffsResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/changes [get]
+func (h *PropertyHistoryHandler) GetChanges(c *gin.Context) {
+	id := c.Param("id")
+	c.Set("property_id", id)
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.diffService.Diffs(c, id, offset, limit, pageURL(c, c.Request.URL.Path), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get property changes",
+			"propertyID", id,
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead allows for the form fields and boundaries around an uploaded file.
+const multipartOverhead = 1 << 20
+
+type PropertyMediaHandler struct {
+	mediaService *services.PropertyMediaService
+	maxUploadMB  int
+}
+
+func NewPropertyMediaHandler(mediaService *services.PropertyMediaService, maxUploadMB int) *PropertyMediaHandler {
+	return &PropertyMediaHandler{
+		mediaService: mediaService,
+		maxUploadMB:  maxUploadMB,
+	}
+}
+
+// UploadPhoto stores an image sent as the "file" field of a multipart form, with an optional "caption".
+// @Summary Upload a photo
+// @Description Store an image for a property
+// @Tags Media
+// @Accept mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param file formData file true "File to upload"
+// @Param caption formData string false "Caption"
+// @Success 201 {object} models.PropertyMedia
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 413 {object} errors.ErrorResponse
+// @Router /properties/{id}/photos [post]
+func (h *PropertyMediaHandler) UploadPhoto(c *gin.Context) {
+	h.upload(c, models.MediaKindPhoto)
+}
+
+// UploadDocument stores a document sent as the "file" field of a multipart form, with an optional "caption".
+// @Summary Upload a document
+// @Description Store a document, such as an inspection report, for a property
+// @Tags Media
+// @Accept mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param file formData file true "File to upload"
+// @Param caption formData string false "Caption"
+// @Success 201 {object} models.PropertyMedia
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 413 {object} errors.ErrorResponse
+// @Router /properties/{id}/documents [post]
+func (h *PropertyMediaHandler) UploadDocument(c *gin.Context) {
+	h.upload(c, models.MediaKindDocument)
+}
+
+func (h *PropertyMediaHandler) upload(c *gin.Context, kind string) {
+	id := c.Param("id")
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, int64(h.maxUploadMB)<<20+multipartOverhead)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		status := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		appErr := errors.NewAppError(
+			"invalid upload",
+			"A file is required in the \"file\" field of a multipart form",
+			errors.ErrCodeInvalidParameters,
+			status,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid media upload: id=%s, error=%v", id, err)
+		c.Error(appErr)
+		return
+	}
+
+	media, err := h.mediaService.Upload(c, id, kind, file, c.PostForm("caption"), c.GetString("user_id"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "upload property media", "id", id, "kind", kind))
+		return
+	}
+	c.JSON(http.StatusCreated, media)
+}
+
+// DeleteMedia godoc
+// @Summary Delete a photo or document
+// @Description Delete a photo or document of a property
+// @Tags Media
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param mediaId path string true "Media ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/media/{mediaId} [delete]
+func (h *PropertyMediaHandler) DeleteMedia(c *gin.Context) {
+	id := c.Param("id")
+	mediaID := c.Param("mediaId")
+
+	if err := h.mediaService.Delete(c, id, mediaID); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "delete property media", "id", id, "mediaId", mediaID))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
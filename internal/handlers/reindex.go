@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReindexHandler struct {
+	reindexService *services.ReindexService
+}
+
+func NewReindexHandler(reindexService *services.ReindexService) *ReindexHandler {
+	return &ReindexHandler{
+		reindexService: reindexService,
+	}
+}
+
+// StartReindex queues a background index build and returns the job to poll.
+// @Summary Start an index build
+// @Description Queue a background index build and return the job to poll
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.ReindexRequest true "Index definition"
+// @Success 202 {object} models.ReindexJob
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /admin/reindex [post]
+func (h *ReindexHandler) StartReindex(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req models.ReindexRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid reindex request: user_id=%s, error=%v", userID, err)
+		c.Error(appErr)
+		return
+	}
+
+	job, err := h.reindexService.StartReindex(c, &req, userID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "start reindex", "collection", req.Collection, "index", req.IndexName))
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ListJobs godoc
+// @Summary List index builds
+// @Description List the index build jobs, newest first
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{data=[]models.ReindexJob}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/reindex [get]
+func (h *ReindexHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.reindexService.ListJobs(c)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list reindex jobs"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": jobs})
+}
+
+// GetJob godoc
+// @Summary Get an index build
+// @Description Get an index build job with its progress
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param jobId path string true "Job ID"
+// @Success 200 {object} models.ReindexJob
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /admin/reindex/{jobId} [get]
+func (h *ReindexHandler) GetJob(c *gin.Context) {
+	jobID := c.Param("jobId")
+
+	job, err := h.reindexService.GetJob(c, jobID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get reindex job", "job_id", jobID))
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// EnsureIndexes queues a background build of the indexes the API relies on and returns the job to
+// poll. With ?rebuild=true, indexes whose definition changed are dropped and built again.
+// @Summary Ensure indexes
+// @Description Queue a background build of the indexes the API relies on and return the job to poll
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param rebuild query bool false "Drop and build again the indexes whose definition changed"
+// @Success 202 {object} jobs.Job
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/indexes [post]
+func (h *ReindexHandler) EnsureIndexes(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req models.EnsureIndexesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid query parameters",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid ensure indexes request: user_id=%s, error=%v", userID, err)
+		c.Error(appErr)
+		return
+	}
+
+	job, err := h.reindexService.EnsureIndexes(c, &req, userID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "ensure indexes", "rebuild", req.Rebuild))
+		return
+	}
+	c.Header("Location", "/api/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// ExplainQuery runs MongoDB's explain on a named property query (properties.list, properties.cursor
+// or properties.search). It takes the same filter, sort, q and limit parameters as the endpoint that
+// sends the query.
+// @Summary Explain a property query
+// @Description Run MongoDB's explain on a named property query with the same filter, sort, q and limit parameters as the endpoint that sends it
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param query path string true "Query name" Enums(properties.list, properties.cursor, properties.search)
+// @Param q query string false "Search text, for properties.search"
+// @Param sort query string false "Comma-separated field:asc or field:desc"
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Param filter query models.PropertyFilter false "Property filters"
+// @Success 200 {object} models.QueryExplanation
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /admin/explain/{query} [get]
+func (h *ReindexHandler) ExplainQuery(c *gin.Context) {
+	query := c.Param("query")
+	limit, ok := parseLimit(c)
+	if !ok {
+		return
+	}
+
+	var filter models.PropertyFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		appErr := errors.NewAppError(
+			"invalid filter parameters",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid explain filter: query=%s, error=%v", c.Request.URL.RawQuery, err)
+		c.Error(appErr)
+		return
+	}
+	sort, err := models.ParsePropertySort(c.Query("sort"))
+	if err != nil {
+		appErr := errors.NewAppError(
+			err.Error(),
+			"Sort must be a comma-separated list of field:asc or field:desc using sortable fields",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid explain sort: value=%s, error=%v", c.Query("sort"), err)
+		c.Error(appErr)
+		return
+	}
+
+	explanation, err := h.reindexService.Explain(c, query, &filter, sort, c.Query("q"), limit)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "explain query", "query", query, "filter", filter.String()))
+		return
+	}
+	c.JSON(http.StatusOK, explanation)
+}
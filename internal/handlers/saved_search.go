@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SavedSearchHandler struct {
+	savedSearchService *services.SavedSearchService
+}
+
+func NewSavedSearchHandler(savedSearchService *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+	}
+}
+
+// CreateSavedSearch godoc
+// @Summary Create a saved search
+// @Description Save a search; new properties matching it are reported as matches and notified
+// @Tags Saved Searches
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateSavedSearchRequest true "Search criteria"
+// @Success 201 {object} models.SavedSearch
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /saved-searches [post]
+func (h *SavedSearchHandler) CreateSavedSearch(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req models.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid saved search request: user_id=%s, error=%v", userID, err)
+		c.Error(appErr)
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSavedSearch(c, userID, &req)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "create saved search", "user_id", userID))
+		return
+	}
+	c.JSON(http.StatusCreated, search)
+}
+
+// ListSavedSearches godoc
+// @Summary List saved searches
+// @Description List the current user's saved searches
+// @Tags Saved Searches
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{data=[]models.SavedSearch}
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /saved-searches [get]
+func (h *SavedSearchHandler) ListSavedSearches(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	searches, err := h.savedSearchService.ListSavedSearches(c, userID)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list saved searches", "user_id", userID))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": searches})
+}
+
+// DeleteSavedSearch godoc
+// @Summary Delete a saved search
+// @Description Delete one of the current user's saved searches
+// @Tags Saved Searches
+// @Security BearerAuth
+// @Param id path string true "Saved search ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /saved-searches/{id} [delete]
+func (h *SavedSearchHandler) DeleteSavedSearch(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.savedSearchService.DeleteSavedSearch(c, id, c.GetString("user_id")); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "delete saved search", "id", id))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListMatches godoc
+// @Summary List saved search matches
+// @Description List the properties a saved search has matched, newest first
+// @Tags Saved Searches
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved search ID"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.SavedSearchMatchesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /saved-searches/{id}/matches [get]
+func (h *SavedSearchHandler) ListMatches(c *gin.Context) {
+	id := c.Param("id")
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	matches, total, err := h.savedSearchService.ListMatches(c, id, c.GetString("user_id"), offset, limit)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list saved search matches", "id", id))
+		return
+	}
+	c.JSON(http.StatusOK, models.SavedSearchMatchesResponse{
+		Data:     matches,
+		Metadata: models.PaginationMeta{Total: total, Offset: offset, Limit: limit},
+	})
+}
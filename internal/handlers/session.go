@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListSessions godoc
+// @Summary List signed-in devices
+// @Description List the current user's active sessions with device, IP and issued/last-used times; the session making the request is marked current
+// @Tags Authentication
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.SessionsResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /users/me/sessions [get]
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	response, err := h.userService.ListSessions(c, userID, c.GetString("session_id"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list sessions", "user_id", userID))
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeSession godoc
+// @Summary Sign out a device
+// @Description Sign out one of the current user's sessions; its refresh token stops working and its access tokens are rejected
+// @Tags Authentication
+// @Security BearerAuth
+// @Param sessionId path string true "Session ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /users/me/sessions/{sessionId} [delete]
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID := c.GetString("user_id")
+	id := c.Param("sessionId")
+
+	if err := h.userService.RevokeSession(c, userID, id); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "revoke session", "user_id", userID, "id", id))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareHandler struct {
+	shareService *services.ShareService
+}
+
+func NewShareHandler(shareService *services.ShareService) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+	}
+}
+
+// shareURL builds the absolute public URL for a share link token.
+func shareURL(c *gin.Context, token string) string {
+	return pageURL(c, "/api/shared/properties/"+token)
+}
+
+func (h *ShareHandler) toResponse(c *gin.Context, link models.ShareLink) models.ShareLinkResponse {
+	response := models.ShareLinkResponse{ShareLink: link}
+	if link.RevokedAt == nil {
+		response.URL = shareURL(c, h.shareService.Token(&link))
+	}
+	return response
+}
+
+// CreateShareLink godoc
+// @Summary Create a share link
+// @Description Create a public, expiring link to a read-only view of a property
+// @Tags Sharing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param request body models.CreateShareLinkRequest false "Link lifetime"
+// @Success 201 {object} models.ShareLinkResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/share [post]
+func (h *ShareHandler) CreateShareLink(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			"The provided share link options are invalid",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid share link request: id=%s, error=%v", id, err)
+		c.Error(appErr)
+		return
+	}
+
+	link, err := h.shareService.CreateShareLink(c, id, c.GetString("user_id"), req.ExpiresInHours)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "create share link", "id", id))
+		return
+	}
+	c.JSON(http.StatusCreated, h.toResponse(c, *link))
+}
+
+// ListShareLinks godoc
+// @Summary List share links
+// @Description List the current user's share links to a property
+// @Tags Sharing
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 200 {object} object{data=[]models.ShareLinkResponse}
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/share [get]
+func (h *ShareHandler) ListShareLinks(c *gin.Context) {
+	id := c.Param("id")
+
+	links, err := h.shareService.ListShareLinks(c, id, c.GetString("user_id"))
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list share links", "id", id))
+		return
+	}
+
+	response := make([]models.ShareLinkResponse, 0, len(links))
+	for _, link := range links {
+		response = append(response, h.toResponse(c, link))
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response})
+}
+
+// RevokeShareLink godoc
+// @Summary Revoke a share link
+// @Description Revoke a share link so it stops working
+// @Tags Sharing
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param linkId path string true "Share link ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/share/{linkId} [delete]
+func (h *ShareHandler) RevokeShareLink(c *gin.Context) {
+	id := c.Param("id")
+	linkID := c.Param("linkId")
+
+	if err := h.shareService.RevokeShareLink(c, id, linkID, c.GetString("user_id")); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "revoke share link", "id", id, "linkId", linkID))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetSharedProperty godoc
+// @Summary View a shared property
+// @Description Get the read-only view of a property behind a share link; no authentication is needed
+// @Tags Sharing
+// @Produce json
+// @Param token path string true "Share link token"
+// @Success 200 {object} models.SharedPropertyView
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 410 {object} errors.ErrorResponse
+// @Router /shared/properties/{token} [get]
+func (h *ShareHandler) GetSharedProperty(c *gin.Context) {
+	token := c.Param("token")
+
+	view, err := h.shareService.ResolveShareLink(c, token)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get shared property"))
+		return
+	}
+	c.Header("Cache-Control", "private, no-store")
+	c.JSON(http.StatusOK, view)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// StreamProperties writes every property matching the list filters as newline-delimited JSON, for
+// bulk consumers that would otherwise page through GET /api/properties. Each line is flushed as it is
+// written, so a slow reader holds the MongoDB cursor back rather than the server buffering results.
+// @Summary Stream properties
+// @Description Write every property matching the filters as newline-delimited JSON
+// @Tags Properties
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param fields query string false "Comma-separated property fields to return, such as address,building.summary"
+// @Param filter query models.PropertyFilter false "Property filters"
+// @Success 200 {object} models.Property
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /properties/stream [get]
+func (h *PropertyHandler) StreamProperties(c *gin.Context) {
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	var filter models.PropertyFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		appErr := errors.NewAppError(
+			"invalid filter parameters",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid property filter: query=%s, error=%v", c.Request.URL.RawQuery, err)
+		c.Error(appErr)
+		return
+	}
+
+	encoder := json.NewEncoder(c.Writer)
+	var writeErr error
+	err := h.searchService.StreamProperties(c, &filter, fields, func(property *models.Property) error {
+		if !c.Writer.Written() {
+			c.Header("Content-Type", ndjsonContentType)
+			c.Status(http.StatusOK)
+		}
+		var line interface{} = property
+		if len(fields) > 0 {
+			projected, err := fields.Project(property)
+			if err != nil {
+				return err
+			}
+			line = projected
+		}
+		if writeErr = encoder.Encode(line); writeErr != nil {
+			return writeErr
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	switch {
+	case writeErr != nil:
+		logger.GlobalLogger.Warnf("Property stream aborted by client: filter=%s, error=%v", filter.String(), writeErr)
+	case err != nil && !c.Writer.Written():
+		c.Error(utils.LogAndMapError(c, err, "stream properties", "filter", filter.String()))
+	case err != nil:
+		// Too late for an error status; end the stream with an error line instead
+		appErr := utils.LogAndMapError(c, err, "stream properties", "filter", filter.String())
+		encoder.Encode(errors.NewErrorResponse(appErr, requestid.FromContext(c)))
+	case !c.Writer.Written():
+		c.Header("Content-Type", ndjsonContentType)
+		c.Status(http.StatusOK)
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransactionHandler struct {
+	transactionService *services.TransactionService
+}
+
+func NewTransactionHandler(transactionService *services.TransactionService) *TransactionHandler {
+	return &TransactionHandler{
+		transactionService: transactionService,
+	}
+}
+
+// GetTransactions lists the recorded sales and transfers of a property, most recent first, optionally
+// limited to sale dates between ?from= and ?to= (YYYY-MM-DD).
+// @Summary Get property transactions
+// @Description List the recorded sales and transfers of a property, most recent first
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Param from query string false "Earliest sale date (YYYY-MM-DD)"
+// @Param to query string false "Latest sale date (YYYY-MM-DD)"
+// @Param offset query int false "Number of items to skip" default(0)
+// @Param limit query int false "Page size, up to 100" default(10)
+// @Success 200 {object} models.TransactionHistoryResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /properties/{id}/transactions [get]
+func (h *TransactionHandler) GetTransactions(c *gin.Context) {
+	id := c.Param("id")
+	c.Set("property_id", id)
+
+	offset, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	filter := models.TransactionFilter{From: c.Query("from"), To: c.Query("to")}
+
+	response, err := h.transactionService.History(c, id, filter, offset, limit, pageURL(c, c.Request.URL.Path), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get property transactions",
+			"propertyID", id,
+			"offset", offset,
+			"limit", limit))
+		return
+	}
+	setPaginationLinks(c, response.Metadata)
+	c.JSON(http.StatusOK, response)
+}
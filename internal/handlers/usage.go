@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UsageHandler struct {
+	usageService *services.UsageService
+}
+
+func NewUsageHandler(usageService *services.UsageService) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+	}
+}
+
+// GetUsage exports daily request and CoreLogic fetch counts per organization and API key between
+// ?from= and ?to= (YYYY-MM-DD), optionally for one ?subjectType= and ?subject=. With ?format=csv the
+// records are returned as a CSV file for billing.
+// @Summary Export usage
+// @Description Export daily request and CoreLogic fetch counts per organization and API key, as JSON or, with ?format=csv, a CSV file
+// @Tags Admin
+// @Produce json,text/csv
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param subjectType query string false "Kind of subject" Enums(organization, api_key)
+// @Param subject query string false "Organization or API key"
+// @Param format query string false "Response format" Enums(json, csv)
+// @Success 200 {object} models.UsageResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 403 {object} errors.ErrorResponse
+// @Router /admin/usage [get]
+func (h *UsageHandler) GetUsage(c *gin.Context) {
+	filter := models.UsageFilter{
+		From:        c.Query("from"),
+		To:          c.Query("to"),
+		SubjectType: c.Query("subjectType"),
+		Subject:     c.Query("subject"),
+	}
+
+	report, err := h.usageService.Report(c, filter)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get usage", "from", filter.From, "to", filter.To))
+		return
+	}
+	if c.Query("format") != "csv" {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="usage-%s-%s.csv"`, report.From, report.To))
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	w.Write([]string{"day", "subject_type", "subject", "requests", "corelogic_calls"})
+	for _, record := range report.Data {
+		w.Write([]string{
+			record.Day,
+			record.SubjectType,
+			record.Subject,
+			strconv.FormatInt(record.Requests, 10),
+			strconv.FormatInt(record.CoreLogicCalls, 10),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		logger.GlobalLogger.Errorf("Failed to write usage export: error=%v", err)
+	}
+}
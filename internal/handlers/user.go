@@ -1,10 +1,17 @@
 package handlers
 
 import (
+    stderrors "errors"
+    "math"
     "net/http"
+    "strconv"
     "strings"
+    "homeinsight-properties/internal/auth"
+    "homeinsight-properties/internal/errors"
     "homeinsight-properties/internal/models"
     "homeinsight-properties/internal/services"
+    "homeinsight-properties/internal/utils"
+    "homeinsight-properties/pkg/logger"
 
     "github.com/gin-gonic/gin"
 )
@@ -35,11 +42,62 @@ type RegisterRequest struct {
     Password string `json:"password" binding:"required,min=6,max=100" example:"password123"`
 }
 
+// RefreshRequest represents the token refresh request payload
+type RefreshRequest struct {
+    RefreshToken string `json:"refresh_token" binding:"required" example:"3q2-7wEXAMPLEr8Lk0rV1Zr2m6pQ..."`
+}
+
+// LogoutRequest represents the optional logout payload
+type LogoutRequest struct {
+    RefreshToken string `json:"refresh_token" example:"3q2-7wEXAMPLEr8Lk0rV1Zr2m6pQ..."`
+}
+
+// OAuthLoginRequest represents the social login payload
+type OAuthLoginRequest struct {
+    IDToken  string `json:"id_token" binding:"required" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6IjFlOWdkazcifQ..."`
+    Nonce    string `json:"nonce" example:"n-0S6_WzA2Mj"`
+    FullName string `json:"full_name" binding:"omitempty,max=100" example:"John Doe"`
+}
+
+// ForgotPasswordRequest represents the password reset request payload
+type ForgotPasswordRequest struct {
+    Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// ResetPasswordRequest represents the payload choosing a new password with a reset token
+type ResetPasswordRequest struct {
+    Token    string `json:"token" binding:"required" example:"Zm9vYmFyEXAMPLEc2VjcmV0dG9rZW4..."`
+    Password string `json:"password" binding:"required,min=6,max=100" example:"newpassword123"`
+}
+
 // TokenResponse represents the token response
 type TokenResponse struct {
-    Token     string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
-    ExpiresIn string `json:"expires_in" example:"3599"`
-    TokenType string `json:"token_type" example:"Bearer"`
+    Token            string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+    ExpiresIn        string `json:"expires_in" example:"3599"`
+    TokenType        string `json:"token_type" example:"Bearer"`
+    RefreshToken     string `json:"refresh_token,omitempty" example:"3q2-7wEXAMPLEr8Lk0rV1Zr2m6pQ..."`
+    RefreshExpiresIn string `json:"refresh_expires_in,omitempty" example:"2592000"`
+}
+
+// newTokenResponse maps issued token details onto the API response
+func newTokenResponse(tokenDetails *auth.TokenDetails) TokenResponse {
+    return TokenResponse{
+        Token:            tokenDetails.Token,
+        ExpiresIn:        tokenDetails.ExpiresIn,
+        TokenType:        tokenDetails.TokenType,
+        RefreshToken:     tokenDetails.RefreshToken,
+        RefreshExpiresIn: tokenDetails.RefreshExpiresIn,
+    }
+}
+
+// sessionClient describes the device a request comes from, for the session list
+func sessionClient(c *gin.Context) models.SessionClient {
+    return models.SessionClient{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
+
+// invalidInput reports a request body that failed to bind; the failing fields are listed as details
+func invalidInput(err error) *errors.AppError {
+    return errors.NewAppError("invalid request body", errors.MsgInvalidParameters, errors.ErrCodeInvalidParameters, http.StatusBadRequest, err)
 }
 
 // Register godoc
@@ -50,13 +108,13 @@ type TokenResponse struct {
 // @Produce json
 // @Param user body RegisterRequest true "User registration data"
 // @Success 201 {object} TokenResponse
-// @Failure 400 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Router /register [post]
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /auth/register [post]
 func (h *UserHandler) Register(c *gin.Context) {
     var req RegisterRequest
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input: " + err.Error()})
+        c.Error(invalidInput(err))
         return
     }
 
@@ -67,21 +125,17 @@ func (h *UserHandler) Register(c *gin.Context) {
         Password: req.Password, // Password is not trimmed to preserve exact input
     }
 
-    tokenDetails, err := h.userService.Register(user)
+    tokenDetails, err := h.userService.Register(c, user, sessionClient(c))
     if err != nil {
         if err.Error() == "email already registered" {
-            c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+            c.Error(errors.NewAppError(err.Error(), errors.MsgEmailRegistered, errors.ErrCodeEmailRegistered, http.StatusConflict, err))
         } else {
-            c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+            c.Error(utils.LogAndMapError(c, err, "register user"))
         }
         return
     }
 
-    c.JSON(http.StatusCreated, TokenResponse{
-        Token:     tokenDetails.Token,
-        ExpiresIn: tokenDetails.ExpiresIn,
-        TokenType: tokenDetails.TokenType,
-    })
+    c.JSON(http.StatusCreated, newTokenResponse(tokenDetails))
 }
 
 // Login godoc
@@ -92,25 +146,197 @@ func (h *UserHandler) Register(c *gin.Context) {
 // @Produce json
 // @Param credentials body LoginRequest true "Login credentials"
 // @Success 200 {object} TokenResponse
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Router /login [post]
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 429 {object} errors.ErrorResponse
+// @Router /auth/login [post]
 func (h *UserHandler) Login(c *gin.Context) {
     var creds LoginRequest
     if err := c.ShouldBindJSON(&creds); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input: " + err.Error()})
+        c.Error(invalidInput(err))
         return
     }
 
-    tokenDetails, err := h.userService.Login(strings.TrimSpace(creds.Email), creds.Password)
+    tokenDetails, err := h.userService.Login(c, strings.TrimSpace(creds.Email), creds.Password, sessionClient(c))
     if err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+        var locked *services.LoginLockedError
+        if stderrors.As(err, &locked) {
+            c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+            c.Error(errors.NewAppError(err.Error(), errors.MsgAccountLocked, errors.ErrCodeAccountLocked, http.StatusTooManyRequests, err))
+        } else if err.Error() == "invalid email or password" {
+            c.Error(errors.NewAppError(err.Error(), errors.MsgInvalidCredentials, errors.ErrCodeInvalidCredentials, http.StatusUnauthorized, err))
+        } else {
+            c.Error(utils.LogAndMapError(c, err, "login"))
+        }
+        return
+    }
+
+    c.JSON(http.StatusOK, newTokenResponse(tokenDetails))
+}
+
+// OAuthLogin godoc
+// @Summary Login with Google or Apple
+// @Description Exchange an identity provider ID token for our tokens. New users are created, and existing users are linked by verified email
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param provider path string true "Identity provider" Enums(google, apple)
+// @Param request body OAuthLoginRequest true "Provider ID token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 409 {object} errors.ErrorResponse
+// @Router /auth/oauth/{provider} [post]
+func (h *UserHandler) OAuthLogin(c *gin.Context) {
+    var req OAuthLoginRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(invalidInput(err))
+        return
+    }
+
+    provider := c.Param("provider")
+    tokenDetails, err := h.userService.OAuthLogin(c.Request.Context(), provider, req.IDToken, req.Nonce, req.FullName, sessionClient(c))
+    if err != nil {
+        message := err.Error()
+        switch {
+        case strings.HasPrefix(message, "unsupported oauth provider"):
+            c.Error(errors.NewAppError(message, errors.MsgOAuthUnsupported, errors.ErrCodeInvalidParameters, http.StatusBadRequest, err))
+        case strings.HasPrefix(message, "invalid id token"):
+            c.Error(errors.NewAppError(message, errors.MsgInvalidIDToken, errors.ErrCodeInvalidCredentials, http.StatusUnauthorized, err))
+        case strings.HasPrefix(message, "oauth email not verified"):
+            c.Error(errors.NewAppError(message, errors.MsgOAuthEmailUnverified, errors.ErrCodeInvalidCredentials, http.StatusUnauthorized, err))
+        case strings.HasPrefix(message, "account already linked"):
+            c.Error(errors.NewAppError(message, errors.MsgOAuthAccountLinked, errors.ErrCodeEmailRegistered, http.StatusConflict, err))
+        default:
+            c.Error(utils.LogAndMapError(c, err, "oauth login", "provider", provider))
+        }
+        return
+    }
+
+    c.JSON(http.StatusOK, newTokenResponse(tokenDetails))
+}
+
+// Refresh godoc
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token; the refresh token is rotated on every use
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body RefreshRequest true "Refresh token"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ErrorResponse
+// @Router /token/refresh [post]
+func (h *UserHandler) Refresh(c *gin.Context) {
+    var req RefreshRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(invalidInput(err))
+        return
+    }
+
+    tokenDetails, err := h.userService.Refresh(c.Request.Context(), req.RefreshToken, sessionClient(c))
+    if err != nil {
+        if stderrors.Is(err, services.ErrInvalidRefreshToken) {
+            c.Error(errors.NewAppError(err.Error(), errors.MsgSessionExpired, errors.ErrCodeUnauthorized, http.StatusUnauthorized, err))
+            return
+        }
+        // The token store or user lookup failed; the client's token may well be valid
+        logger.GlobalLogger.Errorf("Token refresh failed: client_ip=%s, error=%v", c.ClientIP(), err)
+        c.Error(errors.NewAppError("refresh token failed: "+err.Error(), errors.MsgInternalError, errors.ErrCodeInternal, http.StatusInternalServerError, err))
+        return
+    }
+
+    c.JSON(http.StatusOK, newTokenResponse(tokenDetails))
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Email a single-use password reset link; the response is the same whether or not the email is registered
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 429 {object} errors.ErrorResponse
+// @Router /password/forgot [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+    var req ForgotPasswordRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(invalidInput(err))
+        return
+    }
+
+    if err := h.userService.RequestPasswordReset(c.Request.Context(), strings.TrimSpace(req.Email)); err != nil {
+        logger.GlobalLogger.Errorf("Password reset request failed: error=%v", err)
+        c.Error(errors.NewAppError("failed to request password reset", errors.MsgInternalError, errors.ErrCodeInternal, http.StatusInternalServerError, err))
+        return
+    }
+
+    c.JSON(http.StatusAccepted, gin.H{"message": "If that email is registered, a password reset link has been sent."})
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password using a reset token; all existing sessions are signed out
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 429 {object} errors.ErrorResponse
+// @Router /password/reset [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+    var req ResetPasswordRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(invalidInput(err))
+        return
+    }
+
+    if err := h.userService.ResetPassword(c, req.Token, req.Password); err != nil {
+        switch err.Error() {
+        case "invalid or expired reset token":
+            c.Error(errors.NewAppError(err.Error(), errors.MsgInvalidResetToken, errors.ErrCodeInvalidParameters, http.StatusBadRequest, err))
+        case "password must be between 6 and 100 characters":
+            c.Error(errors.NewAppError(err.Error(), "Passwords must be between 6 and 100 characters.", errors.ErrCodeInvalidParameters, http.StatusBadRequest, err))
+        default:
+            logger.GlobalLogger.Errorf("Password reset failed: error=%v", err)
+            c.Error(errors.NewAppError("failed to reset password", errors.MsgInternalError, errors.ErrCodeInternal, http.StatusInternalServerError, err))
+        }
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Password has been reset. Please log in with your new password."})
+}
+
+// Logout godoc
+// @Summary Logout user
+// @Description Revoke the access token used for this request, and the session's refresh tokens when one is sent
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body LogoutRequest false "Refresh token to revoke"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+    var req LogoutRequest
+    if c.Request.ContentLength != 0 {
+        if err := c.ShouldBindJSON(&req); err != nil {
+            c.Error(invalidInput(err))
+            return
+        }
+    }
+
+    expiresAt := c.GetTime("token_expires_at")
+    if err := h.userService.Logout(c.Request.Context(), c.GetString("user_id"), c.GetString("token_id"), expiresAt, req.RefreshToken); err != nil {
+        logger.GlobalLogger.Errorf("Logout failed: user_id=%s, error=%v", c.GetString("user_id"), err)
+        c.Error(errors.NewAppError("failed to logout", "We're unable to sign you out right now. Please try again shortly.", errors.ErrCodeServiceUnavailable, http.StatusServiceUnavailable, err))
         return
     }
 
-    c.JSON(http.StatusOK, TokenResponse{
-        Token:     tokenDetails.Token,
-        ExpiresIn: tokenDetails.ExpiresIn,
-        TokenType: tokenDetails.TokenType,
-    })
+    c.Status(http.StatusNoContent)
 }
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ValuationHandler struct {
+	valuationService *services.ValuationService
+}
+
+func NewValuationHandler(valuationService *services.ValuationService) *ValuationHandler {
+	return &ValuationHandler{
+		valuationService: valuationService,
+	}
+}
+
+// GetValuation returns the estimated value, confidence score and value range of a property.
+// @Summary Get property valuation
+// @Description Get the estimated value, confidence score and value range of a property
+// @Tags Properties
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Property ID"
+// @Success 200 {object} models.Valuation
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Failure 503 {object} errors.ErrorResponse
+// @Router /properties/{id}/valuation [get]
+func (h *ValuationHandler) GetValuation(c *gin.Context) {
+	id := c.Param("id")
+	c.Set("property_id", id)
+
+	valuation, err := h.valuationService.GetValuation(c, id)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "get valuation", "propertyID", id))
+		return
+	}
+	c.JSON(http.StatusOK, valuation)
+}
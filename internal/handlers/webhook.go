@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/services"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook registers a callback URL. The response carries the signing secret, which is not shown again.
+// @Summary Register a webhook
+// @Description Register a callback URL for property events. The response carries the signing secret, which is not shown again
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.CreateWebhookRequest true "Webhook"
+// @Success 201 {object} models.Webhook
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	userID := c.GetString("user_id")
+
+	var req models.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid webhook request: user_id=%s, error=%v", userID, err)
+		c.Error(appErr)
+		return
+	}
+
+	hook, err := h.webhookService.CreateWebhook(c, userID, &req)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "create webhook", "url", req.URL))
+		return
+	}
+	c.JSON(http.StatusCreated, hook)
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Description List the registered webhooks
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} object{data=[]models.Webhook}
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	hooks, err := h.webhookService.ListWebhooks(c)
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "list webhooks"))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": hooks})
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Stop sending events to a webhook
+// @Tags Webhooks
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 401 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := h.webhookService.DeleteWebhook(c, id); err != nil {
+		c.Error(utils.LogAndMapError(c, err, "delete webhook", "id", id))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
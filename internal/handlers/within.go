@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Map searches show pins rather than result lists, so they page in larger steps than lists do.
+const (
+	defaultWithinLimit = 100
+	maxWithinLimit     = 500
+)
+
+// FindWithin returns the properties inside a polygon drawn on the map or the map's bounding box,
+// paged with ?cursor= and ?limit=. The next page is requested with the same body and the returned
+// cursor.
+// @Summary Find properties within an area
+// @Description Page through the properties inside a polygon or bounding box; request the next page with the same body and the returned cursor
+// @Tags Properties
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.WithinRequest true "Polygon or bounding box"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Page size, up to 500" default(100)
+// @Param fields query string false "Comma-separated property fields to return, such as address,building.summary"
+// @Success 200 {object} models.PaginatedPropertiesResponse
+// @Failure 400 {object} errors.ErrorResponse
+// @Failure 401 {object} errors.ErrorResponse
+// @Router /properties/within [post]
+func (h *PropertyHandler) FindWithin(c *gin.Context) {
+	var req models.WithinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		appErr := errors.NewAppError(
+			"invalid request body",
+			"The boundary must be a GeoJSON polygon or a bbox of [west, south, east, north]",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+		logger.GlobalLogger.Errorf("Invalid boundary: error=%v", err)
+		c.Error(appErr)
+		return
+	}
+
+	limit := defaultWithinLimit
+	if raw := c.Query("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 || value > maxWithinLimit {
+			appErr := errors.NewAppError(
+				"invalid limit parameter",
+				"Limit must be between 1 and 500",
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				err,
+			)
+			logger.GlobalLogger.Errorf("Invalid limit: value=%s", raw)
+			c.Error(appErr)
+			return
+		}
+		limit = value
+	}
+	fields, ok := parseFields(c)
+	if !ok {
+		return
+	}
+
+	cursor := c.Query("cursor")
+	response, err := h.searchService.FindWithin(c, &req, cursor, fields, limit, pageURL(c, "/api/properties/within"), c.Request.URL.Query())
+	if err != nil {
+		c.Error(utils.LogAndMapError(c, err, "find properties within boundary",
+			"cursor", cursor,
+			"limit", limit))
+		return
+	}
+	writeProperties(c, fields, response)
+}
@@ -0,0 +1,86 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/testutil"
+	"homeinsight-properties/pkg/metrics"
+
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSearchIsCached(t *testing.T) {
+	env.Reset(t)
+	env.SeedProperties(t, horseshoeFile)
+	token := env.Token(t, "user")
+
+	testutil.DecodeJSON(t, search(t, token, horseshoeAddress), http.StatusOK, nil)
+	if keys := env.CacheKeys(t, "properties:search-specific:*"); len(keys) != 1 {
+		t.Fatalf("search keys = %v, want one", keys)
+	}
+	if keys := env.CacheKeys(t, "property:org:*:id:"+horseshoeID); len(keys) != 1 {
+		t.Fatalf("property keys = %v, want one", keys)
+	}
+
+	hits := promtest.ToFloat64(metrics.CacheHitsTotal)
+	var property models.Property
+	testutil.DecodeJSON(t, search(t, token, horseshoeAddress), http.StatusOK, &property)
+	if property.PropertyID != horseshoeID {
+		t.Errorf("propertyId = %q, want %q", property.PropertyID, horseshoeID)
+	}
+	if got := promtest.ToFloat64(metrics.CacheHitsTotal); got != hits+1 {
+		t.Errorf("cache hits = %v, want %v", got, hits+1)
+	}
+}
+
+func TestPatchInvalidatesSearch(t *testing.T) {
+	env.Reset(t)
+	env.SeedProperties(t, horseshoeFile)
+	token := env.Token(t, "user")
+	testutil.DecodeJSON(t, search(t, token, horseshoeAddress), http.StatusOK, nil)
+
+	rec := env.Do(t, http.MethodPatch, "/api/properties/"+horseshoeID, `{"building":{"summary":{"bedroomsCount":7}}}`, token)
+	testutil.DecodeJSON(t, rec, http.StatusOK, nil)
+	if keys := env.CacheKeys(t, "properties:search-specific:*"); len(keys) != 0 {
+		t.Errorf("search keys = %v, want none after the patch", keys)
+	}
+
+	var property models.Property
+	testutil.DecodeJSON(t, search(t, token, horseshoeAddress), http.StatusOK, &property)
+	if property.Building.Summary.BedroomsCount != 7 {
+		t.Errorf("searched bedrooms = %d, want 7", property.Building.Summary.BedroomsCount)
+	}
+	testutil.DecodeJSON(t, env.Do(t, http.MethodGet, "/api/properties/property-detail/"+horseshoeID, nil, token), http.StatusOK, &property)
+	if property.Building.Summary.BedroomsCount != 7 {
+		t.Errorf("detail bedrooms = %d, want 7", property.Building.Summary.BedroomsCount)
+	}
+}
+
+func TestDeleteInvalidatesListPages(t *testing.T) {
+	env.Reset(t)
+	env.SeedProperties(t)
+	token := env.Token(t, models.RoleAdmin)
+
+	var page models.PaginatedPropertiesResponse
+	testutil.DecodeJSON(t, env.Do(t, http.MethodGet, "/api/properties", nil, token), http.StatusOK, &page)
+	if len(page.Data) != 2 {
+		t.Fatalf("listed %d properties, want 2", len(page.Data))
+	}
+	if keys := env.CacheKeys(t, "properties:list:org:*"); len(keys) == 0 {
+		t.Fatal("list page was not cached")
+	}
+
+	rec := env.Do(t, http.MethodDelete, "/api/properties/property-detail/"+horseshoeID, nil, token)
+	testutil.DecodeJSON(t, rec, http.StatusNoContent, nil)
+	if keys := env.CacheKeys(t, "properties:list:org:*"); len(keys) != 0 {
+		t.Errorf("list keys = %v, want none after the delete", keys)
+	}
+
+	page = models.PaginatedPropertiesResponse{}
+	testutil.DecodeJSON(t, env.Do(t, http.MethodGet, "/api/properties", nil, token), http.StatusOK, &page)
+	if len(page.Data) != 1 || page.Data[0].PropertyID != nightshadeID {
+		t.Errorf("data = %+v, want only %s", page.Data, nightshadeID)
+	}
+}
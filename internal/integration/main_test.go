@@ -0,0 +1,33 @@
+// Package integration_test runs the API against real MongoDB and Redis servers started by
+// internal/testutil. Without docker or TEST_MONGO_URI/TEST_REDIS_ADDR the tests are skipped.
+package integration_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"homeinsight-properties/internal/testutil"
+)
+
+var env *testutil.Env
+
+func TestMain(m *testing.M) {
+	os.Exit(testutil.Run(m, &env))
+}
+
+// Sandbox fixtures the tests seed or search for.
+const (
+	horseshoeID      = "7909216472"
+	horseshoeAddress = "1050 Horseshoe Dr, Nashville, TN 37216"
+	nightshadeID     = "1005285055"
+	horseshoeFile    = "1050-horseshoe-dr_nashville_tn_37216"
+)
+
+// search looks up a single property by address.
+func search(t *testing.T, token, address string) *httptest.ResponseRecorder {
+	t.Helper()
+	return env.Do(t, http.MethodGet, "/api/properties/property-search?"+url.Values{"q": {address}}.Encode(), nil, token)
+}
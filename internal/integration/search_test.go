@@ -0,0 +1,66 @@
+package integration_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/testutil"
+)
+
+func TestSearchFindsStoredProperty(t *testing.T) {
+	env.Reset(t)
+	env.SeedProperties(t, horseshoeFile)
+	token := env.Token(t, "user")
+
+	var property models.Property
+	testutil.DecodeJSON(t, search(t, token, horseshoeAddress), http.StatusOK, &property)
+	if property.PropertyID != horseshoeID {
+		t.Errorf("propertyId = %q, want %q", property.PropertyID, horseshoeID)
+	}
+	if property.Address.City != "NASHVILLE" || property.Address.ZipCode != "37216" {
+		t.Errorf("address = %+v", property.Address)
+	}
+}
+
+func TestSearchStoresPropertyFromProvider(t *testing.T) {
+	env.Reset(t)
+	token := env.Token(t, "user")
+
+	var property models.Property
+	testutil.DecodeJSON(t, search(t, token, "3416 Nightshade Dr, Murfreesboro, TN 37128"), http.StatusOK, &property)
+	if property.PropertyID != nightshadeID {
+		t.Fatalf("propertyId = %q, want %q", property.PropertyID, nightshadeID)
+	}
+
+	stored, err := env.Properties.FindByID(tenant.WithOrgID(context.Background(), env.App.DefaultOrgID), nightshadeID)
+	if err != nil {
+		t.Fatalf("find stored property: %v", err)
+	}
+	if stored == nil {
+		t.Fatal("property fetched from the provider was not stored")
+	}
+}
+
+func TestListFiltersCursorPages(t *testing.T) {
+	env.Reset(t)
+	env.SeedProperties(t)
+	token := env.Token(t, "user")
+
+	var page models.PaginatedPropertiesResponse
+	testutil.DecodeJSON(t, env.Do(t, http.MethodGet, "/api/properties?cursor=&city=Murfreesboro", nil, token), http.StatusOK, &page)
+	if len(page.Data) != 1 || page.Data[0].PropertyID != nightshadeID {
+		t.Fatalf("data = %+v, want only %s", page.Data, nightshadeID)
+	}
+	if page.Metadata.Total != 1 {
+		t.Errorf("total = %d, want 1", page.Metadata.Total)
+	}
+	if page.Metadata.Next != nil {
+		t.Errorf("next = %s, want none on the last page", *page.Metadata.Next)
+	}
+
+	rec := env.Do(t, http.MethodGet, "/api/properties?cursor=&sort=yearBuilt:desc", nil, token)
+	testutil.DecodeJSON(t, rec, http.StatusBadRequest, nil)
+}
@@ -3,40 +3,77 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"homeinsight-properties/internal/auth"
-	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		cfg, err := config.LoadConfig("configs/config.yaml")
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load config"})
+		keys := auth.Keys()
+		if keys == nil {
+			c.Error(errors.NewAppError("signing keys not configured", errors.MsgInternalError, errors.ErrCodeInternal, http.StatusInternalServerError, nil))
 			c.Abort()
 			return
 		}
 
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization header required"})
-			c.Abort()
+			abortUnauthorized(c, "authorization header required", errors.MsgUnauthorized, nil)
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
-			c.Abort()
+			abortUnauthorized(c, "invalid authorization header format", "The Authorization header must have the form: Bearer <token>.", nil)
 			return
 		}
 
-		claims, err := auth.ValidateJWT(parts[1], cfg.JWT.Secret)
+		claims, err := auth.ValidateJWT(parts[1], keys)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-			c.Abort()
+			abortUnauthorized(c, "invalid access token", errors.MsgSessionExpired, err)
+			return
+		}
+
+		// Tokens issued before jti was added can't be revoked and simply run out
+		if claims.ID != "" {
+			denied, err := cache.IsTokenDenied(c, claims.ID)
+			if err != nil {
+				logger.GlobalLogger.Errorf("Token denylist check failed: user_id=%s, error=%v", claims.UserID, err)
+				c.Error(errors.NewAppError("unable to verify token", "We're unable to verify your session right now. Please try again shortly.", errors.ErrCodeServiceUnavailable, http.StatusServiceUnavailable, err))
+				c.Abort()
+				return
+			}
+			if denied {
+				abortUnauthorized(c, "token has been revoked", errors.MsgSessionExpired, nil)
+				return
+			}
+		}
+
+		// Tokens issued before sessions were tracked have no sid and can only be revoked by jti
+		if claims.SessionID != "" {
+			denied, err := cache.UseSession(c, claims.SessionID, time.Now())
+			if err != nil {
+				logger.GlobalLogger.Errorf("Session check failed: user_id=%s, error=%v", claims.UserID, err)
+				c.Error(errors.NewAppError("unable to verify session", "We're unable to verify your session right now. Please try again shortly.", errors.ErrCodeServiceUnavailable, http.StatusServiceUnavailable, err))
+				c.Abort()
+				return
+			}
+			if denied {
+				abortUnauthorized(c, "session has been signed out", errors.MsgSessionExpired, nil)
+				return
+			}
+		}
+
+		// Tokens issued before organizations were introduced can't be scoped to one
+		if claims.OrgID == "" {
+			abortUnauthorized(c, "token has no organization", errors.MsgSessionExpired, nil)
 			return
 		}
 
@@ -45,6 +82,19 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Set("full_name", claims.FullName)
 		c.Set("email", claims.Email)
 		c.Set("phone", claims.Phone)
+		c.Set("role", claims.Role)
+		c.Set("token_id", claims.ID)
+		c.Set("session_id", claims.SessionID)
+		tenant.Attach(c, claims.OrgID)
+		if claims.ExpiresAt != nil {
+			c.Set("token_expires_at", claims.ExpiresAt.Time)
+		}
 		c.Next()
 	}
 }
+
+// abortUnauthorized rejects a request whose access token is missing or unusable.
+func abortUnauthorized(c *gin.Context, technicalMessage, userMessage string, err error) {
+	c.Error(errors.NewAppError(technicalMessage, userMessage, errors.ErrCodeUnauthorized, http.StatusUnauthorized, err))
+	c.Abort()
+}
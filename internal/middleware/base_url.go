@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"homeinsight-properties/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BaseURLMiddleware records the absolute URL clients reach the API at as "base_url", for building
+// links in responses: server.public_url when set, otherwise the request's scheme and host. The
+// scheme and host a proxy forwarded in X-Forwarded-Proto and X-Forwarded-Host are only used when
+// the request came from one of server.trusted_proxies, since any client can send those headers.
+func BaseURLMiddleware(cfg *config.Config) gin.HandlerFunc {
+	publicURL := cfg.Server.PublicURL
+	proxies := trustedProxyNets(cfg.Server.TrustedProxies)
+	return func(c *gin.Context) {
+		baseURL := publicURL
+		if baseURL == "" {
+			baseURL = requestBaseURL(c, fromTrustedProxy(proxies, c.Request.RemoteAddr))
+		}
+		c.Set("base_url", baseURL)
+		c.Next()
+	}
+}
+
+func requestBaseURL(c *gin.Context, forwarded bool) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	host := c.Request.Host
+	if !forwarded {
+		return scheme + "://" + host
+	}
+	if proto := forwardedValue(c.GetHeader("X-Forwarded-Proto")); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	if forwardedHost := forwardedValue(c.GetHeader("X-Forwarded-Host")); forwardedHost != "" {
+		host = forwardedHost
+	}
+	return scheme + "://" + host
+}
+
+// forwardedValue returns the first of a forwarded header's values, the one set by the proxy nearest
+// the client when several proxies appended theirs.
+func forwardedValue(header string) string {
+	value, _, _ := strings.Cut(header, ",")
+	return strings.TrimSpace(value)
+}
+
+// trustedProxyNets parses server.trusted_proxies, which config loading has validated; a single
+// address is a range of its own.
+func trustedProxyNets(proxies []string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if _, ipNet, err := net.ParseCIDR(proxy); err == nil {
+			nets = append(nets, ipNet)
+			continue
+		}
+		if ip := net.ParseIP(proxy); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+		}
+	}
+	return nets
+}
+
+// fromTrustedProxy reports whether the peer of a connection is one of the trusted proxies.
+func fromTrustedProxy(proxies []*net.IPNet, remoteAddr string) bool {
+	if len(proxies) == 0 {
+		return false
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
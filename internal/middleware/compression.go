@@ -0,0 +1,221 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionOptions configures CompressionMiddleware.
+type CompressionOptions struct {
+	// Level is the gzip level, 1 to 9, and BrotliLevel the brotli quality, 0 to 11
+	Level        int
+	BrotliLevel  int
+	MinSizeBytes int
+	// ContentTypes are media type prefixes worth compressing, e.g. "application/json" or "text/"
+	ContentTypes []string
+}
+
+// CompressionMiddleware compresses responses with brotli or gzip, whichever the client's
+// Accept-Encoding prefers (brotli on a tie), when the response's Content-Type matches and its body
+// reaches MinSizeBytes. The start of the body is held back until
+// that is known, so small responses go out as they are. A handler that flushes, such as the NDJSON
+// stream, gets compressed from its first flush and each flush still reaches the client. Responses
+// that already carry a Content-Encoding are passed through.
+func CompressionMiddleware(opts CompressionOptions) gin.HandlerFunc {
+	pools := map[string]*sync.Pool{
+		"br": {New: func() interface{} {
+			return brotli.NewWriterLevel(nil, opts.BrotliLevel)
+		}},
+		"gzip": {New: func() interface{} {
+			gz, _ := gzip.NewWriterLevel(nil, opts.Level)
+			return gz
+		}},
+	}
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if c.Request.Method == http.MethodHead || encoding == "" {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &compressWriter{ResponseWriter: original, opts: &opts, encoding: encoding, pool: pools[encoding]}
+		c.Writer = w
+		defer func() {
+			w.close()
+			c.Writer = original
+		}()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks the content coding to compress with from an Accept-Encoding header: the
+// supported one with the highest quality, brotli on a tie, or "" when neither is accepted.
+func negotiateEncoding(header string) string {
+	br, gz := encodingQuality(header, "br"), encodingQuality(header, "gzip")
+	switch {
+	case br > 0 && br >= gz:
+		return "br"
+	case gz > 0:
+		return "gzip"
+	}
+	return ""
+}
+
+// encodingQuality returns the quality an Accept-Encoding header gives the content coding, either by
+// name or through "*"; it is 0 when the coding isn't accepted.
+func encodingQuality(header, encoding string) float64 {
+	accepted := 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != encoding && name != "*" {
+			continue
+		}
+		q := 1.0
+		if key, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(key) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				q = parsed
+			}
+		}
+		if name == encoding {
+			// An explicit entry overrides the wildcard
+			return q
+		}
+		accepted = q
+	}
+	return accepted
+}
+
+// encoder is what a compressWriter needs of gzip.Writer and brotli.Writer.
+type encoder interface {
+	io.Writer
+	Flush() error
+	Close() error
+	Reset(io.Writer)
+}
+
+// compressWriter buffers the start of a response until it knows whether to compress it.
+type compressWriter struct {
+	gin.ResponseWriter
+	opts     *CompressionOptions
+	encoding string
+	pool     *sync.Pool
+
+	buf     []byte
+	decided bool
+	enc     encoder
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) < w.opts.MinSizeBytes {
+			return len(data), nil
+		}
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if w.enc != nil {
+		return w.enc.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow sends the headers before any body, so the response can no longer be compressed.
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		w.decide(false)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *compressWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush starts compressing a response that is streamed, whatever its size so far, and pushes what was
+// written to the client.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(true)
+	}
+	if w.enc != nil {
+		w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// close writes out a response shorter than MinSizeBytes uncompressed and finishes the compressed
+// stream of a compressed one.
+func (w *compressWriter) close() {
+	if !w.decided {
+		w.decide(len(w.buf) >= w.opts.MinSizeBytes)
+	}
+	if w.enc != nil {
+		w.enc.Close()
+		w.enc.Reset(nil)
+		w.pool.Put(w.enc)
+		w.enc = nil
+	}
+}
+
+// decide picks compression when compressible allows it and the response qualifies, then writes the
+// buffered start of the body.
+func (w *compressWriter) decide(compressible bool) error {
+	w.decided = true
+	header := w.Header()
+	if w.eligible() {
+		header.Add("Vary", "Accept-Encoding")
+		if compressible && len(w.buf) > 0 {
+			header.Set("Content-Encoding", w.encoding)
+			header.Del("Content-Length")
+			w.enc = w.pool.Get().(encoder)
+			w.enc.Reset(w.ResponseWriter)
+		}
+	}
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	buf := w.buf
+	w.buf = nil
+	if w.enc != nil {
+		_, err := w.enc.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// eligible reports whether the response's status, encoding and content type allow compressing it.
+func (w *compressWriter) eligible() bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified || status == http.StatusPartialContent {
+		return false
+	}
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, prefix := range w.opts.ContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestDeadlineMiddleware bounds each request's context by the configured budget. Outbound calls
+// bound to the request context stop at the deadline or as soon as the client disconnects. Routes in
+// exempt, such as long-running streams, set their own deadline.
+func RequestDeadlineMiddleware(budget time.Duration, exempt ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if slices.Contains(exempt, c.FullPath()) {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), budget)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/deprecation"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+const deprecationRecordTimeout = time.Second
+
+// DeprecationMiddleware announces deprecated endpoints and parameters with the Deprecation, Sunset
+// and Link headers and records which client called them, so removals can be planned per client.
+func DeprecationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matched := deprecation.Match(c.Request.Method, c.FullPath(), c.Request.URL.Query())
+		if len(matched) == 0 {
+			c.Next()
+			return
+		}
+		setDeprecationHeaders(c, matched)
+
+		c.Next()
+
+		// The client is identified after the handlers ran, once auth middleware has set it
+		client := deprecationClient(c)
+		ctx, cancel := context.WithTimeout(context.Background(), deprecationRecordTimeout)
+		defer cancel()
+		for _, d := range matched {
+			metrics.DeprecatedRequestsTotal.WithLabelValues(d.ID).Inc()
+			if err := cache.RecordDeprecatedCall(ctx, d.ID, client, time.Now()); err != nil {
+				logger.GlobalLogger.Warnf("Failed to record deprecated call: deprecation=%s, client=%s, error=%v", d.ID, client, err)
+			}
+		}
+	}
+}
+
+// setDeprecationHeaders follows RFC 9745 and RFC 8594; with several matches the earliest dates win.
+func setDeprecationHeaders(c *gin.Context, matched []models.Deprecation) {
+	since, sunset := matched[0].Since, matched[0].Sunset
+	var links []string
+	for _, d := range matched {
+		if d.Since.Before(since) {
+			since = d.Since
+		}
+		if d.Sunset.Before(sunset) {
+			sunset = d.Sunset
+		}
+		if d.Link != "" {
+			links = append(links, fmt.Sprintf("<%s>; rel=\"deprecation\"", d.Link))
+		}
+	}
+	c.Header("Deprecation", fmt.Sprintf("@%d", since.Unix()))
+	c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+	if len(links) > 0 {
+		c.Writer.Header().Add("Link", strings.Join(links, ", "))
+	}
+}
+
+// deprecationClient prefers the partner key a request was authorized with, then the user.
+func deprecationClient(c *gin.Context) string {
+	if partner := c.GetString("signing_partner"); partner != "" {
+		return "partner:" + partner
+	}
+	if partner := c.GetString("embed_partner"); partner != "" {
+		return "partner:" + partner
+	}
+	if userID := c.GetString("user_id"); userID != "" {
+		return "user:" + userID
+	}
+	return "ip:" + c.ClientIP()
+}
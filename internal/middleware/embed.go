@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+)
+
+type embedPartner struct {
+	config  config.EmbedPartner
+	limiter *rate.Limiter
+}
+
+// requestOrigin returns the scheme://host the request was embedded from, preferring
+// the Origin header (fetch/XHR) and falling back to Referer (iframes, script tags).
+func requestOrigin(c *gin.Context) string {
+	if origin := c.GetHeader("Origin"); origin != "" && origin != "null" {
+		return strings.TrimRight(origin, "/")
+	}
+	if referer := c.GetHeader("Referer"); referer != "" {
+		if u, err := url.Parse(referer); err == nil && u.Host != "" {
+			return u.Scheme + "://" + u.Host
+		}
+	}
+	return ""
+}
+
+func (p *embedPartner) originAllowed(origin string) bool {
+	if len(p.config.AllowedOrigins) == 0 {
+		return true
+	}
+	for _, allowed := range p.config.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimRight(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// EmbedMiddleware authenticates partner API keys, enforces their origin allow-list and
+// per-key rate limit, and sets CORS/framing headers for embeddable responses.
+func EmbedMiddleware(partners []config.EmbedPartner) gin.HandlerFunc {
+	byKey := make(map[string]*embedPartner, len(partners))
+	for _, p := range partners {
+		if p.APIKey == "" {
+			continue
+		}
+		byKey[p.APIKey] = &embedPartner{
+			config:  p,
+			limiter: rate.NewLimiter(rate.Limit(float64(p.RequestsPerMinute)/60.0), p.RequestsPerMinute),
+		}
+	}
+
+	return func(c *gin.Context) {
+		apiKey := c.Query("key")
+		if apiKey == "" {
+			apiKey = c.GetHeader("X-API-Key")
+		}
+		partner, ok := byKey[apiKey]
+		if !ok {
+			logger.GlobalLogger.Warnf("Embed request with invalid API key: path=%s, client_ip=%s", c.Request.URL.Path, c.ClientIP())
+			c.Error(errors.NewAppError("invalid embed api key", errors.MsgInvalidAPIKey, errors.ErrCodeInvalidAPIKey, http.StatusUnauthorized, nil))
+			c.Abort()
+			return
+		}
+
+		origin := requestOrigin(c)
+		if len(partner.config.AllowedOrigins) > 0 && (origin == "" || !partner.originAllowed(origin)) {
+			logger.GlobalLogger.Warnf("Embed origin rejected: partner=%s, origin=%s", partner.config.Name, origin)
+			c.Error(errors.NewAppError("embed origin not allowed: "+origin, errors.MsgOriginNotAllowed, errors.ErrCodeOriginNotAllowed, http.StatusForbidden, nil))
+			c.Abort()
+			return
+		}
+
+		if !partner.limiter.Allow() {
+			c.Error(errors.NewAppError("embed rate limit exceeded: partner="+partner.config.Name, errors.MsgRateLimited, errors.ErrCodeRateLimited, http.StatusTooManyRequests, nil))
+			c.Abort()
+			return
+		}
+
+		// Embeds are public, credential-less reads: allow the calling origin and framing by it
+		if origin != "" {
+			c.Header("Access-Control-Allow-Origin", origin)
+		} else {
+			c.Header("Access-Control-Allow-Origin", "*")
+		}
+		c.Writer.Header().Del("Access-Control-Allow-Credentials")
+		c.Writer.Header().Del("X-Frame-Options")
+		c.Header("Vary", "Origin")
+		if len(partner.config.AllowedOrigins) > 0 {
+			c.Header("Content-Security-Policy", "frame-ancestors "+strings.Join(partner.config.AllowedOrigins, " "))
+		}
+
+		c.Set("embed_partner", partner.config.Name)
+		tenant.Attach(c, partner.config.OrgID)
+		c.Next()
+	}
+}
@@ -1,14 +1,19 @@
 package middleware
 
 import (
+	"fmt"
+	"net/http"
 
 	"homeinsight-properties/internal/errors"
 	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/requestid"
 
 	"github.com/gin-gonic/gin"
 )
 
-// ErrorHandler catches errors and returns standardized responses.
+// ErrorHandler catches errors and returns standardized responses. Every failed request gets the
+// errors.ErrorResponse envelope; handlers and middleware report failures with c.Error rather than
+// writing their own bodies.
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
@@ -18,19 +23,40 @@ func ErrorHandler() gin.HandlerFunc {
 			appErr := errors.MapError(err)
 
 			// Log technical details
-			logger.GlobalLogger.Errorf("Request failed: path=%s, method=%s, client_ip=%s, error=%s",
-				c.Request.URL.Path,
-				c.Request.Method,
-				c.ClientIP(),
-				appErr.TechnicalMessage)
-
-			c.JSON(appErr.HTTPStatus, gin.H{
-				"error": gin.H{
-					"message": appErr.UserMessage,
-					"code":    appErr.Code,
-				},
-			})
+			logger.GlobalLogger.WithContext(c).With(
+				"path", c.Request.URL.Path,
+				"method", c.Request.Method,
+				"client_ip", c.ClientIP(),
+				"code", appErr.Code,
+				"error", appErr.TechnicalMessage,
+			).Error("request failed")
+
+			// A streamed response already sent its status; it reports errors in-band
+			if c.Writer.Written() {
+				return
+			}
+			c.JSON(appErr.HTTPStatus, errors.NewErrorResponse(appErr, requestid.FromContext(c)))
 			return
 		}
 	}
 }
+
+// Recovery turns a panic in a handler into an INTERNAL_ERROR response. It must be registered after
+// ErrorHandler.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		c.Error(errors.NewAppError(fmt.Sprintf("panic: %v", recovered), errors.MsgInternalError, errors.ErrCodeInternal, http.StatusInternalServerError, nil))
+		c.Abort()
+	})
+}
+
+// RouteNotFound answers requests to paths no route matches.
+func RouteNotFound(c *gin.Context) {
+	c.Error(errors.NewAppError(
+		fmt.Sprintf("route not found: %s %s", c.Request.Method, c.Request.URL.Path),
+		errors.MsgRouteNotFound,
+		errors.ErrCodeRouteNotFound,
+		http.StatusNotFound,
+		nil,
+	))
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"homeinsight-properties/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPCacheMiddleware adds caching headers to successful GET responses of the routes configured in
+// http_cache, and answers conditional requests for them. The body is held back to compute a weak
+// ETag; a request whose If-None-Match names it gets 304 without the body. Caching headers set by the
+// handler are kept. Rules are read per request so reloaded config applies without a restart.
+func HTTPCacheMiddleware(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+		rule, ok := cfg.Live().HTTPCache.Routes[c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		w := &cacheWriter{ResponseWriter: original}
+		c.Writer = w
+		c.Next()
+		c.Writer = original
+
+		if w.streamed {
+			return
+		}
+		if w.Status() != http.StatusOK || len(c.Errors) > 0 {
+			if len(w.buf) > 0 {
+				original.Write(w.buf)
+			}
+			return
+		}
+
+		header := original.Header()
+		anonymous := c.GetHeader("Authorization") == "" && c.GetString("user_id") == ""
+		if header.Get("Cache-Control") == "" {
+			header.Set("Cache-Control", cacheControl(rule, anonymous))
+		}
+		if rule.Public && anonymous && rule.SurrogateMaxAgeSeconds > 0 && header.Get("Surrogate-Control") == "" {
+			header.Set("Surrogate-Control", "max-age="+strconv.Itoa(rule.SurrogateMaxAgeSeconds))
+		}
+		etag := header.Get("ETag")
+		if etag == "" {
+			sum := sha256.Sum256(w.buf)
+			etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+			header.Set("ETag", etag)
+		}
+
+		if etagMatches(c.GetHeader("If-None-Match"), etag) {
+			header.Del("Content-Type")
+			header.Del("Content-Length")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+		original.Write(w.buf)
+	}
+}
+
+// cacheControl renders a rule as a Cache-Control value. A public rule stays private for signed-in
+// requests, so a shared cache never serves one user's response to another.
+func cacheControl(rule config.HTTPCacheRule, anonymous bool) string {
+	directives := []string{"private"}
+	if rule.Public && anonymous {
+		directives[0] = "public"
+	}
+	if rule.MaxAgeSeconds > 0 {
+		directives = append(directives, "max-age="+strconv.Itoa(rule.MaxAgeSeconds))
+	} else {
+		directives = append(directives, "no-cache")
+	}
+	if rule.StaleWhileRevalidateSeconds > 0 {
+		directives = append(directives, "stale-while-revalidate="+strconv.Itoa(rule.StaleWhileRevalidateSeconds))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// etagMatches reports whether an If-None-Match header names etag, comparing weakly as RFC 9110
+// requires for If-None-Match.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheWriter holds back a response body until its ETag is known. A handler that flushes is streaming
+// and is passed through instead.
+type cacheWriter struct {
+	gin.ResponseWriter
+	buf      []byte
+	streamed bool
+}
+
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	if w.streamed {
+		return w.ResponseWriter.Write(data)
+	}
+	w.buf = append(w.buf, data...)
+	return len(data), nil
+}
+
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *cacheWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+func (w *cacheWriter) Flush() {
+	if !w.streamed {
+		w.streamed = true
+		if len(w.buf) > 0 {
+			w.ResponseWriter.Write(w.buf)
+			w.buf = nil
+		}
+	}
+	w.ResponseWriter.Flush()
+}
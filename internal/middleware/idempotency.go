@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Clients send IdempotencyKeyHeader with a unique value per logical operation; retries carrying the
+// same key get the first response back, marked with IdempotentReplayedHeader, instead of re-running it.
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyWriter keeps a copy of the response body so it can be stored for replay.
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes a mutation route safe to retry. Requests without an Idempotency-Key pass
+// through; the first request with a key runs and its response is kept for ttl, later requests with the
+// same key and body replay it, and reusing a key for a different body is rejected. Keys are scoped to
+// the authenticated user and route. Failed requests release the key so the client can retry.
+func IdempotencyMiddleware(ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.Error(errors.NewAppError("idempotency key too long", errors.MsgInvalidParameters, errors.ErrCodeInvalidParameters, http.StatusBadRequest, nil))
+			c.Abort()
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.Error(errors.NewAppError("unreadable request body", errors.MsgInvalidParameters, errors.ErrCodeInvalidParameters, http.StatusBadRequest, err))
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		hash := sha256.New()
+		hash.Write([]byte(c.Request.Method + "\n" + c.Request.URL.Path + "\n"))
+		hash.Write(body)
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		userID := c.GetString("user_id")
+		if userID == "" {
+			userID = "anonymous"
+		}
+		storeKey := cache.IdempotencyKey(userID+":"+c.Request.Method+":"+c.FullPath(), key)
+
+		existing, err := cache.ReserveIdempotencyKey(c, storeKey, fingerprint, ttl)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to reserve idempotency key: user_id=%s, path=%s, error=%v", userID, c.Request.URL.Path, err)
+			c.Error(errors.NewAppError("idempotency store unavailable", errors.MsgServiceUnavailable, errors.ErrCodeServiceUnavailable, http.StatusServiceUnavailable, err))
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			switch {
+			case existing.Fingerprint != fingerprint:
+				c.Error(errors.NewAppError("idempotency key reused with a different request: user_id="+userID, errors.MsgIdempotencyKeyReused, errors.ErrCodeIdempotencyKeyReused, http.StatusUnprocessableEntity, nil))
+			case !existing.Completed:
+				c.Error(errors.NewAppError("idempotency key still in progress: user_id="+userID, errors.MsgIdempotencyInProgress, errors.ErrCodeIdempotencyInProgress, http.StatusConflict, nil))
+			default:
+				logger.GlobalLogger.Printf("Replaying idempotent response: user_id=%s, path=%s, status=%d", userID, c.Request.URL.Path, existing.Status)
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(existing.Status, existing.ContentType, existing.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		// The request context may already be cancelled once the handler returns
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := cache.ReleaseIdempotencyKey(context.Background(), storeKey); err != nil {
+				logger.GlobalLogger.Warnf("Failed to release idempotency key: user_id=%s, error=%v", userID, err)
+			}
+		}()
+
+		c.Next()
+
+		// Errors are rendered later by ErrorHandler and server failures are worth retrying, so neither is stored
+		if len(c.Errors) > 0 || writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		response := &cache.IdempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		}
+		if err := cache.CompleteIdempotencyKey(context.Background(), storeKey, response, ttl); err != nil {
+			logger.GlobalLogger.Errorf("Failed to store idempotent response: user_id=%s, error=%v", userID, err)
+			return
+		}
+		completed = true
+	}
+}
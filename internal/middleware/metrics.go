@@ -9,14 +9,21 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// MetricsMiddleware counts and times requests by method, route template and status. Labeling by
+// template rather than path keeps IDs out of the label values; requests matching no route share the
+// "unmatched" label.
 func MetricsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		c.Next()
 		duration := time.Since(start).Seconds()
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
 		status := strconv.Itoa(c.Writer.Status())
-		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, c.Request.URL.Path, status).Inc()
-		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, c.Request.URL.Path, status).Observe(duration)
+		metrics.HTTPRequestsTotal.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(duration)
 
 		// Track cache hits/misses (based on context values set by handlers)
 		if cacheHit, exists := c.Get("cache_hit"); exists && cacheHit.(bool) {
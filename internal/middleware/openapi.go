@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/openapi"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxValidatedResponseBytes caps how much of a response is kept for validation; larger responses
+// aren't checked.
+const maxValidatedResponseBytes = 1 << 20
+
+// schemaWriter keeps a copy of the response body, up to a limit, for validation.
+type schemaWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *schemaWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *schemaWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *schemaWriter) keep(data []byte) {
+	if w.truncated || w.body.Len()+len(data) > maxValidatedResponseBytes {
+		w.truncated = true
+		return
+	}
+	w.body.Write(data)
+}
+
+// OpenAPIValidationMiddleware checks requests against the swagger spec and rejects those that don't
+// match with 422, listing each offending field as a JSON pointer. A route under the spec's base path
+// that the spec doesn't document is rejected the same way, so a handler added without annotations
+// fails loudly in dev rather than going unchecked. With responses set, successful JSON responses are
+// checked too; a mismatch is logged rather than returned, since the client already got a usable
+// answer. Requests that match no route, and routes outside the base path, pass through.
+func OpenAPIValidationMiddleware(validator *openapi.Validator, responses bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" || !validator.Describes(route) {
+			c.Next()
+			return
+		}
+		op := validator.Operation(c.Request.Method, route)
+		if op == nil {
+			violations := []openapi.Violation{{In: "route", Path: c.Request.Method + " " + route, Message: "is not documented in the API spec"}}
+			metrics.SchemaViolationsTotal.WithLabelValues(route, "request").Inc()
+			logger.GlobalLogger.Warnf("Route missing from API spec: method=%s, route=%s", c.Request.Method, route)
+			c.Error(errors.NewAppError("route missing from API spec", errors.MsgSchemaViolation, errors.ErrCodeSchemaViolation, http.StatusUnprocessableEntity, nil).WithDetails(violations))
+			c.Abort()
+			return
+		}
+
+		var body []byte
+		if op.HasBody() && c.Request.Body != nil && isJSON(c.ContentType()) {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				c.Error(errors.NewAppError("unreadable request body", errors.MsgInvalidParameters, errors.ErrCodeInvalidParameters, http.StatusBadRequest, err))
+				c.Abort()
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+		if violations := validator.ValidateRequest(op, c.Request, pathParams, body); len(violations) > 0 {
+			metrics.SchemaViolationsTotal.WithLabelValues(route, "request").Inc()
+			logger.GlobalLogger.Warnf("Request does not match API spec: method=%s, route=%s, violations=%v", c.Request.Method, route, violations)
+			c.Error(errors.NewAppError("request does not match API spec", errors.MsgSchemaViolation, errors.ErrCodeSchemaViolation, http.StatusUnprocessableEntity, nil).WithDetails(violations))
+			c.Abort()
+			return
+		}
+
+		if !responses {
+			c.Next()
+			return
+		}
+		writer := &schemaWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		status := writer.Status()
+		contentType := writer.Header().Get("Content-Type")
+		if status < 200 || status >= 300 || writer.truncated || !isJSONResponse(contentType) {
+			return
+		}
+		if violations := validator.ValidateResponse(op, status, contentType, writer.body.Bytes()); len(violations) > 0 {
+			metrics.SchemaViolationsTotal.WithLabelValues(route, "response").Inc()
+			logger.GlobalLogger.Errorf("Response does not match API spec: method=%s, route=%s, status=%d, violations=%v", c.Request.Method, route, status, violations)
+		}
+	}
+}
+
+// isJSON accepts an empty content type, which clients commonly omit on JSON bodies.
+func isJSON(contentType string) bool {
+	return contentType == "" || strings.Contains(contentType, "json")
+}
+
+// isJSONResponse matches a single JSON document, leaving out streams such as application/x-ndjson.
+func isJSONResponse(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
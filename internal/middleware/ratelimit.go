@@ -1,76 +1,98 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
-	"sync"
+	"strconv"
 	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+
 	"github.com/gin-gonic/gin"
-	"golang.org/x/time/rate"
 )
 
-// RateLimiter holds a map of IP-based limiters
-type RateLimiter struct {
-	limiters map[string]*rate.Limiter
-	mu       sync.RWMutex
-	rate     rate.Limit
-	burst    int
-}
+// Rate limit headers describe the tightest limit that applied to the request; Reset is a unix time.
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
 
-// NewRateLimiter creates a new rate limiter with specified rate and burst
-func NewRateLimiter(r rate.Limit, b int) *RateLimiter {
-	return &RateLimiter{
-		limiters: make(map[string]*rate.Limiter),
-		rate:     r,
-		burst:    b,
-	}
+// rateLimitSubject is one sliding window a request is counted against.
+type rateLimitSubject struct {
+	key   string
+	limit int
 }
 
-// getLimiter returns or creates a limiter for the given IP
-func (rl *RateLimiter) getLimiter(ip string) *rate.Limiter {
-	rl.mu.RLock()
-	limiter, exists := rl.limiters[ip]
-	rl.mu.RUnlock()
+// RateLimitMiddleware applies the configured sliding-window limits of a route group, per user and per
+// client IP, with the windows kept in Redis so limits hold across restarts and replicas. Place it after
+// AuthMiddleware for per-user limits to apply. If Redis is unavailable requests are let through.
+func RateLimitMiddleware(cfg *config.Config, group string) gin.HandlerFunc {
+	// costUnitsPerToken converts request cost into extra hits, so expensive requests use up the window faster
+	costUnitsPerToken := cfg.RequestCost.UnitsPerRateLimitToken
 
-	if !exists {
-		rl.mu.Lock()
-		limiter = rate.NewLimiter(rl.rate, rl.burst)
-		rl.limiters[ip] = limiter
-		rl.mu.Unlock()
-	}
-
-	return limiter
-}
-
-// RateLimitMiddleware applies rate limiting based on client IP
-func RateLimitMiddleware(rl *RateLimiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := c.ClientIP()
-		limiter := rl.getLimiter(ip)
+		// Limits are read per request so reloaded config applies without a restart
+		limits := cfg.Live().RateLimit
+		rule := limits.Default
+		if groupRule, ok := limits.Groups[group]; ok {
+			rule = groupRule
+		}
+		window := time.Duration(limits.WindowSeconds) * time.Second
 
-		// Check if request is allowed
-		if !limiter.Allow() {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "rate limit exceeded",
-			})
-			c.Abort()
-			return
+		var subjects []rateLimitSubject
+		if userID := c.GetString("user_id"); userID != "" && rule.PerUser > 0 {
+			subjects = append(subjects, rateLimitSubject{key: cache.RateLimitKey(group, "user:"+userID), limit: rule.PerUser})
+		}
+		if rule.PerIP > 0 {
+			subjects = append(subjects, rateLimitSubject{key: cache.RateLimitKey(group, "ip:"+c.ClientIP()), limit: rule.PerIP})
+		}
+
+		tightestRemaining := -1
+		for _, subject := range subjects {
+			result, err := cache.AllowRequest(c, subject.key, subject.limit, window)
+			if err != nil {
+				logger.GlobalLogger.Warnf("Rate limit not checked: key=%s, error=%v", subject.key, err)
+				continue
+			}
+			remaining := subject.limit - result.Count
+			if tightestRemaining < 0 || remaining < tightestRemaining || !result.Allowed {
+				tightestRemaining = remaining
+				c.Header(RateLimitLimitHeader, strconv.Itoa(subject.limit))
+				c.Header(RateLimitRemainingHeader, strconv.Itoa(max(remaining, 0)))
+				c.Header(RateLimitResetHeader, strconv.FormatInt(result.Reset.Unix(), 10))
+			}
+			if !result.Allowed {
+				retryAfter := int(time.Until(result.Reset).Seconds()) + 1
+				c.Header(RetryAfterHeader, strconv.Itoa(retryAfter))
+				logger.GlobalLogger.Warnf("Rate limit exceeded: key=%s, limit=%d, client_ip=%s", subject.key, subject.limit, c.ClientIP())
+				c.Error(errors.NewAppError("rate limit exceeded: key="+subject.key, errors.MsgRateLimited, errors.ErrCodeRateLimited, http.StatusTooManyRequests, nil))
+				c.Abort()
+				return
+			}
 		}
 
 		c.Next()
-	}
-}
 
-// Cleanup removes old limiters periodically
-func (rl *RateLimiter) Cleanup() {
-	for {
-		time.Sleep(time.Hour)
-		rl.mu.Lock()
-		for ip, limiter := range rl.limiters {
-			// Remove limiters that haven't been used recently
-			if limiter.Tokens() == float64(rl.burst) {
-				delete(rl.limiters, ip)
+		// Charge heavyweight requests (e.g. fresh CoreLogic fetches) extra hits after the fact
+		meter := cost.FromContext(c)
+		if meter == nil || costUnitsPerToken <= 0 {
+			return
+		}
+		extra := int(meter.Units() / costUnitsPerToken)
+		for _, subject := range subjects {
+			if extra <= 0 {
+				break
+			}
+			// Charged even when the client has gone away meanwhile
+			if err := cache.ChargeRequests(context.WithoutCancel(c), subject.key, min(extra, subject.limit), window); err != nil {
+				logger.GlobalLogger.Warnf("Rate limit charge failed: key=%s, error=%v", subject.key, err)
 			}
 		}
-		rl.mu.Unlock()
 	}
 }
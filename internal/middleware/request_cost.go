@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"strconv"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestCostHeader reports the cost units consumed by a request.
+const RequestCostHeader = "X-Request-Cost"
+
+// costWriter stamps the request cost header just before the response headers are flushed.
+type costWriter struct {
+	gin.ResponseWriter
+	meter *cost.Meter
+}
+
+func (w *costWriter) stamp() {
+	if !w.ResponseWriter.Written() {
+		w.Header().Set(RequestCostHeader, strconv.FormatInt(w.meter.Units(), 10))
+	}
+}
+
+func (w *costWriter) WriteHeaderNow() {
+	w.stamp()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *costWriter) Write(data []byte) (int, error) {
+	w.stamp()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *costWriter) WriteString(s string) (int, error) {
+	w.stamp()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// RequestCostMiddleware meters cache, database and CoreLogic work per request, returns the
+// total in the X-Request-Cost header and records it for usage reporting.
+func RequestCostMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		meter := cost.Attach(c)
+		c.Writer = &costWriter{ResponseWriter: c.Writer, meter: meter}
+
+		c.Next()
+
+		// Bodiless responses are flushed after the middleware chain returns
+		if !c.Writer.Written() {
+			c.Header(RequestCostHeader, strconv.FormatInt(meter.Units(), 10))
+		}
+		metrics.RequestCostUnitsTotal.WithLabelValues(c.Request.Method, c.FullPath()).Add(float64(meter.Units()))
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"regexp"
+
+	"homeinsight-properties/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// validRequestID bounds client-supplied IDs so they are safe to log and forward.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware reuses the caller's X-Request-ID or generates one, stores it on the gin
+// context and the request context, and echoes it in the response so logs can be correlated.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !validRequestID.MatchString(id) {
+			id = requestid.New()
+		}
+		c.Set(requestid.GinKey, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
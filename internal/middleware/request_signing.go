@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers a signing partner sends with a mutation request. The signature is the hex HMAC-SHA256,
+// keyed by the partner secret, of: METHOD \n request URI \n timestamp \n nonce \n hex SHA-256 of the body.
+const (
+	SignatureKeyIDHeader     = "X-Signature-Key-Id"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+	SignatureNonceHeader     = "X-Signature-Nonce"
+	SignatureHeader          = "X-Signature"
+)
+
+const (
+	minNonceLength = 16
+	maxNonceLength = 128
+)
+
+// SignedRequestPayload builds the string a partner signs for a request.
+func SignedRequestPayload(method, requestURI, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	return method + "\n" + requestURI + "\n" + timestamp + "\n" + nonce + "\n" + hex.EncodeToString(bodyHash[:])
+}
+
+// RequestSigningMiddleware verifies the optional signed-nonce scheme on every request that isn't
+// GET, HEAD or OPTIONS.
+// Unsigned requests pass through to normal authentication; signed ones must carry a known key id,
+// a timestamp within the tolerance, a valid signature and a nonce not seen inside that window.
+func RequestSigningMiddleware(cfg *config.Config) gin.HandlerFunc {
+	secrets := make(map[string]config.SigningPartner, len(cfg.RequestSigning.Partners))
+	for _, p := range cfg.RequestSigning.Partners {
+		if p.KeyID == "" || p.Secret == "" {
+			continue
+		}
+		secrets[p.KeyID] = p
+	}
+	tolerance := time.Duration(cfg.RequestSigning.TimestampToleranceSeconds) * time.Second
+
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		keyID := c.GetHeader(SignatureKeyIDHeader)
+		if keyID == "" && c.GetHeader(SignatureHeader) == "" {
+			c.Next()
+			return
+		}
+
+		reject := func(reason string) {
+			logger.GlobalLogger.Warnf("Signed request rejected: key_id=%s, path=%s, client_ip=%s, reason=%s", keyID, c.Request.URL.Path, c.ClientIP(), reason)
+			c.Error(errors.NewAppError("invalid request signature: "+reason, errors.MsgInvalidSignature, errors.ErrCodeInvalidSignature, http.StatusUnauthorized, nil))
+			c.Abort()
+		}
+
+		partner, ok := secrets[keyID]
+		if !ok {
+			reject("unknown key id")
+			return
+		}
+
+		timestamp := c.GetHeader(SignatureTimestampHeader)
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			reject("malformed timestamp")
+			return
+		}
+		skew := time.Since(time.Unix(unix, 0))
+		if skew > tolerance || skew < -tolerance {
+			reject("timestamp outside tolerance")
+			return
+		}
+
+		nonce := c.GetHeader(SignatureNonceHeader)
+		if len(nonce) < minNonceLength || len(nonce) > maxNonceLength {
+			reject("nonce must be 16-128 characters")
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				reject("unreadable body")
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		mac := hmac.New(sha256.New, []byte(partner.Secret))
+		mac.Write([]byte(SignedRequestPayload(c.Request.Method, c.Request.URL.RequestURI(), timestamp, nonce, body)))
+		signature, err := hex.DecodeString(c.GetHeader(SignatureHeader))
+		if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+			reject("signature mismatch")
+			return
+		}
+
+		// Only verified requests claim a nonce, so forged requests can't burn a partner's nonces.
+		// The nonce outlives both edges of the timestamp window, after which the timestamp check rejects it.
+		fresh, err := cache.ClaimNonce(c, keyID, nonce, 2*tolerance)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to record request nonce: key_id=%s, error=%v", keyID, err)
+			c.Error(errors.NewAppError("nonce store unavailable", errors.MsgServiceUnavailable, errors.ErrCodeServiceUnavailable, http.StatusServiceUnavailable, err))
+			c.Abort()
+			return
+		}
+		if !fresh {
+			logger.GlobalLogger.Warnf("Replayed request rejected: partner=%s, key_id=%s, path=%s, client_ip=%s", partner.Name, keyID, c.Request.URL.Path, c.ClientIP())
+			c.Error(errors.NewAppError("replayed request nonce: key_id="+keyID, errors.MsgReplayedRequest, errors.ErrCodeReplayedRequest, http.StatusConflict, nil))
+			c.Abort()
+			return
+		}
+
+		c.Set("signing_partner", partner.Name)
+		c.Next()
+	}
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole rejects requests whose token does not carry the given role. It must run after AuthMiddleware.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") != role {
+			appErr := errors.NewAppError(
+				"missing required role: "+role,
+				errors.MsgForbidden,
+				errors.ErrCodeForbidden,
+				http.StatusForbidden,
+				nil,
+			)
+			logger.GlobalLogger.Warnf("Forbidden: user_id=%s, path=%s, required_role=%s", c.GetString("user_id"), c.Request.URL.Path, role)
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAnyRole rejects requests whose token carries none of the given roles. It must run after
+// AuthMiddleware.
+func RequireAnyRole(roles ...string) gin.HandlerFunc {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+	required := strings.Join(roles, ",")
+	return func(c *gin.Context) {
+		if !allowed[c.GetString("role")] {
+			appErr := errors.NewAppError(
+				"missing required role: one of "+required,
+				errors.MsgForbidden,
+				errors.ErrCodeForbidden,
+				http.StatusForbidden,
+				nil,
+			)
+			logger.GlobalLogger.Warnf("Forbidden: user_id=%s, path=%s, required_roles=%s", c.GetString("user_id"), c.Request.URL.Path, required)
+			c.Error(appErr)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"homeinsight-properties/internal/usage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UsageMiddleware counts the request against the daily request quota of the caller's organization
+// and API key, refusing it with 429 until midnight UTC once the quota is used up. Place it after the
+// middleware that authenticates the caller.
+func UsageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := usage.Take(c, usage.Requests); err != nil {
+			now := time.Now()
+			c.Header(RetryAfterHeader, strconv.Itoa(int(usage.ResetsAt(now).Sub(now).Seconds())+1))
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
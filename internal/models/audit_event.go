@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Security events recorded in the audit log.
+const (
+	AuditEventLoginSucceeded      = "login.succeeded"
+	AuditEventLoginFailed         = "login.failed"
+	AuditEventLoginLocked         = "login.locked"
+	AuditEventPasswordChanged     = "password.changed"
+	AuditEventMemberRoleChanged   = "member.role_changed"
+	AuditEventMemberRemoved       = "member.removed"
+	AuditEventPropertyDeleted     = "property.deleted"
+	AuditEventPropertyPurged      = "property.purged"
+	AuditEventPropertyBulkDeleted = "property.bulk_deleted"
+	AuditEventPropertyBulkUpdated = "property.bulk_updated"
+	AuditEventCacheFlushed        = "cache.flushed"
+)
+
+// AuditEvent records a security-relevant action: who did it, from where, under which request, and
+// what it acted on. Events are only ever appended. ActorID is empty for failed sign-ins to unknown
+// accounts.
+type AuditEvent struct {
+	ID         primitive.ObjectID     `json:"id" bson:"_id"`
+	Type       string                 `json:"type" bson:"type"`
+	ActorID    string                 `json:"actorId,omitempty" bson:"actorId,omitempty"`
+	ActorRole  string                 `json:"actorRole,omitempty" bson:"actorRole,omitempty"`
+	IP         string                 `json:"ip,omitempty" bson:"ip,omitempty"`
+	RequestID  string                 `json:"requestId,omitempty" bson:"requestId,omitempty"`
+	TargetType string                 `json:"targetType,omitempty" bson:"targetType,omitempty"`
+	TargetID   string                 `json:"targetId,omitempty" bson:"targetId,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty" bson:"details,omitempty"`
+	OccurredAt time.Time              `json:"occurredAt" bson:"occurredAt"`
+}
+
+// AuditEventFilter narrows an audit log query. From and To are RFC 3339 timestamps or UTC days
+// (YYYY-MM-DD, inclusive).
+type AuditEventFilter struct {
+	From    string
+	To      string
+	Type    string
+	ActorID string
+}
+
+type AuditEventsResponse struct {
+	Data     []AuditEvent   `json:"data"`
+	Metadata PaginationMeta `json:"metadata"`
+}
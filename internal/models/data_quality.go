@@ -0,0 +1,28 @@
+package models
+
+// Data quality issues a property can be flagged with.
+const (
+	DataQualityMissingCoordinates  = "missing_coordinates"
+	DataQualityMissingBuildingArea = "missing_building_area"
+	DataQualityMissingTaxData      = "missing_tax_data"
+	DataQualityMissingYearBuilt    = "missing_year_built"
+	DataQualityMissingLotSize      = "missing_lot_size"
+	DataQualityMissingOwner        = "missing_owner"
+	DataQualityMissingLandUse      = "missing_land_use"
+)
+
+// DataQuality rates how complete a property's data is. Score runs from 0 to 100, less the weight of
+// each issue found; Issues lists them.
+type DataQuality struct {
+	Score  int      `json:"score" bson:"score"`
+	Issues []string `json:"issues,omitempty" bson:"issues,omitempty"`
+}
+
+// DataQualityReport summarizes the data quality of an organization's properties. Unscored counts
+// properties not written since scoring was introduced; they are scored the next time they are.
+type DataQualityReport struct {
+	Total        int64            `json:"total"`
+	Unscored     int64            `json:"unscored"`
+	AverageScore float64          `json:"averageScore"`
+	Issues       map[string]int64 `json:"issues"`
+}
@@ -0,0 +1,37 @@
+package models
+
+// Demographics are census tract statistics for the area around a property, from the American
+// Community Survey 5-year estimates. Figures the survey suppresses for the tract are left out;
+// rates are percentages rounded to one decimal.
+type Demographics struct {
+	PropertyID string `json:"propertyId"`
+	// CensusTract is the tract's 11-digit GEOID: state, county and tract number
+	CensusTract string              `json:"censusTract"`
+	Name        string              `json:"name"`
+	Source      string              `json:"source"`
+	Year        int                 `json:"year"`
+	Population  *int                `json:"population,omitempty"`
+	MedianAge   *float64            `json:"medianAge,omitempty"`
+	Households  *int                `json:"households,omitempty"`
+	Income      DemographicsIncome  `json:"income"`
+	Housing     DemographicsHousing `json:"housing"`
+}
+
+type DemographicsIncome struct {
+	MedianHousehold *int     `json:"medianHousehold,omitempty"`
+	PerCapita       *int     `json:"perCapita,omitempty"`
+	PovertyRate     *float64 `json:"povertyRate,omitempty"`
+}
+
+type DemographicsHousing struct {
+	Units             *int     `json:"units,omitempty"`
+	Occupied          *int     `json:"occupied,omitempty"`
+	Vacant            *int     `json:"vacant,omitempty"`
+	VacancyRate       *float64 `json:"vacancyRate,omitempty"`
+	OwnerOccupied     *int     `json:"ownerOccupied,omitempty"`
+	RenterOccupied    *int     `json:"renterOccupied,omitempty"`
+	OwnerOccupiedRate *float64 `json:"ownerOccupiedRate,omitempty"`
+	MedianHomeValue   *int     `json:"medianHomeValue,omitempty"`
+	MedianGrossRent   *int     `json:"medianGrossRent,omitempty"`
+	MedianYearBuilt   *int     `json:"medianYearBuilt,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Deprecation marks an endpoint, or one query parameter of it, as scheduled for removal.
+type Deprecation struct {
+	ID          string    `json:"id" example:"properties-offset-pagination"`
+	Method      string    `json:"method" example:"GET"`
+	Route       string    `json:"route" example:"/api/properties"`
+	Param       string    `json:"param,omitempty" example:"offset"` // empty deprecates the whole endpoint
+	Since       time.Time `json:"since"`
+	Sunset      time.Time `json:"sunset"`
+	Replacement string    `json:"replacement" example:"GET /api/properties?cursor="`
+	Link        string    `json:"link,omitempty"`
+}
+
+// DeprecationClientUsage is how often one client called a deprecated surface.
+type DeprecationClientUsage struct {
+	Client   string    `json:"client" example:"user:64f1c2a9e4b0a1b2c3d4e5f6"`
+	Calls    int64     `json:"calls"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// DeprecationReport lists the clients that still depend on a deprecation, most recently seen first.
+type DeprecationReport struct {
+	Deprecation
+	TotalCalls int64                    `json:"totalCalls"`
+	Clients    []DeprecationClientUsage `json:"clients"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Reasons two properties are flagged as likely duplicates.
+const (
+	// DuplicateReasonAddress: the same street, city, state and 5-digit ZIP once case and spacing are ignored
+	DuplicateReasonAddress = "address"
+	// DuplicateReasonClip: the same CoreLogic CLIP, carried in avmPropertyId
+	DuplicateReasonClip = "clip"
+)
+
+// DuplicateGroup is a set of properties sharing a normalized address or CLIP.
+type DuplicateGroup struct {
+	Reason      string   `bson:"-"`
+	Key         string   `bson:"_id"`
+	PropertyIDs []string `bson:"propertyIds"`
+}
+
+// DuplicateCandidate is a pair of properties that likely describe the same parcel, found by the
+// duplicate scan. PropertyIDs is sorted, so each pair is stored once.
+type DuplicateCandidate struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID       string             `json:"-" bson:"orgId,omitempty"`
+	PropertyIDs []string           `json:"propertyIds" bson:"propertyIds"`
+	Reasons     []string           `json:"reasons" bson:"reasons"`
+	Keys        []string           `json:"keys" bson:"keys"`
+	DetectedAt  time.Time          `json:"detectedAt" bson:"detectedAt"`
+}
+
+type DuplicatesResponse struct {
+	Data     []DuplicateCandidate `json:"data"`
+	Metadata PaginationMeta       `json:"metadata"`
+}
+
+// DuplicateScanResult is the result of a duplicate scan job.
+type DuplicateScanResult struct {
+	Groups        int `json:"groups"`
+	Candidates    int `json:"candidates"`
+	SkippedGroups int `json:"skippedGroups,omitempty"`
+}
+
+// MergePropertiesRequest merges MergeID into KeepID; the merged property moves to the trash.
+type MergePropertiesRequest struct {
+	KeepID  string `json:"keepId" binding:"required"`
+	MergeID string `json:"mergeId" binding:"required"`
+}
+
+// MergePropertiesResult describes a completed merge. FilledFields are the sections of the kept
+// property that were empty and taken from the merged one. Remapped counts the records per collection
+// moved to the kept property; Conflicts counts those left behind because the kept property already
+// has an equivalent one, such as an open listing.
+type MergePropertiesResult struct {
+	Property     *Property        `json:"property"`
+	MergedID     string           `json:"mergedId"`
+	FilledFields []string         `json:"filledFields"`
+	Remapped     map[string]int64 `json:"remapped"`
+	Conflicts    map[string]int64 `json:"conflicts,omitempty"`
+}
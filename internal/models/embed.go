@@ -0,0 +1,18 @@
+package models
+
+// EmbedPropertySummary is the minimal public payload served to partner widgets.
+type EmbedPropertySummary struct {
+	PropertyID           string  `json:"propertyId"`
+	StreetAddress        string  `json:"streetAddress"`
+	City                 string  `json:"city"`
+	State                string  `json:"state"`
+	ZipCode              string  `json:"zipCode"`
+	BedroomsCount        int     `json:"bedroomsCount"`
+	BathroomsCount       int     `json:"bathroomsCount"`
+	LivingAreaSquareFeet int     `json:"livingAreaSquareFeet"`
+	LotAreaAcres         float64 `json:"lotAreaAcres"`
+	YearBuilt            int     `json:"yearBuilt"`
+	AssessedValue        int     `json:"assessedValue"`
+	LastSaleDate         string  `json:"lastSaleDate"`
+	LastSaleAmount       int     `json:"lastSaleAmount"`
+}
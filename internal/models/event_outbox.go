@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Delivery state of an outbox entry.
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusPublished = "published"
+)
+
+// PropertyEvent is the message published to the event broker for a property change. OrgID is the
+// organization holding the property. Property is the state after the change without owner names and
+// mailing address, which consumers read from the API, and is omitted for deletions. Delivery is at
+// least once, so consumers should drop IDs they have already processed.
+type PropertyEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	OrgID      string    `json:"orgId"`
+	PropertyID string    `json:"propertyId"`
+	Property   *Property `json:"property,omitempty"`
+}
+
+// OutboxEvent is a property event waiting in the outbox until the broker acknowledges it. Payload is
+// the encoded PropertyEvent. NextAttemptAt also serves as the claim lease of the relay publishing it.
+type OutboxEvent struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	Type          string             `json:"type" bson:"type"`
+	PropertyID    string             `json:"propertyId" bson:"propertyId"`
+	Payload       string             `json:"payload" bson:"payload"`
+	Status        string             `json:"status" bson:"status"`
+	Attempts      int                `json:"attempts" bson:"attempts"`
+	LastError     string             `json:"lastError,omitempty" bson:"lastError,omitempty"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+	NextAttemptAt time.Time          `json:"nextAttemptAt" bson:"nextAttemptAt"`
+	PublishedAt   *time.Time         `json:"publishedAt,omitempty" bson:"publishedAt,omitempty"`
+	ExpiresAt     *time.Time         `json:"expiresAt,omitempty" bson:"expiresAt,omitempty"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// QueryExplanation summarizes MongoDB's explain output for a named repository query, for choosing
+// indexes and query hints.
+type QueryExplanation struct {
+	Query           string      `json:"query"`
+	Collection      string      `json:"collection"`
+	Hint            string      `json:"hint,omitempty"`
+	Indexes         []string    `json:"indexes"`
+	Stages          []string    `json:"stages"`
+	Returned        int64       `json:"nReturned"`
+	KeysExamined    int64       `json:"totalKeysExamined"`
+	DocsExamined    int64       `json:"totalDocsExamined"`
+	ExecutionTimeMS int64       `json:"executionTimeMillis"`
+	WinningPlan     interface{} `json:"winningPlan"`
+}
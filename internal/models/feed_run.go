@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Feed run statuses. A run whose job gives up is failed; its file is kept so it can be uploaded again.
+const (
+	FeedRunStatusQueued    = "queued"
+	FeedRunStatusRunning   = "running"
+	FeedRunStatusCompleted = "completed"
+	FeedRunStatusFailed    = "failed"
+)
+
+// FeedRun records the processing of one file uploaded to a data feed. Processed counts the records gone
+// through so far: Created and Updated those applied to properties, Failed those rejected, each with an
+// entry in RowErrors up to the configured maximum.
+type FeedRun struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID        string             `json:"orgId,omitempty" bson:"orgId,omitempty"`
+	Provider     string             `json:"provider" bson:"provider"`
+	Format       string             `json:"format" bson:"format"`
+	FileName     string             `json:"fileName,omitempty" bson:"fileName,omitempty"`
+	FileKey      string             `json:"-" bson:"fileKey"`
+	SizeBytes    int64              `json:"sizeBytes" bson:"sizeBytes"`
+	SHA256       string             `json:"sha256" bson:"sha256"`
+	Status       string             `json:"status" bson:"status"`
+	Processed    int64              `json:"processed" bson:"processed"`
+	Created      int64              `json:"created" bson:"created"`
+	Updated      int64              `json:"updated" bson:"updated"`
+	Failed       int64              `json:"failed" bson:"failed"`
+	RowErrors    []FeedRowError     `json:"rowErrors,omitempty" bson:"rowErrors,omitempty"`
+	Error        string             `json:"error,omitempty" bson:"error,omitempty"`
+	JobID        string             `json:"jobId,omitempty" bson:"jobId,omitempty"`
+	UploadedBy   string             `json:"uploadedBy,omitempty" bson:"uploadedBy,omitempty"`
+	UploaderRole string             `json:"-" bson:"uploaderRole,omitempty"`
+	UploadedAt   time.Time          `json:"uploadedAt" bson:"uploadedAt"`
+	UpdatedAt    time.Time          `json:"updatedAt" bson:"updatedAt"`
+	FinishedAt   *time.Time         `json:"finishedAt,omitempty" bson:"finishedAt,omitempty"`
+}
+
+// FeedRowError explains why one record of a feed wasn't applied. Row counts records from 1, not file
+// lines; PropertyID is set when the record got far enough to have one.
+type FeedRowError struct {
+	Row        int    `json:"row" bson:"row"`
+	PropertyID string `json:"propertyId,omitempty" bson:"propertyId,omitempty"`
+	Error      string `json:"error" bson:"error"`
+}
+
+// FeedRunProgress is what a processed batch of records adds to a run.
+type FeedRunProgress struct {
+	Processed int64
+	Created   int64
+	Updated   int64
+	Errors    []FeedRowError
+}
+
+// FeedRunsResponse is a page of feed runs.
+type FeedRunsResponse struct {
+	Data     []FeedRun      `json:"data"`
+	Metadata PaginationMeta `json:"metadata"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// HazardRisk is a property's natural hazard exposure, looked up at its parcel coordinates when it
+// is fetched from the data provider. Scores run from 1 (lowest) to 5 (highest) and are left out when
+// the hazard provider has no rating.
+type HazardRisk struct {
+	// FloodZone is the FEMA flood zone designation, such as AE, VE or X
+	FloodZone        string `json:"floodZone,omitempty" bson:"floodZone,omitempty"`
+	FloodZoneSubtype string `json:"floodZoneSubtype,omitempty" bson:"floodZoneSubtype,omitempty"`
+	// SpecialFloodHazardArea is set in zones with a 1% annual chance of flooding, where federally
+	// backed mortgages require flood insurance
+	SpecialFloodHazardArea bool      `json:"specialFloodHazardArea" bson:"specialFloodHazardArea"`
+	FloodRiskScore         int       `json:"floodRiskScore,omitempty" bson:"floodRiskScore,omitempty"`
+	WildfireRiskScore      int       `json:"wildfireRiskScore,omitempty" bson:"wildfireRiskScore,omitempty"`
+	Source                 string    `json:"source" bson:"source"`
+	RetrievedAt            time.Time `json:"retrievedAt" bson:"retrievedAt"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// Health statuses, of an instance and of each dependency it checks.
+const (
+	HealthOK       = "ok"
+	HealthDegraded = "degraded"
+	HealthDown     = "down"
+)
+
+// ComponentHealth is the outcome of checking one dependency.
+type ComponentHealth struct {
+	Name      string      `json:"name"`
+	Status    string      `json:"status"`
+	Critical  bool        `json:"critical"`
+	LatencyMS int64       `json:"latencyMs"`
+	Error     string      `json:"error,omitempty"`
+	Detail    interface{} `json:"detail,omitempty"`
+}
+
+// HealthReport is the readiness of an instance. It is down when a critical dependency is down, and
+// degraded while serving with some dependency impaired.
+type HealthReport struct {
+	Status     string            `json:"status"`
+	Degraded   bool              `json:"degraded"`
+	Components []ComponentHealth `json:"components"`
+}
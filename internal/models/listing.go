@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Listing statuses. A property has at most one active or pending listing at a time.
+const (
+	ListingStatusActive  = "active"
+	ListingStatusPending = "pending"
+	ListingStatusSold    = "sold"
+)
+
+// ListingAgent is the agent representing the seller.
+type ListingAgent struct {
+	Name          string `json:"name" bson:"name" binding:"required" example:"Jane Doe"`
+	Email         string `json:"email,omitempty" bson:"email,omitempty" binding:"omitempty,email"`
+	Phone         string `json:"phone,omitempty" bson:"phone,omitempty"`
+	Brokerage     string `json:"brokerage,omitempty" bson:"brokerage,omitempty"`
+	LicenseNumber string `json:"licenseNumber,omitempty" bson:"licenseNumber,omitempty"`
+}
+
+// Listing is a property offered for sale. Listings are kept after they close, so a property's
+// listings form its sales history alongside the assessor record.
+type Listing struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID       string             `json:"orgId,omitempty" bson:"orgId,omitempty"`
+	PropertyID  string             `json:"propertyId" bson:"propertyId"`
+	ListPrice   int64              `json:"listPrice" bson:"listPrice" example:"450000"`
+	Status      string             `json:"status" bson:"status" example:"active"`
+	Description string             `json:"description,omitempty" bson:"description,omitempty"`
+	Agent       ListingAgent       `json:"agent" bson:"agent"`
+	ListedAt    time.Time          `json:"listedAt" bson:"listedAt"`
+	SoldAt      *time.Time         `json:"soldAt,omitempty" bson:"soldAt,omitempty"`
+	SoldPrice   int64              `json:"soldPrice,omitempty" bson:"soldPrice,omitempty"`
+	CreatedBy   string             `json:"createdBy" bson:"createdBy"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+// ListingRequest is the body for creating or replacing a listing. ListedAt defaults to now.
+type ListingRequest struct {
+	ListPrice   int64        `json:"listPrice" binding:"required" example:"450000"`
+	Status      string       `json:"status" binding:"required" example:"active"`
+	Description string       `json:"description"`
+	Agent       ListingAgent `json:"agent" binding:"required"`
+	ListedAt    *time.Time   `json:"listedAt"`
+	SoldPrice   int64        `json:"soldPrice"`
+}
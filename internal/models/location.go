@@ -0,0 +1,12 @@
+package models
+
+// LocationCount is one entry of the state, city and zip code browse hierarchy with the number of live
+// properties under it.
+type LocationCount struct {
+	Name          string `json:"name" bson:"_id" example:"SAN DIEGO"`
+	PropertyCount int64  `json:"propertyCount" bson:"count"`
+}
+
+type LocationsResponse struct {
+	Data []LocationCount `json:"data"`
+}
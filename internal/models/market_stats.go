@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// MarketStats summarizes the properties of a zip code for market dashboards. Sale figures come from
+// each property's last market sale.
+type MarketStats struct {
+	ZipCode             string            `json:"zipCode" example:"92101"`
+	PropertyCount       int64             `json:"propertyCount"`
+	SalesCount          int64             `json:"salesCount"`
+	MedianSalePrice     float64           `json:"medianSalePrice" example:"615000"`
+	AveragePricePerSqft float64           `json:"averagePricePerSqft" example:"412.5"`
+	DaysSinceLastSale   []HistogramBucket `json:"daysSinceLastSale"`
+	YearBuilt           []HistogramBucket `json:"yearBuilt"`
+	ComputedAt          time.Time         `json:"computedAt"`
+}
+
+// HistogramBucket counts the values in [Min, Max). Max is omitted for the open-ended last bucket.
+type HistogramBucket struct {
+	Label string `json:"label" example:"90-179"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max,omitempty"`
+	Count int64  `json:"count"`
+}
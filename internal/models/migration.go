@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Migration run statuses. A failed run is resumed from its checkpoint when launched again.
+const (
+	MigrationStatusQueued    = "queued"
+	MigrationStatusRunning   = "running"
+	MigrationStatusCompleted = "completed"
+	MigrationStatusFailed    = "failed"
+)
+
+// MigrationRun records the progress of one run of a data migration. Processed counts the documents
+// gone through so far, Errors those that couldn't be migrated; Total is estimated at launch.
+type MigrationRun struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id"`
+	Name         string             `json:"name" bson:"name"`
+	Status       string             `json:"status" bson:"status"`
+	Processed    int64              `json:"processed" bson:"processed"`
+	Total        int64              `json:"total" bson:"total"`
+	Errors       int64              `json:"errors" bson:"errors"`
+	RecentErrors []string           `json:"recentErrors,omitempty" bson:"recentErrors,omitempty"`
+	Error        string             `json:"error,omitempty" bson:"error,omitempty"`
+	Checkpoint   string             `json:"-" bson:"checkpoint,omitempty"`
+	Fence        int64              `json:"-" bson:"fence,omitempty"`
+	JobID        string             `json:"jobId,omitempty" bson:"jobId,omitempty"`
+	StartedBy    string             `json:"startedBy,omitempty" bson:"startedBy,omitempty"`
+	StartedAt    time.Time          `json:"startedAt" bson:"startedAt"`
+	UpdatedAt    time.Time          `json:"updatedAt" bson:"updatedAt"`
+	FinishedAt   *time.Time         `json:"finishedAt,omitempty" bson:"finishedAt,omitempty"`
+}
+
+// MigrationInfo describes a registered migration and its latest run.
+type MigrationInfo struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	LatestRun   *MigrationRun `json:"latestRun,omitempty"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Neighborhood describes the area around a property, looked up at its parcel coordinates when the
+// property is fetched from the data provider.
+type Neighborhood struct {
+	Walkability *Walkability `json:"walkability,omitempty" bson:"walkability,omitempty"`
+}
+
+// Walkability is how well a property's area is served on foot. WalkScore runs from 0, where
+// errands need a car, to 100, where daily errands don't.
+type Walkability struct {
+	WalkScore        int              `json:"walkScore" bson:"walkScore"`
+	Description      string           `json:"description" bson:"description"`
+	RadiusMeters     int              `json:"radiusMeters" bson:"radiusMeters"`
+	PointsOfInterest PointsOfInterest `json:"pointsOfInterest" bson:"pointsOfInterest"`
+	Source           string           `json:"source" bson:"source"`
+	RetrievedAt      time.Time        `json:"retrievedAt" bson:"retrievedAt"`
+}
+
+// PointsOfInterest counts the amenities within Walkability.RadiusMeters of a property.
+type PointsOfInterest struct {
+	Grocery     int `json:"grocery" bson:"grocery"`
+	Restaurants int `json:"restaurants" bson:"restaurants"`
+	Shopping    int `json:"shopping" bson:"shopping"`
+	Schools     int `json:"schools" bson:"schools"`
+	Parks       int `json:"parks" bson:"parks"`
+	Transit     int `json:"transit" bson:"transit"`
+	Healthcare  int `json:"healthcare" bson:"healthcare"`
+}
+
+// KeepEnrichments carries hazard risk and neighborhood data over from a property's previous version
+// wherever a refresh couldn't look them up again.
+func (p *Property) KeepEnrichments(previous *Property) {
+	if p.Hazard == nil {
+		p.Hazard = previous.Hazard
+	}
+	if previous.Neighborhood == nil {
+		return
+	}
+	if p.Neighborhood == nil {
+		p.Neighborhood = &Neighborhood{}
+	}
+	if p.Neighborhood.Walkability == nil {
+		p.Neighborhood.Walkability = previous.Neighborhood.Walkability
+	}
+}
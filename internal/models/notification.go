@@ -0,0 +1,77 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Digest frequencies a user can choose for property-change alerts.
+const (
+	DigestInstant = "instant"
+	DigestHourly  = "hourly"
+	DigestDaily   = "daily"
+)
+
+type NotificationPreference struct {
+	ID              primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	UserID          string             `json:"userId" bson:"userId"`
+	DigestFrequency string             `json:"digestFrequency" bson:"digestFrequency"`
+	UpdatedAt       time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+type UpdateNotificationPreferenceRequest struct {
+	DigestFrequency string `json:"digestFrequency" binding:"required,oneof=instant hourly daily" example:"daily"`
+}
+
+// AlertTypeOwnershipChange marks a property alert raised because a property a user follows changed owners.
+const AlertTypeOwnershipChange = "ownership_change"
+
+// PropertyAlert is a single pending property-change alert awaiting delivery to a user.
+type PropertyAlert struct {
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	UserID      string             `json:"userId" bson:"userId"`
+	PropertyID  string             `json:"propertyId" bson:"propertyId"`
+	Type        string             `json:"type" bson:"type"`
+	Area        string             `json:"area" bson:"area"`
+	Summary     string             `json:"summary" bson:"summary"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	DeliveredAt *time.Time         `json:"deliveredAt,omitempty" bson:"deliveredAt,omitempty"`
+}
+
+type DigestGroup struct {
+	Area   string          `json:"area"`
+	Alerts []PropertyAlert `json:"alerts"`
+}
+
+// NotificationDigest bundles a user's deduplicated alerts grouped by area.
+type NotificationDigest struct {
+	UserID      string        `json:"userId"`
+	Frequency   string        `json:"frequency"`
+	Groups      []DigestGroup `json:"groups"`
+	AlertCount  int           `json:"alertCount"`
+	GeneratedAt time.Time     `json:"generatedAt"`
+}
+
+// NotificationTypePasswordChanged marks an in-app notification that the user's password was reset.
+const NotificationTypePasswordChanged = "password_changed"
+
+// Notification is an in-app message shown in the user's notification list. Alerts create one when
+// they are queued, independently of when the email digest goes out.
+type Notification struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id"`
+	UserID     string             `json:"-" bson:"userId"`
+	Type       string             `json:"type" bson:"type"`
+	Title      string             `json:"title" bson:"title"`
+	Body       string             `json:"body" bson:"body"`
+	PropertyID string             `json:"propertyId,omitempty" bson:"propertyId,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	ReadAt     *time.Time         `json:"readAt,omitempty" bson:"readAt,omitempty"`
+	ExpiresAt  time.Time          `json:"-" bson:"expiresAt"`
+}
+
+type NotificationsResponse struct {
+	Data        []Notification `json:"data"`
+	Metadata    PaginationMeta `json:"metadata"`
+	UnreadCount int64          `json:"unreadCount"`
+}
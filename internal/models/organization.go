@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Roles a user can have within their organization. Organization admins manage its members;
+// platform admins (RoleAdmin) manage every organization.
+const (
+	MembershipRoleAdmin  = "admin"
+	MembershipRoleMember = "member"
+)
+
+// DefaultOrganizationSlug identifies the organization that data and users from before multi-tenancy
+// belong to, and that users join when they sign up on their own.
+const DefaultOrganizationSlug = "default"
+
+// Organization is a tenant, e.g. a brokerage, whose users only see the organization's own properties.
+type Organization struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id"`
+	Name      string             `json:"name" bson:"name"`
+	Slug      string             `json:"slug" bson:"slug"`
+	CreatedBy string             `json:"createdBy,omitempty" bson:"createdBy,omitempty"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// Membership places a user in an organization. A user belongs to exactly one organization.
+type Membership struct {
+	ID        primitive.ObjectID `json:"-" bson:"_id"`
+	OrgID     string             `json:"orgId" bson:"orgId"`
+	UserID    string             `json:"userId" bson:"userId"`
+	Role      string             `json:"role" bson:"role"`
+	CreatedAt time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// Member is a membership with the user's name and email, as listed to organization admins.
+type Member struct {
+	UserID   string    `json:"userId"`
+	FullName string    `json:"fullName"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required,max=200" example:"Acme Realty"`
+	Slug string `json:"slug" binding:"required,max=63" example:"acme-realty"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" binding:"required,email" example:"agent@acme-realty.com"`
+	Role  string `json:"role" binding:"omitempty,oneof=admin member" example:"member"`
+}
+
+type OrganizationsResponse struct {
+	Data     []Organization `json:"data"`
+	Metadata PaginationMeta `json:"metadata"`
+}
+
+type MembersResponse struct {
+	Data     []Member       `json:"data"`
+	Metadata PaginationMeta `json:"metadata"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OwnerEntity groups every parcel held by the same normalized owner name. The name is sealed at rest
+// when PII encryption is enabled; NameIndex holds blind indexes of the name and of each run of its
+// leading words, which name lookups match instead.
+type OwnerEntity struct {
+	ID          primitive.ObjectID `json:"_id" bson:"_id"`
+	OrgID       string             `json:"-" bson:"orgId,omitempty"`
+	EntityID    string             `json:"entityId" bson:"entityId"`
+	Name        string             `json:"name" bson:"name"`
+	NameIndex   []string           `json:"-" bson:"nameIndex,omitempty"`
+	IsCorporate bool               `json:"isCorporate" bson:"isCorporate"`
+	PropertyIDs []string           `json:"propertyIds" bson:"propertyIds"`
+	UpdatedAt   time.Time          `json:"updatedAt" bson:"updatedAt"`
+}
+
+type OwnerPortfolioResponse struct {
+	Owner    OwnerEntity    `json:"owner" bson:"owner"`
+	Data     []Property     `json:"data" bson:"data"`
+	Metadata PaginationMeta `json:"metadata" bson:"metadata"`
+}
+
+// OwnerSearchResponse lists the properties of the owners whose names match a search.
+type OwnerSearchResponse struct {
+	Owners   []OwnerEntity  `json:"owners" bson:"owners"`
+	Data     []Property     `json:"data" bson:"data"`
+	Metadata PaginationMeta `json:"metadata" bson:"metadata"`
+}
+
+type RelatedPropertiesResponse struct {
+	PropertyID string        `json:"propertyId" bson:"propertyId"`
+	By         string        `json:"by" bson:"by"`
+	Owners     []OwnerEntity `json:"owners" bson:"owners"`
+	Data       []Property    `json:"data" bson:"data"`
+}
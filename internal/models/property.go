@@ -6,28 +6,56 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// CurrentPropertySchemaVersion is the document shape written by this build; older stored
+// shapes are upgraded on read by the repository's migration pipeline.
+const CurrentPropertySchemaVersion = 3
+
 type Property struct {
 	ID                 primitive.ObjectID `json:"_id" bson:"_id"`
+	OrgID              string             `json:"orgId,omitempty" bson:"orgId,omitempty"`
+	SchemaVersion      int                `json:"schemaVersion" bson:"schemaVersion"`
 	PropertyID         string             `json:"propertyId" bson:"propertyId" validate:"required"`
-	AVMPropertyID      string             `json:"avmPropertyId" bson:"avmPropertyId" validate:"required"`
-	Address            Address            `json:"address" bson:"address" validate:"required,dive"`
+	AVMPropertyID      string             `json:"avmPropertyId" bson:"avmPropertyId"`
+	Address            Address            `json:"address" bson:"address"`
 	Location           Location           `json:"location" bson:"location"`
 	Lot                Lot                `json:"lot" bson:"lot"`
 	LandUseAndZoning   LandUseAndZoning   `json:"landUseAndZoning" bson:"landUseAndZoning"`
 	Utilities          Utilities          `json:"utilities" bson:"utilities"`
 	Building           Building           `json:"building" bson:"building"`
 	Ownership          Ownership          `json:"ownership" bson:"ownership"`
+	// TaxAssessment is the latest year of TaxAssessments, kept at the top level for filters and indexes
 	TaxAssessment      TaxAssessment      `json:"taxAssessment" bson:"taxAssessment"`
+	TaxAssessments     []TaxAssessment    `json:"taxAssessments" bson:"taxAssessments"`
 	LastMarketSale     LastMarketSale     `json:"lastMarketSale" bson:"lastMarketSale"`
+	// DataQuality is derived from the other fields whenever the property is written
+	DataQuality        *DataQuality       `json:"dataQuality,omitempty" bson:"dataQuality,omitempty"`
+	// Hazard is kept from the last successful hazard lookup when a refresh can't look it up again
+	Hazard             *HazardRisk        `json:"hazard,omitempty" bson:"hazard,omitempty"`
+	// Neighborhood is kept section by section from the last successful lookup, like Hazard
+	Neighborhood       *Neighborhood      `json:"neighborhood,omitempty" bson:"neighborhood,omitempty"`
 	UpdatedAt          time.Time          `json:"updatedAt" bson:"updatedAt"`
+	DeletedAt          *time.Time         `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	// Media is read from the property_media collection when a single property is returned, and for
+	// search results on request with ?include=media
+	Media []PropertyMedia `json:"media,omitempty" bson:"-"`
+	// Listing is the property's latest listing, included on request with ?include=listing. Sent when
+	// creating a property, it is created as the property's first listing.
+	Listing *Listing `json:"listing,omitempty" bson:"-"`
+	// Valuation is the property's current valuation, included on request with ?include=valuation
+	Valuation *Valuation `json:"valuation,omitempty" bson:"-"`
+	// RecentTransactions are the property's latest transactions, included on request with
+	// ?include=transactions
+	RecentTransactions []Transaction `json:"transactions,omitempty" bson:"-"`
+	// Transactions is the sale history from the data provider, stored in the transactions collection
+	Transactions []Transaction `json:"-" bson:"-"`
 }
 
 type Address struct {
 	StreetAddress       string             `json:"streetAddress" bson:"streetAddress" validate:"required"`
 	StreetAddressParsed StreetAddressParsed `json:"streetAddressParsed" bson:"streetAddressParsed"`
 	City                string             `json:"city" bson:"city" validate:"required"`
-	State               string             `json:"state" bson:"state" validate:"required,len=2"`
-	ZipCode             string             `json:"zipCode" bson:"zipCode" validate:"required,regex=^[0-9]{5}$"`
+	State               string             `json:"state" bson:"state" validate:"required,usstate"`
+	ZipCode             string             `json:"zipCode" bson:"zipCode" validate:"required,zipcode"`
 	ZipPlus4            string             `json:"zipPlus4" bson:"zipPlus4"`
 	County              string             `json:"county" bson:"county"`
 	CarrierRoute        string             `json:"carrierRoute" bson:"carrierRoute"`
@@ -49,6 +77,23 @@ type Location struct {
 type Coordinates struct {
 	Parcel CoordinatesPoint `json:"parcel" bson:"parcel"`
 	Block  CoordinatesPoint `json:"block" bson:"block"`
+	// ParcelPoint mirrors Parcel as GeoJSON for the 2dsphere index; Parcel stores latitude first,
+	// which MongoDB would misread as a legacy [lng, lat] pair.
+	ParcelPoint *GeoJSONPoint `json:"-" bson:"parcelPoint,omitempty"`
+}
+
+// GeoJSONPoint is a GeoJSON Point; coordinates are [longitude, latitude].
+type GeoJSONPoint struct {
+	Type        string     `json:"type" bson:"type"`
+	Coordinates [2]float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// NewGeoJSONPoint converts a lat/lng point, returning nil for the unset (0, 0) point.
+func NewGeoJSONPoint(p CoordinatesPoint) *GeoJSONPoint {
+	if p.Lat == 0 && p.Lng == 0 {
+		return nil
+	}
+	return &GeoJSONPoint{Type: "Point", Coordinates: [2]float64{p.Lng, p.Lat}}
 }
 
 type CoordinatesPoint struct {
@@ -251,8 +296,8 @@ type Owner struct {
 type MailingAddress struct {
 	StreetAddress string `json:"streetAddress" bson:"streetAddress"`
 	City         string `json:"city" bson:"city"`
-	State        string `json:"state" bson:"state" validate:"len=2"`
-	ZipCode      string `json:"zipCode" bson:"zipCode" validate:"regex=^[0-9]{5}$"`
+	State        string `json:"state" bson:"state" validate:"omitempty,usstate"`
+	ZipCode      string `json:"zipCode" bson:"zipCode" validate:"omitempty,zipcode"`
 	CarrierRoute string `json:"carrierRoute" bson:"carrierRoute"`
 }
 
@@ -322,11 +367,49 @@ type SearchRequest struct {
 }
 
 type PaginationMeta struct {
-	Total  int64   `json:"total" bson:"total"`
-	Offset int     `json:"offset" bson:"offset"`
-	Limit  int     `json:"limit" bson:"limit"`
-	Next   *string `json:"next,omitempty" bson:"next,omitempty"`
-	Prev   *string `json:"prev,omitempty" bson:"prev,omitempty"`
+	Total      int64   `json:"total" bson:"total"`
+	Offset     int     `json:"offset" bson:"offset"`
+	Limit      int     `json:"limit" bson:"limit"`
+	Next       *string `json:"next,omitempty" bson:"next,omitempty"`
+	Prev       *string `json:"prev,omitempty" bson:"prev,omitempty"`
+	NextCursor *string `json:"nextCursor,omitempty" bson:"nextCursor,omitempty"`
+}
+
+// NearbyProperty is a radius search hit with its distance from the query point.
+type NearbyProperty struct {
+	Property       `bson:",inline"`
+	DistanceMeters float64 `json:"distanceMeters" bson:"distanceMeters"`
+}
+
+// PropertyMatch is a property an ambiguous address search could mean, with how closely its street
+// address matches the query, from 1 (the same spelling) down.
+type PropertyMatch struct {
+	Property        `bson:",inline"`
+	MatchConfidence float64 `json:"matchConfidence" bson:"-"`
+}
+
+type PropertyMatchesResponse struct {
+	Query    string          `json:"query"`
+	Data     []PropertyMatch `json:"data"`
+	Metadata PaginationMeta  `json:"metadata"`
+}
+
+type NearbyQuery struct {
+	Lat          float64 `json:"lat"`
+	Lng          float64 `json:"lng"`
+	RadiusMeters float64 `json:"radiusMeters"`
+}
+
+type NearbyPropertiesResponse struct {
+	Query    NearbyQuery      `json:"query"`
+	Data     []NearbyProperty `json:"data"`
+	Metadata PaginationMeta   `json:"metadata"`
+}
+
+// CachedSearchResult is the cached form of one page of search results, in rank order.
+type CachedSearchResult struct {
+	PropertyIDs []string `json:"propertyIds"`
+	Total       int64    `json:"total"`
 }
 
 type PaginatedPropertiesResponse struct {
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Actions recorded in a property's change history.
+const (
+	AuditActionCreated  = "created"
+	AuditActionUpdated  = "updated"
+	AuditActionDeleted  = "deleted"
+	AuditActionRestored = "restored"
+	AuditActionPurged   = "purged"
+	AuditActionMerged   = "merged"
+)
+
+// AuditActorSystem is recorded for changes made without a signed-in user, such as CoreLogic refreshes.
+const AuditActorSystem = "system"
+
+// FieldChange is one leaf of a property that changed, addressed by its dotted JSON path. Old is
+// omitted for fields that were previously unset and New for fields that were cleared.
+type FieldChange struct {
+	Path string      `json:"path" bson:"path"`
+	Old  interface{} `json:"old,omitempty" bson:"old,omitempty"`
+	New  interface{} `json:"new,omitempty" bson:"new,omitempty"`
+}
+
+// PropertyAuditEntry records who changed a property, when, and which fields changed.
+type PropertyAuditEntry struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID      string             `json:"-" bson:"orgId,omitempty"`
+	PropertyID string             `json:"propertyId" bson:"propertyId"`
+	Action     string             `json:"action" bson:"action"`
+	Actor      string             `json:"actor" bson:"actor"`
+	ActorRole  string             `json:"actorRole,omitempty" bson:"actorRole,omitempty"`
+	Timestamp  time.Time          `json:"timestamp" bson:"timestamp"`
+	Changes    []FieldChange      `json:"changes,omitempty" bson:"changes,omitempty"`
+}
+
+type PropertyHistoryResponse struct {
+	Data     []PropertyAuditEntry `json:"data"`
+	Metadata PaginationMeta       `json:"metadata"`
+}
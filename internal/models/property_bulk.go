@@ -0,0 +1,94 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BulkDeleteRequest is the body of POST /api/properties/bulk-delete. The filter takes the same
+// criteria as the property list, as JSON, and must set at least one of them. A dry run only reports
+// what would be deleted.
+type BulkDeleteRequest struct {
+	Filter PropertyFilter `json:"filter"`
+	DryRun bool           `json:"dryRun"`
+}
+
+// BulkUpdateRequest is the body of POST /api/properties/bulk-update. FieldMask lists the dotted paths
+// to write on every matching property, such as "building.details.construction.yearBuilt"; each takes its value
+// from Update, and a path Update doesn't have is cleared.
+type BulkUpdateRequest struct {
+	Filter    PropertyFilter  `json:"filter"`
+	Update    json.RawMessage `json:"update"`
+	FieldMask []string        `json:"fieldMask" binding:"required,min=1,max=20"`
+	DryRun    bool            `json:"dryRun"`
+}
+
+// BulkPreview is the outcome of a dry run: how many properties the operation would touch, with the
+// IDs of the first few. For an update, Errors lists those of the first few it would leave invalid.
+type BulkPreview struct {
+	DryRun     bool                `json:"dryRun"`
+	Matched    int64               `json:"matched"`
+	SampleIDs  []string            `json:"sampleIds"`
+	FieldMask  []string            `json:"fieldMask,omitempty"`
+	Errors     []BulkPropertyError `json:"errors,omitempty"`
+	MaxAllowed int                 `json:"maxAllowed"`
+}
+
+// BulkProgress is reported on a bulk job while it runs and becomes its result. Matched is counted when
+// the job starts; Processed counts the properties gone through, Succeeded those deleted or updated
+// and Failed those rejected, each with an entry in Errors up to a maximum. AfterID is where a retried
+// attempt resumes.
+type BulkProgress struct {
+	Matched   int64               `json:"matched"`
+	Processed int64               `json:"processed"`
+	Succeeded int64               `json:"succeeded"`
+	Failed    int64               `json:"failed"`
+	Errors    []BulkPropertyError `json:"errors,omitempty"`
+	AfterID   string              `json:"afterId,omitempty"`
+}
+
+// BulkPropertyError explains why a bulk update leaves one property unchanged.
+type BulkPropertyError struct {
+	PropertyID string `json:"propertyId"`
+	Error      string `json:"error"`
+}
+
+// ValidateFieldMask checks that every path of a bulk update's field mask exists on a property and
+// doesn't run through an array, since a merge patch can only replace arrays whole. Paths already
+// covered by another path are dropped.
+func ValidateFieldMask(mask []string) ([]string, error) {
+	paths := make([]string, 0, len(mask))
+	for _, raw := range mask {
+		path := strings.TrimSpace(raw)
+		if !propertyFieldSettable(path) {
+			return nil, fmt.Errorf("invalid bulk update: unknown or unsettable field %q", path)
+		}
+		paths = append(paths, path)
+	}
+	fields, err := ParsePropertyFields(strings.Join(paths, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bulk update: %v", err)
+	}
+	return fields, nil
+}
+
+// propertyFieldSettable is propertyFieldExists for paths that stop at the first array they reach.
+func propertyFieldSettable(path string) bool {
+	if !propertyFieldExists(path) {
+		return false
+	}
+	t := reflect.TypeOf(Property{})
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Slice {
+			return false
+		}
+		field, _ := jsonField(t, name)
+		t = field.Type
+	}
+	return true
+}
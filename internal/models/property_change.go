@@ -0,0 +1,18 @@
+package models
+
+// Change stream operations that end a watch; the collection is gone or was renamed.
+const (
+	ChangeOperationDrop       = "drop"
+	ChangeOperationRename     = "rename"
+	ChangeOperationInvalidate = "invalidate"
+)
+
+// PropertyChange is one write to the properties collection seen on its change stream. PropertyID and
+// OrgID are empty for hard deletes when the collection does not record pre-images. ResumeToken is the
+// stream position just after this change.
+type PropertyChange struct {
+	Operation   string
+	PropertyID  string
+	OrgID       string
+	ResumeToken []byte
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PropertyDiff is what changed in a property when it was refreshed from a data provider. The
+// summaries pick out the changes people ask about; Changes lists every changed field.
+type PropertyDiff struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID       string             `json:"-" bson:"orgId,omitempty"`
+	PropertyID  string             `json:"propertyId" bson:"propertyId"`
+	Source      string             `json:"source,omitempty" bson:"source,omitempty"`
+	RefreshedAt time.Time          `json:"refreshedAt" bson:"refreshedAt"`
+	// AssessedValue is set when the total assessed value changed, including with a new tax year
+	AssessedValue *AssessedValueChange `json:"assessedValue,omitempty" bson:"assessedValue,omitempty"`
+	// NewSale is set when a sale more recent than the last one known was recorded
+	NewSale *SaleChange `json:"newSale,omitempty" bson:"newSale,omitempty"`
+	// Building lists the changed building fields, such as area, rooms or year built
+	Building []FieldChange `json:"building,omitempty" bson:"building,omitempty"`
+	Changes  []FieldChange `json:"changes" bson:"changes"`
+}
+
+// AssessedValueChange compares the total assessed value before and after a refresh. ChangePercent
+// is 0 when there was no previous value.
+type AssessedValueChange struct {
+	PreviousYear  int     `json:"previousYear,omitempty" bson:"previousYear,omitempty"`
+	Year          int     `json:"year" bson:"year"`
+	Previous      int     `json:"previous" bson:"previous"`
+	Current       int     `json:"current" bson:"current"`
+	Change        int     `json:"change" bson:"change"`
+	ChangePercent float64 `json:"changePercent" bson:"changePercent"`
+}
+
+// SaleChange is a newly recorded sale, with the sale it replaces as the last market sale.
+type SaleChange struct {
+	Date           string `json:"date" bson:"date"`
+	Amount         int    `json:"amount" bson:"amount"`
+	PreviousDate   string `json:"previousDate,omitempty" bson:"previousDate,omitempty"`
+	PreviousAmount int    `json:"previousAmount,omitempty" bson:"previousAmount,omitempty"`
+}
+
+type PropertyDiffsResponse struct {
+	Data     []PropertyDiff `json:"data"`
+	Metadata PaginationMeta `json:"metadata"`
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MaxPropertyFields caps how many paths one ?fields= may select.
+const MaxPropertyFields = 20
+
+// alwaysIncludedFields are kept in every projected property so clients can still identify it, along
+// with the search scores of nearby and address match results.
+var alwaysIncludedFields = []string{"_id", "propertyId", "distanceMeters", "matchConfidence"}
+
+// PropertyFields is a sparse fieldset of dotted JSON paths into a property, such as "building.summary".
+// Stored documents use the same names, so the paths double as a Mongo projection. Empty selects everything.
+type PropertyFields []string
+
+// ParsePropertyFields reads a comma-separated ?fields= value, rejecting paths that do not exist on a
+// property and dropping paths already covered by a selected parent.
+func ParsePropertyFields(raw string) (PropertyFields, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) > MaxPropertyFields {
+		return nil, fmt.Errorf("invalid fields: at most %d fields allowed", MaxPropertyFields)
+	}
+	paths := make([]string, 0, len(parts))
+	for _, part := range parts {
+		path := strings.TrimSpace(part)
+		if !propertyFieldExists(path) {
+			return nil, fmt.Errorf("invalid fields: unknown field %q", path)
+		}
+		paths = append(paths, path)
+	}
+
+	// Sorted order puts a parent directly before its children
+	sort.Strings(paths)
+	fields := make(PropertyFields, 0, len(paths))
+	for _, path := range paths {
+		if n := len(fields); n > 0 && (fields[n-1] == path || strings.HasPrefix(path, fields[n-1]+".")) {
+			continue
+		}
+		fields = append(fields, path)
+	}
+	return fields, nil
+}
+
+// propertyFieldExists walks the JSON names of Property, descending through nested structs and slices.
+func propertyFieldExists(path string) bool {
+	if path == "" {
+		return false
+	}
+	t := reflect.TypeOf(Property{})
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Slice || t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return false
+		}
+		field, ok := jsonField(t, name)
+		if !ok {
+			return false
+		}
+		t = field.Type
+	}
+	return true
+}
+
+func jsonField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == name && tag != "-" {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Project returns only the selected fields of a property (or a type embedding one), keyed as in its
+// JSON form. With no fields selected the whole value is returned unchanged.
+func (f PropertyFields) Project(property interface{}) (interface{}, error) {
+	if len(f) == 0 {
+		return property, nil
+	}
+	data, err := json.Marshal(property)
+	if err != nil {
+		return nil, err
+	}
+	var full map[string]interface{}
+	if err := json.Unmarshal(data, &full); err != nil {
+		return nil, err
+	}
+	projected := make(map[string]interface{}, len(f)+len(alwaysIncludedFields))
+	for _, name := range alwaysIncludedFields {
+		if value, ok := full[name]; ok {
+			projected[name] = value
+		}
+	}
+	for _, path := range f {
+		copyPath(projected, full, strings.Split(path, "."))
+	}
+	return projected, nil
+}
+
+// copyPath copies one dotted path from src into dst, applying the rest of the path to every element
+// when it runs through an array.
+func copyPath(dst, src map[string]interface{}, path []string) {
+	value, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 || value == nil {
+		dst[path[0]] = value
+		return
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, _ := dst[path[0]].(map[string]interface{})
+		if child == nil {
+			child = make(map[string]interface{})
+			dst[path[0]] = child
+		}
+		copyPath(child, v, path[1:])
+	case []interface{}:
+		items, _ := dst[path[0]].([]interface{})
+		if items == nil {
+			items = make([]interface{}, len(v))
+			dst[path[0]] = items
+		}
+		for i, item := range v {
+			element, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			child, _ := items[i].(map[string]interface{})
+			if child == nil {
+				child = make(map[string]interface{})
+				items[i] = child
+			}
+			copyPath(child, element, path[1:])
+		}
+	}
+}
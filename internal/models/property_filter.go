@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PropertyFilter narrows GET /api/properties; empty fields match anything. City and state match
+// case-insensitively, ranges are inclusive and the price range applies to the last market sale amount.
+type PropertyFilter struct {
+	City             string `form:"city" binding:"omitempty,max=100"`
+	State            string `form:"state" binding:"omitempty,len=2"`
+	ZipCode          string `form:"zipCode" binding:"omitempty,max=10"`
+	MinBeds          *int   `form:"minBeds" binding:"omitempty,gte=0"`
+	MaxBeds          *int   `form:"maxBeds" binding:"omitempty,gte=0"`
+	MinBaths         *int   `form:"minBaths" binding:"omitempty,gte=0"`
+	MaxBaths         *int   `form:"maxBaths" binding:"omitempty,gte=0"`
+	MinYearBuilt     *int   `form:"minYearBuilt" binding:"omitempty,gte=0"`
+	MaxYearBuilt     *int   `form:"maxYearBuilt" binding:"omitempty,gte=0"`
+	MinAssessedValue *int   `form:"minAssessedValue" binding:"omitempty,gte=0"`
+	MaxAssessedValue *int   `form:"maxAssessedValue" binding:"omitempty,gte=0"`
+	MinPrice         *int   `form:"minPrice" binding:"omitempty,gte=0"`
+	MaxPrice         *int   `form:"maxPrice" binding:"omitempty,gte=0"`
+	// FloodZone matches FEMA flood zones, such as AE or a comma-separated list like AE,VE
+	FloodZone       string `form:"floodZone" binding:"omitempty,max=50"`
+	MinWildfireRisk *int   `form:"minWildfireRisk" binding:"omitempty,gte=1,lte=5"`
+	MaxWildfireRisk *int   `form:"maxWildfireRisk" binding:"omitempty,gte=1,lte=5"`
+}
+
+// FloodZones returns the flood zones FloodZone lists, uppercased.
+func (f *PropertyFilter) FloodZones() []string {
+	var zones []string
+	for _, zone := range strings.Split(f.FloodZone, ",") {
+		if zone = strings.ToUpper(strings.TrimSpace(zone)); zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// IsEmpty reports whether the filter matches every property.
+func (f *PropertyFilter) IsEmpty() bool {
+	return f == nil || f.String() == ""
+}
+
+// String returns the set filters as name=value pairs in a fixed order, for cache keys and logs.
+func (f *PropertyFilter) String() string {
+	if f == nil {
+		return ""
+	}
+	var parts []string
+	addString := func(name, value string) {
+		if value != "" {
+			parts = append(parts, name+"="+strings.ToLower(value))
+		}
+	}
+	addInt := func(name string, value *int) {
+		if value != nil {
+			parts = append(parts, fmt.Sprintf("%s=%d", name, *value))
+		}
+	}
+	addString("city", f.City)
+	addString("state", f.State)
+	addString("zipCode", f.ZipCode)
+	addInt("minBeds", f.MinBeds)
+	addInt("maxBeds", f.MaxBeds)
+	addInt("minBaths", f.MinBaths)
+	addInt("maxBaths", f.MaxBaths)
+	addInt("minYearBuilt", f.MinYearBuilt)
+	addInt("maxYearBuilt", f.MaxYearBuilt)
+	addInt("minAssessedValue", f.MinAssessedValue)
+	addInt("maxAssessedValue", f.MaxAssessedValue)
+	addInt("minPrice", f.MinPrice)
+	addInt("maxPrice", f.MaxPrice)
+	addString("floodZone", strings.Join(f.FloodZones(), ","))
+	addInt("minWildfireRisk", f.MinWildfireRisk)
+	addInt("maxWildfireRisk", f.MaxWildfireRisk)
+	return strings.Join(parts, ",")
+}
+
+// PropertySortFields maps the field names accepted by ?sort= to the indexed document paths they order by.
+var PropertySortFields = map[string]string{
+	"streetAddress": "address.streetAddress",
+	"city":          "address.city",
+	"zipCode":       "address.zipCode",
+	"beds":          "building.summary.bedroomsCount",
+	"baths":         "building.summary.bathroomsCount",
+	"yearBuilt":     "building.details.construction.yearBuilt",
+	"assessedValue": "taxAssessment.assessedValue.totalValue",
+	"price":         "lastMarketSale.amount",
+}
+
+// MaxSortFields caps how many fields one ?sort= may combine.
+const MaxSortFields = 3
+
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// PropertySort orders a property list; empty means street address ascending.
+type PropertySort []SortField
+
+// ParsePropertySort reads "field:dir,field:dir" where dir is asc (the default) or desc.
+func ParsePropertySort(raw string) (PropertySort, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	parts := strings.Split(raw, ",")
+	if len(parts) > MaxSortFields {
+		return nil, fmt.Errorf("invalid sort: at most %d fields allowed", MaxSortFields)
+	}
+	sort := make(PropertySort, 0, len(parts))
+	seen := make(map[string]bool, len(parts))
+	for _, part := range parts {
+		name, dir, _ := strings.Cut(strings.TrimSpace(part), ":")
+		if _, ok := PropertySortFields[name]; !ok {
+			return nil, fmt.Errorf("invalid sort: unknown field %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("invalid sort: duplicate field %q", name)
+		}
+		seen[name] = true
+		switch strings.ToLower(dir) {
+		case "", "asc":
+			sort = append(sort, SortField{Field: name})
+		case "desc":
+			sort = append(sort, SortField{Field: name, Descending: true})
+		default:
+			return nil, fmt.Errorf("invalid sort: direction %q for field %q must be asc or desc", dir, name)
+		}
+	}
+	return sort, nil
+}
+
+// String returns the sort in ?sort= form, for cache keys and logs.
+func (s PropertySort) String() string {
+	parts := make([]string, 0, len(s))
+	for _, field := range s {
+		dir := "asc"
+		if field.Descending {
+			dir = "desc"
+		}
+		parts = append(parts, field.Field+":"+dir)
+	}
+	return strings.Join(parts, ",")
+}
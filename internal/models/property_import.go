@@ -0,0 +1,23 @@
+package models
+
+// ImportPropertiesRequest is the body of a bulk property import, which runs as a background job.
+type ImportPropertiesRequest struct {
+	Properties []Property `json:"properties" binding:"required,min=1"`
+}
+
+// ImportPropertiesResult is the result of a finished import job. Properties that already exist are
+// skipped, so an import can be run again after a partial failure.
+type ImportPropertiesResult struct {
+	Total   int                   `json:"total"`
+	Created int                   `json:"created"`
+	Skipped int                   `json:"skipped"`
+	Failed  int                   `json:"failed"`
+	Errors  []ImportPropertyError `json:"errors,omitempty"`
+}
+
+// ImportPropertyError explains why one property of an import was not created.
+type ImportPropertyError struct {
+	Index      int    `json:"index"`
+	PropertyID string `json:"propertyId,omitempty"`
+	Error      string `json:"error"`
+}
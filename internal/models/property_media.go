@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Kinds of files attached to a property.
+const (
+	MediaKindPhoto    = "photo"
+	MediaKindDocument = "document"
+)
+
+// PropertyMedia is a photo or document attached to a property. The file lives in object storage;
+// URL and ThumbnailURL are short-lived signed links filled in when the media is read.
+type PropertyMedia struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID        string             `json:"-" bson:"orgId,omitempty"`
+	PropertyID   string             `json:"propertyId" bson:"propertyId"`
+	Kind         string             `json:"kind" bson:"kind"`
+	FileName     string             `json:"fileName" bson:"fileName"`
+	ContentType  string             `json:"contentType" bson:"contentType"`
+	Size         int64              `json:"size" bson:"size"`
+	Width        int                `json:"width,omitempty" bson:"width,omitempty"`
+	Height       int                `json:"height,omitempty" bson:"height,omitempty"`
+	Caption      string             `json:"caption,omitempty" bson:"caption,omitempty"`
+	StorageKey   string             `json:"-" bson:"storageKey"`
+	ThumbnailKey string             `json:"-" bson:"thumbnailKey,omitempty"`
+	UploadedBy   string             `json:"uploadedBy" bson:"uploadedBy"`
+	CreatedAt    time.Time          `json:"createdAt" bson:"createdAt"`
+	URL          string             `json:"url,omitempty" bson:"-"`
+	ThumbnailURL string             `json:"thumbnailUrl,omitempty" bson:"-"`
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxBoundaryVertices caps the positions across all rings of a boundary drawn on the map.
+const MaxBoundaryVertices = 1000
+
+// GeoJSONPolygon is a GeoJSON Polygon: an outer ring followed by any holes, each a closed list of
+// [longitude, latitude] positions.
+type GeoJSONPolygon struct {
+	Type        string         `json:"type" bson:"type"`
+	Coordinates [][][2]float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// WithinRequest is the boundary of a map search: a drawn polygon or the bounding box of the
+// visible map, given as [west, south, east, north] in degrees. Exactly one is set.
+type WithinRequest struct {
+	Polygon *GeoJSONPolygon `json:"polygon,omitempty"`
+	BBox    []float64       `json:"bbox,omitempty"`
+}
+
+// Boundary validates the request and returns its boundary as a polygon, converting a bounding box
+// into one. Boundaries may not cross the antimeridian or span a hemisphere, which MongoDB would
+// read as the complement of the area drawn.
+func (r *WithinRequest) Boundary() (*GeoJSONPolygon, error) {
+	switch {
+	case r.Polygon != nil && r.BBox != nil:
+		return nil, fmt.Errorf("invalid boundary: give either polygon or bbox, not both")
+	case r.BBox != nil:
+		return bboxPolygon(r.BBox)
+	case r.Polygon != nil:
+		return r.Polygon, validatePolygon(r.Polygon)
+	}
+	return nil, fmt.Errorf("invalid boundary: polygon or bbox is required")
+}
+
+func bboxPolygon(bbox []float64) (*GeoJSONPolygon, error) {
+	if len(bbox) != 4 {
+		return nil, fmt.Errorf("invalid boundary: bbox must be [west, south, east, north]")
+	}
+	west, south, east, north := bbox[0], bbox[1], bbox[2], bbox[3]
+	if err := validatePosition([2]float64{west, south}); err != nil {
+		return nil, err
+	}
+	if err := validatePosition([2]float64{east, north}); err != nil {
+		return nil, err
+	}
+	if west >= east || south >= north {
+		return nil, fmt.Errorf("invalid boundary: bbox west must be less than east and south less than north")
+	}
+	polygon := &GeoJSONPolygon{
+		Type: "Polygon",
+		Coordinates: [][][2]float64{{
+			{west, south}, {east, south}, {east, north}, {west, north}, {west, south},
+		}},
+	}
+	return polygon, validateExtent(polygon)
+}
+
+func validatePolygon(polygon *GeoJSONPolygon) error {
+	if polygon.Type != "Polygon" {
+		return fmt.Errorf("invalid boundary: polygon type must be Polygon, got %q", polygon.Type)
+	}
+	if len(polygon.Coordinates) == 0 {
+		return fmt.Errorf("invalid boundary: polygon has no rings")
+	}
+	vertices := 0
+	for i, ring := range polygon.Coordinates {
+		if len(ring) < 4 {
+			return fmt.Errorf("invalid boundary: ring %d needs at least 4 positions", i)
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return fmt.Errorf("invalid boundary: ring %d is not closed; its last position must repeat the first", i)
+		}
+		for _, position := range ring {
+			if err := validatePosition(position); err != nil {
+				return err
+			}
+		}
+		vertices += len(ring)
+	}
+	if vertices > MaxBoundaryVertices {
+		return fmt.Errorf("invalid boundary: polygon has %d positions, at most %d allowed", vertices, MaxBoundaryVertices)
+	}
+	return validateExtent(polygon)
+}
+
+func validatePosition(position [2]float64) error {
+	lng, lat := position[0], position[1]
+	if math.IsNaN(lng) || math.IsNaN(lat) || lng < -180 || lng > 180 || lat < -90 || lat > 90 {
+		return fmt.Errorf("invalid boundary: position [%g, %g] is not a valid [longitude, latitude]", lng, lat)
+	}
+	return nil
+}
+
+// validateExtent rejects boundaries whose outer ring spans 180 degrees of longitude or more.
+func validateExtent(polygon *GeoJSONPolygon) error {
+	west, east := 180.0, -180.0
+	for _, position := range polygon.Coordinates[0] {
+		west = math.Min(west, position[0])
+		east = math.Max(east, position[0])
+	}
+	if east-west >= 180 {
+		return fmt.Errorf("invalid boundary: boundary must span less than 180 degrees of longitude")
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshToken is a stored, revocable refresh token. Only the SHA-256 hash of the token is kept.
+// Tokens issued from one login share a FamilyID so reuse of a rotated token can revoke the chain.
+type RefreshToken struct {
+	ID         primitive.ObjectID `json:"_id" bson:"_id"`
+	TokenHash  string             `json:"-" bson:"tokenHash"`
+	UserID     string             `json:"userId" bson:"userId"`
+	FamilyID   string             `json:"familyId" bson:"familyId"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	ExpiresAt  time.Time          `json:"expiresAt" bson:"expiresAt"`
+	RevokedAt  *time.Time         `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+	ReplacedBy string             `json:"-" bson:"replacedBy,omitempty"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Reindex job lifecycle.
+const (
+	ReindexStatusPending   = "pending"
+	ReindexStatusBuilding  = "building"
+	ReindexStatusSwapping  = "swapping"
+	ReindexStatusDropping  = "dropping"
+	ReindexStatusCompleted = "completed"
+	ReindexStatusFailed    = "failed"
+)
+
+// IndexKey is one field of an index definition. Type is asc, desc, text, 2dsphere or hashed.
+type IndexKey struct {
+	Field string `json:"field" bson:"field" binding:"required" example:"address.zipCode"`
+	Type  string `json:"type" bson:"type" binding:"required,oneof=asc desc text 2dsphere hashed" example:"asc"`
+}
+
+type ReindexRequest struct {
+	Collection  string     `json:"collection" binding:"required" example:"properties"`
+	IndexName   string     `json:"indexName" binding:"required" example:"zip_street"`
+	Keys        []IndexKey `json:"keys" binding:"required,min=1,dive"`
+	Unique      bool       `json:"unique"`
+	HintFor     []string   `json:"hintFor" example:"properties.list"`
+	DropIndexes []string   `json:"dropIndexes" example:"streetAddress_1__id_1"`
+}
+
+// ReindexJob tracks an admin-triggered background index build through to the hint swap and cleanup.
+type ReindexJob struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id"`
+	Collection  string             `json:"collection" bson:"collection"`
+	IndexName   string             `json:"indexName" bson:"indexName"`
+	Keys        []IndexKey         `json:"keys" bson:"keys"`
+	Unique      bool               `json:"unique" bson:"unique"`
+	HintFor     []string           `json:"hintFor,omitempty" bson:"hintFor,omitempty"`
+	DropIndexes []string           `json:"dropIndexes,omitempty" bson:"dropIndexes,omitempty"`
+	Status      string             `json:"status" bson:"status"`
+	Progress    float64            `json:"progress" bson:"progress"`
+	Error       string             `json:"error,omitempty" bson:"error,omitempty"`
+	RequestedBy string             `json:"requestedBy" bson:"requestedBy"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt" bson:"updatedAt"`
+	CompletedAt *time.Time         `json:"completedAt,omitempty" bson:"completedAt,omitempty"`
+}
+
+// EnsureIndexesRequest asks for the indexes of the index registry to be built. Rebuild also replaces
+// the indexes whose definition changed since they were built.
+type EnsureIndexesRequest struct {
+	Rebuild bool `form:"rebuild"`
+}
+
+// IndexHint pins a named repository query to an index.
+type IndexHint struct {
+	Query      string    `json:"query" bson:"_id"`
+	Collection string    `json:"collection" bson:"collection"`
+	IndexName  string    `json:"indexName" bson:"indexName"`
+	UpdatedAt  time.Time `json:"updatedAt" bson:"updatedAt"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AlertTypeSavedSearchMatch marks a property alert raised because a property newly matched a saved search.
+const AlertTypeSavedSearchMatch = "saved_search_match"
+
+// SavedSearchCriteria filters properties; empty fields match anything. Address is a case-insensitive
+// street address fragment and the price range applies to the last market sale amount.
+type SavedSearchCriteria struct {
+	Address  string `json:"address,omitempty" bson:"address,omitempty" example:"main st"`
+	City     string `json:"city,omitempty" bson:"city,omitempty" example:"Austin"`
+	State    string `json:"state,omitempty" bson:"state,omitempty" binding:"omitempty,len=2" example:"TX"`
+	ZipCode  string `json:"zipCode,omitempty" bson:"zipCode,omitempty" example:"78701"`
+	MinPrice *int   `json:"minPrice,omitempty" bson:"minPrice,omitempty" binding:"omitempty,gte=0" example:"250000"`
+	MaxPrice *int   `json:"maxPrice,omitempty" bson:"maxPrice,omitempty" binding:"omitempty,gte=0" example:"500000"`
+}
+
+type SavedSearch struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id"`
+	OrgID      string              `json:"-" bson:"orgId,omitempty"`
+	UserID     string              `json:"userId" bson:"userId"`
+	Name       string              `json:"name" bson:"name"`
+	Criteria   SavedSearchCriteria `json:"criteria" bson:"criteria"`
+	MatchCount int64               `json:"matchCount" bson:"matchCount"`
+	CreatedAt  time.Time           `json:"createdAt" bson:"createdAt"`
+	LastRunAt  *time.Time          `json:"lastRunAt,omitempty" bson:"lastRunAt,omitempty"`
+	// LastRunAt is how far properties have been checked; LastAttemptAt schedules the next run.
+	LastAttemptAt *time.Time `json:"-" bson:"lastAttemptAt,omitempty"`
+	// BaselineComplete is set once every property matching at creation time has been recorded;
+	// only matches found after that raise alerts.
+	BaselineComplete bool `json:"-" bson:"baselineComplete"`
+}
+
+type CreateSavedSearchRequest struct {
+	Name     string              `json:"name" binding:"required,max=100" example:"Downtown condos"`
+	Criteria SavedSearchCriteria `json:"criteria" binding:"required"`
+}
+
+// SavedSearchMatch records a property that matched a saved search the first time it was seen.
+type SavedSearchMatch struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id"`
+	OrgID         string             `json:"-" bson:"orgId,omitempty"`
+	SavedSearchID primitive.ObjectID `json:"savedSearchId" bson:"savedSearchId"`
+	UserID        string             `json:"userId" bson:"userId"`
+	PropertyID    string             `json:"propertyId" bson:"propertyId"`
+	Address       Address            `json:"address" bson:"address"`
+	Price         int                `json:"price" bson:"price"`
+	MatchedAt     time.Time          `json:"matchedAt" bson:"matchedAt"`
+}
+
+type SavedSearchMatchesResponse struct {
+	Data     []SavedSearchMatch `json:"data"`
+	Metadata PaginationMeta     `json:"metadata"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session is one signed-in device of a user. It lives as long as its refresh token family, whose
+// FamilyID is the session's ID, and access tokens carry the ID as their sid claim.
+type Session struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id"`
+	UserID     string             `json:"-" bson:"userId"`
+	Device     string             `json:"device" bson:"device"`
+	UserAgent  string             `json:"userAgent" bson:"userAgent"`
+	IP         string             `json:"ip" bson:"ip"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+	LastUsedAt time.Time          `json:"lastUsedAt" bson:"lastUsedAt"`
+	ExpiresAt  time.Time          `json:"expiresAt" bson:"expiresAt"`
+	RevokedAt  *time.Time         `json:"-" bson:"revokedAt,omitempty"`
+	Current    bool               `json:"current" bson:"-"`
+}
+
+// SessionClient describes the client a session is started or refreshed from.
+type SessionClient struct {
+	UserAgent string
+	IP        string
+}
+
+type SessionsResponse struct {
+	Data []Session `json:"data"`
+}
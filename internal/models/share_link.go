@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ShareLink grants time-boxed, unauthenticated read access to a redacted property view.
+type ShareLink struct {
+	ID             primitive.ObjectID `json:"_id" bson:"_id"`
+	OrgID          string             `json:"-" bson:"orgId,omitempty"`
+	LinkID         string             `json:"linkId" bson:"linkId"`
+	PropertyID     string             `json:"propertyId" bson:"propertyId"`
+	CreatedBy      string             `json:"createdBy" bson:"createdBy"`
+	CreatedAt      time.Time          `json:"createdAt" bson:"createdAt"`
+	ExpiresAt      time.Time          `json:"expiresAt" bson:"expiresAt"`
+	RevokedAt      *time.Time         `json:"revokedAt,omitempty" bson:"revokedAt,omitempty"`
+	AccessCount    int64              `json:"accessCount" bson:"accessCount"`
+	LastAccessedAt *time.Time         `json:"lastAccessedAt,omitempty" bson:"lastAccessedAt,omitempty"`
+}
+
+type CreateShareLinkRequest struct {
+	ExpiresInHours int `json:"expires_in_hours" binding:"omitempty,min=1" example:"72"`
+}
+
+type ShareLinkResponse struct {
+	ShareLink
+	URL string `json:"url"`
+}
+
+// SharedPropertyView is the redacted property shown through a share link; owner,
+// mailing and buyer/seller details are intentionally left out.
+type SharedPropertyView struct {
+	PropertyID       string           `json:"propertyId"`
+	Address          Address          `json:"address"`
+	Coordinates      CoordinatesPoint `json:"coordinates"`
+	Lot              Lot              `json:"lot"`
+	LandUseAndZoning LandUseAndZoning `json:"landUseAndZoning"`
+	BuildingSummary  BuildingSummary  `json:"buildingSummary"`
+	YearBuilt        int              `json:"yearBuilt"`
+	AssessedValue    AssessedValue    `json:"assessedValue"`
+	TaxYear          int              `json:"taxYear"`
+	LastSaleDate     string           `json:"lastSaleDate"`
+	LastSaleAmount   int              `json:"lastSaleAmount"`
+	ExpiresAt        time.Time        `json:"expiresAt"`
+}
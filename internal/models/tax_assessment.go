@@ -0,0 +1,28 @@
+package models
+
+import "sort"
+
+// SyncTaxAssessments orders the tax history newest year first and points TaxAssessment at its latest
+// year. The history is authoritative; a TaxAssessment for a year the history lacks, as written by
+// clients that only know the single assessment, is added to it.
+func (p *Property) SyncTaxAssessments() {
+	if p.TaxAssessment.Year > 0 && !p.hasTaxYear(p.TaxAssessment.Year) {
+		p.TaxAssessments = append(p.TaxAssessments, p.TaxAssessment)
+	}
+	if len(p.TaxAssessments) == 0 {
+		return
+	}
+	sort.SliceStable(p.TaxAssessments, func(i, j int) bool {
+		return p.TaxAssessments[i].Year > p.TaxAssessments[j].Year
+	})
+	p.TaxAssessment = p.TaxAssessments[0]
+}
+
+func (p *Property) hasTaxYear(year int) bool {
+	for _, assessment := range p.TaxAssessments {
+		if assessment.Year == year {
+			return true
+		}
+	}
+	return false
+}
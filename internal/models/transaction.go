@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Transaction is one recorded sale or ownership transfer of a property, from the deed records the
+// data provider returns. LastMarketSale on the property is the most recent of them.
+type Transaction struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrgID          string             `json:"-" bson:"orgId,omitempty"`
+	PropertyID     string             `json:"propertyId" bson:"propertyId"`
+	LastMarketSale `bson:",inline"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// SameDeed reports whether two transactions record the same deed.
+func (t Transaction) SameDeed(other Transaction) bool {
+	return t.Date == other.Date && t.RecordingDate == other.RecordingDate && t.DocumentNumber == other.DocumentNumber
+}
+
+// TransactionFilter narrows a property's transactions to sale dates within [From, To], as YYYY-MM-DD.
+// Empty bounds are open.
+type TransactionFilter struct {
+	From string
+	To   string
+}
+
+type TransactionHistoryResponse struct {
+	Data     []Transaction  `json:"data"`
+	Metadata PaginationMeta `json:"metadata"`
+}
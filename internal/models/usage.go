@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// UsageRecord is the metered usage of one organization or API key on a UTC day.
+type UsageRecord struct {
+	Day            string    `json:"day" bson:"day"`
+	SubjectType    string    `json:"subjectType" bson:"subjectType"`
+	Subject        string    `json:"subject" bson:"subject"`
+	Requests       int64     `json:"requests" bson:"requests"`
+	CoreLogicCalls int64     `json:"corelogicCalls" bson:"corelogicCalls"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// UsageFilter narrows a usage export to a range of UTC days (YYYY-MM-DD, inclusive) and optionally
+// one subject.
+type UsageFilter struct {
+	From        string
+	To          string
+	SubjectType string
+	Subject     string
+}
+
+type UsageResponse struct {
+	From string        `json:"from"`
+	To   string        `json:"to"`
+	Data []UsageRecord `json:"data"`
+}
@@ -1,13 +1,29 @@
 package models
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// RoleAdmin grants access to /api/admin endpoints. Roles are assigned directly in the users collection.
+const RoleAdmin = "admin"
+
 type User struct {
 	ID       primitive.ObjectID `json:"_id" bson:"_id"`
 	FullName string             `json:"full_name" bson:"full_name"`
 	Email    string             `json:"email" bson:"email"`
 	Phone    string             `json:"phone" bson:"phone"`
 	Password string             `json:"password,omitempty" bson:"password"`
+	Role     string             `json:"role,omitempty" bson:"role,omitempty"`
+	// Identities are the provider accounts the user signs in with besides a password, which users
+	// created by social login don't have
+	Identities []UserIdentity `json:"identities,omitempty" bson:"identities,omitempty"`
+}
+
+// UserIdentity links a user to an account with an identity provider such as Google or Apple.
+type UserIdentity struct {
+	Provider string    `json:"provider" bson:"provider"`
+	Subject  string    `json:"subject" bson:"subject"`
+	LinkedAt time.Time `json:"linkedAt" bson:"linkedAt"`
 }
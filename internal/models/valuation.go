@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ValueRange is the band the true market value is expected to fall within.
+type ValueRange struct {
+	Low  float64 `json:"low" bson:"low" example:"410000"`
+	High float64 `json:"high" bson:"high" example:"472000"`
+}
+
+// Valuation is one automated valuation (AVM) of a property. Every valuation fetched from CoreLogic
+// is kept, so the valuations collection holds each property's value history.
+type Valuation struct {
+	ID                        primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	OrgID                     string             `json:"orgId,omitempty" bson:"orgId,omitempty"`
+	PropertyID                string             `json:"propertyId" bson:"propertyId"`
+	EstimatedValue            float64            `json:"estimatedValue" bson:"estimatedValue" example:"441000"`
+	ValueRange                ValueRange         `json:"valueRange" bson:"valueRange"`
+	ConfidenceScore           float64            `json:"confidenceScore" bson:"confidenceScore" example:"87"`
+	ForecastStandardDeviation float64            `json:"forecastStandardDeviation,omitempty" bson:"forecastStandardDeviation,omitempty"`
+	ValuationDate             string             `json:"valuationDate,omitempty" bson:"valuationDate,omitempty" example:"2026-09-30"`
+	Source                    string             `json:"source" bson:"source" example:"CORELOGIC_AVM"`
+	RetrievedAt               time.Time          `json:"retrievedAt" bson:"retrievedAt"`
+}
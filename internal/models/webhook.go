@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Property events a webhook can subscribe to.
+const (
+	EventPropertyCreated          = "property.created"
+	EventPropertyUpdated          = "property.updated"
+	EventPropertyDeleted          = "property.deleted"
+	EventPropertyRestored         = "property.restored"
+	EventPropertyOwnershipChanged = "property.ownership_changed"
+)
+
+// Outcome of the most recent delivery to a webhook.
+const (
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// Webhook is a callback URL that receives signed POSTs for the events it subscribes to, for the
+// properties of the organization that registered it. The secret is only returned when the webhook
+// is created.
+type Webhook struct {
+	ID                 primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	OrgID              string             `json:"orgId,omitempty" bson:"orgId,omitempty"`
+	URL                string             `json:"url" bson:"url"`
+	Events             []string           `json:"events" bson:"events"`
+	Secret             string             `json:"secret,omitempty" bson:"secret"`
+	CreatedBy          string             `json:"createdBy" bson:"createdBy"`
+	CreatedAt          time.Time          `json:"createdAt" bson:"createdAt"`
+	LastDeliveryAt     *time.Time         `json:"lastDeliveryAt,omitempty" bson:"lastDeliveryAt,omitempty"`
+	LastDeliveryStatus string             `json:"lastDeliveryStatus,omitempty" bson:"lastDeliveryStatus,omitempty"`
+	LastDeliveryError  string             `json:"lastDeliveryError,omitempty" bson:"lastDeliveryError,omitempty"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" binding:"required,url" example:"https://partner.example.com/hooks/properties"`
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=property.created property.updated property.deleted property.restored property.ownership_changed" example:"property.updated"`
+}
+
+// WebhookEvent is the JSON body POSTed to subscribers. Property is omitted for deletions.
+type WebhookEvent struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurredAt"`
+	PropertyID string    `json:"propertyId"`
+	Property   *Property `json:"property,omitempty"`
+}
@@ -0,0 +1,294 @@
+// Package openapi checks request and response payloads against the swagger spec generated into the
+// docs package, so drift between the documentation and the handlers shows up at runtime.
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"homeinsight-properties/docs"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// Violation is one place where a payload doesn't match the spec. In is "body", "query", "path",
+// "header", "route" or "response"; Path is a JSON pointer into the body, or the parameter name.
+type Violation struct {
+	In      string `json:"in"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Operation is a documented route with the schemas its payloads are checked against.
+type Operation struct {
+	route *routers.Route
+}
+
+// HasBody reports whether the operation documents a request body.
+func (o *Operation) HasBody() bool {
+	return o.route.Operation.RequestBody != nil
+}
+
+// Validator holds the documented operations keyed by method and gin route.
+type Validator struct {
+	basePath   string
+	operations map[string]*Operation
+	options    openapi3filter.Options
+}
+
+// Load builds a Validator from the spec generated into the docs package.
+func Load() (*Validator, error) {
+	return New([]byte(docs.SwaggerInfo.ReadDoc()))
+}
+
+// New builds a Validator from a swagger 2.0 document, converted to OpenAPI 3 for kin-openapi.
+func New(doc []byte) (*Validator, error) {
+	var swagger openapi2.T
+	if err := swagger.UnmarshalJSON(doc); err != nil {
+		return nil, fmt.Errorf("parse swagger spec failed: %v", err)
+	}
+	spec, err := openapi2conv.ToV3(&swagger)
+	if err != nil {
+		return nil, fmt.Errorf("convert swagger spec failed: %v", err)
+	}
+	allowNull(spec)
+
+	v := &Validator{
+		basePath:   strings.TrimSuffix(swagger.BasePath, "/"),
+		operations: make(map[string]*Operation),
+		options: openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	}
+	for path, item := range spec.Paths.Map() {
+		route := v.basePath + ginRoute(path)
+		for method, op := range item.Operations() {
+			allowEmptyStrings(op)
+			v.operations[method+" "+route] = &Operation{route: &routers.Route{
+				Spec:      spec,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: op,
+			}}
+		}
+	}
+	return v, nil
+}
+
+// allowEmptyStrings accepts empty string query parameters, which swag has no annotation for.
+// Handlers read them with c.Query, where ?name= means the same as leaving name out, or for ?cursor=
+// asks for the first page.
+func allowEmptyStrings(op *openapi3.Operation) {
+	for _, param := range op.Parameters {
+		if param.Value == nil || param.Value.In != openapi3.ParameterInQuery || param.Value.Schema == nil {
+			continue
+		}
+		if schema := param.Value.Schema.Value; schema != nil && schema.Type.Is(openapi3.TypeString) {
+			param.Value.AllowEmptyValue = true
+		}
+	}
+}
+
+// allowNull marks every body schema nullable, since Go encodes nil slices, maps and pointers as null.
+func allowNull(spec *openapi3.T) {
+	seen := make(map[*openapi3.Schema]bool)
+	for _, schema := range spec.Components.Schemas {
+		markNullable(schema, seen)
+	}
+	for _, item := range spec.Paths.Map() {
+		for _, op := range item.Operations() {
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				for _, media := range op.RequestBody.Value.Content {
+					markNullable(media.Schema, seen)
+				}
+			}
+			for _, response := range op.Responses.Map() {
+				if response.Value == nil {
+					continue
+				}
+				for _, media := range response.Value.Content {
+					markNullable(media.Schema, seen)
+				}
+			}
+		}
+	}
+}
+
+func markNullable(ref *openapi3.SchemaRef, seen map[*openapi3.Schema]bool) {
+	if ref == nil || ref.Value == nil || seen[ref.Value] {
+		return
+	}
+	schema := ref.Value
+	seen[schema] = true
+	schema.Nullable = true
+	markNullable(schema.Items, seen)
+	markNullable(schema.Not, seen)
+	markNullable(schema.AdditionalProperties.Schema, seen)
+	for _, property := range schema.Properties {
+		markNullable(property, seen)
+	}
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, member := range group {
+			markNullable(member, seen)
+		}
+	}
+}
+
+// ginRoute turns a swagger path template such as /properties/{id} into the gin route /properties/:id.
+func ginRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			segments[i] = ":" + segment[1:len(segment)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Operation returns the documented operation for a method and gin route, or nil if it isn't documented.
+func (v *Validator) Operation(method, route string) *Operation {
+	return v.operations[method+" "+route]
+}
+
+// Describes reports whether a gin route lies under the spec's base path, where every route is
+// expected to be documented.
+func (v *Validator) Describes(route string) bool {
+	return v.basePath == "" || route == v.basePath || strings.HasPrefix(route, v.basePath+"/")
+}
+
+// ValidateRequest checks the parameters of a request and, when body isn't nil, its JSON body. The
+// request itself is left as it was.
+func (v *Validator) ValidateRequest(op *Operation, req *http.Request, pathParams map[string]string, body []byte) []Violation {
+	clone := req.Clone(req.Context())
+	clone.Body = http.NoBody
+	options := v.options
+	options.ExcludeRequestBody = body == nil
+	if body != nil {
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+		clone.ContentLength = int64(len(body))
+		if clone.Header.Get("Content-Type") == "" {
+			clone.Header.Set("Content-Type", "application/json")
+		}
+	}
+
+	err := openapi3filter.ValidateRequest(req.Context(), &openapi3filter.RequestValidationInput{
+		Request:    clone,
+		PathParams: pathParams,
+		Route:      op.route,
+		Options:    &options,
+	})
+	return toViolations(err, "request")
+}
+
+// ValidateResponse checks a response body against the schema documented for its status code.
+// Statuses without a documented schema aren't checked.
+func (v *Validator) ValidateResponse(op *Operation, status int, contentType string, body []byte) []Violation {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request: &http.Request{Method: op.route.Method, URL: &url.URL{Path: op.route.Path}, Header: http.Header{}},
+			Route:   op.route,
+			Options: &v.options,
+		},
+		Status:  status,
+		Header:  http.Header{"Content-Type": {contentType}},
+		Options: &v.options,
+	}
+	input.SetBodyBytes(body)
+	return toViolations(openapi3filter.ValidateResponse(context.Background(), input), "response")
+}
+
+// toViolations flattens the errors kin-openapi reports into violations, one per offending value.
+func toViolations(err error, in string) []Violation {
+	if err == nil {
+		return nil
+	}
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var violations []Violation
+		for _, inner := range e {
+			violations = append(violations, toViolations(inner, in)...)
+		}
+		return violations
+	case *openapi3filter.RequestError:
+		switch {
+		case e.Parameter != nil:
+			return paramViolations(e, e.Parameter)
+		case e.RequestBody != nil:
+			if e.Err != nil && hasSchemaError(e.Err) {
+				return toViolations(e.Err, "body")
+			}
+			if errors.Is(e.Err, openapi3filter.ErrInvalidRequired) {
+				return []Violation{{In: "body", Message: "request body is required"}}
+			}
+			return []Violation{{In: "body", Message: message(e.Reason, e.Err)}}
+		}
+		return []Violation{{In: in, Message: e.Error()}}
+	case *openapi3filter.ResponseError:
+		if e.Err != nil && hasSchemaError(e.Err) {
+			return toViolations(e.Err, "response")
+		}
+		return []Violation{{In: "response", Message: message(e.Reason, e.Err)}}
+	case *openapi3filter.SecurityRequirementsError:
+		return nil
+	case *openapi3.SchemaError:
+		return []Violation{{In: in, Path: pointer(e.JSONPointer()), Message: e.Reason}}
+	}
+	return []Violation{{In: in, Message: err.Error()}}
+}
+
+// paramViolations reports the errors of one query, path or header parameter under its name.
+func paramViolations(e *openapi3filter.RequestError, param *openapi3.Parameter) []Violation {
+	if e.Err != nil && hasSchemaError(e.Err) {
+		violations := toViolations(e.Err, param.In)
+		for i := range violations {
+			violations[i].In = param.In
+			violations[i].Path = param.Name + violations[i].Path
+		}
+		return violations
+	}
+	if errors.Is(e.Err, openapi3filter.ErrInvalidRequired) {
+		return []Violation{{In: param.In, Path: param.Name, Message: "is required"}}
+	}
+	return []Violation{{In: param.In, Path: param.Name, Message: message(e.Reason, e.Err)}}
+}
+
+func hasSchemaError(err error) bool {
+	var schemaErr *openapi3.SchemaError
+	return errors.As(err, &schemaErr)
+}
+
+func message(reason string, err error) string {
+	if err == nil {
+		return reason
+	}
+	if reason == "" || reason == err.Error() {
+		return err.Error()
+	}
+	return reason + ": " + err.Error()
+}
+
+// pointer builds a JSON pointer (RFC 6901) from the tokens of a path into a payload.
+func pointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditEventRepository only inserts and reads; events are never updated or deleted.
+type auditEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditEventRepository() AuditEventRepository {
+	return &auditEventRepository{
+		collection: database.DB.Collection("audit_events"),
+	}
+}
+
+func (r *auditEventRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, event)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "audit_events").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "audit_events").Inc()
+		return err
+	}
+	return nil
+}
+
+// Find pages through the events that occurred in [from, to), newest first. A zero from or to leaves
+// that end open, and an empty eventType or actorID matches any.
+func (r *auditEventRepository) Find(ctx context.Context, from, to time.Time, eventType, actorID string, offset, limit int) ([]models.AuditEvent, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := bson.M{}
+	occurredAt := bson.M{}
+	if !from.IsZero() {
+		occurredAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		occurredAt["$lt"] = to
+	}
+	if len(occurredAt) > 0 {
+		filter["occurredAt"] = occurredAt
+	}
+	if eventType != "" {
+		filter["type"] = eventType
+	}
+	if actorID != "" {
+		filter["actorId"] = actorID
+	}
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "audit_events").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "audit_events").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "occurredAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "audit_events").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "audit_events").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.AuditEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "audit_events").Inc()
+		return nil, 0, err
+	}
+	return events, total, nil
+}
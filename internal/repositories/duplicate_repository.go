@@ -0,0 +1,383 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// propertyReference is a collection whose documents point at a property by propertyId. Records that
+// collide with a unique index when moved to another property are either dropped, because the target
+// already has the same record, or left where they are.
+type propertyReference struct {
+	collection     string
+	dropOnConflict bool
+	unique         *referenceIndex
+}
+
+// referenceIndex describes a collection's unique index on orgId, propertyId and fields, limited to
+// the records matching filter when it is partial.
+type referenceIndex struct {
+	fields []string
+	filter bson.M
+}
+
+// propertyReferences are moved to the kept property when two properties are merged. Owner entities
+// list their properties in an array and are handled separately.
+var propertyReferences = []propertyReference{
+	{collection: "property_audit"},
+	{collection: "property_diffs"},
+	{collection: "transactions", dropOnConflict: true, unique: &referenceIndex{fields: []string{"date", "recordingDate", "documentNumber"}}},
+	{collection: "valuations"},
+	{collection: "property_media"},
+	{collection: "listings", unique: &referenceIndex{filter: bson.M{"status": bson.M{"$in": bson.A{"active", "pending"}}}}},
+	{collection: "share_links"},
+	{collection: "saved_search_matches", dropOnConflict: true, unique: &referenceIndex{fields: []string{"savedSearchId"}}},
+}
+
+type duplicateRepository struct {
+	db         *mongo.Database
+	properties *mongo.Collection
+	collection *mongo.Collection
+}
+
+func NewDuplicateRepository() DuplicateRepository {
+	return &duplicateRepository{
+		db:         database.DB,
+		properties: database.DB.Collection("properties"),
+		collection: database.DB.Collection("duplicate_candidates"),
+	}
+}
+
+type duplicateGroupFacets struct {
+	Address []models.DuplicateGroup `bson:"address"`
+	Clip    []models.DuplicateGroup `bson:"clip"`
+}
+
+// FindGroups returns the live properties that share a normalized address or a CLIP, at most limit
+// groups of each.
+func (r *duplicateRepository) FindGroups(ctx context.Context, limit int) ([]models.DuplicateGroup, error) {
+	trimUpper := func(field string) bson.M {
+		return bson.M{"$toUpper": bson.M{"$trim": bson.M{"input": bson.M{"$ifNull": bson.A{field, ""}}}}}
+	}
+	groupStages := func(key string) bson.A {
+		return bson.A{
+			bson.M{"$match": bson.M{key: bson.M{"$nin": bson.A{"", nil}}}},
+			bson.M{"$group": bson.M{"_id": "$" + key, "propertyIds": bson.M{"$push": "$propertyId"}, "count": bson.M{"$sum": 1}}},
+			bson.M{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+			bson.M{"$limit": limit},
+		}
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(inTenant(ctx, bson.M{}))}},
+		{{Key: "$project", Value: bson.M{
+			"propertyId": 1,
+			"clip":       "$avmPropertyId",
+			"addressKey": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{trimUpper("$address.streetAddress"), ""}},
+				"",
+				bson.M{"$concat": bson.A{
+					trimUpper("$address.streetAddress"), "|",
+					trimUpper("$address.city"), "|",
+					trimUpper("$address.state"), "|",
+					bson.M{"$substrCP": bson.A{trimUpper("$address.zipCode"), 0, 5}},
+				}},
+			}},
+		}}},
+		{{Key: "$facet", Value: bson.M{
+			"address": groupStages("addressKey"),
+			"clip":    groupStages("clip"),
+		}}},
+	}
+
+	start := time.Now()
+	cursor, err := r.properties.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	metrics.MongoOperationDuration.WithLabelValues("aggregate_duplicates", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("aggregate_duplicates", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []duplicateGroupFacets
+	if err := cursor.All(ctx, &facets); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	var groups []models.DuplicateGroup
+	for _, facet := range facets {
+		for _, group := range facet.Address {
+			group.Reason = models.DuplicateReasonAddress
+			groups = append(groups, group)
+		}
+		for _, group := range facet.Clip {
+			group.Reason = models.DuplicateReasonClip
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// ReplaceCandidates swaps the organization's duplicate candidates for those of a new scan.
+func (r *duplicateRepository) ReplaceCandidates(ctx context.Context, candidates []models.DuplicateCandidate) error {
+	start := time.Now()
+	_, err := r.collection.DeleteMany(ctx, inTenant(ctx, bson.M{}))
+	metrics.MongoOperationDuration.WithLabelValues("delete_many", "duplicate_candidates").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete_many", "duplicate_candidates").Inc()
+		return err
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(candidates))
+	for i := range candidates {
+		docs[i] = candidates[i]
+	}
+	start = time.Now()
+	_, err = r.collection.InsertMany(ctx, docs)
+	metrics.MongoOperationDuration.WithLabelValues("insert_many", "duplicate_candidates").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert_many", "duplicate_candidates").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindCandidates pages through the duplicate candidates, most recently detected first.
+func (r *duplicateRepository) FindCandidates(ctx context.Context, offset, limit int) ([]models.DuplicateCandidate, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := inTenant(ctx, bson.M{})
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "duplicate_candidates").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "duplicate_candidates").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "detectedAt", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "duplicate_candidates").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "duplicate_candidates").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	candidates := []models.DuplicateCandidate{}
+	if err := cursor.All(ctx, &candidates); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "duplicate_candidates").Inc()
+		return nil, 0, err
+	}
+	return candidates, total, nil
+}
+
+// DeleteCandidatesFor removes the candidate pairs a property is part of.
+func (r *duplicateRepository) DeleteCandidatesFor(ctx context.Context, propertyID string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.DeleteMany(ctx, inTenant(ctx, bson.M{"propertyIds": propertyID}))
+	metrics.MongoOperationDuration.WithLabelValues("delete_many", "duplicate_candidates").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete_many", "duplicate_candidates").Inc()
+		return err
+	}
+	return nil
+}
+
+// RemapPropertyID points the records of property from at property to: its history, transactions,
+// valuations, media, listings, share links, saved search matches and owner entities. It returns how
+// many records moved and how many were left behind per collection. Re-running it after a failure
+// picks up where it stopped.
+func (r *duplicateRepository) RemapPropertyID(ctx context.Context, from, to string) (map[string]int64, map[string]int64, error) {
+	remapped := make(map[string]int64)
+	conflicts := make(map[string]int64)
+	for _, ref := range propertyReferences {
+		moved, left, err := r.remapCollection(ctx, ref, from, to)
+		if moved > 0 {
+			remapped[ref.collection] = moved
+		}
+		if left > 0 {
+			conflicts[ref.collection] = left
+		}
+		if err != nil {
+			return remapped, conflicts, err
+		}
+	}
+
+	// Owner entities list their properties; swap the ID without listing the kept property twice
+	start := time.Now()
+	result, err := r.db.Collection("owner_entities").UpdateMany(ctx,
+		inTenant(ctx, bson.M{"propertyIds": from}),
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"propertyIds": bson.M{"$setUnion": bson.A{
+			bson.M{"$setDifference": bson.A{"$propertyIds", bson.A{from}}},
+			bson.A{to},
+		}}}}}},
+	)
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "owner_entities").Inc()
+		return remapped, conflicts, err
+	}
+	if result.ModifiedCount > 0 {
+		remapped["owner_entities"] = result.ModifiedCount
+	}
+	return remapped, conflicts, nil
+}
+
+// remapCollection moves a collection's records in one update. Records known to collide with the
+// target's are dropped or skipped beforehand, since inside a transaction a rejected write aborts it;
+// records colliding with ones written meanwhile are moved one at a time.
+func (r *duplicateRepository) remapCollection(ctx context.Context, ref propertyReference, from, to string) (int64, int64, error) {
+	collection := r.db.Collection(ref.collection)
+	filter := inTenant(ctx, bson.M{"propertyId": from})
+	update := bson.M{"$set": bson.M{"propertyId": to}}
+
+	colliding, err := r.findColliding(ctx, collection, ref, from, to)
+	if err != nil {
+		return 0, 0, err
+	}
+	var skipped int64
+	if len(colliding) > 0 {
+		if ref.dropOnConflict {
+			start := time.Now()
+			_, err = collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": colliding}})
+			metrics.MongoOperationDuration.WithLabelValues("delete_many", ref.collection).Observe(time.Since(start).Seconds())
+			if err != nil {
+				metrics.MongoErrorsTotal.WithLabelValues("delete_many", ref.collection).Inc()
+				return 0, 0, err
+			}
+		} else {
+			filter["_id"] = bson.M{"$nin": colliding}
+			skipped = int64(len(colliding))
+		}
+	}
+
+	start := time.Now()
+	result, err := collection.UpdateMany(ctx, filter, update)
+	metrics.MongoOperationDuration.WithLabelValues("update_many", ref.collection).Observe(time.Since(start).Seconds())
+	if err == nil {
+		return result.ModifiedCount, skipped, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", ref.collection).Inc()
+		return 0, 0, err
+	}
+
+	start = time.Now()
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	metrics.MongoOperationDuration.WithLabelValues("find", ref.collection).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", ref.collection).Inc()
+		return 0, 0, err
+	}
+	var ids []struct {
+		ID interface{} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &ids); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", ref.collection).Inc()
+		return 0, 0, err
+	}
+
+	moved, left := int64(0), skipped
+	for _, doc := range ids {
+		start = time.Now()
+		_, err := collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, update)
+		metrics.MongoOperationDuration.WithLabelValues("update", ref.collection).Observe(time.Since(start).Seconds())
+		switch {
+		case err == nil:
+			moved++
+		case mongo.IsDuplicateKeyError(err) && ref.dropOnConflict:
+			start = time.Now()
+			_, err = collection.DeleteOne(ctx, bson.M{"_id": doc.ID})
+			metrics.MongoOperationDuration.WithLabelValues("delete", ref.collection).Observe(time.Since(start).Seconds())
+			if err != nil {
+				metrics.MongoErrorsTotal.WithLabelValues("delete", ref.collection).Inc()
+				return moved, left, err
+			}
+		case mongo.IsDuplicateKeyError(err):
+			left++
+		default:
+			metrics.MongoErrorsTotal.WithLabelValues("update", ref.collection).Inc()
+			return moved, left, err
+		}
+	}
+	return moved, left, nil
+}
+
+// findColliding returns the IDs of from's records that the to property already has a record for
+// under the collection's unique index.
+func (r *duplicateRepository) findColliding(ctx context.Context, collection *mongo.Collection, ref propertyReference, from, to string) ([]interface{}, error) {
+	if ref.unique == nil {
+		return nil, nil
+	}
+	projection := bson.M{"_id": 1}
+	for _, field := range ref.unique.fields {
+		projection[field] = 1
+	}
+	find := func(propertyID string) ([]bson.M, error) {
+		filter := inTenant(ctx, bson.M{"propertyId": propertyID})
+		for key, value := range ref.unique.filter {
+			filter[key] = value
+		}
+		start := time.Now()
+		cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(projection))
+		metrics.MongoOperationDuration.WithLabelValues("find", ref.collection).Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.MongoErrorsTotal.WithLabelValues("find", ref.collection).Inc()
+			return nil, err
+		}
+		var docs []bson.M
+		if err := cursor.All(ctx, &docs); err != nil {
+			metrics.MongoErrorsTotal.WithLabelValues("cursor_all", ref.collection).Inc()
+			return nil, err
+		}
+		return docs, nil
+	}
+	key := func(doc bson.M) string {
+		values := make([]string, 0, len(ref.unique.fields))
+		for _, field := range ref.unique.fields {
+			values = append(values, fmt.Sprint(doc[field]))
+		}
+		return strings.Join(values, "\x00")
+	}
+
+	existing, err := find(to)
+	if err != nil || len(existing) == 0 {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, doc := range existing {
+		taken[key(doc)] = true
+	}
+	candidates, err := find(from)
+	if err != nil {
+		return nil, err
+	}
+	var colliding []interface{}
+	for _, doc := range candidates {
+		if taken[key(doc)] {
+			colliding = append(colliding, doc["_id"])
+		}
+	}
+	return colliding, nil
+}
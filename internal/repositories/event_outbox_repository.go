@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type eventOutboxRepository struct {
+	collection *mongo.Collection
+	pii        fieldcrypt.Cipher
+}
+
+func NewEventOutboxRepository(pii fieldcrypt.Cipher) EventOutboxRepository {
+	return &eventOutboxRepository{
+		collection: database.DB.Collection("event_outbox"),
+		pii:        pii,
+	}
+}
+
+// Create stores a pending event. The payload leaves out owner PII, but is still sealed as a whole
+// while it waits in the outbox.
+func (r *eventOutboxRepository) Create(ctx context.Context, event *models.OutboxEvent) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	stored := *event
+	payload, err := r.pii.Encrypt(event.Payload)
+	if err != nil {
+		return err
+	}
+	stored.Payload = payload
+
+	start := time.Now()
+	_, err = r.collection.InsertOne(ctx, &stored)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "event_outbox").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "event_outbox").Inc()
+		return err
+	}
+	return nil
+}
+
+// ClaimDue takes the oldest pending event that is due and pushes its next attempt out by lease, so
+// other relays skip it while it is being published. An event whose relay dies becomes due again once
+// the lease runs out. Returns nil when nothing is due.
+func (r *eventOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxEvent, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"status": models.OutboxStatusPending, "nextAttemptAt": bson.M{"$lte": now}}
+	update := bson.M{
+		"$set": bson.M{"nextAttemptAt": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "nextAttemptAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	start := time.Now()
+	var event models.OutboxEvent
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&event)
+	metrics.MongoOperationDuration.WithLabelValues("find_one_and_update", "event_outbox").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one_and_update", "event_outbox").Inc()
+		return nil, err
+	}
+
+	if event.Payload, err = r.pii.Decrypt(event.Payload); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// MarkPublished records the broker's acknowledgement; the TTL index removes the entry at expiresAt.
+func (r *eventOutboxRepository) MarkPublished(ctx context.Context, id primitive.ObjectID, at, expiresAt time.Time) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{
+		"$set":   bson.M{"status": models.OutboxStatusPublished, "publishedAt": at, "expiresAt": expiresAt},
+		"$unset": bson.M{"lastError": ""},
+	}
+	return r.update(ctx, id, update)
+}
+
+// MarkFailed keeps the event pending and schedules its next attempt.
+func (r *eventOutboxRepository) MarkFailed(ctx context.Context, id primitive.ObjectID, nextAttemptAt time.Time, publishErr string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{"$set": bson.M{"nextAttemptAt": nextAttemptAt, "lastError": publishErr}}
+	return r.update(ctx, id, update)
+}
+
+func (r *eventOutboxRepository) update(ctx context.Context, id primitive.ObjectID, update bson.M) error {
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "event_outbox").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "event_outbox").Inc()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type feedRunRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFeedRunRepository() FeedRunRepository {
+	return &feedRunRepository{
+		collection: database.DB.Collection("feed_runs"),
+	}
+}
+
+func (r *feedRunRepository) Create(ctx context.Context, run *models.FeedRun) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, run)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "feed_runs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "feed_runs").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindByID returns a feed run of the organization ctx is scoped to, or nil if it doesn't exist.
+func (r *feedRunRepository) FindByID(ctx context.Context, id string) (*models.FeedRun, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	cost.Record(ctx, cost.MongoQuery)
+
+	start := time.Now()
+	var run models.FeedRun
+	err = r.collection.FindOne(ctx, inTenant(ctx, bson.M{"_id": objID})).Decode(&run)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "feed_runs").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "feed_runs").Inc()
+		return nil, err
+	}
+	return &run, nil
+}
+
+// Find pages through the feed runs of the organization, newest first, without their row errors. An
+// empty provider matches any.
+func (r *feedRunRepository) Find(ctx context.Context, provider string, offset, limit int) ([]models.FeedRun, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := bson.M{}
+	if provider != "" {
+		filter["provider"] = provider
+	}
+	filter = inTenant(ctx, filter)
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "feed_runs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "feed_runs").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "uploadedAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"rowErrors": 0})
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "feed_runs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "feed_runs").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	runs := []models.FeedRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "feed_runs").Inc()
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// SaveProgress adds a processed batch of records to a run. Row errors past maxRowErrors are counted
+// but not kept.
+func (r *feedRunRepository) SaveProgress(ctx context.Context, id primitive.ObjectID, progress models.FeedRunProgress, maxRowErrors int) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{
+		"$set": bson.M{"updatedAt": time.Now().UTC()},
+		"$inc": bson.M{
+			"processed": progress.Processed,
+			"created":   progress.Created,
+			"updated":   progress.Updated,
+			"failed":    int64(len(progress.Errors)),
+		},
+	}
+	if len(progress.Errors) > 0 {
+		update["$push"] = bson.M{"rowErrors": bson.M{"$each": progress.Errors, "$slice": maxRowErrors}}
+	}
+
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "feed_runs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "feed_runs").Inc()
+		return err
+	}
+	return nil
+}
+
+// SetStatus moves a run to a status. Completed and failed runs get their finish time and failed ones
+// the error that stopped them.
+func (r *feedRunRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status, runErr string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	now := time.Now().UTC()
+	set := bson.M{"status": status, "updatedAt": now}
+	unset := bson.M{}
+	switch status {
+	case models.FeedRunStatusCompleted, models.FeedRunStatusFailed:
+		set["finishedAt"] = now
+	default:
+		unset["finishedAt"] = ""
+	}
+	if runErr != "" {
+		set["error"] = runErr
+	} else {
+		unset["error"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "feed_runs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "feed_runs").Inc()
+		return err
+	}
+	return nil
+}
+
+// SetJob records the background job currently working on a run.
+func (r *feedRunRepository) SetJob(ctx context.Context, id primitive.ObjectID, jobID string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"jobId": jobID, "updatedAt": time.Now().UTC()}})
+	metrics.MongoOperationDuration.WithLabelValues("update", "feed_runs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "feed_runs").Inc()
+		return err
+	}
+	return nil
+}
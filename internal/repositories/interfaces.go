@@ -5,33 +5,316 @@ import (
 	"time"
 
 	"homeinsight-properties/internal/models"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Transactor runs functions writing several documents atomically. Repository calls made with the
+// ctx passed to fn take part in the transaction.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type PropertyRepository interface {
 	FindByID(ctx context.Context, id string) (*models.Property, error)
 	FindByAddress(ctx context.Context, street, city, state, zip string) (*models.Property, error)
-	FindWithPagination(ctx context.Context, offset, limit int) ([]models.Property, int64, error)
-	Create(ctx context.Context, property *models.Property) error
+	FindAddressCandidates(ctx context.Context, houseNumber, city, state, zip string, limit int) ([]models.Property, error)
+	FindWithPagination(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int) ([]models.Property, int64, error)
+	FindPage(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int) ([]models.Property, error)
+	FindPageWithTotal(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int) ([]models.Property, int64, error)
+	CountMatching(ctx context.Context, filter *models.PropertyFilter) (int64, error)
+	FindAfterCursor(ctx context.Context, filter *models.PropertyFilter, fields models.PropertyFields, afterStreet string, afterID primitive.ObjectID, limit int) ([]models.Property, error)
+	EstimatedCount(ctx context.Context) (int64, error)
+	TextSearch(ctx context.Context, query string, offset, limit int) ([]models.Property, int64, error)
+	FindNearby(ctx context.Context, lat, lng, radiusMeters float64, offset, limit int) ([]models.NearbyProperty, int64, error)
+	FindWithin(ctx context.Context, boundary *models.GeoJSONPolygon, fields models.PropertyFields, afterID primitive.ObjectID, limit int) ([]models.Property, error)
+	CountWithin(ctx context.Context, boundary *models.GeoJSONPolygon, max int64) (int64, error)
+	FindMatchingAfter(ctx context.Context, filter *models.PropertyFilter, fields models.PropertyFields, afterID primitive.ObjectID, limit int) ([]models.Property, error)
+	BackfillGeoPoints(ctx context.Context) (int64, error)
+	RotatePIIEncryption(ctx context.Context) (int64, error)
+	FindAddressesAfter(ctx context.Context, afterID primitive.ObjectID, limit int) ([]models.Property, error)
+	UpdateAddress(ctx context.Context, id primitive.ObjectID, address models.Address) error
+	Create(ctx context.Context, property *models.Property) (bool, error)
 	Update(ctx context.Context, property *models.Property) error
+	Patch(ctx context.Context, property *models.Property, paths []string) error
+	PatchMany(ctx context.Context, properties []*models.Property, paths []string) (int64, error)
 	Delete(ctx context.Context, id string) error
+	DeleteMany(ctx context.Context, ids []string) (int64, error)
+	Restore(ctx context.Context, id string) error
+	Purge(ctx context.Context, id string) error
+	FindDeleted(ctx context.Context, offset, limit int) ([]models.Property, int64, error)
+	DataQualityReport(ctx context.Context) (*models.DataQualityReport, error)
 	FindAll(ctx context.Context) ([]models.Property, error)
+	FindByIDs(ctx context.Context, ids []string, fields models.PropertyFields, offset, limit int) ([]models.Property, error)
+	FindRecentlyUpdated(ctx context.Context, limit int) ([]models.Property, error)
+	FindStale(ctx context.Context, updatedBefore time.Time, limit int) ([]models.Property, error)
+	Stream(ctx context.Context, filter *models.PropertyFilter, fields models.PropertyFields, batchSize int, fn func(*models.Property) error) error
+	FindMatchingCriteria(ctx context.Context, criteria models.SavedSearchCriteria, updatedSince time.Time, limit int) ([]models.Property, error)
+	WatchChanges(ctx context.Context, resumeAfter []byte, fn func(change models.PropertyChange) error) error
+	Explain(ctx context.Context, query string, filter *models.PropertyFilter, sort models.PropertySort, text string, limit int) (*models.QueryExplanation, error)
 }
 
 type PropertyCache interface {
-	GetProperty(ctx context.Context, key string) (*models.Property, error)
+	GetProperty(ctx context.Context, key string) (*models.Property, string, error)
 	SetProperty(ctx context.Context, key string, property *models.Property, expiration time.Duration) error
+	GetProperties(ctx context.Context, ids []string) (map[string]*models.Property, error)
+	SetProperties(ctx context.Context, properties []models.Property) error
 	GetSearchKey(ctx context.Context, key string) (string, error)
 	SetSearchKey(ctx context.Context, key, propertyID string, expiration time.Duration) error
 	AddCacheKeyToPropertySet(ctx context.Context, propertyID, cacheKey string) error
+	AddCacheKeyToPropertySets(ctx context.Context, propertyIDs []string, cacheKey string) error
 	InvalidatePropertyCacheKeys(ctx context.Context, propertyID string) error
+	GetCount(ctx context.Context, key string) (int64, bool, error)
+	SetCount(ctx context.Context, key string, count int64, expiration time.Duration) error
+	GetSearchResult(ctx context.Context, key string) (*models.CachedSearchResult, error)
+	SetSearchResult(ctx context.Context, key string, result *models.CachedSearchResult, expiration time.Duration) error
+	SetListPage(ctx context.Context, key string, result *models.CachedSearchResult, expiration time.Duration) error
+	GetValuation(ctx context.Context, key string) (*models.Valuation, error)
+	SetValuation(ctx context.Context, key string, valuation *models.Valuation, expiration time.Duration) error
+	GetListing(ctx context.Context, key string) (*models.Listing, error)
+	SetListing(ctx context.Context, key string, listing *models.Listing, expiration time.Duration) error
+	GetTransactions(ctx context.Context, key string) ([]models.Transaction, bool, error)
+	SetTransactions(ctx context.Context, key, propertyID string, transactions []models.Transaction, expiration time.Duration) error
+	GetMedia(ctx context.Context, key string) ([]models.PropertyMedia, bool, error)
+	SetMedia(ctx context.Context, key, propertyID string, media []models.PropertyMedia, expiration time.Duration) error
+	TTL(class string) time.Duration
 	Delete(ctx context.Context, key string) error
-	ClearAll(ctx context.Context) error
+	ClearSearches(ctx context.Context) (int64, error)
+	ClearAll(ctx context.Context) (int64, error)
+	PruneKeySets(ctx context.Context) (int64, error)
+}
+
+// OwnerEntityRepository defines the interface for the owner-entity index
+type OwnerEntityRepository interface {
+	FindByEntityID(ctx context.Context, entityID string) (*models.OwnerEntity, error)
+	FindByPropertyID(ctx context.Context, propertyID string) ([]models.OwnerEntity, error)
+	FindByNamePrefix(ctx context.Context, name string, limit int) ([]models.OwnerEntity, error)
+	LinkProperty(ctx context.Context, entity *models.OwnerEntity, propertyID string) error
+	UnlinkProperty(ctx context.Context, propertyID string) error
+	Count(ctx context.Context) (int64, error)
 }
 
+// ShareLinkRepository defines the interface for property share link operations
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *models.ShareLink) error
+	FindByPropertyID(ctx context.Context, propertyID, createdBy string) ([]models.ShareLink, error)
+	RecordAccess(ctx context.Context, linkID string) (*models.ShareLink, error)
+	Revoke(ctx context.Context, propertyID, linkID, createdBy string) (bool, error)
+}
 
+// NotificationPreferenceRepository defines the interface for per-user notification settings
+type NotificationPreferenceRepository interface {
+	FindByUserID(ctx context.Context, userID string) (*models.NotificationPreference, error)
+	FindByUserIDs(ctx context.Context, userIDs []string) ([]models.NotificationPreference, error)
+	Upsert(ctx context.Context, pref *models.NotificationPreference) error
+}
+
+// NotificationRepository defines the interface for in-app notifications
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	FindByUserID(ctx context.Context, userID string, unreadOnly bool, offset, limit int) ([]models.Notification, int64, error)
+	CountUnread(ctx context.Context, userID string) (int64, error)
+	MarkRead(ctx context.Context, userID, id string, readAt time.Time) (bool, error)
+	MarkAllRead(ctx context.Context, userID string, readAt time.Time) (int64, error)
+}
+
+// PropertyAlertRepository defines the interface for queued property-change alerts
+type PropertyAlertRepository interface {
+	Create(ctx context.Context, alert *models.PropertyAlert) error
+	FindPendingUserIDs(ctx context.Context) ([]string, error)
+	FindPendingByUserID(ctx context.Context, userID string) ([]models.PropertyAlert, error)
+	MarkDelivered(ctx context.Context, ids []primitive.ObjectID, deliveredAt time.Time) error
+}
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByID(ctx context.Context, id string) (*models.User, error)
 	Create(ctx context.Context, user *models.User) error
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	FindByIdentity(ctx context.Context, provider, subject string) (*models.User, error)
+	AddIdentity(ctx context.Context, id string, identity models.UserIdentity) (bool, error)
+}
+
+// RefreshTokenRepository defines the interface for stored refresh tokens
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *models.RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	Rotate(ctx context.Context, tokenHash, replacedBy string) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID string) error
+}
+
+// SessionRepository defines the interface for the signed-in devices of users
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	FindActive(ctx context.Context, userID string) ([]models.Session, error)
+	Touch(ctx context.Context, id, ip string, usedAt, expiresAt time.Time) error
+	Revoke(ctx context.Context, userID, id string) (bool, error)
+	RevokeAllForUser(ctx context.Context, userID string) ([]string, error)
+}
+
+// ReindexJobRepository defines the interface for admin-triggered index build jobs
+type ReindexJobRepository interface {
+	Create(ctx context.Context, job *models.ReindexJob) error
+	FindByID(ctx context.Context, id string) (*models.ReindexJob, error)
+	FindRecent(ctx context.Context, limit int) ([]models.ReindexJob, error)
+	UpdateProgress(ctx context.Context, job *models.ReindexJob) error
+}
+
+// IndexHintRepository defines the interface for persisted query hints
+type IndexHintRepository interface {
+	FindAll(ctx context.Context) ([]models.IndexHint, error)
+	Set(ctx context.Context, hint *models.IndexHint) error
+	DeleteByIndex(ctx context.Context, collection, indexName string) error
+}
+
+// SavedSearchRepository defines the interface for users' saved search criteria
+type SavedSearchRepository interface {
+	Create(ctx context.Context, search *models.SavedSearch) error
+	FindByID(ctx context.Context, id, userID string) (*models.SavedSearch, error)
+	FindByUserID(ctx context.Context, userID string) ([]models.SavedSearch, error)
+	CountByUserID(ctx context.Context, userID string) (int64, error)
+	FindDue(ctx context.Context, before time.Time, limit int) ([]models.SavedSearch, error)
+	MarkAttempted(ctx context.Context, id primitive.ObjectID, attemptAt time.Time) error
+	MarkRun(ctx context.Context, id primitive.ObjectID, runAt time.Time, newMatches int64, caughtUp bool) error
+	Delete(ctx context.Context, id, userID string) (bool, error)
+}
+
+// SavedSearchMatchRepository defines the interface for properties recorded against saved searches
+type SavedSearchMatchRepository interface {
+	InsertNew(ctx context.Context, matches []models.SavedSearchMatch) ([]models.SavedSearchMatch, error)
+	FindBySavedSearchID(ctx context.Context, savedSearchID primitive.ObjectID, offset, limit int) ([]models.SavedSearchMatch, int64, error)
+	DeleteBySavedSearchID(ctx context.Context, savedSearchID primitive.ObjectID) error
+	FindUserIDsByPropertyID(ctx context.Context, propertyID string) ([]string, error)
+}
+
+// WebhookRepository defines the interface for registered webhook callbacks
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	FindAll(ctx context.Context) ([]models.Webhook, error)
+	FindByEvent(ctx context.Context, eventType string) ([]models.Webhook, error)
+	FindByID(ctx context.Context, id string) (*models.Webhook, error)
+	RecordDelivery(ctx context.Context, id primitive.ObjectID, at time.Time, status, deliveryErr string) error
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// EventOutboxRepository defines the interface for property events awaiting publication to the broker
+type EventOutboxRepository interface {
+	Create(ctx context.Context, event *models.OutboxEvent) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id primitive.ObjectID, at, expiresAt time.Time) error
+	MarkFailed(ctx context.Context, id primitive.ObjectID, nextAttemptAt time.Time, publishErr string) error
+}
+
+// ValuationRepository defines the interface for the property valuation history
+type ValuationRepository interface {
+	Create(ctx context.Context, valuation *models.Valuation) error
+	FindLatest(ctx context.Context, propertyID string) (*models.Valuation, error)
+}
+
+// PropertyAuditRepository defines the interface for the property change history
+type PropertyAuditRepository interface {
+	Create(ctx context.Context, entry *models.PropertyAuditEntry) error
+	FindByProperty(ctx context.Context, propertyID string, offset, limit int) ([]models.PropertyAuditEntry, int64, error)
+}
+
+// PropertyDiffRepository defines the interface for the changes found by property refreshes
+type PropertyDiffRepository interface {
+	Create(ctx context.Context, diff *models.PropertyDiff) error
+	FindByProperty(ctx context.Context, propertyID string, offset, limit int) ([]models.PropertyDiff, int64, error)
+}
+
+// AuditEventRepository defines the interface for the append-only security audit log
+type AuditEventRepository interface {
+	Create(ctx context.Context, event *models.AuditEvent) error
+	Find(ctx context.Context, from, to time.Time, eventType, actorID string, offset, limit int) ([]models.AuditEvent, int64, error)
+}
+
+// PropertyMediaRepository defines the interface for photos and documents attached to properties
+type PropertyMediaRepository interface {
+	Create(ctx context.Context, media *models.PropertyMedia) error
+	FindByPropertyID(ctx context.Context, propertyID string) ([]models.PropertyMedia, error)
+	FindByID(ctx context.Context, propertyID, id string) (*models.PropertyMedia, error)
+	Delete(ctx context.Context, propertyID, id string) (bool, error)
+}
+
+// ListingRepository defines the interface for for-sale listings of properties
+type ListingRepository interface {
+	Create(ctx context.Context, listing *models.Listing) error
+	FindByPropertyID(ctx context.Context, propertyID string) ([]models.Listing, error)
+	FindByID(ctx context.Context, propertyID, id string) (*models.Listing, error)
+	FindLatest(ctx context.Context, propertyID string) (*models.Listing, error)
+	Update(ctx context.Context, listing *models.Listing) error
+	Delete(ctx context.Context, propertyID, id string) (bool, error)
+}
+
+// MarketStatsRepository defines the interface for market statistics aggregated from properties
+type MarketStatsRepository interface {
+	ComputeZipStats(ctx context.Context, zipCode string) (*models.MarketStats, error)
+}
+
+// TransactionRepository defines the interface for the sale and transfer history of properties
+type TransactionRepository interface {
+	UpsertMany(ctx context.Context, propertyID string, transactions []models.Transaction) error
+	FindByProperty(ctx context.Context, propertyID string, filter models.TransactionFilter, offset, limit int) ([]models.Transaction, int64, error)
+}
+
+// OrganizationRepository defines the interface for organizations (tenants)
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *models.Organization) error
+	FindByID(ctx context.Context, id string) (*models.Organization, error)
+	FindBySlug(ctx context.Context, slug string) (*models.Organization, error)
+	FindAll(ctx context.Context, offset, limit int) ([]models.Organization, int64, error)
+	AssignUnowned(ctx context.Context, orgID string) (int64, error)
+}
+
+// MembershipRepository defines the interface for the organization each user belongs to
+type MembershipRepository interface {
+	FindByUserID(ctx context.Context, userID string) (*models.Membership, error)
+	FindByOrgID(ctx context.Context, orgID string, offset, limit int) ([]models.Membership, int64, error)
+	Join(ctx context.Context, membership *models.Membership) (*models.Membership, error)
+	Set(ctx context.Context, membership *models.Membership) error
+}
+
+// UsageRepository defines the interface for daily usage rollups used for billing
+type UsageRepository interface {
+	UpsertMany(ctx context.Context, records []models.UsageRecord) error
+	Find(ctx context.Context, filter models.UsageFilter) ([]models.UsageRecord, error)
+}
+
+// MigrationRepository defines the interface for the progress of data migrations launched by admins
+type MigrationRepository interface {
+	Create(ctx context.Context, run *models.MigrationRun) error
+	FindByID(ctx context.Context, id string) (*models.MigrationRun, error)
+	FindByName(ctx context.Context, name string, limit int) ([]models.MigrationRun, error)
+	SaveProgress(ctx context.Context, id primitive.ObjectID, fence int64, checkpoint string, processed int64, failures []string) error
+	SetStatus(ctx context.Context, id primitive.ObjectID, status, runErr string) error
+	SetJob(ctx context.Context, id primitive.ObjectID, jobID string) error
+}
+
+// FeedRunRepository defines the interface for the processing of files uploaded to data feeds
+type FeedRunRepository interface {
+	Create(ctx context.Context, run *models.FeedRun) error
+	FindByID(ctx context.Context, id string) (*models.FeedRun, error)
+	Find(ctx context.Context, provider string, offset, limit int) ([]models.FeedRun, int64, error)
+	SaveProgress(ctx context.Context, id primitive.ObjectID, progress models.FeedRunProgress, maxRowErrors int) error
+	SetStatus(ctx context.Context, id primitive.ObjectID, status, runErr string) error
+	SetJob(ctx context.Context, id primitive.ObjectID, jobID string) error
+}
+
+// DuplicateRepository defines the interface for finding duplicate properties and merging their records
+type DuplicateRepository interface {
+	FindGroups(ctx context.Context, limit int) ([]models.DuplicateGroup, error)
+	ReplaceCandidates(ctx context.Context, candidates []models.DuplicateCandidate) error
+	FindCandidates(ctx context.Context, offset, limit int) ([]models.DuplicateCandidate, int64, error)
+	DeleteCandidatesFor(ctx context.Context, propertyID string) error
+	RemapPropertyID(ctx context.Context, from, to string) (map[string]int64, map[string]int64, error)
+}
+
+// LocationRepository defines the interface for the state, city and zip code hierarchy of properties
+type LocationRepository interface {
+	States(ctx context.Context) ([]models.LocationCount, error)
+	Cities(ctx context.Context, state string) ([]models.LocationCount, error)
+	Zips(ctx context.Context, state, city string) ([]models.LocationCount, error)
 }
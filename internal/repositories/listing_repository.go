@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type listingRepository struct {
+	collection *mongo.Collection
+}
+
+func NewListingRepository() ListingRepository {
+	return &listingRepository{
+		collection: database.DB.Collection("listings"),
+	}
+}
+
+// Create inserts a listing. The unique index on open listings rejects a second active or pending
+// listing for the same property.
+func (r *listingRepository) Create(ctx context.Context, listing *models.Listing) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	listing.OrgID = tenant.OrgID(ctx)
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, listing)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "listings").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "listings").Inc()
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("open listing already exists: propertyId=%s", listing.PropertyID)
+		}
+		return err
+	}
+	return nil
+}
+
+// FindByPropertyID returns a property's listings, newest first.
+func (r *listingRepository) FindByPropertyID(ctx context.Context, propertyID string) ([]models.Listing, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	opts := options.Find().SetSort(bson.D{{Key: "listedAt", Value: -1}})
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, inTenant(ctx, bson.M{"propertyId": propertyID}), opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "listings").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "listings").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	listings := []models.Listing{}
+	if err := cursor.All(ctx, &listings); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "listings").Inc()
+		return nil, err
+	}
+	return listings, nil
+}
+
+// FindByID returns one listing of a property, or nil if it doesn't exist.
+func (r *listingRepository) FindByID(ctx context.Context, propertyID, id string) (*models.Listing, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.findOne(ctx, inTenant(ctx, bson.M{"_id": objID, "propertyId": propertyID}), nil)
+}
+
+// FindLatest returns a property's most recently listed listing, or nil if it has none.
+func (r *listingRepository) FindLatest(ctx context.Context, propertyID string) (*models.Listing, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	opts := options.FindOne().SetSort(bson.D{{Key: "listedAt", Value: -1}})
+	return r.findOne(ctx, inTenant(ctx, bson.M{"propertyId": propertyID}), opts)
+}
+
+func (r *listingRepository) findOne(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*models.Listing, error) {
+	start := time.Now()
+	var listing models.Listing
+	err := r.collection.FindOne(ctx, filter, opts).Decode(&listing)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "listings").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "listings").Inc()
+		return nil, err
+	}
+	return &listing, nil
+}
+
+func (r *listingRepository) Update(ctx context.Context, listing *models.Listing) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	result, err := r.collection.ReplaceOne(ctx, inTenant(ctx, bson.M{"_id": listing.ID, "propertyId": listing.PropertyID}), listing)
+	metrics.MongoOperationDuration.WithLabelValues("replace_one", "listings").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("replace_one", "listings").Inc()
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("open listing already exists: propertyId=%s", listing.PropertyID)
+		}
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("listing not found: propertyId=%s, id=%s", listing.PropertyID, listing.ID.Hex())
+	}
+	return nil
+}
+
+func (r *listingRepository) Delete(ctx context.Context, propertyID, id string) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	result, err := r.collection.DeleteOne(ctx, inTenant(ctx, bson.M{"_id": objID, "propertyId": propertyID}))
+	metrics.MongoOperationDuration.WithLabelValues("delete", "listings").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete", "listings").Inc()
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type locationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLocationRepository() LocationRepository {
+	return &locationRepository{
+		collection: database.DB.Collection("properties"),
+	}
+}
+
+// States counts the live properties of each state.
+func (r *locationRepository) States(ctx context.Context) ([]models.LocationCount, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	return r.countBy(ctx, "aggregate_location_states", bson.M{}, bson.M{"$toUpper": "$address.state"})
+}
+
+// Cities counts the live properties of each city in a state.
+func (r *locationRepository) Cities(ctx context.Context, state string) ([]models.LocationCount, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	return r.countBy(ctx, "aggregate_location_cities", bson.M{"address.state": state}, bson.M{"$toUpper": "$address.city"})
+}
+
+// Zips counts the live properties of each 5-digit zip code in a city.
+func (r *locationRepository) Zips(ctx context.Context, state, city string) ([]models.LocationCount, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	return r.countBy(ctx, "aggregate_location_zips", bson.M{"address.state": state, "address.city": city},
+		bson.M{"$substrCP": bson.A{"$address.zipCode", 0, 5}})
+}
+
+// countBy groups the organization's live properties matching filter by key, in name order. Case
+// variants of a name share a group; properties without one are left out.
+func (r *locationRepository) countBy(ctx context.Context, op string, filter bson.M, key bson.M) ([]models.LocationCount, error) {
+	cost.Record(ctx, cost.MongoQuery)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(inTenant(ctx, filter))}},
+		{{Key: "$group", Value: bson.M{"_id": key, "count": bson.M{"$sum": 1}}}},
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$ne": ""}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	start := time.Now()
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	metrics.MongoOperationDuration.WithLabelValues(op, "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues(op, "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	locations := []models.LocationCount{}
+	if err := cursor.All(ctx, &locations); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	return locations, nil
+}
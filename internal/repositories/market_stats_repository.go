@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const millisecondsPerDay = 24 * 60 * 60 * 1000
+
+// daysSinceSaleBoundaries are the lower bounds of the days-since-last-sale buckets. The last bound
+// only closes the final bucket, which is reported as open-ended.
+var daysSinceSaleBoundaries = []int{0, 30, 90, 180, 365, 730, 1825, 3650, 1 << 30}
+
+type marketStatsRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMarketStatsRepository() MarketStatsRepository {
+	return &marketStatsRepository{
+		collection: database.DB.Collection("properties"),
+	}
+}
+
+type marketStatsFacets struct {
+	Count []struct {
+		N int64 `bson:"n"`
+	} `bson:"count"`
+	Sales []struct {
+		Count  int64   `bson:"count"`
+		Median float64 `bson:"median"`
+	} `bson:"sales"`
+	PricePerSqft []struct {
+		Average float64 `bson:"average"`
+	} `bson:"pricePerSqft"`
+	DaysSinceLastSale []struct {
+		Min   int   `bson:"_id"`
+		Count int64 `bson:"count"`
+	} `bson:"daysSinceLastSale"`
+	YearBuilt []struct {
+		Decade int   `bson:"_id"`
+		Count  int64 `bson:"count"`
+	} `bson:"yearBuilt"`
+}
+
+// ComputeZipStats aggregates the statistics of a zip code's properties in a single pass over the
+// zip's documents.
+func (r *marketStatsRepository) ComputeZipStats(ctx context.Context, zipCode string) (*models.MarketStats, error) {
+	cost.Record(ctx, cost.MongoQuery)
+	saleDate := bson.M{"$dateFromString": bson.M{
+		"dateString": "$lastMarketSale.date",
+		"format":     "%Y-%m-%d",
+		"onError":    nil,
+		"onNull":     nil,
+	}}
+	// The median of the sorted prices is the middle one, or the mean of the middle two
+	median := bson.M{"$let": bson.M{
+		"vars": bson.M{"n": bson.M{"$size": "$prices"}, "half": bson.M{"$floor": bson.M{"$divide": bson.A{bson.M{"$size": "$prices"}, 2}}}},
+		"in": bson.M{"$cond": bson.A{
+			bson.M{"$eq": bson.A{bson.M{"$mod": bson.A{"$$n", 2}}, 1}},
+			bson.M{"$arrayElemAt": bson.A{"$prices", "$$half"}},
+			bson.M{"$avg": bson.A{
+				bson.M{"$arrayElemAt": bson.A{"$prices", bson.M{"$subtract": bson.A{"$$half", 1}}}},
+				bson.M{"$arrayElemAt": bson.A{"$prices", "$$half"}},
+			}},
+		}},
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(bson.M{"address.zipCode": zipCode})}},
+		{{Key: "$facet", Value: bson.M{
+			"count": bson.A{bson.M{"$count": "n"}},
+			"sales": bson.A{
+				bson.M{"$match": bson.M{"lastMarketSale.amount": bson.M{"$gt": 0}}},
+				bson.M{"$sort": bson.M{"lastMarketSale.amount": 1}},
+				bson.M{"$group": bson.M{"_id": nil, "prices": bson.M{"$push": "$lastMarketSale.amount"}}},
+				bson.M{"$project": bson.M{"count": bson.M{"$size": "$prices"}, "median": median}},
+			},
+			"pricePerSqft": bson.A{
+				bson.M{"$match": bson.M{
+					"lastMarketSale.amount":                 bson.M{"$gt": 0},
+					"building.summary.livingAreaSquareFeet": bson.M{"$gt": 0},
+				}},
+				bson.M{"$group": bson.M{"_id": nil, "average": bson.M{"$avg": bson.M{
+					"$divide": bson.A{"$lastMarketSale.amount", "$building.summary.livingAreaSquareFeet"},
+				}}}},
+			},
+			"daysSinceLastSale": bson.A{
+				bson.M{"$project": bson.M{"days": bson.M{"$divide": bson.A{
+					bson.M{"$subtract": bson.A{"$$NOW", saleDate}},
+					millisecondsPerDay,
+				}}}},
+				bson.M{"$match": bson.M{"days": bson.M{"$gte": 0, "$lt": daysSinceSaleBoundaries[len(daysSinceSaleBoundaries)-1]}}},
+				bson.M{"$bucket": bson.M{
+					"groupBy":    "$days",
+					"boundaries": daysSinceSaleBoundaries,
+					"output":     bson.M{"count": bson.M{"$sum": 1}},
+				}},
+			},
+			"yearBuilt": bson.A{
+				bson.M{"$match": bson.M{"building.details.construction.yearBuilt": bson.M{"$gt": 0}}},
+				bson.M{"$group": bson.M{
+					"_id": bson.M{"$subtract": bson.A{
+						"$building.details.construction.yearBuilt",
+						bson.M{"$mod": bson.A{"$building.details.construction.yearBuilt", 10}},
+					}},
+					"count": bson.M{"$sum": 1},
+				}},
+				bson.M{"$sort": bson.M{"_id": 1}},
+			},
+		}}},
+	}
+
+	start := time.Now()
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	metrics.MongoOperationDuration.WithLabelValues("aggregate_market_stats", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("aggregate_market_stats", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []marketStatsFacets
+	if err := cursor.All(ctx, &facets); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+
+	stats := &models.MarketStats{
+		ZipCode:           zipCode,
+		DaysSinceLastSale: []models.HistogramBucket{},
+		YearBuilt:         []models.HistogramBucket{},
+		ComputedAt:        time.Now().UTC(),
+	}
+	if len(facets) == 0 {
+		return stats, nil
+	}
+	result := facets[0]
+	if len(result.Count) > 0 {
+		stats.PropertyCount = result.Count[0].N
+	}
+	if len(result.Sales) > 0 {
+		stats.SalesCount = result.Sales[0].Count
+		stats.MedianSalePrice = result.Sales[0].Median
+	}
+	if len(result.PricePerSqft) > 0 {
+		stats.AveragePricePerSqft = result.PricePerSqft[0].Average
+	}
+	for _, bucket := range result.DaysSinceLastSale {
+		stats.DaysSinceLastSale = append(stats.DaysSinceLastSale, daysSinceSaleBucket(bucket.Min, bucket.Count))
+	}
+	for _, bucket := range result.YearBuilt {
+		stats.YearBuilt = append(stats.YearBuilt, models.HistogramBucket{
+			Label: fmt.Sprintf("%ds", bucket.Decade),
+			Min:   bucket.Decade,
+			Max:   bucket.Decade + 10,
+			Count: bucket.Count,
+		})
+	}
+	return stats, nil
+}
+
+// daysSinceSaleBucket labels the bucket starting at min, e.g. "90-179" or "3650+".
+func daysSinceSaleBucket(min int, count int64) models.HistogramBucket {
+	bucket := models.HistogramBucket{Min: min, Count: count}
+	for i, bound := range daysSinceSaleBoundaries[:len(daysSinceSaleBoundaries)-2] {
+		if bound == min {
+			bucket.Max = daysSinceSaleBoundaries[i+1]
+			bucket.Label = fmt.Sprintf("%d-%d", min, bucket.Max-1)
+			return bucket
+		}
+	}
+	bucket.Label = fmt.Sprintf("%d+", min)
+	return bucket
+}
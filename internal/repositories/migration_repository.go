@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// maxRecentMigrationErrors bounds the error messages kept on a migration run.
+const maxRecentMigrationErrors = 20
+
+// ErrStaleFence is returned for a write made under a lock that has since been taken over.
+var ErrStaleFence = errors.New("write rejected: lock has been taken over by a newer holder")
+
+type migrationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMigrationRepository() MigrationRepository {
+	return &migrationRepository{
+		collection: database.DB.Collection("migrations"),
+	}
+}
+
+func (r *migrationRepository) Create(ctx context.Context, run *models.MigrationRun) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, run)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "migrations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "migrations").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindByID returns a migration run, or nil if it doesn't exist.
+func (r *migrationRepository) FindByID(ctx context.Context, id string) (*models.MigrationRun, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	start := time.Now()
+	var run models.MigrationRun
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&run)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "migrations").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "migrations").Inc()
+		return nil, err
+	}
+	return &run, nil
+}
+
+// FindByName returns the latest runs of a migration, newest first.
+func (r *migrationRepository) FindByName(ctx context.Context, name string, limit int) ([]models.MigrationRun, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	opts := options.Find().
+		SetSort(bson.D{{Key: "startedAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, bson.M{"name": name}, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "migrations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "migrations").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	runs := []models.MigrationRun{}
+	if err := cursor.All(ctx, &runs); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "migrations").Inc()
+		return nil, err
+	}
+	return runs, nil
+}
+
+// SaveProgress records a finished batch: the checkpoint to resume after, the documents gone through
+// and the errors of those that couldn't be migrated. fence is the fencing token of the lock the batch
+// was migrated under; progress from a holder with an older token than the last saved is rejected with
+// ErrStaleFence.
+func (r *migrationRepository) SaveProgress(ctx context.Context, id primitive.ObjectID, fence int64, checkpoint string, processed int64, failures []string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{
+		"$set": bson.M{"checkpoint": checkpoint, "fence": fence, "updatedAt": time.Now().UTC()},
+		"$inc": bson.M{"processed": processed, "errors": int64(len(failures))},
+	}
+	if len(failures) > 0 {
+		update["$push"] = bson.M{"recentErrors": bson.M{"$each": failures, "$slice": -maxRecentMigrationErrors}}
+	}
+
+	start := time.Now()
+	filter := bson.M{"_id": id, "$or": bson.A{bson.M{"fence": bson.M{"$exists": false}}, bson.M{"fence": bson.M{"$lte": fence}}}}
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "migrations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "migrations").Inc()
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrStaleFence
+	}
+	return nil
+}
+
+// SetStatus moves a run to a status. Completed and failed runs get their finish time and failed ones
+// the error that stopped them.
+func (r *migrationRepository) SetStatus(ctx context.Context, id primitive.ObjectID, status, runErr string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	now := time.Now().UTC()
+	set := bson.M{"status": status, "updatedAt": now}
+	unset := bson.M{}
+	switch status {
+	case models.MigrationStatusCompleted, models.MigrationStatusFailed:
+		set["finishedAt"] = now
+	default:
+		unset["finishedAt"] = ""
+	}
+	if runErr != "" {
+		set["error"] = runErr
+	} else {
+		unset["error"] = ""
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "migrations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "migrations").Inc()
+		return err
+	}
+	return nil
+}
+
+// SetJob records the background job currently working on a run.
+func (r *migrationRepository) SetJob(ctx context.Context, id primitive.ObjectID, jobID string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"jobId": jobID, "updatedAt": time.Now().UTC()}})
+	metrics.MongoOperationDuration.WithLabelValues("update", "migrations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "migrations").Inc()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type notificationPreferenceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationPreferenceRepository() NotificationPreferenceRepository {
+	return &notificationPreferenceRepository{
+		collection: database.DB.Collection("notification_preferences"),
+	}
+}
+
+func (r *notificationPreferenceRepository) FindByUserID(ctx context.Context, userID string) (*models.NotificationPreference, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	var pref models.NotificationPreference
+	err := r.collection.FindOne(ctx, bson.M{"userId": userID}).Decode(&pref)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "notification_preferences").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "notification_preferences").Inc()
+		return nil, err
+	}
+	return &pref, nil
+}
+
+func (r *notificationPreferenceRepository) FindByUserIDs(ctx context.Context, userIDs []string) ([]models.NotificationPreference, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": bson.M{"$in": userIDs}})
+	metrics.MongoOperationDuration.WithLabelValues("find", "notification_preferences").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "notification_preferences").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var prefs []models.NotificationPreference
+	if err := cursor.All(ctx, &prefs); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "notification_preferences").Inc()
+		return nil, err
+	}
+	return prefs, nil
+}
+
+func (r *notificationPreferenceRepository) Upsert(ctx context.Context, pref *models.NotificationPreference) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{
+		"$set": bson.M{
+			"digestFrequency": pref.DigestFrequency,
+			"updatedAt":       pref.UpdatedAt,
+		},
+	}
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"userId": pref.UserID}, update, options.Update().SetUpsert(true))
+	metrics.MongoOperationDuration.WithLabelValues("upsert", "notification_preferences").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("upsert", "notification_preferences").Inc()
+		return err
+	}
+	return nil
+}
+
+type propertyAlertRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPropertyAlertRepository() PropertyAlertRepository {
+	return &propertyAlertRepository{
+		collection: database.DB.Collection("property_alerts"),
+	}
+}
+
+func (r *propertyAlertRepository) Create(ctx context.Context, alert *models.PropertyAlert) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	alert.ID = primitive.NewObjectID()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, alert)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "property_alerts").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "property_alerts").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *propertyAlertRepository) FindPendingUserIDs(ctx context.Context) ([]string, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	values, err := r.collection.Distinct(ctx, "userId", bson.M{"deliveredAt": bson.M{"$exists": false}})
+	metrics.MongoOperationDuration.WithLabelValues("distinct", "property_alerts").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("distinct", "property_alerts").Inc()
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(values))
+	for _, v := range values {
+		if id, ok := v.(string); ok {
+			userIDs = append(userIDs, id)
+		}
+	}
+	return userIDs, nil
+}
+
+func (r *propertyAlertRepository) FindPendingByUserID(ctx context.Context, userID string) ([]models.PropertyAlert, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID, "deliveredAt": bson.M{"$exists": false}}, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "property_alerts").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "property_alerts").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var alerts []models.PropertyAlert
+	if err := cursor.All(ctx, &alerts); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "property_alerts").Inc()
+		return nil, err
+	}
+	return alerts, nil
+}
+
+func (r *propertyAlertRepository) MarkDelivered(ctx context.Context, ids []primitive.ObjectID, deliveredAt time.Time) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	if len(ids) == 0 {
+		return nil
+	}
+	start := time.Now()
+	_, err := r.collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$set": bson.M{"deliveredAt": deliveredAt}})
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "property_alerts").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "property_alerts").Inc()
+		return err
+	}
+	return nil
+}
+
+type notificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationRepository() NotificationRepository {
+	return &notificationRepository{
+		collection: database.DB.Collection("notifications"),
+	}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, notification)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "notifications").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "notifications").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindByUserID returns a page of a user's notifications, newest first.
+func (r *notificationRepository) FindByUserID(ctx context.Context, userID string, unreadOnly bool, offset, limit int) ([]models.Notification, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"userId": userID}
+	if unreadOnly {
+		filter["readAt"] = bson.M{"$exists": false}
+	}
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "notifications").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "notifications").Inc()
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "notifications").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "notifications").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	notifications := []models.Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "notifications").Inc()
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, userID string) (int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	count, err := r.collection.CountDocuments(ctx, bson.M{"userId": userID, "readAt": bson.M{"$exists": false}})
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "notifications").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "notifications").Inc()
+		return 0, err
+	}
+	return count, nil
+}
+
+// MarkRead marks one of a user's notifications read, keeping the original time if it already was.
+// It reports whether the notification exists.
+func (r *notificationRepository) MarkRead(ctx context.Context, userID, id string, readAt time.Time) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{"readAt": bson.M{"$ifNull": bson.A{"$readAt", readAt}}}}}}
+
+	start := time.Now()
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": objID, "userId": userID}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "notifications").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "notifications").Inc()
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, userID string, readAt time.Time) (int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	result, err := r.collection.UpdateMany(ctx, bson.M{"userId": userID, "readAt": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"readAt": readAt}})
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "notifications").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "notifications").Inc()
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
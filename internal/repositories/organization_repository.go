@@ -0,0 +1,257 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tenantCollections hold documents that belong to an organization.
+var tenantCollections = []string{
+	"properties",
+	"listings",
+	"valuations",
+	"property_media",
+	"transactions",
+	"property_audit",
+	"property_diffs",
+	"share_links",
+	"owner_entities",
+	"saved_searches",
+	"saved_search_matches",
+	"webhooks",
+}
+
+type organizationRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+}
+
+func NewOrganizationRepository() OrganizationRepository {
+	return &organizationRepository{
+		db:         database.DB,
+		collection: database.DB.Collection("organizations"),
+	}
+}
+
+func (r *organizationRepository) Create(ctx context.Context, org *models.Organization) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, org)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "organizations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "organizations").Inc()
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("organization already exists: slug=%s", org.Slug)
+		}
+		return err
+	}
+	return nil
+}
+
+// FindByID returns an organization, or nil if it doesn't exist.
+func (r *organizationRepository) FindByID(ctx context.Context, id string) (*models.Organization, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.findOne(ctx, bson.M{"_id": objID})
+}
+
+// FindBySlug returns an organization, or nil if none has the slug.
+func (r *organizationRepository) FindBySlug(ctx context.Context, slug string) (*models.Organization, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	return r.findOne(ctx, bson.M{"slug": slug})
+}
+
+func (r *organizationRepository) findOne(ctx context.Context, filter bson.M) (*models.Organization, error) {
+	start := time.Now()
+	var org models.Organization
+	err := r.collection.FindOne(ctx, filter).Decode(&org)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "organizations").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "organizations").Inc()
+		return nil, err
+	}
+	return &org, nil
+}
+
+// FindAll pages through the organizations by name.
+func (r *organizationRepository) FindAll(ctx context.Context, offset, limit int) ([]models.Organization, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, bson.M{})
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "organizations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "organizations").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "name", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "organizations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "organizations").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	orgs := []models.Organization{}
+	if err := cursor.All(ctx, &orgs); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "organizations").Inc()
+		return nil, 0, err
+	}
+	return orgs, total, nil
+}
+
+// AssignUnowned gives every document without an organization to orgID, so data stored before
+// multi-tenancy stays visible to the users of that organization. It returns how many were assigned.
+func (r *organizationRepository) AssignUnowned(ctx context.Context, orgID string) (int64, error) {
+	var assigned int64
+	for _, name := range tenantCollections {
+		start := time.Now()
+		result, err := r.db.Collection(name).UpdateMany(ctx,
+			bson.M{"orgId": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"orgId": orgID}},
+		)
+		metrics.MongoOperationDuration.WithLabelValues("update_many", name).Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.MongoErrorsTotal.WithLabelValues("update_many", name).Inc()
+			return assigned, fmt.Errorf("assign %s to organization failed: %v", name, err)
+		}
+		assigned += result.ModifiedCount
+	}
+	return assigned, nil
+}
+
+type membershipRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMembershipRepository() MembershipRepository {
+	return &membershipRepository{
+		collection: database.DB.Collection("memberships"),
+	}
+}
+
+// FindByUserID returns the membership of a user, or nil if the user hasn't joined an organization.
+func (r *membershipRepository) FindByUserID(ctx context.Context, userID string) (*models.Membership, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	var membership models.Membership
+	err := r.collection.FindOne(ctx, bson.M{"userId": userID}).Decode(&membership)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "memberships").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "memberships").Inc()
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// FindByOrgID pages through the members of an organization in the order they joined.
+func (r *membershipRepository) FindByOrgID(ctx context.Context, orgID string, offset, limit int) ([]models.Membership, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"orgId": orgID}
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "memberships").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "memberships").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "memberships").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "memberships").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	memberships := []models.Membership{}
+	if err := cursor.All(ctx, &memberships); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "memberships").Inc()
+		return nil, 0, err
+	}
+	return memberships, total, nil
+}
+
+// Join adds a user to an organization unless the user already belongs to one, and returns the
+// membership the user ends up with.
+func (r *membershipRepository) Join(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	start := time.Now()
+	var stored models.Membership
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"userId": membership.UserID}, bson.M{"$setOnInsert": membership}, opts).Decode(&stored)
+	metrics.MongoOperationDuration.WithLabelValues("find_one_and_update", "memberships").Observe(time.Since(start).Seconds())
+	if mongo.IsDuplicateKeyError(err) {
+		// A concurrent sign-in of the same user inserted the membership first
+		return r.FindByUserID(ctx, membership.UserID)
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one_and_update", "memberships").Inc()
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// Set places a user in an organization with a role, moving the user out of any other organization.
+func (r *membershipRepository) Set(ctx context.Context, membership *models.Membership) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{
+		"$set": bson.M{
+			"orgId":     membership.OrgID,
+			"role":      membership.Role,
+			"createdAt": membership.CreatedAt,
+		},
+		"$setOnInsert": bson.M{"_id": membership.ID},
+	}
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"userId": membership.UserID}, update, options.Update().SetUpsert(true))
+	metrics.MongoOperationDuration.WithLabelValues("upsert", "memberships").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("upsert", "memberships").Inc()
+		return err
+	}
+	return nil
+}
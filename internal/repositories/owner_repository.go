@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ownerEntityRepository stores owner names sealed with the PII cipher; names are looked up through
+// their blind indexes.
+type ownerEntityRepository struct {
+	collection *mongo.Collection
+	pii        fieldcrypt.Cipher
+	index      fieldcrypt.BlindIndex
+}
+
+func NewOwnerEntityRepository(pii fieldcrypt.Cipher, index fieldcrypt.BlindIndex) OwnerEntityRepository {
+	return &ownerEntityRepository{
+		collection: database.DB.Collection("owner_entities"),
+		pii:        pii,
+		index:      index,
+	}
+}
+
+// nameIndex returns the blind indexes of a normalized name and of each run of its leading words,
+// so "SMITH JOHN A" is found by "SMITH", "SMITH JOHN" and "SMITH JOHN A".
+func (r *ownerEntityRepository) nameIndex(name string) []string {
+	words := strings.Fields(name)
+	index := make([]string, 0, len(words))
+	for i := range words {
+		index = append(index, r.index.Index(strings.Join(words[:i+1], " ")))
+	}
+	return index
+}
+
+func (r *ownerEntityRepository) open(entities []models.OwnerEntity) error {
+	for i := range entities {
+		name, err := r.pii.Decrypt(entities[i].Name)
+		if err != nil {
+			return err
+		}
+		entities[i].Name = name
+		entities[i].NameIndex = nil
+	}
+	return nil
+}
+
+func (r *ownerEntityRepository) FindByEntityID(ctx context.Context, entityID string) (*models.OwnerEntity, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	var entity models.OwnerEntity
+	err := r.collection.FindOne(ctx, inTenant(ctx, bson.M{"entityId": entityID})).Decode(&entity)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "owner_entities").Inc()
+		return nil, err
+	}
+	entities := []models.OwnerEntity{entity}
+	if err := r.open(entities); err != nil {
+		return nil, err
+	}
+	return &entities[0], nil
+}
+
+func (r *ownerEntityRepository) FindByPropertyID(ctx context.Context, propertyID string) ([]models.OwnerEntity, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, inTenant(ctx, bson.M{"propertyIds": propertyID}))
+	metrics.MongoOperationDuration.WithLabelValues("find", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "owner_entities").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entities []models.OwnerEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "owner_entities").Inc()
+		return nil, err
+	}
+	if err := r.open(entities); err != nil {
+		return nil, err
+	}
+	return entities, nil
+}
+
+// FindByNamePrefix returns up to limit owner entities whose normalized name is name or starts with
+// name followed by more words, so "SMITH JOHN" also finds "SMITH JOHN A", in name order.
+func (r *ownerEntityRepository) FindByNamePrefix(ctx context.Context, name string, limit int) ([]models.OwnerEntity, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	// Sealed names can't be sorted by the database, so which entities past the limit are left out
+	// follows the entity ID; the ones returned are sorted by name once opened
+	filter := inTenant(ctx, bson.M{"nameIndex": r.index.Index(strings.Join(strings.Fields(name), " "))})
+	findOptions := options.Find().SetSort(bson.D{{Key: "entityId", Value: 1}}).SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "owner_entities").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	entities := []models.OwnerEntity{}
+	if err := cursor.All(ctx, &entities); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "owner_entities").Inc()
+		return nil, err
+	}
+	if err := r.open(entities); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entities, func(i, j int) bool { return entities[i].Name < entities[j].Name })
+	return entities, nil
+}
+
+// LinkProperty adds a property to an owner entity, creating the entity in the organization ctx is
+// scoped to if it doesn't exist there yet.
+func (r *ownerEntityRepository) LinkProperty(ctx context.Context, entity *models.OwnerEntity, propertyID string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	sealed, err := r.pii.Encrypt(entity.Name)
+	if err != nil {
+		return err
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"name":        sealed,
+			"nameIndex":   r.nameIndex(entity.Name),
+			"isCorporate": entity.IsCorporate,
+			"updatedAt":   time.Now(),
+		},
+		"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+		"$addToSet":    bson.M{"propertyIds": propertyID},
+	}
+	start := time.Now()
+	_, err = r.collection.UpdateOne(ctx, inTenant(ctx, bson.M{"entityId": entity.EntityID}), update, options.Update().SetUpsert(true))
+	metrics.MongoOperationDuration.WithLabelValues("upsert", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("upsert", "owner_entities").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *ownerEntityRepository) UnlinkProperty(ctx context.Context, propertyID string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.UpdateMany(ctx,
+		inTenant(ctx, bson.M{"propertyIds": propertyID}),
+		bson.M{"$pull": bson.M{"propertyIds": propertyID}, "$set": bson.M{"updatedAt": time.Now()}},
+	)
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "owner_entities").Inc()
+		return err
+	}
+
+	// Drop entities that no longer hold any parcel
+	start = time.Now()
+	_, err = r.collection.DeleteMany(ctx, bson.M{"propertyIds": bson.M{"$size": 0}})
+	metrics.MongoOperationDuration.WithLabelValues("delete_many", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete_many", "owner_entities").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *ownerEntityRepository) Count(ctx context.Context) (int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	count, err := r.collection.EstimatedDocumentCount(ctx)
+	metrics.MongoOperationDuration.WithLabelValues("estimated_count", "owner_entities").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("estimated_count", "owner_entities").Inc()
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/fieldcrypt"
+)
+
+// piiFields lists pointers to every owner PII field on an ownership record.
+func piiFields(ownership *models.Ownership) []*string {
+	fields := []*string{
+		&ownership.MailingAddress.StreetAddress,
+		&ownership.MailingAddress.City,
+		&ownership.MailingAddress.State,
+		&ownership.MailingAddress.ZipCode,
+	}
+	for i := range ownership.CurrentOwners {
+		owner := &ownership.CurrentOwners[i]
+		fields = append(fields, &owner.FullName, &owner.FirstName, &owner.MiddleName, &owner.LastName)
+	}
+	return fields
+}
+
+// sealOwnership returns a copy of the ownership record with PII encrypted for storage,
+// leaving the caller's value untouched.
+func sealOwnership(pii fieldcrypt.Cipher, ownership models.Ownership) (models.Ownership, error) {
+	if !pii.Enabled() {
+		return ownership, nil
+	}
+	ownership.CurrentOwners = append([]models.Owner(nil), ownership.CurrentOwners...)
+	for _, field := range piiFields(&ownership) {
+		sealed, err := pii.Encrypt(*field)
+		if err != nil {
+			return models.Ownership{}, err
+		}
+		*field = sealed
+	}
+	return ownership, nil
+}
+
+// sealProperty returns a shallow copy of the property with owner PII encrypted.
+func sealProperty(pii fieldcrypt.Cipher, property *models.Property) (*models.Property, error) {
+	ownership, err := sealOwnership(pii, property.Ownership)
+	if err != nil {
+		return nil, err
+	}
+	sealed := *property
+	sealed.Ownership = ownership
+	return &sealed, nil
+}
+
+// openProperty decrypts owner PII in place after a read.
+func openProperty(pii fieldcrypt.Cipher, property *models.Property) error {
+	if !pii.Enabled() {
+		return nil
+	}
+	for _, field := range piiFields(&property.Ownership) {
+		plaintext, err := pii.Decrypt(*field)
+		if err != nil {
+			return err
+		}
+		*field = plaintext
+	}
+	return nil
+}
+
+func openProperties(pii fieldcrypt.Cipher, properties []models.Property) error {
+	for i := range properties {
+		if err := openProperty(pii, &properties[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownershipNeedsRotation reports whether any PII field is plaintext or sealed with a retired key.
+func ownershipNeedsRotation(pii fieldcrypt.Cipher, ownership *models.Ownership) bool {
+	for _, field := range piiFields(ownership) {
+		if pii.NeedsRotation(*field) {
+			return true
+		}
+	}
+	return false
+}
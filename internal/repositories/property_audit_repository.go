@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type propertyAuditRepository struct {
+	collection *mongo.Collection
+	pii        fieldcrypt.Cipher
+}
+
+func NewPropertyAuditRepository(pii fieldcrypt.Cipher) PropertyAuditRepository {
+	return &propertyAuditRepository{
+		collection: database.DB.Collection("property_audit"),
+		pii:        pii,
+	}
+}
+
+// isOwnershipPath reports whether a changed field sits under ownership, where owner PII lives.
+func isOwnershipPath(path string) bool {
+	return path == "ownership" || strings.HasPrefix(path, "ownership.")
+}
+
+// sealChangeValue encrypts a changed ownership value, JSON-encoded, so owner PII is no more exposed in
+// the history than on the property itself.
+func sealChangeValue(pii fieldcrypt.Cipher, value interface{}) (interface{}, error) {
+	if value == nil || !pii.Enabled() {
+		return value, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return pii.Encrypt(string(data))
+}
+
+func openChangeValue(pii fieldcrypt.Cipher, value interface{}) (interface{}, error) {
+	sealed, ok := value.(string)
+	if !ok || !pii.Enabled() {
+		return value, nil
+	}
+	plaintext, err := pii.Decrypt(sealed)
+	if err != nil || plaintext == sealed {
+		return value, err
+	}
+	var opened interface{}
+	if err := json.Unmarshal([]byte(plaintext), &opened); err != nil {
+		return nil, err
+	}
+	return opened, nil
+}
+
+// sealChanges returns a copy of changes with the ownership values encrypted.
+func sealChanges(pii fieldcrypt.Cipher, changes []models.FieldChange) ([]models.FieldChange, error) {
+	sealed := make([]models.FieldChange, len(changes))
+	for i, change := range changes {
+		if isOwnershipPath(change.Path) {
+			var err error
+			if change.Old, err = sealChangeValue(pii, change.Old); err != nil {
+				return nil, err
+			}
+			if change.New, err = sealChangeValue(pii, change.New); err != nil {
+				return nil, err
+			}
+		}
+		sealed[i] = change
+	}
+	return sealed, nil
+}
+
+// openChanges decrypts the ownership values of changes in place.
+func openChanges(pii fieldcrypt.Cipher, changes []models.FieldChange) error {
+	for i := range changes {
+		change := &changes[i]
+		if !isOwnershipPath(change.Path) {
+			continue
+		}
+		var err error
+		if change.Old, err = openChangeValue(pii, change.Old); err != nil {
+			return err
+		}
+		if change.New, err = openChangeValue(pii, change.New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *propertyAuditRepository) Create(ctx context.Context, entry *models.PropertyAuditEntry) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	stored := *entry
+	stored.OrgID = tenant.OrgID(ctx)
+	changes, err := sealChanges(r.pii, entry.Changes)
+	if err != nil {
+		return err
+	}
+	stored.Changes = changes
+
+	start := time.Now()
+	_, err = r.collection.InsertOne(ctx, &stored)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "property_audit").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "property_audit").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindByProperty pages through a property's history, newest first.
+func (r *propertyAuditRepository) FindByProperty(ctx context.Context, propertyID string, offset, limit int) ([]models.PropertyAuditEntry, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := inTenant(ctx, bson.M{"propertyId": propertyID})
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "property_audit").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "property_audit").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "property_audit").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "property_audit").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []models.PropertyAuditEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "property_audit").Inc()
+		return nil, 0, err
+	}
+	for i := range entries {
+		if err := openChanges(r.pii, entries[i].Changes); err != nil {
+			return nil, 0, err
+		}
+	}
+	return entries, total, nil
+}
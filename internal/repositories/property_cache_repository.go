@@ -2,70 +2,298 @@ package repositories
 
 import (
 	"context"
-	"encoding/json"
 	"time"
 
+	"homeinsight-properties/internal/cost"
 	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
 	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/logger"
 	"homeinsight-properties/pkg/metrics"
 
 	"github.com/go-redis/redis/v8"
 )
 
 type propertyCache struct {
-	client *redis.Client
+	client      redis.UniversalClient
+	pii         fieldcrypt.Cipher
+	ttl         *cache.AdaptiveTTL
+	staleWindow time.Duration
+	local       *cache.LocalCache
+	codec       *cache.Codec
 }
 
-func NewPropertyCache() PropertyCache {
+// NewPropertyCache keeps owner PII encrypted with pii in cached property payloads and reports
+// per-class hits, misses and invalidations to ttl, which in turn picks expirations for callers.
+// A non-zero staleWindow keeps properties in Redis that long past their expiration, during which
+// they are reported stale so callers can serve them while revalidating. Fresh properties are also
+// kept in local, if not nil, with changes broadcast so other instances drop their copies. Values
+// are written with codec; entries in any other format are re-encoded as they are read.
+func NewPropertyCache(pii fieldcrypt.Cipher, ttl *cache.AdaptiveTTL, staleWindow time.Duration, local *cache.LocalCache, codec *cache.Codec) PropertyCache {
 	return &propertyCache{
-		client: cache.RedisClient,
+		client:      cache.RedisClient,
+		pii:         pii,
+		ttl:         ttl,
+		staleWindow: staleWindow,
+		local:       local,
+		codec:       codec,
 	}
 }
 
-func (c *propertyCache) GetProperty(ctx context.Context, key string) (*models.Property, error) {
-	start := time.Now()
-	data, err := c.client.Get(ctx, key).Result()
-	metrics.RedisOperationDuration.WithLabelValues("get").Observe(time.Since(start).Seconds())
-	if err == redis.Nil {
-		return nil, nil
+// TTL returns the current expiration for keys of the given class.
+func (c *propertyCache) TTL(class string) time.Duration {
+	return c.ttl.TTL(class)
+}
+
+func (c *propertyCache) recordLookup(key string, hit bool) {
+	if hit {
+		c.ttl.RecordHit(cache.KeyClass(key))
+	} else {
+		c.ttl.RecordMiss(cache.KeyClass(key))
+	}
+}
+
+// dropLocal removes keys from the local cache here and, via pub/sub, on other instances.
+func (c *propertyCache) dropLocal(ctx context.Context, keys []string, all bool) {
+	if c.local == nil {
+		return
+	}
+	if all {
+		c.local.Clear()
+	} else {
+		c.local.Delete(keys...)
+	}
+	if err := cache.PublishInvalidation(ctx, keys, all); err != nil {
+		logger.GlobalLogger.Warnf("Failed to publish cache invalidation: keys=%v, error=%v", keys, err)
+	}
+}
+
+// migrate re-encodes a value read in another format than the configured codec, so entries move over
+// as they are read rather than all at once. v is the decoded value.
+func (c *propertyCache) migrate(ctx context.Context, key string, data []byte, v interface{}) {
+	if c.codec.Current(data) {
+		return
+	}
+	encoded, err := c.codec.Encode(v)
+	if err == nil {
+		_, err = cache.MigrateValue(ctx, key, data, encoded)
 	}
 	if err != nil {
-		metrics.RedisErrorsTotal.WithLabelValues("get", "").Inc()
-		return nil, err
+		logger.GlobalLogger.Warnf("Failed to migrate cached value: key=%s, codec=%s, error=%v", key, c.codec.Name(), err)
 	}
+}
+
+// decodeProperty decodes a cached property payload and decrypts its owner PII, first migrating the
+// payload in Redis if it is in an old format and key is set.
+func (c *propertyCache) decodeProperty(ctx context.Context, key string, data []byte) (*models.Property, error) {
 	var property models.Property
-	if err := json.Unmarshal([]byte(data), &property); err != nil {
+	if err := c.codec.Decode(data, &property); err != nil {
+		return nil, err
+	}
+	if key != "" {
+		c.migrate(ctx, key, data, &property)
+	}
+	if err := openProperty(c.pii, &property); err != nil {
 		return nil, err
 	}
 	return &property, nil
 }
 
+// GetProperty returns a cached property and whether it is fresh or stale. An entry is stale once
+// its remaining TTL falls within the stale window, i.e. once its own expiration has passed.
+// Properties held in the local cache are always fresh. A property of another organization than ctx
+// is scoped to is a miss, whatever key it was found under.
+func (c *propertyCache) GetProperty(ctx context.Context, key string) (*models.Property, string, error) {
+	if data, ok := c.local.Get(key); ok {
+		if property, err := c.decodeProperty(ctx, "", data); err == nil {
+			if !tenant.Owns(ctx, property.OrgID) {
+				return nil, cache.StateMiss, nil
+			}
+			return property, cache.StateFresh, nil
+		}
+		c.local.Delete(key)
+	}
+
+	cost.Record(ctx, cost.CacheRead)
+	start := time.Now()
+	pipe := c.client.Pipeline()
+	getCmd := pipe.Get(ctx, key)
+	ttlCmd := pipe.PTTL(ctx, key)
+	pipe.Exec(ctx) // per-command results are checked below
+	metrics.RedisOperationDuration.WithLabelValues("get").Observe(time.Since(start).Seconds())
+	data, err := getCmd.Result()
+	if err == redis.Nil {
+		c.recordLookup(key, false)
+		return nil, cache.StateMiss, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get").Inc()
+		return nil, cache.StateMiss, err
+	}
+	property, err := c.decodeProperty(ctx, key, []byte(data))
+	if err != nil {
+		return nil, cache.StateMiss, err
+	}
+	if !tenant.Owns(ctx, property.OrgID) {
+		c.recordLookup(key, false)
+		return nil, cache.StateMiss, nil
+	}
+	c.recordLookup(key, true)
+
+	state := cache.StateFresh
+	if remaining := ttlCmd.Val(); c.staleWindow > 0 && remaining >= 0 && remaining <= c.staleWindow {
+		state = cache.StateStale
+	} else {
+		c.local.Set(key, []byte(data))
+	}
+	return property, state, nil
+}
+
 func (c *propertyCache) SetProperty(ctx context.Context, key string, property *models.Property, expiration time.Duration) error {
-	data, err := json.Marshal(property)
+	sealed, err := sealProperty(c.pii, property)
+	if err != nil {
+		return err
+	}
+	data, err := c.codec.Encode(sealed)
 	if err != nil {
 		return err
 	}
+	if expiration > 0 {
+		expiration += c.staleWindow
+	}
 	start := time.Now()
 	err = c.client.Set(ctx, key, data, expiration).Err()
 	metrics.RedisOperationDuration.WithLabelValues("set").Observe(time.Since(start).Seconds())
+	c.dropLocal(ctx, []string{key}, false)
 	if err != nil {
-		metrics.RedisErrorsTotal.WithLabelValues("set", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("set").Inc()
 		return err
 	}
+	c.ttl.RecordSet(cache.KeyClass(key))
+	c.local.Set(key, data)
+	return nil
+}
+
+// GetProperties returns the fresh cached properties among ids of the organization ctx is scoped to,
+// by ID, in one round trip. Missing and stale properties are left out for the caller to load.
+func (c *propertyCache) GetProperties(ctx context.Context, ids []string) (map[string]*models.Property, error) {
+	orgID := tenant.OrgID(ctx)
+	found := make(map[string]*models.Property, len(ids))
+	var remote []string
+	for _, id := range ids {
+		key := cache.PropertyKey(orgID, id)
+		if data, ok := c.local.Get(key); ok {
+			if property, err := c.decodeProperty(ctx, "", data); err == nil {
+				if tenant.Owns(ctx, property.OrgID) {
+					found[id] = property
+				}
+				continue
+			}
+			c.local.Delete(key)
+		}
+		remote = append(remote, id)
+	}
+	if len(remote) == 0 {
+		return found, nil
+	}
+
+	cost.Record(ctx, cost.CacheRead)
+	metrics.RedisBatchSize.WithLabelValues("get_many").Observe(float64(len(remote)))
+	start := time.Now()
+	pipe := c.client.Pipeline()
+	getCmds := make([]*redis.StringCmd, len(remote))
+	ttlCmds := make([]*redis.DurationCmd, len(remote))
+	for i, id := range remote {
+		getCmds[i] = pipe.Get(ctx, cache.PropertyKey(orgID, id))
+		ttlCmds[i] = pipe.PTTL(ctx, cache.PropertyKey(orgID, id))
+	}
+	pipe.Exec(ctx) // per-command results are checked below
+	metrics.RedisOperationDuration.WithLabelValues("get_many").Observe(time.Since(start).Seconds())
+
+	for i, id := range remote {
+		key := cache.PropertyKey(orgID, id)
+		data, err := getCmds[i].Result()
+		if err == redis.Nil {
+			c.recordLookup(key, false)
+			continue
+		}
+		if err != nil {
+			metrics.RedisErrorsTotal.WithLabelValues("get_many").Inc()
+			return found, err
+		}
+		if remaining := ttlCmds[i].Val(); c.staleWindow > 0 && remaining >= 0 && remaining <= c.staleWindow {
+			continue
+		}
+		property, err := c.decodeProperty(ctx, key, []byte(data))
+		if err != nil || !tenant.Owns(ctx, property.OrgID) {
+			c.recordLookup(key, false)
+			continue
+		}
+		c.recordLookup(key, true)
+		c.local.Set(key, []byte(data))
+		found[id] = property
+	}
+	return found, nil
+}
+
+// SetProperties caches properties under the ID keys of their organizations and registers each key for
+// invalidation, in one round trip. Each key gets its own TTL, so a batch doesn't expire all at once.
+func (c *propertyCache) SetProperties(ctx context.Context, properties []models.Property) error {
+	if len(properties) == 0 {
+		return nil
+	}
+	keys := make([]string, len(properties))
+	values := make([][]byte, len(properties))
+	pipe := c.client.Pipeline()
+	for i := range properties {
+		sealed, err := sealProperty(c.pii, &properties[i])
+		if err != nil {
+			return err
+		}
+		data, err := c.codec.Encode(sealed)
+		if err != nil {
+			return err
+		}
+		expiration := c.ttl.TTL(cache.ClassProperty)
+		if expiration > 0 {
+			expiration += c.staleWindow
+		}
+		keys[i] = cache.PropertyKey(properties[i].OrgID, properties[i].PropertyID)
+		values[i] = data
+		pipe.Set(ctx, keys[i], data, expiration)
+		pipe.SAdd(ctx, cache.PropertyKeysSetKey(properties[i].OrgID, properties[i].PropertyID), keys[i])
+	}
+	metrics.RedisBatchSize.WithLabelValues("set_many").Observe(float64(len(properties)))
+	start := time.Now()
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("set_many").Observe(time.Since(start).Seconds())
+	c.dropLocal(ctx, keys, false)
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_many").Inc()
+		return err
+	}
+	for i, key := range keys {
+		c.ttl.RecordSet(cache.KeyClass(key))
+		c.local.Set(key, values[i])
+	}
 	return nil
 }
 
 func (c *propertyCache) GetSearchKey(ctx context.Context, key string) (string, error) {
+	cost.Record(ctx, cost.CacheRead)
 	start := time.Now()
 	result, err := c.client.Get(ctx, key).Result()
 	metrics.RedisOperationDuration.WithLabelValues("get_search").Observe(time.Since(start).Seconds())
 	if err == redis.Nil {
+		c.recordLookup(key, false)
 		return "", nil
 	}
 	if err != nil {
-		metrics.RedisErrorsTotal.WithLabelValues("get_search", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("get_search").Inc()
 		return "", err
 	}
+	c.recordLookup(key, true)
 	return result, nil
 }
 
@@ -74,73 +302,477 @@ func (c *propertyCache) SetSearchKey(ctx context.Context, key, propertyID string
 	err := c.client.Set(ctx, key, propertyID, expiration).Err()
 	metrics.RedisOperationDuration.WithLabelValues("set_search").Observe(time.Since(start).Seconds())
 	if err != nil {
-		metrics.RedisErrorsTotal.WithLabelValues("set_search", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("set_search").Inc()
 		return err
 	}
+	c.ttl.RecordSet(cache.KeyClass(key))
 	return nil
 }
 
+// AddCacheKeyToPropertySet registers a cache key with a property of the organization ctx is scoped
+// to, so an update of the property invalidates it.
 func (c *propertyCache) AddCacheKeyToPropertySet(ctx context.Context, propertyID, cacheKey string) error {
 	start := time.Now()
-	err := c.client.SAdd(ctx, cache.PropertyKeysSetKey(propertyID), cacheKey).Err()
+	err := c.client.SAdd(ctx, cache.PropertyKeysSetKey(tenant.OrgID(ctx), propertyID), cacheKey).Err()
 	metrics.RedisOperationDuration.WithLabelValues("sadd").Observe(time.Since(start).Seconds())
 	if err != nil {
-		metrics.RedisErrorsTotal.WithLabelValues("sadd", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("sadd").Inc()
 		return err
  }
 	return nil
 }
 
+// AddCacheKeyToPropertySets registers one cache key with several properties in one round trip, so
+// an update of any of them invalidates it.
+func (c *propertyCache) AddCacheKeyToPropertySets(ctx context.Context, propertyIDs []string, cacheKey string) error {
+	if len(propertyIDs) == 0 {
+		return nil
+	}
+	orgID := tenant.OrgID(ctx)
+	metrics.RedisBatchSize.WithLabelValues("sadd_many").Observe(float64(len(propertyIDs)))
+	start := time.Now()
+	pipe := c.client.Pipeline()
+	for _, propertyID := range propertyIDs {
+		pipe.SAdd(ctx, cache.PropertyKeysSetKey(orgID, propertyID), cacheKey)
+	}
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("sadd_many").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("sadd_many").Inc()
+		return err
+	}
+	return nil
+}
+
+// InvalidatePropertyCacheKeys drops the cache keys registered with a property of the organization ctx
+// is scoped to, along with every list page.
 func (c *propertyCache) InvalidatePropertyCacheKeys(ctx context.Context, propertyID string) error {
+	orgID := tenant.OrgID(ctx)
+	// Dropped after Redis so a concurrent read can't copy the old entry back in
+	defer c.dropLocal(ctx, []string{cache.PropertyKey(orgID, propertyID)}, false)
+
 	start := time.Now()
-	keys, err := c.client.SMembers(ctx, cache.PropertyKeysSetKey(propertyID)).Result()
+	keys, err := c.client.SMembers(ctx, cache.PropertyKeysSetKey(orgID, propertyID)).Result()
 	metrics.RedisOperationDuration.WithLabelValues("smembers").Observe(time.Since(start).Seconds())
 	if err != nil && err != redis.Nil {
-		metrics.RedisErrorsTotal.WithLabelValues("smembers", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("smembers").Inc()
 		return err
 	}
-	for _, key := range keys {
-		start := time.Now()
-		err = c.client.Del(ctx, key).Err()
-		metrics.RedisOperationDuration.WithLabelValues("del").Observe(time.Since(start).Seconds())
-		if err != nil && err != redis.Nil {
-			metrics.RedisErrorsTotal.WithLabelValues("del", "").Inc()
-		}
-	}
+	c.deleteKeys(ctx, keys)
 	start = time.Now()
-	err = c.client.Del(ctx, cache.PropertyKeysSetKey(propertyID)).Err()
+	err = c.client.Del(ctx, cache.PropertyKeysSetKey(orgID, propertyID)).Err()
 	metrics.RedisOperationDuration.WithLabelValues("del_set").Observe(time.Since(start).Seconds())
 	if err != nil && err != redis.Nil {
-		metrics.RedisErrorsTotal.WithLabelValues("del_set", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("del_set").Inc()
 		return err
 	}
 	start = time.Now()
 	err = c.client.Del(ctx, cache.PropertyListKey()).Err()
 	metrics.RedisOperationDuration.WithLabelValues("del_list").Observe(time.Since(start).Seconds())
 	if err != nil && err != redis.Nil {
-		metrics.RedisErrorsTotal.WithLabelValues("del_list", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("del_list").Inc()
 	}
+
+	// Any change can shift every list page, so drop them all
+	start = time.Now()
+	listKeys, err := c.client.SMembers(ctx, cache.PropertyListKeysSetKey()).Result()
+	metrics.RedisOperationDuration.WithLabelValues("smembers").Observe(time.Since(start).Seconds())
+	if err != nil && err != redis.Nil {
+		metrics.RedisErrorsTotal.WithLabelValues("smembers").Inc()
+		return nil
+	}
+	listKeys = append(listKeys, cache.PropertyListKeysSetKey())
+	c.deleteKeys(ctx, listKeys)
 	return nil
 }
 
-func (c *propertyCache) Delete(ctx context.Context, key string) error {
+// PruneKeySets removes the keys that have expired from the properties' invalidation sets, which
+// otherwise only shrink when their property changes, deleting sets left empty. It returns how many
+// keys were removed.
+func (c *propertyCache) PruneKeySets(ctx context.Context) (int64, error) {
+	var removed int64
+	err := cache.ScanKeys(ctx, cache.PropertyKeysSetKey("*", "*"), func(sets []string) error {
+		for _, set := range sets {
+			start := time.Now()
+			members, err := c.client.SMembers(ctx, set).Result()
+			metrics.RedisOperationDuration.WithLabelValues("smembers").Observe(time.Since(start).Seconds())
+			if err != nil && err != redis.Nil {
+				metrics.RedisErrorsTotal.WithLabelValues("smembers").Inc()
+				return err
+			}
+
+			start = time.Now()
+			pipe := c.client.Pipeline()
+			exists := make([]*redis.IntCmd, len(members))
+			for i, member := range members {
+				exists[i] = pipe.Exists(ctx, member)
+			}
+			_, err = pipe.Exec(ctx)
+			metrics.RedisOperationDuration.WithLabelValues("exists_many").Observe(time.Since(start).Seconds())
+			if err != nil && err != redis.Nil {
+				metrics.RedisErrorsTotal.WithLabelValues("exists_many").Inc()
+				return err
+			}
+			var expired []interface{}
+			for i, member := range members {
+				if exists[i].Val() == 0 {
+					expired = append(expired, member)
+				}
+			}
+			if len(expired) == 0 {
+				continue
+			}
+
+			// SREM deletes the set along with its last member. A key cached again since the check
+			// loses its registration until it expires, a rare miss for a nightly run
+			start = time.Now()
+			n, err := c.client.SRem(ctx, set, expired...).Result()
+			metrics.RedisOperationDuration.WithLabelValues("srem").Observe(time.Since(start).Seconds())
+			if err != nil {
+				metrics.RedisErrorsTotal.WithLabelValues("srem").Inc()
+				return err
+			}
+			removed += n
+		}
+		return nil
+	})
+	return removed, err
+}
+
+// deleteKeys removes cache keys one by one, counting each removed key as an invalidation of its class.
+func (c *propertyCache) deleteKeys(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		start := time.Now()
+		n, err := c.client.Del(ctx, key).Result()
+		metrics.RedisOperationDuration.WithLabelValues("del").Observe(time.Since(start).Seconds())
+		if err != nil && err != redis.Nil {
+			metrics.RedisErrorsTotal.WithLabelValues("del").Inc()
+			continue
+		}
+		if n > 0 {
+			c.ttl.RecordInvalidation(cache.KeyClass(key))
+		}
+	}
+}
+
+func (c *propertyCache) GetSearchResult(ctx context.Context, key string) (*models.CachedSearchResult, error) {
+	cost.Record(ctx, cost.CacheRead)
 	start := time.Now()
-	err := c.client.Del(ctx, key).Err()
-	metrics.RedisOperationDuration.WithLabelValues("del").Observe(time.Since(start).Seconds())
-	if err != nil && err != redis.Nil {
-		metrics.RedisErrorsTotal.WithLabelValues("del", "").Inc()
+	data, err := c.client.Get(ctx, key).Result()
+	metrics.RedisOperationDuration.WithLabelValues("get_search_result").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		c.recordLookup(key, false)
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_search_result").Inc()
+		return nil, err
+	}
+	var result models.CachedSearchResult
+	if err := c.codec.Decode([]byte(data), &result); err != nil {
+		return nil, err
+	}
+	c.migrate(ctx, key, []byte(data), &result)
+	c.recordLookup(key, true)
+	return &result, nil
+}
+
+// SetSearchResult stores a search page and registers the key with each property so updates invalidate it.
+func (c *propertyCache) SetSearchResult(ctx context.Context, key string, result *models.CachedSearchResult, expiration time.Duration) error {
+	data, err := c.codec.Encode(result)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = c.client.Set(ctx, key, data, expiration).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_search_result").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_search_result").Inc()
 		return err
 	}
+	c.ttl.RecordSet(cache.KeyClass(key))
+	return c.AddCacheKeyToPropertySets(ctx, result.PropertyIDs, key)
+}
+
+// SetListPage stores a page of the property list; every page is dropped whenever any property changes.
+func (c *propertyCache) SetListPage(ctx context.Context, key string, result *models.CachedSearchResult, expiration time.Duration) error {
+	data, err := c.codec.Encode(result)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, key, data, expiration)
+	pipe.SAdd(ctx, cache.PropertyListKeysSetKey(), key)
+	pipe.Expire(ctx, cache.PropertyListKeysSetKey(), c.ttl.TTL(cache.ClassList)+time.Hour)
+	_, err = pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("set_list_page").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_list_page").Inc()
+		return err
+	}
+	c.ttl.RecordSet(cache.KeyClass(key))
 	return nil
 }
 
-func (c *propertyCache) ClearAll(ctx context.Context) error {
+// GetCount returns a cached property count and whether there was one. Counts are only expired, never
+// invalidated, so they may be behind by up to their TTL.
+func (c *propertyCache) GetCount(ctx context.Context, key string) (int64, bool, error) {
+	cost.Record(ctx, cost.CacheRead)
+	start := time.Now()
+	count, err := c.client.Get(ctx, key).Int64()
+	metrics.RedisOperationDuration.WithLabelValues("get_count").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		c.recordLookup(key, false)
+		return 0, false, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_count").Inc()
+		return 0, false, err
+	}
+	c.recordLookup(key, true)
+	return count, true, nil
+}
+
+func (c *propertyCache) SetCount(ctx context.Context, key string, count int64, expiration time.Duration) error {
+	start := time.Now()
+	err := c.client.Set(ctx, key, count, expiration).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_count").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_count").Inc()
+		return err
+	}
+	c.ttl.RecordSet(cache.KeyClass(key))
+	return nil
+}
+
+func (c *propertyCache) GetValuation(ctx context.Context, key string) (*models.Valuation, error) {
+	cost.Record(ctx, cost.CacheRead)
+	start := time.Now()
+	data, err := c.client.Get(ctx, key).Result()
+	metrics.RedisOperationDuration.WithLabelValues("get_valuation").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		c.recordLookup(key, false)
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_valuation").Inc()
+		return nil, err
+	}
+	var valuation models.Valuation
+	if err := c.codec.Decode([]byte(data), &valuation); err != nil {
+		return nil, err
+	}
+	c.migrate(ctx, key, []byte(data), &valuation)
+	if !tenant.Owns(ctx, valuation.OrgID) {
+		c.recordLookup(key, false)
+		return nil, nil
+	}
+	c.recordLookup(key, true)
+	return &valuation, nil
+}
+
+// SetValuation stores a property's latest valuation and registers the key with the property so
+// deleting the property invalidates it.
+func (c *propertyCache) SetValuation(ctx context.Context, key string, valuation *models.Valuation, expiration time.Duration) error {
+	data, err := c.codec.Encode(valuation)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = c.client.Set(ctx, key, data, expiration).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_valuation").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_valuation").Inc()
+		return err
+	}
+	c.ttl.RecordSet(cache.KeyClass(key))
+	return c.AddCacheKeyToPropertySet(ctx, valuation.PropertyID, key)
+}
+
+func (c *propertyCache) GetListing(ctx context.Context, key string) (*models.Listing, error) {
+	cost.Record(ctx, cost.CacheRead)
+	start := time.Now()
+	data, err := c.client.Get(ctx, key).Result()
+	metrics.RedisOperationDuration.WithLabelValues("get_listing").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		c.recordLookup(key, false)
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_listing").Inc()
+		return nil, err
+	}
+	var listing models.Listing
+	if err := c.codec.Decode([]byte(data), &listing); err != nil {
+		return nil, err
+	}
+	c.migrate(ctx, key, []byte(data), &listing)
+	if !tenant.Owns(ctx, listing.OrgID) {
+		c.recordLookup(key, false)
+		return nil, nil
+	}
+	c.recordLookup(key, true)
+	return &listing, nil
+}
+
+// SetListing stores a property's latest listing and registers the key with the property so deleting
+// the property invalidates it.
+func (c *propertyCache) SetListing(ctx context.Context, key string, listing *models.Listing, expiration time.Duration) error {
+	data, err := c.codec.Encode(listing)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = c.client.Set(ctx, key, data, expiration).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_listing").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_listing").Inc()
+		return err
+	}
+	c.ttl.RecordSet(cache.KeyClass(key))
+	return c.AddCacheKeyToPropertySet(ctx, listing.PropertyID, key)
+}
+
+// GetTransactions returns a property's cached transactions, and whether any were cached, as an
+// empty list is cached too.
+func (c *propertyCache) GetTransactions(ctx context.Context, key string) ([]models.Transaction, bool, error) {
+	cost.Record(ctx, cost.CacheRead)
+	start := time.Now()
+	data, err := c.client.Get(ctx, key).Result()
+	metrics.RedisOperationDuration.WithLabelValues("get_transactions").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		c.recordLookup(key, false)
+		return nil, false, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_transactions").Inc()
+		return nil, false, err
+	}
+	var transactions []models.Transaction
+	if err := c.codec.Decode([]byte(data), &transactions); err != nil {
+		return nil, false, err
+	}
+	c.migrate(ctx, key, []byte(data), &transactions)
+	for _, transaction := range transactions {
+		if !tenant.Owns(ctx, transaction.OrgID) {
+			c.recordLookup(key, false)
+			return nil, false, nil
+		}
+	}
+	c.recordLookup(key, true)
+	return transactions, true, nil
+}
+
+// SetTransactions stores a property's transactions and registers the key with the property so
+// updating or deleting the property invalidates it.
+func (c *propertyCache) SetTransactions(ctx context.Context, key, propertyID string, transactions []models.Transaction, expiration time.Duration) error {
+	data, err := c.codec.Encode(transactions)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = c.client.Set(ctx, key, data, expiration).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_transactions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_transactions").Inc()
+		return err
+	}
+	c.ttl.RecordSet(cache.KeyClass(key))
+	return c.AddCacheKeyToPropertySet(ctx, propertyID, key)
+}
+
+// GetMedia returns a property's cached photos and documents, and whether any were cached, as an
+// empty list is cached too.
+func (c *propertyCache) GetMedia(ctx context.Context, key string) ([]models.PropertyMedia, bool, error) {
+	cost.Record(ctx, cost.CacheRead)
+	start := time.Now()
+	data, err := c.client.Get(ctx, key).Result()
+	metrics.RedisOperationDuration.WithLabelValues("get_media").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		c.recordLookup(key, false)
+		return nil, false, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_media").Inc()
+		return nil, false, err
+	}
+	var media []models.PropertyMedia
+	if err := c.codec.Decode([]byte(data), &media); err != nil {
+		return nil, false, err
+	}
+	c.migrate(ctx, key, []byte(data), &media)
+	for _, item := range media {
+		if !tenant.Owns(ctx, item.OrgID) {
+			c.recordLookup(key, false)
+			return nil, false, nil
+		}
+	}
+	c.recordLookup(key, true)
+	return media, true, nil
+}
+
+// SetMedia stores a property's photos and documents and registers the key with the property so
+// updating or deleting the property invalidates it.
+func (c *propertyCache) SetMedia(ctx context.Context, key, propertyID string, media []models.PropertyMedia, expiration time.Duration) error {
+	data, err := c.codec.Encode(media)
+	if err != nil {
+		return err
+	}
 	start := time.Now()
-	err := c.client.FlushAll(ctx).Err()
-	metrics.RedisOperationDuration.WithLabelValues("flush_all").Observe(time.Since(start).Seconds())
+	err = c.client.Set(ctx, key, data, expiration).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_media").Observe(time.Since(start).Seconds())
 	if err != nil {
-		metrics.RedisErrorsTotal.WithLabelValues("flush_all", "").Inc()
+		metrics.RedisErrorsTotal.WithLabelValues("set_media").Inc()
+		return err
+	}
+	c.ttl.RecordSet(cache.KeyClass(key))
+	return c.AddCacheKeyToPropertySet(ctx, propertyID, key)
+}
+
+func (c *propertyCache) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := c.client.Del(ctx, key).Err()
+	metrics.RedisOperationDuration.WithLabelValues("del").Observe(time.Since(start).Seconds())
+	c.dropLocal(ctx, []string{key}, false)
+	if err != nil && err != redis.Nil {
+		metrics.RedisErrorsTotal.WithLabelValues("del").Inc()
 		return err
 	}
 	return nil
 }
+
+// ClearSearches drops every cached search, full-text and list result and returns how many keys went.
+func (c *propertyCache) ClearSearches(ctx context.Context) (int64, error) {
+	return c.clearPatterns(ctx, cache.SearchCachePatterns)
+}
+
+// ClearAll drops every cached property, search, valuation and embedded or looked-up data, leaving
+// non-cache state such as rate limits and revoked tokens in place, and returns how many keys went.
+func (c *propertyCache) ClearAll(ctx context.Context) (int64, error) {
+	deleted, err := c.clearPatterns(ctx, cache.CachedDataPatterns)
+	c.dropLocal(ctx, nil, true)
+	return deleted, err
+}
+
+// clearPatterns deletes keys matching the patterns, scanning in batches so Redis isn't blocked.
+func (c *propertyCache) clearPatterns(ctx context.Context, patterns []string) (int64, error) {
+	var deleted int64
+	for _, pattern := range patterns {
+		err := cache.ScanKeys(ctx, pattern, func(keys []string) error {
+			n, err := cache.UnlinkKeys(ctx, keys)
+			deleted += n
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				c.ttl.RecordInvalidation(cache.KeyClass(key))
+			}
+			return nil
+		})
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type propertyDiffRepository struct {
+	collection *mongo.Collection
+	pii        fieldcrypt.Cipher
+}
+
+func NewPropertyDiffRepository(pii fieldcrypt.Cipher) PropertyDiffRepository {
+	return &propertyDiffRepository{
+		collection: database.DB.Collection("property_diffs"),
+		pii:        pii,
+	}
+}
+
+// Create stores a refresh's diff, with changed owner values encrypted as in the change history.
+func (r *propertyDiffRepository) Create(ctx context.Context, diff *models.PropertyDiff) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	stored := *diff
+	stored.OrgID = tenant.OrgID(ctx)
+	changes, err := sealChanges(r.pii, diff.Changes)
+	if err != nil {
+		return err
+	}
+	stored.Changes = changes
+
+	start := time.Now()
+	_, err = r.collection.InsertOne(ctx, &stored)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "property_diffs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "property_diffs").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindByProperty pages through a property's refresh diffs, newest first.
+func (r *propertyDiffRepository) FindByProperty(ctx context.Context, propertyID string, offset, limit int) ([]models.PropertyDiff, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := inTenant(ctx, bson.M{"propertyId": propertyID})
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "property_diffs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "property_diffs").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "refreshedAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "property_diffs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "property_diffs").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var diffs []models.PropertyDiff
+	if err := cursor.All(ctx, &diffs); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "property_diffs").Inc()
+		return nil, 0, err
+	}
+	for i := range diffs {
+		if err := openChanges(r.pii, diffs[i].Changes); err != nil {
+			return nil, 0, err
+		}
+	}
+	return diffs, total, nil
+}
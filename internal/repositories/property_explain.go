@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// QueryPropertySearch is the full-text property search. It can be explained but not hinted, since
+// $text queries always use the text index.
+const QueryPropertySearch = "properties.search"
+
+// ExplainableQueries lists the property queries Explain accepts.
+var ExplainableQueries = []string{QueryPropertyList, QueryPropertyCursor, QueryPropertySearch}
+
+// Explain runs MongoDB's explain with execution stats on the find a named property query would send
+// for the given parameters: the list filter and sort for properties.list, the first page for
+// properties.cursor and the search text for properties.search. The query is scoped like the real one.
+func (r *propertyRepository) Explain(ctx context.Context, query string, filter *models.PropertyFilter, sort models.PropertySort, text string, limit int) (*models.QueryExplanation, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	command := bson.D{{Key: "find", Value: "properties"}}
+	var hint string
+	switch query {
+	case QueryPropertyList:
+		command = append(command,
+			bson.E{Key: "filter", Value: notDeleted(inTenant(ctx, propertyFilterQuery(filter)))},
+			bson.E{Key: "sort", Value: propertySortSpec(sort)},
+		)
+		if h, ok := database.QueryHint(QueryPropertyList); ok && filter.IsEmpty() && len(sort) == 0 {
+			hint = h
+		}
+	case QueryPropertyCursor:
+		command = append(command,
+			bson.E{Key: "filter", Value: notDeleted(inTenant(ctx, bson.M{}))},
+			bson.E{Key: "sort", Value: bson.D{{Key: "address.streetAddress", Value: 1}, {Key: "_id", Value: 1}}},
+		)
+		hint, _ = database.QueryHint(QueryPropertyCursor)
+	case QueryPropertySearch:
+		score := bson.M{"$meta": "textScore"}
+		command = append(command,
+			bson.E{Key: "filter", Value: notDeleted(inTenant(ctx, bson.M{"$text": bson.M{"$search": text}}))},
+			bson.E{Key: "projection", Value: bson.M{"score": score}},
+			bson.E{Key: "sort", Value: bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}},
+		)
+	default:
+		return nil, fmt.Errorf("unknown query: %s", query)
+	}
+	command = append(command, bson.E{Key: "limit", Value: limit})
+	if hint != "" {
+		command = append(command, bson.E{Key: "hint", Value: hint})
+	}
+
+	var result struct {
+		QueryPlanner struct {
+			WinningPlan bson.Raw `bson:"winningPlan"`
+		} `bson:"queryPlanner"`
+		ExecutionStats struct {
+			Returned        int64 `bson:"nReturned"`
+			KeysExamined    int64 `bson:"totalKeysExamined"`
+			DocsExamined    int64 `bson:"totalDocsExamined"`
+			ExecutionTimeMS int64 `bson:"executionTimeMillis"`
+		} `bson:"executionStats"`
+	}
+	start := time.Now()
+	err := r.collection.Database().RunCommand(ctx, bson.D{
+		{Key: "explain", Value: command},
+		{Key: "verbosity", Value: "executionStats"},
+	}).Decode(&result)
+	metrics.MongoOperationDuration.WithLabelValues("explain", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("explain", "properties").Inc()
+		return nil, err
+	}
+
+	explanation := &models.QueryExplanation{
+		Query:           query,
+		Collection:      "properties",
+		Hint:            hint,
+		Indexes:         []string{},
+		Stages:          []string{},
+		Returned:        result.ExecutionStats.Returned,
+		KeysExamined:    result.ExecutionStats.KeysExamined,
+		DocsExamined:    result.ExecutionStats.DocsExamined,
+		ExecutionTimeMS: result.ExecutionStats.ExecutionTimeMS,
+	}
+	if plan := result.QueryPlanner.WinningPlan; len(plan) > 0 {
+		collectPlan(plan, explanation)
+		var winningPlan bson.M
+		if err := bson.Unmarshal(plan, &winningPlan); err == nil {
+			explanation.WinningPlan = winningPlan
+		}
+	}
+	return explanation, nil
+}
+
+// collectPlan gathers the stages of a query plan, outermost first, and the indexes they scan.
+func collectPlan(plan bson.Raw, explanation *models.QueryExplanation) {
+	if stage, ok := plan.Lookup("stage").StringValueOK(); ok {
+		explanation.Stages = append(explanation.Stages, stage)
+	}
+	if index, ok := plan.Lookup("indexName").StringValueOK(); ok {
+		explanation.Indexes = append(explanation.Indexes, index)
+	}
+	if child, ok := plan.Lookup("inputStage").DocumentOK(); ok {
+		collectPlan(child, explanation)
+	}
+	if children := plan.Lookup("inputStages"); children.Type == bsontype.Array {
+		values, _ := children.Array().Values()
+		for _, value := range values {
+			if child, ok := value.DocumentOK(); ok {
+				collectPlan(child, explanation)
+			}
+		}
+	}
+}
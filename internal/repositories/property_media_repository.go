@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type propertyMediaRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPropertyMediaRepository() PropertyMediaRepository {
+	return &propertyMediaRepository{
+		collection: database.DB.Collection("property_media"),
+	}
+}
+
+func (r *propertyMediaRepository) Create(ctx context.Context, media *models.PropertyMedia) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	media.OrgID = tenant.OrgID(ctx)
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, media)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "property_media").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "property_media").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindByPropertyID returns a property's media, oldest first.
+func (r *propertyMediaRepository) FindByPropertyID(ctx context.Context, propertyID string) ([]models.PropertyMedia, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, inTenant(ctx, bson.M{"propertyId": propertyID}), opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "property_media").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "property_media").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	media := []models.PropertyMedia{}
+	if err := cursor.All(ctx, &media); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "property_media").Inc()
+		return nil, err
+	}
+	return media, nil
+}
+
+// FindByID returns one media item of a property, or nil if it doesn't exist.
+func (r *propertyMediaRepository) FindByID(ctx context.Context, propertyID, id string) (*models.PropertyMedia, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	start := time.Now()
+	var media models.PropertyMedia
+	err = r.collection.FindOne(ctx, inTenant(ctx, bson.M{"_id": objID, "propertyId": propertyID})).Decode(&media)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "property_media").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "property_media").Inc()
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *propertyMediaRepository) Delete(ctx context.Context, propertyID, id string) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	result, err := r.collection.DeleteOne(ctx, inTenant(ctx, bson.M{"_id": objID, "propertyId": propertyID}))
+	metrics.MongoOperationDuration.WithLabelValues("delete", "property_media").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete", "property_media").Inc()
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
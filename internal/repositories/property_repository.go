@@ -2,11 +2,17 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
+	"homeinsight-properties/internal/cost"
 	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
 	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/fieldcrypt"
 	"homeinsight-properties/pkg/logger"
 	"homeinsight-properties/pkg/metrics"
 
@@ -16,20 +22,53 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// ErrChangeStreamHistoryLost is returned by WatchChanges when the resume point has aged out of the
+// oplog, so the changes since then can no longer be replayed.
+var ErrChangeStreamHistoryLost = errors.New("change stream resume point is no longer in the oplog")
+
+// Server error codes for a change stream that cannot be resumed.
+const (
+	changeStreamFatalErrorCode       = 280
+	changeStreamHistoryLostErrorCode = 286
+)
+
+// propertyRepository sends each call through the handle of its operation class, so the read
+// preference and read and write concerns configured for the class apply: collection for point
+// reads, lists for paged lists and searches, exports for whole-collection scans and writes for
+// anything that changes documents.
 type propertyRepository struct {
 	collection *mongo.Collection
+	lists      *mongo.Collection
+	exports    *mongo.Collection
+	writes     *mongo.Collection
+	pii        fieldcrypt.Cipher
 }
 
-func NewPropertyRepository() PropertyRepository {
+// NewPropertyRepository stores owner PII encrypted with pii and decrypts it on every read.
+func NewPropertyRepository(pii fieldcrypt.Cipher) PropertyRepository {
 	return &propertyRepository{
-		collection: database.DB.Collection("properties"),
+		collection: database.Collection("properties", database.OperationRead),
+		lists:      database.Collection("properties", database.OperationList),
+		exports:    database.Collection("properties", database.OperationExport),
+		writes:     database.Collection("properties", database.OperationWrite),
+		pii:        pii,
 	}
 }
 
+// notDeleted adds the soft-delete condition to a filter; properties in the trash are hidden from
+// every read and write except restore and purge.
+func notDeleted(filter bson.M) bson.M {
+	filter["deletedAt"] = nil
+	return filter
+}
+
 func (r *propertyRepository) FindByID(ctx context.Context, id string) (*models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
 	start := time.Now()
 	var property models.Property
-	err := r.collection.FindOne(ctx, bson.M{"propertyId": id}).Decode(&property)
+	raw, err := r.collection.FindOne(ctx, notDeleted(inTenant(ctx, bson.M{"propertyId": id}))).Raw()
 	metrics.MongoOperationDuration.WithLabelValues("find_one", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -38,10 +77,19 @@ func (r *propertyRepository) FindByID(ctx context.Context, id string) (*models.P
 		metrics.MongoErrorsTotal.WithLabelValues("find_one", "properties").Inc()
 		return nil, err
 	}
+	if err := decodeProperty(raw, &property); err != nil {
+		return nil, err
+	}
+	if err := openProperty(r.pii, &property); err != nil {
+		return nil, err
+	}
 	return &property, nil
 }
 
 func (r *propertyRepository) FindByAddress(ctx context.Context, street, city, state, zip string) (*models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
 	filter := bson.M{
 		"address.streetAddress": street,
 		"address.city":         city,
@@ -52,9 +100,13 @@ func (r *propertyRepository) FindByAddress(ctx context.Context, street, city, st
 	if zip != "" {
 		filter["address.zipCode"] = zip
 	}
+	findOneOptions := options.FindOne()
+	if hint, ok := database.QueryHint(QueryPropertyAddress); ok {
+		findOneOptions.SetHint(hint)
+	}
 	start := time.Now()
 	var property models.Property
-	err := r.collection.FindOne(ctx, filter).Decode(&property)
+	raw, err := r.collection.FindOne(ctx, notDeleted(inTenant(ctx, filter)), findOneOptions).Raw()
 	metrics.MongoOperationDuration.WithLabelValues("find_one", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
@@ -63,104 +115,403 @@ func (r *propertyRepository) FindByAddress(ctx context.Context, street, city, st
 		metrics.MongoErrorsTotal.WithLabelValues("find_one", "properties").Inc()
 		return nil, err
 	}
+	if err := decodeProperty(raw, &property); err != nil {
+		return nil, err
+	}
+	if err := openProperty(r.pii, &property); err != nil {
+		return nil, err
+	}
 	return &property, nil
 }
 
-func (r *propertyRepository) FindWithPagination(ctx context.Context, offset, limit int) ([]models.Property, int64, error) {
+// FindAddressCandidates returns up to limit properties in the city whose street address starts with
+// houseNumber, for fuzzy matching a search that found no exact address.
+func (r *propertyRepository) FindAddressCandidates(ctx context.Context, houseNumber, city, state, zip string, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := bson.M{
+		// an anchored, case-sensitive prefix can be served by the streetAddress index
+		"address.streetAddress": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(houseNumber) + `\b`},
+		"address.city":          city,
+	}
+	if state != "" {
+		filter["address.state"] = state
+	}
+	if zip != "" {
+		filter["address.zipCode"] = zip
+	}
+	findOptions := options.Find().SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, notDeleted(inTenant(ctx, filter)), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	properties, err := decodeProperties(ctx, cursor)
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// propertyFilterQuery builds the Mongo query for a list filter; a nil or empty filter matches everything.
+func propertyFilterQuery(filter *models.PropertyFilter) bson.M {
+	query := bson.M{}
+	if filter == nil {
+		return query
+	}
+	if filter.City != "" {
+		query["address.city"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.City) + "$", Options: "i"}
+	}
+	if filter.State != "" {
+		query["address.state"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.State) + "$", Options: "i"}
+	}
+	if filter.ZipCode != "" {
+		query["address.zipCode"] = filter.ZipCode
+	}
+	addRange := func(field string, min, max *int) {
+		if min == nil && max == nil {
+			return
+		}
+		bounds := bson.M{}
+		if min != nil {
+			bounds["$gte"] = *min
+		}
+		if max != nil {
+			bounds["$lte"] = *max
+		}
+		query[field] = bounds
+	}
+	addRange("building.summary.bedroomsCount", filter.MinBeds, filter.MaxBeds)
+	addRange("building.summary.bathroomsCount", filter.MinBaths, filter.MaxBaths)
+	addRange("building.details.construction.yearBuilt", filter.MinYearBuilt, filter.MaxYearBuilt)
+	addRange("taxAssessment.assessedValue.totalValue", filter.MinAssessedValue, filter.MaxAssessedValue)
+	addRange("lastMarketSale.amount", filter.MinPrice, filter.MaxPrice)
+	if zones := filter.FloodZones(); len(zones) > 0 {
+		query["hazard.floodZone"] = bson.M{"$in": zones}
+	}
+	// Properties without a wildfire rating store no score; a maximum alone shouldn't match them
+	if filter.MinWildfireRisk == nil && filter.MaxWildfireRisk != nil {
+		minRisk := 1
+		addRange("hazard.wildfireRiskScore", &minRisk, filter.MaxWildfireRisk)
+	} else {
+		addRange("hazard.wildfireRiskScore", filter.MinWildfireRisk, filter.MaxWildfireRisk)
+	}
+	return query
+}
+
+// propertyProjection turns a sparse fieldset into a Mongo projection, keeping the fields decoding and
+// paging rely on. It is nil when every field is wanted.
+func propertyProjection(fields models.PropertyFields, required ...string) bson.M {
+	if len(fields) == 0 {
+		return nil
+	}
+	projection := bson.M{"propertyId": 1, "schemaVersion": 1}
+	for _, path := range fields {
+		projection[path] = 1
+	}
+	for _, path := range required {
+		covered := false
+		for _, field := range fields {
+			if path == field || strings.HasPrefix(path, field+".") {
+				covered = true
+				break
+			}
+		}
+		// Projecting a path and its parent together is a path collision in Mongo
+		if !covered {
+			projection[path] = 1
+		}
+	}
+	return projection
+}
+
+// propertySortSpec maps a list sort onto indexed paths, with _id last so pages are stable across ties.
+// An empty sort keeps the default street address order.
+func propertySortSpec(sort models.PropertySort) bson.D {
+	if len(sort) == 0 {
+		return bson.D{{Key: "address.streetAddress", Value: 1}}
+	}
+	spec := make(bson.D, 0, len(sort)+1)
+	for _, field := range sort {
+		direction := 1
+		if field.Descending {
+			direction = -1
+		}
+		spec = append(spec, bson.E{Key: models.PropertySortFields[field.Field], Value: direction})
+	}
+	return append(spec, bson.E{Key: "_id", Value: 1})
+}
+
+func (r *propertyRepository) FindWithPagination(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int) ([]models.Property, int64, error) {
+	total, err := r.CountMatching(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	properties, err := r.FindPage(ctx, filter, sort, fields, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return properties, total, nil
+}
+
+// CountMatching counts the properties matching filter, reading every match.
+func (r *propertyRepository) CountMatching(ctx context.Context, filter *models.PropertyFilter) (int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
 	start := time.Now()
-	total, err := r.collection.CountDocuments(ctx, bson.M{})
+	total, err := r.lists.CountDocuments(ctx, notDeleted(inTenant(ctx, propertyFilterQuery(filter))))
 	metrics.MongoOperationDuration.WithLabelValues("count_documents", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
 		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "properties").Inc()
-		return nil, 0, err
+		return 0, err
+	}
+	return total, nil
+}
+
+// FindMatchingAfter returns the properties matching filter in _id order, starting after afterID
+// unless it is zero, for jobs walking through all of them in batches.
+func (r *propertyRepository) FindMatchingAfter(ctx context.Context, filter *models.PropertyFilter, fields models.PropertyFields, afterID primitive.ObjectID, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	query := propertyFilterQuery(filter)
+	if !afterID.IsZero() {
+		query["_id"] = bson.M{"$gt": afterID}
 	}
 
 	findOptions := options.Find().
-		SetSort(bson.D{{Key: "address.streetAddress", Value: 1}}).
-		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
 		SetLimit(int64(limit))
+	if projection := propertyProjection(fields); projection != nil {
+		findOptions.SetProjection(projection)
+	}
 
-	start = time.Now()
-	cursor, err := r.collection.Find(ctx, bson.M{}, findOptions)
+	start := time.Now()
+	cursor, err := r.writes.Find(ctx, notDeleted(inTenant(ctx, query)), findOptions)
 	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
 		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
-		return nil, 0, err
+		return nil, err
 	}
 	defer cursor.Close(ctx)
 
-	var properties []models.Property
 	start = time.Now()
-	err = cursor.All(ctx, &properties)
+	properties, err := decodeProperties(ctx, cursor)
 	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
 		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
-		return nil, 0, err
+		return nil, err
 	}
-	return properties, total, nil
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
 }
 
-func (r *propertyRepository) Create(ctx context.Context, property *models.Property) error {
-	property.ID = primitive.NewObjectID()
+// FindPage returns a page of properties like FindWithPagination, without counting them.
+func (r *propertyRepository) FindPage(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	findOptions := options.Find().
+		SetSort(propertySortSpec(sort)).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	if projection := propertyProjection(fields); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+	// The list hint is tuned for the default unfiltered scan; let the planner pick an index otherwise
+	if hint, ok := database.QueryHint(QueryPropertyList); ok && filter.IsEmpty() && len(sort) == 0 {
+		findOptions.SetHint(hint)
+	}
+
 	start := time.Now()
-	_, err := r.collection.InsertOne(ctx, property)
-	metrics.MongoOperationDuration.WithLabelValues("insert", "properties").Observe(time.Since(start).Seconds())
+	cursor, err := r.lists.Find(ctx, notDeleted(inTenant(ctx, propertyFilterQuery(filter))), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
-		metrics.MongoErrorsTotal.WithLabelValues("insert", "properties").Inc()
-		return err
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
 	}
-	return nil
+	defer cursor.Close(ctx)
+
+	var properties []models.Property
+	start = time.Now()
+	properties, err = decodeProperties(ctx, cursor)
+	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
 }
 
-func (r *propertyRepository) Update(ctx context.Context, property *models.Property) error {
-	update := bson.M{
-		"$set": bson.M{
-			"avmPropertyId":    property.AVMPropertyID,
-			"address":          property.Address,
-			"location":         property.Location,
-			"lot":              property.Lot,
-			"landUseAndZoning": property.LandUseAndZoning,
-			"utilities":        property.Utilities,
-			"building":         property.Building,
-			"ownership":        property.Ownership,
-			"taxAssessment":    property.TaxAssessment,
-			"lastMarketSale":   property.LastMarketSale,
-			"updatedAt":        property.UpdatedAt,
-		},
+// FindPageWithTotal returns what FindWithPagination does from a single $facet aggregation, saving a
+// round trip. Counting still reads every match, and the list hint doesn't apply.
+func (r *propertyRepository) FindPageWithTotal(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int) ([]models.Property, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	page := bson.A{
+		bson.M{"$sort": propertySortSpec(sort)},
+		bson.M{"$skip": int64(offset)},
+		bson.M{"$limit": int64(limit)},
 	}
+	if projection := propertyProjection(fields); projection != nil {
+		page = append(page, bson.M{"$project": projection})
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(inTenant(ctx, propertyFilterQuery(filter)))}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "n"}},
+			"page":  page,
+		}}},
+	}
+
 	start := time.Now()
-	result, err := r.collection.UpdateOne(ctx, bson.M{"propertyId": property.PropertyID}, update)
-	metrics.MongoOperationDuration.WithLabelValues("update_one", "properties").Observe(time.Since(start).Seconds())
+	cursor, err := r.lists.Aggregate(ctx, pipeline)
+	metrics.MongoOperationDuration.WithLabelValues("aggregate_page", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
-		metrics.MongoErrorsTotal.WithLabelValues("update_one", "properties").Inc()
-		logger.GlobalLogger.Errorf("Failed to update property in MongoDB: propertyId=%s, error=%v", property.PropertyID, err)
-		return err
+		metrics.MongoErrorsTotal.WithLabelValues("aggregate_page", "properties").Inc()
+		return nil, 0, err
 	}
-	if result.MatchedCount == 0 {
-		logger.GlobalLogger.Errorf("Property not found for update: propertyId=%s", property.PropertyID)
-		return fmt.Errorf("property not found")
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+		Page []bson.Raw `bson:"page"`
 	}
-	logger.GlobalLogger.Printf("Successfully updated property: propertyId=%s, updatedAt=%s", property.PropertyID, property.UpdatedAt.String())
-	return nil
+	start = time.Now()
+	err = cursor.All(ctx, &results)
+	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, 0, err
+	}
+	// $facet always emits one document; its count is empty when nothing matched
+	var total int64
+	if len(results[0].Total) > 0 {
+		total = results[0].Total[0].N
+	}
+	properties := make([]models.Property, len(results[0].Page))
+	for i, raw := range results[0].Page {
+		if err := decodeProperty(raw, &properties[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, 0, err
+	}
+	return properties, total, nil
 }
 
-func (r *propertyRepository) Delete(ctx context.Context, id string) error {
+// DataQualityReport summarizes the stored data quality scores of the organization's properties in
+// one pass. Like other scans of the whole collection it runs on the export read settings and isn't
+// bounded by the per-operation timeout.
+func (r *propertyRepository) DataQualityReport(ctx context.Context) (*models.DataQualityReport, error) {
+	cost.Record(ctx, cost.MongoQuery)
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted(inTenant(ctx, bson.M{}))}},
+		{{Key: "$facet", Value: bson.M{
+			"summary": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":      nil,
+					"total":    bson.M{"$sum": 1},
+					"unscored": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$type": "$dataQuality.score"}, "missing"}}, 1, 0}}},
+					"average":  bson.M{"$avg": "$dataQuality.score"},
+				}},
+			},
+			"issues": bson.A{
+				bson.M{"$unwind": "$dataQuality.issues"},
+				bson.M{"$group": bson.M{"_id": "$dataQuality.issues", "count": bson.M{"$sum": 1}}},
+			},
+		}}},
+	}
+
 	start := time.Now()
-	result, err := r.collection.DeleteOne(ctx, bson.M{"propertyId": id})
-	metrics.MongoOperationDuration.WithLabelValues("delete_one", "properties").Observe(time.Since(start).Seconds())
+	cursor, err := r.exports.Aggregate(ctx, pipeline)
+	metrics.MongoOperationDuration.WithLabelValues("aggregate_data_quality", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
-		metrics.MongoErrorsTotal.WithLabelValues("delete_one", "properties").Inc()
-		return err
+		metrics.MongoErrorsTotal.WithLabelValues("aggregate_data_quality", "properties").Inc()
+		return nil, err
 	}
-	if result.DeletedCount == 0 {
-		return fmt.Errorf("property not found")
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Summary []struct {
+			Total    int64   `bson:"total"`
+			Unscored int64   `bson:"unscored"`
+			Average  float64 `bson:"average"`
+		} `bson:"summary"`
+		Issues []struct {
+			Issue string `bson:"_id"`
+			Count int64  `bson:"count"`
+		} `bson:"issues"`
 	}
-	return nil
+	if err := cursor.All(ctx, &facets); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+
+	report := &models.DataQualityReport{Issues: map[string]int64{}}
+	if len(facets) == 0 {
+		return report, nil
+	}
+	if len(facets[0].Summary) > 0 {
+		summary := facets[0].Summary[0]
+		report.Total = summary.Total
+		report.Unscored = summary.Unscored
+		report.AverageScore = summary.Average
+	}
+	for _, issue := range facets[0].Issues {
+		report.Issues[issue.Issue] = issue.Count
+	}
+	return report, nil
 }
 
-func (r *propertyRepository) FindAll(ctx context.Context) ([]models.Property, error) {
+// FindAfterCursor returns up to limit properties matching filter, ordered by street address and _id,
+// that sort strictly after the given position, so each page is an index range scan rather than a skip.
+func (r *propertyRepository) FindAfterCursor(ctx context.Context, filter *models.PropertyFilter, fields models.PropertyFields, afterStreet string, afterID primitive.ObjectID, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	query := propertyFilterQuery(filter)
+	if !afterID.IsZero() {
+		query["$or"] = []bson.M{
+			{"address.streetAddress": bson.M{"$gt": afterStreet}},
+			{"address.streetAddress": afterStreet, "_id": bson.M{"$gt": afterID}},
+		}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "address.streetAddress", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	// The next cursor is built from the street address of the last row
+	if projection := propertyProjection(fields, "address.streetAddress"); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+	// The hinted index only serves the unfiltered scan; leave filtered pages to the planner
+	if hint, ok := database.QueryHint(QueryPropertyCursor); ok && filter.IsEmpty() {
+		findOptions.SetHint(hint)
+	}
+
 	start := time.Now()
-	cursor, err := r.collection.Find(ctx, bson.M{})
+	cursor, err := r.lists.Find(ctx, notDeleted(inTenant(ctx, query)), findOptions)
 	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
 		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
@@ -170,10 +521,1004 @@ func (r *propertyRepository) FindAll(ctx context.Context) ([]models.Property, er
 
 	var properties []models.Property
 	start = time.Now()
-	err = cursor.All(ctx, &properties)
+	properties, err = decodeProperties(ctx, cursor)
+	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// EstimatedCount uses collection metadata instead of scanning, for cursor pagination totals.
+// Properties in the trash are included, so the figure is only an estimate. Metadata covers every
+// organization, so an organization's own properties are counted from the orgId index instead.
+func (r *propertyRepository) EstimatedCount(ctx context.Context) (int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	start := time.Now()
+	var total int64
+	var err error
+	if orgID := tenant.OrgID(ctx); orgID != "" {
+		total, err = r.lists.CountDocuments(ctx, bson.M{"orgId": orgID})
+	} else {
+		total, err = r.lists.EstimatedDocumentCount(ctx)
+	}
+	metrics.MongoOperationDuration.WithLabelValues("estimated_count", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("estimated_count", "properties").Inc()
+		return 0, err
+	}
+	return total, nil
+}
+
+// TextSearch runs a $text query against the property text index, ordered by relevance score.
+func (r *propertyRepository) TextSearch(ctx context.Context, query string, offset, limit int) ([]models.Property, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := notDeleted(inTenant(ctx, bson.M{"$text": bson.M{"$search": query}}))
+
+	start := time.Now()
+	total, err := r.lists.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "properties").Inc()
+		return nil, 0, err
+	}
+
+	score := bson.M{"$meta": "textScore"}
+	findOptions := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.lists.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("text_search", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("text_search", "properties").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var properties []models.Property
+	start = time.Now()
+	properties, err = decodeProperties(ctx, cursor)
+	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, 0, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, 0, err
+	}
+	return properties, total, nil
+}
+
+// earthRadiusMeters converts meter distances to radians for $centerSphere.
+const earthRadiusMeters = 6378100.0
+
+// FindNearby returns properties within radiusMeters of the point, nearest first, with their distance.
+func (r *propertyRepository) FindNearby(ctx context.Context, lat, lng, radiusMeters float64, offset, limit int) ([]models.NearbyProperty, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	center := bson.A{lng, lat}
+
+	start := time.Now()
+	total, err := r.lists.CountDocuments(ctx, notDeleted(inTenant(ctx, bson.M{
+		"location.coordinates.parcelPoint": bson.M{
+			"$geoWithin": bson.M{"$centerSphere": bson.A{center, radiusMeters / earthRadiusMeters}},
+		},
+	})))
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "properties").Inc()
+		return nil, 0, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$geoNear", Value: bson.M{
+			"near":          bson.M{"type": "Point", "coordinates": center},
+			"key":           "location.coordinates.parcelPoint",
+			"distanceField": "distanceMeters",
+			"maxDistance":   radiusMeters,
+			"spherical":     true,
+			"query":         notDeleted(inTenant(ctx, bson.M{})),
+		}}},
+		{{Key: "$skip", Value: int64(offset)}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+
+	start = time.Now()
+	cursor, err := r.lists.Aggregate(ctx, pipeline)
+	metrics.MongoOperationDuration.WithLabelValues("geo_near", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("geo_near", "properties").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var properties []models.NearbyProperty
+	start = time.Now()
+	for cursor.Next(ctx) {
+		var nearby models.NearbyProperty
+		if err := decodeProperty(cursor.Current, &nearby.Property); err != nil {
+			return nil, 0, err
+		}
+		if err := openProperty(r.pii, &nearby.Property); err != nil {
+			return nil, 0, err
+		}
+		nearby.DistanceMeters, _ = cursor.Current.Lookup("distanceMeters").DoubleOK()
+		properties = append(properties, nearby)
+	}
+	err = cursor.Err()
 	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
 	if err != nil {
 		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, 0, err
+	}
+	return properties, total, nil
+}
+
+// withinQuery matches properties whose parcel point lies inside boundary.
+func withinQuery(boundary *models.GeoJSONPolygon) bson.M {
+	return bson.M{
+		"location.coordinates.parcelPoint": bson.M{
+			"$geoWithin": bson.M{"$geometry": boundary},
+		},
+	}
+}
+
+// FindWithin returns properties inside boundary in _id order, starting after afterID unless it is
+// zero. The order doesn't depend on the boundary, so overlapping map views page consistently.
+func (r *propertyRepository) FindWithin(ctx context.Context, boundary *models.GeoJSONPolygon, fields models.PropertyFields, afterID primitive.ObjectID, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := withinQuery(boundary)
+	if !afterID.IsZero() {
+		filter["_id"] = bson.M{"$gt": afterID}
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+	// Map pins need the coordinates whatever else is selected
+	if projection := propertyProjection(fields, "location.coordinates.parcel"); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+
+	start := time.Now()
+	cursor, err := r.lists.Find(ctx, notDeleted(inTenant(ctx, filter)), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	start = time.Now()
+	properties, err := decodeProperties(ctx, cursor)
+	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// CountWithin counts the properties inside boundary, stopping at max so huge boundaries stay cheap.
+func (r *propertyRepository) CountWithin(ctx context.Context, boundary *models.GeoJSONPolygon, max int64) (int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+
+	start := time.Now()
+	total, err := r.lists.CountDocuments(ctx, notDeleted(inTenant(ctx, withinQuery(boundary))), options.Count().SetLimit(max))
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "properties").Inc()
+		return 0, err
+	}
+	return total, nil
+}
+
+// BackfillGeoPoints derives the GeoJSON parcel point for documents stored before it existed.
+func (r *propertyRepository) BackfillGeoPoints(ctx context.Context) (int64, error) {
+	filter := bson.M{
+		"location.coordinates.parcelPoint": bson.M{"$exists": false},
+		"$or": bson.A{
+			bson.M{"location.coordinates.parcel.lat": bson.M{"$ne": 0}},
+			bson.M{"location.coordinates.parcel.lng": bson.M{"$ne": 0}},
+		},
+	}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"location.coordinates.parcelPoint": bson.M{
+				"type":        "Point",
+				"coordinates": bson.A{"$location.coordinates.parcel.lng", "$location.coordinates.parcel.lat"},
+			},
+		}}},
+	}
+
+	start := time.Now()
+	result, err := r.writes.UpdateMany(ctx, filter, update)
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "properties").Inc()
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// RotatePIIEncryption re-seals owner PII that is still plaintext or encrypted with a retired key.
+func (r *propertyRepository) RotatePIIEncryption(ctx context.Context) (int64, error) {
+	if !r.pii.Enabled() {
+		return 0, nil
+	}
+
+	findOptions := options.Find().SetProjection(bson.M{"propertyId": 1, "ownership": 1})
+	start := time.Now()
+	cursor, err := r.writes.Find(ctx, bson.M{}, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var rotated int64
+	for cursor.Next(ctx) {
+		var property models.Property
+		if err := cursor.Decode(&property); err != nil {
+			return rotated, err
+		}
+		if !ownershipNeedsRotation(r.pii, &property.Ownership) {
+			continue
+		}
+		if err := openProperty(r.pii, &property); err != nil {
+			return rotated, fmt.Errorf("decrypt owner data failed: propertyId=%s: %v", property.PropertyID, err)
+		}
+		ownership, err := sealOwnership(r.pii, property.Ownership)
+		if err != nil {
+			return rotated, err
+		}
+
+		start := time.Now()
+		_, err = r.writes.UpdateOne(ctx, bson.M{"_id": property.ID}, bson.M{"$set": bson.M{"ownership": ownership}})
+		metrics.MongoOperationDuration.WithLabelValues("update_one", "properties").Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.MongoErrorsTotal.WithLabelValues("update_one", "properties").Inc()
+			return rotated, err
+		}
+		rotated++
+	}
+	return rotated, cursor.Err()
+}
+
+// FindAddressesAfter returns the addresses of the next limit properties by _id, across all
+// organizations and including deleted ones, for migrations that walk the whole collection.
+func (r *propertyRepository) FindAddressesAfter(ctx context.Context, afterID primitive.ObjectID, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{}
+	if !afterID.IsZero() {
+		filter["_id"] = bson.M{"$gt": afterID}
+	}
+	findOptions := options.Find().
+		SetProjection(bson.M{"_id": 1, "propertyId": 1, "address": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	properties := []models.Property{}
+	if err := cursor.All(ctx, &properties); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	return properties, nil
+}
+
+// UpdateAddress rewrites the address fields of a property without touching the rest of it.
+func (r *propertyRepository) UpdateAddress(ctx context.Context, id primitive.ObjectID, address models.Address) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{"$set": bson.M{
+		"address.streetAddress": address.StreetAddress,
+		"address.city":          address.City,
+		"address.state":         address.State,
+		"address.zipCode":       address.ZipCode,
+	}}
+
+	start := time.Now()
+	_, err := r.writes.UpdateOne(ctx, bson.M{"_id": id}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "properties").Inc()
+		return err
+	}
+	return nil
+}
+
+// addressFilter matches properties at the given address; use it with database.AddressCollation so
+// it compares the way the unique address index does.
+func addressFilter(address models.Address) bson.M {
+	return bson.M{
+		"address.streetAddress": address.StreetAddress,
+		"address.city":          address.City,
+		"address.state":         address.State,
+		"address.zipCode":       address.ZipCode,
+	}
+}
+
+// Create upserts a property keyed by its propertyId or address within the organization ctx is
+// scoped to. If a live property already has either, nothing is written, the stored property is
+// loaded into property and created is false, so concurrent creates of the same address settle on a
+// single document. A property in the trash with either makes the create fail; it must be restored
+// or purged first.
+func (r *propertyRepository) Create(ctx context.Context, property *models.Property) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	property.ID = primitive.NewObjectID()
+	property.OrgID = tenant.OrgID(ctx)
+	property.SchemaVersion = models.CurrentPropertySchemaVersion
+	property.Location.Coordinates.ParcelPoint = models.NewGeoJSONPoint(property.Location.Coordinates.Parcel)
+	property.SyncTaxAssessments()
+	sealed, err := sealProperty(r.pii, property)
+	if err != nil {
+		return false, err
+	}
+	match := bson.A{bson.M{"propertyId": property.PropertyID}, addressFilter(property.Address)}
+
+	// $exists rather than notDeleted: an upsert copies equality conditions into the inserted document
+	filter := inTenant(ctx, bson.M{"$or": match, "deletedAt": bson.M{"$exists": false}})
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.Before).
+		SetCollation(database.AddressCollation)
+	var raw bson.Raw
+	for attempt := 0; attempt < 2; attempt++ {
+		start := time.Now()
+		raw, err = r.writes.FindOneAndUpdate(ctx, filter, bson.M{"$setOnInsert": sealed}, opts).Raw()
+		metrics.MongoOperationDuration.WithLabelValues("upsert", "properties").Observe(time.Since(start).Seconds())
+		// Concurrent upserts can both miss and insert; the one that loses on the unique index
+		// matches the winner when retried
+		if !mongo.IsDuplicateKeyError(err) {
+			break
+		}
+	}
+	if err == mongo.ErrNoDocuments {
+		return true, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("upsert", "properties").Inc()
+		if mongo.IsDuplicateKeyError(err) {
+			return false, r.duplicateError(ctx, property, match)
+		}
+		return false, err
+	}
+
+	var existing models.Property
+	if err := decodeProperty(raw, &existing); err != nil {
+		return false, err
+	}
+	if err := openProperty(r.pii, &existing); err != nil {
+		return false, err
+	}
+	*property = existing
+	return false, nil
+}
+
+// duplicateError explains an insert the unique indexes rejected although no live property matched:
+// a property in the trash still holds the ID or address. It is left for an admin to restore or purge
+// rather than replaced.
+func (r *propertyRepository) duplicateError(ctx context.Context, property *models.Property, match bson.A) error {
+	var trashed models.Property
+	filter := inTenant(ctx, bson.M{"$or": match, "deletedAt": bson.M{"$ne": nil}})
+	opts := options.FindOne().SetProjection(bson.M{"propertyId": 1}).SetCollation(database.AddressCollation)
+	if err := r.writes.FindOne(ctx, filter, opts).Decode(&trashed); err == nil {
+		return fmt.Errorf("property already exists in the trash: propertyId=%s, trashed=%s", property.PropertyID, trashed.PropertyID)
+	}
+	return fmt.Errorf("property already exists: propertyId=%s", property.PropertyID)
+}
+
+func (r *propertyRepository) Update(ctx context.Context, property *models.Property) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	property.SchemaVersion = models.CurrentPropertySchemaVersion
+	property.Location.Coordinates.ParcelPoint = models.NewGeoJSONPoint(property.Location.Coordinates.Parcel)
+	property.SyncTaxAssessments()
+	ownership, err := sealOwnership(r.pii, property.Ownership)
+	if err != nil {
+		return err
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"schemaVersion":    property.SchemaVersion,
+			"avmPropertyId":    property.AVMPropertyID,
+			"address":          property.Address,
+			"location":         property.Location,
+			"lot":              property.Lot,
+			"landUseAndZoning": property.LandUseAndZoning,
+			"utilities":        property.Utilities,
+			"building":         property.Building,
+			"ownership":        ownership,
+			"taxAssessment":    property.TaxAssessment,
+			"taxAssessments":   property.TaxAssessments,
+			"lastMarketSale":   property.LastMarketSale,
+			"dataQuality":      property.DataQuality,
+			"updatedAt":        property.UpdatedAt,
+		},
+	}
+	// Hazard risk and neighborhood data only come from their providers; edits that don't carry them
+	// leave them alone
+	if property.Hazard != nil {
+		update["$set"].(bson.M)["hazard"] = property.Hazard
+	}
+	if property.Neighborhood != nil {
+		update["$set"].(bson.M)["neighborhood"] = property.Neighborhood
+	}
+	start := time.Now()
+	result, err := r.writes.UpdateOne(ctx, notDeleted(inTenant(ctx, bson.M{"propertyId": property.PropertyID})), update)
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "properties").Inc()
+		logger.GlobalLogger.Errorf("Failed to update property in MongoDB: propertyId=%s, error=%v", property.PropertyID, err)
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("property already exists at this address: propertyId=%s", property.PropertyID)
+		}
+		return err
+	}
+	if result.MatchedCount == 0 {
+		logger.GlobalLogger.Errorf("Property not found for update: propertyId=%s", property.PropertyID)
+		return fmt.Errorf("property not found")
+	}
+	logger.GlobalLogger.Printf("Successfully updated property: propertyId=%s, updatedAt=%s", property.PropertyID, property.UpdatedAt.String())
+	return nil
+}
+
+// Patch writes only the given dotted paths of an already merged property, plus its update time.
+// Values are taken from the sealed document so owner PII stays encrypted; a path with no stored
+// value is unset.
+func (r *propertyRepository) Patch(ctx context.Context, property *models.Property, paths []string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	update, err := r.patchUpdate(property, paths)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	result, err := r.writes.UpdateOne(ctx, notDeleted(inTenant(ctx, bson.M{"propertyId": property.PropertyID})), update)
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "properties").Inc()
+		logger.GlobalLogger.Errorf("Failed to patch property in MongoDB: propertyId=%s, error=%v", property.PropertyID, err)
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("property already exists at this address: propertyId=%s", property.PropertyID)
+		}
+		return err
+	}
+	if result.MatchedCount == 0 {
+		logger.GlobalLogger.Errorf("Property not found for patch: propertyId=%s", property.PropertyID)
+		return fmt.Errorf("property not found")
+	}
+	logger.GlobalLogger.Printf("Successfully patched property: propertyId=%s, paths=%v", property.PropertyID, paths)
+	return nil
+}
+
+// patchUpdate builds the update writing the given paths of a property, unsetting those it has no
+// value for.
+func (r *propertyRepository) patchUpdate(property *models.Property, paths []string) (bson.M, error) {
+	property.SchemaVersion = models.CurrentPropertySchemaVersion
+	property.Location.Coordinates.ParcelPoint = models.NewGeoJSONPoint(property.Location.Coordinates.Parcel)
+	property.SyncTaxAssessments()
+	sealed, err := sealProperty(r.pii, property)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := bson.Marshal(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	// The derived parcel point follows the parcel coordinates it is built from, and the latest tax
+	// assessment follows the tax history
+	paths = append([]string(nil), paths...)
+	for _, path := range paths {
+		if path == "location.coordinates.parcel" || strings.HasPrefix(path, "location.coordinates.parcel.") {
+			paths = append(paths, "location.coordinates.parcelPoint")
+			break
+		}
+	}
+	for _, path := range paths {
+		if path == "taxAssessments" || strings.HasPrefix(path, "taxAssessments.") {
+			paths = append(paths, "taxAssessment")
+			break
+		}
+	}
+
+	set := bson.M{
+		"schemaVersion": property.SchemaVersion,
+		"updatedAt":     property.UpdatedAt,
+	}
+	unset := bson.M{}
+	for _, path := range paths {
+		value, err := bson.Raw(doc).LookupErr(strings.Split(path, ".")...)
+		if err != nil {
+			unset[path] = ""
+			continue
+		}
+		set[path] = value
+	}
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
+// PatchMany writes the given paths of several properties in one bulk write, like Patch does for one,
+// and returns how many it matched. Properties deleted meanwhile are skipped.
+func (r *propertyRepository) PatchMany(ctx context.Context, properties []*models.Property, paths []string) (int64, error) {
+	if len(properties) == 0 {
+		return 0, nil
+	}
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	writes := make([]mongo.WriteModel, 0, len(properties))
+	for _, property := range properties {
+		update, err := r.patchUpdate(property, paths)
+		if err != nil {
+			return 0, err
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(notDeleted(inTenant(ctx, bson.M{"propertyId": property.PropertyID}))).
+			SetUpdate(update))
+	}
+
+	start := time.Now()
+	result, err := r.writes.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	metrics.MongoOperationDuration.WithLabelValues("bulk_write", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("bulk_write", "properties").Inc()
+		logger.GlobalLogger.Errorf("Failed to patch properties in MongoDB: count=%d, error=%v", len(properties), err)
+		if mongo.IsDuplicateKeyError(err) {
+			return 0, fmt.Errorf("property already exists at this address: bulk update of %d properties", len(properties))
+		}
+		return 0, err
+	}
+	return result.MatchedCount, nil
+}
+
+// Delete moves a property to the trash by stamping deletedAt; Restore brings it back and Purge removes it.
+func (r *propertyRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	now := time.Now().UTC()
+	start := time.Now()
+	result, err := r.writes.UpdateOne(ctx, notDeleted(inTenant(ctx, bson.M{"propertyId": id})), bson.M{
+		"$set": bson.M{"deletedAt": now, "updatedAt": now},
+	})
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "properties").Inc()
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("property not found")
+	}
+	return nil
+}
+
+// DeleteMany moves several properties to the trash at once and returns how many it moved.
+func (r *propertyRepository) DeleteMany(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	now := time.Now().UTC()
+	start := time.Now()
+	result, err := r.writes.UpdateMany(ctx, notDeleted(inTenant(ctx, bson.M{"propertyId": bson.M{"$in": ids}})), bson.M{
+		"$set": bson.M{"deletedAt": now, "updatedAt": now},
+	})
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "properties").Inc()
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// Restore takes a property out of the trash.
+func (r *propertyRepository) Restore(ctx context.Context, id string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	start := time.Now()
+	result, err := r.writes.UpdateOne(ctx, inTenant(ctx, bson.M{"propertyId": id, "deletedAt": bson.M{"$ne": nil}}), bson.M{
+		"$unset": bson.M{"deletedAt": ""},
+		"$set":   bson.M{"updatedAt": time.Now().UTC()},
+	})
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "properties").Inc()
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("property not found in trash")
+	}
+	return nil
+}
+
+// Purge permanently removes a property that is already in the trash.
+func (r *propertyRepository) Purge(ctx context.Context, id string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	start := time.Now()
+	result, err := r.writes.DeleteOne(ctx, inTenant(ctx, bson.M{"propertyId": id, "deletedAt": bson.M{"$ne": nil}}))
+	metrics.MongoOperationDuration.WithLabelValues("delete_one", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete_one", "properties").Inc()
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("property not found in trash")
+	}
+	return nil
+}
+
+// FindDeleted pages through the trash, most recently deleted first.
+func (r *propertyRepository) FindDeleted(ctx context.Context, offset, limit int) ([]models.Property, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := inTenant(ctx, bson.M{"deletedAt": bson.M{"$ne": nil}})
+
+	start := time.Now()
+	total, err := r.lists.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "properties").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "deletedAt", Value: -1}, {Key: "_id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.lists.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	properties, err := decodeProperties(ctx, cursor)
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, 0, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, 0, err
+	}
+	return properties, total, nil
+}
+
+func (r *propertyRepository) FindAll(ctx context.Context) ([]models.Property, error) {
+	cost.Record(ctx, cost.MongoQuery)
+	start := time.Now()
+	cursor, err := r.exports.Find(ctx, notDeleted(inTenant(ctx, bson.M{})))
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var properties []models.Property
+	start = time.Now()
+	properties, err = decodeProperties(ctx, cursor)
+	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+func (r *propertyRepository) FindByIDs(ctx context.Context, ids []string, fields models.PropertyFields, offset, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	if len(ids) == 0 {
+		return []models.Property{}, nil
+	}
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "address.streetAddress", Value: 1}})
+	if offset > 0 {
+		findOptions.SetSkip(int64(offset))
+	}
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+	if projection := propertyProjection(fields); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, notDeleted(inTenant(ctx, bson.M{"propertyId": bson.M{"$in": ids}})), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var properties []models.Property
+	start = time.Now()
+	properties, err = decodeProperties(ctx, cursor)
+	metrics.MongoOperationDuration.WithLabelValues("cursor_all", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// Stream calls fn with each property matching filter in _id order, fetching batchSize at a time so
+// memory stays flat however many match. The cursor only advances as fn returns, and the first error
+// from fn stops it.
+func (r *propertyRepository) Stream(ctx context.Context, filter *models.PropertyFilter, fields models.PropertyFields, batchSize int, fn func(*models.Property) error) error {
+	cost.Record(ctx, cost.MongoQuery)
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetBatchSize(int32(batchSize))
+	if projection := propertyProjection(fields); projection != nil {
+		findOptions.SetProjection(projection)
+	}
+
+	start := time.Now()
+	cursor, err := r.exports.Find(ctx, notDeleted(inTenant(ctx, propertyFilterQuery(filter))), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var property models.Property
+		if err := decodeProperty(cursor.Current, &property); err != nil {
+			return err
+		}
+		if err := openProperty(r.pii, &property); err != nil {
+			return err
+		}
+		if err := fn(&property); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_next", "properties").Inc()
+		return err
+	}
+	return nil
+}
+
+// WatchChanges follows the collection's change stream from resumeAfter (or from now when nil) and
+// calls fn for every change until ctx is cancelled, fn fails or the stream ends. Updates look up the
+// current document and deletes use its pre-image when the collection records them, so every change
+// carries the property and organization IDs; only those fields are sent back.
+func (r *propertyRepository) WatchChanges(ctx context.Context, resumeAfter []byte, fn func(change models.PropertyChange) error) error {
+	pipeline := mongo.Pipeline{{{Key: "$project", Value: bson.M{
+		"operationType":                       1,
+		"fullDocument.propertyId":             1,
+		"fullDocument.orgId":                  1,
+		"fullDocumentBeforeChange.propertyId": 1,
+		"fullDocumentBeforeChange.orgId":      1,
+	}}}}
+	streamOptions := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+	if resumeAfter != nil {
+		streamOptions.SetResumeAfter(bson.Raw(resumeAfter))
+	}
+
+	start := time.Now()
+	stream, err := r.collection.Watch(ctx, pipeline, streamOptions)
+	metrics.MongoOperationDuration.WithLabelValues("watch", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("watch", "properties").Inc()
+		return changeStreamError(err)
+	}
+	defer stream.Close(context.Background())
+
+	type changedDocument struct {
+		PropertyID string `bson:"propertyId"`
+		OrgID      string `bson:"orgId"`
+	}
+	for stream.Next(ctx) {
+		var event struct {
+			OperationType            string           `bson:"operationType"`
+			FullDocument             *changedDocument `bson:"fullDocument"`
+			FullDocumentBeforeChange *changedDocument `bson:"fullDocumentBeforeChange"`
+		}
+		if err := stream.Decode(&event); err != nil {
+			return err
+		}
+		change := models.PropertyChange{
+			Operation:   event.OperationType,
+			ResumeToken: stream.ResumeToken(),
+		}
+		if event.FullDocument != nil {
+			change.PropertyID, change.OrgID = event.FullDocument.PropertyID, event.FullDocument.OrgID
+		} else if event.FullDocumentBeforeChange != nil {
+			change.PropertyID, change.OrgID = event.FullDocumentBeforeChange.PropertyID, event.FullDocumentBeforeChange.OrgID
+		}
+		if err := fn(change); err != nil {
+			return err
+		}
+	}
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		metrics.MongoErrorsTotal.WithLabelValues("change_stream_next", "properties").Inc()
+		return changeStreamError(err)
+	}
+	return nil
+}
+
+func changeStreamError(err error) error {
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && (serverErr.HasErrorCode(changeStreamHistoryLostErrorCode) || serverErr.HasErrorCode(changeStreamFatalErrorCode)) {
+		return fmt.Errorf("%w: %v", ErrChangeStreamHistoryLost, err)
+	}
+	return err
+}
+
+// FindRecentlyUpdated returns up to limit properties, most recently updated first.
+func (r *propertyRepository) FindRecentlyUpdated(ctx context.Context, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "updatedAt", Value: -1}}).
+		SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.exports.Find(ctx, notDeleted(inTenant(ctx, bson.M{})), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	properties, err := decodeProperties(ctx, cursor)
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// FindStale returns up to limit properties last updated before updatedBefore, least recently
+// updated first, with only their identifiers and address.
+func (r *propertyRepository) FindStale(ctx context.Context, updatedBefore time.Time, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "updatedAt", Value: 1}}).
+		SetLimit(int64(limit)).
+		SetProjection(bson.M{"_id": 1, "orgId": 1, "propertyId": 1, "address": 1, "updatedAt": 1})
+
+	start := time.Now()
+	cursor, err := r.exports.Find(ctx, notDeleted(inTenant(ctx, bson.M{"updatedAt": bson.M{"$lt": updatedBefore}})), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	properties, err := decodeProperties(ctx, cursor)
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	return properties, nil
+}
+
+// FindMatchingCriteria returns up to limit properties matching saved search criteria that were updated
+// after updatedSince, so periodic re-runs only look at properties that could have started matching.
+func (r *propertyRepository) FindMatchingCriteria(ctx context.Context, criteria models.SavedSearchCriteria, updatedSince time.Time, limit int) ([]models.Property, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	filter := notDeleted(inTenant(ctx, bson.M{}))
+	if !updatedSince.IsZero() {
+		filter["updatedAt"] = bson.M{"$gt": updatedSince}
+	}
+	if criteria.Address != "" {
+		filter["address.streetAddress"] = primitive.Regex{Pattern: regexp.QuoteMeta(criteria.Address), Options: "i"}
+	}
+	if criteria.City != "" {
+		filter["address.city"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(criteria.City) + "$", Options: "i"}
+	}
+	if criteria.State != "" {
+		filter["address.state"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(criteria.State) + "$", Options: "i"}
+	}
+	if criteria.ZipCode != "" {
+		filter["address.zipCode"] = criteria.ZipCode
+	}
+	if criteria.MinPrice != nil || criteria.MaxPrice != nil {
+		price := bson.M{}
+		if criteria.MinPrice != nil {
+			price["$gte"] = *criteria.MinPrice
+		}
+		if criteria.MaxPrice != nil {
+			price["$lte"] = *criteria.MaxPrice
+		}
+		filter["lastMarketSale.amount"] = price
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "updatedAt", Value: 1}}).
+		SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.lists.Find(ctx, filter, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "properties").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "properties").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	properties, err := decodeProperties(ctx, cursor)
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "properties").Inc()
+		return nil, err
+	}
+	if err := openProperties(r.pii, properties); err != nil {
 		return nil, err
 	}
 	return properties, nil
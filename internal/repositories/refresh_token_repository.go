@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type refreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRefreshTokenRepository() RefreshTokenRepository {
+	return &refreshTokenRepository{
+		collection: database.DB.Collection("refresh_tokens"),
+	}
+}
+
+func (r *refreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	token.ID = primitive.NewObjectID()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, token)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "refresh_tokens").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "refresh_tokens").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	var token models.RefreshToken
+	err := r.collection.FindOne(ctx, bson.M{"tokenHash": tokenHash}).Decode(&token)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "refresh_tokens").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "refresh_tokens").Inc()
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate atomically revokes an active token, recording its replacement. It returns false when the
+// token was already revoked, so concurrent or replayed refreshes cannot both succeed.
+func (r *refreshTokenRepository) Rotate(ctx context.Context, tokenHash, replacedBy string) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"tokenHash": tokenHash, "revokedAt": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revokedAt": time.Now().UTC(), "replacedBy": replacedBy}}
+	start := time.Now()
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "refresh_tokens").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "refresh_tokens").Inc()
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"familyId": familyID, "revokedAt": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}}
+	start := time.Now()
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "refresh_tokens").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "refresh_tokens").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"userId": userID, "revokedAt": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}}
+	start := time.Now()
+	_, err := r.collection.UpdateMany(ctx, filter, update)
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "refresh_tokens").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "refresh_tokens").Inc()
+		return err
+	}
+	return nil
+}
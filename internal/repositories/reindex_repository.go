@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Named queries whose index can be pinned with a hint, mapped to the collection they run against.
+const (
+	QueryPropertyList    = "properties.list"
+	QueryPropertyCursor  = "properties.cursor"
+	QueryPropertyAddress = "properties.address"
+)
+
+var HintableQueries = map[string]string{
+	QueryPropertyList:    "properties",
+	QueryPropertyCursor:  "properties",
+	QueryPropertyAddress: "properties",
+}
+
+type reindexJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReindexJobRepository() ReindexJobRepository {
+	return &reindexJobRepository{
+		collection: database.DB.Collection("reindex_jobs"),
+	}
+}
+
+func (r *reindexJobRepository) Create(ctx context.Context, job *models.ReindexJob) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, job)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "reindex_jobs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "reindex_jobs").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *reindexJobRepository) FindByID(ctx context.Context, id string) (*models.ReindexJob, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil // Not found
+
+	}
+
+	start := time.Now()
+	var job models.ReindexJob
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&job)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "reindex_jobs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "reindex_jobs").Inc()
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *reindexJobRepository) FindRecent(ctx context.Context, limit int) ([]models.ReindexJob, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}).SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "reindex_jobs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "reindex_jobs").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []models.ReindexJob{}
+	if err := cursor.All(ctx, &jobs); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "reindex_jobs").Inc()
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// UpdateProgress persists the job's mutable state: status, progress, error and completion time.
+func (r *reindexJobRepository) UpdateProgress(ctx context.Context, job *models.ReindexJob) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	set := bson.M{
+		"status":    job.Status,
+		"progress":  job.Progress,
+		"error":     job.Error,
+		"updatedAt": job.UpdatedAt,
+	}
+	if job.CompletedAt != nil {
+		set["completedAt"] = job.CompletedAt
+	}
+
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": job.ID}, bson.M{"$set": set})
+	metrics.MongoOperationDuration.WithLabelValues("update", "reindex_jobs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "reindex_jobs").Inc()
+		return err
+	}
+	return nil
+}
+
+type indexHintRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIndexHintRepository() IndexHintRepository {
+	return &indexHintRepository{
+		collection: database.DB.Collection("index_hints"),
+	}
+}
+
+func (r *indexHintRepository) FindAll(ctx context.Context) ([]models.IndexHint, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, bson.M{})
+	metrics.MongoOperationDuration.WithLabelValues("find", "index_hints").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "index_hints").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var hints []models.IndexHint
+	if err := cursor.All(ctx, &hints); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "index_hints").Inc()
+		return nil, err
+	}
+	return hints, nil
+}
+
+func (r *indexHintRepository) Set(ctx context.Context, hint *models.IndexHint) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{
+		"$set": bson.M{
+			"collection": hint.Collection,
+			"indexName":  hint.IndexName,
+			"updatedAt":  hint.UpdatedAt,
+		},
+	}
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": hint.Query}, update, options.Update().SetUpsert(true))
+	metrics.MongoOperationDuration.WithLabelValues("upsert", "index_hints").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("upsert", "index_hints").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *indexHintRepository) DeleteByIndex(ctx context.Context, collection, indexName string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.DeleteMany(ctx, bson.M{"collection": collection, "indexName": indexName})
+	metrics.MongoOperationDuration.WithLabelValues("delete", "index_hints").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete", "index_hints").Inc()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type savedSearchRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSavedSearchRepository() SavedSearchRepository {
+	return &savedSearchRepository{
+		collection: database.DB.Collection("saved_searches"),
+	}
+}
+
+func (r *savedSearchRepository) Create(ctx context.Context, search *models.SavedSearch) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	search.OrgID = tenant.OrgID(ctx)
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, search)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "saved_searches").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) FindByID(ctx context.Context, id, userID string) (*models.SavedSearch, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil // Not found
+	}
+
+	start := time.Now()
+	var search models.SavedSearch
+	err = r.collection.FindOne(ctx, inTenant(ctx, bson.M{"_id": objID, "userId": userID})).Decode(&search)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "saved_searches").Inc()
+		return nil, err
+	}
+	return &search, nil
+}
+
+func (r *savedSearchRepository) FindByUserID(ctx context.Context, userID string) ([]models.SavedSearch, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, inTenant(ctx, bson.M{"userId": userID}), opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "saved_searches").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	searches := []models.SavedSearch{}
+	if err := cursor.All(ctx, &searches); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "saved_searches").Inc()
+		return nil, err
+	}
+	return searches, nil
+}
+
+func (r *savedSearchRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	count, err := r.collection.CountDocuments(ctx, inTenant(ctx, bson.M{"userId": userID}))
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "saved_searches").Inc()
+		return 0, err
+	}
+	return count, nil
+}
+
+// FindDue returns up to limit saved searches of every organization that have not been attempted since
+// the given time, oldest first.
+func (r *savedSearchRepository) FindDue(ctx context.Context, before time.Time, limit int) ([]models.SavedSearch, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"$or": []bson.M{
+		{"lastAttemptAt": bson.M{"$exists": false}},
+		{"lastAttemptAt": bson.M{"$lt": before}},
+	}}
+	opts := options.Find().SetSort(bson.D{{Key: "lastAttemptAt", Value: 1}}).SetLimit(int64(limit))
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "saved_searches").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var searches []models.SavedSearch
+	if err := cursor.All(ctx, &searches); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "saved_searches").Inc()
+		return nil, err
+	}
+	return searches, nil
+}
+
+// MarkAttempted records that a saved search was picked up for a run, so it isn't due again until the next pass.
+func (r *savedSearchRepository) MarkAttempted(ctx context.Context, id primitive.ObjectID, attemptAt time.Time) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"lastAttemptAt": attemptAt}})
+	metrics.MongoOperationDuration.WithLabelValues("update", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "saved_searches").Inc()
+		return err
+	}
+	return nil
+}
+
+// MarkRun records how far a saved search has been checked and adds its newly recorded matches to the total.
+func (r *savedSearchRepository) MarkRun(ctx context.Context, id primitive.ObjectID, runAt time.Time, newMatches int64, caughtUp bool) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	set := bson.M{"lastRunAt": runAt}
+	if caughtUp {
+		set["baselineComplete"] = true
+	}
+	update := bson.M{
+		"$set": set,
+		"$inc": bson.M{"matchCount": newMatches},
+	}
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "saved_searches").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *savedSearchRepository) Delete(ctx context.Context, id, userID string) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	result, err := r.collection.DeleteOne(ctx, inTenant(ctx, bson.M{"_id": objID, "userId": userID}))
+	metrics.MongoOperationDuration.WithLabelValues("delete", "saved_searches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete", "saved_searches").Inc()
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+type savedSearchMatchRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSavedSearchMatchRepository() SavedSearchMatchRepository {
+	return &savedSearchMatchRepository{
+		collection: database.DB.Collection("saved_search_matches"),
+	}
+}
+
+// InsertNew records matches that were not already recorded for their saved search and returns only those.
+func (r *savedSearchMatchRepository) InsertNew(ctx context.Context, matches []models.SavedSearchMatch) ([]models.SavedSearchMatch, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	inserted := make([]models.SavedSearchMatch, 0, len(matches))
+	for _, match := range matches {
+		match.OrgID = tenant.OrgID(ctx)
+		filter := bson.M{"savedSearchId": match.SavedSearchID, "propertyId": match.PropertyID}
+		update := bson.M{"$setOnInsert": match}
+
+		start := time.Now()
+		result, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		metrics.MongoOperationDuration.WithLabelValues("upsert", "saved_search_matches").Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.MongoErrorsTotal.WithLabelValues("upsert", "saved_search_matches").Inc()
+			return inserted, err
+		}
+		if result.UpsertedCount > 0 {
+			inserted = append(inserted, match)
+		}
+	}
+	return inserted, nil
+}
+
+func (r *savedSearchMatchRepository) FindBySavedSearchID(ctx context.Context, savedSearchID primitive.ObjectID, offset, limit int) ([]models.SavedSearchMatch, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{"savedSearchId": savedSearchID}
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, filter)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "saved_search_matches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "saved_search_matches").Inc()
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "matchedAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "saved_search_matches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "saved_search_matches").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	matches := []models.SavedSearchMatch{}
+	if err := cursor.All(ctx, &matches); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "saved_search_matches").Inc()
+		return nil, 0, err
+	}
+	return matches, total, nil
+}
+
+func (r *savedSearchMatchRepository) DeleteBySavedSearchID(ctx context.Context, savedSearchID primitive.ObjectID) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.DeleteMany(ctx, bson.M{"savedSearchId": savedSearchID})
+	metrics.MongoOperationDuration.WithLabelValues("delete", "saved_search_matches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete", "saved_search_matches").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindUserIDsByPropertyID returns the distinct users with a saved search the property has matched.
+func (r *savedSearchMatchRepository) FindUserIDsByPropertyID(ctx context.Context, propertyID string) ([]string, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	values, err := r.collection.Distinct(ctx, "userId", inTenant(ctx, bson.M{"propertyId": propertyID}))
+	metrics.MongoOperationDuration.WithLabelValues("distinct", "saved_search_matches").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("distinct", "saved_search_matches").Inc()
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		if userID, ok := value.(string); ok && userID != "" {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	return userIDs, nil
+}
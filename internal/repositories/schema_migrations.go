@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// documentMigration upgrades a raw document by exactly one schema version.
+type documentMigration func(doc bson.M) error
+
+// propertyMigrations maps a stored schema version to the step that upgrades it to the next one.
+// Documents written before versioning carry no schemaVersion and are treated as version 1.
+var propertyMigrations = map[int]documentMigration{
+	1: migratePropertyV1ToV2,
+	2: migratePropertyV2ToV3,
+}
+
+// migratePropertyV1ToV2 derives the GeoJSON parcel point used by radius search.
+func migratePropertyV1ToV2(doc bson.M) error {
+	location, _ := doc["location"].(bson.M)
+	coordinates, _ := location["coordinates"].(bson.M)
+	parcel, _ := coordinates["parcel"].(bson.M)
+	if parcel == nil {
+		return nil
+	}
+	if _, exists := coordinates["parcelPoint"]; exists {
+		return nil
+	}
+	point := models.NewGeoJSONPoint(models.CoordinatesPoint{
+		Lat: toFloat(parcel["lat"]),
+		Lng: toFloat(parcel["lng"]),
+	})
+	if point != nil {
+		coordinates["parcelPoint"] = point
+	}
+	return nil
+}
+
+// migratePropertyV2ToV3 starts the tax assessment history from the single stored assessment.
+func migratePropertyV2ToV3(doc bson.M) error {
+	if _, exists := doc["taxAssessments"]; exists {
+		return nil
+	}
+	assessment, _ := doc["taxAssessment"].(bson.M)
+	if assessment == nil || toFloat(assessment["year"]) <= 0 {
+		doc["taxAssessments"] = bson.A{}
+		return nil
+	}
+	doc["taxAssessments"] = bson.A{assessment}
+	return nil
+}
+
+func toFloat(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+// storedSchemaVersion reads schemaVersion without decoding the whole document.
+func storedSchemaVersion(raw bson.Raw) int {
+	value, err := raw.LookupErr("schemaVersion")
+	if err != nil {
+		return 1
+	}
+	if version, ok := value.AsInt64OK(); ok {
+		return int(version)
+	}
+	return 1
+}
+
+// decodeProperty decodes a stored property, upgrading older document shapes in memory first.
+// The upgraded shape is persisted the next time the property is written.
+func decodeProperty(raw bson.Raw, property *models.Property) error {
+	version := storedSchemaVersion(raw)
+	if version >= models.CurrentPropertySchemaVersion {
+		return bson.Unmarshal(raw, property)
+	}
+
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+	from := version
+	for ; version < models.CurrentPropertySchemaVersion; version++ {
+		migrate, ok := propertyMigrations[version]
+		if !ok {
+			return fmt.Errorf("no property migration from schema version %d", version)
+		}
+		if err := migrate(doc); err != nil {
+			return fmt.Errorf("migrate property from schema version %d: %v", version, err)
+		}
+	}
+	doc["schemaVersion"] = version
+
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if err := bson.Unmarshal(data, property); err != nil {
+		return err
+	}
+	metrics.SchemaMigratedReadsTotal.WithLabelValues("properties", strconv.Itoa(from)).Inc()
+	return nil
+}
+
+// decodeProperties drains a cursor through decodeProperty.
+func decodeProperties(ctx context.Context, cursor *mongo.Cursor) ([]models.Property, error) {
+	var properties []models.Property
+	for cursor.Next(ctx) {
+		var property models.Property
+		if err := decodeProperty(cursor.Current, &property); err != nil {
+			return nil, err
+		}
+		properties = append(properties, property)
+	}
+	return properties, cursor.Err()
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type sessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSessionRepository() SessionRepository {
+	return &sessionRepository{
+		collection: database.DB.Collection("sessions"),
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *models.Session) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, session)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "sessions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "sessions").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindActive returns a user's sessions that are neither signed out nor expired, most recently used first.
+func (r *sessionRepository) FindActive(ctx context.Context, userID string) ([]models.Session, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := bson.M{
+		"userId":    userID,
+		"revokedAt": bson.M{"$exists": false},
+		"expiresAt": bson.M{"$gt": time.Now().UTC()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "lastUsedAt", Value: -1}})
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "sessions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "sessions").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	sessions := []models.Session{}
+	if err := cursor.All(ctx, &sessions); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "sessions").Inc()
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// Touch records a refresh of the session from ip, extending it to expiresAt. Sessions started before
+// sessions were tracked have no document and are left alone.
+func (r *sessionRepository) Touch(ctx context.Context, id, ip string, usedAt, expiresAt time.Time) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil
+	}
+	update := bson.M{"$set": bson.M{"lastUsedAt": usedAt, "expiresAt": expiresAt, "ip": ip}}
+
+	start := time.Now()
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID, "revokedAt": bson.M{"$exists": false}}, update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "sessions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "sessions").Inc()
+		return err
+	}
+	return nil
+}
+
+// Revoke signs out one of a user's sessions. It reports whether an active session was found.
+func (r *sessionRepository) Revoke(ctx context.Context, userID, id string) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+	filter := bson.M{"_id": objID, "userId": userID, "revokedAt": bson.M{"$exists": false}}
+
+	start := time.Now()
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}})
+	metrics.MongoOperationDuration.WithLabelValues("update", "sessions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "sessions").Inc()
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// RevokeAllForUser signs out every session of a user and returns the IDs of those that were active.
+func (r *sessionRepository) RevokeAllForUser(ctx context.Context, userID string) ([]string, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	sessions, err := r.FindActive(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	ids := make([]primitive.ObjectID, 0, len(sessions))
+	hexIDs := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID)
+		hexIDs = append(hexIDs, session.ID.Hex())
+	}
+
+	start := time.Now()
+	_, err = r.collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}})
+	metrics.MongoOperationDuration.WithLabelValues("update_many", "sessions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_many", "sessions").Inc()
+		return nil, err
+	}
+	return hexIDs, nil
+}
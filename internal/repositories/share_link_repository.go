@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type shareLinkRepository struct {
+	collection *mongo.Collection
+}
+
+func NewShareLinkRepository() ShareLinkRepository {
+	return &shareLinkRepository{
+		collection: database.DB.Collection("share_links"),
+	}
+}
+
+func (r *shareLinkRepository) Create(ctx context.Context, link *models.ShareLink) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	link.ID = primitive.NewObjectID()
+	link.OrgID = tenant.OrgID(ctx)
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, link)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "share_links").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "share_links").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *shareLinkRepository) FindByPropertyID(ctx context.Context, propertyID, createdBy string) ([]models.ShareLink, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, inTenant(ctx, bson.M{"propertyId": propertyID, "createdBy": createdBy}), findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "share_links").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "share_links").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	links := []models.ShareLink{}
+	if err := cursor.All(ctx, &links); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "share_links").Inc()
+		return nil, err
+	}
+	return links, nil
+}
+
+// RecordAccess atomically bumps the access counter of an unrevoked link and returns it;
+// nil is returned when the link does not exist or has been revoked. Links are opened without
+// signing in, so the lookup is not scoped to an organization; the link carries its own.
+func (r *shareLinkRepository) RecordAccess(ctx context.Context, linkID string) (*models.ShareLink, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	now := time.Now()
+	update := bson.M{
+		"$inc": bson.M{"accessCount": 1},
+		"$set": bson.M{"lastAccessedAt": now},
+	}
+	findOptions := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	start := time.Now()
+	var link models.ShareLink
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"linkId": linkID, "revokedAt": bson.M{"$exists": false}}, update, findOptions).Decode(&link)
+	metrics.MongoOperationDuration.WithLabelValues("find_one_and_update", "share_links").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		metrics.MongoErrorsTotal.WithLabelValues("find_one_and_update", "share_links").Inc()
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *shareLinkRepository) Revoke(ctx context.Context, propertyID, linkID, createdBy string) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	filter := inTenant(ctx, bson.M{
+		"linkId":     linkID,
+		"propertyId": propertyID,
+		"createdBy":  createdBy,
+		"revokedAt":  bson.M{"$exists": false},
+	})
+	start := time.Now()
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revokedAt": time.Now()}})
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "share_links").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "share_links").Inc()
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"homeinsight-properties/internal/tenant"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// inTenant narrows a filter to the organization ctx is scoped to, so a tenant only reads and writes
+// its own records. Work that spans organizations runs with an unscoped ctx and sees every record.
+func inTenant(ctx context.Context, filter bson.M) bson.M {
+	if orgID := tenant.OrgID(ctx); orgID != "" {
+		filter["orgId"] = orgID
+	}
+	return filter
+}
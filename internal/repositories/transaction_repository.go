@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type transactionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewTransactionRepository() TransactionRepository {
+	return &transactionRepository{
+		collection: database.DB.Collection("transactions"),
+	}
+}
+
+// UpsertMany stores a property's transactions, keyed by deed, so fetching the same history again
+// updates the stored records instead of duplicating them. Inserted records take the organization
+// from the filter.
+func (r *transactionRepository) UpsertMany(ctx context.Context, propertyID string, transactions []models.Transaction) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	if len(transactions) == 0 {
+		return nil
+	}
+	cost.Record(ctx, cost.MongoQuery)
+	now := time.Now().UTC()
+	writes := make([]mongo.WriteModel, 0, len(transactions))
+	for _, transaction := range transactions {
+		data, err := bson.Marshal(transaction.LastMarketSale)
+		if err != nil {
+			return err
+		}
+		var set bson.M
+		if err := bson.Unmarshal(data, &set); err != nil {
+			return err
+		}
+		set["updatedAt"] = now
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(inTenant(ctx, bson.M{
+				"propertyId":     propertyID,
+				"date":           transaction.Date,
+				"recordingDate":  transaction.RecordingDate,
+				"documentNumber": transaction.DocumentNumber,
+			})).
+			SetUpdate(bson.M{"$set": set, "$setOnInsert": bson.M{"createdAt": now}}).
+			SetUpsert(true))
+	}
+
+	start := time.Now()
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	metrics.MongoOperationDuration.WithLabelValues("bulk_write", "transactions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("bulk_write", "transactions").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindByProperty pages through a property's transactions, most recent sale first.
+func (r *transactionRepository) FindByProperty(ctx context.Context, propertyID string, filter models.TransactionFilter, offset, limit int) ([]models.Transaction, int64, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	query := inTenant(ctx, bson.M{"propertyId": propertyID})
+	dateRange := bson.M{}
+	if filter.From != "" {
+		dateRange["$gte"] = filter.From
+	}
+	if filter.To != "" {
+		dateRange["$lte"] = filter.To
+	}
+	if len(dateRange) > 0 {
+		query["date"] = dateRange
+	}
+
+	start := time.Now()
+	total, err := r.collection.CountDocuments(ctx, query)
+	metrics.MongoOperationDuration.WithLabelValues("count_documents", "transactions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("count_documents", "transactions").Inc()
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "date", Value: -1}, {Key: "recordingDate", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	start = time.Now()
+	cursor, err := r.collection.Find(ctx, query, findOptions)
+	metrics.MongoOperationDuration.WithLabelValues("find", "transactions").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "transactions").Inc()
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	transactions := []models.Transaction{}
+	if err := cursor.All(ctx, &transactions); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "transactions").Inc()
+		return nil, 0, err
+	}
+	return transactions, total, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// Error labels the server puts on errors worth retrying: the whole transaction for a transient
+// error such as a write conflict, only the commit when its outcome is unknown.
+const (
+	labelTransientTransaction = "TransientTransactionError"
+	labelUnknownCommitResult  = "UnknownTransactionCommitResult"
+)
+
+type commitHooksKey struct{}
+
+// commitHooks collects the work to do once a transaction has committed.
+type commitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+func (h *commitHooks) add(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fns = append(h.fns, fn)
+}
+
+func (h *commitHooks) run() {
+	for _, fn := range h.fns {
+		fn()
+	}
+}
+
+// AfterCommit defers fn until the transaction ctx belongs to has committed, and drops it when the
+// transaction rolls back. Outside a transaction fn runs right away. Side effects outside MongoDB,
+// such as cache writes and queued jobs, go through it so a retried or failed transaction doesn't
+// leave them behind.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		hooks.add(fn)
+		return
+	}
+	fn()
+}
+
+type mongoTransactor struct {
+	enabled     bool
+	maxAttempts int
+	options     *options.TransactionOptions
+}
+
+// NewTransactor returns a Transactor running transactions on the connected deployment. On a
+// standalone server, or with transactions disabled, functions run without one.
+func NewTransactor(cfg *config.Config) Transactor {
+	enabled := cfg.Database.Transactions.Enabled && database.SupportsTransactions()
+	if cfg.Database.Transactions.Enabled && !enabled {
+		logger.GlobalLogger.Warnf("MongoDB deployment does not support transactions; multi-document writes are not atomic")
+	}
+	return &mongoTransactor{
+		enabled:     enabled,
+		maxAttempts: cfg.Database.Transactions.MaxAttempts,
+		options: options.Transaction().
+			SetReadPreference(readpref.Primary()).
+			SetReadConcern(readconcern.Snapshot()).
+			SetWriteConcern(writeconcern.Majority()),
+	}
+}
+
+// WithTransaction runs fn in a transaction, committing when it returns nil and rolling back
+// otherwise. fn is run again from the start on a transient error, up to the configured number of
+// attempts, so it must not keep state between runs. Called from within a transaction, fn joins it.
+func (t *mongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !t.enabled {
+		return fn(ctx)
+	}
+	if _, ok := ctx.Value(commitHooksKey{}).(*commitHooks); ok {
+		return fn(ctx)
+	}
+
+	session, err := database.MongoClient.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(context.Background())
+
+	for attempt := 1; ; attempt++ {
+		hooks := &commitHooks{}
+		err := t.attempt(ctx, session, hooks, fn)
+		if err == nil {
+			hooks.run()
+			return nil
+		}
+		if attempt >= t.maxAttempts || ctx.Err() != nil || !hasErrorLabel(err, labelTransientTransaction) {
+			return err
+		}
+		logger.GlobalLogger.WithContext(ctx).Warnf("Retrying transaction: attempt=%d, error=%v", attempt, err)
+	}
+}
+
+// attempt runs fn in a new transaction on the session and commits it, retrying the commit alone
+// while its outcome is unknown.
+func (t *mongoTransactor) attempt(ctx context.Context, session mongo.Session, hooks *commitHooks, fn func(ctx context.Context) error) error {
+	if err := session.StartTransaction(t.options); err != nil {
+		return err
+	}
+	txCtx := context.WithValue(mongo.NewSessionContext(ctx, session), commitHooksKey{}, hooks)
+	if err := fn(txCtx); err != nil {
+		if abortErr := session.AbortTransaction(context.Background()); abortErr != nil {
+			logger.GlobalLogger.WithContext(ctx).Warnf("Failed to abort transaction: error=%v", abortErr)
+		}
+		return err
+	}
+	for commits := 1; ; commits++ {
+		err := session.CommitTransaction(ctx)
+		if err == nil || commits >= t.maxAttempts || ctx.Err() != nil || !hasErrorLabel(err, labelUnknownCommitResult) {
+			return err
+		}
+	}
+}
+
+func hasErrorLabel(err error, label string) bool {
+	var labeled mongo.LabeledError
+	return errors.As(err, &labeled) && labeled.HasErrorLabel(label)
+}
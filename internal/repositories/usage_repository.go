@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type usageRepository struct {
+	collection *mongo.Collection
+}
+
+func NewUsageRepository() UsageRepository {
+	return &usageRepository{
+		collection: database.DB.Collection("usage_daily"),
+	}
+}
+
+// UpsertMany stores daily usage records, replacing the counts of records rolled up before.
+func (r *usageRepository) UpsertMany(ctx context.Context, records []models.UsageRecord) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	if len(records) == 0 {
+		return nil
+	}
+	writes := make([]mongo.WriteModel, 0, len(records))
+	for _, record := range records {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"day": record.Day, "subjectType": record.SubjectType, "subject": record.Subject}).
+			SetUpdate(bson.M{"$set": record}).
+			SetUpsert(true))
+	}
+
+	start := time.Now()
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	metrics.MongoOperationDuration.WithLabelValues("bulk_write", "usage_daily").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("bulk_write", "usage_daily").Inc()
+		return err
+	}
+	return nil
+}
+
+// Find returns the usage records matching filter, by day and subject.
+func (r *usageRepository) Find(ctx context.Context, filter models.UsageFilter) ([]models.UsageRecord, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	query := bson.M{"day": bson.M{"$gte": filter.From, "$lte": filter.To}}
+	if filter.SubjectType != "" {
+		query["subjectType"] = filter.SubjectType
+	}
+	if filter.Subject != "" {
+		query["subject"] = filter.Subject
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "day", Value: 1}, {Key: "subjectType", Value: 1}, {Key: "subject", Value: 1}})
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, query, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "usage_daily").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "usage_daily").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	records := []models.UsageRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "usage_daily").Inc()
+		return nil, err
+	}
+	return records, nil
+}
@@ -8,6 +8,7 @@ import (
 	"homeinsight-properties/pkg/metrics"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -22,6 +23,8 @@ func NewUserRepository() UserRepository {
 }
 
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
 	var user models.User
 	collection := r.db.Collection("users")
 	start := time.Now()
@@ -38,7 +41,29 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*models
 	return &user, nil
 }
 
+func (r *userRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	var user models.User
+	collection := r.db.Collection("users")
+	start := time.Now()
+	err = collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&user)
+	duration := time.Since(start).Seconds()
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "users").Observe(duration)
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "users").Inc()
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
 	collection := r.db.Collection("users")
 	start := time.Now()
 	_, err := collection.InsertOne(ctx, user)
@@ -50,3 +75,65 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	}
 	return nil
 }
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return mongo.ErrNoDocuments
+	}
+	collection := r.db.Collection("users")
+	start := time.Now()
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objectID}, bson.M{"$set": bson.M{"password": passwordHash}})
+	duration := time.Since(start).Seconds()
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "users").Observe(duration)
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "users").Inc()
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindByIdentity returns the user linked to an identity provider account.
+func (r *userRepository) FindByIdentity(ctx context.Context, provider, subject string) (*models.User, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	var user models.User
+	collection := r.db.Collection("users")
+	filter := bson.M{"identities": bson.M{"$elemMatch": bson.M{"provider": provider, "subject": subject}}}
+	start := time.Now()
+	err := collection.FindOne(ctx, filter).Decode(&user)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "users").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			metrics.MongoErrorsTotal.WithLabelValues("find_one", "users").Inc()
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// AddIdentity links a provider account to a user. It reports false when the user is already linked
+// to another account with the same provider.
+func (r *userRepository) AddIdentity(ctx context.Context, id string, identity models.UserIdentity) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, mongo.ErrNoDocuments
+	}
+	collection := r.db.Collection("users")
+	filter := bson.M{"_id": objectID, "identities.provider": bson.M{"$ne": identity.Provider}}
+	start := time.Now()
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$push": bson.M{"identities": identity}})
+	metrics.MongoOperationDuration.WithLabelValues("update_one", "users").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update_one", "users").Inc()
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
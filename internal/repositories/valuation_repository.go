@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type valuationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewValuationRepository() ValuationRepository {
+	return &valuationRepository{
+		collection: database.DB.Collection("valuations"),
+	}
+}
+
+func (r *valuationRepository) Create(ctx context.Context, valuation *models.Valuation) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	valuation.OrgID = tenant.OrgID(ctx)
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, valuation)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "valuations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "valuations").Inc()
+		return err
+	}
+	return nil
+}
+
+// FindLatest returns the most recently retrieved valuation of a property.
+func (r *valuationRepository) FindLatest(ctx context.Context, propertyID string) (*models.Valuation, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	cost.Record(ctx, cost.MongoQuery)
+	opts := options.FindOne().SetSort(bson.D{{Key: "retrievedAt", Value: -1}})
+
+	start := time.Now()
+	var valuation models.Valuation
+	err := r.collection.FindOne(ctx, inTenant(ctx, bson.M{"propertyId": propertyID}), opts).Decode(&valuation)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "valuations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "valuations").Inc()
+		return nil, err
+	}
+	return &valuation, nil
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookRepository() WebhookRepository {
+	return &webhookRepository{
+		collection: database.DB.Collection("webhooks"),
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	webhook.OrgID = tenant.OrgID(ctx)
+	start := time.Now()
+	_, err := r.collection.InsertOne(ctx, webhook)
+	metrics.MongoOperationDuration.WithLabelValues("insert", "webhooks").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("insert", "webhooks").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) FindAll(ctx context.Context) ([]models.Webhook, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	return r.find(ctx, inTenant(ctx, bson.M{}))
+}
+
+// FindByEvent returns the webhooks subscribed to the given event type.
+func (r *webhookRepository) FindByEvent(ctx context.Context, eventType string) ([]models.Webhook, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	return r.find(ctx, inTenant(ctx, bson.M{"events": eventType}))
+}
+
+// FindByID returns a webhook, or nil if it doesn't exist (any more).
+func (r *webhookRepository) FindByID(ctx context.Context, id string) (*models.Webhook, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	start := time.Now()
+	var webhook models.Webhook
+	err = r.collection.FindOne(ctx, inTenant(ctx, bson.M{"_id": objID})).Decode(&webhook)
+	metrics.MongoOperationDuration.WithLabelValues("find_one", "webhooks").Observe(time.Since(start).Seconds())
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find_one", "webhooks").Inc()
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) find(ctx context.Context, filter bson.M) ([]models.Webhook, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	start := time.Now()
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	metrics.MongoOperationDuration.WithLabelValues("find", "webhooks").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("find", "webhooks").Inc()
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := []models.Webhook{}
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("cursor_all", "webhooks").Inc()
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// RecordDelivery stores the outcome of the latest delivery attempt sequence for a webhook.
+func (r *webhookRepository) RecordDelivery(ctx context.Context, id primitive.ObjectID, at time.Time, status, deliveryErr string) error {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	update := bson.M{"$set": bson.M{
+		"lastDeliveryAt":     at,
+		"lastDeliveryStatus": status,
+		"lastDeliveryError":  deliveryErr,
+	}}
+	start := time.Now()
+	_, err := r.collection.UpdateOne(ctx, inTenant(ctx, bson.M{"_id": id}), update)
+	metrics.MongoOperationDuration.WithLabelValues("update", "webhooks").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("update", "webhooks").Inc()
+		return err
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, nil
+	}
+
+	start := time.Now()
+	result, err := r.collection.DeleteOne(ctx, inTenant(ctx, bson.M{"_id": objID}))
+	metrics.MongoOperationDuration.WithLabelValues("delete", "webhooks").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("delete", "webhooks").Inc()
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+	"homeinsight-properties/pkg/standardization"
+)
+
+// AddressStandardizationService resolves user-entered addresses to the configured provider's canonical
+// form, so spelling variants of one address find and create the same record. It never fails the
+// caller: with standardization off, no match or the provider down, the input is used as given.
+type AddressStandardizationService struct {
+	standardizer standardization.Standardizer
+	addrTrans    transformers.AddressTransformer
+}
+
+func NewAddressStandardizationService(standardizer standardization.Standardizer, addrTrans transformers.AddressTransformer) *AddressStandardizationService {
+	return &AddressStandardizationService{
+		standardizer: standardizer,
+		addrTrans:    addrTrans,
+	}
+}
+
+// Standardize returns the canonical form of an address with its components normalized like stored
+// addresses, or nil when it can't be standardized.
+func (s *AddressStandardizationService) Standardize(ctx context.Context, street, city, state, zip string) *standardization.Address {
+	if s == nil || s.standardizer == nil {
+		return nil
+	}
+	name := s.standardizer.Name()
+	address, err := s.standardizer.Standardize(ctx, street, city, state, zip)
+	if err != nil {
+		if errors.Is(err, standardization.ErrNoMatch) {
+			metrics.AddressStandardizationsTotal.WithLabelValues(name, "no_match").Inc()
+			return nil
+		}
+		metrics.AddressStandardizationsTotal.WithLabelValues(name, "failed").Inc()
+		logger.GlobalLogger.WithContext(ctx).Warnf("Address standardization failed, using input as given: provider=%s, street=%s, error=%v", name, street, err)
+		return nil
+	}
+	metrics.AddressStandardizationsTotal.WithLabelValues(name, "matched").Inc()
+
+	// Keep input components the provider left out, e.g. a ZIP code Google didn't return
+	if address.City == "" {
+		address.City = city
+	}
+	if address.State == "" {
+		address.State = state
+	}
+	if address.Zip == "" {
+		address.Zip = zip
+	}
+
+	address.Street = s.addrTrans.NormalizeAddressComponent(address.Street)
+	address.City = s.addrTrans.NormalizeAddressComponent(address.City)
+	address.State = s.addrTrans.NormalizeAddressComponent(address.State)
+	address.HouseNumber = s.addrTrans.NormalizeAddressComponent(address.HouseNumber)
+	address.StreetName = s.addrTrans.NormalizeAddressComponent(address.StreetName)
+	address.StreetSuffix = s.addrTrans.NormalizeAddressComponent(address.StreetSuffix)
+	return address
+}
+
+// StandardizeProperty replaces a property's address with its canonical form and, when the provider
+// geocodes and the property has no parcel coordinates yet, fills them in.
+func (s *AddressStandardizationService) StandardizeProperty(ctx context.Context, property *models.Property) {
+	address := s.Standardize(ctx, property.Address.StreetAddress, property.Address.City, property.Address.State, property.Address.ZipCode)
+	if address == nil {
+		return
+	}
+	property.Address.StreetAddress = address.Street
+	property.Address.City = address.City
+	property.Address.State = address.State
+	property.Address.ZipCode = address.Zip
+	if address.ZipPlus4 != "" {
+		property.Address.ZipPlus4 = address.ZipPlus4
+	}
+	if address.HouseNumber != "" && address.StreetName != "" {
+		property.Address.StreetAddressParsed = models.StreetAddressParsed{
+			HouseNumber:      address.HouseNumber,
+			StreetName:       address.StreetName,
+			StreetNameSuffix: address.StreetSuffix,
+		}
+	}
+	parcel := &property.Location.Coordinates.Parcel
+	if address.HasCoordinates() && parcel.Lat == 0 && parcel.Lng == 0 {
+		*parcel = models.CoordinatesPoint{Lat: address.Lat, Lng: address.Lng}
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/requestid"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// auditDayFormat is the date-only form accepted by the audit log's from and to filters.
+const auditDayFormat = "2006-01-02"
+
+// AuditEventService keeps the security audit log: sign-ins, password changes, membership changes,
+// property deletions and cache flushes.
+type AuditEventService struct {
+	repo repositories.AuditEventRepository
+}
+
+func NewAuditEventService(repo repositories.AuditEventRepository) *AuditEventService {
+	return &AuditEventService{repo: repo}
+}
+
+// Record appends an event, filling in its time and the request ID carried by ctx. When ctx is a
+// request's gin context, the signed-in user and client IP are taken from it unless already set.
+// Failures are logged rather than returned so the audit log never undoes an action that already
+// happened.
+func (s *AuditEventService) Record(ctx context.Context, event models.AuditEvent) {
+	event.ID = primitive.NewObjectID()
+	event.OccurredAt = time.Now().UTC()
+	event.RequestID = requestid.FromContext(ctx)
+	// Looked up by key rather than asserted, so contexts derived from the request's, e.g. for a
+	// transaction, still record who acted
+	if ginCtx, ok := ctx.Value(gin.ContextKey).(*gin.Context); ok {
+		if event.ActorID == "" {
+			event.ActorID = ginCtx.GetString("user_id")
+			event.ActorRole = ginCtx.GetString("role")
+		}
+		if event.IP == "" && ginCtx.Request != nil {
+			event.IP = ginCtx.ClientIP()
+		}
+	}
+
+	if err := s.repo.Create(ctx, &event); err != nil {
+		logger.GlobalLogger.WithContext(ctx).Errorf("Failed to record audit event: type=%s, actor_id=%s, target_id=%s, error=%v",
+			event.Type, event.ActorID, event.TargetID, err)
+	}
+}
+
+// List returns a page of the events matching filter, newest first.
+func (s *AuditEventService) List(ctx context.Context, filter models.AuditEventFilter, offset, limit int, baseURL string, params url.Values) (*models.AuditEventsResponse, error) {
+	from, err := parseAuditTime(filter.From, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: from must be an RFC 3339 timestamp or a date in YYYY-MM-DD format")
+	}
+	to, err := parseAuditTime(filter.To, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: to must be an RFC 3339 timestamp or a date in YYYY-MM-DD format")
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, fmt.Errorf("invalid filter: from must be before to")
+	}
+
+	events, total, err := s.repo.Find(ctx, from, to, filter.Type, filter.ActorID, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: audit events from=%s, to=%s", filter.From, filter.To)
+	}
+	if events == nil {
+		events = []models.AuditEvent{}
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+	return &models.AuditEventsResponse{Data: events, Metadata: metadata}, nil
+}
+
+// parseAuditTime reads a from or to filter. A bare day used as the end of the range includes the whole
+// day. An empty value is the zero time, leaving that end of the range open.
+func parseAuditTime(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	day, err := time.Parse(auditDayFormat, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if end {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
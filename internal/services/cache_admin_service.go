@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+)
+
+// CacheAdminService lets admins drop cached data that has gone stale, without Redis access.
+type CacheAdminService struct {
+	cache repositories.PropertyCache
+	audit *AuditEventService
+}
+
+func NewCacheAdminService(cache repositories.PropertyCache, audit *AuditEventService) *CacheAdminService {
+	return &CacheAdminService{cache: cache, audit: audit}
+}
+
+// InvalidateProperty drops every cached entry derived from a property, including search and list
+// pages that contain it.
+func (s *CacheAdminService) InvalidateProperty(ctx context.Context, id, adminID string) error {
+	if err := s.cache.InvalidatePropertyCacheKeys(ctx, id); err != nil {
+		return utils.WrapError(err, "cache operation failed: invalidate property: id=%s", id)
+	}
+	logger.GlobalLogger.Printf("Admin invalidated property cache: id=%s, admin_id=%s", id, adminID)
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:       models.AuditEventCacheFlushed,
+		ActorID:    adminID,
+		TargetType: "property",
+		TargetID:   id,
+		Details:    map[string]interface{}{"scope": "property"},
+	})
+	return nil
+}
+
+// ClearSearches drops every cached search, full-text and list result.
+func (s *CacheAdminService) ClearSearches(ctx context.Context, adminID string) (int64, error) {
+	deleted, err := s.cache.ClearSearches(ctx)
+	if err != nil {
+		return deleted, utils.WrapError(err, "cache operation failed: clear searches")
+	}
+	logger.GlobalLogger.Printf("Admin cleared search cache: keys=%d, admin_id=%s", deleted, adminID)
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:    models.AuditEventCacheFlushed,
+		ActorID: adminID,
+		Details: map[string]interface{}{"scope": "search", "keys": deleted},
+	})
+	return deleted, nil
+}
+
+// Flush drops all cached data. Rate limits, revoked tokens and other non-cache state are kept.
+func (s *CacheAdminService) Flush(ctx context.Context, adminID string) (int64, error) {
+	deleted, err := s.cache.ClearAll(ctx)
+	if err != nil {
+		return deleted, utils.WrapError(err, "cache operation failed: flush")
+	}
+	logger.GlobalLogger.Warnf("Admin flushed cache: keys=%d, admin_id=%s", deleted, adminID)
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:    models.AuditEventCacheFlushed,
+		ActorID: adminID,
+		Details: map[string]interface{}{"scope": "all", "keys": deleted},
+	})
+	return deleted, nil
+}
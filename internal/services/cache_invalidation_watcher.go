@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+)
+
+const (
+	changeStreamCollection = "properties"
+	changeStreamLockName   = "change-stream:properties"
+	// changeStreamLockTTL is how long another instance waits to take over after the watcher dies.
+	changeStreamLockTTL = 30 * time.Second
+)
+
+// CacheInvalidationWatcher drops cached entries for every property that changes in MongoDB, whether
+// the write came through this API or not. Write-path invalidation stays in place so readers see their
+// own writes immediately; the watcher covers everything else.
+type CacheInvalidationWatcher struct {
+	repo  repositories.PropertyRepository
+	cache repositories.PropertyCache
+	retry time.Duration
+}
+
+func NewCacheInvalidationWatcher(repo repositories.PropertyRepository, cache repositories.PropertyCache, cfg *config.Config) *CacheInvalidationWatcher {
+	return &CacheInvalidationWatcher{
+		repo:  repo,
+		cache: cache,
+		retry: time.Duration(cfg.ChangeStream.RetrySeconds) * time.Second,
+	}
+}
+
+// Run watches the properties change stream until ctx is cancelled. A shared lock keeps a single
+// instance watching; the others retry and take over if it goes away. Other instances' in-process
+// entries are dropped through the cache invalidation channel.
+func (w *CacheInvalidationWatcher) Run(ctx context.Context) {
+	for {
+		err := w.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil && err != cache.ErrLockHeld {
+			logger.GlobalLogger.Warnf("Property change stream stopped: %v", err)
+		}
+
+		timer := time.NewTimer(w.retry)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// watch holds the watcher lock for as long as the change stream runs, resuming where the last
+// watcher left off.
+func (w *CacheInvalidationWatcher) watch(ctx context.Context) error {
+	// Should the lock be lost another instance may take over, so the watch stops rather than run twice
+	return cache.WithLock(ctx, changeStreamLockName, changeStreamLockTTL, func(watchCtx context.Context, _ *cache.Lock) error {
+		return w.watchLocked(watchCtx)
+	})
+}
+
+func (w *CacheInvalidationWatcher) watchLocked(watchCtx context.Context) error {
+	token, err := cache.GetResumeToken(watchCtx, changeStreamCollection)
+	if err != nil {
+		return err
+	}
+	logger.GlobalLogger.Printf("Watching property change stream: resumed=%t", token != nil)
+
+	err = w.repo.WatchChanges(watchCtx, token, func(change models.PropertyChange) error {
+		w.apply(watchCtx, change)
+		return nil
+	})
+	if errors.Is(err, repositories.ErrChangeStreamHistoryLost) {
+		// Changes since the stored position can't be replayed, so nothing cached can be trusted
+		logger.GlobalLogger.Warnf("Property change stream history lost, clearing cache: %v", err)
+		w.restart(watchCtx)
+		return nil
+	}
+	return err
+}
+
+func (w *CacheInvalidationWatcher) apply(ctx context.Context, change models.PropertyChange) {
+	switch change.Operation {
+	case models.ChangeOperationDrop, models.ChangeOperationRename, models.ChangeOperationInvalidate:
+		logger.GlobalLogger.Warnf("Properties collection %s, clearing cache", change.Operation)
+		w.restart(ctx)
+		return
+	}
+
+	if change.PropertyID == "" {
+		logger.GlobalLogger.Warnf("Property change without a property ID, cache not invalidated: operation=%s", change.Operation)
+	} else if err := w.cache.InvalidatePropertyCacheKeys(tenant.WithOrgID(ctx, change.OrgID), change.PropertyID); err != nil {
+		logger.GlobalLogger.Errorf("Failed to invalidate cache keys: id=%s, error=%v", change.PropertyID, err)
+	}
+	if err := cache.SetResumeToken(ctx, changeStreamCollection, change.ResumeToken); err != nil {
+		logger.GlobalLogger.Warnf("Failed to store change stream resume token: %v", err)
+	}
+}
+
+// restart clears the cache and forgets the stream position, so the next watch starts from now.
+func (w *CacheInvalidationWatcher) restart(ctx context.Context) {
+	if _, err := w.cache.ClearAll(ctx); err != nil {
+		logger.GlobalLogger.Errorf("Failed to clear cache: %v", err)
+	}
+	if err := cache.SetResumeToken(ctx, changeStreamCollection, nil); err != nil {
+		logger.GlobalLogger.Warnf("Failed to reset change stream resume token: %v", err)
+	}
+}
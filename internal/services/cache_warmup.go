@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+)
+
+// JobCacheWarmup is the background job type preloading the cache.
+const JobCacheWarmup = "cache.warmup"
+
+const (
+	cacheWarmupLockName = "cache-warmup"
+	cacheWarmupLockTTL  = time.Minute
+)
+
+// cacheWarmupResult reports a finished warm-up job.
+type cacheWarmupResult struct {
+	Strategy   string `json:"strategy"`
+	Properties int    `json:"properties"`
+}
+
+// EnqueueCacheWarmup queues a warm-up. Instances starting while one is pending, as in a rolling
+// deploy, share it instead of each loading the same properties.
+func (s *PropertyService) EnqueueCacheWarmup(ctx context.Context) error {
+	_, err := s.jobs.Enqueue(ctx, JobCacheWarmup, nil, jobs.EnqueueOptions{UniqueKey: JobCacheWarmup})
+	return err
+}
+
+// runCacheWarmup warms the cache under a shared lock, so a warm-up queued after the last one was
+// picked up, but before it finished, doesn't load the same properties again alongside it.
+func (s *PropertyService) runCacheWarmup(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var result *cacheWarmupResult
+	err := cache.WithLock(ctx, cacheWarmupLockName, cacheWarmupLockTTL, func(ctx context.Context, _ *cache.Lock) error {
+		strategy, count, err := s.WarmCache(ctx)
+		if err != nil {
+			return err
+		}
+		result = &cacheWarmupResult{Strategy: strategy, Properties: count}
+		return nil
+	})
+	if err == cache.ErrLockHeld {
+		logger.GlobalLogger.Printf("Cache warm-up already running on another instance, skipping: jobId=%s", job.ID)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// recordPropertyHit counts a property read toward the popular warm-up strategy.
+func (s *PropertyService) recordPropertyHit(ctx context.Context, id string) {
+	if !s.config.CacheWarmup.Enabled || s.config.CacheWarmup.Strategy != "popular" {
+		return
+	}
+	if err := cache.RecordPropertyHit(ctx, id); err != nil {
+		logger.GlobalLogger.Warnf("Failed to record property hit: id=%s, error=%v", id, err)
+	}
+}
+
+// WarmCache preloads the configured number of properties into the cache, so a cold start doesn't
+// send every read to MongoDB. The popular strategy falls back to recently updated properties until
+// reads have been recorded. Returns the strategy used and the number of properties cached.
+func (s *PropertyService) WarmCache(ctx context.Context) (string, int, error) {
+	start := time.Now()
+	strategy := s.config.CacheWarmup.Strategy
+	count := s.config.CacheWarmup.Count
+
+	var properties []models.Property
+	if strategy == "popular" {
+		ids, err := cache.TopPropertyHits(ctx, count)
+		if err != nil {
+			logger.GlobalLogger.Warnf("Failed to load property hits for cache warm-up: error=%v", err)
+		} else if len(ids) > 0 {
+			if properties, err = s.repo.FindByIDs(ctx, ids, nil, 0, 0); err != nil {
+				return strategy, 0, utils.WrapError(err, "database query failed: popular properties for cache warm-up")
+			}
+		}
+	}
+	if len(properties) == 0 {
+		strategy = "recent"
+		var err error
+		if properties, err = s.repo.FindRecentlyUpdated(ctx, count); err != nil {
+			return strategy, 0, utils.WrapError(err, "database query failed: recent properties for cache warm-up")
+		}
+	}
+
+	if err := s.cache.SetProperties(ctx, properties); err != nil {
+		return strategy, 0, utils.WrapError(err, "cache write failed: properties for cache warm-up")
+	}
+	logger.GlobalLogger.Printf("Cache warmed: strategy=%s, properties=%d, duration=%s", strategy, len(properties), time.Since(start).Round(time.Millisecond))
+	return strategy, len(properties), nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/census"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// DemographicsService serves census tract statistics for properties, for market analysis. The
+// tract comes from the property's stored census tract ID when it is a full GEOID, and otherwise
+// from its parcel coordinates.
+type DemographicsService struct {
+	census     census.Provider
+	properties *PropertyService
+}
+
+func NewDemographicsService(provider census.Provider, properties *PropertyService) *DemographicsService {
+	return &DemographicsService{census: provider, properties: properties}
+}
+
+// GetDemographics returns the statistics of the census tract a property lies in.
+func (s *DemographicsService) GetDemographics(ctx context.Context, propertyID string) (*models.Demographics, error) {
+	if s.census == nil {
+		return nil, fmt.Errorf("census data unavailable: census demographics are disabled")
+	}
+	property, err := s.properties.GetPropertyByID(ctx, propertyID)
+	if err != nil {
+		return nil, err
+	}
+
+	geoid, err := s.tract(ctx, property)
+	if err == nil {
+		var stats *census.TractStats
+		if stats, err = s.census.TractStats(ctx, geoid); err == nil {
+			metrics.DemographicsLookupsTotal.WithLabelValues("found").Inc()
+			return demographicsFromStats(propertyID, stats), nil
+		}
+	}
+	if errors.Is(err, census.ErrNotFound) {
+		metrics.DemographicsLookupsTotal.WithLabelValues("not_found").Inc()
+		return nil, fmt.Errorf("demographics not found: propertyId=%s: %w", propertyID, err)
+	}
+	metrics.DemographicsLookupsTotal.WithLabelValues("failed").Inc()
+	return nil, utils.WrapError(err, "census data unavailable: propertyId=%s", propertyID)
+}
+
+// tract resolves the GEOID of a property's census tract. Data providers that store the tract
+// number alone, without its state and county, fall back to the coordinates.
+func (s *DemographicsService) tract(ctx context.Context, property *models.Property) (string, error) {
+	if id := property.Location.CensusTract.ID; census.IsGEOID(id) {
+		return id, nil
+	}
+	parcel := property.Location.Coordinates.Parcel
+	if parcel.Lat == 0 && parcel.Lng == 0 {
+		return "", fmt.Errorf("%w: no census tract or coordinates stored", census.ErrNotFound)
+	}
+	geoid, err := s.census.TractForPoint(ctx, parcel.Lat, parcel.Lng)
+	if err != nil {
+		return "", err
+	}
+	logger.GlobalLogger.WithContext(ctx).Debugf("Resolved census tract from coordinates: propertyId=%s, geoid=%s", property.PropertyID, geoid)
+	return geoid, nil
+}
+
+func demographicsFromStats(propertyID string, stats *census.TractStats) *models.Demographics {
+	return &models.Demographics{
+		PropertyID:  propertyID,
+		CensusTract: stats.GEOID,
+		Name:        stats.Name,
+		Source:      "acs5",
+		Year:        stats.Year,
+		Population:  stats.Population,
+		MedianAge:   stats.MedianAge,
+		Households:  stats.Households,
+		Income: models.DemographicsIncome{
+			MedianHousehold: stats.MedianHouseholdIncome,
+			PerCapita:       stats.PerCapitaIncome,
+			PovertyRate:     percentage(stats.BelowPoverty, stats.PovertyUniverse),
+		},
+		Housing: models.DemographicsHousing{
+			Units:             stats.HousingUnits,
+			Occupied:          stats.OccupiedUnits,
+			Vacant:            stats.VacantUnits,
+			VacancyRate:       percentage(stats.VacantUnits, stats.HousingUnits),
+			OwnerOccupied:     stats.OwnerOccupied,
+			RenterOccupied:    stats.RenterOccupied,
+			OwnerOccupiedRate: percentage(stats.OwnerOccupied, stats.OccupiedUnits),
+			MedianHomeValue:   stats.MedianHomeValue,
+			MedianGrossRent:   stats.MedianGrossRent,
+			MedianYearBuilt:   stats.MedianYearBuilt,
+		},
+	}
+}
+
+// percentage is part as a percentage of whole, or nil when either is unknown or whole is zero.
+func percentage(part, whole *int) *float64 {
+	if part == nil || whole == nil || *whole == 0 {
+		return nil
+	}
+	rate := math.Round(float64(*part)/float64(*whole)*1000) / 10
+	return &rate
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"homeinsight-properties/internal/deprecation"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+)
+
+type DeprecationService struct{}
+
+func NewDeprecationService() *DeprecationService {
+	return &DeprecationService{}
+}
+
+// Report returns every registered deprecation with the clients that still call it.
+func (s *DeprecationService) Report(ctx context.Context) ([]models.DeprecationReport, error) {
+	deprecations := deprecation.All()
+	reports := make([]models.DeprecationReport, 0, len(deprecations))
+	for _, d := range deprecations {
+		callers, err := cache.DeprecatedCallers(ctx, d.ID)
+		if err != nil {
+			return nil, utils.WrapError(err, "cache query failed: deprecation usage: id=%s", d.ID)
+		}
+
+		report := models.DeprecationReport{Deprecation: d, Clients: make([]models.DeprecationClientUsage, 0, len(callers))}
+		for client, caller := range callers {
+			report.TotalCalls += caller.Calls
+			report.Clients = append(report.Clients, models.DeprecationClientUsage{
+				Client:   client,
+				Calls:    caller.Calls,
+				LastSeen: caller.LastSeen,
+			})
+		}
+		sort.Slice(report.Clients, func(i, j int) bool {
+			return report.Clients[i].LastSeen.After(report.Clients[j].LastSeen)
+		})
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
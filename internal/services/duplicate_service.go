@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const JobDuplicateScan = "properties.duplicate_scan"
+
+const (
+	// maxDuplicateGroups bounds the groups of each kind a scan returns.
+	maxDuplicateGroups = 1000
+	// maxDuplicateGroupSize skips groups too large to be real duplicates, such as many properties
+	// sharing a placeholder address, which would otherwise produce a pair for every combination.
+	maxDuplicateGroupSize = 10
+)
+
+// mergeSkippedFields identify a property or are maintained by the service, so a merge never copies them.
+var mergeSkippedFields = map[string]bool{
+	"_id":           true,
+	"orgId":         true,
+	"schemaVersion": true,
+	"propertyId":    true,
+	"updatedAt":     true,
+	"deletedAt":     true,
+}
+
+type duplicateScanPayload struct {
+	OrgID string `json:"orgId"`
+}
+
+// DuplicateService finds properties that likely describe the same parcel and merges them. A scan
+// runs as a background job and replaces the organization's list of candidate pairs; admins review
+// the pairs and merge the ones that are real duplicates.
+type DuplicateService struct {
+	repo       repositories.DuplicateRepository
+	properties repositories.PropertyRepository
+	service    *PropertyService
+	audit      *PropertyAuditService
+	jobs       *jobs.Queue
+	tx         repositories.Transactor
+}
+
+func NewDuplicateService(repo repositories.DuplicateRepository, properties repositories.PropertyRepository, service *PropertyService, audit *PropertyAuditService, jobQueue *jobs.Queue, tx repositories.Transactor) *DuplicateService {
+	s := &DuplicateService{
+		repo:       repo,
+		properties: properties,
+		service:    service,
+		audit:      audit,
+		jobs:       jobQueue,
+		tx:         tx,
+	}
+	jobQueue.Register(JobDuplicateScan, s.runScan, jobs.Options{Workers: 1, MaxAttempts: 3})
+	return s
+}
+
+// Scan queues a duplicate scan of the signed-in user's organization. While one is pending, the
+// pending job is returned instead of queueing another.
+func (s *DuplicateService) Scan(ctx context.Context, userID string) (*jobs.Job, error) {
+	orgID := tenant.OrgID(ctx)
+	job, err := s.jobs.Enqueue(ctx, JobDuplicateScan, duplicateScanPayload{OrgID: orgID}, jobs.EnqueueOptions{
+		CreatedBy: userID,
+		UniqueKey: JobDuplicateScan + ":" + orgID,
+	})
+	if err != nil {
+		return nil, utils.WrapError(err, "queue duplicate scan failed: orgId=%s", orgID)
+	}
+	return job, nil
+}
+
+func (s *DuplicateService) runScan(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload duplicateScanPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, jobs.Permanent(err)
+	}
+	ctx = tenant.WithOrgID(ctx, payload.OrgID)
+
+	groups, err := s.repo.FindGroups(ctx, maxDuplicateGroups)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: duplicate groups orgId=%s", payload.OrgID)
+	}
+
+	result := &models.DuplicateScanResult{Groups: len(groups)}
+	detectedAt := time.Now().UTC()
+	pairs := make(map[string]*models.DuplicateCandidate)
+	var keys []string
+	for _, group := range groups {
+		if len(group.PropertyIDs) > maxDuplicateGroupSize {
+			result.SkippedGroups++
+			logger.GlobalLogger.Warnf("Duplicate group too large, skipped: orgId=%s, reason=%s, key=%s, properties=%d", payload.OrgID, group.Reason, group.Key, len(group.PropertyIDs))
+			continue
+		}
+		ids := append([]string(nil), group.PropertyIDs...)
+		sort.Strings(ids)
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				pairKey := ids[i] + "|" + ids[j]
+				candidate, ok := pairs[pairKey]
+				if !ok {
+					candidate = &models.DuplicateCandidate{
+						ID:          primitive.NewObjectID(),
+						OrgID:       payload.OrgID,
+						PropertyIDs: []string{ids[i], ids[j]},
+						DetectedAt:  detectedAt,
+					}
+					pairs[pairKey] = candidate
+					keys = append(keys, pairKey)
+				}
+				candidate.Reasons = append(candidate.Reasons, group.Reason)
+				candidate.Keys = append(candidate.Keys, group.Key)
+			}
+		}
+	}
+
+	candidates := make([]models.DuplicateCandidate, 0, len(keys))
+	for _, key := range keys {
+		candidates = append(candidates, *pairs[key])
+	}
+	if err := s.repo.ReplaceCandidates(ctx, candidates); err != nil {
+		return nil, utils.WrapError(err, "database update failed: duplicate candidates orgId=%s", payload.OrgID)
+	}
+	result.Candidates = len(candidates)
+	logger.GlobalLogger.Printf("Duplicate scan completed: orgId=%s, groups=%d, candidates=%d, skipped=%d", payload.OrgID, result.Groups, result.Candidates, result.SkippedGroups)
+	return result, nil
+}
+
+// List pages through the candidate pairs found by the latest scan.
+func (s *DuplicateService) List(ctx context.Context, offset, limit int) ([]models.DuplicateCandidate, int64, error) {
+	candidates, total, err := s.repo.FindCandidates(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, utils.WrapError(err, "database query failed: duplicate candidates offset=%d, limit=%d", offset, limit)
+	}
+	return candidates, total, nil
+}
+
+// Merge folds one property into another. Sections the kept property lacks are taken from the merged
+// one; the merged property's history, transactions, valuations, media, listings, share links, saved
+// search matches and owner links move to the kept property; the merged property goes to the trash.
+func (s *DuplicateService) Merge(ctx context.Context, req *models.MergePropertiesRequest) (*models.MergePropertiesResult, error) {
+	if req.KeepID == req.MergeID {
+		return nil, fmt.Errorf("invalid merge: a property cannot be merged into itself: id=%s", req.KeepID)
+	}
+
+	// Either every step of the merge happens or none does; a retried transaction starts over from
+	// freshly read properties
+	var keep *models.Property
+	var filled []string
+	var remapped map[string]int64
+	var conflicts map[string]int64
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		keep, err = s.properties.FindByID(ctx, req.KeepID)
+		if err != nil {
+			return utils.WrapError(err, "database query failed: id=%s", req.KeepID)
+		}
+		merged, err := s.properties.FindByID(ctx, req.MergeID)
+		if err != nil {
+			return utils.WrapError(err, "database query failed: id=%s", req.MergeID)
+		}
+		if keep == nil || merged == nil {
+			return fmt.Errorf("property not found: keepId=%s, mergeId=%s", req.KeepID, req.MergeID)
+		}
+
+		filled = fillMissing(keep, merged)
+		if len(filled) > 0 {
+			if err := s.service.UpdateProperty(ctx, keep); err != nil {
+				return err
+			}
+		}
+
+		remapped, conflicts, err = s.repo.RemapPropertyID(ctx, req.MergeID, req.KeepID)
+		if err != nil {
+			return utils.WrapError(err, "database update failed: remap property keepId=%s, mergeId=%s", req.KeepID, req.MergeID)
+		}
+		if err := s.service.DeleteProperty(ctx, req.MergeID); err != nil {
+			return err
+		}
+		s.audit.RecordMerge(ctx, req.KeepID, req.MergeID)
+		if err := s.repo.DeleteCandidatesFor(ctx, req.MergeID); err != nil {
+			logger.GlobalLogger.Errorf("Failed to remove duplicate candidates of merged property: id=%s, error=%v", req.MergeID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.GlobalLogger.WithContext(ctx).Printf("Properties merged: keepId=%s, mergeId=%s, filled=%v, remapped=%v, conflicts=%v", req.KeepID, req.MergeID, filled, remapped, conflicts)
+	return &models.MergePropertiesResult{
+		Property:     keep,
+		MergedID:     req.MergeID,
+		FilledFields: filled,
+		Remapped:     remapped,
+		Conflicts:    conflicts,
+	}, nil
+}
+
+// fillMissing copies the top-level sections keep leaves empty from merged and returns their JSON names.
+func fillMissing(keep, merged *models.Property) []string {
+	filled := []string{}
+	keepValue := reflect.ValueOf(keep).Elem()
+	mergedValue := reflect.ValueOf(merged).Elem()
+	fields := keepValue.Type()
+	for i := 0; i < fields.NumField(); i++ {
+		field := fields.Field(i)
+		// Sections read from other collections aren't stored on the property
+		if field.Tag.Get("bson") == "-" {
+			continue
+		}
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" || mergeSkippedFields[name] {
+			continue
+		}
+		if keepValue.Field(i).IsZero() && !mergedValue.Field(i).IsZero() {
+			keepValue.Field(i).Set(mergedValue.Field(i))
+			filled = append(filled, name)
+		}
+	}
+	return filled
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"homeinsight-properties/internal/models"
+)
+
+type EmbedService struct {
+	propertyService *PropertyService
+}
+
+func NewEmbedService(propertyService *PropertyService) *EmbedService {
+	return &EmbedService{
+		propertyService: propertyService,
+	}
+}
+
+func (s *EmbedService) GetPropertySummary(ctx context.Context, id string) (*models.EmbedPropertySummary, error) {
+	property, err := s.propertyService.GetPropertyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.EmbedPropertySummary{
+		PropertyID:           property.PropertyID,
+		StreetAddress:        property.Address.StreetAddress,
+		City:                 property.Address.City,
+		State:                property.Address.State,
+		ZipCode:              property.Address.ZipCode,
+		BedroomsCount:        property.Building.Summary.BedroomsCount,
+		BathroomsCount:       property.Building.Summary.BathroomsCount,
+		LivingAreaSquareFeet: property.Building.Summary.LivingAreaSquareFeet,
+		LotAreaAcres:         property.Lot.AreaAcres,
+		YearBuilt:            property.Building.Details.Construction.YearBuilt,
+		AssessedValue:        property.TaxAssessment.AssessedValue.TotalValue,
+		LastSaleDate:         property.LastMarketSale.Date,
+		LastSaleAmount:       property.LastMarketSale.Amount,
+	}, nil
+}
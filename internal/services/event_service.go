@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/events"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventService publishes property changes to the event broker through the event_outbox collection:
+// changes are first stored as pending events, and the relay publishes them and keeps retrying until
+// the broker acknowledges each one, so every change is delivered at least once. Changes are also
+// passed on to the search index, when there is one.
+type EventService struct {
+	repo      repositories.EventOutboxRepository
+	publisher events.Publisher
+	index     *SearchIndexService
+	config    *config.Config
+}
+
+// NewEventService returns the event service; with a nil publisher (events disabled) Record publishes
+// nothing, and with a nil index it syncs nothing.
+func NewEventService(repo repositories.EventOutboxRepository, publisher events.Publisher, index *SearchIndexService, cfg *config.Config) *EventService {
+	return &EventService{repo: repo, publisher: publisher, index: index, config: cfg}
+}
+
+// Record queues a property change for publication. property may be nil for deletions. Failures are
+// logged rather than returned, like the audit history, so the outbox never undoes a completed change.
+func (s *EventService) Record(ctx context.Context, eventType, propertyID string, property *models.Property) {
+	s.index.Sync(ctx, propertyID)
+	if s.publisher == nil {
+		return
+	}
+	orgID := tenant.OrgID(ctx)
+	if property != nil {
+		orgID = property.OrgID
+	}
+	now := time.Now().UTC()
+	id := primitive.NewObjectID()
+	payload, err := json.Marshal(&models.PropertyEvent{
+		ID:         id.Hex(),
+		Type:       eventType,
+		OccurredAt: now,
+		OrgID:      orgID,
+		PropertyID: propertyID,
+		Property:   withoutOwnerPII(property),
+	})
+	if err != nil {
+		logger.GlobalLogger.WithContext(ctx).Errorf("Failed to encode property event: event=%s, propertyId=%s, error=%v", eventType, propertyID, err)
+		return
+	}
+
+	event := &models.OutboxEvent{
+		ID:            id,
+		Type:          eventType,
+		PropertyID:    propertyID,
+		Payload:       string(payload),
+		Status:        models.OutboxStatusPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	if err := s.repo.Create(ctx, event); err != nil {
+		logger.GlobalLogger.WithContext(ctx).Errorf("Failed to queue property event: event=%s, propertyId=%s, error=%v", eventType, propertyID, err)
+	}
+}
+
+// withoutOwnerPII returns a shallow copy of the property without owner names and mailing address,
+// which would otherwise sit in the outbox and reach every broker consumer. Whether owners are
+// corporate is kept. property may be nil.
+func withoutOwnerPII(property *models.Property) *models.Property {
+	if property == nil {
+		return nil
+	}
+	redacted := *property
+	redacted.Ownership.MailingAddress = models.MailingAddress{}
+	redacted.Ownership.CurrentOwners = make([]models.Owner, len(property.Ownership.CurrentOwners))
+	for i, owner := range property.Ownership.CurrentOwners {
+		redacted.Ownership.CurrentOwners[i] = models.Owner{SequenceNumber: owner.SequenceNumber, IsCorporate: owner.IsCorporate}
+	}
+	return &redacted
+}
+
+// Relay publishes up to one batch of due outbox events. A failed event is retried after a backoff
+// that doubles with every attempt up to the configured maximum; events are never given up on.
+func (s *EventService) Relay(ctx context.Context) error {
+	timeout := time.Duration(s.config.Events.PublishTimeoutSeconds) * time.Second
+	// the lease outlasts the publish timeout so an event is not claimed twice while it is in flight
+	lease := 2 * timeout
+	retention := time.Duration(s.config.Events.RetentionHours) * time.Hour
+
+	published, failed := 0, 0
+	for published+failed < s.config.Events.BatchSize {
+		event, err := s.repo.ClaimDue(ctx, time.Now().UTC(), lease)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			break
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = s.publisher.Publish(publishCtx, events.Message{
+			ID:   event.ID.Hex(),
+			Key:  event.PropertyID,
+			Type: event.Type,
+			Body: []byte(event.Payload),
+		})
+		cancel()
+
+		now := time.Now().UTC()
+		if err == nil {
+			published++
+			metrics.EventsPublishedTotal.WithLabelValues(event.Type, "succeeded").Inc()
+			if err := s.repo.MarkPublished(ctx, event.ID, now, now.Add(retention)); err != nil {
+				// the lease runs out and the event is published again, which at-least-once allows
+				logger.GlobalLogger.Errorf("Failed to mark event published: eventId=%s, error=%v", event.ID.Hex(), err)
+			}
+			continue
+		}
+
+		failed++
+		metrics.EventsPublishedTotal.WithLabelValues(event.Type, "failed").Inc()
+		logger.GlobalLogger.Warnf("Event publish failed: eventId=%s, event=%s, propertyId=%s, attempt=%d, error=%v",
+			event.ID.Hex(), event.Type, event.PropertyID, event.Attempts, err)
+		if err := s.repo.MarkFailed(ctx, event.ID, now.Add(s.backoff(event.Attempts)), err.Error()); err != nil {
+			logger.GlobalLogger.Errorf("Failed to reschedule event: eventId=%s, error=%v", event.ID.Hex(), err)
+		}
+	}
+
+	if published+failed > 0 {
+		logger.GlobalLogger.Printf("Event relay run: published=%d, failed=%d", published, failed)
+	}
+	return nil
+}
+
+// backoff is the wait after the given number of failed attempts.
+func (s *EventService) backoff(attempts int) time.Duration {
+	backoff := time.Duration(s.config.Events.InitialBackoffSeconds) * time.Second
+	maxBackoff := time.Duration(s.config.Events.MaxBackoffSeconds) * time.Second
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
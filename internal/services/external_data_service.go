@@ -2,45 +2,86 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
 
+	"homeinsight-properties/internal/cost"
 	"homeinsight-properties/internal/models"
 	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/internal/usage"
 	"homeinsight-properties/internal/utils"
 	"homeinsight-properties/pkg/config"
-	"homeinsight-properties/pkg/corelogic"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+	"homeinsight-properties/pkg/providers"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// PropertyEnricher adds data from a secondary provider to a property fetched from the data
+// provider, before it is stored. Enrichers log their failures rather than failing the fetch.
+type PropertyEnricher interface {
+	Enrich(ctx context.Context, property *models.Property)
+}
+
 type ExternalDataService struct {
-	corelogic *corelogic.Client
+	sources   []providers.Source
+	enrichers []PropertyEnricher
 	propTrans transformers.PropertyTransformer
 	config    *config.Config
 }
 
 func NewExternalDataService(
-	corelogicClient *corelogic.Client,
+	sources []providers.Source,
+	enrichers []PropertyEnricher,
 	propTrans transformers.PropertyTransformer,
 	cfg *config.Config,
 ) *ExternalDataService {
 	return &ExternalDataService{
-		corelogic: corelogicClient,
+		sources:   sources,
+		enrichers: enrichers,
 		propTrans: propTrans,
 		config:    cfg,
 	}
 }
 
+// FetchFromExternalSource asks the configured providers for the address in priority order, moving on
+// to the next one as each provider's fallback policy allows.
 func (s *ExternalDataService) FetchFromExternalSource(ctx context.Context, street, city, state, zip string, req *models.SearchRequest) (*models.Property, error) {
 	ginCtx, _ := ctx.(*gin.Context)
 	if ginCtx == nil {
 		ginCtx = &gin.Context{}
 	}
 
-	// Request CoreLogic
-	property, err := s.corelogic.RequestCoreLogic(ctx, street, city, state, zip)
+	var property *models.Property
+	var err error
+	for i, source := range s.sources {
+		name := source.Provider.Name()
+		cost.Record(ctx, cost.CoreLogicCall)
+		if err = usage.Take(ctx, usage.CoreLogicCalls); err != nil {
+			break
+		}
+		property, err = source.Provider.FetchProperty(ctx, street, city, state, zip)
+		if err == nil {
+			metrics.PropertyProviderRequestsTotal.WithLabelValues(name, "succeeded").Inc()
+			ginCtx.Set("data_source", strings.ToUpper(name)+"_API")
+			break
+		}
+
+		outcome := "failed"
+		if errors.Is(err, providers.ErrNotFound) {
+			outcome = "not_found"
+		}
+		metrics.PropertyProviderRequestsTotal.WithLabelValues(name, outcome).Inc()
+		if i == len(s.sources)-1 || !source.FallsBack(err) {
+			break
+		}
+		logger.GlobalLogger.WithContext(ctx).Warnf("Property provider failed, falling back: provider=%s, next=%s, error=%v",
+			name, s.sources[i+1].Provider.Name(), err)
+	}
 	if err != nil {
-		return nil, utils.WrapError(err, "CoreLogic fetch failed: query=%s", req.Search)
+		return nil, utils.WrapError(err, "property data provider fetch failed: query=%s", req.Search)
 	}
 
 	// Override address fields with search input
@@ -52,5 +93,8 @@ func (s *ExternalDataService) FetchFromExternalSource(ctx context.Context, stree
 	// Generate a new ID
 	property.ID = primitive.NewObjectID()
 
+	for _, enricher := range s.enrichers {
+		enricher.Enrich(ctx, property)
+	}
 	return property, nil
 }
@@ -0,0 +1,400 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/feeds"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobFeedRun is the background job type applying an uploaded feed file to properties.
+const JobFeedRun = "feed.run"
+
+type feedRunJob struct {
+	RunID string `json:"runId"`
+}
+
+// FeedService accepts bulk files from data providers, such as MLS exports and assessor rolls, and
+// applies their records to properties in the background. Each upload is a feed run: the file is kept in
+// storage and worked through in slices, each a job of its own that checkpoints after every batch of
+// records, so a large file outlives job timeouts and a crashed slice resumes where it stopped.
+type FeedService struct {
+	repo         repositories.FeedRunRepository
+	properties   *PropertyService
+	store        storage.ObjectStorage
+	jobs         *jobs.Queue
+	transformers map[string]transformers.FeedTransformer
+	spoolDir     string
+	batchSize    int
+	maxRowErrors int
+	slice        time.Duration
+}
+
+func NewFeedService(repo repositories.FeedRunRepository, properties *PropertyService, store storage.ObjectStorage, jobQueue *jobs.Queue, cfg *config.Config, feedTransformers ...transformers.FeedTransformer) *FeedService {
+	s := &FeedService{
+		repo:         repo,
+		properties:   properties,
+		store:        store,
+		jobs:         jobQueue,
+		transformers: make(map[string]transformers.FeedTransformer, len(feedTransformers)),
+		spoolDir:     cfg.Feeds.SpoolDir,
+		batchSize:    cfg.Feeds.BatchSize,
+		maxRowErrors: cfg.Feeds.MaxRowErrors,
+		slice:        time.Duration(cfg.Feeds.SliceSeconds) * time.Second,
+	}
+	for _, transformer := range feedTransformers {
+		s.transformers[transformer.Provider()] = transformer
+	}
+	jobQueue.Register(JobFeedRun, s.run, jobs.Options{Workers: 1, Timeout: s.slice + time.Minute})
+	return s
+}
+
+// Upload stores a feed file read from body and queues it to be applied. The file is spooled to disk
+// first, so a body cut off mid-upload or one that doesn't parse is refused before anything is stored.
+func (s *FeedService) Upload(ctx context.Context, provider, format, fileName string, body io.Reader, userID, role string) (*models.FeedRun, error) {
+	if _, ok := s.transformers[provider]; !ok {
+		return nil, fmt.Errorf("feed provider not found: provider=%s", provider)
+	}
+	if format != feeds.FormatCSV && format != feeds.FormatXML {
+		return nil, fmt.Errorf("invalid feed: format must be csv or xml, got %q", format)
+	}
+
+	if err := os.MkdirAll(s.spoolDir, 0o750); err != nil {
+		return nil, utils.WrapError(err, "create feed spool dir failed: dir=%s", s.spoolDir)
+	}
+	spool, err := os.CreateTemp(s.spoolDir, "upload-*")
+	if err != nil {
+		return nil, utils.WrapError(err, "create feed spool file failed: dir=%s", s.spoolDir)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(spool, io.TeeReader(body, hash))
+	if err != nil {
+		return nil, utils.WrapError(err, "invalid feed: upload failed after %d bytes", size)
+	}
+	if size == 0 {
+		return nil, fmt.Errorf("invalid feed: file is empty")
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	reader, err := feeds.NewReader(spool, format)
+	if err == nil {
+		_, err = reader.Next()
+	}
+	var rowErr *feeds.RowError
+	if err != nil && err != io.EOF && !stderrors.As(err, &rowErr) {
+		return nil, fmt.Errorf("invalid feed: %v", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	run := &models.FeedRun{
+		ID:           primitive.NewObjectID(),
+		OrgID:        tenant.OrgID(ctx),
+		Provider:     provider,
+		Format:       format,
+		FileName:     fileName,
+		SizeBytes:    size,
+		SHA256:       hex.EncodeToString(hash.Sum(nil)),
+		Status:       models.FeedRunStatusQueued,
+		UploadedBy:   userID,
+		UploaderRole: role,
+		UploadedAt:   now,
+		UpdatedAt:    now,
+	}
+	run.FileKey = fmt.Sprintf("feeds/%s/%s.%s", provider, run.ID.Hex(), format)
+	if err := s.store.PutStream(ctx, run.FileKey, feedContentType(format), spool, size); err != nil {
+		return nil, utils.WrapError(err, "store feed file failed: provider=%s", provider)
+	}
+	if err := s.repo.Create(ctx, run); err != nil {
+		return nil, utils.WrapError(err, "database insert failed: feed run provider=%s", provider)
+	}
+
+	job, err := s.jobs.Enqueue(ctx, JobFeedRun, &feedRunJob{RunID: run.ID.Hex()}, jobs.EnqueueOptions{CreatedBy: userID})
+	if err != nil {
+		return nil, utils.WrapError(err, "queue feed run failed: runId=%s", run.ID.Hex())
+	}
+	if err := s.repo.SetJob(ctx, run.ID, job.ID); err != nil {
+		return nil, utils.WrapError(err, "database update failed: feed runId=%s", run.ID.Hex())
+	}
+	run.JobID = job.ID
+	logger.GlobalLogger.Printf("Feed uploaded: provider=%s, runId=%s, jobId=%s, bytes=%d, by=%s", provider, run.ID.Hex(), job.ID, size, userID)
+	return run, nil
+}
+
+func feedContentType(format string) string {
+	if format == feeds.FormatXML {
+		return "application/xml"
+	}
+	return "text/csv"
+}
+
+// GetRun returns a feed run of the caller's organization with its row errors.
+func (s *FeedService) GetRun(ctx context.Context, id string) (*models.FeedRun, error) {
+	run, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: feed runId=%s", id)
+	}
+	if run == nil {
+		return nil, fmt.Errorf("feed run not found: runId=%s", id)
+	}
+	return run, nil
+}
+
+// ListRuns pages through the feed runs of the caller's organization, newest first, optionally of one
+// provider. Row errors are left out; fetch a single run for them.
+func (s *FeedService) ListRuns(ctx context.Context, provider string, offset, limit int, baseURL string, params url.Values) (*models.FeedRunsResponse, error) {
+	runs, total, err := s.repo.Find(ctx, provider, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: feed runs provider=%s", provider)
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+	return &models.FeedRunsResponse{Data: runs, Metadata: metadata}, nil
+}
+
+// run works through one slice of a feed run, from the record after the run's checkpoint. When the
+// slice is used up it hands the rest of the run over to a new job.
+func (s *FeedService) run(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload feedRunJob
+	if err := job.Decode(&payload); err != nil {
+		return nil, err
+	}
+	run, err := s.repo.FindByID(ctx, payload.RunID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: feed runId=%s", payload.RunID)
+	}
+	if run == nil {
+		return nil, jobs.Permanent(fmt.Errorf("feed run not found: runId=%s", payload.RunID))
+	}
+	if run.Status == models.FeedRunStatusCompleted || run.Status == models.FeedRunStatusFailed {
+		return nil, nil
+	}
+	transformer, ok := s.transformers[run.Provider]
+	if !ok {
+		s.fail(run, "feed provider is no longer registered")
+		return nil, jobs.Permanent(fmt.Errorf("feed provider not found: provider=%s", run.Provider))
+	}
+	if err := s.repo.SetStatus(ctx, run.ID, models.FeedRunStatusRunning, ""); err != nil {
+		return nil, utils.WrapError(err, "database update failed: feed runId=%s", payload.RunID)
+	}
+
+	if err := s.work(ctx, job, run, transformer); err != nil {
+		var invalid *invalidFeedError
+		if stderrors.As(err, &invalid) {
+			s.fail(run, err.Error())
+			return nil, jobs.Permanent(err)
+		}
+		logger.GlobalLogger.Warnf("Feed batch failed: provider=%s, runId=%s, processed=%d, attempt=%d, error=%v",
+			run.Provider, payload.RunID, run.Processed, job.Attempts, err)
+		if job.LastAttempt() {
+			s.fail(run, err.Error())
+		}
+		return nil, err
+	}
+	return nil, nil
+}
+
+// invalidFeedError reports a feed file that can't be read any further, such as malformed XML. Retrying
+// doesn't help, so the run fails.
+type invalidFeedError struct {
+	err error
+}
+
+func (e *invalidFeedError) Error() string { return "invalid feed: " + e.err.Error() }
+func (e *invalidFeedError) Unwrap() error { return e.err }
+
+// work applies the records of a run's file from its checkpoint, saving progress after every batch,
+// until the file ends or the slice is used up.
+func (s *FeedService) work(ctx context.Context, job *jobs.Job, run *models.FeedRun, transformer transformers.FeedTransformer) error {
+	file, err := s.store.Open(ctx, run.FileKey)
+	if err != nil {
+		return utils.WrapError(err, "open feed file failed: key=%s", run.FileKey)
+	}
+	defer file.Close()
+	source := &feedSource{reader: file}
+	reader, err := feeds.NewReader(source, run.Format)
+	if err != nil {
+		return s.readError(source, err)
+	}
+
+	// Records before the checkpoint were applied by earlier slices
+	for skipped := int64(0); skipped < run.Processed; skipped++ {
+		if _, err := reader.Next(); err != nil {
+			var rowErr *feeds.RowError
+			if stderrors.As(err, &rowErr) {
+				continue
+			}
+			if err == io.EOF {
+				err = fmt.Errorf("file ends before the checkpoint at record %d", run.Processed)
+			}
+			return s.readError(source, err)
+		}
+	}
+
+	ctx = WithAuditActor(tenant.WithOrgID(ctx, run.OrgID), run.UploadedBy, run.UploaderRole)
+	deadline := time.Now().Add(s.slice)
+	var batch models.FeedRunProgress
+	save := func() error {
+		if batch.Processed == 0 {
+			return nil
+		}
+		if err := s.repo.SaveProgress(ctx, run.ID, batch, s.maxRowErrors); err != nil {
+			return utils.WrapError(err, "database update failed: feed runId=%s", run.ID.Hex())
+		}
+		run.Processed += batch.Processed
+		batch = models.FeedRunProgress{}
+		return nil
+	}
+
+	for {
+		record, err := reader.Next()
+		var rowErr *feeds.RowError
+		switch {
+		case err == io.EOF:
+			if err := save(); err != nil {
+				return err
+			}
+			if err := s.repo.SetStatus(ctx, run.ID, models.FeedRunStatusCompleted, ""); err != nil {
+				return utils.WrapError(err, "database update failed: feed runId=%s", run.ID.Hex())
+			}
+			logger.GlobalLogger.Printf("Feed run completed: provider=%s, runId=%s, records=%d", run.Provider, run.ID.Hex(), run.Processed)
+			return nil
+		case stderrors.As(err, &rowErr):
+			batch.Processed++
+			batch.Errors = append(batch.Errors, models.FeedRowError{Row: rowErr.Row, Error: rowErr.Err.Error()})
+		case err != nil:
+			if saveErr := save(); saveErr != nil {
+				return saveErr
+			}
+			return s.readError(source, err)
+		default:
+			if err := s.apply(ctx, transformer, record, &batch); err != nil {
+				// The record is applied again by the retry
+				if saveErr := save(); saveErr != nil {
+					return saveErr
+				}
+				return err
+			}
+		}
+
+		if batch.Processed < int64(s.batchSize) {
+			continue
+		}
+		if err := save(); err != nil {
+			return err
+		}
+		if time.Now().After(deadline) {
+			next, err := s.jobs.Enqueue(ctx, JobFeedRun, &feedRunJob{RunID: run.ID.Hex()}, jobs.EnqueueOptions{CreatedBy: job.CreatedBy})
+			if err != nil {
+				return utils.WrapError(err, "queue feed run failed: runId=%s", run.ID.Hex())
+			}
+			if err := s.repo.SetJob(ctx, run.ID, next.ID); err != nil {
+				return utils.WrapError(err, "database update failed: feed runId=%s", run.ID.Hex())
+			}
+			logger.GlobalLogger.Printf("Feed run continues in new job: provider=%s, runId=%s, jobId=%s, processed=%d", run.Provider, run.ID.Hex(), next.ID, run.Processed)
+			return nil
+		}
+	}
+}
+
+// apply maps one record to a property and creates or updates it. Records the provider's data or the
+// property validation rejects become row errors; only failures that may go away on retry are returned.
+func (s *FeedService) apply(ctx context.Context, transformer transformers.FeedTransformer, record *feeds.Record, batch *models.FeedRunProgress) error {
+	property, err := transformer.TransformRecord(record.Fields)
+	if err != nil {
+		batch.Processed++
+		batch.Errors = append(batch.Errors, models.FeedRowError{Row: record.Row, Error: err.Error()})
+		return nil
+	}
+	propertyID := property.PropertyID
+	created, err := s.properties.ApplyFeedProperty(ctx, property)
+	switch {
+	case err == nil && created:
+		batch.Created++
+	case err == nil:
+		batch.Updated++
+	case utils.IsRetryableError(err) || ctx.Err() != nil:
+		return utils.WrapError(err, "apply feed record failed: row=%d, propertyId=%s", record.Row, propertyID)
+	default:
+		batch.Errors = append(batch.Errors, models.FeedRowError{Row: record.Row, PropertyID: propertyID, Error: errors.MapError(err).TechnicalMessage})
+	}
+	batch.Processed++
+	return nil
+}
+
+// readError classifies an error from reading the feed: a failure to fetch the file is retried, while
+// anything else means the file itself is unreadable.
+func (s *FeedService) readError(source *feedSource, err error) error {
+	if source.err != nil {
+		return utils.WrapError(source.err, "read feed file failed")
+	}
+	return &invalidFeedError{err: err}
+}
+
+// fail marks a run failed. Its file is kept, so the upload can be repeated once the problem is fixed.
+func (s *FeedService) fail(run *models.FeedRun, reason string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.repo.SetStatus(ctx, run.ID, models.FeedRunStatusFailed, reason); err != nil {
+		logger.GlobalLogger.Errorf("Failed to mark feed run failed: provider=%s, runId=%s, error=%v", run.Provider, run.ID.Hex(), err)
+		return
+	}
+	logger.GlobalLogger.Errorf("Feed run failed: provider=%s, runId=%s, error=%s", run.Provider, run.ID.Hex(), reason)
+}
+
+// feedSource remembers a failure to read the stored file, which the parsers report like bad content.
+type feedSource struct {
+	reader io.Reader
+	err    error
+}
+
+func (f *feedSource) Read(p []byte) (int, error) {
+	n, err := f.reader.Read(p)
+	if err != nil && err != io.EOF {
+		f.err = err
+	}
+	return n, err
+}
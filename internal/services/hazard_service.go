@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/hazard"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// HazardService adds flood zone and wildfire risk to properties fetched from the data provider. It
+// never fails the caller: with hazard enrichment off, no coordinates or the provider down, the
+// property is stored without them.
+type HazardService struct {
+	provider hazard.Provider
+}
+
+func NewHazardService(provider hazard.Provider) *HazardService {
+	return &HazardService{provider: provider}
+}
+
+// Enrich looks up the hazard risk at the property's parcel coordinates.
+func (s *HazardService) Enrich(ctx context.Context, property *models.Property) {
+	if s == nil || s.provider == nil {
+		return
+	}
+	parcel := property.Location.Coordinates.Parcel
+	if parcel.Lat == 0 && parcel.Lng == 0 {
+		return
+	}
+	name := s.provider.Name()
+	risk, err := s.provider.Lookup(ctx, parcel.Lat, parcel.Lng)
+	if err != nil {
+		if errors.Is(err, hazard.ErrNoData) {
+			metrics.HazardLookupsTotal.WithLabelValues(name, "no_data").Inc()
+			return
+		}
+		metrics.HazardLookupsTotal.WithLabelValues(name, "failed").Inc()
+		logger.GlobalLogger.WithContext(ctx).Warnf("Hazard lookup failed, storing property without it: provider=%s, propertyId=%s, error=%v", name, property.PropertyID, err)
+		return
+	}
+	metrics.HazardLookupsTotal.WithLabelValues(name, "found").Inc()
+
+	property.Hazard = &models.HazardRisk{
+		FloodZone:              risk.FloodZone,
+		FloodZoneSubtype:       risk.FloodZoneSubtype,
+		SpecialFloodHazardArea: risk.SpecialFloodHazardArea,
+		FloodRiskScore:         risk.FloodRiskScore,
+		WildfireRiskScore:      risk.WildfireRiskScore,
+		Source:                 name,
+		RetrievedAt:            time.Now().UTC(),
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/corelogic"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+)
+
+// healthCheck checks one dependency. It returns the component's status with optional detail; a
+// non-nil error is reported alongside the status.
+type healthCheck struct {
+	name     string
+	critical bool
+	check    func(ctx context.Context) (string, interface{}, error)
+}
+
+// HealthService checks the dependencies an instance needs to serve traffic, for readiness probes and
+// status pages. MongoDB and Redis are critical; CoreLogic and the job queue only degrade service,
+// since cached property data can still be served without them.
+type HealthService struct {
+	checks  []healthCheck
+	timeout time.Duration
+}
+
+func NewHealthService(corelogicClient *corelogic.Client, jobQueue *jobs.Queue, cfg *config.Config) *HealthService {
+	s := &HealthService{timeout: time.Duration(cfg.Health.CheckTimeoutSeconds) * time.Second}
+	s.checks = append(s.checks,
+		healthCheck{name: "mongodb", critical: true, check: checkMongo},
+		healthCheck{name: "redis", critical: true, check: checkRedis},
+	)
+	if cfg.CoreLogic.ClientKey != "" && !cfg.Sandbox.Enabled {
+		s.checks = append(s.checks, healthCheck{name: "corelogic", check: func(ctx context.Context) (string, interface{}, error) {
+			return checkCoreLogic(ctx, corelogicClient)
+		}})
+	}
+	s.checks = append(s.checks, healthCheck{name: "job_queue", check: func(ctx context.Context) (string, interface{}, error) {
+		return checkJobQueue(ctx, jobQueue, cfg.Health.MaxQueueDepth)
+	}})
+	return s
+}
+
+// Ready runs every check concurrently, each under its own timeout.
+func (s *HealthService) Ready(ctx context.Context) *models.HealthReport {
+	components := make([]models.ComponentHealth, len(s.checks))
+	var wg sync.WaitGroup
+	for i, hc := range s.checks {
+		wg.Add(1)
+		go func(i int, hc healthCheck) {
+			defer wg.Done()
+			components[i] = s.run(ctx, hc)
+		}(i, hc)
+	}
+	wg.Wait()
+
+	report := &models.HealthReport{Status: models.HealthOK, Components: components}
+	for _, component := range components {
+		switch {
+		case component.Status == models.HealthOK:
+		case component.Critical && component.Status == models.HealthDown:
+			report.Status = models.HealthDown
+		default:
+			report.Degraded = true
+			if report.Status == models.HealthOK {
+				report.Status = models.HealthDegraded
+			}
+		}
+	}
+	return report
+}
+
+func (s *HealthService) run(ctx context.Context, hc healthCheck) models.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	status, detail, err := hc.check(ctx)
+	component := models.ComponentHealth{
+		Name:      hc.name,
+		Status:    status,
+		Critical:  hc.critical,
+		LatencyMS: time.Since(start).Milliseconds(),
+		Detail:    detail,
+	}
+	if err != nil {
+		component.Error = err.Error()
+		logger.GlobalLogger.Warnf("Health check failed: component=%s, status=%s, error=%v", hc.name, status, err)
+	}
+	return component
+}
+
+func checkMongo(ctx context.Context) (string, interface{}, error) {
+	if err := database.MongoClient.Ping(ctx, nil); err != nil {
+		return models.HealthDown, nil, err
+	}
+	return models.HealthOK, nil, nil
+}
+
+func checkRedis(ctx context.Context) (string, interface{}, error) {
+	if err := cache.RedisClient.Ping(ctx).Err(); err != nil {
+		return models.HealthDown, nil, err
+	}
+	return models.HealthOK, nil, nil
+}
+
+// checkCoreLogic reports CoreLogic degraded while its circuit breaker is open or it rejects our
+// credentials. A valid cached token is reused, so this rarely calls CoreLogic.
+func checkCoreLogic(ctx context.Context, client *corelogic.Client) (string, interface{}, error) {
+	detail := map[string]string{"circuitBreaker": client.BreakerState()}
+	if err := client.CheckToken(ctx); err != nil {
+		return models.HealthDegraded, detail, err
+	}
+	if detail["circuitBreaker"] == "open" {
+		return models.HealthDegraded, detail, nil
+	}
+	return models.HealthOK, detail, nil
+}
+
+// checkJobQueue reports the queue degraded once more than maxDepth jobs are waiting for a worker.
+func checkJobQueue(ctx context.Context, jobQueue *jobs.Queue, maxDepth int64) (string, interface{}, error) {
+	depth, err := jobQueue.Depth(ctx)
+	if err != nil {
+		return models.HealthDegraded, nil, err
+	}
+	var queued int64
+	for _, n := range depth {
+		queued += n
+	}
+	detail := map[string]interface{}{"queued": queued, "byType": depth}
+	if queued > maxDepth {
+		return models.HealthDegraded, detail, fmt.Errorf("job backlog too long: queued=%d, max=%d", queued, maxDepth)
+	}
+	return models.HealthOK, detail, nil
+}
@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/jobs"
+)
+
+// JobService reports the status of background jobs to the users who started them.
+type JobService struct {
+	queue *jobs.Queue
+}
+
+func NewJobService(queue *jobs.Queue) *JobService {
+	return &JobService{queue: queue}
+}
+
+// GetJob returns a job started by the user. Admins may see any job, including those the service
+// queued itself; to anyone else, other users' jobs don't exist.
+func (s *JobService) GetJob(ctx context.Context, id, userID, role string) (*jobs.Job, error) {
+	job, err := s.queue.Get(ctx, id)
+	if err != nil {
+		return nil, utils.WrapError(err, "job lookup failed: jobId=%s", id)
+	}
+	if job == nil || (role != models.RoleAdmin && job.CreatedBy != userID) {
+		return nil, fmt.Errorf("job not found: jobId=%s", id)
+	}
+	return job, nil
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/internal/validators"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListingService manages for-sale listings of properties. Listings live in their own collection,
+// separate from the assessor record, and the latest one is cached per property.
+type ListingService struct {
+	repo            repositories.ListingRepository
+	cache           repositories.PropertyCache
+	properties      repositories.PropertyRepository
+	propertyService *PropertyService
+	validator       validators.ListingValidator
+	tx              repositories.Transactor
+}
+
+func NewListingService(repo repositories.ListingRepository, cache repositories.PropertyCache, properties repositories.PropertyRepository, propertyService *PropertyService, validator validators.ListingValidator, tx repositories.Transactor) *ListingService {
+	return &ListingService{
+		repo:            repo,
+		cache:           cache,
+		properties:      properties,
+		propertyService: propertyService,
+		validator:       validator,
+		tx:              tx,
+	}
+}
+
+func (s *ListingService) CreateListing(ctx context.Context, propertyID, userID string, req *models.ListingRequest) (*models.Listing, error) {
+	if err := s.ensureProperty(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	listing, err := s.newListing(propertyID, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, listing); err != nil {
+		return nil, utils.WrapError(err, "database insert failed: listing propertyId=%s", propertyID)
+	}
+	repositories.AfterCommit(ctx, func() {
+		s.invalidate(ctx, propertyID)
+	})
+	logger.GlobalLogger.Printf("Listing created: propertyId=%s, id=%s, status=%s", propertyID, listing.ID.Hex(), listing.Status)
+	return listing, nil
+}
+
+// CreatePropertyWithListing creates a property together with its first listing, taken from the
+// property's listing section. Neither is stored unless both are.
+func (s *ListingService) CreatePropertyWithListing(ctx context.Context, property *models.Property, userID string) (*models.Listing, error) {
+	req := listingRequestFrom(property.Listing)
+	property.Listing = nil
+	// Checked up front too, so a deployment without transactions doesn't keep a property whose
+	// listing is then rejected
+	if _, err := s.newListing(property.PropertyID, userID, req); err != nil {
+		return nil, err
+	}
+
+	var listing *models.Listing
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.propertyService.CreateProperty(ctx, property); err != nil {
+			return err
+		}
+		var err error
+		listing, err = s.CreateListing(ctx, property.PropertyID, userID, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	property.Listing = listing
+	return listing, nil
+}
+
+// newListing builds and validates a listing of a property from a request.
+func (s *ListingService) newListing(propertyID, userID string, req *models.ListingRequest) (*models.Listing, error) {
+	now := time.Now().UTC()
+	listing := &models.Listing{
+		ID:         primitive.NewObjectID(),
+		PropertyID: propertyID,
+		ListedAt:   now,
+		CreatedBy:  userID,
+		CreatedAt:  now,
+	}
+	applyListingRequest(listing, req, now)
+	if err := s.validator.ValidateListing(listing); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// ListListings returns every listing of a property, newest first.
+func (s *ListingService) ListListings(ctx context.Context, propertyID string) ([]models.Listing, error) {
+	if err := s.ensureProperty(ctx, propertyID); err != nil {
+		return nil, err
+	}
+	listings, err := s.repo.FindByPropertyID(ctx, propertyID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: listings propertyId=%s", propertyID)
+	}
+	return listings, nil
+}
+
+func (s *ListingService) GetListing(ctx context.Context, propertyID, id string) (*models.Listing, error) {
+	listing, err := s.repo.FindByID(ctx, propertyID, id)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: listing id=%s", id)
+	}
+	if listing == nil {
+		return nil, fmt.Errorf("listing not found: propertyId=%s, id=%s", propertyID, id)
+	}
+	return listing, nil
+}
+
+// UpdateListing replaces the editable fields of a listing. Marking it sold records when it sold.
+func (s *ListingService) UpdateListing(ctx context.Context, propertyID, id string, req *models.ListingRequest) (*models.Listing, error) {
+	listing, err := s.GetListing(ctx, propertyID, id)
+	if err != nil {
+		return nil, err
+	}
+	applyListingRequest(listing, req, time.Now().UTC())
+	if err := s.validator.ValidateListing(listing); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, listing); err != nil {
+		return nil, utils.WrapError(err, "database update failed: listing id=%s", id)
+	}
+	repositories.AfterCommit(ctx, func() {
+		s.invalidate(ctx, propertyID)
+	})
+	return listing, nil
+}
+
+func (s *ListingService) DeleteListing(ctx context.Context, propertyID, id string) error {
+	deleted, err := s.repo.Delete(ctx, propertyID, id)
+	if err != nil {
+		return utils.WrapError(err, "database delete failed: listing id=%s", id)
+	}
+	if !deleted {
+		return fmt.Errorf("listing not found: propertyId=%s, id=%s", propertyID, id)
+	}
+	s.invalidate(ctx, propertyID)
+	return nil
+}
+
+// AttachListing fills in a property's latest listing. Failures are logged rather than failing the
+// read of the property.
+func (s *ListingService) AttachListing(ctx context.Context, property *models.Property) {
+	key := cache.ListingKey(property.OrgID, property.PropertyID)
+	if listing, err := s.cache.GetListing(ctx, key); err == nil && listing != nil {
+		property.Listing = listing
+		return
+	}
+	listing, err := s.repo.FindLatest(ctx, property.PropertyID)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to load property listing: propertyId=%s, error=%v", property.PropertyID, err)
+		return
+	}
+	if listing == nil {
+		return
+	}
+	if err := s.cache.SetListing(ctx, key, listing, s.cache.TTL(cache.ClassProperty)); err != nil {
+		logger.GlobalLogger.Errorf("Failed to cache listing: propertyId=%s, error=%v", property.PropertyID, err)
+	}
+	property.Listing = listing
+}
+
+func (s *ListingService) ensureProperty(ctx context.Context, propertyID string) error {
+	property, err := s.properties.FindByID(ctx, propertyID)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: id=%s", propertyID)
+	}
+	if property == nil {
+		return fmt.Errorf("property not found: id=%s", propertyID)
+	}
+	return nil
+}
+
+func (s *ListingService) invalidate(ctx context.Context, propertyID string) {
+	if err := s.cache.Delete(ctx, cache.ListingKey(tenant.OrgID(ctx), propertyID)); err != nil {
+		logger.GlobalLogger.Errorf("Failed to invalidate listing cache: propertyId=%s, error=%v", propertyID, err)
+	}
+}
+
+func applyListingRequest(listing *models.Listing, req *models.ListingRequest, now time.Time) {
+	wasSold := listing.Status == models.ListingStatusSold
+	listing.ListPrice = req.ListPrice
+	listing.Status = strings.ToLower(strings.TrimSpace(req.Status))
+	listing.Description = strings.TrimSpace(req.Description)
+	listing.Agent = req.Agent
+	listing.SoldPrice = req.SoldPrice
+	if req.ListedAt != nil {
+		listing.ListedAt = req.ListedAt.UTC()
+	}
+	switch {
+	case listing.Status != models.ListingStatusSold:
+		listing.SoldAt = nil
+	case !wasSold:
+		listing.SoldAt = &now
+	}
+	listing.UpdatedAt = now
+}
+
+// listingRequestFrom reads a listing sent along with a new property as a listing request.
+func listingRequestFrom(listing *models.Listing) *models.ListingRequest {
+	req := &models.ListingRequest{
+		ListPrice:   listing.ListPrice,
+		Status:      listing.Status,
+		Description: listing.Description,
+		Agent:       listing.Agent,
+		SoldPrice:   listing.SoldPrice,
+	}
+	if !listing.ListedAt.IsZero() {
+		listedAt := listing.ListedAt
+		req.ListedAt = &listedAt
+	}
+	return req
+}
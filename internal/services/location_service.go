@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+)
+
+var statePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// LocationService serves the state, city and zip code hierarchy of an organization's properties for
+// drill-down browsing. Each level aggregates every property under it, so levels are cached for hours
+// and new locations show up once the cached level expires.
+type LocationService struct {
+	repo   repositories.LocationRepository
+	config *config.Config
+}
+
+func NewLocationService(repo repositories.LocationRepository, cfg *config.Config) *LocationService {
+	return &LocationService{
+		repo:   repo,
+		config: cfg,
+	}
+}
+
+// States returns the states with properties and how many each has.
+func (s *LocationService) States(ctx context.Context) ([]models.LocationCount, error) {
+	return s.cached(ctx, cache.LocationStatesKey(tenant.OrgID(ctx)), func() ([]models.LocationCount, error) {
+		return s.repo.States(ctx)
+	})
+}
+
+// Cities returns the cities of a state with properties and how many each has.
+func (s *LocationService) Cities(ctx context.Context, state string) ([]models.LocationCount, error) {
+	state, err := normalizeState(state)
+	if err != nil {
+		return nil, err
+	}
+	return s.cached(ctx, cache.LocationCitiesKey(tenant.OrgID(ctx), state), func() ([]models.LocationCount, error) {
+		return s.repo.Cities(ctx, state)
+	})
+}
+
+// Zips returns the zip codes of a city with properties and how many each has.
+func (s *LocationService) Zips(ctx context.Context, state, city string) ([]models.LocationCount, error) {
+	state, err := normalizeState(state)
+	if err != nil {
+		return nil, err
+	}
+	city = strings.ToUpper(strings.Join(strings.Fields(city), " "))
+	if city == "" || len(city) > 100 {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("invalid city: %q", city),
+			"City must be between 1 and 100 characters",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	return s.cached(ctx, cache.LocationZipsKey(tenant.OrgID(ctx), state, city), func() ([]models.LocationCount, error) {
+		return s.repo.Zips(ctx, state, city)
+	})
+}
+
+// cached returns a level of the hierarchy from the cache, aggregating and caching it on a miss.
+func (s *LocationService) cached(ctx context.Context, key string, load func() ([]models.LocationCount, error)) ([]models.LocationCount, error) {
+	if data, err := cache.GetLocations(ctx, key); err != nil {
+		logger.GlobalLogger.Warnf("Failed to read cached locations: key=%s, error=%v", key, err)
+	} else if data != nil {
+		var locations []models.LocationCount
+		if err := json.Unmarshal(data, &locations); err == nil {
+			return locations, nil
+		}
+	}
+
+	locations, err := load()
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: locations key=%s", key)
+	}
+	// Empty levels aren't cached, so probing arbitrary cities can't fill the cache
+	if len(locations) == 0 {
+		return locations, nil
+	}
+	data, err := json.Marshal(locations)
+	if err != nil {
+		return locations, nil
+	}
+	ttl := time.Duration(s.config.Live().Locations.CacheTTLHours) * time.Hour
+	if err := cache.SetLocations(ctx, key, data, ttl); err != nil {
+		logger.GlobalLogger.Errorf("Failed to cache locations: key=%s, error=%v", key, err)
+	}
+	return locations, nil
+}
+
+// normalizeState upper-cases a state code, which properties store as a 2-letter USPS code.
+func normalizeState(state string) (string, error) {
+	state = strings.ToUpper(strings.TrimSpace(state))
+	if !statePattern.MatchString(state) {
+		return "", errors.NewAppError(
+			fmt.Sprintf("invalid state: %q", state),
+			"State must be a 2-letter code",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	return state, nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+	"homeinsight-properties/pkg/notifications"
+)
+
+// lockoutHistory is how long a lockout counts towards lengthening the next one.
+const lockoutHistory = 24 * time.Hour
+
+// LoginLockedError is returned by Login while sign-in is locked for the email or client IP.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("login temporarily locked: retry_after=%s", e.RetryAfter.Round(time.Second))
+}
+
+// loginSubjects names the email and client IP a sign-in is protected by.
+func loginSubjects(email, ip string) (emailSubject, ipSubject string) {
+	return "email:" + strings.ToLower(strings.TrimSpace(email)), "ip:" + ip
+}
+
+// checkLoginLock refuses a sign-in while its email or client IP is locked. Redis being unavailable
+// doesn't stop sign-ins.
+func (s *UserService) checkLoginLock(ctx context.Context, email, ip string) error {
+	emailSubject, ipSubject := loginSubjects(email, ip)
+	remaining, err := cache.LoginLockRemaining(ctx, emailSubject, ipSubject)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Login lock not checked: error=%v", err)
+		return nil
+	}
+	if remaining > 0 {
+		return &LoginLockedError{RetryAfter: remaining}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a wrong password, or an unknown email, against the email and client IP,
+// locking whichever reaches its limit. user is nil for unknown emails.
+func (s *UserService) recordLoginFailure(ctx context.Context, email string, user *models.User, ip string) {
+	metrics.LoginFailuresTotal.Inc()
+	userID := ""
+	if user != nil {
+		userID = user.ID.Hex()
+	}
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:    models.AuditEventLoginFailed,
+		ActorID: userID,
+		IP:      ip,
+		Details: map[string]interface{}{"email": strings.ToLower(strings.TrimSpace(email))},
+	})
+
+	cfg := s.cfg.Live().LoginProtection
+	window := time.Duration(cfg.WindowMinutes) * time.Minute
+	emailSubject, ipSubject := loginSubjects(email, ip)
+
+	for _, limit := range []struct {
+		scope, subject string
+		max            int
+	}{{"email", emailSubject, cfg.MaxFailures}, {"ip", ipSubject, cfg.IPMaxFailures}} {
+		if limit.max <= 0 {
+			continue
+		}
+		failures, err := cache.RecordLoginFailure(ctx, limit.subject, window)
+		if err != nil {
+			logger.GlobalLogger.Warnf("Login failure not recorded: scope=%s, error=%v", limit.scope, err)
+			continue
+		}
+		if failures < int64(limit.max) {
+			continue
+		}
+
+		lockout, err := cache.LockLogin(ctx, limit.subject,
+			time.Duration(cfg.LockoutSeconds)*time.Second, time.Duration(cfg.MaxLockoutMinutes)*time.Minute, lockoutHistory)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to lock login: scope=%s, error=%v", limit.scope, err)
+			continue
+		}
+		metrics.LoginLockoutsTotal.WithLabelValues(limit.scope).Inc()
+		logger.GlobalLogger.Warnf("Login locked: scope=%s, user_id=%s, ip=%s, failures=%d, lockout=%s",
+			limit.scope, userID, ip, failures, lockout)
+		s.audit.Record(ctx, models.AuditEvent{
+			Type:    models.AuditEventLoginLocked,
+			ActorID: userID,
+			IP:      ip,
+			Details: map[string]interface{}{"scope": limit.scope, "failures": failures, "lockoutSeconds": int64(lockout / time.Second)},
+		})
+		if limit.scope == "email" && user != nil && cfg.NotifyUser {
+			s.notifyAccountLocked(user, lockout)
+		}
+	}
+}
+
+// clearLoginFailures forgets an email's failed sign-ins once the password is entered correctly. The
+// client IP's failures are kept, since one IP may be trying many emails.
+func (s *UserService) clearLoginFailures(ctx context.Context, email string) {
+	emailSubject, _ := loginSubjects(email, "")
+	if err := cache.ClearLoginFailures(ctx, emailSubject); err != nil {
+		logger.GlobalLogger.Warnf("Failed to clear login failures: error=%v", err)
+	}
+}
+
+// notifyAccountLocked emails the user that password sign-in is locked, in the background.
+func (s *UserService) notifyAccountLocked(user *models.User, lockout time.Duration) {
+	data := struct{ Minutes int }{Minutes: int(math.Ceil(lockout.Minutes()))}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), passwordResetSendTimeout)
+		defer cancel()
+		if err := s.notifications.Email(ctx, user.Email, notifications.TemplateAccountLocked, data); err != nil {
+			logger.GlobalLogger.Errorf("Failed to send account locked email: user_id=%s, error=%v", user.ID.Hex(), err)
+		}
+	}()
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+)
+
+var zipCodePattern = regexp.MustCompile(`^[0-9]{5}$`)
+
+// MarketStatsService serves zip code market statistics. Aggregating a zip scans all its properties,
+// so results are cached and recomputed nightly rather than on every request.
+type MarketStatsService struct {
+	repo   repositories.MarketStatsRepository
+	config *config.Config
+}
+
+func NewMarketStatsService(repo repositories.MarketStatsRepository, cfg *config.Config) *MarketStatsService {
+	return &MarketStatsService{
+		repo:   repo,
+		config: cfg,
+	}
+}
+
+// GetZipStats returns the market statistics of a zip code, from the cache when available.
+func (s *MarketStatsService) GetZipStats(ctx context.Context, zipCode string) (*models.MarketStats, error) {
+	if !zipCodePattern.MatchString(zipCode) {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("invalid zip code: %s", zipCode),
+			"Zip code must be 5 digits",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	if data, err := cache.GetMarketStats(ctx, zipCode); err != nil {
+		logger.GlobalLogger.Warnf("Failed to read cached market stats: zipCode=%s, error=%v", zipCode, err)
+	} else if data != nil {
+		var stats models.MarketStats
+		if err := json.Unmarshal(data, &stats); err == nil {
+			return &stats, nil
+		}
+	}
+	return s.refresh(ctx, zipCode)
+}
+
+// RefreshAll recomputes the statistics of every zip code requested since the last cache flush.
+func (s *MarketStatsService) RefreshAll(ctx context.Context) error {
+	_, err := s.RefreshZips(ctx)
+	return err
+}
+
+// RefreshZips is RefreshAll, returning how many zip codes were refreshed.
+func (s *MarketStatsService) RefreshZips(ctx context.Context) (int, error) {
+	zips, err := cache.MarketStatsZips(ctx)
+	if err != nil {
+		return 0, err
+	}
+	failed := 0
+	for i, zipCode := range zips {
+		if ctx.Err() != nil {
+			return i - failed, ctx.Err()
+		}
+		if _, err := s.refresh(ctx, zipCode); err != nil {
+			logger.GlobalLogger.Errorf("Failed to refresh market stats: zipCode=%s, error=%v", zipCode, err)
+			failed++
+		}
+	}
+	logger.GlobalLogger.Printf("Market stats refreshed: zips=%d, failed=%d", len(zips)-failed, failed)
+	return len(zips) - failed, nil
+}
+
+func (s *MarketStatsService) refresh(ctx context.Context, zipCode string) (*models.MarketStats, error) {
+	stats, err := s.repo.ComputeZipStats(ctx, zipCode)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: market stats zipCode=%s", zipCode)
+	}
+	// Zips without properties aren't cached, so probing arbitrary zips can't grow the nightly refresh
+	if stats.PropertyCount == 0 {
+		return stats, nil
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return stats, nil
+	}
+	ttl := time.Duration(s.config.Live().Markets.StatsCacheTTLHours) * time.Hour
+	if err := cache.SetMarketStats(ctx, zipCode, data, ttl); err != nil {
+		logger.GlobalLogger.Errorf("Failed to cache market stats: zipCode=%s, error=%v", zipCode, err)
+	}
+	return stats, nil
+}
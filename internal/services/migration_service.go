@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JobMigrationRun is the background job type working through a data migration run.
+const JobMigrationRun = "migration.run"
+
+// maxMigrationRuns bounds the run history returned for a migration.
+const maxMigrationRuns = 20
+
+const (
+	migrationStartLockPrefix = "migration-start:"
+	migrationRunLockPrefix   = "migration-run:"
+	migrationLockTTL         = time.Minute
+)
+
+func migrationRunLockName(runID primitive.ObjectID) string {
+	return migrationRunLockPrefix + runID.Hex()
+}
+
+// Migration is a data migration admins can launch by name. Step migrates the batch after checkpoint
+// (empty for the first batch) and reports where the next batch starts. A batch interrupted by a crash
+// is migrated again, so steps must be safe to repeat.
+type Migration struct {
+	Name        string
+	Description string
+	Count       func(ctx context.Context) (int64, error)
+	Step        func(ctx context.Context, checkpoint string, batchSize int) (*MigrationStep, error)
+}
+
+// MigrationStep is the outcome of one batch. Failures describe documents that couldn't be migrated
+// and were skipped; an error returned by Step instead fails the batch, which is retried.
+type MigrationStep struct {
+	Checkpoint string
+	Processed  int64
+	Failures   []string
+	Done       bool
+}
+
+type migrationJob struct {
+	RunID string `json:"runId"`
+}
+
+// MigrationService launches registered data migrations in the background and records their progress
+// in the migrations collection. A run works in slices, each a job of its own that checkpoints after
+// every batch, so a run outlives job timeouts and picks up where it stopped after a crash.
+type MigrationService struct {
+	repo       repositories.MigrationRepository
+	jobs       *jobs.Queue
+	migrations map[string]*Migration
+	names      []string
+	batchSize  int
+	slice      time.Duration
+}
+
+func NewMigrationService(repo repositories.MigrationRepository, jobQueue *jobs.Queue, cfg *config.Config) *MigrationService {
+	s := &MigrationService{
+		repo:       repo,
+		jobs:       jobQueue,
+		migrations: make(map[string]*Migration),
+		batchSize:  cfg.Migrations.BatchSize,
+		slice:      time.Duration(cfg.Migrations.SliceSeconds) * time.Second,
+	}
+	jobQueue.Register(JobMigrationRun, s.run, jobs.Options{Workers: 1, Timeout: s.slice + time.Minute})
+	return s
+}
+
+// Add registers a migration so admins can launch it.
+func (s *MigrationService) Add(m Migration) {
+	if _, ok := s.migrations[m.Name]; !ok {
+		s.names = append(s.names, m.Name)
+	}
+	s.migrations[m.Name] = &m
+}
+
+// List returns the registered migrations with their latest runs.
+func (s *MigrationService) List(ctx context.Context) ([]models.MigrationInfo, error) {
+	infos := make([]models.MigrationInfo, 0, len(s.names))
+	for _, name := range s.names {
+		latest, err := s.latest(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, models.MigrationInfo{Name: name, Description: s.migrations[name].Description, LatestRun: latest})
+	}
+	return infos, nil
+}
+
+// Runs returns the latest runs of a migration, newest first.
+func (s *MigrationService) Runs(ctx context.Context, name string) ([]models.MigrationRun, error) {
+	if _, ok := s.migrations[name]; !ok {
+		return nil, fmt.Errorf("migration not found: name=%s", name)
+	}
+	runs, err := s.repo.FindByName(ctx, name, maxMigrationRuns)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: migrations name=%s", name)
+	}
+	return runs, nil
+}
+
+// Start launches a migration. A failed or abandoned run is resumed from its checkpoint; otherwise a new
+// run starts from the beginning. Only one run of a migration is in progress at a time.
+func (s *MigrationService) Start(ctx context.Context, name, userID string) (*models.MigrationRun, error) {
+	m, ok := s.migrations[name]
+	if !ok {
+		return nil, fmt.Errorf("migration not found: name=%s", name)
+	}
+	// Launches from two instances at once would both find no run in progress
+	var run *models.MigrationRun
+	err := cache.WithLock(ctx, migrationStartLockPrefix+name, migrationLockTTL, func(ctx context.Context, _ *cache.Lock) error {
+		var err error
+		run, err = s.start(ctx, name, m, userID)
+		return err
+	})
+	if err == cache.ErrLockHeld {
+		return nil, fmt.Errorf("migration already running: name=%s", name)
+	}
+	return run, err
+}
+
+func (s *MigrationService) start(ctx context.Context, name string, m *Migration, userID string) (*models.MigrationRun, error) {
+	run, err := s.latest(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case run != nil && (run.Status == models.MigrationStatusQueued || run.Status == models.MigrationStatusRunning):
+		if !s.abandoned(ctx, run) {
+			return nil, fmt.Errorf("migration already running: name=%s, runId=%s", name, run.ID.Hex())
+		}
+		logger.GlobalLogger.Warnf("Resuming abandoned migration run: name=%s, runId=%s, processed=%d", name, run.ID.Hex(), run.Processed)
+	case run != nil && run.Status == models.MigrationStatusFailed:
+		logger.GlobalLogger.Printf("Resuming failed migration run: name=%s, runId=%s, processed=%d", name, run.ID.Hex(), run.Processed)
+	default:
+		// Migrations span every organization, whichever one the launching admin is in
+		total, err := m.Count(tenant.WithOrgID(ctx, ""))
+		if err != nil {
+			return nil, utils.WrapError(err, "count documents for migration failed: name=%s", name)
+		}
+		now := time.Now().UTC()
+		run = &models.MigrationRun{
+			ID:        primitive.NewObjectID(),
+			Name:      name,
+			Status:    models.MigrationStatusQueued,
+			Total:     total,
+			StartedBy: userID,
+			StartedAt: now,
+			UpdatedAt: now,
+		}
+		if err := s.repo.Create(ctx, run); err != nil {
+			return nil, utils.WrapError(err, "database insert failed: migration name=%s", name)
+		}
+	}
+
+	if err := s.repo.SetStatus(ctx, run.ID, models.MigrationStatusQueued, ""); err != nil {
+		return nil, utils.WrapError(err, "database update failed: migration runId=%s", run.ID.Hex())
+	}
+	job, err := s.enqueue(ctx, run.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	logger.GlobalLogger.Printf("Migration started: name=%s, runId=%s, jobId=%s, by=%s", name, run.ID.Hex(), job.ID, userID)
+
+	run.Status = models.MigrationStatusQueued
+	run.JobID = job.ID
+	run.Error = ""
+	run.FinishedAt = nil
+	return run, nil
+}
+
+func (s *MigrationService) latest(ctx context.Context, name string) (*models.MigrationRun, error) {
+	runs, err := s.repo.FindByName(ctx, name, 1)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: migrations name=%s", name)
+	}
+	if len(runs) == 0 {
+		return nil, nil
+	}
+	return &runs[0], nil
+}
+
+// abandoned reports whether an in-progress run has no job left working on it, as happens when the
+// job gave up on its last attempt while the instance running it crashed.
+func (s *MigrationService) abandoned(ctx context.Context, run *models.MigrationRun) bool {
+	if run.JobID == "" {
+		return time.Since(run.UpdatedAt) > s.slice+time.Minute
+	}
+	job, err := s.jobs.Get(ctx, run.JobID)
+	if err != nil {
+		return false
+	}
+	return job == nil || job.Status == jobs.StatusSucceeded || job.Status == jobs.StatusFailed
+}
+
+func (s *MigrationService) enqueue(ctx context.Context, runID primitive.ObjectID, userID string) (*jobs.Job, error) {
+	job, err := s.jobs.Enqueue(ctx, JobMigrationRun, &migrationJob{RunID: runID.Hex()}, jobs.EnqueueOptions{CreatedBy: userID})
+	if err != nil {
+		return nil, utils.WrapError(err, "queue migration failed: runId=%s", runID.Hex())
+	}
+	if err := s.repo.SetJob(ctx, runID, job.ID); err != nil {
+		return nil, utils.WrapError(err, "database update failed: migration runId=%s", runID.Hex())
+	}
+	return job, nil
+}
+
+// run works through one slice of a migration run, batch by batch from the run's checkpoint. When the
+// slice is used up it hands the rest of the run over to a new job.
+func (s *MigrationService) run(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload migrationJob
+	if err := job.Decode(&payload); err != nil {
+		return nil, jobs.Permanent(err)
+	}
+	run, err := s.repo.FindByID(ctx, payload.RunID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: migration runId=%s", payload.RunID)
+	}
+	if run == nil {
+		return nil, jobs.Permanent(fmt.Errorf("migration run not found: runId=%s", payload.RunID))
+	}
+	if run.Status == models.MigrationStatusCompleted {
+		return nil, nil
+	}
+	m, ok := s.migrations[run.Name]
+	if !ok {
+		s.fail(run, "migration is no longer registered")
+		return nil, jobs.Permanent(fmt.Errorf("migration not found: name=%s", run.Name))
+	}
+
+	// A job retried while an earlier attempt is still working, on an instance that stalled past the job
+	// timeout, waits for it; one that stalled past the lock is fenced off the run's progress
+	err = cache.WithLock(ctx, migrationRunLockName(run.ID), migrationLockTTL, func(ctx context.Context, lock *cache.Lock) error {
+		return s.runSlice(ctx, job, run, m, lock.Fence())
+	})
+	if err == cache.ErrLockHeld {
+		return nil, fmt.Errorf("migration run is being worked on by another job: runId=%s", payload.RunID)
+	}
+	return nil, err
+}
+
+func (s *MigrationService) runSlice(ctx context.Context, job *jobs.Job, run *models.MigrationRun, m *Migration, fence int64) error {
+	runID := run.ID.Hex()
+	if err := s.repo.SetStatus(ctx, run.ID, models.MigrationStatusRunning, ""); err != nil {
+		return utils.WrapError(err, "database update failed: migration runId=%s", runID)
+	}
+
+	deadline := time.Now().Add(s.slice)
+	checkpoint := run.Checkpoint
+	for time.Now().Before(deadline) {
+		step, err := m.Step(ctx, checkpoint, s.batchSize)
+		if err != nil {
+			logger.GlobalLogger.Warnf("Migration batch failed: name=%s, runId=%s, checkpoint=%s, attempt=%d, error=%v",
+				run.Name, runID, checkpoint, job.Attempts, err)
+			if job.LastAttempt() {
+				s.fail(run, err.Error())
+			}
+			return err
+		}
+		err = s.repo.SaveProgress(ctx, run.ID, fence, step.Checkpoint, step.Processed, step.Failures)
+		if err == repositories.ErrStaleFence {
+			logger.GlobalLogger.Warnf("Migration run taken over by another job: name=%s, runId=%s, jobId=%s", run.Name, runID, job.ID)
+			return jobs.Permanent(err)
+		}
+		if err != nil {
+			return utils.WrapError(err, "database update failed: migration runId=%s", runID)
+		}
+		checkpoint = step.Checkpoint
+		if step.Done {
+			if err := s.repo.SetStatus(ctx, run.ID, models.MigrationStatusCompleted, ""); err != nil {
+				return utils.WrapError(err, "database update failed: migration runId=%s", runID)
+			}
+			logger.GlobalLogger.Printf("Migration completed: name=%s, runId=%s", run.Name, runID)
+			return nil
+		}
+	}
+
+	next, err := s.enqueue(ctx, run.ID, job.CreatedBy)
+	if err != nil {
+		return err
+	}
+	logger.GlobalLogger.Printf("Migration continues in new job: name=%s, runId=%s, jobId=%s", run.Name, runID, next.ID)
+	return nil
+}
+
+// fail marks a run failed so it can be resumed by launching the migration again.
+func (s *MigrationService) fail(run *models.MigrationRun, reason string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.repo.SetStatus(ctx, run.ID, models.MigrationStatusFailed, reason); err != nil {
+		logger.GlobalLogger.Errorf("Failed to mark migration run failed: name=%s, runId=%s, error=%v", run.Name, run.ID.Hex(), err)
+		return
+	}
+	logger.GlobalLogger.Errorf("Migration failed: name=%s, runId=%s, error=%s", run.Name, run.ID.Hex(), reason)
+}
+
+// UppercaseAddressesMigration rewrites stored address components in the normalized form address
+// searches look them up by (uppercase, without surrounding spaces). It covers every organization and
+// properties in the trash.
+func UppercaseAddressesMigration(repo repositories.PropertyRepository, addrTrans transformers.AddressTransformer) Migration {
+	return Migration{
+		Name:        "uppercase-addresses",
+		Description: "Normalize stored street, city, state and zip code to the uppercase form address searches use.",
+		Count:       repo.EstimatedCount,
+		Step: func(ctx context.Context, checkpoint string, batchSize int) (*MigrationStep, error) {
+			var afterID primitive.ObjectID
+			if checkpoint != "" {
+				id, err := primitive.ObjectIDFromHex(checkpoint)
+				if err != nil {
+					return nil, jobs.Permanent(fmt.Errorf("invalid migration checkpoint: %s", checkpoint))
+				}
+				afterID = id
+			}
+			properties, err := repo.FindAddressesAfter(ctx, afterID, batchSize)
+			if err != nil {
+				return nil, utils.WrapError(err, "database query failed: properties after id=%s", checkpoint)
+			}
+
+			step := &MigrationStep{Checkpoint: checkpoint, Done: len(properties) < batchSize}
+			for _, property := range properties {
+				step.Checkpoint = property.ID.Hex()
+				step.Processed++
+				address := models.Address{
+					StreetAddress: addrTrans.NormalizeAddressComponent(property.Address.StreetAddress),
+					City:          addrTrans.NormalizeAddressComponent(property.Address.City),
+					State:         addrTrans.NormalizeAddressComponent(property.Address.State),
+					ZipCode:       addrTrans.NormalizeAddressComponent(property.Address.ZipCode),
+				}
+				if address.StreetAddress == property.Address.StreetAddress && address.City == property.Address.City &&
+					address.State == property.Address.State && address.ZipCode == property.Address.ZipCode {
+					continue
+				}
+				if err := repo.UpdateAddress(ctx, property.ID, address); err != nil {
+					if utils.IsRetryableError(err) || ctx.Err() != nil {
+						return nil, utils.WrapError(err, "database update failed: propertyId=%s", property.PropertyID)
+					}
+					step.Failures = append(step.Failures, fmt.Sprintf("propertyId=%s: %v", property.PropertyID, err))
+				}
+			}
+			return step, nil
+		},
+	}
+}
+
+// ReindexOwnersMigration links every property to its owner entities again, storing owner names sealed
+// and keyed by the current blind index. Entities built before owner names were encrypted are dropped
+// once none of their properties link to them any more.
+func ReindexOwnersMigration(repo repositories.PropertyRepository, owners *OwnerService) Migration {
+	return Migration{
+		Name:        "reindex-owners",
+		Description: "Rebuild the owner entity index with sealed owner names and blind index lookups.",
+		Count:       repo.EstimatedCount,
+		Step: func(ctx context.Context, checkpoint string, batchSize int) (*MigrationStep, error) {
+			var afterID primitive.ObjectID
+			if checkpoint != "" {
+				id, err := primitive.ObjectIDFromHex(checkpoint)
+				if err != nil {
+					return nil, jobs.Permanent(fmt.Errorf("invalid migration checkpoint: %s", checkpoint))
+				}
+				afterID = id
+			}
+			fields := models.PropertyFields{"orgId", "ownership"}
+			properties, err := repo.FindMatchingAfter(ctx, &models.PropertyFilter{}, fields, afterID, batchSize)
+			if err != nil {
+				return nil, utils.WrapError(err, "database query failed: properties after id=%s", checkpoint)
+			}
+
+			step := &MigrationStep{Checkpoint: checkpoint, Done: len(properties) < batchSize}
+			for i := range properties {
+				property := &properties[i]
+				step.Checkpoint = property.ID.Hex()
+				step.Processed++
+				if err := owners.IndexProperty(tenant.WithOrgID(ctx, property.OrgID), property); err != nil {
+					if utils.IsRetryableError(err) || ctx.Err() != nil {
+						return nil, err
+					}
+					step.Failures = append(step.Failures, fmt.Sprintf("propertyId=%s: %v", property.PropertyID, err))
+				}
+			}
+			return step, nil
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+	"homeinsight-properties/pkg/neighborhood"
+)
+
+// NeighborhoodService adds points of interest and a walk score to properties fetched from the data
+// provider. It never fails the caller: with neighborhood enrichment off, no coordinates or the
+// provider down, the property is stored without them.
+type NeighborhoodService struct {
+	walkability neighborhood.Provider
+}
+
+func NewNeighborhoodService(walkability neighborhood.Provider) *NeighborhoodService {
+	return &NeighborhoodService{walkability: walkability}
+}
+
+// Enrich looks up the walkability of the property's parcel coordinates.
+func (s *NeighborhoodService) Enrich(ctx context.Context, property *models.Property) {
+	if s == nil || s.walkability == nil {
+		return
+	}
+	parcel := property.Location.Coordinates.Parcel
+	if parcel.Lat == 0 && parcel.Lng == 0 {
+		return
+	}
+	name := s.walkability.Name()
+	walkability, err := s.walkability.Lookup(ctx, parcel.Lat, parcel.Lng)
+	if err != nil {
+		metrics.WalkabilityLookupsTotal.WithLabelValues(name, "failed").Inc()
+		logger.GlobalLogger.WithContext(ctx).Warnf("Walkability lookup failed, storing property without it: provider=%s, propertyId=%s, error=%v", name, property.PropertyID, err)
+		return
+	}
+	metrics.WalkabilityLookupsTotal.WithLabelValues(name, "found").Inc()
+
+	if property.Neighborhood == nil {
+		property.Neighborhood = &models.Neighborhood{}
+	}
+	property.Neighborhood.Walkability = &models.Walkability{
+		WalkScore:        walkability.WalkScore,
+		Description:      neighborhood.Describe(walkability.WalkScore),
+		RadiusMeters:     walkability.RadiusMeters,
+		PointsOfInterest: models.PointsOfInterest(walkability.PointsOfInterest),
+		Source:           name,
+		RetrievedAt:      time.Now().UTC(),
+	}
+}
@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/notifications"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Notifier delivers a digest of property-change alerts to a user.
+type Notifier interface {
+	SendDigest(ctx context.Context, digest *models.NotificationDigest) error
+}
+
+// EmailNotifier emails digests to the address on the user's account.
+type EmailNotifier struct {
+	users  repositories.UserRepository
+	sender *notifications.Sender
+}
+
+func NewEmailNotifier(users repositories.UserRepository, sender *notifications.Sender) *EmailNotifier {
+	return &EmailNotifier{users: users, sender: sender}
+}
+
+func (n *EmailNotifier) SendDigest(ctx context.Context, digest *models.NotificationDigest) error {
+	user, err := n.users.FindByID(ctx, digest.UserID)
+	if err == mongo.ErrNoDocuments {
+		// The account is gone; drop the digest rather than retrying it forever
+		logger.GlobalLogger.Warnf("Notification digest dropped, user not found: userID=%s, alerts=%d", digest.UserID, digest.AlertCount)
+		return nil
+	}
+	if err != nil {
+		return utils.WrapError(err, "database query failed: userID=%s", digest.UserID)
+	}
+	return n.sender.Email(ctx, user.Email, notifications.TemplateAlertDigest, digest)
+}
+
+// NotificationService queues property-change alerts for emailed digests and keeps each user's in-app
+// notification list.
+type NotificationService struct {
+	prefRepo  repositories.NotificationPreferenceRepository
+	alertRepo repositories.PropertyAlertRepository
+	inbox     repositories.NotificationRepository
+	notifier  Notifier
+	sender    *notifications.Sender
+	config    *config.Config
+}
+
+func NewNotificationService(
+	prefRepo repositories.NotificationPreferenceRepository,
+	alertRepo repositories.PropertyAlertRepository,
+	inbox repositories.NotificationRepository,
+	notifier Notifier,
+	sender *notifications.Sender,
+	cfg *config.Config,
+) *NotificationService {
+	return &NotificationService{
+		prefRepo:  prefRepo,
+		alertRepo: alertRepo,
+		inbox:     inbox,
+		notifier:  notifier,
+		sender:    sender,
+		config:    cfg,
+	}
+}
+
+// GetPreferences returns a user's notification preferences, defaulting to instant delivery.
+func (s *NotificationService) GetPreferences(ctx context.Context, userID string) (*models.NotificationPreference, error) {
+	pref, err := s.prefRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: userID=%s", userID)
+	}
+	if pref == nil {
+		pref = &models.NotificationPreference{UserID: userID, DigestFrequency: models.DigestInstant}
+	}
+	return pref, nil
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID string, req *models.UpdateNotificationPreferenceRequest) (*models.NotificationPreference, error) {
+	pref := &models.NotificationPreference{
+		UserID:          userID,
+		DigestFrequency: req.DigestFrequency,
+		UpdatedAt:       time.Now().UTC(),
+	}
+	if err := s.prefRepo.Upsert(ctx, pref); err != nil {
+		return nil, utils.WrapError(err, "update notification preferences failed: userID=%s", userID)
+	}
+
+	// Flush anything queued under the old window so switching to instant doesn't strand alerts
+	if pref.DigestFrequency == models.DigestInstant {
+		if err := s.deliverPending(ctx, userID, models.DigestInstant); err != nil {
+			logger.GlobalLogger.Warnf("Failed to flush pending alerts: userID=%s, error=%v", userID, err)
+		}
+	}
+	return pref, nil
+}
+
+// AlertArea is the grouping key used for a property's alerts in a digest.
+func AlertArea(property *models.Property) string {
+	if property.Address.ZipCode != "" {
+		return property.Address.ZipCode
+	}
+	return fmt.Sprintf("%s, %s", property.Address.City, property.Address.State)
+}
+
+// EnqueueAlert queues a property-change alert for a user and delivers it right away for instant subscribers.
+// The alert shows up in the user's in-app notifications immediately, whatever their digest frequency.
+func (s *NotificationService) EnqueueAlert(ctx context.Context, alert *models.PropertyAlert) error {
+	alert.CreatedAt = time.Now().UTC()
+	if err := s.alertRepo.Create(ctx, alert); err != nil {
+		return utils.WrapError(err, "enqueue property alert failed: userID=%s, propertyID=%s", alert.UserID, alert.PropertyID)
+	}
+	if err := s.Notify(ctx, alert.UserID, alert.Type, alert.PropertyID, alert); err != nil {
+		logger.GlobalLogger.Warnf("Failed to add in-app notification: userID=%s, propertyID=%s, error=%v", alert.UserID, alert.PropertyID, err)
+	}
+
+	pref, err := s.GetPreferences(ctx, alert.UserID)
+	if err != nil {
+		return err
+	}
+	if pref.DigestFrequency != models.DigestInstant {
+		return nil
+	}
+	return s.deliverPending(ctx, alert.UserID, models.DigestInstant)
+}
+
+// Notify adds an in-app notification for a user, rendered from the template named after its type.
+func (s *NotificationService) Notify(ctx context.Context, userID, notificationType, propertyID string, data interface{}) error {
+	content, err := notifications.Render(notificationType, data)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	notification := &models.Notification{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		Type:       notificationType,
+		Title:      content.Subject,
+		Body:       content.Body,
+		PropertyID: propertyID,
+		CreatedAt:  now,
+		ExpiresAt:  now.AddDate(0, 0, s.config.Notifications.RetentionDays),
+	}
+	if err := s.inbox.Create(ctx, notification); err != nil {
+		return utils.WrapError(err, "create notification failed: userID=%s, type=%s", userID, notificationType)
+	}
+	return nil
+}
+
+// Email renders a notification template and sends it to an address right away.
+func (s *NotificationService) Email(ctx context.Context, to, template string, data interface{}) error {
+	return s.sender.Email(ctx, to, template, data)
+}
+
+// ListNotifications returns a page of a user's in-app notifications, newest first, and how many are unread.
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, unreadOnly bool, offset, limit int) (*models.NotificationsResponse, error) {
+	items, total, err := s.inbox.FindByUserID(ctx, userID, unreadOnly, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: notifications userID=%s", userID)
+	}
+	unread := total
+	if !unreadOnly {
+		if unread, err = s.inbox.CountUnread(ctx, userID); err != nil {
+			return nil, utils.WrapError(err, "database query failed: unread notifications userID=%s", userID)
+		}
+	}
+	return &models.NotificationsResponse{
+		Data:        items,
+		Metadata:    models.PaginationMeta{Total: total, Offset: offset, Limit: limit},
+		UnreadCount: unread,
+	}, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id string) error {
+	found, err := s.inbox.MarkRead(ctx, userID, id, time.Now().UTC())
+	if err != nil {
+		return utils.WrapError(err, "database update failed: notification id=%s", id)
+	}
+	if !found {
+		return fmt.Errorf("notification not found: id=%s", id)
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread notification of a user read and returns how many there were.
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID string) (int64, error) {
+	updated, err := s.inbox.MarkAllRead(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return 0, utils.WrapError(err, "database update failed: notifications userID=%s", userID)
+	}
+	return updated, nil
+}
+
+// RunDigest delivers pending alerts for every user whose preference matches the given frequency.
+func (s *NotificationService) RunDigest(ctx context.Context, frequency string) error {
+	userIDs, err := s.alertRepo.FindPendingUserIDs(ctx)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: pending alert users")
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	prefs, err := s.prefRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: notification preferences")
+	}
+	frequencies := make(map[string]string, len(prefs))
+	for _, pref := range prefs {
+		frequencies[pref.UserID] = pref.DigestFrequency
+	}
+
+	delivered := 0
+	for _, userID := range userIDs {
+		userFrequency, ok := frequencies[userID]
+		if !ok {
+			userFrequency = models.DigestInstant
+		}
+		if userFrequency != frequency {
+			continue
+		}
+		if err := s.deliverPending(ctx, userID, frequency); err != nil {
+			logger.GlobalLogger.Errorf("Failed to deliver notification digest: userID=%s, frequency=%s, error=%v", userID, frequency, err)
+			continue
+		}
+		delivered++
+	}
+	logger.GlobalLogger.Printf("Notification digest run: frequency=%s, users=%d", frequency, delivered)
+	return nil
+}
+
+func (s *NotificationService) deliverPending(ctx context.Context, userID, frequency string) error {
+	alerts, err := s.alertRepo.FindPendingByUserID(ctx, userID)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: userID=%s", userID)
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	digest := buildDigest(userID, frequency, alerts)
+	if err := s.notifier.SendDigest(ctx, digest); err != nil {
+		return utils.WrapError(err, "send notification digest failed: userID=%s", userID)
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(alerts))
+	for _, alert := range alerts {
+		ids = append(ids, alert.ID)
+	}
+	if err := s.alertRepo.MarkDelivered(ctx, ids, digest.GeneratedAt); err != nil {
+		return utils.WrapError(err, "mark alerts delivered failed: userID=%s", userID)
+	}
+	return nil
+}
+
+// buildDigest keeps the latest alert per property and type, then groups them by area.
+func buildDigest(userID, frequency string, alerts []models.PropertyAlert) *models.NotificationDigest {
+	latest := make(map[string]models.PropertyAlert)
+	for _, alert := range alerts {
+		key := alert.PropertyID + "|" + alert.Type
+		if existing, ok := latest[key]; !ok || !alert.CreatedAt.Before(existing.CreatedAt) {
+			latest[key] = alert
+		}
+	}
+
+	byArea := make(map[string][]models.PropertyAlert)
+	for _, alert := range latest {
+		byArea[alert.Area] = append(byArea[alert.Area], alert)
+	}
+
+	areas := make([]string, 0, len(byArea))
+	for area := range byArea {
+		areas = append(areas, area)
+	}
+	sort.Strings(areas)
+
+	groups := make([]models.DigestGroup, 0, len(areas))
+	for _, area := range areas {
+		grouped := byArea[area]
+		sort.Slice(grouped, func(i, j int) bool {
+			return grouped[i].CreatedAt.After(grouped[j].CreatedAt)
+		})
+		groups = append(groups, models.DigestGroup{Area: area, Alerts: grouped})
+	}
+
+	return &models.NotificationDigest{
+		UserID:      userID,
+		Frequency:   frequency,
+		Groups:      groups,
+		AlertCount:  len(latest),
+		GeneratedAt: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/auth"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// OAuthLogin signs a user in with an identity provider's ID token and starts a session. The provider
+// account is looked up by its subject; on first sign-in it is linked to the user with the same email,
+// or a user without a password is created. Either needs an email the provider has verified, so an
+// account can't be taken over by claiming its email elsewhere. fullName names new users when the
+// token carries no name, as Apple's tokens don't.
+func (s *UserService) OAuthLogin(ctx context.Context, provider, idToken, nonce, fullName string, client models.SessionClient) (*auth.TokenDetails, error) {
+	identity, err := s.idTokens.Verify(ctx, provider, idToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.FindByIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		s.recordOAuthLogin(ctx, user, provider, client)
+		return s.startSession(ctx, user, client)
+	}
+	if err != mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("failed to query user: %v", err)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, fmt.Errorf("oauth email not verified: provider=%s", provider)
+	}
+	link := models.UserIdentity{Provider: identity.Provider, Subject: identity.Subject, LinkedAt: time.Now().UTC()}
+
+	user, err = s.repo.FindByEmail(ctx, identity.Email)
+	switch {
+	case err == nil:
+		linked, err := s.repo.AddIdentity(ctx, user.ID.Hex(), link)
+		if err != nil {
+			return nil, fmt.Errorf("failed to link %s account: %v", provider, err)
+		}
+		if !linked {
+			return nil, fmt.Errorf("account already linked to another %s account", provider)
+		}
+		logger.GlobalLogger.Printf("Linked identity provider account: user_id=%s, provider=%s", user.ID.Hex(), provider)
+	case err == mongo.ErrNoDocuments:
+		user = &models.User{
+			ID:         primitive.NewObjectID(),
+			FullName:   newUserName(identity, fullName),
+			Email:      identity.Email,
+			Identities: []models.UserIdentity{link},
+		}
+		if err := s.repo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to register user: %v", err)
+		}
+		logger.GlobalLogger.Printf("Registered user from identity provider: user_id=%s, provider=%s", user.ID.Hex(), provider)
+	default:
+		return nil, fmt.Errorf("failed to query user: %v", err)
+	}
+	s.recordOAuthLogin(ctx, user, provider, client)
+	return s.startSession(ctx, user, client)
+}
+
+// recordOAuthLogin adds a successful social sign-in to the audit log.
+func (s *UserService) recordOAuthLogin(ctx context.Context, user *models.User, provider string, client models.SessionClient) {
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:      models.AuditEventLoginSucceeded,
+		ActorID:   user.ID.Hex(),
+		ActorRole: user.Role,
+		IP:        client.IP,
+		Details:   map[string]interface{}{"method": "oauth", "provider": provider},
+	})
+}
+
+// newUserName picks the name of a user created by social login: the provider's, then the one the
+// client sent, then the email's local part.
+func newUserName(identity *auth.ExternalIdentity, fullName string) string {
+	if name := strings.TrimSpace(identity.Name); name != "" {
+		return name
+	}
+	if name := strings.TrimSpace(fullName); name != "" {
+		return name
+	}
+	local, _, _ := strings.Cut(identity.Email, "@")
+	return local
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var organizationSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// OrganizationService manages the organizations (tenants) sharing the deployment and their members.
+// Every user belongs to one organization; the organization is carried in the access token and scopes
+// all property data the user reads and writes.
+type OrganizationService struct {
+	repo         repositories.OrganizationRepository
+	memberships  repositories.MembershipRepository
+	users        repositories.UserRepository
+	audit        *AuditEventService
+	defaultOrgID string
+}
+
+func NewOrganizationService(repo repositories.OrganizationRepository, memberships repositories.MembershipRepository, users repositories.UserRepository, audit *AuditEventService) *OrganizationService {
+	return &OrganizationService{
+		repo:        repo,
+		memberships: memberships,
+		users:       users,
+		audit:       audit,
+	}
+}
+
+// EnsureDefault creates the default organization on first start and assigns it all data stored
+// before multi-tenancy. It must run before requests are served.
+func (s *OrganizationService) EnsureDefault(ctx context.Context) error {
+	org, err := s.repo.FindBySlug(ctx, models.DefaultOrganizationSlug)
+	if err != nil {
+		return fmt.Errorf("find default organization failed: %v", err)
+	}
+	if org == nil {
+		org = &models.Organization{
+			ID:        primitive.NewObjectID(),
+			Name:      "Default",
+			Slug:      models.DefaultOrganizationSlug,
+			CreatedAt: time.Now().UTC(),
+		}
+		if err := s.repo.Create(ctx, org); err != nil {
+			// Another instance created it concurrently
+			if org, err = s.repo.FindBySlug(ctx, models.DefaultOrganizationSlug); err != nil || org == nil {
+				return fmt.Errorf("create default organization failed: %v", err)
+			}
+		}
+	}
+	s.defaultOrgID = org.ID.Hex()
+
+	assigned, err := s.repo.AssignUnowned(ctx, s.defaultOrgID)
+	if err != nil {
+		return err
+	}
+	if assigned > 0 {
+		logger.GlobalLogger.Printf("Assigned documents without organization to default organization: orgId=%s, documents=%d", s.defaultOrgID, assigned)
+	}
+	return nil
+}
+
+// DefaultOrgID returns the ID of the default organization.
+func (s *OrganizationService) DefaultOrgID() string {
+	return s.defaultOrgID
+}
+
+// OrgIDFor returns the organization of a user. Users who haven't been placed in one, such as those
+// who signed up on their own, join the default organization as members.
+func (s *OrganizationService) OrgIDFor(ctx context.Context, userID string) (string, error) {
+	membership, err := s.memberships.FindByUserID(ctx, userID)
+	if err != nil {
+		return "", utils.WrapError(err, "database query failed: membership userId=%s", userID)
+	}
+	if membership != nil {
+		return membership.OrgID, nil
+	}
+	if s.defaultOrgID == "" {
+		return "", fmt.Errorf("default organization not initialized")
+	}
+	membership, err = s.memberships.Join(ctx, &models.Membership{
+		ID:        primitive.NewObjectID(),
+		OrgID:     s.defaultOrgID,
+		UserID:    userID,
+		Role:      models.MembershipRoleMember,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return "", utils.WrapError(err, "database insert failed: membership userId=%s", userID)
+	}
+	return membership.OrgID, nil
+}
+
+// Create adds an organization. Slugs are lowercase letters, digits and dashes.
+func (s *OrganizationService) Create(ctx context.Context, req *models.CreateOrganizationRequest, createdBy string) (*models.Organization, error) {
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if !organizationSlugPattern.MatchString(slug) {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("invalid organization slug: %s", req.Slug),
+			"Slugs may only contain lowercase letters, digits and dashes.",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	org := &models.Organization{
+		ID:        primitive.NewObjectID(),
+		Name:      strings.TrimSpace(req.Name),
+		Slug:      slug,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.Create(ctx, org); err != nil {
+		return nil, utils.WrapError(err, "database insert failed: organization slug=%s", slug)
+	}
+	logger.GlobalLogger.Printf("Organization created: orgId=%s, slug=%s, createdBy=%s", org.ID.Hex(), slug, createdBy)
+	return org, nil
+}
+
+func (s *OrganizationService) List(ctx context.Context, offset, limit int) ([]models.Organization, int64, error) {
+	orgs, total, err := s.repo.FindAll(ctx, offset, limit)
+	if err != nil {
+		return nil, 0, utils.WrapError(err, "database query failed: organizations offset=%d, limit=%d", offset, limit)
+	}
+	return orgs, total, nil
+}
+
+func (s *OrganizationService) Get(ctx context.Context, id string) (*models.Organization, error) {
+	org, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: organization id=%s", id)
+	}
+	if org == nil {
+		return nil, fmt.Errorf("organization not found: id=%s", id)
+	}
+	return org, nil
+}
+
+// Current returns the organization of the signed-in user.
+func (s *OrganizationService) Current(ctx context.Context) (*models.Organization, error) {
+	return s.Get(ctx, tenant.OrgID(ctx))
+}
+
+// ListMembers pages through the members of an organization. Only platform admins and admins of the
+// organization may list them.
+func (s *OrganizationService) ListMembers(ctx context.Context, orgID, actorID, actorRole string, offset, limit int) ([]models.Member, int64, error) {
+	if err := s.authorize(ctx, orgID, actorID, actorRole); err != nil {
+		return nil, 0, err
+	}
+	memberships, total, err := s.memberships.FindByOrgID(ctx, orgID, offset, limit)
+	if err != nil {
+		return nil, 0, utils.WrapError(err, "database query failed: members orgId=%s", orgID)
+	}
+	members := make([]models.Member, 0, len(memberships))
+	for _, membership := range memberships {
+		member := models.Member{UserID: membership.UserID, Role: membership.Role, JoinedAt: membership.CreatedAt}
+		user, err := s.users.FindByID(ctx, membership.UserID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, 0, utils.WrapError(err, "database query failed: user id=%s", membership.UserID)
+		}
+		if user != nil {
+			member.FullName = user.FullName
+			member.Email = user.Email
+		}
+		members = append(members, member)
+	}
+	return members, total, nil
+}
+
+// AddMember places a registered user in an organization. Only users still in the default
+// organization can be added, unless a platform admin moves them out of the one they were in. The
+// move takes effect when the user's access token is next refreshed.
+func (s *OrganizationService) AddMember(ctx context.Context, orgID, actorID, actorRole string, req *models.AddMemberRequest) (*models.Member, error) {
+	if err := s.authorize(ctx, orgID, actorID, actorRole); err != nil {
+		return nil, err
+	}
+	email := strings.TrimSpace(req.Email)
+	user, err := s.users.FindByEmail(ctx, email)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("member not found: email=%s", email),
+			"No user is registered with this email address.",
+			errors.ErrCodeMemberNotFound,
+			http.StatusNotFound,
+			nil,
+		)
+	}
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: user email=%s", email)
+	}
+
+	membership := &models.Membership{
+		ID:        primitive.NewObjectID(),
+		OrgID:     orgID,
+		UserID:    user.ID.Hex(),
+		Role:      req.Role,
+		CreatedAt: time.Now().UTC(),
+	}
+	if membership.Role == "" {
+		membership.Role = models.MembershipRoleMember
+	}
+	previous, err := s.memberships.FindByUserID(ctx, membership.UserID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: membership userId=%s", membership.UserID)
+	}
+	// an organization admin can't claim the members of another organization
+	if previous != nil && previous.OrgID != orgID && previous.OrgID != s.defaultOrgID && actorRole != models.RoleAdmin {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("user belongs to another organization: orgId=%s, userId=%s", previous.OrgID, membership.UserID),
+			"This user already belongs to another organization.",
+			errors.ErrCodeMemberOfOtherOrg,
+			http.StatusConflict,
+			nil,
+		)
+	}
+	if err := s.memberships.Set(ctx, membership); err != nil {
+		return nil, utils.WrapError(err, "database update failed: membership userId=%s", membership.UserID)
+	}
+	logger.GlobalLogger.Printf("Organization member added: orgId=%s, userId=%s, role=%s, by=%s", orgID, membership.UserID, membership.Role, actorID)
+	details := map[string]interface{}{"orgId": orgID, "role": membership.Role}
+	if previous != nil {
+		details["previousOrgId"] = previous.OrgID
+		details["previousRole"] = previous.Role
+	}
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:       models.AuditEventMemberRoleChanged,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		TargetType: "user",
+		TargetID:   membership.UserID,
+		Details:    details,
+	})
+	return &models.Member{
+		UserID:   membership.UserID,
+		FullName: user.FullName,
+		Email:    user.Email,
+		Role:     membership.Role,
+		JoinedAt: membership.CreatedAt,
+	}, nil
+}
+
+// RemoveMember takes a user out of an organization; the user falls back to the default organization.
+func (s *OrganizationService) RemoveMember(ctx context.Context, orgID, userID, actorID, actorRole string) error {
+	if err := s.authorize(ctx, orgID, actorID, actorRole); err != nil {
+		return err
+	}
+	if orgID == s.defaultOrgID {
+		return errors.NewAppError(
+			fmt.Sprintf("cannot remove member of default organization: userId=%s", userID),
+			"Users cannot be removed from the default organization.",
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	membership, err := s.memberships.FindByUserID(ctx, userID)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: membership userId=%s", userID)
+	}
+	if membership == nil || membership.OrgID != orgID {
+		return fmt.Errorf("member not found: orgId=%s, userId=%s", orgID, userID)
+	}
+	err = s.memberships.Set(ctx, &models.Membership{
+		ID:        primitive.NewObjectID(),
+		OrgID:     s.defaultOrgID,
+		UserID:    userID,
+		Role:      models.MembershipRoleMember,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return utils.WrapError(err, "database update failed: membership userId=%s", userID)
+	}
+	logger.GlobalLogger.Printf("Organization member removed: orgId=%s, userId=%s, by=%s", orgID, userID, actorID)
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:       models.AuditEventMemberRemoved,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		TargetType: "user",
+		TargetID:   userID,
+		Details:    map[string]interface{}{"orgId": orgID, "previousRole": membership.Role},
+	})
+	return nil
+}
+
+// authorize allows platform admins to manage any organization and organization admins to manage
+// their own.
+func (s *OrganizationService) authorize(ctx context.Context, orgID, actorID, actorRole string) error {
+	if _, err := s.Get(ctx, orgID); err != nil {
+		return err
+	}
+	if actorRole == models.RoleAdmin {
+		return nil
+	}
+	membership, err := s.memberships.FindByUserID(ctx, actorID)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: membership userId=%s", actorID)
+	}
+	if membership == nil || membership.OrgID != orgID || membership.Role != models.MembershipRoleAdmin {
+		return errors.NewAppError(
+			fmt.Sprintf("not an admin of organization: orgId=%s, userId=%s", orgID, actorID),
+			errors.MsgForbidden,
+			errors.ErrCodeForbidden,
+			http.StatusForbidden,
+			nil,
+		)
+	}
+	return nil
+}
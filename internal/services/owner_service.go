@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxRelatedProperties bounds the related-parcel payload for owners with very large portfolios.
+const maxRelatedProperties = 100
+
+// maxOwnerSearchEntities bounds how many owners a name search matches; a short name such as "SMITH"
+// needs more words to narrow it down beyond this.
+const maxOwnerSearchEntities = 100
+
+type OwnerService struct {
+	ownerRepo    repositories.OwnerEntityRepository
+	propertyRepo repositories.PropertyRepository
+	ownerTrans   transformers.OwnerTransformer
+	index        fieldcrypt.BlindIndex
+}
+
+func NewOwnerService(
+	ownerRepo repositories.OwnerEntityRepository,
+	propertyRepo repositories.PropertyRepository,
+	ownerTrans transformers.OwnerTransformer,
+	index fieldcrypt.BlindIndex,
+) *OwnerService {
+	return &OwnerService{
+		ownerRepo:    ownerRepo,
+		propertyRepo: propertyRepo,
+		ownerTrans:   ownerTrans,
+		index:        index,
+	}
+}
+
+// entityID identifies the owner entity of a normalized name. Entity IDs appear in URLs and logs, so
+// while owner names are encrypted they're blind indexes of the name rather than slugs of it.
+func (s *OwnerService) entityID(name string) string {
+	if s.index.Enabled() {
+		return s.index.Index(name)
+	}
+	return s.ownerTrans.EntityID(name)
+}
+
+// entitiesFor derives the owner entities referenced by a property's current owners.
+func (s *OwnerService) entitiesFor(property *models.Property) []models.OwnerEntity {
+	seen := make(map[string]bool)
+	var entities []models.OwnerEntity
+	for _, owner := range property.Ownership.CurrentOwners {
+		name := s.ownerTrans.NormalizeOwnerName(owner.FullName)
+		if name == "" {
+			continue
+		}
+		entityID := s.entityID(name)
+		if seen[entityID] {
+			continue
+		}
+		seen[entityID] = true
+		entities = append(entities, models.OwnerEntity{
+			EntityID:    entityID,
+			Name:        name,
+			IsCorporate: owner.IsCorporate,
+		})
+	}
+	return entities
+}
+
+// IndexProperty re-links a property to the owner entities derived from its current owners.
+func (s *OwnerService) IndexProperty(ctx context.Context, property *models.Property) error {
+	if err := s.ownerRepo.UnlinkProperty(ctx, property.PropertyID); err != nil {
+		return utils.WrapError(err, "unlink owner entities failed: propertyID=%s", property.PropertyID)
+	}
+	for _, entity := range s.entitiesFor(property) {
+		if err := s.ownerRepo.LinkProperty(ctx, &entity, property.PropertyID); err != nil {
+			return utils.WrapError(err, "link owner entity failed: propertyID=%s, entityID=%s", property.PropertyID, entity.EntityID)
+		}
+	}
+	return nil
+}
+
+// RemoveProperty drops a deleted property from every owner entity.
+func (s *OwnerService) RemoveProperty(ctx context.Context, propertyID string) error {
+	if err := s.ownerRepo.UnlinkProperty(ctx, propertyID); err != nil {
+		return utils.WrapError(err, "unlink owner entities failed: propertyID=%s", propertyID)
+	}
+	return nil
+}
+
+// RebuildIndexIfEmpty backfills the owner-entity index from existing properties on first run. An
+// index built before owner names were encrypted is rebuilt by the reindex-owners migration.
+func (s *OwnerService) RebuildIndexIfEmpty(ctx context.Context) {
+	count, err := s.ownerRepo.Count(ctx)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to count owner entities: error=%v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	properties, err := s.propertyRepo.FindAll(ctx)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to load properties for owner index: error=%v", err)
+		return
+	}
+	indexed := 0
+	for i := range properties {
+		// Owner entities are kept per organization, like the properties they hold
+		if err := s.IndexProperty(tenant.WithOrgID(ctx, properties[i].OrgID), &properties[i]); err != nil {
+			logger.GlobalLogger.Warnf("Failed to index property owners: propertyID=%s, error=%v", properties[i].PropertyID, err)
+			continue
+		}
+		indexed++
+	}
+	logger.GlobalLogger.Printf("Owner entity index rebuilt: properties=%d", indexed)
+}
+
+func (s *OwnerService) GetPortfolio(ctx context.Context, entityID string, offset, limit int, baseURL string, params url.Values) (*models.OwnerPortfolioResponse, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+	ginCtx.Set("data_source", "DATABASE")
+	ginCtx.Set("query", "entityId="+entityID)
+
+	entity, err := s.ownerRepo.FindByEntityID(ctx, entityID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: entityId=%s", entityID)
+	}
+	if entity == nil {
+		return nil, fmt.Errorf("owner entity not found: entityId=%s", entityID)
+	}
+
+	properties, err := s.propertyRepo.FindByIDs(ctx, entity.PropertyIDs, nil, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: entityId=%s", entityID)
+	}
+
+	total := int64(len(entity.PropertyIDs))
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+
+	return &models.OwnerPortfolioResponse{
+		Owner:    *entity,
+		Data:     properties,
+		Metadata: metadata,
+	}, nil
+}
+
+// SearchByOwnerName pages through the properties of the owners whose normalized name is name or
+// starts with it, such as "SMITH JOHN" for "SMITH JOHN A". Owner names are matched through the owner
+// entities rather than the properties, whose owner names may be encrypted at rest.
+func (s *OwnerService) SearchByOwnerName(ctx context.Context, name string, offset, limit int, baseURL string, params url.Values) (*models.OwnerSearchResponse, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+	// Owner names are PII, so they're kept out of the request log
+	ginCtx.Set("data_source", "DATABASE")
+	ginCtx.Set("query", "owner-name")
+
+	normalized := s.ownerTrans.NormalizeOwnerName(name)
+	if len(normalized) < 2 {
+		return nil, fmt.Errorf("invalid filter: owner name must have at least 2 characters")
+	}
+	entities, err := s.ownerRepo.FindByNamePrefix(ctx, normalized, maxOwnerSearchEntities)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: owner name search")
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, entity := range entities {
+		for _, id := range entity.PropertyIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	properties, err := s.propertyRepo.FindByIDs(ctx, ids, nil, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: owner name search")
+	}
+
+	total := int64(len(ids))
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+
+	logger.GlobalLogger.WithContext(ctx).Printf("Owner name search: owners=%d, properties=%d", len(entities), total)
+	return &models.OwnerSearchResponse{
+		Owners:   entities,
+		Data:     properties,
+		Metadata: metadata,
+	}, nil
+}
+
+// GetRelatedProperties returns parcels sharing an owner entity with the given property.
+func (s *OwnerService) GetRelatedProperties(ctx context.Context, propertyID string) (*models.RelatedPropertiesResponse, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+	ginCtx.Set("data_source", "DATABASE")
+	ginCtx.Set("property_id", propertyID)
+
+	property, err := s.propertyRepo.FindByID(ctx, propertyID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: propertyID=%s", propertyID)
+	}
+	if property == nil {
+		return nil, fmt.Errorf("property not found: propertyID=%s", propertyID)
+	}
+
+	entities, err := s.ownerRepo.FindByPropertyID(ctx, propertyID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: propertyID=%s", propertyID)
+	}
+
+	related := make(map[string]bool)
+	for _, entity := range entities {
+		for _, id := range entity.PropertyIDs {
+			if id != propertyID {
+				related[id] = true
+			}
+		}
+	}
+	ids := make([]string, 0, len(related))
+	for id := range related {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	properties, err := s.propertyRepo.FindByIDs(ctx, ids, nil, 0, maxRelatedProperties)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: propertyID=%s", propertyID)
+	}
+
+	if entities == nil {
+		entities = []models.OwnerEntity{}
+	}
+	return &models.RelatedPropertiesResponse{
+		PropertyID: propertyID,
+		By:         "owner",
+		Owners:     entities,
+		Data:       properties,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OwnershipChangeService notices when a refreshed property has a different set of current owners,
+// publishes a property.ownership_changed event and alerts the users whose saved searches the property
+// has matched.
+type OwnershipChangeService struct {
+	matchRepo     repositories.SavedSearchMatchRepository
+	notifications *NotificationService
+	webhooks      *WebhookService
+	events        *EventService
+	ownerTrans    transformers.OwnerTransformer
+}
+
+func NewOwnershipChangeService(
+	matchRepo repositories.SavedSearchMatchRepository,
+	notifications *NotificationService,
+	webhooks *WebhookService,
+	events *EventService,
+	ownerTrans transformers.OwnerTransformer,
+) *OwnershipChangeService {
+	return &OwnershipChangeService{
+		matchRepo:     matchRepo,
+		notifications: notifications,
+		webhooks:      webhooks,
+		events:        events,
+		ownerTrans:    ownerTrans,
+	}
+}
+
+// Detect compares the current owners of a property before and after a refresh. Owner names are
+// compared normalized, so formatting differences between fetches are not reported as a sale. A
+// record without owners on either side is not treated as a change. Failures are logged rather than
+// returned so the refresh itself never fails.
+func (s *OwnershipChangeService) Detect(ctx context.Context, before, after *models.Property) {
+	if s == nil || before == nil || after == nil {
+		return
+	}
+	previous, current := s.ownerNames(before), s.ownerNames(after)
+	if len(previous) == 0 || len(current) == 0 || maps.Equal(previous, current) {
+		return
+	}
+	logger.GlobalLogger.Printf("Ownership change detected: propertyID=%s, previousOwners=%d, currentOwners=%d", after.PropertyID, len(previous), len(current))
+
+	s.webhooks.Publish(ctx, models.EventPropertyOwnershipChanged, after.PropertyID, after)
+	s.events.Record(ctx, models.EventPropertyOwnershipChanged, after.PropertyID, after)
+
+	userIDs, err := s.matchRepo.FindUserIDsByPropertyID(ctx, after.PropertyID)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to find users following property: propertyID=%s, error=%v", after.PropertyID, err)
+		return
+	}
+	for _, userID := range userIDs {
+		alert := &models.PropertyAlert{
+			ID:         primitive.NewObjectID(),
+			UserID:     userID,
+			PropertyID: after.PropertyID,
+			Type:       models.AlertTypeOwnershipChange,
+			Area:       AlertArea(after),
+			Summary:    fmt.Sprintf("%s has new owners", after.Address.StreetAddress),
+		}
+		if err := s.notifications.EnqueueAlert(ctx, alert); err != nil {
+			logger.GlobalLogger.Warnf("Failed to enqueue ownership change alert: userID=%s, propertyID=%s, error=%v", userID, after.PropertyID, err)
+		}
+	}
+}
+
+// ownerNames returns the normalized names of a property's current owners.
+func (s *OwnershipChangeService) ownerNames(property *models.Property) map[string]bool {
+	names := make(map[string]bool)
+	for _, owner := range property.Ownership.CurrentOwners {
+		if name := s.ownerTrans.NormalizeOwnerName(owner.FullName); name != "" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"homeinsight-properties/internal/auth"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/notifications"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// passwordResetSendTimeout bounds delivery of reset emails and notifications, which happens after the request returns.
+const passwordResetSendTimeout = 30 * time.Second
+
+// RequestPasswordReset emails a single-use reset link to email if it belongs to a user. It succeeds
+// whether or not the email is registered, and sends in the background, so responses don't reveal
+// which emails have accounts.
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil
+		}
+		return fmt.Errorf("failed to query user: %v", err)
+	}
+	userID := user.ID.Hex()
+
+	cooldown := time.Duration(s.cfg.PasswordReset.ResendCooldownSeconds) * time.Second
+	claimed, err := cache.ClaimPasswordResetSlot(ctx, userID, cooldown)
+	if err != nil {
+		return fmt.Errorf("failed to throttle password reset: %v", err)
+	}
+	if !claimed {
+		logger.GlobalLogger.Warnf("Password reset throttled: user_id=%s", userID)
+		return nil
+	}
+
+	token, tokenHash, err := auth.GeneratePasswordResetToken()
+	if err != nil {
+		return err
+	}
+	ttl := time.Duration(s.cfg.PasswordReset.TokenTTLMinutes) * time.Minute
+	if err := cache.StorePasswordResetToken(ctx, userID, tokenHash, ttl); err != nil {
+		return fmt.Errorf("failed to store password reset token: %v", err)
+	}
+
+	data := passwordResetEmail{Token: token, Minutes: int(ttl.Minutes())}
+	if s.cfg.PasswordReset.ResetURL != "" {
+		data.Link = s.cfg.PasswordReset.ResetURL + "?token=" + url.QueryEscape(token)
+	}
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), passwordResetSendTimeout)
+		defer cancel()
+		if err := s.notifications.Email(sendCtx, user.Email, notifications.TemplatePasswordReset, data); err != nil {
+			logger.GlobalLogger.Errorf("Failed to send password reset email: user_id=%s, error=%v", userID, err)
+		}
+	}()
+	logger.GlobalLogger.Printf("Password reset requested: user_id=%s", userID)
+	return nil
+}
+
+// passwordResetEmail fills in the password reset template. Link is empty when no reset page is
+// configured, and the bare token is sent instead.
+type passwordResetEmail struct {
+	Token   string
+	Link    string
+	Minutes int
+}
+
+// ResetPassword sets a new password for the user a reset token was issued to. The token is used up
+// even if the rest fails, and every existing session of the user is signed out.
+func (s *UserService) ResetPassword(ctx context.Context, token, password string) error {
+	if err := s.validator.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	userID, err := cache.ConsumePasswordResetToken(ctx, auth.HashPasswordResetToken(token))
+	if err != nil {
+		return fmt.Errorf("failed to check password reset token: %v", err)
+	}
+	if userID == "" {
+		return fmt.Errorf("invalid or expired reset token")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %v", err)
+	}
+	if err := s.repo.UpdatePassword(ctx, userID, string(hashedPassword)); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("invalid or expired reset token")
+		}
+		return fmt.Errorf("failed to update password: %v", err)
+	}
+
+	if err := s.endAllSessions(ctx, userID); err != nil {
+		logger.GlobalLogger.Errorf("Failed to revoke sessions after password reset: user_id=%s, error=%v", userID, err)
+	}
+	s.notifyPasswordChanged(userID)
+	s.audit.Record(ctx, models.AuditEvent{
+		Type:       models.AuditEventPasswordChanged,
+		ActorID:    userID,
+		TargetType: "user",
+		TargetID:   userID,
+		Details:    map[string]interface{}{"method": "reset"},
+	})
+	logger.GlobalLogger.Printf("Password reset completed: user_id=%s", userID)
+	return nil
+}
+
+// notifyPasswordChanged tells the user their password was reset, in-app and by email, so a reset
+// they didn't ask for doesn't go unnoticed. It runs in the background and only logs failures.
+func (s *UserService) notifyPasswordChanged(userID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), passwordResetSendTimeout)
+		defer cancel()
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypePasswordChanged, "", nil); err != nil {
+			logger.GlobalLogger.Errorf("Failed to add password changed notification: user_id=%s, error=%v", userID, err)
+		}
+		user, err := s.repo.FindByID(ctx, userID)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to load user for password changed email: user_id=%s, error=%v", userID, err)
+			return
+		}
+		if err := s.notifications.Email(ctx, user.Email, notifications.TemplatePasswordChanged, nil); err != nil {
+			logger.GlobalLogger.Errorf("Failed to send password changed email: user_id=%s, error=%v", userID, err)
+		}
+	}()
+}
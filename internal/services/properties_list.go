@@ -2,18 +2,27 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/errors"
 	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
 	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
 	"homeinsight-properties/pkg/logger"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-func (s *PropertySearchService) ListProperties(ctx context.Context, offset, limit int, baseURL string, params url.Values) (*models.PaginatedPropertiesResponse, error) {
+// ListProperties returns a page of properties narrowed by filter when it is set, in sort order or by
+// street address when sort is empty. Only the selected fields are loaded when fields is set.
+func (s *PropertySearchService) ListProperties(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int, baseURL string, params url.Values) (*models.PaginatedPropertiesResponse, error) {
 	ginCtx, ok := ctx.(*gin.Context)
 	if !ok {
 		ginCtx = &gin.Context{}
@@ -25,26 +34,75 @@ func (s *PropertySearchService) ListProperties(ctx context.Context, offset, limi
 	if offset < 0 {
 		offset = 0
 	}
+	if filter != nil {
+		if err := s.validator.ValidateFilter(filter); err != nil {
+			return nil, err
+		}
+	}
 
-	ginCtx.Set("data_source", "DATABASE")
-	ginCtx.Set("query", "offset="+strconv.Itoa(offset)+",limit="+strconv.Itoa(limit))
+	filterKey := filter.String()
+	sortKey := sort.String()
+	cacheKey := cache.PropertyListPaginatedKey(tenant.OrgID(ctx), offset, limit, filterKey, sortKey)
+	ginCtx.Set("data_source", "REDIS")
+	query := "offset=" + strconv.Itoa(offset) + ",limit=" + strconv.Itoa(limit)
+	if filterKey != "" {
+		query += "," + filterKey
+	}
+	if sortKey != "" {
+		query += ",sort=" + sortKey
+	}
+	ginCtx.Set("query", query)
 
-	// Query database
 	var properties []models.Property
 	var total int64
-	var err error
-	for attempt := 1; attempt <= s.config.ErrorHandling.RetryAttempts; attempt++ {
-		properties, total, err = s.repo.FindWithPagination(ctx, offset, limit)
-		if err == nil || !utils.IsRetryableError(err) {
-			break
+
+	// Check cache; pages hold IDs in list order and are dropped whenever any property changes
+	cached, err := s.cache.GetSearchResult(ctx, cacheKey)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Cache lookup failed for property list: cacheKey=%s, error=%v", cacheKey, err)
+	}
+	if cached != nil {
+		hydrated, err := s.hydrate(ctx, cached.PropertyIDs, fields)
+		if err == nil && len(hydrated) == len(cached.PropertyIDs) {
+			ginCtx.Set("cache_hit", true)
+			properties = hydrated
+			total = cached.Total
 		}
-		logger.GlobalLogger.Warnf("Database query attempt %d/%d failed: offset=%d, limit=%d, error=%v", attempt, s.config.ErrorHandling.RetryAttempts, offset, limit, err)
-		time.Sleep(time.Duration(s.config.ErrorHandling.RetryDelayMS) * time.Millisecond)
 	}
-	if err != nil {
-		return nil, utils.LogAndMapError(ctx, err, "list properties",
-			"offset", offset,
-			"limit", limit)
+
+	if properties == nil {
+		ginCtx.Set("cache_hit", false)
+		ginCtx.Set("data_source", "DATABASE")
+
+		for attempt := 1; attempt <= s.config.ErrorHandling.RetryAttempts; attempt++ {
+			properties, total, err = s.findPage(ctx, filter, sort, fields, offset, limit)
+			if err == nil || !utils.IsRetryableError(err) {
+				break
+			}
+			logger.GlobalLogger.Warnf("Database query attempt %d/%d failed: offset=%d, limit=%d, filter=%s, sort=%s, error=%v", attempt, s.config.ErrorHandling.RetryAttempts, offset, limit, filterKey, sortKey, err)
+			time.Sleep(time.Duration(s.config.ErrorHandling.RetryDelayMS) * time.Millisecond)
+		}
+		if err != nil {
+			return nil, utils.LogAndMapError(ctx, err, "list properties",
+				"offset", offset,
+				"limit", limit,
+				"filter", filterKey,
+				"sort", sortKey)
+		}
+
+		ids := make([]string, 0, len(properties))
+		for _, property := range properties {
+			ids = append(ids, property.PropertyID)
+		}
+		if err := s.cache.SetListPage(ctx, cacheKey, &models.CachedSearchResult{PropertyIDs: ids, Total: total}, s.cache.TTL(cache.ClassList)); err != nil {
+			logger.GlobalLogger.Warnf("Failed to cache property list page: cacheKey=%s, error=%v", cacheKey, err)
+		}
+		// Only whole properties may stand in for a property read
+		if s.config.CacheWarmup.ListItems && len(fields) == 0 {
+			if err := s.cache.SetProperties(ctx, properties); err != nil {
+				logger.GlobalLogger.Warnf("Failed to cache listed properties: cacheKey=%s, error=%v", cacheKey, err)
+			}
+		}
 	}
 
 	metadata := models.PaginationMeta{
@@ -72,3 +130,196 @@ func (s *PropertySearchService) ListProperties(ctx context.Context, offset, limi
 
 	return response, nil
 }
+
+// findPage loads a page of properties and the total number matching, counted as configured by
+// pagination.count_strategy. Only the exact strategy counts an unfiltered list on every request.
+func (s *PropertySearchService) findPage(ctx context.Context, filter *models.PropertyFilter, sort models.PropertySort, fields models.PropertyFields, offset, limit int) ([]models.Property, int64, error) {
+	strategy := s.config.Pagination.CountStrategy
+	if strategy == "exact" {
+		return s.repo.FindWithPagination(ctx, filter, sort, fields, offset, limit)
+	}
+	if filter.IsEmpty() {
+		properties, err := s.repo.FindPage(ctx, filter, sort, fields, offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		total, err := s.repo.EstimatedCount(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		return properties, total, nil
+	}
+
+	switch strategy {
+	case "facet":
+		return s.repo.FindPageWithTotal(ctx, filter, sort, fields, offset, limit)
+	case "cached":
+		properties, err := s.repo.FindPage(ctx, filter, sort, fields, offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		total, err := s.cachedCount(ctx, filter)
+		if err != nil {
+			return nil, 0, err
+		}
+		return properties, total, nil
+	default:
+		return s.repo.FindWithPagination(ctx, filter, sort, fields, offset, limit)
+	}
+}
+
+// cachedCount counts the properties matching filter, reusing a count cached for the organization
+// within the last pagination.count_cache_seconds.
+func (s *PropertySearchService) cachedCount(ctx context.Context, filter *models.PropertyFilter) (int64, error) {
+	key := cache.PropertyCountKey(tenant.OrgID(ctx), filter.String())
+	total, ok, err := s.cache.GetCount(ctx, key)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Cache lookup failed for property count: cacheKey=%s, error=%v", key, err)
+	}
+	if ok {
+		return total, nil
+	}
+	total, err = s.repo.CountMatching(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.cache.SetCount(ctx, key, total, time.Duration(s.config.Pagination.CountCacheSeconds)*time.Second); err != nil {
+		logger.GlobalLogger.Warnf("Failed to cache property count: cacheKey=%s, error=%v", key, err)
+	}
+	return total, nil
+}
+
+// ListPropertiesByCursor pages through properties narrowed by filter by (street address, _id) instead
+// of skip/limit. An empty cursor starts from the beginning.
+func (s *PropertySearchService) ListPropertiesByCursor(ctx context.Context, filter *models.PropertyFilter, cursor string, fields models.PropertyFields, limit int, baseURL string, params url.Values) (*models.PaginatedPropertiesResponse, error) {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		ginCtx = &gin.Context{}
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if filter != nil {
+		if err := s.validator.ValidateFilter(filter); err != nil {
+			return nil, err
+		}
+	}
+
+	var afterStreet string
+	var afterID primitive.ObjectID
+	if cursor != "" {
+		sortKey, id, err := utils.DecodeCursor(cursor)
+		if err == nil {
+			afterID, err = primitive.ObjectIDFromHex(id)
+		}
+		if err != nil {
+			return nil, errors.NewAppError(
+				fmt.Sprintf("invalid cursor: %v", err),
+				"The provided pagination cursor is invalid",
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				err,
+			)
+		}
+		afterStreet = sortKey
+	}
+
+	filterKey := filter.String()
+	ginCtx.Set("data_source", "DATABASE")
+	query := "cursor=" + cursor + ",limit=" + strconv.Itoa(limit)
+	if filterKey != "" {
+		query += "," + filterKey
+	}
+	ginCtx.Set("query", query)
+
+	// Fetch one extra row to learn whether another page exists without counting
+	var properties []models.Property
+	var err error
+	for attempt := 1; attempt <= s.config.ErrorHandling.RetryAttempts; attempt++ {
+		properties, err = s.repo.FindAfterCursor(ctx, filter, fields, afterStreet, afterID, limit+1)
+		if err == nil || !utils.IsRetryableError(err) {
+			break
+		}
+		logger.GlobalLogger.Warnf("Database query attempt %d/%d failed: cursor=%s, limit=%d, filter=%s, error=%v", attempt, s.config.ErrorHandling.RetryAttempts, cursor, limit, filterKey, err)
+		time.Sleep(time.Duration(s.config.ErrorHandling.RetryDelayMS) * time.Millisecond)
+	}
+	if err != nil {
+		return nil, utils.LogAndMapError(ctx, err, "list properties",
+			"cursor", cursor,
+			"limit", limit,
+			"filter", filterKey)
+	}
+
+	var total int64
+	if filter.IsEmpty() {
+		total, err = s.repo.EstimatedCount(ctx)
+	} else {
+		total, err = s.cachedCount(ctx, filter)
+	}
+	if err != nil {
+		logger.GlobalLogger.Warnf("Failed to count properties: filter=%s, error=%v", filterKey, err)
+	}
+
+	metadata := models.PaginationMeta{
+		Total: total,
+		Limit: limit,
+	}
+	if len(properties) > limit {
+		properties = properties[:limit]
+		last := properties[len(properties)-1]
+		nextCursor := utils.EncodeCursor(last.Address.StreetAddress, last.ID.Hex())
+		nextURL := utils.BuildCursorURL(baseURL, nextCursor, limit, params)
+		metadata.NextCursor = &nextCursor
+		metadata.Next = &nextURL
+	}
+	if properties == nil {
+		properties = []models.Property{}
+	}
+
+	return &models.PaginatedPropertiesResponse{
+		Data:     properties,
+		Metadata: metadata,
+	}, nil
+}
+
+// StreamProperties calls fn with every property matching filter, read straight from a MongoDB cursor.
+// It runs on the request's own context, so it stops when the client goes away, bounded by the stream
+// timeout instead of the usual request budget.
+func (s *PropertySearchService) StreamProperties(ctx context.Context, filter *models.PropertyFilter, fields models.PropertyFields, fn func(*models.Property) error) error {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		ginCtx = &gin.Context{}
+	}
+	if filter != nil {
+		if err := s.validator.ValidateFilter(filter); err != nil {
+			return err
+		}
+	}
+	ginCtx.Set("data_source", "DATABASE")
+	ginCtx.Set("query", "stream,"+filter.String())
+
+	streamCtx := context.Background()
+	if ginCtx.Request != nil {
+		streamCtx = ginCtx.Request.Context()
+	}
+	streamCtx, cancel := context.WithTimeout(streamCtx, time.Duration(s.config.Server.StreamTimeoutMinutes)*time.Minute)
+	defer cancel()
+
+	start := time.Now()
+	batchSize := s.config.Server.StreamBatchSize
+	streamed := 0
+	err := s.repo.Stream(streamCtx, filter, fields, batchSize, func(property *models.Property) error {
+		// The request context carries no cost meter, so charge the request for each batch here
+		if streamed%batchSize == 0 {
+			cost.Record(ctx, cost.MongoQuery)
+		}
+		streamed++
+		return fn(property)
+	})
+	logger.GlobalLogger.Printf("Property stream finished: filter=%s, properties=%d, duration=%s, error=%v", filter.String(), streamed, time.Since(start).Round(time.Millisecond), err)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: stream properties")
+	}
+	return nil
+}
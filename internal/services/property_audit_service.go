@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"sort"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// auditIgnoredPaths are maintained by the service on every write and would only add noise to diffs.
+var auditIgnoredPaths = map[string]bool{
+	"_id":           true,
+	"schemaVersion": true,
+	"updatedAt":     true,
+	"deletedAt":     true,
+	// Restamped on every refresh even when the data looked up hasn't changed
+	"hazard.retrievedAt":                   true,
+	"neighborhood.walkability.retrievedAt": true,
+}
+
+// auditActor names the user behind changes made outside of a request, e.g. by a background job.
+type auditActor struct {
+	userID string
+	role   string
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor attributes the changes recorded under ctx to a user when ctx isn't their request.
+func WithAuditActor(ctx context.Context, userID, role string) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, auditActor{userID: userID, role: role})
+}
+
+type PropertyAuditService struct {
+	repo repositories.PropertyAuditRepository
+}
+
+func NewPropertyAuditService(repo repositories.PropertyAuditRepository) *PropertyAuditService {
+	return &PropertyAuditService{repo: repo}
+}
+
+// Record stores one change to a property with the signed-in user as actor. before and after may be
+// nil for creations and removals; the field-level diff is taken between them. Failures are logged
+// rather than returned so a history write never undoes a change that already happened.
+func (s *PropertyAuditService) Record(ctx context.Context, action, propertyID string, before, after *models.Property) {
+	var changes []models.FieldChange
+	if before != nil || after != nil {
+		var err error
+		changes, err = diffProperties(before, after)
+		if err != nil {
+			logger.GlobalLogger.WithContext(ctx).Errorf("Failed to diff property for audit: propertyId=%s, action=%s, error=%v", propertyID, action, err)
+		}
+	}
+	s.record(ctx, action, propertyID, changes)
+}
+
+// RecordMerge notes on both properties that mergedID was merged into keptID.
+func (s *PropertyAuditService) RecordMerge(ctx context.Context, keptID, mergedID string) {
+	s.record(ctx, models.AuditActionMerged, keptID, []models.FieldChange{{Path: "mergedFrom", New: mergedID}})
+	s.record(ctx, models.AuditActionMerged, mergedID, []models.FieldChange{{Path: "mergedInto", New: keptID}})
+}
+
+func (s *PropertyAuditService) record(ctx context.Context, action, propertyID string, changes []models.FieldChange) {
+	entry := &models.PropertyAuditEntry{
+		ID:         primitive.NewObjectID(),
+		PropertyID: propertyID,
+		Action:     action,
+		Actor:      models.AuditActorSystem,
+		Timestamp:  time.Now().UTC(),
+		Changes:    changes,
+	}
+	if ginCtx, ok := ctx.Value(gin.ContextKey).(*gin.Context); ok {
+		if userID := ginCtx.GetString("user_id"); userID != "" {
+			entry.Actor = userID
+			entry.ActorRole = ginCtx.GetString("role")
+		}
+	} else if actor, ok := ctx.Value(auditActorKey{}).(auditActor); ok && actor.userID != "" {
+		entry.Actor = actor.userID
+		entry.ActorRole = actor.role
+	}
+
+	if err := s.repo.Create(ctx, entry); err != nil {
+		logger.GlobalLogger.WithContext(ctx).Errorf("Failed to record property audit entry: propertyId=%s, action=%s, error=%v", propertyID, action, err)
+	}
+}
+
+// History returns a page of a property's recorded changes, newest first.
+func (s *PropertyAuditService) History(ctx context.Context, propertyID string, offset, limit int, baseURL string, params url.Values) (*models.PropertyHistoryResponse, error) {
+	entries, total, err := s.repo.FindByProperty(ctx, propertyID, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: property history propertyId=%s", propertyID)
+	}
+	if entries == nil {
+		entries = []models.PropertyAuditEntry{}
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+	return &models.PropertyHistoryResponse{Data: entries, Metadata: metadata}, nil
+}
+
+// diffProperties compares two properties field by field in their JSON form. A missing side is
+// compared as an empty property, so a creation lists only the fields that were set.
+func diffProperties(before, after *models.Property) ([]models.FieldChange, error) {
+	if before == nil {
+		before = &models.Property{}
+	}
+	if after == nil {
+		after = &models.Property{}
+	}
+	old, err := toJSONMap(before)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := toJSONMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []models.FieldChange
+	diffValues("", old, updated, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+func toJSONMap(property *models.Property) (map[string]interface{}, error) {
+	data, err := json.Marshal(property)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// diffValues walks nested objects and records every differing leaf; arrays are compared whole.
+func diffValues(path string, old, updated interface{}, changes *[]models.FieldChange) {
+	if auditIgnoredPaths[path] {
+		return
+	}
+	oldObject, oldIsObject := old.(map[string]interface{})
+	newObject, newIsObject := updated.(map[string]interface{})
+	if oldIsObject && newIsObject {
+		for key, value := range oldObject {
+			diffValues(joinPath(path, key), value, newObject[key], changes)
+		}
+		for key, value := range newObject {
+			if _, seen := oldObject[key]; !seen {
+				diffValues(joinPath(path, key), nil, value, changes)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(old, updated) {
+		*changes = append(*changes, models.FieldChange{Path: path, Old: old, New: updated})
+	}
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Background job types deleting and updating the properties matching a filter.
+const (
+	JobPropertyBulkDelete = "property.bulk_delete"
+	JobPropertyBulkUpdate = "property.bulk_update"
+)
+
+const (
+	// bulkPreviewSize is how many matching property IDs a dry run lists
+	bulkPreviewSize = 10
+	// maxBulkErrors bounds the rejected properties listed in a bulk job's progress
+	maxBulkErrors = 100
+)
+
+// propertyBulkPayload carries the requesting admin along, so the audit history credits them, and
+// their organization, which bounds the properties touched. Patch and Paths are set for updates.
+type propertyBulkPayload struct {
+	Filter    models.PropertyFilter  `json:"filter"`
+	Patch     map[string]interface{} `json:"patch,omitempty"`
+	Paths     []string               `json:"paths,omitempty"`
+	Actor     string                 `json:"actor"`
+	ActorRole string                 `json:"actorRole"`
+	OrgID     string                 `json:"orgId"`
+}
+
+// PreviewBulkDelete reports how many properties a bulk delete would move to the trash.
+func (s *PropertyService) PreviewBulkDelete(ctx context.Context, req *models.BulkDeleteRequest) (*models.BulkPreview, error) {
+	matched, err := s.countBulk(ctx, &req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.previewBulk(ctx, &req.Filter, matched, nil, nil)
+}
+
+// BulkDelete queues the properties matching the filter to be moved to the trash in the background and
+// returns the job to poll.
+func (s *PropertyService) BulkDelete(ctx context.Context, req *models.BulkDeleteRequest, userID, role string) (*jobs.Job, error) {
+	matched, err := s.countBulk(ctx, &req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	payload := &propertyBulkPayload{Filter: req.Filter, Actor: userID, ActorRole: role, OrgID: tenant.OrgID(ctx)}
+	job, err := s.jobs.Enqueue(ctx, JobPropertyBulkDelete, payload, jobs.EnqueueOptions{CreatedBy: userID})
+	if err != nil {
+		return nil, utils.WrapError(err, "queue bulk delete failed: filter=%s", req.Filter.String())
+	}
+	s.auditLog.Record(ctx, models.AuditEvent{
+		Type:       models.AuditEventPropertyBulkDeleted,
+		TargetType: "job",
+		TargetID:   job.ID,
+		Details:    map[string]interface{}{"filter": req.Filter.String(), "matched": matched},
+	})
+	return job, nil
+}
+
+// PreviewBulkUpdate reports how many properties a bulk update would change, checking the update
+// against the first few of them.
+func (s *PropertyService) PreviewBulkUpdate(ctx context.Context, req *models.BulkUpdateRequest) (*models.BulkPreview, error) {
+	patch, paths, err := bulkPatch(req)
+	if err != nil {
+		return nil, err
+	}
+	matched, err := s.countBulk(ctx, &req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.previewBulk(ctx, &req.Filter, matched, patch, paths)
+}
+
+// BulkUpdate queues the field mask of the update to be written to every property matching the filter
+// in the background and returns the job to poll.
+func (s *PropertyService) BulkUpdate(ctx context.Context, req *models.BulkUpdateRequest, userID, role string) (*jobs.Job, error) {
+	patch, paths, err := bulkPatch(req)
+	if err != nil {
+		return nil, err
+	}
+	matched, err := s.countBulk(ctx, &req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	payload := &propertyBulkPayload{Filter: req.Filter, Patch: patch, Paths: paths, Actor: userID, ActorRole: role, OrgID: tenant.OrgID(ctx)}
+	job, err := s.jobs.Enqueue(ctx, JobPropertyBulkUpdate, payload, jobs.EnqueueOptions{CreatedBy: userID})
+	if err != nil {
+		return nil, utils.WrapError(err, "queue bulk update failed: filter=%s", req.Filter.String())
+	}
+	s.auditLog.Record(ctx, models.AuditEvent{
+		Type:       models.AuditEventPropertyBulkUpdated,
+		TargetType: "job",
+		TargetID:   job.ID,
+		Details:    map[string]interface{}{"filter": req.Filter.String(), "matched": matched, "fieldMask": paths},
+	})
+	return job, nil
+}
+
+// countBulk counts the properties a bulk operation would touch. The filter must narrow the operation
+// down, and at most the configured number of properties may match.
+func (s *PropertyService) countBulk(ctx context.Context, filter *models.PropertyFilter) (int64, error) {
+	if filter.IsEmpty() {
+		return 0, fmt.Errorf("invalid filter: a bulk operation needs at least one filter")
+	}
+	if err := s.validator.ValidateFilter(filter); err != nil {
+		return 0, err
+	}
+	matched, err := s.repo.CountMatching(ctx, filter)
+	if err != nil {
+		return 0, utils.WrapError(err, "database query failed: count bulk filter=%s", filter.String())
+	}
+	if max := s.config.Jobs.BulkMaxProperties; matched > int64(max) {
+		return 0, errors.NewAppError(
+			fmt.Sprintf("too many properties for bulk operation: matched=%d, max=%d", matched, max),
+			fmt.Sprintf("The filter matches %d properties; at most %d can be changed at once. Narrow it down and try again.", matched, max),
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+	return matched, nil
+}
+
+// previewBulk lists the first matching properties. With a patch, those it would leave invalid are
+// reported as errors.
+func (s *PropertyService) previewBulk(ctx context.Context, filter *models.PropertyFilter, matched int64, patch map[string]interface{}, paths []string) (*models.BulkPreview, error) {
+	var fields models.PropertyFields
+	if patch == nil {
+		fields = models.PropertyFields{"propertyId"}
+	}
+	sample, err := s.repo.FindMatchingAfter(ctx, filter, fields, primitive.NilObjectID, bulkPreviewSize)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: preview bulk filter=%s", filter.String())
+	}
+
+	preview := &models.BulkPreview{
+		DryRun:     true,
+		Matched:    matched,
+		SampleIDs:  make([]string, 0, len(sample)),
+		FieldMask:  paths,
+		MaxAllowed: s.config.Jobs.BulkMaxProperties,
+	}
+	for i := range sample {
+		preview.SampleIDs = append(preview.SampleIDs, sample[i].PropertyID)
+		if patch == nil {
+			continue
+		}
+		if _, err := s.applyPatch(&sample[i], patch); err != nil {
+			preview.Errors = append(preview.Errors, models.BulkPropertyError{PropertyID: sample[i].PropertyID, Error: errors.MapError(err).TechnicalMessage})
+		}
+	}
+	return preview, nil
+}
+
+// bulkPatch turns the field mask of a bulk update into a merge patch taking every masked path from
+// the update, with null for the paths it doesn't have so they are cleared. It also returns the paths
+// the patch writes.
+func bulkPatch(req *models.BulkUpdateRequest) (map[string]interface{}, []string, error) {
+	mask, err := models.ValidateFieldMask(req.FieldMask)
+	if err != nil {
+		return nil, nil, err
+	}
+	var update map[string]interface{}
+	if len(req.Update) > 0 {
+		if err := json.Unmarshal(req.Update, &update); err != nil {
+			return nil, nil, fmt.Errorf("invalid bulk update: update must be a JSON object")
+		}
+	}
+
+	patch := make(map[string]interface{})
+	for _, path := range mask {
+		names := strings.Split(path, ".")
+		target, source := patch, update
+		for _, name := range names[:len(names)-1] {
+			child, _ := target[name].(map[string]interface{})
+			if child == nil {
+				child = make(map[string]interface{})
+				target[name] = child
+			}
+			target = child
+			source, _ = source[name].(map[string]interface{})
+		}
+		last := names[len(names)-1]
+		target[last] = source[last]
+	}
+	if err := checkPatchFields(patch); err != nil {
+		return nil, nil, err
+	}
+	return patch, utils.MergePatchPaths(patch), nil
+}
+
+// startBulk picks up a bulk job's progress: from the attempt before when retried, otherwise by
+// counting the properties it is about to go through.
+func (s *PropertyService) startBulk(ctx context.Context, job *jobs.Job, filter *models.PropertyFilter) (*models.BulkProgress, primitive.ObjectID, error) {
+	progress := &models.BulkProgress{}
+	if len(job.Progress) > 0 && json.Unmarshal(job.Progress, progress) == nil {
+		afterID, _ := primitive.ObjectIDFromHex(progress.AfterID)
+		return progress, afterID, nil
+	}
+	matched, err := s.repo.CountMatching(ctx, filter)
+	if err != nil {
+		return nil, primitive.NilObjectID, utils.WrapError(err, "database query failed: count bulk filter=%s", filter.String())
+	}
+	progress.Matched = matched
+	return progress, primitive.NilObjectID, nil
+}
+
+// reportBulk records a bulk job's progress after a batch. The batch is written by then, so failing
+// to report it only costs a retried attempt going through it again.
+func (s *PropertyService) reportBulk(ctx context.Context, job *jobs.Job, progress *models.BulkProgress) {
+	if err := s.jobs.ReportProgress(ctx, job, progress); err != nil {
+		logger.GlobalLogger.Warnf("Failed to report bulk job progress: jobId=%s, error=%v", job.ID, err)
+	}
+}
+
+// runBulkDelete moves the properties matching a bulk delete's filter to the trash, a batch at a time,
+// each batch atomically with its audit records. Deleted properties stop matching, so a retried attempt
+// only has the rest left.
+func (s *PropertyService) runBulkDelete(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload propertyBulkPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, err
+	}
+	ctx = WithAuditActor(tenant.WithOrgID(ctx, payload.OrgID), payload.Actor, payload.ActorRole)
+	progress, afterID, err := s.startBulk(ctx, job, &payload.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := models.PropertyFields{"propertyId", "orgId"}
+	for {
+		batch, err := s.repo.FindMatchingAfter(ctx, &payload.Filter, fields, afterID, s.config.Jobs.BulkBatchSize)
+		if err != nil {
+			return nil, utils.WrapError(err, "database query failed: bulk delete filter=%s", payload.Filter.String())
+		}
+		if len(batch) == 0 {
+			break
+		}
+		ids := make([]string, len(batch))
+		for i := range batch {
+			ids[i] = batch[i].PropertyID
+		}
+
+		var deleted int64
+		err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			if deleted, err = s.repo.DeleteMany(ctx, ids); err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := s.owners.RemoveProperty(ctx, id); err != nil {
+					logger.GlobalLogger.Errorf("Failed to remove property from owner index: id=%s, error=%v", id, err)
+				}
+				s.audit.Record(ctx, models.AuditActionDeleted, id, nil, nil)
+				s.events.Record(ctx, models.EventPropertyDeleted, id, nil)
+			}
+			repositories.AfterCommit(ctx, func() {
+				for i, id := range ids {
+					if err := s.cache.InvalidatePropertyCacheKeys(ctx, id); err != nil {
+						logger.GlobalLogger.Errorf("Failed to invalidate cache keys: id=%s, error=%v", id, err)
+					}
+					s.webhooks.Publish(tenant.WithOrgID(ctx, batch[i].OrgID), models.EventPropertyDeleted, id, nil)
+				}
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, utils.WrapError(err, "bulk delete failed: afterId=%s, count=%d", afterID.Hex(), len(ids))
+		}
+
+		afterID = batch[len(batch)-1].ID
+		progress.Processed += int64(len(batch))
+		progress.Succeeded += deleted
+		progress.AfterID = afterID.Hex()
+		s.reportBulk(ctx, job, progress)
+	}
+	logger.GlobalLogger.Printf("Bulk delete finished: jobId=%s, matched=%d, deleted=%d", job.ID, progress.Matched, progress.Succeeded)
+	return progress, nil
+}
+
+// runBulkUpdate writes a bulk update's patch to the properties matching its filter, a batch at a time,
+// each batch atomically with its audit records. Properties the patch would leave invalid are skipped
+// and reported. A retried attempt resumes after the last batch reported.
+func (s *PropertyService) runBulkUpdate(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload propertyBulkPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, err
+	}
+	ctx = WithAuditActor(tenant.WithOrgID(ctx, payload.OrgID), payload.Actor, payload.ActorRole)
+	progress, afterID, err := s.startBulk(ctx, job, &payload.Filter)
+	if err != nil {
+		return nil, err
+	}
+	paths := append(payload.Paths, "dataQuality")
+
+	for {
+		batch, err := s.repo.FindMatchingAfter(ctx, &payload.Filter, nil, afterID, s.config.Jobs.BulkBatchSize)
+		if err != nil {
+			return nil, utils.WrapError(err, "database query failed: bulk update filter=%s", payload.Filter.String())
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		now := time.Now().UTC()
+		updated := make([]*models.Property, 0, len(batch))
+		before := make(map[string]*models.Property, len(batch))
+		for i := range batch {
+			existing := &batch[i]
+			property, err := s.applyPatch(existing, payload.Patch)
+			if err != nil {
+				progress.Failed++
+				if len(progress.Errors) < maxBulkErrors {
+					progress.Errors = append(progress.Errors, models.BulkPropertyError{PropertyID: existing.PropertyID, Error: errors.MapError(err).TechnicalMessage})
+				}
+				continue
+			}
+			property.UpdatedAt = now
+			updated = append(updated, property)
+			before[property.PropertyID] = existing
+		}
+
+		var matched int64
+		err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			if matched, err = s.repo.PatchMany(ctx, updated, paths); err != nil {
+				return err
+			}
+			for _, property := range updated {
+				if err := s.owners.IndexProperty(ctx, property); err != nil {
+					logger.GlobalLogger.Errorf("Failed to index property owners: id=%s, error=%v", property.PropertyID, err)
+				}
+				s.audit.Record(ctx, models.AuditActionUpdated, property.PropertyID, before[property.PropertyID], property)
+				s.events.Record(ctx, models.EventPropertyUpdated, property.PropertyID, property)
+			}
+			repositories.AfterCommit(ctx, func() {
+				for _, property := range updated {
+					s.recache(ctx, property)
+					s.webhooks.Publish(ctx, models.EventPropertyUpdated, property.PropertyID, property)
+				}
+			})
+			return nil
+		})
+		if err != nil {
+			err = utils.WrapError(err, "bulk update failed: afterId=%s, count=%d", afterID.Hex(), len(updated))
+			if !utils.IsRetryableError(err) {
+				// e.g. the update moves properties onto the same address; retrying can't help
+				err = jobs.Permanent(err)
+			}
+			return nil, err
+		}
+
+		afterID = batch[len(batch)-1].ID
+		progress.Processed += int64(len(batch))
+		progress.Succeeded += matched
+		progress.AfterID = afterID.Hex()
+		s.reportBulk(ctx, job, progress)
+	}
+	logger.GlobalLogger.Printf("Bulk update finished: jobId=%s, matched=%d, updated=%d, failed=%d", job.ID, progress.Matched, progress.Succeeded, progress.Failed)
+	return progress, nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PropertyDiffService keeps what each refresh from a data provider changed in a property, for
+// showing users what changed since they last looked.
+type PropertyDiffService struct {
+	repo repositories.PropertyDiffRepository
+}
+
+func NewPropertyDiffService(repo repositories.PropertyDiffRepository) *PropertyDiffService {
+	return &PropertyDiffService{repo: repo}
+}
+
+// RecordRefresh stores the diff between a property before and after a refresh from source, unless
+// nothing changed. Derived fields such as the data quality score are left out. Failures are logged
+// rather than returned so the refresh itself never fails.
+func (s *PropertyDiffService) RecordRefresh(ctx context.Context, source string, before, after *models.Property) {
+	if s == nil || before == nil || after == nil {
+		return
+	}
+	all, err := diffProperties(before, after)
+	if err != nil {
+		logger.GlobalLogger.WithContext(ctx).Errorf("Failed to diff refreshed property: propertyId=%s, error=%v", after.PropertyID, err)
+		return
+	}
+	diff := &models.PropertyDiff{
+		ID:          primitive.NewObjectID(),
+		PropertyID:  after.PropertyID,
+		Source:      source,
+		RefreshedAt: time.Now().UTC(),
+	}
+	for _, change := range all {
+		if change.Path == "dataQuality" || strings.HasPrefix(change.Path, "dataQuality.") {
+			continue
+		}
+		diff.Changes = append(diff.Changes, change)
+		if strings.HasPrefix(change.Path, "building.") {
+			diff.Building = append(diff.Building, change)
+		}
+	}
+	if len(diff.Changes) == 0 {
+		return
+	}
+	diff.AssessedValue = assessedValueChange(before, after)
+	diff.NewSale = newSale(before, after)
+
+	if err := s.repo.Create(ctx, diff); err != nil {
+		logger.GlobalLogger.WithContext(ctx).Errorf("Failed to record property diff: propertyId=%s, error=%v", after.PropertyID, err)
+	}
+}
+
+// Diffs returns a page of a property's refresh diffs, newest first.
+func (s *PropertyDiffService) Diffs(ctx context.Context, propertyID string, offset, limit int, baseURL string, params url.Values) (*models.PropertyDiffsResponse, error) {
+	diffs, total, err := s.repo.FindByProperty(ctx, propertyID, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: property diffs propertyId=%s", propertyID)
+	}
+	if diffs == nil {
+		diffs = []models.PropertyDiff{}
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+	return &models.PropertyDiffsResponse{Data: diffs, Metadata: metadata}, nil
+}
+
+// assessedValueChange compares the latest total assessed values, or returns nil when it is unchanged
+// or no longer known.
+func assessedValueChange(before, after *models.Property) *models.AssessedValueChange {
+	previous, current := before.TaxAssessment.AssessedValue.TotalValue, after.TaxAssessment.AssessedValue.TotalValue
+	if current == 0 || current == previous {
+		return nil
+	}
+	change := &models.AssessedValueChange{
+		PreviousYear: before.TaxAssessment.Year,
+		Year:         after.TaxAssessment.Year,
+		Previous:     previous,
+		Current:      current,
+		Change:       current - previous,
+	}
+	if previous > 0 {
+		change.ChangePercent = math.Round(float64(current-previous)/float64(previous)*10000) / 100
+	}
+	return change
+}
+
+// newSale returns the last market sale after a refresh when it is more recent than the one before,
+// or nil. Sale dates are ISO dates, so they compare as strings.
+func newSale(before, after *models.Property) *models.SaleChange {
+	previous, current := before.LastMarketSale, after.LastMarketSale
+	if current.Date == "" || current.Date <= previous.Date {
+		return nil
+	}
+	return &models.SaleChange{
+		Date:           current.Date,
+		Amount:         current.Amount,
+		PreviousDate:   previous.Date,
+		PreviousAmount: previous.Amount,
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FullTextSearch ranks properties against a free-text query over address, owner names,
+// subdivision and school district.
+func (s *PropertySearchService) FullTextSearch(ctx context.Context, query string, offset, limit int, baseURL string, params url.Values) (*models.PaginatedPropertiesResponse, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	cacheKey := cache.PropertyFullTextSearchKey(tenant.OrgID(ctx), query, offset, limit)
+	ginCtx.Set("data_source", "REDIS")
+	ginCtx.Set("query", query+",offset="+strconv.Itoa(offset)+",limit="+strconv.Itoa(limit))
+
+	var properties []models.Property
+	var total int64
+
+	// Check cache; hits hold ranked IDs, so hydrate them by primary key instead of re-scoring
+	cached, err := s.cache.GetSearchResult(ctx, cacheKey)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Cache lookup failed for full-text search: cacheKey=%s, error=%v", cacheKey, err)
+	}
+	if cached != nil {
+		hydrated, err := s.hydrate(ctx, cached.PropertyIDs, nil)
+		if err == nil && len(hydrated) == len(cached.PropertyIDs) {
+			ginCtx.Set("cache_hit", true)
+			properties = hydrated
+			total = cached.Total
+		}
+	}
+
+	if properties == nil {
+		ginCtx.Set("cache_hit", false)
+		ginCtx.Set("data_source", "DATABASE")
+
+		if s.index != nil {
+			properties, total, err = s.index.TextSearch(ctx, query, offset, limit)
+			if err == nil {
+				ginCtx.Set("data_source", "SEARCH_INDEX")
+			} else {
+				logger.GlobalLogger.Warnf("Search index query failed, falling back to database: query=%s, error=%v", query, err)
+			}
+		}
+		for attempt := 1; properties == nil && attempt <= s.config.ErrorHandling.RetryAttempts; attempt++ {
+			properties, total, err = s.repo.TextSearch(ctx, query, offset, limit)
+			if err == nil || !utils.IsRetryableError(err) {
+				break
+			}
+			logger.GlobalLogger.Warnf("Database query attempt %d/%d failed: query=%s, error=%v", attempt, s.config.ErrorHandling.RetryAttempts, query, err)
+			time.Sleep(time.Duration(s.config.ErrorHandling.RetryDelayMS) * time.Millisecond)
+		}
+		if err != nil {
+			return nil, utils.LogAndMapError(ctx, utils.WrapError(err, "database query failed: query=%s", query),
+				"full-text search",
+				"query", query,
+				"offset", offset,
+				"limit", limit)
+		}
+
+		ids := make([]string, 0, len(properties))
+		for _, property := range properties {
+			ids = append(ids, property.PropertyID)
+		}
+		if err := s.cache.SetSearchResult(ctx, cacheKey, &models.CachedSearchResult{PropertyIDs: ids, Total: total}, s.cache.TTL(cache.ClassList)); err != nil {
+			logger.GlobalLogger.Warnf("Failed to cache full-text search: cacheKey=%s, error=%v", cacheKey, err)
+		}
+	}
+	if properties == nil {
+		properties = []models.Property{}
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+
+	return &models.PaginatedPropertiesResponse{
+		Data:     properties,
+		Metadata: metadata,
+	}, nil
+}
+
+// hydrate loads the properties of a cached result page in ranked order, taking those in the property
+// cache from it in one round trip and the rest from the database. Selected fields always come from
+// the database.
+func (s *PropertySearchService) hydrate(ctx context.Context, ids []string, fields models.PropertyFields) ([]models.Property, error) {
+	var properties []models.Property
+	missing := ids
+	if len(fields) == 0 {
+		cached, err := s.cache.GetProperties(ctx, ids)
+		if err != nil {
+			logger.GlobalLogger.Warnf("Cache lookup failed for result page properties: count=%d, error=%v", len(ids), err)
+		}
+		missing = make([]string, 0, len(ids))
+		for _, id := range ids {
+			if property, ok := cached[id]; ok {
+				properties = append(properties, *property)
+			} else {
+				missing = append(missing, id)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		loaded, err := s.repo.FindByIDs(ctx, missing, fields, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, loaded...)
+	}
+	return orderByIDs(properties, ids), nil
+}
+
+// orderByIDs restores the ranked order of a cached result page.
+func orderByIDs(properties []models.Property, ids []string) []models.Property {
+	byID := make(map[string]models.Property, len(properties))
+	for _, property := range properties {
+		byID[property.PropertyID] = property
+	}
+	ordered := make([]models.Property, 0, len(ids))
+	for _, id := range ids {
+		if property, ok := byID[id]; ok {
+			ordered = append(ordered, property)
+		}
+	}
+	return ordered
+}
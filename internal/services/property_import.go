@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/jobs"
+)
+
+// JobPropertyImport is the background job type creating properties in bulk.
+const JobPropertyImport = "property.import"
+
+// propertyImportPayload carries the importing user along, so the audit history credits them, and
+// their organization, which the properties are created in.
+type propertyImportPayload struct {
+	Properties []models.Property `json:"properties"`
+	Actor      string            `json:"actor"`
+	ActorRole  string            `json:"actorRole"`
+	OrgID      string            `json:"orgId"`
+}
+
+// ImportProperties queues the properties to be created in the background and returns the job to poll.
+func (s *PropertyService) ImportProperties(ctx context.Context, req *models.ImportPropertiesRequest, userID, role string) (*jobs.Job, error) {
+	if len(req.Properties) > s.config.Jobs.ImportMaxProperties {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("too many properties to import: count=%d, max=%d", len(req.Properties), s.config.Jobs.ImportMaxProperties),
+			fmt.Sprintf("At most %d properties can be imported per request.", s.config.Jobs.ImportMaxProperties),
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	payload := &propertyImportPayload{Properties: req.Properties, Actor: userID, ActorRole: role, OrgID: tenant.OrgID(ctx)}
+	job, err := s.jobs.Enqueue(ctx, JobPropertyImport, payload, jobs.EnqueueOptions{CreatedBy: userID})
+	if err != nil {
+		return nil, utils.WrapError(err, "queue property import failed: count=%d", len(req.Properties))
+	}
+	return job, nil
+}
+
+// runImport creates the properties of an import job one by one, each atomically with its audit
+// record. A retried import skips the ones an earlier attempt already created; only failures that may
+// go away on retry fail the attempt.
+func (s *PropertyService) runImport(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload propertyImportPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, err
+	}
+	ctx = WithAuditActor(tenant.WithOrgID(ctx, payload.OrgID), payload.Actor, payload.ActorRole)
+
+	result := &models.ImportPropertiesResult{Total: len(payload.Properties)}
+	for i := range payload.Properties {
+		property := &payload.Properties[i]
+		propertyID := property.PropertyID
+		err := s.CreateProperty(ctx, property)
+		switch {
+		case err == nil:
+			result.Created++
+		case errors.MapError(err).Code == errors.ErrCodePropertyExists:
+			result.Skipped++
+		case utils.IsRetryableError(err) || ctx.Err() != nil:
+			return nil, utils.WrapError(err, "import property failed: index=%d, propertyId=%s", i, propertyID)
+		default:
+			result.Failed++
+			result.Errors = append(result.Errors, models.ImportPropertyError{Index: i, PropertyID: propertyID, Error: errors.MapError(err).TechnicalMessage})
+		}
+	}
+	return result, nil
+}
+
+// ApplyFeedProperty creates a property from a data feed record, or updates the one already stored
+// under its ID or address. A record carries only part of a property, so an update sets just the fields
+// the record has a value for; a tax assessment joins the property's history, replacing the same year.
+func (s *PropertyService) ApplyFeedProperty(ctx context.Context, property *models.Property) (bool, error) {
+	// CreateProperty overwrites property with the stored one when it already exists
+	body, err := json.Marshal(property)
+	if err != nil {
+		return false, err
+	}
+	assessment := property.TaxAssessment
+
+	err = s.CreateProperty(ctx, property)
+	if err == nil {
+		return true, nil
+	}
+	if errors.MapError(err).Code != errors.ErrCodePropertyExists {
+		return false, err
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return false, err
+	}
+	for _, field := range immutablePatchFields {
+		delete(patch, field)
+	}
+	patch = utils.CompactPatch(patch)
+	if assessment.Year > 0 {
+		history := []models.TaxAssessment{assessment}
+		for _, existing := range property.TaxAssessments {
+			if existing.Year != assessment.Year {
+				history = append(history, existing)
+			}
+		}
+		patch["taxAssessments"] = history
+	}
+	if len(patch) == 0 {
+		return false, nil
+	}
+	if body, err = json.Marshal(patch); err != nil {
+		return false, err
+	}
+	if _, err := s.PatchProperty(ctx, property.PropertyID, body); err != nil {
+		return false, err
+	}
+	return false, nil
+}
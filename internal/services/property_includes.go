@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/logger"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Related resources ?include= can embed in property responses.
+const (
+	IncludeListing      = "listing"
+	IncludeMedia        = "media"
+	IncludeTransactions = "transactions"
+	IncludeValuation    = "valuation"
+)
+
+// Includable lists the names ?include= accepts.
+var Includable = []string{IncludeListing, IncludeMedia, IncludeTransactions, IncludeValuation}
+
+// PropertyIncludeService embeds related resources in a property, so clients get a property and
+// what they show alongside it in one request instead of one per resource.
+type PropertyIncludeService struct {
+	listings     *ListingService
+	media        *PropertyMediaService
+	transactions *TransactionService
+	valuations   *ValuationService
+}
+
+// NewPropertyIncludeService builds the include service; media is nil when media storage is disabled.
+func NewPropertyIncludeService(listings *ListingService, media *PropertyMediaService, transactions *TransactionService, valuations *ValuationService) *PropertyIncludeService {
+	return &PropertyIncludeService{
+		listings:     listings,
+		media:        media,
+		transactions: transactions,
+		valuations:   valuations,
+	}
+}
+
+// Attach resolves the included resources of a property concurrently, each from its own cache before
+// the database. A resource that fails to load is logged and left out rather than failing the read of
+// the property.
+func (s *PropertyIncludeService) Attach(ctx context.Context, property *models.Property, include map[string]bool) {
+	if len(include) == 0 {
+		return
+	}
+	// Each resolver fills in a field of its own, so they can share the property. They get a context
+	// of their own too, so the request's data source and cache hit aren't overwritten by theirs.
+	g, gctx := errgroup.WithContext(ctx)
+	if include[IncludeListing] {
+		g.Go(func() error {
+			s.listings.AttachListing(gctx, property)
+			return nil
+		})
+	}
+	if include[IncludeMedia] {
+		g.Go(func() error {
+			s.media.AttachMedia(gctx, property)
+			return nil
+		})
+	}
+	if include[IncludeTransactions] {
+		g.Go(func() error {
+			transactions, err := s.transactions.Recent(gctx, property.PropertyID)
+			if err != nil {
+				logger.GlobalLogger.WithContext(ctx).Errorf("Failed to load included transactions: propertyId=%s, error=%v", property.PropertyID, err)
+				return nil
+			}
+			property.RecentTransactions = transactions
+			return nil
+		})
+	}
+	if include[IncludeValuation] {
+		g.Go(func() error {
+			valuation, err := s.valuations.GetValuation(gctx, property.PropertyID)
+			if err != nil {
+				logger.GlobalLogger.WithContext(ctx).Errorf("Failed to load included valuation: propertyId=%s, error=%v", property.PropertyID, err)
+				return nil
+			}
+			property.Valuation = valuation
+			return nil
+		})
+	}
+	g.Wait()
+}
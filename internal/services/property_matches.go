@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchMatchingProperties returns every stored property an address search could mean, best match
+// first, for searches that are ambiguous such as "123 Main St" on a building with several units.
+// When no stored property matches, it falls back to the single-property search, which may fetch the
+// property from CoreLogic.
+func (s *PropertySearchService) SearchMatchingProperties(ctx context.Context, req *models.SearchRequest, limit int) (*models.PropertyMatchesResponse, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+
+	if err := s.validator.ValidateSearch(req); err != nil {
+		return nil, utils.LogAndMapError(ctx, err, "validate search request", "query", req.Search)
+	}
+	street, city, state, zip := s.addrTrans.ParseAddress(req.Search)
+	if street == "" || city == "" {
+		err := fmt.Errorf("street address and city are required")
+		return nil, utils.LogAndMapError(ctx, err, "parse address", "query", req.Search)
+	}
+	if address := s.standardizer.Standardize(ctx, street, city, state, zip); address != nil {
+		street, city, state, zip = address.Street, address.City, address.State, address.Zip
+	}
+	canonical := s.addrTrans.CanonicalizeStreet(street)
+	houseNumber, _ := s.addrTrans.SplitStreet(canonical)
+	if houseNumber == "" {
+		// Without a house number there are no candidates to rank
+		return s.singleMatch(ctx, req, street, limit)
+	}
+
+	cacheKey := cache.PropertyMatchesSearchKey(tenant.OrgID(ctx), canonical, city, state, zip)
+	ginCtx.Set("data_source", "REDIS")
+	ginCtx.Set("query", req.Search)
+
+	// Check cache; hits hold ranked IDs, so hydrate them by primary key instead of re-ranking
+	var properties []models.Property
+	cached, err := s.cache.GetSearchResult(ctx, cacheKey)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Cache lookup failed for property matches: cacheKey=%s, error=%v", cacheKey, err)
+	}
+	if cached != nil && len(cached.PropertyIDs) > 0 {
+		hydrated, err := s.hydrate(ctx, cached.PropertyIDs, nil)
+		if err == nil && len(hydrated) == len(cached.PropertyIDs) {
+			ginCtx.Set("cache_hit", true)
+			properties = hydrated
+		}
+	}
+
+	var matches []models.PropertyMatch
+	if properties != nil {
+		for _, property := range properties {
+			matches = append(matches, models.PropertyMatch{
+				Property:        property,
+				MatchConfidence: s.addrTrans.MatchConfidence(street, property.Address.StreetAddress),
+			})
+		}
+	} else {
+		ginCtx.Set("cache_hit", false)
+		ginCtx.Set("data_source", "DATABASE")
+
+		candidates, err := s.repo.FindAddressCandidates(ctx, houseNumber, city, state, zip, s.config.Live().AddressMatching.MaxCandidates)
+		if err != nil {
+			return nil, utils.LogAndMapError(ctx, utils.WrapError(err, "database query failed: query=%s", req.Search),
+				"search matching properties",
+				"query", req.Search)
+		}
+		matches = rankMatches(candidates, func(candidate string) float64 {
+			return s.addrTrans.MatchConfidence(street, candidate)
+		}, s.config.Live().AddressMatching.MinConfidence)
+		if len(matches) == 0 {
+			return s.singleMatch(ctx, req, street, limit)
+		}
+
+		ids := make([]string, 0, len(matches))
+		for _, match := range matches {
+			ids = append(ids, match.PropertyID)
+		}
+		if err := s.cache.SetSearchResult(ctx, cacheKey, &models.CachedSearchResult{PropertyIDs: ids, Total: int64(len(ids))}, s.cache.TTL(cache.ClassList)); err != nil {
+			logger.GlobalLogger.Warnf("Failed to cache property matches: cacheKey=%s, error=%v", cacheKey, err)
+		}
+	}
+
+	total := int64(len(matches))
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return &models.PropertyMatchesResponse{
+		Query:    req.Search,
+		Data:     matches,
+		Metadata: models.PaginationMeta{Total: total, Limit: limit},
+	}, nil
+}
+
+// singleMatch answers a match search with the property the single-property search resolves.
+func (s *PropertySearchService) singleMatch(ctx context.Context, req *models.SearchRequest, street string, limit int) (*models.PropertyMatchesResponse, error) {
+	property, err := s.SearchSpecificProperty(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PropertyMatchesResponse{
+		Query: req.Search,
+		Data: []models.PropertyMatch{{
+			Property:        *property,
+			MatchConfidence: s.addrTrans.MatchConfidence(street, property.Address.StreetAddress),
+		}},
+		Metadata: models.PaginationMeta{Total: 1, Limit: limit},
+	}, nil
+}
+
+// rankMatches keeps the candidates whose street address reaches minConfidence, best match first.
+// Equally good matches, such as the units of one building, are ordered by street address.
+func rankMatches(candidates []models.Property, confidence func(string) float64, minConfidence float64) []models.PropertyMatch {
+	var matches []models.PropertyMatch
+	for _, candidate := range candidates {
+		if c := confidence(candidate.Address.StreetAddress); c >= minConfidence {
+			matches = append(matches, models.PropertyMatch{Property: candidate, MatchConfidence: c})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].MatchConfidence != matches[j].MatchConfidence {
+			return matches[i].MatchConfidence > matches[j].MatchConfidence
+		}
+		if matches[i].Address.StreetAddress != matches[j].Address.StreetAddress {
+			return matches[i].Address.StreetAddress < matches[j].Address.StreetAddress
+		}
+		return matches[i].PropertyID < matches[j].PropertyID
+	})
+	return matches
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxPhotoPixels rejects images whose decoded size would exhaust memory, whatever their file size.
+const maxPhotoPixels = 50_000_000
+
+// photoContentTypes are the image formats photos can be uploaded in.
+var photoContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// PropertyMediaService stores photos and documents of properties in object storage and their
+// metadata in MongoDB.
+type PropertyMediaService struct {
+	repo       repositories.PropertyMediaRepository
+	properties repositories.PropertyRepository
+	cache      repositories.PropertyCache
+	storage    storage.ObjectStorage
+	config     *config.Config
+}
+
+func NewPropertyMediaService(repo repositories.PropertyMediaRepository, properties repositories.PropertyRepository, propertyCache repositories.PropertyCache, store storage.ObjectStorage, cfg *config.Config) *PropertyMediaService {
+	return &PropertyMediaService{
+		repo:       repo,
+		properties: properties,
+		cache:      propertyCache,
+		storage:    store,
+		config:     cfg,
+	}
+}
+
+// Upload stores a file for a property. Photos must be JPEG, PNG or GIF and get a JPEG thumbnail;
+// documents must be of a configured content type. The type is detected from the content, not taken
+// from the client.
+func (s *PropertyMediaService) Upload(ctx context.Context, propertyID, kind string, file *multipart.FileHeader, caption, userID string) (*models.PropertyMedia, error) {
+	maxBytes := int64(s.config.Media.MaxUploadMB) << 20
+	if file.Size > maxBytes {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("upload too large: size=%d, max=%d", file.Size, maxBytes),
+			fmt.Sprintf("Files can be at most %d MB.", s.config.Media.MaxUploadMB),
+			errors.ErrCodeInvalidParameters,
+			http.StatusRequestEntityTooLarge,
+			nil,
+		)
+	}
+	property, err := s.properties.FindByID(ctx, propertyID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: id=%s", propertyID)
+	}
+	if property == nil {
+		return nil, fmt.Errorf("property not found: id=%s", propertyID)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("read upload failed: %v", err)
+	}
+	defer src.Close()
+	data, err := io.ReadAll(io.LimitReader(src, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read upload failed: %v", err)
+	}
+
+	media := &models.PropertyMedia{
+		ID:          primitive.NewObjectID(),
+		PropertyID:  propertyID,
+		Kind:        kind,
+		FileName:    path.Base(file.Filename),
+		ContentType: http.DetectContentType(data),
+		Size:        int64(len(data)),
+		Caption:     strings.TrimSpace(caption),
+		UploadedBy:  userID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	prefix := fmt.Sprintf("properties/%s/%ss/%s", propertyID, kind, media.ID.Hex())
+
+	var thumb []byte
+	switch kind {
+	case models.MediaKindPhoto:
+		ext, ok := photoContentTypes[media.ContentType]
+		if !ok {
+			return nil, invalidUpload(fmt.Sprintf("unsupported photo type: contentType=%s", media.ContentType), "Photos must be JPEG, PNG or GIF images.")
+		}
+		media.StorageKey = prefix + ext
+		media.ThumbnailKey = prefix + "_thumb.jpg"
+		if thumb, media.Width, media.Height, err = makeThumbnail(data, s.config.Media.ThumbnailSize); err != nil {
+			return nil, invalidUpload(fmt.Sprintf("invalid photo: %v", err), "The photo could not be read. Please upload a valid image.")
+		}
+	default:
+		if !slices.Contains(s.config.Media.DocumentContentTypes, media.ContentType) {
+			return nil, invalidUpload(fmt.Sprintf("unsupported document type: contentType=%s", media.ContentType),
+				fmt.Sprintf("Documents must be one of: %s.", strings.Join(s.config.Media.DocumentContentTypes, ", ")))
+		}
+		media.StorageKey = prefix
+		if exts, _ := mime.ExtensionsByType(media.ContentType); len(exts) > 0 {
+			media.StorageKey += exts[0]
+		}
+	}
+
+	if err := s.storage.Put(ctx, media.StorageKey, media.ContentType, data); err != nil {
+		return nil, utils.WrapError(err, "store media failed: propertyId=%s", propertyID)
+	}
+	if thumb != nil {
+		if err := s.storage.Put(ctx, media.ThumbnailKey, "image/jpeg", thumb); err != nil {
+			s.deleteObjects(ctx, media.StorageKey)
+			return nil, utils.WrapError(err, "store media thumbnail failed: propertyId=%s", propertyID)
+		}
+	}
+	if err := s.repo.Create(ctx, media); err != nil {
+		s.deleteObjects(ctx, media.StorageKey, media.ThumbnailKey)
+		return nil, utils.WrapError(err, "database insert failed: media propertyId=%s", propertyID)
+	}
+	s.invalidate(ctx, propertyID)
+	s.sign(media)
+	return media, nil
+}
+
+// AttachMedia fills in a property's photos and documents with fresh signed URLs. The media are cached
+// without their URLs, which are signed on every read so they never expire early. Failures are logged
+// rather than failing the read of the property.
+func (s *PropertyMediaService) AttachMedia(ctx context.Context, property *models.Property) {
+	if s == nil {
+		return
+	}
+	key := cache.MediaKey(property.OrgID, property.PropertyID)
+	media, ok, err := s.cache.GetMedia(ctx, key)
+	if err != nil || !ok {
+		media, err = s.repo.FindByPropertyID(ctx, property.PropertyID)
+		if err != nil {
+			logger.GlobalLogger.Errorf("Failed to load property media: propertyId=%s, error=%v", property.PropertyID, err)
+			return
+		}
+		if media == nil {
+			media = []models.PropertyMedia{}
+		}
+		if err := s.cache.SetMedia(ctx, key, property.PropertyID, media, s.cache.TTL(cache.ClassProperty)); err != nil {
+			logger.GlobalLogger.Errorf("Failed to cache property media: propertyId=%s, error=%v", property.PropertyID, err)
+		}
+	}
+	for i := range media {
+		s.sign(&media[i])
+	}
+	property.Media = media
+}
+
+// Delete removes a photo or document and its stored files.
+func (s *PropertyMediaService) Delete(ctx context.Context, propertyID, id string) error {
+	media, err := s.repo.FindByID(ctx, propertyID, id)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: media id=%s", id)
+	}
+	if media == nil {
+		return fmt.Errorf("media not found: propertyId=%s, id=%s", propertyID, id)
+	}
+	if _, err := s.repo.Delete(ctx, propertyID, id); err != nil {
+		return utils.WrapError(err, "database delete failed: media id=%s", id)
+	}
+	s.invalidate(ctx, propertyID)
+	s.deleteObjects(ctx, media.StorageKey, media.ThumbnailKey)
+	return nil
+}
+
+// invalidate drops a property's cached media after one was added or removed.
+func (s *PropertyMediaService) invalidate(ctx context.Context, propertyID string) {
+	if err := s.cache.Delete(ctx, cache.MediaKey(tenant.OrgID(ctx), propertyID)); err != nil {
+		logger.GlobalLogger.Warnf("Failed to invalidate cached media: propertyId=%s, error=%v", propertyID, err)
+	}
+}
+
+func (s *PropertyMediaService) sign(media *models.PropertyMedia) {
+	ttl := time.Duration(s.config.Media.SignedURLTTLMinutes) * time.Minute
+	var err error
+	if media.URL, err = s.storage.SignedURL(media.StorageKey, ttl); err != nil {
+		logger.GlobalLogger.Errorf("Failed to sign media URL: id=%s, error=%v", media.ID.Hex(), err)
+	}
+	if media.ThumbnailKey != "" {
+		if media.ThumbnailURL, err = s.storage.SignedURL(media.ThumbnailKey, ttl); err != nil {
+			logger.GlobalLogger.Errorf("Failed to sign media thumbnail URL: id=%s, error=%v", media.ID.Hex(), err)
+		}
+	}
+}
+
+// deleteObjects removes stored files best-effort; an orphaned object only costs storage.
+func (s *PropertyMediaService) deleteObjects(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil {
+			logger.GlobalLogger.Warnf("Failed to delete media object: key=%s, error=%v", key, err)
+		}
+	}
+}
+
+func invalidUpload(technical, user string) error {
+	return errors.NewAppError(technical, user, errors.ErrCodeInvalidParameters, http.StatusBadRequest, nil)
+}
+
+// makeThumbnail decodes an image and returns a JPEG scaled to fit maxSize on its longest side, along
+// with the original dimensions. Transparent areas are flattened onto white.
+func makeThumbnail(data []byte, maxSize int) ([]byte, int, int, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if cfg.Width*cfg.Height > maxPhotoPixels {
+		return nil, 0, 0, fmt.Errorf("image too large: %dx%d", cfg.Width, cfg.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	thumbWidth, thumbHeight := width, height
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			thumbWidth, thumbHeight = maxSize, max(1, height*maxSize/width)
+		} else {
+			thumbWidth, thumbHeight = max(1, width*maxSize/height), maxSize
+		}
+	}
+
+	// Average the source pixels each thumbnail pixel covers
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		y0 := bounds.Min.Y + y*height/thumbHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/thumbHeight)
+		for x := 0; x < thumbWidth; x++ {
+			x0 := bounds.Min.X + x*width/thumbWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/thumbWidth)
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr + 0xffff - pa)
+					g += uint64(pg + 0xffff - pa)
+					b += uint64(pb + 0xffff - pa)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: 0xffff})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), width, height, nil
+}
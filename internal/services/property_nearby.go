@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FindNearby returns properties within radiusMeters of a point, nearest first.
+func (s *PropertySearchService) FindNearby(ctx context.Context, lat, lng, radiusMeters float64, offset, limit int, baseURL string, params url.Values) (*models.NearbyPropertiesResponse, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+	query := fmt.Sprintf("lat=%f,lng=%f,radius=%.0f", lat, lng, radiusMeters)
+	ginCtx.Set("data_source", "DATABASE")
+	ginCtx.Set("query", query)
+
+	var properties []models.NearbyProperty
+	var total int64
+	var err error
+	if s.index != nil {
+		properties, total, err = s.index.FindNearby(ctx, lat, lng, radiusMeters, offset, limit)
+		if err == nil {
+			ginCtx.Set("data_source", "SEARCH_INDEX")
+		} else {
+			logger.GlobalLogger.Warnf("Search index query failed, falling back to database: query=%s, error=%v", query, err)
+		}
+	}
+	for attempt := 1; properties == nil && attempt <= s.config.ErrorHandling.RetryAttempts; attempt++ {
+		properties, total, err = s.repo.FindNearby(ctx, lat, lng, radiusMeters, offset, limit)
+		if err == nil || !utils.IsRetryableError(err) {
+			break
+		}
+		logger.GlobalLogger.Warnf("Database query attempt %d/%d failed: query=%s, error=%v", attempt, s.config.ErrorHandling.RetryAttempts, query, err)
+		time.Sleep(time.Duration(s.config.ErrorHandling.RetryDelayMS) * time.Millisecond)
+	}
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: %s", query)
+	}
+	if properties == nil {
+		properties = []models.NearbyProperty{}
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+
+	return &models.NearbyPropertiesResponse{
+		Query:    models.NearbyQuery{Lat: lat, Lng: lng, RadiusMeters: radiusMeters},
+		Data:     properties,
+		Metadata: metadata,
+	}, nil
+}
+
+// BackfillGeoPoints populates the geospatial index field for properties stored before it existed.
+func (s *PropertySearchService) BackfillGeoPoints(ctx context.Context) {
+	updated, err := s.repo.BackfillGeoPoints(ctx)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to backfill property geo points: error=%v", err)
+		return
+	}
+	if updated > 0 {
+		logger.GlobalLogger.Printf("Property geo points backfilled: properties=%d", updated)
+	}
+}
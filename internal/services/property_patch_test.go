@@ -0,0 +1,31 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPatchFieldsRejectsDerivedFields(t *testing.T) {
+	for _, field := range []string{"media", "listing", "valuation", "transactions", "hazard", "neighborhood", "dataQuality", "orgId"} {
+		patch := map[string]interface{}{field: map[string]interface{}{"value": 1}}
+		err := checkPatchFields(patch)
+		if err == nil {
+			t.Errorf("patch setting %q was accepted", field)
+			continue
+		}
+		// the error mapper turns "invalid patch" errors into a 400
+		if !strings.Contains(err.Error(), "invalid patch") {
+			t.Errorf("patch setting %q: error %q doesn't map to a 400", field, err)
+		}
+	}
+}
+
+func TestCheckPatchFieldsAllowsStoredFields(t *testing.T) {
+	patch := map[string]interface{}{
+		"address":   map[string]interface{}{"city": "AUSTIN"},
+		"ownership": map[string]interface{}{"occupancyCode": "O"},
+	}
+	if err := checkPatchFields(patch); err != nil {
+		t.Fatalf("patch of stored fields was rejected: %v", err)
+	}
+}
@@ -4,21 +4,23 @@ package services
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
+	"homeinsight-properties/internal/errors"
 	"homeinsight-properties/internal/models"
 	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
 	"homeinsight-properties/internal/transformers"
 	"homeinsight-properties/internal/utils"
 	"homeinsight-properties/internal/validators"
 	"homeinsight-properties/pkg/cache"
 	"homeinsight-properties/pkg/config"
-	"homeinsight-properties/pkg/corelogic"
+	"homeinsight-properties/pkg/jobs"
 	"homeinsight-properties/pkg/logger"
-	"homeinsight-properties/pkg/metrics"
+	"homeinsight-properties/pkg/providers"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type PropertySearchService struct {
@@ -28,6 +30,16 @@ type PropertySearchService struct {
 	propTrans           transformers.PropertyTransformer
 	validator           validators.PropertyValidator
 	externalDataService *ExternalDataService
+	owners              *OwnerService
+	webhooks            *WebhookService
+	audit               *PropertyAuditService
+	events              *EventService
+	index               *SearchIndexService
+	standardizer        *AddressStandardizationService
+	transactions        *TransactionService
+	ownership           *OwnershipChangeService
+	diffs               *PropertyDiffService
+	jobs                *jobs.Queue
 	config              *config.Config
 }
 
@@ -37,29 +49,51 @@ func NewPropertySearchService(
 	addrTrans transformers.AddressTransformer,
 	propTrans transformers.PropertyTransformer,
 	validator validators.PropertyValidator,
-	corelogicClient *corelogic.Client,
+	sources []providers.Source,
+	enrichers []PropertyEnricher,
+	owners *OwnerService,
+	webhooks *WebhookService,
+	audit *PropertyAuditService,
+	events *EventService,
+	index *SearchIndexService,
+	standardizer *AddressStandardizationService,
+	transactions *TransactionService,
+	ownership *OwnershipChangeService,
+	diffs *PropertyDiffService,
+	jobQueue *jobs.Queue,
 	cfg *config.Config,
 ) *PropertySearchService {
-	return &PropertySearchService{
+	s := &PropertySearchService{
 		repo:                repo,
 		cache:               cache,
 		addrTrans:           addrTrans,
 		propTrans:           propTrans,
 		validator:           validator,
-		externalDataService: NewExternalDataService(corelogicClient, propTrans, cfg),
+		externalDataService: NewExternalDataService(sources, enrichers, propTrans, cfg),
+		owners:              owners,
+		webhooks:            webhooks,
+		audit:               audit,
+		events:              events,
+		index:               index,
+		standardizer:        standardizer,
+		transactions:        transactions,
+		ownership:           ownership,
+		diffs:               diffs,
+		jobs:                jobQueue,
 		config:              cfg,
 	}
+	jobQueue.Register(JobPropertyRefresh, s.runRefresh, jobs.Options{MaxAttempts: 3, Timeout: time.Minute})
+	return s
 }
 
 // cacheProperty stores a property and its search key in the cache.
 func (s *PropertySearchService) cacheProperty(ctx context.Context, property *models.Property, cacheKey string) error {
-	propertyKey := cache.PropertyKey(property.PropertyID)
-	cacheTTL := time.Duration(s.config.Redis.CacheTTLDays) * 24 * time.Hour
-	if err := s.cache.SetProperty(ctx, propertyKey, property, cacheTTL); err != nil {
+	propertyKey := cache.PropertyKey(property.OrgID, property.PropertyID)
+	if err := s.cache.SetProperty(ctx, propertyKey, property, s.cache.TTL(cache.ClassProperty)); err != nil {
 		logger.GlobalLogger.Warnf("Failed to cache property: propertyID=%s, error=%v", property.PropertyID, err)
 		return nil
 	}
-	if err := s.cache.SetSearchKey(ctx, cacheKey, property.PropertyID, cacheTTL); err != nil {
+	if err := s.cache.SetSearchKey(ctx, cacheKey, property.PropertyID, s.cache.TTL(cache.ClassSearch)); err != nil {
 		logger.GlobalLogger.Warnf("Failed to cache search key: propertyID=%s, error=%v", property.PropertyID, err)
 		return nil
 	}
@@ -72,7 +106,7 @@ func (s *PropertySearchService) cacheProperty(ctx context.Context, property *mod
 
 // isPropertyStale checks if a property's UpdatedAt timestamp is older than the staleness threshold.
 func (s *PropertySearchService) isPropertyStale(updatedAt time.Time) bool {
-	threshold := time.Now().AddDate(0, 0, -s.config.Database.StaleThresholdDays)
+	threshold := time.Now().AddDate(0, 0, -s.config.Live().Database.StaleThresholdDays)
 	return !updatedAt.After(threshold)
 }
 
@@ -94,25 +128,150 @@ func (s *PropertySearchService) SearchSpecificProperty(ctx context.Context, req
 		return nil, utils.LogAndMapError(ctx, err, "parse address", "query", req.Search)
 	}
 
-	// Generate cache key and set initial metadata
-	cacheKey := cache.PropertySpecificSearchKey(street, city)
+	// Standardize the address so spelling variants look up and create the same record
+	if address := s.standardizer.Standardize(ctx, street, city, state, zip); address != nil {
+		street, city, state, zip = address.Street, address.City, address.State, address.Zip
+	}
+
+	// Generate cache key and set initial metadata; spellings of the same address share the key
+	cacheKey := cache.PropertySpecificSearchKey(tenant.OrgID(ctx), s.addrTrans.CanonicalizeStreet(street), city)
 	ginCtx.Set("data_source", "REDIS")
 	ginCtx.Set("query", req.Search)
 
 	// Check cache
 	if propertyID, err := s.cache.GetSearchKey(ctx, cacheKey); err == nil && propertyID != "" {
-		if property, err := s.cache.GetProperty(ctx, cache.PropertyKey(propertyID)); err == nil && property != nil {
-			metrics.CacheHitsTotal.Inc()
+		if property, cacheState, err := s.cache.GetProperty(ctx, cache.PropertyKey(tenant.OrgID(ctx), propertyID)); err == nil && property != nil {
+			// With stale-while-revalidate an outdated record is also served while CoreLogic is queried
+			if s.config.CacheTTL.StaleWhileRevalidate.Enabled && s.isPropertyStale(property.UpdatedAt) {
+				cacheState = cache.StateStale
+			}
 			ginCtx.Set("cache_hit", true)
+			ginCtx.Set("cache_state", cacheState)
 			ginCtx.Set("property_id", propertyID)
+			if cacheState == cache.StateStale {
+				s.enqueueRefresh(ctx, req.Search, street, city, state, zip, cacheKey)
+			}
+			ginCtx.Set("match_confidence", s.addrTrans.MatchConfidence(street, property.Address.StreetAddress))
 			return property, nil
 		}
 		logger.GlobalLogger.Warnf("Cache miss for property: cacheKey=%s, error=%v", cacheKey, err)
 	}
 
 	// Cache miss
-	metrics.CacheMissesTotal.Inc()
 	ginCtx.Set("cache_hit", false)
+	ginCtx.Set("cache_state", cache.StateMiss)
+	property, err := s.resolveProperty(ctx, req, street, city, state, zip, cacheKey)
+	if err != nil {
+		return nil, err
+	}
+	ginCtx.Set("match_confidence", s.addrTrans.MatchConfidence(street, property.Address.StreetAddress))
+	return property, nil
+}
+
+// findFuzzyMatch looks for the property a search most likely meant when no stored address matched it
+// exactly, e.g. a stored "123 MAIN ST" for "123 Main Street, Apt 4". Returns nil when no candidate
+// reaches the configured confidence.
+func (s *PropertySearchService) findFuzzyMatch(ctx context.Context, street, city, state, zip string) (*models.Property, error) {
+	houseNumber, _ := s.addrTrans.SplitStreet(s.addrTrans.CanonicalizeStreet(street))
+	if houseNumber == "" {
+		return nil, nil
+	}
+	candidates, err := s.addressCandidates(ctx, street, houseNumber, city, state, zip)
+	if err != nil {
+		return nil, err
+	}
+
+	var best *models.Property
+	var bestConfidence float64
+	for i := range candidates {
+		confidence := s.addrTrans.MatchConfidence(street, candidates[i].Address.StreetAddress)
+		if confidence >= s.config.Live().AddressMatching.MinConfidence && confidence > bestConfidence {
+			best, bestConfidence = &candidates[i], confidence
+		}
+	}
+	if best != nil {
+		logger.GlobalLogger.Printf("Fuzzy address match: query=%s, propertyID=%s, confidence=%.2f", street, best.PropertyID, bestConfidence)
+	}
+	return best, nil
+}
+
+// addressCandidates loads the stored properties a fuzzy address match chooses from: those whose
+// street address resembles street according to the search index, or else those with the same house
+// number.
+func (s *PropertySearchService) addressCandidates(ctx context.Context, street, houseNumber, city, state, zip string) ([]models.Property, error) {
+	limit := s.config.Live().AddressMatching.MaxCandidates
+	if s.index != nil {
+		candidates, err := s.index.FindAddressCandidates(ctx, street, city, state, zip, limit)
+		if err == nil {
+			return candidates, nil
+		}
+		logger.GlobalLogger.Warnf("Search index query failed, falling back to database: query=%s, error=%v", street, err)
+	}
+	return s.repo.FindAddressCandidates(ctx, houseNumber, city, state, zip, limit)
+}
+
+// JobPropertyRefresh is the background job type refreshing a stale searched property from the
+// property data providers.
+const JobPropertyRefresh = "property.refresh"
+
+// propertyRefreshPayload holds a parsed property search to resolve again for an organization.
+type propertyRefreshPayload struct {
+	Search   string `json:"search"`
+	Street   string `json:"street"`
+	City     string `json:"city"`
+	State    string `json:"state"`
+	Zip      string `json:"zip"`
+	CacheKey string `json:"cacheKey"`
+	OrgID    string `json:"orgId"`
+}
+
+// enqueueRefresh queues a refresh of a stale search result. Searches for the same address share one
+// pending refresh across the fleet.
+func (s *PropertySearchService) enqueueRefresh(ctx context.Context, search, street, city, state, zip, cacheKey string) error {
+	payload := &propertyRefreshPayload{Search: search, Street: street, City: city, State: state, Zip: zip, CacheKey: cacheKey, OrgID: tenant.OrgID(ctx)}
+	_, err := s.jobs.Enqueue(ctx, JobPropertyRefresh, payload, jobs.EnqueueOptions{UniqueKey: cache.RefreshLockName(cacheKey)})
+	if err != nil {
+		logger.GlobalLogger.Warnf("Failed to queue property refresh: cacheKey=%s, error=%v", cacheKey, err)
+	}
+	return err
+}
+
+// QueueRefresh queues a refresh of a stored property from the data providers, as a search for its
+// address would once it is stale. It runs in the property's organization.
+func (s *PropertySearchService) QueueRefresh(ctx context.Context, property *models.Property) error {
+	ctx = tenant.WithOrgID(ctx, property.OrgID)
+	address := property.Address
+	search := fmt.Sprintf("%s, %s, %s %s", address.StreetAddress, address.City, address.State, address.ZipCode)
+	cacheKey := cache.PropertySpecificSearchKey(property.OrgID, s.addrTrans.CanonicalizeStreet(address.StreetAddress), address.City)
+	return s.enqueueRefresh(ctx, search, address.StreetAddress, address.City, address.State, address.ZipCode, cacheKey)
+}
+
+// runRefresh resolves a queued search again. Errors the caller would have seen as 4xx, such as the
+// address no longer being found, won't change on retry.
+func (s *PropertySearchService) runRefresh(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload propertyRefreshPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrgID(ctx, payload.OrgID)
+	req := &models.SearchRequest{Search: payload.Search}
+	property, err := s.resolveProperty(ctx, req, payload.Street, payload.City, payload.State, payload.Zip, payload.CacheKey)
+	if err != nil {
+		if errors.MapError(err).HTTPStatus < http.StatusInternalServerError {
+			return nil, jobs.Permanent(err)
+		}
+		return nil, err
+	}
+	return map[string]string{"propertyId": property.PropertyID}, nil
+}
+
+// resolveProperty loads a searched property from the database, refreshing it from CoreLogic when it
+// is missing or outdated, and caches the result under cacheKey.
+func (s *PropertySearchService) resolveProperty(ctx context.Context, req *models.SearchRequest, street, city, state, zip, cacheKey string) (*models.Property, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
 
 	// Query database
 	var property *models.Property
@@ -135,6 +294,13 @@ func (s *PropertySearchService) SearchSpecificProperty(ctx context.Context, req
 			"zip", zip)
 	}
 
+	// Fall back to a fuzzy match before asking the data providers for an address we may already have
+	if property == nil && s.config.Live().AddressMatching.Fuzzy {
+		if property, err = s.findFuzzyMatch(ctx, street, city, state, zip); err != nil {
+			logger.GlobalLogger.Warnf("Fuzzy address match failed: query=%s, error=%v", req.Search, err)
+		}
+	}
+
 	// Handle existing property
 	if property != nil {
 		ginCtx.Set("property_id", property.PropertyID)
@@ -155,6 +321,8 @@ func (s *PropertySearchService) SearchSpecificProperty(ctx context.Context, req
 		// Update existing property
 		newProperty.ID = property.ID
 		newProperty.PropertyID = property.PropertyID
+		newProperty.AVMPropertyID = property.AVMPropertyID
+		newProperty.KeepEnrichments(property)
 		newProperty.UpdatedAt = time.Now()
 
 		if err := s.repo.Update(ctx, newProperty); err != nil {
@@ -163,11 +331,22 @@ func (s *PropertySearchService) SearchSpecificProperty(ctx context.Context, req
 				"propertyID", newProperty.PropertyID)
 		}
 
+		if err := s.owners.IndexProperty(ctx, newProperty); err != nil {
+			logger.GlobalLogger.Warnf("Owner index update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
+		}
+		if err := s.transactions.Record(ctx, newProperty); err != nil {
+			logger.GlobalLogger.Warnf("Transaction history update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
+		}
+		s.webhooks.Publish(ctx, models.EventPropertyUpdated, newProperty.PropertyID, newProperty)
+		s.audit.Record(ctx, models.AuditActionUpdated, newProperty.PropertyID, property, newProperty)
+		s.events.Record(ctx, models.EventPropertyUpdated, newProperty.PropertyID, newProperty)
+		s.ownership.Detect(ctx, property, newProperty)
+		s.diffs.RecordRefresh(ctx, ginCtx.GetString("data_source"), property, newProperty)
+
 		// Cache updated property
 		if err := s.cacheProperty(ctx, newProperty, cacheKey); err != nil {
 			logger.GlobalLogger.Warnf("Cache update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
 		}
-		ginCtx.Set("data_source", "CORELOGIC_API")
 		return newProperty, nil
 	}
 
@@ -177,16 +356,22 @@ func (s *PropertySearchService) SearchSpecificProperty(ctx context.Context, req
 		return nil, utils.WrapError(err, "fetch external data failed: query=%s", req.Search)
 	}
 
-	// Check for race condition
-	existingProperty, err := s.repo.FindByID(ctx, newProperty.PropertyID)
+	// Create the property; a concurrent search may have stored the same address first, in which
+	// case that document is refreshed with the fetched data instead
+	fetched := *newProperty
+	newProperty.UpdatedAt = time.Now()
+	created, err := s.repo.Create(ctx, newProperty)
 	if err != nil {
-		return nil, utils.LogAndMapError(ctx, utils.WrapError(err, "check existing property failed: propertyID=%s", newProperty.PropertyID),
-			"check existing property",
+		return nil, utils.LogAndMapError(ctx, utils.WrapError(err, "create property failed: propertyID=%s", newProperty.PropertyID),
+			"create property",
 			"propertyID", newProperty.PropertyID)
 	}
-	if existingProperty != nil {
+	if !created {
+		existingProperty := newProperty
+		newProperty = &fetched
 		newProperty.ID = existingProperty.ID
 		newProperty.PropertyID = existingProperty.PropertyID
+		newProperty.AVMPropertyID = existingProperty.AVMPropertyID
 		newProperty.UpdatedAt = time.Now()
 
 		if err := s.repo.Update(ctx, newProperty); err != nil {
@@ -195,23 +380,33 @@ func (s *PropertySearchService) SearchSpecificProperty(ctx context.Context, req
 				"propertyID", newProperty.PropertyID)
 		}
 
+		if err := s.owners.IndexProperty(ctx, newProperty); err != nil {
+			logger.GlobalLogger.Warnf("Owner index update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
+		}
+		if err := s.transactions.Record(ctx, newProperty); err != nil {
+			logger.GlobalLogger.Warnf("Transaction history update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
+		}
+		s.webhooks.Publish(ctx, models.EventPropertyUpdated, newProperty.PropertyID, newProperty)
+		s.audit.Record(ctx, models.AuditActionUpdated, newProperty.PropertyID, existingProperty, newProperty)
+		s.events.Record(ctx, models.EventPropertyUpdated, newProperty.PropertyID, newProperty)
+		s.ownership.Detect(ctx, existingProperty, newProperty)
+
 		if err := s.cacheProperty(ctx, newProperty, cacheKey); err != nil {
 			logger.GlobalLogger.Warnf("Cache update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
 		}
-		ginCtx.Set("data_source", "CORELOGIC_API")
 		ginCtx.Set("property_id", newProperty.PropertyID)
 		return newProperty, nil
 	}
 
-	// Create new property
-	newProperty.ID = primitive.NewObjectID()
-	newProperty.UpdatedAt = time.Now()
-
-	if err := s.repo.Create(ctx, newProperty); err != nil {
-		return nil, utils.LogAndMapError(ctx, utils.WrapError(err, "create property failed: propertyID=%s", newProperty.PropertyID),
-			"create property",
-			"propertyID", newProperty.PropertyID)
+	if err := s.owners.IndexProperty(ctx, newProperty); err != nil {
+		logger.GlobalLogger.Warnf("Owner index update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
+	}
+	if err := s.transactions.Record(ctx, newProperty); err != nil {
+		logger.GlobalLogger.Warnf("Transaction history update failed: propertyID=%s, error=%v", newProperty.PropertyID, err)
 	}
+	s.webhooks.Publish(ctx, models.EventPropertyCreated, newProperty.PropertyID, newProperty)
+	s.audit.Record(ctx, models.AuditActionCreated, newProperty.PropertyID, nil, newProperty)
+	s.events.Record(ctx, models.EventPropertyCreated, newProperty.PropertyID, newProperty)
 
 	// Cache new property
 	if err := s.cacheProperty(ctx, newProperty, cacheKey); err != nil {
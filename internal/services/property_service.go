@@ -1,32 +1,45 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
 	"homeinsight-properties/internal/models"
 	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
 	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/internal/utils"
 	"homeinsight-properties/internal/validators"
 	"homeinsight-properties/pkg/cache"
 	"homeinsight-properties/pkg/config"
 	"homeinsight-properties/pkg/corelogic"
+	"homeinsight-properties/pkg/jobs"
 	"homeinsight-properties/pkg/logger"
-	"homeinsight-properties/pkg/metrics"
 
 	"github.com/gin-gonic/gin"
 )
 
 type PropertyService struct {
-	repo      repositories.PropertyRepository
-	cache     repositories.PropertyCache
-	trans     transformers.PropertyTransformer
-	addrTrans transformers.AddressTransformer
-	validator validators.PropertyValidator
-	corelogic *corelogic.Client
-	config    *config.Config
-	cacheTTL  time.Duration
+	repo         repositories.PropertyRepository
+	cache        repositories.PropertyCache
+	trans        transformers.PropertyTransformer
+	addrTrans    transformers.AddressTransformer
+	validator    validators.PropertyValidator
+	corelogic    *corelogic.Client
+	owners       *OwnerService
+	webhooks     *WebhookService
+	audit        *PropertyAuditService
+	auditLog     *AuditEventService
+	events       *EventService
+	standardizer *AddressStandardizationService
+	jobs         *jobs.Queue
+	config       *config.Config
+	revalidator  revalidator
+	tx           repositories.Transactor
 }
 
 func NewPropertyService(
@@ -36,18 +49,38 @@ func NewPropertyService(
 	addrTrans transformers.AddressTransformer,
 	validator validators.PropertyValidator,
 	corelogicClient *corelogic.Client,
+	owners *OwnerService,
+	webhooks *WebhookService,
+	audit *PropertyAuditService,
+	auditLog *AuditEventService,
+	events *EventService,
+	standardizer *AddressStandardizationService,
+	jobQueue *jobs.Queue,
+	tx repositories.Transactor,
 	cfg *config.Config,
 ) *PropertyService {
-	return &PropertyService{
-		repo:      repo,
-		cache:     cache,
-		trans:     trans,
-		addrTrans: addrTrans,
-		validator: validator,
-		corelogic: corelogicClient,
-		config:    cfg,
-		cacheTTL:  time.Duration(cfg.Redis.CacheTTLDays) * 24 * time.Hour,
+	s := &PropertyService{
+		repo:         repo,
+		cache:        cache,
+		trans:        trans,
+		addrTrans:    addrTrans,
+		validator:    validator,
+		corelogic:    corelogicClient,
+		owners:       owners,
+		webhooks:     webhooks,
+		audit:        audit,
+		auditLog:     auditLog,
+		events:       events,
+		standardizer: standardizer,
+		jobs:         jobQueue,
+		tx:           tx,
+		config:       cfg,
 	}
+	jobQueue.Register(JobCacheWarmup, s.runCacheWarmup, jobs.Options{Workers: 1, MaxAttempts: 3})
+	jobQueue.Register(JobPropertyImport, s.runImport, jobs.Options{Workers: 1})
+	jobQueue.Register(JobPropertyBulkDelete, s.runBulkDelete, jobs.Options{Workers: 1})
+	jobQueue.Register(JobPropertyBulkUpdate, s.runBulkUpdate, jobs.Options{Workers: 1})
+	return s
 }
 
 func (s *PropertyService) GetPropertyByID(ctx context.Context, id string) (*models.Property, error) {
@@ -56,19 +89,26 @@ func (s *PropertyService) GetPropertyByID(ctx context.Context, id string) (*mode
 		ginCtx = &gin.Context{}
 	}
 
-	propertyKey := cache.PropertyKey(id)
+	propertyKey := cache.PropertyKey(tenant.OrgID(ctx), id)
 	ginCtx.Set("data_source", "REDIS")
 	ginCtx.Set("property_id", id)
+	s.recordPropertyHit(ctx, id)
 
-	// Check cache
-	if property, err := s.cache.GetProperty(ctx, propertyKey); err == nil && property != nil {
-		metrics.CacheHitsTotal.Inc()
+	// Check cache; a stale hit is served as is and refreshed in the background
+	if property, state, err := s.cache.GetProperty(ctx, propertyKey); err == nil && property != nil {
 		ginCtx.Set("cache_hit", true)
+		ginCtx.Set("cache_state", state)
+		if state == cache.StateStale {
+			orgID := property.OrgID
+			s.revalidator.Revalidate(propertyKey, func(ctx context.Context) error {
+				return s.refreshCachedProperty(tenant.WithOrgID(ctx, orgID), id)
+			})
+		}
 		return property, nil
 	}
 
-	metrics.CacheMissesTotal.Inc()
 	ginCtx.Set("cache_hit", false)
+	ginCtx.Set("cache_state", cache.StateMiss)
 
 	// Query database
 	property, err := s.repo.FindByID(ctx, id)
@@ -78,20 +118,53 @@ func (s *PropertyService) GetPropertyByID(ctx context.Context, id string) (*mode
 	}
 	if property == nil {
 		logger.GlobalLogger.Errorf("Property not found: id=%s", id)
-		return nil, fmt.Errorf("property with id %s not found", id)
+		return nil, fmt.Errorf("property not found: id=%s", id)
 	}
 
 	ginCtx.Set("data_source", "DATABASE")
 
-	// Cache the property
-	if err := s.cache.SetProperty(ctx, propertyKey, property, s.cacheTTL); err != nil {
-		logger.GlobalLogger.Errorf("Failed to cache property: id=%s, error=%v", id, err)
+	s.cacheProperty(ctx, property)
+	return property, nil
+}
+
+// GetTaxHistory returns a property's tax assessments as a timeline, oldest year first.
+func (s *PropertyService) GetTaxHistory(ctx context.Context, id string) ([]models.TaxAssessment, error) {
+	property, err := s.GetPropertyByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Properties cached before the history existed only carry the latest assessment
+	property.SyncTaxAssessments()
+	timeline := make([]models.TaxAssessment, 0, len(property.TaxAssessments))
+	for i := len(property.TaxAssessments) - 1; i >= 0; i-- {
+		timeline = append(timeline, property.TaxAssessments[i])
+	}
+	return timeline, nil
+}
+
+// cacheProperty stores a property under its ID key and registers the key for invalidation.
+func (s *PropertyService) cacheProperty(ctx context.Context, property *models.Property) {
+	propertyKey := cache.PropertyKey(property.OrgID, property.PropertyID)
+	if err := s.cache.SetProperty(ctx, propertyKey, property, s.cache.TTL(cache.ClassProperty)); err != nil {
+		logger.GlobalLogger.Errorf("Failed to cache property: id=%s, error=%v", property.PropertyID, err)
 	}
 	if err := s.cache.AddCacheKeyToPropertySet(ctx, property.PropertyID, propertyKey); err != nil {
-		logger.GlobalLogger.Errorf("Failed to add cache key to property set: id=%s, key=%s, error=%v", id, propertyKey, err)
+		logger.GlobalLogger.Errorf("Failed to add cache key to property set: id=%s, key=%s, error=%v", property.PropertyID, propertyKey, err)
 	}
+}
 
-	return property, nil
+// refreshCachedProperty reloads a stale cached property from the database, dropping it from the
+// cache if it no longer exists.
+func (s *PropertyService) refreshCachedProperty(ctx context.Context, id string) error {
+	property, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: id=%s", id)
+	}
+	if property == nil {
+		return s.cache.InvalidatePropertyCacheKeys(ctx, id)
+	}
+	s.cacheProperty(ctx, property)
+	return nil
 }
 
 func (s *PropertyService) CreateProperty(ctx context.Context, property *models.Property) error {
@@ -99,48 +172,278 @@ func (s *PropertyService) CreateProperty(ctx context.Context, property *models.P
 		return err
 	}
 
+	s.standardizer.StandardizeProperty(ctx, property)
 	s.normalizeAddress(property)
-	if err := s.repo.Create(ctx, property); err != nil {
+	transformers.ScoreDataQuality(property)
+	propertyID := property.PropertyID
+	// The property, its owner links, history and outbox event are written together
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		created, err := s.repo.Create(ctx, property)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return fmt.Errorf("property already exists: propertyId=%s, existing=%s", propertyID, property.PropertyID)
+		}
+
+		if err := s.owners.IndexProperty(ctx, property); err != nil {
+			logger.GlobalLogger.Errorf("Failed to index property owners: id=%s, error=%v", property.PropertyID, err)
+		}
+		s.audit.Record(ctx, models.AuditActionCreated, property.PropertyID, nil, property)
+		s.events.Record(ctx, models.EventPropertyCreated, property.PropertyID, property)
+		repositories.AfterCommit(ctx, func() {
+			s.recache(ctx, property)
+			s.webhooks.Publish(ctx, models.EventPropertyCreated, property.PropertyID, property)
+		})
+		return nil
+	})
+}
+
+func (s *PropertyService) UpdateProperty(ctx context.Context, property *models.Property) error {
+	if err := s.validator.ValidateUpdate(property); err != nil {
 		return err
 	}
 
-	propertyKey := cache.PropertyKey(property.PropertyID)
-	if err := s.cache.SetProperty(ctx, propertyKey, property, s.cacheTTL); err != nil {
+	s.normalizeAddress(property)
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		before, err := s.repo.FindByID(ctx, property.PropertyID)
+		if err != nil {
+			return utils.WrapError(err, "database query failed: id=%s", property.PropertyID)
+		}
+		// Clients that predate the tax history send only the latest assessment; keep the stored history
+		if property.TaxAssessments == nil && before != nil {
+			property.TaxAssessments = before.TaxAssessments
+		}
+		transformers.ScoreDataQuality(property)
+		if err := s.repo.Update(ctx, property); err != nil {
+			return err
+		}
+
+		if err := s.owners.IndexProperty(ctx, property); err != nil {
+			logger.GlobalLogger.Errorf("Failed to index property owners: id=%s, error=%v", property.PropertyID, err)
+		}
+		s.audit.Record(ctx, models.AuditActionUpdated, property.PropertyID, before, property)
+		s.events.Record(ctx, models.EventPropertyUpdated, property.PropertyID, property)
+		repositories.AfterCommit(ctx, func() {
+			s.recache(ctx, property)
+			s.webhooks.Publish(ctx, models.EventPropertyUpdated, property.PropertyID, property)
+		})
+		return nil
+	})
+}
+
+// recache replaces a written property in the cache and drops the cached results that include it.
+func (s *PropertyService) recache(ctx context.Context, property *models.Property) {
+	propertyKey := cache.PropertyKey(property.OrgID, property.PropertyID)
+	if err := s.cache.SetProperty(ctx, propertyKey, property, s.cache.TTL(cache.ClassProperty)); err != nil {
 		logger.GlobalLogger.Errorf("Failed to cache property: id=%s, error=%v", property.PropertyID, err)
 	}
 	if err := s.cache.InvalidatePropertyCacheKeys(ctx, property.PropertyID); err != nil {
 		logger.GlobalLogger.Errorf("Failed to invalidate cache keys: id=%s, error=%v", property.PropertyID, err)
 	}
-	return nil
 }
 
-func (s *PropertyService) UpdateProperty(ctx context.Context, property *models.Property) error {
-	if err := s.validator.ValidateUpdate(property); err != nil {
-		return err
+// immutablePatchFields identify a property, tie it to its organization, move it to the trash, are
+// maintained by the service or are embedded from other collections by ?include=, so a patch may not
+// set them.
+var immutablePatchFields = []string{
+	"_id", "orgId", "propertyId", "schemaVersion", "updatedAt", "deletedAt",
+	"taxAssessment", "dataQuality", "hazard", "neighborhood",
+	"media", "listing", "valuation", "transactions",
+}
+
+// PatchProperty applies an RFC 7386 JSON merge patch to a stored property and writes back only the
+// fields the patch touches.
+func (s *PropertyService) PatchProperty(ctx context.Context, id string, body []byte) (*models.Property, error) {
+	var patch map[string]interface{}
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, fmt.Errorf("invalid patch: body must be a JSON object")
+	}
+	if err := checkPatchFields(patch); err != nil {
+		return nil, err
+	}
+	paths := utils.MergePatchPaths(patch)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("invalid patch: no fields to change")
 	}
 
-	s.normalizeAddress(property)
-	if err := s.repo.Update(ctx, property); err != nil {
-		return err
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: id=%s", id)
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("property not found")
 	}
 
-	propertyKey := cache.PropertyKey(property.PropertyID)
-	if err := s.cache.SetProperty(ctx, propertyKey, property, s.cacheTTL); err != nil {
+	property, err := s.applyPatch(existing, patch)
+	if err != nil {
+		return nil, err
+	}
+	paths = append(paths, "dataQuality")
+	property.UpdatedAt = time.Now().UTC()
+	if err := s.repo.Patch(ctx, property, paths); err != nil {
+		return nil, err
+	}
+
+	propertyKey := cache.PropertyKey(property.OrgID, property.PropertyID)
+	if err := s.cache.SetProperty(ctx, propertyKey, property, s.cache.TTL(cache.ClassProperty)); err != nil {
 		logger.GlobalLogger.Errorf("Failed to cache property: id=%s, error=%v", property.PropertyID, err)
 	}
 	if err := s.cache.InvalidatePropertyCacheKeys(ctx, property.PropertyID); err != nil {
 		logger.GlobalLogger.Errorf("Failed to invalidate cache keys: id=%s, error=%v", property.PropertyID, err)
 	}
+	if err := s.owners.IndexProperty(ctx, property); err != nil {
+		logger.GlobalLogger.Errorf("Failed to index property owners: id=%s, error=%v", property.PropertyID, err)
+	}
+	s.webhooks.Publish(ctx, models.EventPropertyUpdated, property.PropertyID, property)
+	s.audit.Record(ctx, models.AuditActionUpdated, property.PropertyID, existing, property)
+	s.events.Record(ctx, models.EventPropertyUpdated, property.PropertyID, property)
+	return property, nil
+}
+
+// checkPatchFields rejects a patch touching a field that may not be patched.
+func checkPatchFields(patch map[string]interface{}) error {
+	for _, field := range immutablePatchFields {
+		if _, ok := patch[field]; ok {
+			return fmt.Errorf("invalid patch: field %q cannot be changed", field)
+		}
+	}
 	return nil
 }
 
+// applyPatch merges a merge patch into a copy of a stored property and validates the outcome, which
+// is normalized and scored again like any update.
+func (s *PropertyService) applyPatch(existing *models.Property, patch map[string]interface{}) (*models.Property, error) {
+	current, err := json.Marshal(existing)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(current, &document); err != nil {
+		return nil, err
+	}
+	merged, err := json.Marshal(utils.MergePatch(document, patch))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	var property models.Property
+	if err := decoder.Decode(&property); err != nil {
+		return nil, fmt.Errorf("invalid patch: %v", err)
+	}
+	// A property stays with the organization that holds it, whatever reached the merged document
+	if property.OrgID != existing.OrgID || property.ID != existing.ID || property.PropertyID != existing.PropertyID {
+		return nil, fmt.Errorf("invalid patch: a property cannot change organization or identity")
+	}
+	// The latest assessment is derived again from the patched history
+	if _, ok := patch["taxAssessments"]; ok {
+		property.TaxAssessment = models.TaxAssessment{}
+	}
+
+	if err := s.validator.ValidateUpdate(&property); err != nil {
+		return nil, err
+	}
+	s.normalizeAddress(&property)
+	transformers.ScoreDataQuality(&property)
+	return &property, nil
+}
+
 func (s *PropertyService) DeleteProperty(ctx context.Context, id string) error {
-	if err := s.repo.Delete(ctx, id); err != nil {
-		return err
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if err := s.owners.RemoveProperty(ctx, id); err != nil {
+			logger.GlobalLogger.Errorf("Failed to remove property from owner index: id=%s, error=%v", id, err)
+		}
+		s.audit.Record(ctx, models.AuditActionDeleted, id, nil, nil)
+		s.auditLog.Record(ctx, models.AuditEvent{Type: models.AuditEventPropertyDeleted, TargetType: "property", TargetID: id})
+		s.events.Record(ctx, models.EventPropertyDeleted, id, nil)
+		repositories.AfterCommit(ctx, func() {
+			if err := s.cache.InvalidatePropertyCacheKeys(ctx, id); err != nil {
+				logger.GlobalLogger.Errorf("Failed to invalidate cache keys: id=%s, error=%v", id, err)
+			}
+			s.webhooks.Publish(ctx, models.EventPropertyDeleted, id, nil)
+		})
+		return nil
+	})
+}
+
+// RestoreProperty takes a property out of the trash and makes it visible again.
+func (s *PropertyService) RestoreProperty(ctx context.Context, id string) (*models.Property, error) {
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
 	}
+	property, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: id=%s", id)
+	}
+	if property == nil {
+		return nil, fmt.Errorf("property not found")
+	}
+
 	if err := s.cache.InvalidatePropertyCacheKeys(ctx, id); err != nil {
 		logger.GlobalLogger.Errorf("Failed to invalidate cache keys: id=%s, error=%v", id, err)
 	}
+	s.cacheProperty(ctx, property)
+	if err := s.owners.IndexProperty(ctx, property); err != nil {
+		logger.GlobalLogger.Errorf("Failed to index property owners: id=%s, error=%v", id, err)
+	}
+	s.webhooks.Publish(ctx, models.EventPropertyRestored, id, property)
+	s.audit.Record(ctx, models.AuditActionRestored, id, nil, nil)
+	s.events.Record(ctx, models.EventPropertyRestored, id, property)
+	return property, nil
+}
+
+// DataQualityReport summarizes how complete the organization's property data is, with the number of
+// properties flagged with each data quality issue.
+func (s *PropertyService) DataQualityReport(ctx context.Context) (*models.DataQualityReport, error) {
+	report, err := s.repo.DataQualityReport(ctx)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: data quality report")
+	}
+	return report, nil
+}
+
+// ListTrash returns a page of deleted properties that can still be restored, most recent first.
+func (s *PropertyService) ListTrash(ctx context.Context, offset, limit int, baseURL string, params url.Values) (*models.PaginatedPropertiesResponse, error) {
+	properties, total, err := s.repo.FindDeleted(ctx, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: list trash")
+	}
+	if properties == nil {
+		properties = []models.Property{}
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+	return &models.PaginatedPropertiesResponse{Data: properties, Metadata: metadata}, nil
+}
+
+// PurgeProperty permanently removes a property from the trash; it can no longer be restored.
+func (s *PropertyService) PurgeProperty(ctx context.Context, id string) error {
+	if err := s.repo.Purge(ctx, id); err != nil {
+		return err
+	}
+	logger.GlobalLogger.WithContext(ctx).Printf("Property purged: id=%s", id)
+	s.audit.Record(ctx, models.AuditActionPurged, id, nil, nil)
+	s.auditLog.Record(ctx, models.AuditEvent{Type: models.AuditEventPropertyPurged, TargetType: "property", TargetID: id})
 	return nil
 }
 
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxWithinCount is where counting the properties inside a boundary stops; a map showing that many
+// should ask the user to zoom in rather than page through them.
+const maxWithinCount = 10000
+
+// FindWithin returns a page of the properties inside a drawn polygon or map bounding box, in a
+// fixed order so the same area pages the same way however it is reached. An empty cursor starts
+// from the beginning. The total stops counting at maxWithinCount.
+func (s *PropertySearchService) FindWithin(ctx context.Context, req *models.WithinRequest, cursor string, fields models.PropertyFields, limit int, baseURL string, params url.Values) (*models.PaginatedPropertiesResponse, error) {
+	ginCtx, ok := ctx.(*gin.Context)
+	if !ok {
+		ginCtx = &gin.Context{}
+	}
+
+	boundary, err := req.Boundary()
+	if err != nil {
+		return nil, err
+	}
+	var afterID primitive.ObjectID
+	if cursor != "" {
+		_, id, err := utils.DecodeCursor(cursor)
+		if err == nil {
+			afterID, err = primitive.ObjectIDFromHex(id)
+		}
+		if err != nil {
+			return nil, errors.NewAppError(
+				fmt.Sprintf("invalid cursor: %v", err),
+				"The provided pagination cursor is invalid",
+				errors.ErrCodeInvalidParameters,
+				http.StatusBadRequest,
+				err,
+			)
+		}
+	}
+
+	ginCtx.Set("data_source", "DATABASE")
+	ginCtx.Set("query", "within,cursor="+cursor+",limit="+strconv.Itoa(limit))
+
+	// Fetch one extra row to learn whether another page exists
+	var properties []models.Property
+	for attempt := 1; attempt <= s.config.ErrorHandling.RetryAttempts; attempt++ {
+		properties, err = s.repo.FindWithin(ctx, boundary, fields, afterID, limit+1)
+		if err == nil || !utils.IsRetryableError(err) {
+			break
+		}
+		logger.GlobalLogger.Warnf("Database query attempt %d/%d failed: within cursor=%s, limit=%d, error=%v", attempt, s.config.ErrorHandling.RetryAttempts, cursor, limit, err)
+		time.Sleep(time.Duration(s.config.ErrorHandling.RetryDelayMS) * time.Millisecond)
+	}
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: within cursor=%s", cursor)
+	}
+
+	total, err := s.repo.CountWithin(ctx, boundary, maxWithinCount)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Failed to count properties within boundary: error=%v", err)
+	}
+
+	metadata := models.PaginationMeta{
+		Total: total,
+		Limit: limit,
+	}
+	if len(properties) > limit {
+		properties = properties[:limit]
+		nextCursor := utils.EncodeCursor("", properties[len(properties)-1].ID.Hex())
+		nextURL := utils.BuildCursorURL(baseURL, nextCursor, limit, params)
+		metadata.NextCursor = &nextCursor
+		metadata.Next = &nextURL
+	}
+	if properties == nil {
+		properties = []models.Property{}
+	}
+	return &models.PaginatedPropertiesResponse{Data: properties, Metadata: metadata}, nil
+}
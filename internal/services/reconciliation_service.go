@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// Reconciliation tasks, named as in reconciliation.tasks.
+const (
+	ReconciliationRefreshStale      = "refresh_stale"
+	ReconciliationPruneCacheKeySets = "prune_cache_key_sets"
+	ReconciliationRebuildStats      = "rebuild_stats"
+)
+
+// ReconciliationService runs the nightly upkeep that keeps stored and cached data from drifting:
+// refreshing properties nobody has searched for in a while, pruning the cache's invalidation sets
+// and recomputing aggregate statistics.
+type ReconciliationService struct {
+	repo        repositories.PropertyRepository
+	cache       repositories.PropertyCache
+	search      *PropertySearchService
+	marketStats *MarketStatsService
+	config      *config.Config
+}
+
+func NewReconciliationService(
+	repo repositories.PropertyRepository,
+	cache repositories.PropertyCache,
+	search *PropertySearchService,
+	marketStats *MarketStatsService,
+	cfg *config.Config,
+) *ReconciliationService {
+	return &ReconciliationService{
+		repo:        repo,
+		cache:       cache,
+		search:      search,
+		marketStats: marketStats,
+		config:      cfg,
+	}
+}
+
+// Run runs the configured tasks in order. A failed task doesn't stop the others; the run reports
+// the first failure after logging a report of every task.
+func (s *ReconciliationService) Run(ctx context.Context) error {
+	start := time.Now()
+	var report []string
+	var firstErr error
+	for _, task := range s.config.Reconciliation.Tasks {
+		items, err := s.runTask(ctx, task)
+		metrics.ReconciliationItemsTotal.WithLabelValues(task).Add(float64(items))
+		outcome := "succeeded"
+		if err != nil {
+			outcome = "failed"
+			logger.GlobalLogger.Errorf("Reconciliation task failed: task=%s, items=%d, error=%v", task, items, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		metrics.ReconciliationLastRunTimestamp.WithLabelValues(task, outcome).SetToCurrentTime()
+		report = append(report, fmt.Sprintf("%s:%s:%d", task, outcome, items))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	logger.GlobalLogger.Printf("Reconciliation report: tasks=[%s], duration=%s", strings.Join(report, " "), time.Since(start))
+	return firstErr
+}
+
+// runTask runs one task, returning how many items it handled.
+func (s *ReconciliationService) runTask(ctx context.Context, task string) (int, error) {
+	switch task {
+	case ReconciliationRefreshStale:
+		return s.refreshStale(ctx)
+	case ReconciliationPruneCacheKeySets:
+		removed, err := s.cache.PruneKeySets(ctx)
+		return int(removed), err
+	case ReconciliationRebuildStats:
+		return s.marketStats.RefreshZips(ctx)
+	}
+	return 0, nil
+}
+
+// refreshStale queues provider refreshes of the least recently updated properties of every
+// organization. Refreshes already pending for an address aren't queued twice.
+func (s *ReconciliationService) refreshStale(ctx context.Context) (int, error) {
+	before := time.Now().AddDate(0, 0, -s.config.Reconciliation.RefreshOlderThanDays)
+	properties, err := s.repo.FindStale(ctx, before, s.config.Reconciliation.RefreshBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	queued := 0
+	for i := range properties {
+		if err := s.search.QueueRefresh(ctx, &properties[i]); err != nil {
+			return queued, err
+		}
+		queued++
+	}
+	return queued, nil
+}
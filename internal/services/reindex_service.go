@@ -0,0 +1,368 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// HintRefreshInterval is how often every instance reloads query hints from Mongo. Obsolete indexes
+// are only dropped after two refresh cycles, so no instance still hints a query at a missing index.
+const HintRefreshInterval = 30 * time.Second
+
+const (
+	reindexLockTTL       = time.Minute
+	reindexPollInterval  = 5 * time.Second
+	reindexListLimit     = 50
+	reindexDropGrace     = 2 * HintRefreshInterval
+	reindexLockKeyPrefix = "reindex:"
+)
+
+// JobEnsureIndexes is the background job type building every index of the index registry.
+const JobEnsureIndexes = "database.ensure_indexes"
+
+// ensureIndexesTimeout bounds a run of the registry's builds, which can take a while for new indexes
+// on large collections.
+const ensureIndexesTimeout = time.Hour
+
+// collections an admin may reindex
+var reindexableCollections = map[string]bool{
+	"properties":     true,
+	"owner_entities": true,
+}
+
+type ReindexService struct {
+	jobRepo      repositories.ReindexJobRepository
+	hintRepo     repositories.IndexHintRepository
+	propertyRepo repositories.PropertyRepository
+	jobs         *jobs.Queue
+}
+
+func NewReindexService(jobRepo repositories.ReindexJobRepository, hintRepo repositories.IndexHintRepository, propertyRepo repositories.PropertyRepository, jobQueue *jobs.Queue) *ReindexService {
+	s := &ReindexService{
+		jobRepo:      jobRepo,
+		hintRepo:     hintRepo,
+		propertyRepo: propertyRepo,
+		jobs:         jobQueue,
+	}
+	jobQueue.Register(JobEnsureIndexes, s.runEnsureIndexes, jobs.Options{Workers: 1, Timeout: ensureIndexesTimeout})
+	return s
+}
+
+type ensureIndexesPayload struct {
+	Rebuild bool `json:"rebuild"`
+}
+
+// EnsureIndexes queues a build of every index in the registry that is missing, or with Rebuild also
+// of those whose definition changed, and returns the job to poll. One build runs at a time; asking
+// again while one is pending returns it.
+func (s *ReindexService) EnsureIndexes(ctx context.Context, req *models.EnsureIndexesRequest, requestedBy string) (*jobs.Job, error) {
+	payload := &ensureIndexesPayload{Rebuild: req.Rebuild}
+	job, err := s.jobs.Enqueue(ctx, JobEnsureIndexes, payload, jobs.EnqueueOptions{CreatedBy: requestedBy, UniqueKey: JobEnsureIndexes})
+	if err != nil {
+		return nil, utils.WrapError(err, "queue index build failed: rebuild=%t", req.Rebuild)
+	}
+	return job, nil
+}
+
+// runEnsureIndexes builds the registry's indexes. Indexes that fail or conflict are listed in the
+// result rather than failing the job, since building them again won't help; an interrupted run is
+// retried.
+func (s *ReindexService) runEnsureIndexes(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload ensureIndexesPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, err
+	}
+	report, err := database.EnsureIndexes(ctx, database.DB, database.EnsureIndexOptions{Rebuild: payload.Rebuild})
+	if err != nil && ctx.Err() != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// StartReindex validates the request, takes the per-collection lock shared by all instances and
+// runs the build in the background. The returned job can be polled for progress.
+func (s *ReindexService) StartReindex(ctx context.Context, req *models.ReindexRequest, requestedBy string) (*models.ReindexJob, error) {
+	if err := validateReindexRequest(req); err != nil {
+		return nil, errors.NewAppError(err.Error(), errors.MsgInvalidParameters, errors.ErrCodeInvalidParameters, http.StatusBadRequest, err)
+	}
+
+	lock, err := cache.AcquireLock(ctx, reindexLockKeyPrefix+req.Collection, reindexLockTTL)
+	if err == cache.ErrLockHeld {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("reindex already running: collection=%s", req.Collection),
+			errors.MsgReindexInProgress,
+			errors.ErrCodeReindexInProgress,
+			http.StatusConflict,
+			err,
+		)
+	}
+	if err != nil {
+		return nil, utils.WrapError(err, "acquire reindex lock failed: collection=%s", req.Collection)
+	}
+
+	now := time.Now().UTC()
+	job := &models.ReindexJob{
+		ID:          primitive.NewObjectID(),
+		Collection:  req.Collection,
+		IndexName:   req.IndexName,
+		Keys:        req.Keys,
+		Unique:      req.Unique,
+		HintFor:     req.HintFor,
+		DropIndexes: req.DropIndexes,
+		Status:      models.ReindexStatusPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		lock.Release(context.Background())
+		return nil, utils.WrapError(err, "create reindex job failed: collection=%s", req.Collection)
+	}
+
+	logger.GlobalLogger.Printf("Reindex started: jobID=%s, collection=%s, index=%s, requestedBy=%s",
+		job.ID.Hex(), job.Collection, job.IndexName, requestedBy)
+	go s.run(lock, *job)
+	return job, nil
+}
+
+func (s *ReindexService) GetJob(ctx context.Context, id string) (*models.ReindexJob, error) {
+	job, err := s.jobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: jobID=%s", id)
+	}
+	if job == nil {
+		return nil, fmt.Errorf("reindex job not found: jobID=%s", id)
+	}
+	return job, nil
+}
+
+func (s *ReindexService) ListJobs(ctx context.Context) ([]models.ReindexJob, error) {
+	jobs, err := s.jobRepo.FindRecent(ctx, reindexListLimit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: reindex jobs")
+	}
+	return jobs, nil
+}
+
+// Explain shows how MongoDB runs a named property query with the given parameters, to check which
+// index it uses before and after a reindex.
+func (s *ReindexService) Explain(ctx context.Context, query string, filter *models.PropertyFilter, sort models.PropertySort, text string, limit int) (*models.QueryExplanation, error) {
+	if !slices.Contains(repositories.ExplainableQueries, query) {
+		msg := fmt.Sprintf("unknown query: %s", query)
+		return nil, errors.NewAppError(msg, fmt.Sprintf("Query must be one of: %s.", strings.Join(repositories.ExplainableQueries, ", ")), errors.ErrCodeInvalidParameters, http.StatusBadRequest, nil)
+	}
+	if query == repositories.QueryPropertySearch && strings.TrimSpace(text) == "" {
+		return nil, errors.NewAppError("search text missing", "Search query is required", errors.ErrCodeInvalidParameters, http.StatusBadRequest, nil)
+	}
+	explanation, err := s.propertyRepo.Explain(ctx, query, filter, sort, text, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: explain query=%s", query)
+	}
+	return explanation, nil
+}
+
+// RefreshHints reloads the persisted query hints into this instance.
+func (s *ReindexService) RefreshHints(ctx context.Context) error {
+	hints, err := s.hintRepo.FindAll(ctx)
+	if err != nil {
+		return utils.WrapError(err, "load index hints failed")
+	}
+	next := make(map[string]string, len(hints))
+	for _, hint := range hints {
+		next[hint.Query] = hint.IndexName
+	}
+	database.SetQueryHints(next)
+	return nil
+}
+
+// run builds the index, swaps hints onto it once ready and drops the indexes it replaces.
+func (s *ReindexService) run(lock *cache.Lock, job models.ReindexJob) {
+	ctx := context.Background()
+	defer func() {
+		if err := lock.Release(ctx); err != nil && err != cache.ErrLockLost {
+			logger.GlobalLogger.Warnf("Failed to release reindex lock: jobID=%s, error=%v", job.ID.Hex(), err)
+		}
+	}()
+
+	s.setStatus(ctx, &job, models.ReindexStatusBuilding)
+	if err := s.build(ctx, lock, &job); err != nil {
+		s.fail(ctx, &job, err)
+		return
+	}
+
+	s.setStatus(ctx, &job, models.ReindexStatusSwapping)
+	for _, query := range job.HintFor {
+		hint := &models.IndexHint{Query: query, Collection: job.Collection, IndexName: job.IndexName, UpdatedAt: time.Now().UTC()}
+		if err := s.hintRepo.Set(ctx, hint); err != nil {
+			s.fail(ctx, &job, utils.WrapError(err, "set index hint failed: query=%s", query))
+			return
+		}
+		database.SetQueryHint(query, job.IndexName)
+	}
+
+	if len(job.DropIndexes) > 0 {
+		s.setStatus(ctx, &job, models.ReindexStatusDropping)
+		if err := s.dropObsolete(ctx, lock, &job); err != nil {
+			s.fail(ctx, &job, err)
+			return
+		}
+	}
+
+	completedAt := time.Now().UTC()
+	job.CompletedAt = &completedAt
+	job.Progress = 100
+	s.setStatus(ctx, &job, models.ReindexStatusCompleted)
+	logger.GlobalLogger.Printf("Reindex completed: jobID=%s, collection=%s, index=%s", job.ID.Hex(), job.Collection, job.IndexName)
+}
+
+// build starts the index build and polls its progress until it finishes, keeping the lock alive.
+func (s *ReindexService) build(ctx context.Context, lock *cache.Lock, job *models.ReindexJob) error {
+	indexOptions := options.Index().SetName(job.IndexName)
+	if job.Unique {
+		indexOptions.SetUnique(true)
+	}
+	model := mongo.IndexModel{Keys: indexKeys(job.Keys), Options: indexOptions}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- database.BuildIndex(ctx, job.Collection, model)
+	}()
+
+	ticker := time.NewTicker(reindexPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				return utils.WrapError(err, "build index failed: collection=%s, index=%s", job.Collection, job.IndexName)
+			}
+			job.Progress = 100
+			return nil
+		case <-ticker.C:
+			s.extendLock(ctx, lock, job)
+			percent, ok, err := database.IndexBuildProgress(ctx, job.Collection)
+			if err != nil {
+				// $currentOp needs the inprog privilege; the build itself is unaffected
+				logger.GlobalLogger.Warnf("Failed to read index build progress: jobID=%s, error=%v", job.ID.Hex(), err)
+				continue
+			}
+			if ok {
+				job.Progress = percent
+				job.UpdatedAt = time.Now().UTC()
+				if err := s.jobRepo.UpdateProgress(ctx, job); err != nil {
+					logger.GlobalLogger.Warnf("Failed to record reindex progress: jobID=%s, error=%v", job.ID.Hex(), err)
+				}
+			}
+		}
+	}
+}
+
+// dropObsolete clears hints pointing at the indexes being replaced, waits for every instance to
+// pick that up, then drops them.
+func (s *ReindexService) dropObsolete(ctx context.Context, lock *cache.Lock, job *models.ReindexJob) error {
+	for _, name := range job.DropIndexes {
+		if err := s.hintRepo.DeleteByIndex(ctx, job.Collection, name); err != nil {
+			return utils.WrapError(err, "clear index hints failed: index=%s", name)
+		}
+		database.ClearQueryHintsForIndex(name)
+	}
+
+	deadline := time.Now().Add(reindexDropGrace)
+	for time.Now().Before(deadline) {
+		s.extendLock(ctx, lock, job)
+		time.Sleep(reindexPollInterval)
+	}
+
+	existing, err := database.IndexNames(ctx, job.Collection)
+	if err != nil {
+		return utils.WrapError(err, "list indexes failed: collection=%s", job.Collection)
+	}
+	present := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		present[name] = true
+	}
+	for _, name := range job.DropIndexes {
+		if !present[name] {
+			logger.GlobalLogger.Warnf("Obsolete index already absent: jobID=%s, index=%s", job.ID.Hex(), name)
+			continue
+		}
+		if err := database.DropIndex(ctx, job.Collection, name); err != nil {
+			return utils.WrapError(err, "drop index failed: collection=%s, index=%s", job.Collection, name)
+		}
+	}
+	return nil
+}
+
+func (s *ReindexService) extendLock(ctx context.Context, lock *cache.Lock, job *models.ReindexJob) {
+	if err := lock.Extend(ctx); err != nil {
+		logger.GlobalLogger.Warnf("Failed to extend reindex lock: jobID=%s, error=%v", job.ID.Hex(), err)
+	}
+}
+
+func (s *ReindexService) setStatus(ctx context.Context, job *models.ReindexJob, status string) {
+	job.Status = status
+	job.UpdatedAt = time.Now().UTC()
+	if err := s.jobRepo.UpdateProgress(ctx, job); err != nil {
+		logger.GlobalLogger.Warnf("Failed to record reindex status: jobID=%s, status=%s, error=%v", job.ID.Hex(), status, err)
+	}
+}
+
+func (s *ReindexService) fail(ctx context.Context, job *models.ReindexJob, err error) {
+	logger.GlobalLogger.Errorf("Reindex failed: jobID=%s, collection=%s, index=%s, error=%v", job.ID.Hex(), job.Collection, job.IndexName, err)
+	job.Error = err.Error()
+	s.setStatus(ctx, job, models.ReindexStatusFailed)
+}
+
+func validateReindexRequest(req *models.ReindexRequest) error {
+	if !reindexableCollections[req.Collection] {
+		return fmt.Errorf("collection cannot be reindexed: %s", req.Collection)
+	}
+	for _, query := range req.HintFor {
+		if collection, ok := repositories.HintableQueries[query]; !ok || collection != req.Collection {
+			return fmt.Errorf("unknown query for collection %s: %s", req.Collection, query)
+		}
+	}
+	for _, name := range req.DropIndexes {
+		if name == "_id_" || name == req.IndexName {
+			return fmt.Errorf("index cannot be dropped: %s", name)
+		}
+		// The registry would build it again on the next start
+		if database.IsRegisteredIndex(req.Collection, name) {
+			return fmt.Errorf("index cannot be dropped, it is in the index registry: %s", name)
+		}
+	}
+	return nil
+}
+
+func indexKeys(keys []models.IndexKey) bson.D {
+	doc := make(bson.D, 0, len(keys))
+	for _, key := range keys {
+		switch key.Type {
+		case "asc":
+			doc = append(doc, bson.E{Key: key.Field, Value: 1})
+		case "desc":
+			doc = append(doc, bson.E{Key: key.Field, Value: -1})
+		default:
+			doc = append(doc, bson.E{Key: key.Field, Value: key.Type})
+		}
+	}
+	return doc
+}
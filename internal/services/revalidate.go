@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+const (
+	revalidateTimeout = 30 * time.Second
+	revalidateLockTTL = revalidateTimeout + 5*time.Second
+)
+
+// revalidator refreshes stale cache entries in the background. Each key is refreshed by at most one
+// goroutine per instance, and by one instance at a time across the fleet.
+type revalidator struct {
+	inflight sync.Map
+}
+
+// Revalidate runs refresh for key in the background unless a refresh for it is already running.
+// refresh gets a context detached from the request, which may finish before the refresh does.
+func (r *revalidator) Revalidate(key string, refresh func(ctx context.Context) error) {
+	if _, running := r.inflight.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer r.inflight.Delete(key)
+
+		ctx, cancel := context.WithTimeout(context.Background(), revalidateTimeout)
+		defer cancel()
+
+		lock, err := cache.AcquireLock(ctx, cache.RefreshLockName(key), revalidateLockTTL)
+		if err == cache.ErrLockHeld {
+			return
+		}
+		if err != nil {
+			logger.GlobalLogger.Warnf("Failed to acquire revalidation lock: key=%s, error=%v", key, err)
+			return
+		}
+		defer lock.Release(context.Background())
+
+		if err := refresh(ctx); err != nil {
+			logger.GlobalLogger.Warnf("Background revalidation failed: key=%s, error=%v", key, err)
+			return
+		}
+		logger.GlobalLogger.Printf("Revalidated stale cache entry: key=%s", key)
+	}()
+}
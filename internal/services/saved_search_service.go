@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	savedSearchBatchSize = 100
+	savedSearchLockName  = "saved-search-run"
+)
+
+type SavedSearchService struct {
+	repo          repositories.SavedSearchRepository
+	matchRepo     repositories.SavedSearchMatchRepository
+	propertyRepo  repositories.PropertyRepository
+	notifications *NotificationService
+	config        *config.Config
+}
+
+func NewSavedSearchService(
+	repo repositories.SavedSearchRepository,
+	matchRepo repositories.SavedSearchMatchRepository,
+	propertyRepo repositories.PropertyRepository,
+	notifications *NotificationService,
+	cfg *config.Config,
+) *SavedSearchService {
+	return &SavedSearchService{
+		repo:          repo,
+		matchRepo:     matchRepo,
+		propertyRepo:  propertyRepo,
+		notifications: notifications,
+		config:        cfg,
+	}
+}
+
+func (s *SavedSearchService) CreateSavedSearch(ctx context.Context, userID string, req *models.CreateSavedSearchRequest) (*models.SavedSearch, error) {
+	criteria := req.Criteria
+	if criteria.Address == "" && criteria.City == "" && criteria.State == "" && criteria.ZipCode == "" && criteria.MinPrice == nil && criteria.MaxPrice == nil {
+		return nil, errors.NewAppError("saved search has no criteria", "Please provide at least one search criterion.", errors.ErrCodeInvalidParameters, http.StatusBadRequest, nil)
+	}
+	if criteria.MinPrice != nil && criteria.MaxPrice != nil && *criteria.MinPrice > *criteria.MaxPrice {
+		return nil, errors.NewAppError("saved search minPrice exceeds maxPrice", "Minimum price cannot be greater than maximum price.", errors.ErrCodeInvalidParameters, http.StatusBadRequest, nil)
+	}
+
+	count, err := s.repo.CountByUserID(ctx, userID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: userID=%s", userID)
+	}
+	if count >= int64(s.config.SavedSearches.MaxPerUser) {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("saved search limit reached: userID=%s, limit=%d", userID, s.config.SavedSearches.MaxPerUser),
+			fmt.Sprintf("You can save at most %d searches. Please delete one and try again.", s.config.SavedSearches.MaxPerUser),
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	search := &models.SavedSearch{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Name:      req.Name,
+		Criteria:  criteria,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.Create(ctx, search); err != nil {
+		return nil, utils.WrapError(err, "create saved search failed: userID=%s", userID)
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) ListSavedSearches(ctx context.Context, userID string) ([]models.SavedSearch, error) {
+	searches, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: userID=%s", userID)
+	}
+	return searches, nil
+}
+
+func (s *SavedSearchService) DeleteSavedSearch(ctx context.Context, id, userID string) error {
+	search, err := s.repo.FindByID(ctx, id, userID)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: savedSearchId=%s", id)
+	}
+	if search == nil {
+		return fmt.Errorf("saved search not found: id=%s", id)
+	}
+	if _, err := s.repo.Delete(ctx, id, userID); err != nil {
+		return utils.WrapError(err, "delete saved search failed: savedSearchId=%s", id)
+	}
+	if err := s.matchRepo.DeleteBySavedSearchID(ctx, search.ID); err != nil {
+		logger.GlobalLogger.Warnf("Failed to delete saved search matches: savedSearchId=%s, error=%v", id, err)
+	}
+	return nil
+}
+
+func (s *SavedSearchService) ListMatches(ctx context.Context, id, userID string, offset, limit int) ([]models.SavedSearchMatch, int64, error) {
+	search, err := s.repo.FindByID(ctx, id, userID)
+	if err != nil {
+		return nil, 0, utils.WrapError(err, "database query failed: savedSearchId=%s", id)
+	}
+	if search == nil {
+		return nil, 0, fmt.Errorf("saved search not found: id=%s", id)
+	}
+	matches, total, err := s.matchRepo.FindBySavedSearchID(ctx, search.ID, offset, limit)
+	if err != nil {
+		return nil, 0, utils.WrapError(err, "database query failed: savedSearchId=%s", id)
+	}
+	return matches, total, nil
+}
+
+// RunSavedSearches re-runs every saved search that hasn't run since this pass started, recording
+// properties that newly match. A shared lock keeps instances from running the same pass twice.
+func (s *SavedSearchService) RunSavedSearches(ctx context.Context) error {
+	interval := time.Duration(s.config.SavedSearches.RunIntervalMinutes) * time.Minute
+	lock, err := cache.AcquireLock(ctx, savedSearchLockName, interval)
+	if err == cache.ErrLockHeld {
+		return nil
+	}
+	if err != nil {
+		return utils.WrapError(err, "acquire saved search lock failed")
+	}
+	defer lock.Release(context.Background())
+
+	runStart := time.Now().UTC()
+	ran, matched := 0, 0
+	for {
+		searches, err := s.repo.FindDue(ctx, runStart, savedSearchBatchSize)
+		if err != nil {
+			return utils.WrapError(err, "database query failed: due saved searches")
+		}
+		if len(searches) == 0 {
+			break
+		}
+		for i := range searches {
+			newMatches, err := s.runSavedSearch(ctx, &searches[i], runStart)
+			if err != nil {
+				logger.GlobalLogger.Errorf("Saved search run failed: savedSearchId=%s, error=%v", searches[i].ID.Hex(), err)
+			}
+			ran++
+			matched += newMatches
+		}
+		if err := lock.Extend(ctx); err != nil {
+			logger.GlobalLogger.Warnf("Failed to extend saved search lock: %v", err)
+		}
+	}
+	logger.GlobalLogger.Printf("Saved search run: searches=%d, newMatches=%d", ran, matched)
+	return nil
+}
+
+// runSavedSearch checks properties updated since the search last ran. Until the search has caught up
+// with everything that matched when it was created, matches are recorded as a baseline without
+// alerting; after that each new match alerts the owner. Failed runs keep their watermark and are
+// retried on the next pass. Only properties of the organization the search was saved in match.
+func (s *SavedSearchService) runSavedSearch(ctx context.Context, search *models.SavedSearch, runAt time.Time) (int, error) {
+	ctx = tenant.WithOrgID(ctx, search.OrgID)
+	if err := s.repo.MarkAttempted(ctx, search.ID, runAt); err != nil {
+		return 0, utils.WrapError(err, "mark saved search attempted failed: savedSearchId=%s", search.ID.Hex())
+	}
+
+	var since time.Time
+	if search.LastRunAt != nil {
+		since = *search.LastRunAt
+	}
+
+	limit := s.config.SavedSearches.MaxMatchesPerRun
+	properties, err := s.propertyRepo.FindMatchingCriteria(ctx, search.Criteria, since, limit)
+	if err != nil {
+		return 0, utils.WrapError(err, "database query failed: savedSearchId=%s", search.ID.Hex())
+	}
+
+	// When capped, resume after the newest property seen on the next pass
+	caughtUp := len(properties) < limit
+	if !caughtUp {
+		runAt = properties[len(properties)-1].UpdatedAt
+	}
+
+	matches := make([]models.SavedSearchMatch, 0, len(properties))
+	for _, property := range properties {
+		matches = append(matches, models.SavedSearchMatch{
+			ID:            primitive.NewObjectID(),
+			SavedSearchID: search.ID,
+			UserID:        search.UserID,
+			PropertyID:    property.PropertyID,
+			Address:       property.Address,
+			Price:         property.LastMarketSale.Amount,
+			MatchedAt:     time.Now().UTC(),
+		})
+	}
+	inserted, err := s.matchRepo.InsertNew(ctx, matches)
+	if err != nil {
+		return len(inserted), utils.WrapError(err, "record saved search matches failed: savedSearchId=%s", search.ID.Hex())
+	}
+	s.markRun(ctx, search, runAt, int64(len(inserted)), caughtUp)
+
+	if search.BaselineComplete {
+		for _, match := range inserted {
+			alert := &models.PropertyAlert{
+				ID:         primitive.NewObjectID(),
+				UserID:     search.UserID,
+				PropertyID: match.PropertyID,
+				Type:       models.AlertTypeSavedSearchMatch,
+				Area:       savedSearchAlertArea(match.Address),
+				Summary:    fmt.Sprintf("%s now matches your saved search %q", match.Address.StreetAddress, search.Name),
+			}
+			if err := s.notifications.EnqueueAlert(ctx, alert); err != nil {
+				logger.GlobalLogger.Warnf("Failed to enqueue saved search alert: savedSearchId=%s, propertyID=%s, error=%v", search.ID.Hex(), match.PropertyID, err)
+			}
+		}
+	}
+	return len(inserted), nil
+}
+
+func (s *SavedSearchService) markRun(ctx context.Context, search *models.SavedSearch, runAt time.Time, newMatches int64, caughtUp bool) {
+	if err := s.repo.MarkRun(ctx, search.ID, runAt, newMatches, caughtUp); err != nil {
+		logger.GlobalLogger.Errorf("Failed to mark saved search run: savedSearchId=%s, error=%v", search.ID.Hex(), err)
+	}
+}
+
+func savedSearchAlertArea(address models.Address) string {
+	return AlertArea(&models.Property{Address: address})
+}
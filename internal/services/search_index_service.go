@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/search"
+)
+
+// JobSearchIndexSync is the background job type bringing a property's search index document in line
+// with the database.
+const JobSearchIndexSync = "search_index.sync"
+
+type searchIndexSyncPayload struct {
+	PropertyID string `json:"propertyId"`
+	OrgID      string `json:"orgId"`
+}
+
+// SearchIndexService keeps an Elasticsearch/OpenSearch index of properties and answers searches from
+// it. Every property write queues a sync job that reads the property back and indexes or removes it,
+// so the index catches up with the database even when it was unreachable at the time of the write.
+type SearchIndexService struct {
+	client   *search.Client
+	repo     repositories.PropertyRepository
+	jobs     *jobs.Queue
+	bulkSize int
+	// owner names stay out of the index while PII encryption is enabled, as they do out of the
+	// database's text index
+	owners bool
+}
+
+// NewSearchIndexService returns the search index service, or nil when the index is disabled; a nil
+// service queues nothing, and searches then go to MongoDB.
+func NewSearchIndexService(repo repositories.PropertyRepository, jobQueue *jobs.Queue, pii fieldcrypt.Cipher, cfg *config.Config) *SearchIndexService {
+	if !cfg.SearchIndex.Enabled {
+		return nil
+	}
+	s := &SearchIndexService{
+		client:   search.New(cfg),
+		repo:     repo,
+		jobs:     jobQueue,
+		bulkSize: cfg.SearchIndex.BulkSize,
+		owners:   !pii.Enabled(),
+	}
+	jobQueue.Register(JobSearchIndexSync, s.runSync, jobs.Options{Timeout: time.Minute})
+	return s
+}
+
+// Sync queues an update of a property's index document, once the write's transaction commits.
+// Failures are logged rather than returned, so an unavailable queue never undoes a completed change.
+func (s *SearchIndexService) Sync(ctx context.Context, propertyID string) {
+	if s == nil {
+		return
+	}
+	payload := &searchIndexSyncPayload{PropertyID: propertyID, OrgID: tenant.OrgID(ctx)}
+	repositories.AfterCommit(ctx, func() {
+		if _, err := s.jobs.Enqueue(ctx, JobSearchIndexSync, payload, jobs.EnqueueOptions{}); err != nil {
+			logger.GlobalLogger.WithContext(ctx).Errorf("Failed to queue search index sync: propertyId=%s, error=%v", propertyID, err)
+		}
+	})
+}
+
+// runSync indexes the property as it is now, or removes it when it no longer exists or was deleted.
+// Jobs for the same property may finish out of order; the index keeps the most recently updated
+// version either way.
+func (s *SearchIndexService) runSync(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var payload searchIndexSyncPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, jobs.Permanent(err)
+	}
+	ctx = tenant.WithOrgID(ctx, payload.OrgID)
+	property, err := s.repo.FindByID(ctx, payload.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+	if property == nil {
+		return nil, s.client.Delete(ctx, payload.OrgID, payload.PropertyID)
+	}
+	return nil, s.client.Index(ctx, s.document(property))
+}
+
+// Prepare creates the index if needed and fills it from the database when it is empty, e.g. on
+// first start. Properties written meanwhile are synced by their own jobs.
+func (s *SearchIndexService) Prepare(ctx context.Context) {
+	if s == nil {
+		return
+	}
+	if err := s.client.EnsureIndex(ctx); err != nil {
+		logger.GlobalLogger.Errorf("Failed to create search index: error=%v", err)
+		return
+	}
+	count, err := s.client.Count(ctx)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to count search index documents: error=%v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	indexed := 0
+	batch := make([]search.Document, 0, s.bulkSize)
+	flush := func() error {
+		if err := s.client.Bulk(ctx, batch); err != nil {
+			return err
+		}
+		indexed += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	err = s.repo.Stream(ctx, nil, nil, s.bulkSize, func(property *models.Property) error {
+		batch = append(batch, *s.document(property))
+		if len(batch) < s.bulkSize {
+			return nil
+		}
+		return flush()
+	})
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to fill search index: indexed=%d, error=%v", indexed, err)
+		return
+	}
+	logger.GlobalLogger.Printf("Search index filled: properties=%d", indexed)
+}
+
+// TextSearch ranks properties against a free-text query, like the repository's text search but
+// tolerant of typos.
+func (s *SearchIndexService) TextSearch(ctx context.Context, query string, offset, limit int) ([]models.Property, int64, error) {
+	result, err := s.client.FullText(ctx, tenant.OrgID(ctx), query, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	properties, err := s.load(ctx, result)
+	if err != nil {
+		return nil, 0, err
+	}
+	return properties, result.Total, nil
+}
+
+// FindAddressCandidates returns up to limit properties in a city whose street address resembles
+// street, for fuzzy address matching.
+func (s *SearchIndexService) FindAddressCandidates(ctx context.Context, street, city, state, zip string, limit int) ([]models.Property, error) {
+	result, err := s.client.AddressCandidates(ctx, tenant.OrgID(ctx), street, city, state, zip, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.load(ctx, result)
+}
+
+// FindNearby returns properties within radiusMeters of a point, nearest first, with their distance.
+func (s *SearchIndexService) FindNearby(ctx context.Context, lat, lng, radiusMeters float64, offset, limit int) ([]models.NearbyProperty, int64, error) {
+	result, err := s.client.Nearby(ctx, tenant.OrgID(ctx), lat, lng, radiusMeters, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	properties, err := s.load(ctx, result)
+	if err != nil {
+		return nil, 0, err
+	}
+	distances := make(map[string]float64, len(result.Hits))
+	for _, hit := range result.Hits {
+		distances[hit.PropertyID] = hit.DistanceMeters
+	}
+	nearby := make([]models.NearbyProperty, 0, len(properties))
+	for _, property := range properties {
+		nearby = append(nearby, models.NearbyProperty{Property: property, DistanceMeters: distances[property.PropertyID]})
+	}
+	return nearby, result.Total, nil
+}
+
+// load reads the hits' properties from the database in ranked order. Hits the index still holds for
+// properties deleted since are dropped.
+func (s *SearchIndexService) load(ctx context.Context, result *search.Result) ([]models.Property, error) {
+	ids := make([]string, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		ids = append(ids, hit.PropertyID)
+	}
+	properties, err := s.repo.FindByIDs(ctx, ids, nil, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return orderByIDs(properties, ids), nil
+}
+
+func (s *SearchIndexService) document(property *models.Property) *search.Document {
+	doc := &search.Document{
+		PropertyID:     property.PropertyID,
+		OrgID:          property.OrgID,
+		StreetAddress:  property.Address.StreetAddress,
+		City:           property.Address.City,
+		State:          property.Address.State,
+		ZipCode:        property.Address.ZipCode,
+		County:         property.Address.County,
+		Subdivision:    property.Location.Legal.SubdivisionName,
+		SchoolDistrict: property.TaxAssessment.SchoolDistrict.Name,
+		UpdatedAt:      property.UpdatedAt,
+	}
+	for _, owner := range property.Ownership.CurrentOwners {
+		if s.owners && owner.FullName != "" {
+			doc.Owners = append(doc.Owners, owner.FullName)
+		}
+	}
+	if parcel := property.Location.Coordinates.Parcel; parcel.Lat != 0 || parcel.Lng != 0 {
+		doc.Location = &search.GeoPoint{Lat: parcel.Lat, Lon: parcel.Lng}
+	}
+	return doc
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"homeinsight-properties/internal/auth"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// ListSessions returns the devices a user is signed in on, most recently used first, marking the
+// one the request was made from.
+func (s *UserService) ListSessions(ctx context.Context, userID, currentSessionID string) (*models.SessionsResponse, error) {
+	sessions, err := s.sessions.FindActive(ctx, userID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: sessions userID=%s", userID)
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		ids = append(ids, session.ID.Hex())
+	}
+	// Requests between refreshes are only recorded in Redis; without it the refresh time is shown
+	lastUsed, err := cache.SessionsLastUsed(ctx, ids)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Failed to read session last use: user_id=%s, error=%v", userID, err)
+	}
+	for i := range sessions {
+		id := sessions[i].ID.Hex()
+		if used, ok := lastUsed[id]; ok && used.After(sessions[i].LastUsedAt) {
+			sessions[i].LastUsedAt = used
+		}
+		sessions[i].Current = id == currentSessionID
+	}
+	return &models.SessionsResponse{Data: sessions}, nil
+}
+
+// RevokeSession signs out one of a user's devices: its refresh tokens stop working and its access
+// tokens are rejected from the next request.
+func (s *UserService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	found, err := s.sessions.Revoke(ctx, userID, sessionID)
+	if err != nil {
+		return utils.WrapError(err, "database update failed: session id=%s", sessionID)
+	}
+	if !found {
+		return fmt.Errorf("session not found: id=%s", sessionID)
+	}
+	return s.endSession(ctx, userID, sessionID)
+}
+
+// endSession revokes a session's refresh token family and denies its access tokens until they expire.
+func (s *UserService) endSession(ctx context.Context, userID, sessionID string) error {
+	if err := s.refreshRepo.RevokeFamily(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %v", err)
+	}
+	if _, err := s.sessions.Revoke(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %v", err)
+	}
+	if err := cache.DenySession(ctx, sessionID, auth.AccessTokenTTL); err != nil {
+		return fmt.Errorf("failed to revoke session access tokens: %v", err)
+	}
+	return nil
+}
+
+// endAllSessions signs a user out of every device.
+func (s *UserService) endAllSessions(ctx context.Context, userID string) error {
+	if err := s.refreshRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %v", err)
+	}
+	sessionIDs, err := s.sessions.RevokeAllForUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %v", err)
+	}
+	for _, sessionID := range sessionIDs {
+		if err := cache.DenySession(ctx, sessionID, auth.AccessTokenTTL); err != nil {
+			return fmt.Errorf("failed to revoke session access tokens: %v", err)
+		}
+	}
+	return nil
+}
+
+// Browsers and operating systems in the order they are looked for in a User-Agent; Edge and Opera
+// also claim to be Chrome, and Chrome claims to be Safari.
+var (
+	userAgentBrowsers = []struct{ token, name string }{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"Firefox/", "Firefox"}, {"Chrome/", "Chrome"},
+		{"Safari/", "Safari"}, {"okhttp", "Android app"}, {"CFNetwork", "iOS app"}, {"curl/", "curl"},
+	}
+	userAgentSystems = []struct{ token, name string }{
+		{"iPhone", "iOS"}, {"iPad", "iPadOS"}, {"Android", "Android"}, {"Windows", "Windows"},
+		{"Mac OS X", "macOS"}, {"Linux", "Linux"},
+	}
+)
+
+// describeDevice names the browser and operating system of a User-Agent for the session list, such
+// as "Chrome on macOS".
+func describeDevice(userAgent string) string {
+	var browser, system string
+	for _, b := range userAgentBrowsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+	for _, sys := range userAgentSystems {
+		if strings.Contains(userAgent, sys.token) {
+			system = sys.name
+			break
+		}
+	}
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return "Unknown device"
+	}
+}
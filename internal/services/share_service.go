@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareService struct {
+	repo            repositories.ShareLinkRepository
+	propertyService *PropertyService
+	config          *config.Config
+}
+
+func NewShareService(repo repositories.ShareLinkRepository, propertyService *PropertyService, cfg *config.Config) *ShareService {
+	return &ShareService{
+		repo:            repo,
+		propertyService: propertyService,
+		config:          cfg,
+	}
+}
+
+// sign produces the HMAC signature binding a link ID to its expiry.
+func (s *ShareService) sign(linkID string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, []byte(s.config.ShareLinks.Secret))
+	mac.Write([]byte(linkID + "." + strconv.FormatInt(expiresAt, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Token returns the signed token for a share link in the form <linkId>.<expiresUnix>.<signature>.
+func (s *ShareService) Token(link *models.ShareLink) string {
+	expiresAt := link.ExpiresAt.Unix()
+	return link.LinkID + "." + strconv.FormatInt(expiresAt, 10) + "." + s.sign(link.LinkID, expiresAt)
+}
+
+// verifyToken checks the token signature and expiry and returns the link ID it refers to.
+func (s *ShareService) verifyToken(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid share link: malformed token")
+	}
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid share link: malformed expiry")
+	}
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(parts[0], expiresAt))) {
+		return "", fmt.Errorf("invalid share link: signature mismatch")
+	}
+	if time.Now().Unix() >= expiresAt {
+		return "", fmt.Errorf("share link expired: linkId=%s", parts[0])
+	}
+	return parts[0], nil
+}
+
+func (s *ShareService) CreateShareLink(ctx context.Context, propertyID, userID string, expiresInHours int) (*models.ShareLink, error) {
+	if expiresInHours == 0 {
+		expiresInHours = s.config.ShareLinks.DefaultTTLHours
+	}
+	if expiresInHours < 0 || expiresInHours > s.config.ShareLinks.MaxTTLHours {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("share link ttl out of range: expires_in_hours=%d", expiresInHours),
+			fmt.Sprintf("Share links can be valid for at most %d hours", s.config.ShareLinks.MaxTTLHours),
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+
+	// Ensure the property exists before handing out a link to it
+	if _, err := s.propertyService.GetPropertyByID(ctx, propertyID); err != nil {
+		return nil, err
+	}
+
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, utils.WrapError(err, "generate share link id failed")
+	}
+
+	now := time.Now().UTC()
+	link := &models.ShareLink{
+		LinkID:     hex.EncodeToString(idBytes),
+		PropertyID: propertyID,
+		CreatedBy:  userID,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(time.Duration(expiresInHours) * time.Hour).Truncate(time.Second),
+	}
+	if err := s.repo.Create(ctx, link); err != nil {
+		return nil, utils.WrapError(err, "create share link failed: propertyID=%s", propertyID)
+	}
+	return link, nil
+}
+
+func (s *ShareService) ListShareLinks(ctx context.Context, propertyID, userID string) ([]models.ShareLink, error) {
+	links, err := s.repo.FindByPropertyID(ctx, propertyID, userID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: propertyID=%s", propertyID)
+	}
+	return links, nil
+}
+
+func (s *ShareService) RevokeShareLink(ctx context.Context, propertyID, linkID, userID string) error {
+	revoked, err := s.repo.Revoke(ctx, propertyID, linkID, userID)
+	if err != nil {
+		return utils.WrapError(err, "revoke share link failed: linkId=%s", linkID)
+	}
+	if !revoked {
+		return fmt.Errorf("invalid share link: linkId=%s not found or already revoked", linkID)
+	}
+	return nil
+}
+
+// ResolveShareLink validates a share token, records the access and returns the redacted property view.
+func (s *ShareService) ResolveShareLink(ctx context.Context, token string) (*models.SharedPropertyView, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+
+	linkID, err := s.verifyToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := s.repo.RecordAccess(ctx, linkID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: linkId=%s", linkID)
+	}
+	if link == nil {
+		// The signature proves we issued this link, so a missing record means it was revoked
+		return nil, fmt.Errorf("share link revoked: linkId=%s", linkID)
+	}
+	ginCtx.Set("query", "share_link="+linkID)
+
+	// Links are opened without signing in; the property is the one of the organization that shared it
+	ctx = tenant.Scope(ctx, link.OrgID)
+	property, err := s.propertyService.GetPropertyByID(ctx, link.PropertyID)
+	if err != nil {
+		return nil, err
+	}
+	return redactForShare(property, link.ExpiresAt), nil
+}
+
+func redactForShare(property *models.Property, expiresAt time.Time) *models.SharedPropertyView {
+	return &models.SharedPropertyView{
+		PropertyID:       property.PropertyID,
+		Address:          property.Address,
+		Coordinates:      property.Location.Coordinates.Parcel,
+		Lot:              property.Lot,
+		LandUseAndZoning: property.LandUseAndZoning,
+		BuildingSummary:  property.Building.Summary,
+		YearBuilt:        property.Building.Details.Construction.YearBuilt,
+		AssessedValue:    property.TaxAssessment.AssessedValue,
+		TaxYear:          property.TaxAssessment.Year,
+		LastSaleDate:     property.LastMarketSale.Date,
+		LastSaleAmount:   property.LastMarketSale.Amount,
+		ExpiresAt:        expiresAt,
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// recentTransactionsLimit caps the transactions embedded in a property with ?include=transactions;
+// the rest are paged through GET /api/properties/:id/transactions.
+const recentTransactionsLimit = 10
+
+// TransactionService keeps the sale and transfer history of properties, which the property document
+// only holds the latest entry of.
+type TransactionService struct {
+	repo  repositories.TransactionRepository
+	cache repositories.PropertyCache
+}
+
+func NewTransactionService(repo repositories.TransactionRepository, cache repositories.PropertyCache) *TransactionService {
+	return &TransactionService{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// Record stores the transactions fetched with a property.
+func (s *TransactionService) Record(ctx context.Context, property *models.Property) error {
+	if err := s.repo.UpsertMany(ctx, property.PropertyID, property.Transactions); err != nil {
+		return utils.WrapError(err, "database query failed: store transactions propertyId=%s", property.PropertyID)
+	}
+	if err := s.cache.Delete(ctx, cache.TransactionsKey(property.OrgID, property.PropertyID)); err != nil {
+		logger.GlobalLogger.Warnf("Failed to invalidate cached transactions: propertyId=%s, error=%v", property.PropertyID, err)
+	}
+	return nil
+}
+
+// Recent returns a property's latest transactions, most recent sale first, from the cache when it
+// has them.
+func (s *TransactionService) Recent(ctx context.Context, propertyID string) ([]models.Transaction, error) {
+	key := cache.TransactionsKey(tenant.OrgID(ctx), propertyID)
+	if transactions, ok, err := s.cache.GetTransactions(ctx, key); err == nil && ok {
+		return transactions, nil
+	}
+	transactions, _, err := s.repo.FindByProperty(ctx, propertyID, models.TransactionFilter{}, 0, recentTransactionsLimit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: transactions propertyId=%s", propertyID)
+	}
+	if transactions == nil {
+		transactions = []models.Transaction{}
+	}
+	if err := s.cache.SetTransactions(ctx, key, propertyID, transactions, s.cache.TTL(cache.ClassProperty)); err != nil {
+		logger.GlobalLogger.Warnf("Failed to cache transactions: propertyId=%s, error=%v", propertyID, err)
+	}
+	return transactions, nil
+}
+
+// History pages through a property's transactions, most recent sale first.
+func (s *TransactionService) History(ctx context.Context, propertyID string, filter models.TransactionFilter, offset, limit int, baseURL string, params url.Values) (*models.TransactionHistoryResponse, error) {
+	for name, value := range map[string]string{"from": filter.From, "to": filter.To} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("invalid filter: %s must be a date in YYYY-MM-DD format", name)
+		}
+	}
+	if filter.From != "" && filter.To != "" && filter.From > filter.To {
+		return nil, fmt.Errorf("invalid filter: from must not be after to")
+	}
+
+	transactions, total, err := s.repo.FindByProperty(ctx, propertyID, filter, offset, limit)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: transactions propertyId=%s", propertyID)
+	}
+
+	metadata := models.PaginationMeta{
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	}
+	if int64(offset+limit) < total {
+		nextURL := utils.BuildPaginationURL(baseURL, offset+limit, limit, params)
+		metadata.Next = &nextURL
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevURL := utils.BuildPaginationURL(baseURL, prevOffset, limit, params)
+		metadata.Prev = &prevURL
+	}
+	return &models.TransactionHistoryResponse{Data: transactions, Metadata: metadata}, nil
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/usage"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// maxUsageReportDays bounds the range of a usage export.
+const maxUsageReportDays = 366
+
+// UsageService rolls the per-day usage counters kept in Redis up into MongoDB, where they outlive the
+// counters and can be exported for billing.
+type UsageService struct {
+	repo repositories.UsageRepository
+}
+
+func NewUsageService(repo repositories.UsageRepository) *UsageService {
+	return &UsageService{
+		repo: repo,
+	}
+}
+
+// RollUp stores the counts of yesterday and today so far. Yesterday is rolled up again after midnight
+// so its record holds the final counts.
+func (s *UsageService) RollUp(ctx context.Context) error {
+	now := time.Now()
+	for _, day := range []string{usage.Day(now.AddDate(0, 0, -1)), usage.Day(now)} {
+		records := make(map[string]*models.UsageRecord)
+		for _, metric := range usage.Metrics {
+			counts, err := cache.UsageCounts(ctx, string(metric), day)
+			if err != nil {
+				return fmt.Errorf("read usage counters failed: day=%s, metric=%s: %v", day, metric, err)
+			}
+			for field, count := range counts {
+				subjectType, subject, ok := usage.ParseSubject(field)
+				if !ok {
+					continue
+				}
+				record, exists := records[field]
+				if !exists {
+					record = &models.UsageRecord{Day: day, SubjectType: subjectType, Subject: subject, UpdatedAt: now.UTC()}
+					records[field] = record
+				}
+				switch metric {
+				case usage.Requests:
+					record.Requests = count
+				case usage.CoreLogicCalls:
+					record.CoreLogicCalls = count
+				}
+			}
+		}
+
+		batch := make([]models.UsageRecord, 0, len(records))
+		for _, record := range records {
+			batch = append(batch, *record)
+		}
+		if err := s.repo.UpsertMany(ctx, batch); err != nil {
+			return utils.WrapError(err, "database update failed: usage day=%s", day)
+		}
+		logger.GlobalLogger.Printf("Usage rolled up: day=%s, subjects=%d", day, len(batch))
+	}
+	return nil
+}
+
+// Report returns the daily usage records in the filter's range, by default the current month. Today's
+// counts are as of the latest rollup.
+func (s *UsageService) Report(ctx context.Context, filter models.UsageFilter) (*models.UsageResponse, error) {
+	now := time.Now().UTC()
+	if filter.From == "" {
+		filter.From = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).Format(usage.DayFormat)
+	}
+	if filter.To == "" {
+		filter.To = now.Format(usage.DayFormat)
+	}
+	from, err := time.Parse(usage.DayFormat, filter.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: from must be a date in YYYY-MM-DD format")
+	}
+	to, err := time.Parse(usage.DayFormat, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: to must be a date in YYYY-MM-DD format")
+	}
+	if from.After(to) {
+		return nil, fmt.Errorf("invalid filter: from must not be after to")
+	}
+	if to.Sub(from) >= maxUsageReportDays*24*time.Hour {
+		return nil, fmt.Errorf("invalid filter: at most %d days can be exported at once", maxUsageReportDays)
+	}
+	if filter.SubjectType != "" && filter.SubjectType != usage.SubjectOrganization && filter.SubjectType != usage.SubjectAPIKey {
+		return nil, fmt.Errorf("invalid filter: subjectType must be one of %s, %s", usage.SubjectOrganization, usage.SubjectAPIKey)
+	}
+
+	records, err := s.repo.Find(ctx, filter)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: usage from=%s, to=%s", filter.From, filter.To)
+	}
+	return &models.UsageResponse{From: filter.From, To: filter.To, Data: records}, nil
+}
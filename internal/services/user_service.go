@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"homeinsight-properties/internal/auth"
 	"homeinsight-properties/internal/models"
 	"homeinsight-properties/internal/repositories"
 	"homeinsight-properties/internal/validators"
+	"homeinsight-properties/pkg/cache"
 	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
 	"homeinsight-properties/pkg/metrics"
 	"time"
 
@@ -16,32 +19,47 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// ErrInvalidRefreshToken is returned by Refresh for a refresh token that is unknown, expired, revoked
+// or already used, so the client has to sign in again. Other errors from Refresh are storage failures.
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
 type UserService struct {
-    repo      repositories.UserRepository
-    validator validators.UserValidator
-    cfg       *config.Config
+    repo          repositories.UserRepository
+    refreshRepo   repositories.RefreshTokenRepository
+    sessions      repositories.SessionRepository
+    idTokens      *auth.IDTokenVerifier
+    validator     validators.UserValidator
+    notifications *NotificationService
+    orgs          *OrganizationService
+    audit         *AuditEventService
+    cfg           *config.Config
 }
 
-func NewUserService(repo repositories.UserRepository, validator validators.UserValidator) *UserService {
-    cfg, err := config.LoadConfig("configs/config.yaml")
-    if err != nil {
+func NewUserService(repo repositories.UserRepository, refreshRepo repositories.RefreshTokenRepository, sessions repositories.SessionRepository, idTokens *auth.IDTokenVerifier, validator validators.UserValidator, notifications *NotificationService, orgs *OrganizationService, audit *AuditEventService) *UserService {
+    cfg := config.Current()
+    if cfg == nil {
         cfg = &config.Config{} // Fallback to empty config
     }
     return &UserService{
-        repo:      repo,
-        validator: validator,
-        cfg:       cfg,
+        repo:          repo,
+        refreshRepo:   refreshRepo,
+        sessions:      sessions,
+        idTokens:      idTokens,
+        validator:     validator,
+        notifications: notifications,
+        orgs:          orgs,
+        audit:         audit,
+        cfg:           cfg,
     }
 }
 
-func (s *UserService) Register(user *models.User) (*auth.TokenDetails, error) {
+func (s *UserService) Register(ctx context.Context, user *models.User, client models.SessionClient) (*auth.TokenDetails, error) {
     // Validate user input
     if err := s.validator.ValidateRegister(user); err != nil {
         return nil, err
     }
 
     // Check if email already exists
-    ctx := context.Background()
     if existingUser, err := s.repo.FindByEmail(ctx, user.Email); err == nil && existingUser != nil {
         return nil, fmt.Errorf("email already registered")
     } else if err != nil && err != mongo.ErrNoDocuments {
@@ -66,30 +84,25 @@ func (s *UserService) Register(user *models.User) (*auth.TokenDetails, error) {
         return nil, fmt.Errorf("failed to register user: %v", err)
     }
 
-    // Generate JWT
-    start = time.Now()
-    tokenDetails, err := auth.GenerateJWT(user.ID.Hex(), user.FullName, user.Email, user.Phone, s.cfg.JWT.Secret)
-    duration = time.Since(start).Seconds()
-    metrics.MongoOperationDuration.WithLabelValues("generate_jwt", "").Observe(duration)
-    if err != nil {
-        metrics.MongoErrorsTotal.WithLabelValues("generate_jwt", "").Inc()
-        return nil, fmt.Errorf("failed to generate token: %v", err)
-    }
-
-    return tokenDetails, nil
+    return s.startSession(ctx, user, client)
 }
 
-func (s *UserService) Login(email, password string) (*auth.TokenDetails, error) {
+func (s *UserService) Login(ctx context.Context, email, password string, client models.SessionClient) (*auth.TokenDetails, error) {
     // Validate login input
     if err := s.validator.ValidateLogin(email, password); err != nil {
         return nil, err
     }
 
+    // Refuse early while the email or client is locked out after repeated failures
+    if err := s.checkLoginLock(ctx, email, client.IP); err != nil {
+        return nil, err
+    }
+
     // Find user by email
-    ctx := context.Background()
     user, err := s.repo.FindByEmail(ctx, email)
     if err != nil {
         if err == mongo.ErrNoDocuments {
+            s.recordLoginFailure(ctx, email, nil, client.IP)
             return nil, fmt.Errorf("invalid email or password")
         }
         return nil, fmt.Errorf("failed to query user: %v", err)
@@ -101,20 +114,158 @@ func (s *UserService) Login(email, password string) (*auth.TokenDetails, error)
         duration := time.Since(start).Seconds()
         metrics.MongoOperationDuration.WithLabelValues("verify_password", "").Observe(duration)
         metrics.MongoErrorsTotal.WithLabelValues("verify_password", "").Inc()
+        s.recordLoginFailure(ctx, email, user, client.IP)
         return nil, fmt.Errorf("invalid email or password")
     }
     duration := time.Since(start).Seconds()
     metrics.MongoOperationDuration.WithLabelValues("verify_password", "").Observe(duration)
+    s.clearLoginFailures(ctx, email)
+    s.audit.Record(ctx, models.AuditEvent{
+        Type:      models.AuditEventLoginSucceeded,
+        ActorID:   user.ID.Hex(),
+        ActorRole: user.Role,
+        IP:        client.IP,
+        Details:   map[string]interface{}{"method": "password"},
+    })
+
+    return s.startSession(ctx, user, client)
+}
+
+// startSession records a new session for the client and issues its access token and the first
+// refresh token of its token family.
+func (s *UserService) startSession(ctx context.Context, user *models.User, client models.SessionClient) (*auth.TokenDetails, error) {
+    refreshToken, refreshHash, err := auth.GenerateRefreshToken()
+    if err != nil {
+        return nil, err
+    }
+
+    now := time.Now().UTC()
+    session := &models.Session{
+        ID:         primitive.NewObjectID(),
+        UserID:     user.ID.Hex(),
+        Device:     describeDevice(client.UserAgent),
+        UserAgent:  client.UserAgent,
+        IP:         client.IP,
+        CreatedAt:  now,
+        LastUsedAt: now,
+        ExpiresAt:  now.Add(time.Duration(s.cfg.JWT.RefreshTTLHours) * time.Hour),
+    }
+    if err := s.sessions.Create(ctx, session); err != nil {
+        return nil, fmt.Errorf("failed to store session: %v", err)
+    }
+    return s.issueTokens(ctx, user, session.ID.Hex(), refreshToken, refreshHash)
+}
+
+// issueTokens signs an access token for the session and stores the given refresh token in its family.
+func (s *UserService) issueTokens(ctx context.Context, user *models.User, familyID, refreshToken, refreshHash string) (*auth.TokenDetails, error) {
+    // The organization is looked up on every issue, so membership changes apply on refresh
+    orgID, err := s.orgs.OrgIDFor(ctx, user.ID.Hex())
+    if err != nil {
+        return nil, fmt.Errorf("failed to resolve organization: %v", err)
+    }
 
     // Generate JWT
-    start = time.Now()
-    tokenDetails, err := auth.GenerateJWT(user.ID.Hex(), user.FullName, user.Email, user.Phone, s.cfg.JWT.Secret)
-    duration = time.Since(start).Seconds()
+    start := time.Now()
+    tokenDetails, err := auth.GenerateJWT(user.ID.Hex(), user.FullName, user.Email, user.Phone, user.Role, orgID, familyID, auth.Keys())
+    duration := time.Since(start).Seconds()
     metrics.MongoOperationDuration.WithLabelValues("generate_jwt", "").Observe(duration)
     if err != nil {
         metrics.MongoErrorsTotal.WithLabelValues("generate_jwt", "").Inc()
         return nil, fmt.Errorf("failed to generate token: %v", err)
     }
 
+    ttl := time.Duration(s.cfg.JWT.RefreshTTLHours) * time.Hour
+    now := time.Now().UTC()
+    stored := &models.RefreshToken{
+        TokenHash: refreshHash,
+        UserID:    user.ID.Hex(),
+        FamilyID:  familyID,
+        CreatedAt: now,
+        ExpiresAt: now.Add(ttl),
+    }
+    if err := s.refreshRepo.Create(ctx, stored); err != nil {
+        return nil, fmt.Errorf("failed to store refresh token: %v", err)
+    }
+
+    tokenDetails.RefreshToken = refreshToken
+    tokenDetails.RefreshExpiresIn = fmt.Sprintf("%d", int64(ttl/time.Second))
     return tokenDetails, nil
 }
+
+// Refresh exchanges a refresh token for a new access token and a rotated refresh token.
+// Presenting a token that was already rotated revokes its whole family, since it means
+// the token was leaked or replayed.
+func (s *UserService) Refresh(ctx context.Context, refreshToken string, client models.SessionClient) (*auth.TokenDetails, error) {
+    tokenHash := auth.HashRefreshToken(refreshToken)
+    stored, err := s.refreshRepo.FindByHash(ctx, tokenHash)
+    if err != nil {
+        return nil, fmt.Errorf("failed to query refresh token: %v", err)
+    }
+    if stored == nil {
+        return nil, ErrInvalidRefreshToken
+    }
+    if stored.RevokedAt != nil {
+        if err := s.endSession(ctx, stored.UserID, stored.FamilyID); err != nil {
+            return nil, err
+        }
+        return nil, fmt.Errorf("%w: token has been revoked", ErrInvalidRefreshToken)
+    }
+    if time.Now().After(stored.ExpiresAt) {
+        return nil, fmt.Errorf("%w: token expired", ErrInvalidRefreshToken)
+    }
+
+    newToken, newHash, err := auth.GenerateRefreshToken()
+    if err != nil {
+        return nil, err
+    }
+    rotated, err := s.refreshRepo.Rotate(ctx, tokenHash, newHash)
+    if err != nil {
+        return nil, fmt.Errorf("failed to rotate refresh token: %v", err)
+    }
+    if !rotated {
+        // Lost a race with another refresh using the same token
+        if err := s.endSession(ctx, stored.UserID, stored.FamilyID); err != nil {
+            return nil, err
+        }
+        return nil, fmt.Errorf("%w: token has been revoked", ErrInvalidRefreshToken)
+    }
+
+    user, err := s.repo.FindByID(ctx, stored.UserID)
+    if err != nil {
+        if err == mongo.ErrNoDocuments {
+            return nil, ErrInvalidRefreshToken
+        }
+        return nil, fmt.Errorf("failed to query user: %v", err)
+    }
+
+    now := time.Now().UTC()
+    expiresAt := now.Add(time.Duration(s.cfg.JWT.RefreshTTLHours) * time.Hour)
+    if err := s.sessions.Touch(ctx, stored.FamilyID, client.IP, now, expiresAt); err != nil {
+        logger.GlobalLogger.Warnf("Failed to update session on refresh: session_id=%s, error=%v", stored.FamilyID, err)
+    }
+
+    return s.issueTokens(ctx, user, stored.FamilyID, newToken, newHash)
+}
+
+// Logout revokes the access token with the given jti until it expires. If the client also sends its
+// refresh token, its whole session is signed out so it can't be refreshed either.
+func (s *UserService) Logout(ctx context.Context, userID, jti string, expiresAt time.Time, refreshToken string) error {
+    if jti != "" {
+        if err := cache.DenyToken(ctx, jti, time.Until(expiresAt)); err != nil {
+            return fmt.Errorf("failed to revoke access token: %v", err)
+        }
+    }
+
+    if refreshToken == "" {
+        return nil
+    }
+    stored, err := s.refreshRepo.FindByHash(ctx, auth.HashRefreshToken(refreshToken))
+    if err != nil {
+        return fmt.Errorf("failed to query refresh token: %v", err)
+    }
+    // Ignore unknown tokens and tokens of other users rather than revealing which is which
+    if stored == nil || stored.UserID != userID {
+        return nil
+    }
+    return s.endSession(ctx, userID, stored.FamilyID)
+}
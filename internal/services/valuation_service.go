@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/internal/cost"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/usage"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/corelogic"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/providers"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Valuation sources: CoreLogic, or fixtures in sandbox mode.
+const (
+	valuationSource        = "CORELOGIC_AVM"
+	sandboxValuationSource = "SANDBOX_AVM"
+)
+
+type ValuationService struct {
+	repo       repositories.ValuationRepository
+	cache      repositories.PropertyCache
+	properties *PropertyService
+	avm        providers.ValuationProvider
+	source     string
+	config     *config.Config
+}
+
+func NewValuationService(
+	repo repositories.ValuationRepository,
+	cache repositories.PropertyCache,
+	properties *PropertyService,
+	avm providers.ValuationProvider,
+	cfg *config.Config,
+) *ValuationService {
+	source := valuationSource
+	if cfg.Sandbox.Enabled {
+		source = sandboxValuationSource
+	}
+	return &ValuationService{
+		repo:       repo,
+		cache:      cache,
+		properties: properties,
+		avm:        avm,
+		source:     source,
+		config:     cfg,
+	}
+}
+
+// GetValuation returns a property's current valuation from the cache, from the stored history while it
+// is recent enough, or else from a new CoreLogic AVM call that is added to the history.
+func (s *ValuationService) GetValuation(ctx context.Context, propertyID string) (*models.Valuation, error) {
+	ginCtx, _ := ctx.(*gin.Context)
+	if ginCtx == nil {
+		ginCtx = &gin.Context{}
+	}
+
+	valuationKey := cache.ValuationKey(tenant.OrgID(ctx), propertyID)
+	if valuation, err := s.cache.GetValuation(ctx, valuationKey); err == nil && valuation != nil {
+		ginCtx.Set("data_source", "REDIS")
+		ginCtx.Set("cache_hit", true)
+		return valuation, nil
+	}
+
+	property, err := s.properties.GetPropertyByID(ctx, propertyID)
+	if err != nil {
+		return nil, err
+	}
+	ginCtx.Set("cache_hit", false)
+
+	latest, err := s.repo.FindLatest(ctx, propertyID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: valuation propertyId=%s", propertyID)
+	}
+	refreshAfter := time.Duration(s.config.Live().Valuations.RefreshAfterDays) * 24 * time.Hour
+	if latest != nil && time.Since(latest.RetrievedAt) < refreshAfter {
+		ginCtx.Set("data_source", "DATABASE")
+		s.cacheValuation(ctx, latest)
+		return latest, nil
+	}
+
+	ginCtx.Set("data_source", "CORELOGIC_API")
+	cost.Record(ctx, cost.CoreLogicCall)
+	var result *corelogic.AVMResult
+	if err = usage.Take(ctx, usage.CoreLogicCalls); err == nil {
+		result, err = s.avm.RequestValuation(ctx, property.PropertyID, property.AVMPropertyID)
+	}
+	if err != nil {
+		// An outdated valuation is better than none while CoreLogic is unavailable or over quota
+		if latest != nil {
+			logger.GlobalLogger.WithContext(ctx).Warnf("Serving outdated valuation: propertyId=%s, retrievedAt=%s, error=%v",
+				propertyID, latest.RetrievedAt.Format(time.RFC3339), err)
+			ginCtx.Set("data_source", "DATABASE")
+			return latest, nil
+		}
+		return nil, utils.WrapError(err, "CoreLogic valuation failed: propertyId=%s", propertyID)
+	}
+
+	valuation := &models.Valuation{
+		ID:             primitive.NewObjectID(),
+		PropertyID:     propertyID,
+		EstimatedValue: result.AVM.EstimatedValue,
+		ValueRange: models.ValueRange{
+			Low:  result.AVM.EstimatedValueLow,
+			High: result.AVM.EstimatedValueHigh,
+		},
+		ConfidenceScore:           result.AVM.ConfidenceScore,
+		ForecastStandardDeviation: result.AVM.ForecastStandardDeviation,
+		ValuationDate:             result.AVM.ValuationDate,
+		Source:                    s.source,
+		RetrievedAt:               time.Now().UTC(),
+	}
+	if err := s.repo.Create(ctx, valuation); err != nil {
+		return nil, utils.WrapError(err, "database query failed: store valuation propertyId=%s", propertyID)
+	}
+	s.cacheValuation(ctx, valuation)
+	return valuation, nil
+}
+
+func (s *ValuationService) cacheValuation(ctx context.Context, valuation *models.Valuation) {
+	ttl := time.Duration(s.config.Valuations.CacheTTLHours) * time.Hour
+	if err := s.cache.SetValuation(ctx, cache.ValuationKey(valuation.OrgID, valuation.PropertyID), valuation, ttl); err != nil {
+		logger.GlobalLogger.Errorf("Failed to cache valuation: propertyId=%s, error=%v", valuation.PropertyID, err)
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"homeinsight-properties/internal/errors"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/utils"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/jobs"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+	"homeinsight-properties/pkg/webhook"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// webhookLookupTimeout bounds recording delivery outcomes and queueing events, which run detached
+// from the request that triggered the event.
+const webhookLookupTimeout = 5 * time.Second
+
+// Background job types delivering webhook events: a dispatch job fans an event out to one delivery
+// job per subscribed webhook, so each webhook is retried on its own.
+const (
+	JobWebhookDispatch = "webhook.dispatch"
+	JobWebhookDeliver  = "webhook.deliver"
+)
+
+// webhookJob is the payload of both webhook job types; WebhookID is set for deliveries. OrgID is
+// the organization of the property, whose webhooks alone receive the event.
+type webhookJob struct {
+	WebhookID  string `json:"webhookId,omitempty"`
+	OrgID      string `json:"orgId"`
+	EventID    string `json:"eventId"`
+	EventType  string `json:"eventType"`
+	PropertyID string `json:"propertyId"`
+	Body       string `json:"body"`
+}
+
+type WebhookService struct {
+	repo   repositories.WebhookRepository
+	client *http.Client
+	jobs   *jobs.Queue
+	config *config.Config
+}
+
+func NewWebhookService(repo repositories.WebhookRepository, jobQueue *jobs.Queue, cfg *config.Config) *WebhookService {
+	s := &WebhookService{
+		repo:   repo,
+		client: &http.Client{Timeout: time.Duration(cfg.Webhooks.TimeoutSeconds) * time.Second},
+		jobs:   jobQueue,
+		config: cfg,
+	}
+	jobQueue.Register(JobWebhookDispatch, s.dispatch, jobs.Options{})
+	jobQueue.Register(JobWebhookDeliver, s.deliver, jobs.Options{
+		Workers:        cfg.Webhooks.MaxConcurrent,
+		MaxAttempts:    cfg.Webhooks.MaxAttempts,
+		Timeout:        time.Duration(cfg.Webhooks.TimeoutSeconds)*time.Second + 2*webhookLookupTimeout,
+		InitialBackoff: time.Duration(cfg.Webhooks.InitialBackoffSeconds) * time.Second,
+		MaxBackoff:     time.Duration(cfg.Webhooks.MaxBackoffSeconds) * time.Second,
+	})
+	return s
+}
+
+// CreateWebhook registers a callback URL and generates the secret its deliveries are signed with.
+func (s *WebhookService) CreateWebhook(ctx context.Context, userID string, req *models.CreateWebhookRequest) (*models.Webhook, error) {
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, errors.NewAppError(
+			fmt.Sprintf("invalid webhook url: %s", req.URL),
+			errors.MsgInvalidParameters,
+			errors.ErrCodeInvalidParameters,
+			http.StatusBadRequest,
+			err,
+		)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, utils.WrapError(err, "generate webhook secret failed")
+	}
+
+	hook := &models.Webhook{
+		ID:        primitive.NewObjectID(),
+		URL:       req.URL,
+		Events:    dedupeStrings(req.Events),
+		Secret:    hex.EncodeToString(secret),
+		CreatedBy: userID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.repo.Create(ctx, hook); err != nil {
+		return nil, utils.WrapError(err, "database query failed: create webhook")
+	}
+	return hook, nil
+}
+
+// ListWebhooks returns the webhooks registered by the organization without their secrets.
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	hooks, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: webhooks")
+	}
+	for i := range hooks {
+		hooks[i].Secret = ""
+	}
+	return hooks, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return utils.WrapError(err, "database query failed: webhookID=%s", id)
+	}
+	if !deleted {
+		return fmt.Errorf("webhook not found: id=%s", id)
+	}
+	return nil
+}
+
+// Publish queues an event for the webhooks of the property's organization subscribed to eventType;
+// it never fails the change that triggered it. The event is encoded before returning, so the caller
+// may keep using property. property may be nil for deletions, which go to the organization ctx is
+// scoped to.
+func (s *WebhookService) Publish(ctx context.Context, eventType, propertyID string, property *models.Property) {
+	orgID := tenant.OrgID(ctx)
+	if property != nil {
+		orgID = property.OrgID
+	}
+	if orgID == "" {
+		// unscoped work has no organization whose webhooks could receive the event
+		logger.GlobalLogger.Warnf("Skipping webhook event without organization: event=%s, propertyId=%s", eventType, propertyID)
+		return
+	}
+
+	id := make([]byte, 16)
+	rand.Read(id)
+	event := &models.WebhookEvent{
+		ID:         hex.EncodeToString(id),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		PropertyID: propertyID,
+		Property:   property,
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to encode webhook event: event=%s, propertyId=%s, error=%v", eventType, propertyID, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), webhookLookupTimeout)
+	defer cancel()
+	payload := &webhookJob{OrgID: orgID, EventID: event.ID, EventType: eventType, PropertyID: propertyID, Body: string(body)}
+	if _, err := s.jobs.Enqueue(ctx, JobWebhookDispatch, payload, jobs.EnqueueOptions{}); err != nil {
+		logger.GlobalLogger.Errorf("Failed to queue webhook event: event=%s, propertyId=%s, error=%v", eventType, propertyID, err)
+	}
+}
+
+// dispatch queues one delivery per webhook of the property's organization subscribed to the event.
+func (s *WebhookService) dispatch(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var event webhookJob
+	if err := job.Decode(&event); err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrgID(ctx, event.OrgID)
+	hooks, err := s.repo.FindByEvent(ctx, event.EventType)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: webhooks for event=%s", event.EventType)
+	}
+	for i := range hooks {
+		delivery := event
+		delivery.WebhookID = hooks[i].ID.Hex()
+		// a dispatch retried after a partial fan-out doesn't queue a pending delivery twice
+		opts := jobs.EnqueueOptions{UniqueKey: "webhook:" + event.EventID + ":" + delivery.WebhookID}
+		if _, err := s.jobs.Enqueue(ctx, JobWebhookDeliver, &delivery, opts); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]int{"webhooks": len(hooks)}, nil
+}
+
+// deliver POSTs an event to one webhook. A failed attempt is retried by the job queue, waiting twice
+// as long after each attempt up to the configured maximum.
+func (s *WebhookService) deliver(ctx context.Context, job *jobs.Job) (interface{}, error) {
+	var delivery webhookJob
+	if err := job.Decode(&delivery); err != nil {
+		return nil, err
+	}
+	ctx = tenant.WithOrgID(ctx, delivery.OrgID)
+	hook, err := s.repo.FindByID(ctx, delivery.WebhookID)
+	if err != nil {
+		return nil, utils.WrapError(err, "database query failed: webhookID=%s", delivery.WebhookID)
+	}
+	if hook == nil {
+		// deleted since the event was dispatched
+		return nil, nil
+	}
+
+	err = s.post(ctx, hook, &delivery)
+	if err == nil {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(delivery.EventType, models.WebhookDeliverySucceeded).Inc()
+		s.recordDelivery(hook, models.WebhookDeliverySucceeded, "")
+		return nil, nil
+	}
+	metrics.WebhookDeliveriesTotal.WithLabelValues(delivery.EventType, models.WebhookDeliveryFailed).Inc()
+	logger.GlobalLogger.Warnf("Webhook delivery failed: webhookId=%s, event=%s, delivery=%s, attempt=%d, error=%v",
+		hook.ID.Hex(), delivery.EventType, delivery.EventID, job.Attempts, err)
+	if job.LastAttempt() {
+		logger.GlobalLogger.Errorf("Webhook delivery abandoned: webhookId=%s, event=%s, delivery=%s, attempts=%d",
+			hook.ID.Hex(), delivery.EventType, delivery.EventID, job.Attempts)
+		s.recordDelivery(hook, models.WebhookDeliveryFailed, err.Error())
+	}
+	return nil, err
+}
+
+func (s *WebhookService) post(ctx context.Context, hook *models.Webhook, delivery *webhookJob) error {
+	body := []byte(delivery.Body)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.EventHeader, delivery.EventType)
+	req.Header.Set(webhook.DeliveryHeader, delivery.EventID)
+	req.Header.Set(webhook.TimestampHeader, fmt.Sprintf("%d", timestamp))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(hook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *WebhookService) recordDelivery(hook *models.Webhook, status, deliveryErr string) {
+	ctx, cancel := context.WithTimeout(tenant.WithOrgID(context.Background(), hook.OrgID), webhookLookupTimeout)
+	defer cancel()
+	if err := s.repo.RecordDelivery(ctx, hook.ID, time.Now().UTC(), status, deliveryErr); err != nil {
+		logger.GlobalLogger.Errorf("Failed to record webhook delivery: webhookId=%s, error=%v", hook.ID.Hex(), err)
+	}
+}
+
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
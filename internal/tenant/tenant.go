@@ -0,0 +1,53 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contextKey is where the organization ID of an authenticated request lives on the gin context.
+const contextKey = "org_id"
+
+type orgIDKey struct{}
+
+// Attach scopes the rest of a request to an organization, both for code handed the gin context and
+// for code handed the request's own context.
+func Attach(c *gin.Context, orgID string) {
+	c.Set(contextKey, orgID)
+	if c.Request != nil {
+		c.Request = c.Request.WithContext(WithOrgID(c.Request.Context(), orgID))
+	}
+}
+
+// WithOrgID scopes work done outside of a request, e.g. by a background job, to an organization.
+func WithOrgID(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgIDKey{}, orgID)
+}
+
+// Scope scopes ctx to an organization. A gin context is scoped in place and returned as is, so code
+// that reads request metadata from it keeps working.
+func Scope(ctx context.Context, orgID string) context.Context {
+	if c, ok := ctx.(*gin.Context); ok {
+		Attach(c, orgID)
+		return c
+	}
+	return WithOrgID(ctx, orgID)
+}
+
+// OrgID returns the organization ctx is scoped to, or "" for work that spans every organization,
+// such as migrations and scheduled maintenance.
+func OrgID(ctx context.Context) string {
+	if orgID, ok := ctx.Value(orgIDKey{}).(string); ok {
+		return orgID
+	}
+	// gin contexts, and contexts derived from them, resolve string keys from the request's values
+	orgID, _ := ctx.Value(contextKey).(string)
+	return orgID
+}
+
+// Owns reports whether a record of the given organization is visible under ctx.
+func Owns(ctx context.Context, orgID string) bool {
+	scope := OrgID(ctx)
+	return scope == "" || scope == orgID
+}
@@ -0,0 +1,182 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Images the containers run, unless overridden by TEST_MONGO_IMAGE and TEST_REDIS_IMAGE.
+const (
+	defaultMongoImage = "mongo:7"
+	defaultRedisImage = "redis:7-alpine"
+)
+
+// startupTimeout bounds how long a server may take to accept connections, including pulling its image.
+const startupTimeout = 2 * time.Minute
+
+// ErrUnavailable is returned by Start when there is neither a docker daemon to run servers in nor
+// servers given by TEST_MONGO_URI and TEST_REDIS_ADDR.
+var ErrUnavailable = errors.New("docker is not available and TEST_MONGO_URI/TEST_REDIS_ADDR are not set")
+
+// server is a MongoDB or Redis server the tests use: a container started for them, or one given by
+// the environment, which is left running.
+type server struct {
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+	host     string
+	port     int
+}
+
+// stop removes the server's container, if it has one.
+func (s *server) stop() {
+	if s == nil || s.resource == nil {
+		return
+	}
+	if err := s.pool.Purge(s.resource); err != nil {
+		fmt.Fprintf(os.Stderr, "testutil: failed to remove container %s: %v\n", s.resource.Container.ID, err)
+	}
+}
+
+func (s *server) addr() string {
+	return net.JoinHostPort(s.host, strconv.Itoa(s.port))
+}
+
+// connectDocker returns a pool running containers on the docker daemon given by DOCKER_HOST, or the
+// local one, once it answers.
+func connectDocker() (*dockertest.Pool, error) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Client.Ping(); err != nil {
+		return nil, err
+	}
+	pool.MaxWait = startupTimeout
+	return pool, nil
+}
+
+// startContainer runs image with containerPort published on a free loopback port. The container is
+// removed by docker once it stops.
+func startContainer(pool *dockertest.Pool, image string, containerPort int) (*server, error) {
+	repository, tag := docker.ParseRepositoryTag(image)
+	port := docker.Port(fmt.Sprintf("%d/tcp", containerPort))
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository:   repository,
+		Tag:          tag,
+		PortBindings: map[docker.Port][]docker.PortBinding{port: {{HostIP: "127.0.0.1"}}},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("run %s: %v", image, err)
+	}
+	s := &server{pool: pool, resource: resource, host: "127.0.0.1"}
+
+	_, hostPort, err := net.SplitHostPort(resource.GetHostPort(string(port)))
+	if err == nil {
+		s.port, err = strconv.Atoi(hostPort)
+	}
+	if err != nil {
+		s.stop()
+		return nil, fmt.Errorf("no published port for %s: %v", image, err)
+	}
+	return s, nil
+}
+
+// startMongo returns the URI of a MongoDB server accepting connections. pool is only needed when
+// TEST_MONGO_URI is unset.
+func startMongo(pool *dockertest.Pool) (*server, string, error) {
+	if uri := os.Getenv("TEST_MONGO_URI"); uri != "" {
+		return nil, uri, waitForMongo(uri)
+	}
+	s, err := startContainer(pool, envOr("TEST_MONGO_IMAGE", defaultMongoImage), 27017)
+	if err != nil {
+		return nil, "", err
+	}
+	uri := "mongodb://" + s.addr()
+	if err := waitForMongo(uri); err != nil {
+		s.stop()
+		return nil, "", err
+	}
+	return s, uri, nil
+}
+
+// startRedis returns a Redis server accepting connections. pool is only needed when TEST_REDIS_ADDR
+// is unset.
+func startRedis(pool *dockertest.Pool) (*server, error) {
+	if addr := os.Getenv("TEST_REDIS_ADDR"); addr != "" {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("TEST_REDIS_ADDR: %v", err)
+		}
+		s := &server{host: host}
+		if s.port, err = strconv.Atoi(port); err != nil {
+			return nil, fmt.Errorf("TEST_REDIS_ADDR: %v", err)
+		}
+		return s, waitForRedis(s.addr())
+	}
+	s, err := startContainer(pool, envOr("TEST_REDIS_IMAGE", defaultRedisImage), 6379)
+	if err != nil {
+		return nil, err
+	}
+	if err := waitForRedis(s.addr()); err != nil {
+		s.stop()
+		return nil, err
+	}
+	return s, nil
+}
+
+func waitForMongo(uri string) error {
+	return waitFor("MongoDB", func(ctx context.Context) error {
+		client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+		if err != nil {
+			return err
+		}
+		defer client.Disconnect(context.Background())
+		return client.Ping(ctx, nil)
+	})
+}
+
+func waitForRedis(addr string) error {
+	return waitFor("Redis", func(ctx context.Context) error {
+		client := redis.NewClient(&redis.Options{Addr: addr})
+		defer client.Close()
+		return client.Ping(ctx).Err()
+	})
+}
+
+// waitFor calls ping until it succeeds or the startup timeout passes.
+func waitFor(name string, ping func(ctx context.Context) error) error {
+	deadline := time.Now().Add(startupTimeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		err := ping(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s did not come up: %v", name, err)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
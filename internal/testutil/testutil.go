@@ -0,0 +1,330 @@
+// Package testutil runs the API end to end for integration tests. It starts throwaway MongoDB and
+// Redis containers through the docker API, boots the full App against them in sandbox mode, seeds
+// fixture properties and sends requests through the router, so tests cover search, caching and
+// cache invalidation as they happen in production.
+//
+// A test package shares one environment between its tests, resetting it in each test:
+//
+//	var env *testutil.Env
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(testutil.Run(m, &env))
+//	}
+//
+//	func TestSearchIsCached(t *testing.T) {
+//		env.Reset(t)
+//		token := env.Token(t, "user")
+//		rec := env.Do(t, http.MethodGet, "/api/properties/property-search?...", nil, token)
+//		...
+//	}
+//
+// Set TEST_MONGO_URI and TEST_REDIS_ADDR to use servers already running instead of containers;
+// Reset empties them, so never point them at servers holding data you want to keep.
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"homeinsight-properties/internal/app"
+	"homeinsight-properties/internal/auth"
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/repositories"
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/database"
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ory/dockertest/v3"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Env is a running App backed by test servers.
+type Env struct {
+	App    *app.App
+	Config *config.Config
+	// Properties reads and writes properties directly, bypassing the API and its cache
+	Properties repositories.PropertyRepository
+	// Root is the repository's root directory, where configs and fixtures are read from
+	Root string
+
+	mongo *server
+	redis *server
+	// dropDB is set when the database lives on a server the tests didn't start
+	dropDB bool
+}
+
+// Run starts an environment into *env, runs the tests and tears the environment down, returning
+// the exit code for os.Exit. Without docker or test servers the tests are skipped.
+func Run(m *testing.M, env **Env) int {
+	e, err := Start()
+	if err == ErrUnavailable {
+		fmt.Fprintf(os.Stderr, "testutil: skipping integration tests: %v\n", err)
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "testutil: failed to start test environment: %v\n", err)
+		return 1
+	}
+	defer e.Close()
+	*env = e
+	return m.Run()
+}
+
+// Start brings up MongoDB and Redis and boots the App against them, with the repository's config
+// file, a database of its own and sandbox mode on, so no external API is called. The App can only
+// be booted once per process; Close the environment when done.
+func Start() (*Env, error) {
+	var pool *dockertest.Pool
+	if os.Getenv("TEST_MONGO_URI") == "" || os.Getenv("TEST_REDIS_ADDR") == "" {
+		var err error
+		if pool, err = connectDocker(); err != nil {
+			return nil, ErrUnavailable
+		}
+	}
+	root, err := repoRoot()
+	if err != nil {
+		return nil, err
+	}
+	logger.InitLogger(os.Stderr, envOr("TEST_LOG_LEVEL", "ERROR"))
+	gin.SetMode(gin.TestMode)
+
+	e := &Env{Root: root, dropDB: os.Getenv("TEST_MONGO_URI") != ""}
+	mongoServer, mongoURI, err := startMongo(pool)
+	if err != nil {
+		return nil, err
+	}
+	e.mongo = mongoServer
+	if e.redis, err = startRedis(pool); err != nil {
+		e.mongo.stop()
+		return nil, err
+	}
+
+	if e.Config, err = e.loadConfig(mongoURI); err != nil {
+		e.mongo.stop()
+		e.redis.stop()
+		return nil, err
+	}
+	e.App = app.New(e.Config)
+	e.Properties = repositories.NewPropertyRepository(e.App.PIICipher)
+	return e, nil
+}
+
+// loadConfig reads the repository's config file the way the server does, pointed at the test
+// servers and a randomly named database.
+func (e *Env) loadConfig(mongoURI string) (*config.Config, error) {
+	path := filepath.Join(e.Root, "configs", "config.yaml")
+	env := map[string]string{
+		"CONFIG_PATH": path,
+		"MONGO_URI":   mongoURI,
+		"REDIS_HOST":  e.redis.host,
+		"REDIS_MODE":  "standalone",
+		"SANDBOX":     "true",
+		"ENV":         "test",
+	}
+	if os.Getenv("JWT_SECRET") == "" && os.Getenv("JWT_SIGNING_KEYS") == "" {
+		env["JWT_SECRET"] = randomHex(32)
+	}
+	for k, v := range env {
+		if err := os.Setenv(k, v); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Database.DBName = "homeinsight_test_" + randomHex(4)
+	cfg.Redis.Port = e.redis.port
+	cfg.Redis.DB = 0
+	cfg.Sandbox.FixturesDir = filepath.Join(e.Root, "data", "sandbox")
+	return cfg, nil
+}
+
+// Close shuts the App down and removes the containers, or drops the test database from a server
+// given by TEST_MONGO_URI.
+func (e *Env) Close() {
+	if e.dropDB && database.DB != nil {
+		if err := database.DB.Drop(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "testutil: failed to drop test database: %v\n", err)
+		}
+	}
+	e.App.Close()
+	e.mongo.stop()
+	e.redis.stop()
+}
+
+// Reset empties the database, except for the default organization, and the cache, so each test
+// starts from the same state.
+func (e *Env) Reset(t testing.TB) {
+	t.Helper()
+	ctx := context.Background()
+	names, err := database.DB.ListCollectionNames(ctx, bson.M{"name": bson.M{"$not": bson.M{"$regex": "^system\\."}}})
+	if err != nil {
+		t.Fatalf("list collections: %v", err)
+	}
+	for _, name := range names {
+		filter := bson.M{}
+		if name == "organizations" {
+			filter = bson.M{"slug": bson.M{"$ne": models.DefaultOrganizationSlug}}
+		}
+		if _, err := database.DB.Collection(name).DeleteMany(ctx, filter); err != nil {
+			t.Fatalf("empty collection %s: %v", name, err)
+		}
+	}
+	if err := cache.RedisClient.FlushDB(ctx).Err(); err != nil {
+		t.Fatalf("flush cache: %v", err)
+	}
+}
+
+// SeedProperties stores the named fixtures of data/sandbox/properties in the default organization,
+// or all of them when no name is given, and returns the stored properties in order.
+func (e *Env) SeedProperties(t testing.TB, names ...string) []*models.Property {
+	t.Helper()
+	dir := filepath.Join(e.Root, "data", "sandbox", "properties")
+	if len(names) == 0 {
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			t.Fatalf("list fixtures: %v", err)
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			names = append(names, filepath.Base(file))
+		}
+	}
+
+	transformer := transformers.NewPropertyTransformer()
+	ctx := tenant.WithOrgID(context.Background(), e.App.DefaultOrgID)
+	properties := make([]*models.Property, 0, len(names))
+	for _, name := range names {
+		if !strings.HasSuffix(name, ".json") {
+			name += ".json"
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read fixture: %v", err)
+		}
+		var response map[string]interface{}
+		if err := json.Unmarshal(data, &response); err != nil {
+			t.Fatalf("decode fixture %s: %v", name, err)
+		}
+		property, err := transformer.TransformAPIResponse(response)
+		if err != nil {
+			t.Fatalf("transform fixture %s: %v", name, err)
+		}
+		if _, err := e.Properties.Create(ctx, property); err != nil {
+			t.Fatalf("store fixture %s: %v", name, err)
+		}
+		properties = append(properties, property)
+	}
+	return properties
+}
+
+// Token returns an access token for a user with role in the default organization.
+func (e *Env) Token(t testing.TB, role string) string {
+	t.Helper()
+	details, err := auth.GenerateJWT("testutil-"+role, "Test "+role, role+"@example.com", "", role, e.App.DefaultOrgID, "", auth.Keys())
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	return details.Token
+}
+
+// Do sends a request through the App's router. body, unless nil, is sent as JSON, or as is when it
+// is a string or []byte; token, unless empty, as a bearer token.
+func (e *Env) Do(t testing.TB, method, path string, body interface{}, token string) *httptest.ResponseRecorder {
+	t.Helper()
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		reader = strings.NewReader(b)
+	case []byte:
+		reader = bytes.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("encode request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	e.App.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+// DecodeJSON decodes a response body into v, failing the test unless the status is want.
+func DecodeJSON(t testing.TB, rec *httptest.ResponseRecorder, want int, v interface{}) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, want, rec.Body.String())
+	}
+	if v == nil {
+		return
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode response: %v: %s", err, rec.Body.String())
+	}
+}
+
+// CacheKeys returns the cache keys matching a Redis glob pattern, to check what a request cached
+// or invalidated.
+func (e *Env) CacheKeys(t testing.TB, pattern string) []string {
+	t.Helper()
+	keys, err := cache.RedisClient.Keys(context.Background(), pattern).Result()
+	if err != nil {
+		t.Fatalf("list cache keys: %v", err)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// repoRoot finds the directory holding go.mod, from the working directory up; tests run in their
+// package's directory.
+func repoRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found above the working directory")
+		}
+		dir = parent
+	}
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
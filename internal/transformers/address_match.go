@@ -0,0 +1,137 @@
+package transformers
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Match confidence of a street address against a query, from an identical spelling down to a close
+// fuzzy match. A fuzzy match scales fuzzyMatchConfidence by the street name similarity.
+const (
+	exactMatchConfidence     = 1.0
+	canonicalMatchConfidence = 0.95
+	fuzzyMatchConfidence     = 0.9
+)
+
+// streetSuffixes maps USPS street suffix spellings to their standard abbreviation.
+var streetSuffixes = map[string]string{
+	"ALLEY": "ALY", "ALLEE": "ALY", "ALLY": "ALY",
+	"AVENUE": "AVE", "AV": "AVE", "AVEN": "AVE", "AVENU": "AVE", "AVN": "AVE", "AVNUE": "AVE",
+	"BOULEVARD": "BLVD", "BOUL": "BLVD", "BOULV": "BLVD",
+	"CIRCLE": "CIR", "CIRC": "CIR", "CIRCL": "CIR", "CRCL": "CIR", "CRCLE": "CIR",
+	"COURT": "CT", "CRT": "CT",
+	"COVE":     "CV",
+	"CROSSING": "XING", "CRSSNG": "XING",
+	"DRIVE": "DR", "DRIV": "DR", "DRV": "DR",
+	"EXPRESSWAY": "EXPY", "EXPRESS": "EXPY", "EXPW": "EXPY",
+	"FREEWAY": "FWY", "FREEWY": "FWY", "FRWAY": "FWY", "FRWY": "FWY",
+	"HIGHWAY": "HWY", "HIGHWY": "HWY", "HIWAY": "HWY", "HIWY": "HWY", "HWAY": "HWY",
+	"LANE": "LN",
+	"LOOP": "LOOP", "LOOPS": "LOOP",
+	"PARKWAY": "PKWY", "PARKWY": "PKWY", "PKWAY": "PKWY", "PKY": "PKWY",
+	"PLACE": "PL",
+	"PLAZA": "PLZ", "PLZA": "PLZ",
+	"POINT":  "PT",
+	"ROAD":   "RD",
+	"ROUTE":  "RTE",
+	"SQUARE": "SQ", "SQR": "SQ", "SQRE": "SQ", "SQU": "SQ",
+	"STREET": "ST", "STRT": "ST", "STR": "ST",
+	"TERRACE": "TER", "TERR": "TER",
+	"TRAIL": "TRL", "TRAILS": "TRL", "TRLS": "TRL",
+	"TURNPIKE": "TPKE", "TRNPK": "TPKE", "TURNPK": "TPKE",
+	"WAY": "WAY", "WY": "WAY",
+}
+
+var streetDirectionals = map[string]string{
+	"NORTH": "N", "SOUTH": "S", "EAST": "E", "WEST": "W",
+	"NORTHEAST": "NE", "NORTHWEST": "NW", "SOUTHEAST": "SE", "SOUTHWEST": "SW",
+}
+
+// unitPattern matches a trailing secondary unit such as "APT 4B", "STE. 200" or "#12".
+var unitPattern = regexp.MustCompile(`(?:\s+|^)(?:#|(?:APT|APARTMENT|UNIT|STE|SUITE|FL|FLOOR|RM|ROOM|BLDG|BUILDING|LOT|SPC|SPACE|TRLR|DEPT)\b\.?\s*#?)\s*[A-Z0-9-]+$`)
+
+// CanonicalizeStreet reduces a street address to a canonical spelling for comparison: upper case,
+// no punctuation or unit number, and standard suffix and directional abbreviations.
+func (t *addressTransformer) CanonicalizeStreet(street string) string {
+	street = t.NormalizeAddressComponent(street)
+	street = strings.NewReplacer(".", "", ",", " ").Replace(street)
+	street = unitPattern.ReplaceAllString(street, "")
+
+	words := strings.Fields(street)
+	for i, word := range words {
+		if i == 0 {
+			continue // house number
+		}
+		if abbr, ok := streetSuffixes[word]; ok {
+			words[i] = abbr
+		} else if abbr, ok := streetDirectionals[word]; ok {
+			words[i] = abbr
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// SplitStreet separates the house number from the rest of a canonical street address. houseNumber
+// is empty when the address doesn't start with one.
+func (t *addressTransformer) SplitStreet(street string) (houseNumber, streetName string) {
+	first, rest, _ := strings.Cut(street, " ")
+	if first == "" || !unicode.IsDigit(rune(first[0])) {
+		return "", street
+	}
+	return first, rest
+}
+
+// MatchConfidence rates how likely candidate is the street address the query meant, from 0 (no
+// match) to 1 (the same spelling). Fuzzy matches need the same house number and rate by the edit
+// distance between the street names.
+func (t *addressTransformer) MatchConfidence(query, candidate string) float64 {
+	if t.NormalizeAddressComponent(query) == t.NormalizeAddressComponent(candidate) {
+		return exactMatchConfidence
+	}
+	canonicalQuery, canonicalCandidate := t.CanonicalizeStreet(query), t.CanonicalizeStreet(candidate)
+	if canonicalQuery == canonicalCandidate {
+		return canonicalMatchConfidence
+	}
+	queryNumber, queryName := t.SplitStreet(canonicalQuery)
+	candidateNumber, candidateName := t.SplitStreet(canonicalCandidate)
+	if queryNumber == "" || queryNumber != candidateNumber {
+		return 0
+	}
+	return fuzzyMatchConfidence * similarity(queryName, candidateName)
+}
+
+// similarity is 1 minus the Levenshtein distance relative to the longer string.
+func similarity(a, b string) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein counts the single-byte insertions, deletions and substitutions turning a into b. Swapped
+// adjacent letters, a common typo, count as one edit (optimal string alignment).
+func levenshtein(a, b string) int {
+	prevPrev := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prevPrev[j-2]+1)
+			}
+		}
+		prevPrev, prev, curr = prev, curr, prevPrev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,46 @@
+package transformers
+
+import "homeinsight-properties/internal/models"
+
+// dataQualityChecks are the completeness checks behind a property's data quality score, with the
+// points each costs when it fails. The weights add up to 100.
+var dataQualityChecks = []struct {
+	issue  string
+	weight int
+	failed func(property *models.Property) bool
+}{
+	{models.DataQualityMissingCoordinates, 25, func(p *models.Property) bool {
+		return p.Location.Coordinates.Parcel.Lat == 0 && p.Location.Coordinates.Parcel.Lng == 0
+	}},
+	{models.DataQualityMissingBuildingArea, 20, func(p *models.Property) bool {
+		return p.Building.Summary.LivingAreaSquareFeet == 0 && p.Building.Summary.TotalAreaSquareFeet == 0 &&
+			p.Building.Details.Interior.Area.UniversalBuildingAreaSquareFeet == 0
+	}},
+	{models.DataQualityMissingTaxData, 20, func(p *models.Property) bool {
+		return p.TaxAssessment.Year == 0 && len(p.TaxAssessments) == 0
+	}},
+	{models.DataQualityMissingYearBuilt, 10, func(p *models.Property) bool {
+		return p.Building.Details.Construction.YearBuilt == 0
+	}},
+	{models.DataQualityMissingLotSize, 10, func(p *models.Property) bool {
+		return p.Lot.AreaSquareFeet == 0 && p.Lot.AreaAcres == 0
+	}},
+	{models.DataQualityMissingOwner, 10, func(p *models.Property) bool {
+		return len(p.Ownership.CurrentOwners) == 0
+	}},
+	{models.DataQualityMissingLandUse, 5, func(p *models.Property) bool {
+		return p.LandUseAndZoning.PropertyTypeCode == "" && p.LandUseAndZoning.LandUseCode == ""
+	}},
+}
+
+// ScoreDataQuality rates the completeness of a property's data and stores the result on it.
+func ScoreDataQuality(property *models.Property) {
+	quality := &models.DataQuality{Score: 100}
+	for _, check := range dataQualityChecks {
+		if check.failed(property) {
+			quality.Score -= check.weight
+			quality.Issues = append(quality.Issues, check.issue)
+		}
+	}
+	property.DataQuality = quality
+}
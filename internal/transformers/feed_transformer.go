@@ -0,0 +1,263 @@
+package transformers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+)
+
+// parcelPropertyID identifies a property first seen in a feed by its parcel number, which both MLS and
+// assessor feeds carry, so the two feeds update the same property. Parcel numbers are only unique
+// within a county, hence the state; a property already stored under a provider ID is matched by address.
+func parcelPropertyID(state, parcel string) string {
+	parcel = strings.ToUpper(strings.Join(strings.Fields(parcel), ""))
+	return "APN-" + strings.ToUpper(state) + "-" + parcel
+}
+
+// feedRecord reads the fields of one feed record. Each lookup takes the field's alternative names,
+// as providers differ in how they spell them, and the first one present wins. Values that are present
+// but unreadable are collected, so a record reports all of its bad fields at once.
+type feedRecord struct {
+	fields map[string]string
+	errs   []string
+}
+
+func (r *feedRecord) str(names ...string) string {
+	for _, name := range names {
+		if value, ok := r.fields[name]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func (r *feedRecord) number(names ...string) float64 {
+	for _, name := range names {
+		raw, ok := r.fields[name]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.NewReplacer("$", "", ",", "").Replace(raw), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			r.errs = append(r.errs, fmt.Sprintf("%s: %q is not a number", name, raw))
+			return 0
+		}
+		return value
+	}
+	return 0
+}
+
+func (r *feedRecord) integer(names ...string) int {
+	return int(math.Round(r.number(names...)))
+}
+
+// date reads a date in one of the layouts feeds use and returns it as YYYY-MM-DD, the form stored on
+// properties.
+func (r *feedRecord) date(names ...string) string {
+	for _, name := range names {
+		raw, ok := r.fields[name]
+		if !ok {
+			continue
+		}
+		for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02T15:04:05", "01/02/2006", "1/2/2006", "20060102"} {
+			if t, err := time.Parse(layout, raw); err == nil {
+				return t.Format("2006-01-02")
+			}
+		}
+		r.errs = append(r.errs, fmt.Sprintf("%s: %q is not a date", name, raw))
+		return ""
+	}
+	return ""
+}
+
+func (r *feedRecord) require(value, name string) {
+	if value == "" {
+		r.errs = append(r.errs, name+" is required")
+	}
+}
+
+func (r *feedRecord) err() error {
+	if len(r.errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid feed record: %s", strings.Join(r.errs, "; "))
+}
+
+type mlsFeedTransformer struct{}
+
+// NewMLSFeedTransformer maps MLS exports using RESO Data Dictionary field names, e.g. ParcelNumber,
+// StreetNumber, BedroomsTotal and ClosePrice.
+func NewMLSFeedTransformer() FeedTransformer {
+	return &mlsFeedTransformer{}
+}
+
+func (t *mlsFeedTransformer) Provider() string {
+	return "mls"
+}
+
+func (t *mlsFeedTransformer) TransformRecord(fields map[string]string) (*models.Property, error) {
+	r := &feedRecord{fields: fields}
+	parcel := r.str("ParcelNumber")
+	state := r.str("StateOrProvince")
+	r.require(parcel, "ParcelNumber")
+	r.require(state, "StateOrProvince")
+
+	parsed := models.StreetAddressParsed{
+		HouseNumber:      r.str("StreetNumber"),
+		StreetName:       strings.TrimSpace(strings.Join([]string{r.str("StreetDirPrefix"), r.str("StreetName")}, " ")),
+		StreetNameSuffix: r.str("StreetSuffix"),
+	}
+	street := r.str("UnparsedAddress")
+	if street == "" {
+		street = strings.Join(strings.Fields(strings.Join([]string{parsed.HouseNumber, parsed.StreetName, parsed.StreetNameSuffix, r.str("StreetDirSuffix")}, " ")), " ")
+		if unit := r.str("UnitNumber"); unit != "" {
+			street += " UNIT " + unit
+		}
+	}
+
+	property := &models.Property{
+		PropertyID: parcelPropertyID(state, parcel),
+		Address: models.Address{
+			StreetAddress:       street,
+			StreetAddressParsed: parsed,
+			City:                r.str("City"),
+			State:               state,
+			ZipCode:             r.str("PostalCode"),
+			ZipPlus4:            r.str("PostalCodePlus4"),
+			County:              r.str("CountyOrParish"),
+		},
+		Location: models.Location{
+			Coordinates: models.Coordinates{
+				Parcel: models.CoordinatesPoint{Lat: r.number("Latitude"), Lng: r.number("Longitude")},
+			},
+			Legal: models.Legal{SubdivisionName: r.str("SubdivisionName")},
+		},
+		Lot: models.Lot{
+			AreaAcres:      r.number("LotSizeAcres"),
+			AreaSquareFeet: r.integer("LotSizeSquareFeet"),
+		},
+		LandUseAndZoning: models.LandUseAndZoning{
+			PropertyTypeCode: r.str("PropertySubType", "PropertyType"),
+		},
+		Building: models.Building{
+			Summary: models.BuildingSummary{
+				BedroomsCount:        r.integer("BedroomsTotal"),
+				BathroomsCount:       r.integer("BathroomsTotalInteger"),
+				FullBathroomsCount:   r.integer("BathroomsFull"),
+				HalfBathroomsCount:   r.integer("BathroomsHalf"),
+				LivingAreaSquareFeet: r.integer("LivingArea"),
+				TotalAreaSquareFeet:  r.integer("BuildingAreaTotal"),
+			},
+			Details: models.BuildingDetails{
+				VerticalProfile: models.VerticalProfile{StoriesCount: r.integer("StoriesTotal", "Stories")},
+				Construction:    models.Construction{YearBuilt: r.integer("YearBuilt")},
+				Exterior: models.Exterior{
+					Parking: models.Parking{ParkingSpacesCount: r.integer("ParkingTotal", "GarageSpaces")},
+				},
+			},
+		},
+		LastMarketSale: models.LastMarketSale{
+			Date:   r.date("CloseDate"),
+			Amount: r.integer("ClosePrice"),
+		},
+		UpdatedAt: time.Now().UTC(),
+	}
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	return property, nil
+}
+
+type assessorFeedTransformer struct{}
+
+// NewAssessorFeedTransformer maps county assessor rolls: parcel situs address, owner, assessed values
+// and the tax bill of a year, e.g. APN, SitusAddress, OwnerName and AssessedTotalValue.
+func NewAssessorFeedTransformer() FeedTransformer {
+	return &assessorFeedTransformer{}
+}
+
+func (t *assessorFeedTransformer) Provider() string {
+	return "assessor"
+}
+
+func (t *assessorFeedTransformer) TransformRecord(fields map[string]string) (*models.Property, error) {
+	r := &feedRecord{fields: fields}
+	parcel := r.str("APN", "ParcelNumber")
+	state := r.str("SitusState", "State")
+	r.require(parcel, "APN")
+	r.require(state, "SitusState")
+
+	property := &models.Property{
+		PropertyID: parcelPropertyID(state, parcel),
+		Address: models.Address{
+			StreetAddress: r.str("SitusAddress"),
+			City:          r.str("SitusCity"),
+			State:         state,
+			ZipCode:       r.str("SitusZip", "SitusZipCode"),
+			County:        r.str("County"),
+		},
+		Lot: models.Lot{
+			AreaAcres:      r.number("LotAcres"),
+			AreaSquareFeet: r.integer("LotSqFt", "LotSquareFeet"),
+		},
+		LandUseAndZoning: models.LandUseAndZoning{
+			LandUseCode:             r.str("LandUseCode"),
+			StateLandUseCode:        r.str("StateLandUseCode"),
+			StateLandUseDescription: r.str("LandUseDescription"),
+		},
+		Building: models.Building{
+			Summary: models.BuildingSummary{
+				BedroomsCount:        r.integer("Bedrooms"),
+				BathroomsCount:       r.integer("Bathrooms"),
+				LivingAreaSquareFeet: r.integer("BuildingSqFt", "LivingSquareFeet"),
+			},
+			Details: models.BuildingDetails{
+				Construction: models.Construction{YearBuilt: r.integer("YearBuilt")},
+			},
+		},
+		LastMarketSale: models.LastMarketSale{
+			Date:           r.date("LastSaleDate"),
+			RecordingDate:  r.date("LastSaleRecordingDate"),
+			Amount:         r.integer("LastSalePrice"),
+			DocumentNumber: r.str("LastSaleDocumentNumber"),
+		},
+		UpdatedAt: time.Now().UTC(),
+	}
+
+	if owner := r.str("OwnerName"); owner != "" {
+		property.Ownership.CurrentOwners = []models.Owner{{SequenceNumber: 1, FullName: owner}}
+		if second := r.str("OwnerName2"); second != "" {
+			property.Ownership.CurrentOwners = append(property.Ownership.CurrentOwners, models.Owner{SequenceNumber: 2, FullName: second})
+		}
+	}
+	property.Ownership.MailingAddress = models.MailingAddress{
+		StreetAddress: r.str("MailingAddress"),
+		City:          r.str("MailingCity"),
+		State:         r.str("MailingState"),
+		ZipCode:       r.str("MailingZip"),
+	}
+
+	if year := r.integer("TaxYear"); year > 0 {
+		property.TaxAssessment = models.TaxAssessment{
+			Year:           year,
+			TotalTaxAmount: r.integer("TaxAmount"),
+			AssessedValue: models.AssessedValue{
+				TotalValue:       r.integer("AssessedTotalValue"),
+				LandValue:        r.integer("AssessedLandValue"),
+				ImprovementValue: r.integer("AssessedImprovementValue"),
+			},
+			SchoolDistrict: models.SchoolDistrict{Name: r.str("SchoolDistrict")},
+		}
+		if total := property.TaxAssessment.AssessedValue.TotalValue; total > 0 {
+			property.TaxAssessment.AssessedValue.ImprovementValuePercentage = property.TaxAssessment.AssessedValue.ImprovementValue * 100 / total
+		}
+	}
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	return property, nil
+}
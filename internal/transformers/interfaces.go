@@ -11,4 +11,19 @@ type PropertyTransformer interface {
 type AddressTransformer interface {
 	NormalizeAddressComponent(input string) string
 	ParseAddress(search string) (street, city, state, zip string)
+	CanonicalizeStreet(street string) string
+	SplitStreet(street string) (houseNumber, streetName string)
+	MatchConfidence(query, candidate string) float64
+}
+
+type OwnerTransformer interface {
+	NormalizeOwnerName(name string) string
+	EntityID(normalizedName string) string
+}
+
+// FeedTransformer maps a record of a provider's bulk data feed to a property. Provider is the name the
+// feed is uploaded under.
+type FeedTransformer interface {
+	Provider() string
+	TransformRecord(fields map[string]string) (*models.Property, error)
 }
@@ -0,0 +1,60 @@
+package transformers
+
+import (
+	"regexp"
+	"strings"
+)
+
+type ownerTransformer struct{}
+
+func NewOwnerTransformer() OwnerTransformer {
+	return &ownerTransformer{}
+}
+
+var (
+	ownerPunctuation = regexp.MustCompile(`[^A-Z0-9&\s]`)
+	ownerWhitespace  = regexp.MustCompile(`\s+`)
+)
+
+// corporate suffix variants collapsed to a single canonical form so that
+// "ACME HOLDINGS, L.L.C." and "Acme Holdings LLC" resolve to the same entity.
+var corporateSuffixes = map[string]string{
+	"LLC":          "LLC",
+	"L L C":        "LLC",
+	"INC":          "INC",
+	"INCORPORATED": "INC",
+	"CORP":         "CORP",
+	"CORPORATION":  "CORP",
+	"CO":           "CO",
+	"COMPANY":      "CO",
+	"LTD":          "LTD",
+	"LIMITED":      "LTD",
+	"LP":           "LP",
+	"L P":          "LP",
+	"LLP":          "LLP",
+	"TR":           "TRUST",
+	"TRUST":        "TRUST",
+}
+
+func (t *ownerTransformer) NormalizeOwnerName(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	name = ownerPunctuation.ReplaceAllString(name, " ")
+	name = ownerWhitespace.ReplaceAllString(strings.TrimSpace(name), " ")
+	if name == "" {
+		return ""
+	}
+
+	for variant, canonical := range corporateSuffixes {
+		if strings.HasSuffix(name, " "+variant) {
+			name = strings.TrimSuffix(name, " "+variant) + " " + canonical
+			break
+		}
+	}
+	return name
+}
+
+func (t *ownerTransformer) EntityID(normalizedName string) string {
+	id := strings.ToLower(normalizedName)
+	id = strings.ReplaceAll(id, "&", "and")
+	return strings.Join(strings.Fields(id), "-")
+}
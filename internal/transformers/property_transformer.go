@@ -2,6 +2,7 @@ package transformers
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -249,74 +250,105 @@ func (t *propertyTransformer) TransformAPIResponse(apiResponse map[string]interf
 		}
 	}
 
-	if taxAssessment, ok := apiResponse["taxAssessment"].(map[string]interface{})["items"].([]interface{}); ok && len(taxAssessment) > 0 {
-		if item, ok := taxAssessment[0].(map[string]interface{}); ok {
-			property.TaxAssessment = models.TaxAssessment{
-				Year:            getInt(item, "taxAmount.billedYear"),
-				TotalTaxAmount:  getInt(item, "taxAmount.totalTaxAmount"),
-				CountyTaxAmount: getInt(item, "taxAmount.countyTaxAmount"),
-				AssessedValue: models.AssessedValue{
-					TotalValue:                 getInt(item, "assessedValue.calculatedTotalValue"),
-					LandValue:                  getInt(item, "assessedValue.calculatedLandValue"),
-					ImprovementValue:           getInt(item, "assessedValue.calculatedImprovementValue"),
-					ImprovementValuePercentage: getInt(item, "assessedValue.calculatedImprovementValuePercentage"),
-				},
-				TaxRoll: models.TaxRoll{
-					LastAssessorUpdateDate: getString(item, "taxrollUpdate.lastAssessorUpdateDate"),
-					CertificationDate:      getString(item, "taxrollUpdate.taxrollCertificationDate"),
-				},
-				SchoolDistrict: models.SchoolDistrict{
-					Code: getString(item, "schoolDistricts.school.code"),
-					Name: getString(item, "schoolDistricts.school.name"),
-				},
+	if taxAssessments, ok := apiResponse["taxAssessment"].(map[string]interface{})["items"].([]interface{}); ok {
+		for _, raw := range taxAssessments {
+			if item, ok := raw.(map[string]interface{}); ok {
+				property.TaxAssessments = append(property.TaxAssessments, transformTaxAssessment(item))
 			}
 		}
+		property.SyncTaxAssessments()
 	}
 
 	if lastMarketSale, ok := apiResponse["lastMarketSale"].(map[string]interface{})["items"].([]interface{}); ok && len(lastMarketSale) > 0 {
 		if item, ok := lastMarketSale[0].(map[string]interface{}); ok {
-			property.LastMarketSale = models.LastMarketSale{
-				Date:                   getString(item, "transactionDetails.saleDateDerived"),
-				RecordingDate:          getString(item, "transactionDetails.saleRecordingDateDerived"),
-				Amount:                 getInt(item, "transactionDetails.saleAmount"),
-				DocumentTypeCode:       getString(item, "transactionDetails.saleDocumentTypeCode"),
-				DocumentNumber:         getString(item, "transactionDetails.saleDocumentNumber"),
-				BookNumber:             getString(item, "transactionDetails.saleBookNumber"),
-				PageNumber:             getString(item, "transactionDetails.salePageNumber"),
-				MultiOrSplitParcelCode: getString(item, "transactionDetails.multiOrSplitParcelCode"),
-				IsMortgagePurchase:     getBool(item, "transactionDetails.isMortgagePurchase"),
-				IsResale:               getBool(item, "transactionDetails.isResale"),
-				TitleCompany: models.TitleCompany{
-					Name: getString(item, "titleCompany.name"),
-					Code: getString(item, "titleCompany.code"),
-				},
-			}
-			if buyerNames, ok := item["buyerDetails"].(map[string]interface{})["buyerNames"].([]interface{}); ok {
-				for _, buyer := range buyerNames {
-					if buyerMap, ok := buyer.(map[string]interface{}); ok {
-						property.LastMarketSale.Buyers = append(property.LastMarketSale.Buyers, models.Buyer{
-							FullName:                  getString(buyerMap, "fullName"),
-							LastName:                  getString(buyerMap, "lastName"),
-							FirstNameAndMiddleInitial: getString(buyerMap, "firstNameAndMiddleInitial"),
-						})
-					}
-				}
+			property.LastMarketSale = transformMarketSale(item)
+		}
+	}
+
+	// The full sale history is stored in its own collection; market sales and owner transfers often
+	// list the same deed, so repeats are dropped
+	for _, section := range []string{"lastMarketSale", "mostRecentOwnerTransfer"} {
+		items, _ := apiResponse[section].(map[string]interface{})["items"].([]interface{})
+		for _, raw := range items {
+			item, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
 			}
-			if sellerNames, ok := item["sellerDetails"].(map[string]interface{})["sellerNames"].([]interface{}); ok {
-				for _, seller := range sellerNames {
-					if sellerMap, ok := seller.(map[string]interface{}); ok {
-						property.LastMarketSale.Sellers = append(property.LastMarketSale.Sellers, models.Seller{
-							FullName: getString(sellerMap, "fullName"),
-						})
-					}
-				}
+			transaction := models.Transaction{LastMarketSale: transformMarketSale(item)}
+			if !slices.ContainsFunc(property.Transactions, transaction.SameDeed) {
+				property.Transactions = append(property.Transactions, transaction)
 			}
 		}
 	}
 
+	ScoreDataQuality(property)
 	return property, nil
 }
 
+// transformTaxAssessment maps one year of a CoreLogic tax assessment.
+func transformTaxAssessment(item map[string]interface{}) models.TaxAssessment {
+	return models.TaxAssessment{
+		Year:            getInt(item, "taxAmount.billedYear"),
+		TotalTaxAmount:  getInt(item, "taxAmount.totalTaxAmount"),
+		CountyTaxAmount: getInt(item, "taxAmount.countyTaxAmount"),
+		AssessedValue: models.AssessedValue{
+			TotalValue:                 getInt(item, "assessedValue.calculatedTotalValue"),
+			LandValue:                  getInt(item, "assessedValue.calculatedLandValue"),
+			ImprovementValue:           getInt(item, "assessedValue.calculatedImprovementValue"),
+			ImprovementValuePercentage: getInt(item, "assessedValue.calculatedImprovementValuePercentage"),
+		},
+		TaxRoll: models.TaxRoll{
+			LastAssessorUpdateDate: getString(item, "taxrollUpdate.lastAssessorUpdateDate"),
+			CertificationDate:      getString(item, "taxrollUpdate.taxrollCertificationDate"),
+		},
+		SchoolDistrict: models.SchoolDistrict{
+			Code: getString(item, "schoolDistricts.school.code"),
+			Name: getString(item, "schoolDistricts.school.name"),
+		},
+	}
+}
+
+// transformMarketSale maps one CoreLogic sale or owner transfer.
+func transformMarketSale(item map[string]interface{}) models.LastMarketSale {
+	sale := models.LastMarketSale{
+		Date:                   getString(item, "transactionDetails.saleDateDerived"),
+		RecordingDate:          getString(item, "transactionDetails.saleRecordingDateDerived"),
+		Amount:                 getInt(item, "transactionDetails.saleAmount"),
+		DocumentTypeCode:       getString(item, "transactionDetails.saleDocumentTypeCode"),
+		DocumentNumber:         getString(item, "transactionDetails.saleDocumentNumber"),
+		BookNumber:             getString(item, "transactionDetails.saleBookNumber"),
+		PageNumber:             getString(item, "transactionDetails.salePageNumber"),
+		MultiOrSplitParcelCode: getString(item, "transactionDetails.multiOrSplitParcelCode"),
+		IsMortgagePurchase:     getBool(item, "transactionDetails.isMortgagePurchase"),
+		IsResale:               getBool(item, "transactionDetails.isResale"),
+		TitleCompany: models.TitleCompany{
+			Name: getString(item, "titleCompany.name"),
+			Code: getString(item, "titleCompany.code"),
+		},
+	}
+	if buyerNames, ok := item["buyerDetails"].(map[string]interface{})["buyerNames"].([]interface{}); ok {
+		for _, buyer := range buyerNames {
+			if buyerMap, ok := buyer.(map[string]interface{}); ok {
+				sale.Buyers = append(sale.Buyers, models.Buyer{
+					FullName:                  getString(buyerMap, "fullName"),
+					LastName:                  getString(buyerMap, "lastName"),
+					FirstNameAndMiddleInitial: getString(buyerMap, "firstNameAndMiddleInitial"),
+				})
+			}
+		}
+	}
+	if sellerNames, ok := item["sellerDetails"].(map[string]interface{})["sellerNames"].([]interface{}); ok {
+		for _, seller := range sellerNames {
+			if sellerMap, ok := seller.(map[string]interface{}); ok {
+				sale.Sellers = append(sale.Sellers, models.Seller{
+					FullName: getString(sellerMap, "fullName"),
+				})
+			}
+		}
+	}
+	return sale
+}
+
 func getString(m map[string]interface{}, key string) string {
 	keys := strings.Split(key, ".")
 	current := m
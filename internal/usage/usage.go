@@ -0,0 +1,139 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"homeinsight-properties/internal/tenant"
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Metric is a usage counter kept per organization and API key and UTC day.
+type Metric string
+
+const (
+	Requests       Metric = "requests"
+	CoreLogicCalls Metric = "corelogic_calls"
+)
+
+// Metrics lists every usage counter.
+var Metrics = []Metric{Requests, CoreLogicCalls}
+
+// Subject types usage is counted for. API keys are identified by the name of their embed partner.
+const (
+	SubjectOrganization = "organization"
+	SubjectAPIKey       = "api_key"
+)
+
+// DayFormat is how UTC days are written in counters and rollups.
+const DayFormat = "2006-01-02"
+
+var (
+	mu            sync.RWMutex
+	defaultQuota  config.UsageQuota
+	orgQuotas     = map[string]config.UsageQuota{}
+	partnerQuotas = map[string]config.UsageQuota{}
+)
+
+// Configure sets the daily quotas: per organization, falling back to the default, and per embed partner.
+func Configure(cfg *config.Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	defaultQuota = cfg.Usage.Default
+	orgQuotas = make(map[string]config.UsageQuota, len(cfg.Usage.Organizations))
+	for orgID, quota := range cfg.Usage.Organizations {
+		orgQuotas[orgID] = quota
+	}
+	partnerQuotas = make(map[string]config.UsageQuota, len(cfg.Embed.Partners))
+	for _, partner := range cfg.Embed.Partners {
+		partnerQuotas[partner.Name] = partner.Quota
+	}
+}
+
+// Subject returns the counter field of a subject.
+func Subject(subjectType, id string) string {
+	return subjectType + ":" + id
+}
+
+// ParseSubject splits a counter field into its subject type and ID.
+func ParseSubject(field string) (subjectType, id string, ok bool) {
+	return strings.Cut(field, ":")
+}
+
+// Day returns the UTC day of t as written in counters.
+func Day(t time.Time) string {
+	return t.UTC().Format(DayFormat)
+}
+
+// ResetsAt returns when the counters of the UTC day of t start over.
+func ResetsAt(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
+
+type subject struct {
+	field string
+	limit int64
+}
+
+// subjects returns who ctx is counted against: the organization it is scoped to and, for embeds, the
+// partner's API key.
+func subjects(ctx context.Context, metric Metric) []subject {
+	mu.RLock()
+	defer mu.RUnlock()
+	var found []subject
+	if orgID := tenant.OrgID(ctx); orgID != "" {
+		quota, ok := orgQuotas[orgID]
+		if !ok {
+			quota = defaultQuota
+		}
+		found = append(found, subject{field: Subject(SubjectOrganization, orgID), limit: limit(quota, metric)})
+	}
+	if ginCtx, _ := ctx.(*gin.Context); ginCtx != nil {
+		if partner := ginCtx.GetString("embed_partner"); partner != "" {
+			found = append(found, subject{field: Subject(SubjectAPIKey, partner), limit: limit(partnerQuotas[partner], metric)})
+		}
+	}
+	return found
+}
+
+func limit(quota config.UsageQuota, metric Metric) int64 {
+	if metric == CoreLogicCalls {
+		return quota.DailyCoreLogicCalls
+	}
+	return quota.DailyRequests
+}
+
+// Take counts one unit of metric against the organization and API key of ctx, failing once either
+// has used up today's quota. Refused units are not counted. If Redis is unavailable the unit is
+// allowed, so a cache outage doesn't also take down the API.
+func Take(ctx context.Context, metric Metric) error {
+	day := Day(time.Now())
+	var counted []string
+	for _, s := range subjects(ctx, metric) {
+		used, err := cache.IncrementUsage(ctx, string(metric), day, s.field, 1)
+		if err != nil {
+			logger.GlobalLogger.Warnf("Usage not tracked: subject=%s, metric=%s, error=%v", s.field, metric, err)
+			continue
+		}
+		counted = append(counted, s.field)
+		if s.limit > 0 && used > s.limit {
+			for _, field := range counted {
+				if _, err := cache.IncrementUsage(ctx, string(metric), day, field, -1); err != nil {
+					logger.GlobalLogger.Warnf("Failed to release refused usage: subject=%s, metric=%s, error=%v", field, metric, err)
+				}
+			}
+			metrics.UsageQuotaRejectionsTotal.WithLabelValues(string(metric)).Inc()
+			return fmt.Errorf("usage quota exceeded: subject=%s, metric=%s, limit=%d", s.field, metric, s.limit)
+		}
+	}
+	return nil
+}
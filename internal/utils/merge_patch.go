@@ -0,0 +1,76 @@
+package utils
+
+import "sort"
+
+// MergePatch applies an RFC 7386 JSON merge patch to a decoded JSON document: objects merge key by
+// key, null removes a key and any other value replaces the target outright.
+func MergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{}, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = MergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// MergePatchPaths lists the dotted paths a merge patch replaces or removes, in sorted order.
+// Nested objects are walked; every other value, including arrays and null, ends a path.
+func MergePatchPaths(patch map[string]interface{}) []string {
+	var paths []string
+	var walk func(prefix string, object map[string]interface{})
+	walk = func(prefix string, object map[string]interface{}) {
+		for key, value := range object {
+			path := prefix + key
+			if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+				walk(path+".", nested)
+				continue
+			}
+			paths = append(paths, path)
+		}
+	}
+	walk("", patch)
+	sort.Strings(paths)
+	return paths
+}
+
+// CompactPatch removes the nulls, zero values and empty objects and arrays from a decoded JSON object,
+// walking nested objects, so that as a merge patch it only sets the fields that carry a value.
+func CompactPatch(object map[string]interface{}) map[string]interface{} {
+	for key, value := range object {
+		switch v := value.(type) {
+		case nil:
+			delete(object, key)
+		case string:
+			if v == "" {
+				delete(object, key)
+			}
+		case float64:
+			if v == 0 {
+				delete(object, key)
+			}
+		case bool:
+			if !v {
+				delete(object, key)
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				delete(object, key)
+			}
+		case map[string]interface{}:
+			if len(CompactPatch(v)) == 0 {
+				delete(object, key)
+			}
+		}
+	}
+	return object
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"net/url"
 )
@@ -20,3 +22,44 @@ func BuildPaginationURL(baseURL string, offset, limit int, params url.Values) st
 	u.RawQuery = q.Encode()
 	return u.String()
 }
+
+// BuildCursorURL builds the URL for the next page in cursor pagination mode.
+func BuildCursorURL(baseURL, cursor string, limit int, params url.Values) string {
+	u, _ := url.Parse(baseURL)
+	q := url.Values{}
+	q.Set("cursor", cursor)
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	for key, values := range params {
+		if key != "cursor" && key != "limit" && key != "offset" {
+			for _, value := range values {
+				q.Add(key, value)
+			}
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+type pageCursor struct {
+	SortKey string `json:"k"`
+	ID      string `json:"id"`
+}
+
+// EncodeCursor packs the sort key and tiebreaker ID of the last item on a page into an opaque token.
+func EncodeCursor(sortKey, id string) string {
+	data, _ := json.Marshal(pageCursor{SortKey: sortKey, ID: id})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reverses EncodeCursor.
+func DecodeCursor(token string) (sortKey, id string, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid cursor: %v", err)
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return "", "", fmt.Errorf("invalid cursor: %v", err)
+	}
+	return cursor.SortKey, cursor.ID, nil
+}
@@ -8,10 +8,16 @@ type PropertyValidator interface {
 	ValidateCreate(property *models.Property) error
 	ValidateUpdate(property *models.Property) error
 	ValidateSearch(req *models.SearchRequest) error
+	ValidateFilter(filter *models.PropertyFilter) error
 }
 
 
 type UserValidator interface {
 	ValidateRegister(user *models.User) error
 	ValidateLogin(email, password string) error
+	ValidatePassword(password string) error
+}
+
+type ListingValidator interface {
+	ValidateListing(listing *models.Listing) error
 }
@@ -0,0 +1,46 @@
+package validators
+
+import (
+	"fmt"
+
+	"homeinsight-properties/internal/models"
+)
+
+// maxListingDescription caps listing descriptions, which MLS feeds commonly limit to a few thousand characters.
+const maxListingDescription = 5000
+
+type listingValidator struct{}
+
+func NewListingValidator() ListingValidator {
+	return &listingValidator{}
+}
+
+func (v *listingValidator) ValidateListing(listing *models.Listing) error {
+	switch listing.Status {
+	case models.ListingStatusActive, models.ListingStatusPending, models.ListingStatusSold:
+	default:
+		return fmt.Errorf("invalid listing: status must be one of active, pending, sold")
+	}
+	if listing.ListPrice <= 0 {
+		return fmt.Errorf("invalid listing: list price must be positive")
+	}
+	if listing.SoldPrice < 0 {
+		return fmt.Errorf("invalid listing: sold price must not be negative")
+	}
+	if listing.SoldPrice > 0 && listing.Status != models.ListingStatusSold {
+		return fmt.Errorf("invalid listing: sold price is only allowed on sold listings")
+	}
+	if len(listing.Description) > maxListingDescription {
+		return fmt.Errorf("invalid listing: description exceeds %d characters", maxListingDescription)
+	}
+	if listing.Agent.Name == "" {
+		return fmt.Errorf("invalid listing: agent name is required")
+	}
+	if listing.Agent.Email != "" && !isValidEmail(listing.Agent.Email) {
+		return fmt.Errorf("invalid listing: invalid agent email format")
+	}
+	if listing.Agent.Phone != "" && !isValidPhone(listing.Agent.Phone) {
+		return fmt.Errorf("invalid listing: invalid agent phone format")
+	}
+	return nil
+}
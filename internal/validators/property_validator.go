@@ -2,28 +2,53 @@ package validators
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 
+	"homeinsight-properties/internal/errors"
 	"homeinsight-properties/internal/models"
+
+	"github.com/go-playground/validator/v10"
 )
 
-type propertyValidator struct{}
+// propertyValidator enforces the validate tags on models.Property. Failures are returned as a 400
+// AppError listing every offending field.
+type propertyValidator struct {
+	validate *validator.Validate
+}
 
 func NewPropertyValidator() PropertyValidator {
-	return &propertyValidator{}
+	return &propertyValidator{validate: newStructValidator()}
 }
 
 func (v *propertyValidator) ValidateCreate(property *models.Property) error {
-	if property.PropertyID == "" || property.Address.StreetAddress == "" {
-		return fmt.Errorf("property ID and street address are required")
-	}
-	return nil
+	return v.validateProperty(property)
 }
 
 func (v *propertyValidator) ValidateUpdate(property *models.Property) error {
-	if property.PropertyID == "" || property.Address.StreetAddress == "" {
-		return fmt.Errorf("property ID and street address are required")
+	return v.validateProperty(property)
+}
+
+func (v *propertyValidator) validateProperty(property *models.Property) error {
+	err := v.validate.Struct(property)
+	if err == nil {
+		return nil
 	}
-	return nil
+	fields := errors.FieldErrors(err)
+	if fields == nil {
+		return err
+	}
+	problems := make([]string, len(fields))
+	for i, field := range fields {
+		problems[i] = field.String()
+	}
+	return errors.NewAppError(
+		fmt.Sprintf("invalid property: propertyId=%s, %s", property.PropertyID, strings.Join(problems, "; ")),
+		errors.MsgInvalidParameters,
+		errors.ErrCodeInvalidParameters,
+		http.StatusBadRequest,
+		err,
+	)
 }
 
 func (v *propertyValidator) ValidateSearch(req *models.SearchRequest) error {
@@ -32,3 +57,23 @@ func (v *propertyValidator) ValidateSearch(req *models.SearchRequest) error {
 	}
 	return nil
 }
+
+func (v *propertyValidator) ValidateFilter(filter *models.PropertyFilter) error {
+	ranges := []struct {
+		name     string
+		min, max *int
+	}{
+		{"beds", filter.MinBeds, filter.MaxBeds},
+		{"baths", filter.MinBaths, filter.MaxBaths},
+		{"yearBuilt", filter.MinYearBuilt, filter.MaxYearBuilt},
+		{"assessedValue", filter.MinAssessedValue, filter.MaxAssessedValue},
+		{"price", filter.MinPrice, filter.MaxPrice},
+		{"wildfireRisk", filter.MinWildfireRisk, filter.MaxWildfireRisk},
+	}
+	for _, r := range ranges {
+		if r.min != nil && r.max != nil && *r.min > *r.max {
+			return fmt.Errorf("invalid filter: min %s is greater than max %s", r.name, r.name)
+		}
+	}
+	return nil
+}
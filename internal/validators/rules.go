@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var zipCodePattern = regexp.MustCompile(`^[0-9]{5}(-[0-9]{4})?$`)
+
+// usStates holds the USPS codes of the states, DC, the territories and the military post offices.
+var usStates = map[string]bool{
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true, "DE": true,
+	"FL": true, "GA": true, "HI": true, "ID": true, "IL": true, "IN": true, "IA": true, "KS": true,
+	"KY": true, "LA": true, "ME": true, "MD": true, "MA": true, "MI": true, "MN": true, "MS": true,
+	"MO": true, "MT": true, "NE": true, "NV": true, "NH": true, "NJ": true, "NM": true, "NY": true,
+	"NC": true, "ND": true, "OH": true, "OK": true, "OR": true, "PA": true, "RI": true, "SC": true,
+	"SD": true, "TN": true, "TX": true, "UT": true, "VT": true, "VA": true, "WA": true, "WV": true,
+	"WI": true, "WY": true, "DC": true,
+	"AS": true, "GU": true, "MP": true, "PR": true, "VI": true,
+	"AA": true, "AE": true, "AP": true,
+}
+
+// newStructValidator returns a validator for the validate tags on models, reporting fields by their
+// JSON names. Besides the built-in rules it knows:
+//
+//	zipcode  a 5-digit ZIP code, optionally with the +4 extension (12345 or 12345-6789)
+//	usstate  a two-letter USPS state or territory code, in any case
+func newStructValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+	validate.RegisterValidation("zipcode", func(fl validator.FieldLevel) bool {
+		return zipCodePattern.MatchString(fl.Field().String())
+	})
+	validate.RegisterValidation("usstate", func(fl validator.FieldLevel) bool {
+		return usStates[strings.ToUpper(fl.Field().String())]
+	})
+	return validate
+}
@@ -56,6 +56,13 @@ func (v *userValidator) ValidateLogin(email, password string) error {
 	return nil
 }
 
+func (v *userValidator) ValidatePassword(password string) error {
+	if len(password) < 6 || len(password) > 100 {
+		return errors.New("password must be between 6 and 100 characters")
+	}
+	return nil
+}
+
 func isValidEmail(email string) bool {
 	regex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return regex.MatchString(email)
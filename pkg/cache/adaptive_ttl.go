@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// A class whose keys are invalidated at least this often relative to how often they are written has
+// its TTL halved; one invalidated at most this often (and actually read) has it grown by half.
+const (
+	churnShrinkRatio = 0.5
+	churnGrowRatio   = 0.1
+	ttlShrinkFactor  = 0.5
+	ttlGrowFactor    = 1.5
+)
+
+type ttlClass struct {
+	min, max, base, current time.Duration
+
+	// counts for the current tuning window
+	hits, misses, sets, invalidations int64
+}
+
+// AdaptiveTTL tracks hit/miss/invalidation rates per key class and tunes the TTL given to newly cached
+// keys of each class within its configured bounds. Each instance tunes independently from its own traffic.
+type AdaptiveTTL struct {
+	mu       sync.Mutex
+	adaptive bool
+	jitter   float64 // fraction each returned TTL is randomly spread by, either way
+	classes  map[string]*ttlClass
+}
+
+func NewAdaptiveTTL(cfg *config.Config) *AdaptiveTTL {
+	a := &AdaptiveTTL{
+		adaptive: cfg.CacheTTL.Adaptive,
+		jitter:   float64(cfg.CacheTTL.JitterPercent) / 100,
+		classes:  make(map[string]*ttlClass),
+	}
+	for class, bounds := range map[string]config.CacheTTLBounds{
+		ClassProperty: cfg.CacheTTL.Property,
+		ClassSearch:   cfg.CacheTTL.Search,
+		ClassList:     cfg.CacheTTL.List,
+	} {
+		a.classes[class] = &ttlClass{
+			min:     time.Duration(bounds.MinMinutes) * time.Minute,
+			max:     time.Duration(bounds.MaxMinutes) * time.Minute,
+			base:    time.Duration(bounds.BaseMinutes) * time.Minute,
+			current: time.Duration(bounds.BaseMinutes) * time.Minute,
+		}
+		metrics.CacheTTLSeconds.WithLabelValues(class).Set(a.classes[class].current.Seconds())
+	}
+	return a
+}
+
+// TTL returns the expiration to use when caching a key of the given class, randomly spread by the
+// configured jitter so keys cached at the same time don't all expire at the same time.
+func (a *AdaptiveTTL) TTL(class string) time.Duration {
+	a.mu.Lock()
+	c, ok := a.classes[class]
+	if !ok {
+		c = a.classes[ClassProperty]
+	}
+	ttl, jitter := c.current, a.jitter
+	a.mu.Unlock()
+	return withJitter(ttl, jitter)
+}
+
+// SetBounds applies reloaded TTL settings. A class whose base TTL changed starts again from it;
+// otherwise its tuned TTL is kept within the new bounds.
+func (a *AdaptiveTTL) SetBounds(cfg *config.Config) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.jitter = float64(cfg.CacheTTL.JitterPercent) / 100
+	for class, bounds := range map[string]config.CacheTTLBounds{
+		ClassProperty: cfg.CacheTTL.Property,
+		ClassSearch:   cfg.CacheTTL.Search,
+		ClassList:     cfg.CacheTTL.List,
+	} {
+		c, ok := a.classes[class]
+		if !ok {
+			continue
+		}
+		base := time.Duration(bounds.BaseMinutes) * time.Minute
+		c.min = time.Duration(bounds.MinMinutes) * time.Minute
+		c.max = time.Duration(bounds.MaxMinutes) * time.Minute
+		next := c.current
+		if !a.adaptive || base != c.base {
+			next = base
+		}
+		c.base = base
+		if next < c.min {
+			next = c.min
+		}
+		if next > c.max {
+			next = c.max
+		}
+		c.current = next
+		metrics.CacheTTLSeconds.WithLabelValues(class).Set(next.Seconds())
+	}
+}
+
+// withJitter returns ttl moved by a random amount of up to fraction of it, up or down.
+func withJitter(ttl time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || ttl <= 0 {
+		return ttl
+	}
+	return time.Duration(float64(ttl) * (1 + fraction*(2*rand.Float64()-1)))
+}
+
+func (a *AdaptiveTTL) RecordHit(class string) {
+	metrics.CacheClassHitsTotal.WithLabelValues(class).Inc()
+	a.record(class, func(c *ttlClass) { c.hits++ })
+}
+
+func (a *AdaptiveTTL) RecordMiss(class string) {
+	metrics.CacheClassMissesTotal.WithLabelValues(class).Inc()
+	a.record(class, func(c *ttlClass) { c.misses++ })
+}
+
+func (a *AdaptiveTTL) RecordSet(class string) {
+	a.record(class, func(c *ttlClass) { c.sets++ })
+}
+
+func (a *AdaptiveTTL) RecordInvalidation(class string) {
+	metrics.CacheInvalidationsTotal.WithLabelValues(class).Inc()
+	a.record(class, func(c *ttlClass) { c.invalidations++ })
+}
+
+func (a *AdaptiveTTL) record(class string, fn func(c *ttlClass)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if c, ok := a.classes[class]; ok {
+		fn(c)
+	}
+}
+
+// Tune adjusts each class's TTL from the churn seen since the last call, then starts a new window.
+// Classes with no writes in the window keep their TTL.
+func (a *AdaptiveTTL) Tune() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.adaptive {
+		return
+	}
+
+	for class, c := range a.classes {
+		if c.sets > 0 {
+			churn := float64(c.invalidations) / float64(c.sets)
+			next := c.current
+			switch {
+			case churn >= churnShrinkRatio:
+				next = time.Duration(float64(c.current) * ttlShrinkFactor)
+			case churn <= churnGrowRatio && c.hits+c.misses > 0:
+				next = time.Duration(float64(c.current) * ttlGrowFactor)
+			}
+			if next < c.min {
+				next = c.min
+			}
+			if next > c.max {
+				next = c.max
+			}
+			if next != c.current {
+				logger.GlobalLogger.Printf("Cache TTL tuned: class=%s, ttl=%s -> %s, churn=%.2f, hits=%d, misses=%d",
+					class, c.current, next, churn, c.hits, c.misses)
+				c.current = next
+				metrics.CacheTTLSeconds.WithLabelValues(class).Set(next.Seconds())
+			}
+		}
+		c.hits, c.misses, c.sets, c.invalidations = 0, 0, 0, 0
+	}
+}
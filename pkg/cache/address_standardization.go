@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetStandardizedAddress returns the cached standardization result for an address input, or nil
+// when the input hasn't been standardized recently.
+func GetStandardizedAddress(ctx context.Context, input string) ([]byte, error) {
+	start := time.Now()
+	data, err := RedisClient.Get(ctx, AddressStandardizationKey(input)).Bytes()
+	metrics.RedisOperationDuration.WithLabelValues("get_standardized_address").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_standardized_address").Inc()
+		return nil, NewCacheError("get_standardized_address", err, true)
+	}
+	return data, nil
+}
+
+// SetStandardizedAddress caches the standardization result for an address input for ttl.
+func SetStandardizedAddress(ctx context.Context, input string, data []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := RedisClient.Set(ctx, AddressStandardizationKey(input), data, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_standardized_address").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_standardized_address").Inc()
+		return NewCacheError("set_standardized_address", err, true)
+	}
+	return nil
+}
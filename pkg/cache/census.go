@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetCensusData returns a cached census lookup, or nil when it hasn't been looked up recently.
+func GetCensusData(ctx context.Context, key string) ([]byte, error) {
+	start := time.Now()
+	data, err := RedisClient.Get(ctx, key).Bytes()
+	metrics.RedisOperationDuration.WithLabelValues("get_census_data").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_census_data").Inc()
+		return nil, NewCacheError("get_census_data", err, true)
+	}
+	return data, nil
+}
+
+// SetCensusData caches a census lookup for ttl.
+func SetCensusData(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := RedisClient.Set(ctx, key, data, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_census_data").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_census_data").Inc()
+		return NewCacheError("set_census_data", err, true)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetResumeToken returns the last change stream position stored for a collection, or nil if none is stored.
+func GetResumeToken(ctx context.Context, collection string) ([]byte, error) {
+	start := time.Now()
+	token, err := RedisClient.Get(ctx, ChangeStreamResumeKey(collection)).Bytes()
+	metrics.RedisOperationDuration.WithLabelValues("get_resume_token").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_resume_token").Inc()
+		return nil, NewCacheError("get_resume_token", err, true)
+	}
+	return token, nil
+}
+
+// SetResumeToken stores the change stream position to continue from after a restart. Passing nil
+// forgets it, so the next watch starts from the present.
+func SetResumeToken(ctx context.Context, collection string, token []byte) error {
+	start := time.Now()
+	var err error
+	if token == nil {
+		err = RedisClient.Del(ctx, ChangeStreamResumeKey(collection)).Err()
+	} else {
+		err = RedisClient.Set(ctx, ChangeStreamResumeKey(collection), token, 0).Err()
+	}
+	metrics.RedisOperationDuration.WithLabelValues("set_resume_token").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_resume_token").Inc()
+		return NewCacheError("set_resume_token", err, true)
+	}
+	return nil
+}
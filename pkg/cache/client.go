@@ -13,7 +13,8 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
-var RedisClient *redis.Client
+// RedisClient talks to a standalone node, a Sentinel-managed master or a Redis Cluster, per redis.mode.
+var RedisClient redis.UniversalClient
 
 // Initialize the Redis client with the provided configuration.
 func InitRedis(cfg *config.Config) error {
@@ -31,24 +32,55 @@ func InitRedis(cfg *config.Config) error {
 		port = 6379
 	}
 
-	// Configure Redis client options
-	options := &redis.Options{
-		Addr:         fmt.Sprintf("%s:%d", cfg.Redis.Host, port),
-		DB:           cfg.Redis.DB,
-		PoolSize:     10,
-		MinIdleConns: 5,
-		TLSConfig:    tlsConfig,
-		DialTimeout:  5 * time.Second,
-		ReadTimeout:  3 * time.Second,
-		WriteTimeout: 3 * time.Second,
-	}
+	// Bounds each command's reads and writes; a request's own deadline still applies when sooner
+	timeout := time.Duration(cfg.Redis.TimeoutMS) * time.Millisecond
 
-	// Only set password if non-empty
-	if cfg.Redis.Password != "" {
-		options.Password = cfg.Redis.Password
-	}
+	switch cfg.Redis.Mode {
+	case "cluster":
+		RedisClient = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.Redis.Addrs,
+			Password:     cfg.Redis.Password,
+			PoolSize:     10,
+			MinIdleConns: 5,
+			TLSConfig:    tlsConfig,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  timeout,
+			WriteTimeout: timeout,
+		})
+	case "sentinel":
+		RedisClient = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.Redis.MasterName,
+			SentinelAddrs:    cfg.Redis.Addrs,
+			SentinelPassword: cfg.Redis.SentinelPassword,
+			Password:         cfg.Redis.Password,
+			DB:               cfg.Redis.DB,
+			PoolSize:         10,
+			MinIdleConns:     5,
+			TLSConfig:        tlsConfig,
+			DialTimeout:      5 * time.Second,
+			ReadTimeout:      timeout,
+			WriteTimeout:     timeout,
+		})
+	default:
+		// Configure Redis client options
+		options := &redis.Options{
+			Addr:         fmt.Sprintf("%s:%d", cfg.Redis.Host, port),
+			DB:           cfg.Redis.DB,
+			PoolSize:     10,
+			MinIdleConns: 5,
+			TLSConfig:    tlsConfig,
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  timeout,
+			WriteTimeout: timeout,
+		}
 
-	RedisClient = redis.NewClient(options)
+		// Only set password if non-empty
+		if cfg.Redis.Password != "" {
+			options.Password = cfg.Redis.Password
+		}
+
+		RedisClient = redis.NewClient(options)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -63,7 +95,7 @@ func InitRedis(cfg *config.Config) error {
 		return fmt.Errorf("failed to connect to Redis: %v", err)
 	}
 
-	logger.GlobalLogger.Println("Redis connected successfully")
+	logger.GlobalLogger.Printf("Redis connected successfully: mode=%s", cfg.Redis.Mode)
 	return nil
 }
 
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// clusterSlots is the number of hash slots keys are spread over in Redis Cluster.
+const clusterSlots = 16384
+
+// isCluster reports whether RedisClient talks to a Redis Cluster.
+func isCluster() bool {
+	_, ok := RedisClient.(*redis.ClusterClient)
+	return ok
+}
+
+// keySlot returns the Redis Cluster hash slot of key. Only the part inside the first non-empty {hash tag}
+// is hashed, if the key has one.
+func keySlot(key string) int {
+	if open := strings.IndexByte(key, '{'); open >= 0 {
+		if end := strings.IndexByte(key[open+1:], '}'); end > 0 {
+			key = key[open+1 : open+1+end]
+		}
+	}
+	return int(crc16(key)) % clusterSlots
+}
+
+// crc16 is the CRC-16/XMODEM checksum Redis Cluster hashes keys with.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// groupBySlot splits keys into groups that may be used together in one command or script. Redis
+// Cluster rejects those whose keys span hash slots; anywhere else all keys form a single group.
+func groupBySlot(keys []string) [][]string {
+	if len(keys) == 0 {
+		return nil
+	}
+	if !isCluster() {
+		return [][]string{keys}
+	}
+	var groups [][]string
+	index := make(map[int]int)
+	for _, key := range keys {
+		slot := keySlot(key)
+		i, ok := index[slot]
+		if !ok {
+			i = len(groups)
+			index[slot] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], key)
+	}
+	return groups
+}
+
+// runScriptBySlot runs script once per hash slot among keys, with that slot's keys as KEYS. On a single
+// node this is one atomic call; on Redis Cluster each slot's part is atomic on its own.
+func runScriptBySlot(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) error {
+	for _, group := range groupBySlot(keys) {
+		if err := script.Run(ctx, RedisClient, group, args...).Err(); err != nil && err != redis.Nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ScanKeys calls fn with batches of keys matching pattern. On Redis Cluster every master is scanned.
+// fn is never called concurrently.
+func ScanKeys(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	var mu sync.Mutex
+	scan := func(ctx context.Context, client redis.Cmdable) error {
+		var cursor uint64
+		for {
+			start := time.Now()
+			keys, next, err := client.Scan(ctx, cursor, pattern, 500).Result()
+			metrics.RedisOperationDuration.WithLabelValues("scan").Observe(time.Since(start).Seconds())
+			if err != nil {
+				metrics.RedisErrorsTotal.WithLabelValues("scan").Inc()
+				return NewCacheError("scan", err, true)
+			}
+			if len(keys) > 0 {
+				mu.Lock()
+				err = fn(keys)
+				mu.Unlock()
+				if err != nil {
+					return err
+				}
+			}
+			cursor = next
+			if cursor == 0 {
+				return nil
+			}
+		}
+	}
+	if cluster, ok := RedisClient.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scan(ctx, node)
+		})
+	}
+	return scan(ctx, RedisClient)
+}
+
+// UnlinkKeys deletes keys without blocking Redis, one command per hash slot, and returns how many existed.
+func UnlinkKeys(ctx context.Context, keys []string) (int64, error) {
+	var deleted int64
+	for _, group := range groupBySlot(keys) {
+		start := time.Now()
+		n, err := RedisClient.Unlink(ctx, group...).Result()
+		metrics.RedisOperationDuration.WithLabelValues("unlink").Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.RedisErrorsTotal.WithLabelValues("unlink").Inc()
+			return deleted, NewCacheError("unlink", err, true)
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/golang/snappy"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec names, as set in redis.codec.
+const (
+	CodecJSON    = "json"
+	CodecMsgpack = "msgpack"
+	CodecSnappy  = "snappy" // snappy-compressed JSON
+	CodecGzip    = "gzip"   // gzip-compressed JSON
+)
+
+// Payloads written by every codec except JSON start with a tag byte naming their codec. JSON stays
+// untagged, so entries written before codecs existed still decode; no JSON text starts with these bytes.
+const (
+	tagJSON    byte = 0x00
+	tagMsgpack byte = 0x01
+	tagSnappy  byte = 0x02
+	tagGzip    byte = 0x03
+)
+
+// Codec encodes cached values in one format and decodes values written in any of them, so the
+// configured format can change without flushing the cache.
+type Codec struct {
+	name string
+	tag  byte
+}
+
+// NewCodec returns the codec with the given name; an empty name means JSON.
+func NewCodec(name string) (*Codec, error) {
+	switch name {
+	case "", CodecJSON:
+		return &Codec{name: CodecJSON, tag: tagJSON}, nil
+	case CodecMsgpack:
+		return &Codec{name: name, tag: tagMsgpack}, nil
+	case CodecSnappy:
+		return &Codec{name: name, tag: tagSnappy}, nil
+	case CodecGzip:
+		return &Codec{name: name, tag: tagGzip}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache codec %q", name)
+	}
+}
+
+func (c *Codec) Name() string {
+	return c.name
+}
+
+// Encode serializes v with this codec.
+func (c *Codec) Encode(v interface{}) ([]byte, error) {
+	if c.tag == tagMsgpack {
+		var buf bytes.Buffer
+		buf.WriteByte(tagMsgpack)
+		enc := msgpack.NewEncoder(&buf)
+		enc.SetCustomStructTag("json")
+		if err := enc.Encode(v); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	switch c.tag {
+	case tagSnappy:
+		return append([]byte{tagSnappy}, snappy.Encode(nil, data)...), nil
+	case tagGzip:
+		var buf bytes.Buffer
+		buf.WriteByte(tagGzip)
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return data, nil
+	}
+}
+
+// Decode deserializes data into v, whichever codec wrote it.
+func (c *Codec) Decode(data []byte, v interface{}) error {
+	switch payloadTag(data) {
+	case tagMsgpack:
+		dec := msgpack.NewDecoder(bytes.NewReader(data[1:]))
+		dec.SetCustomStructTag("json")
+		return dec.Decode(v)
+	case tagSnappy:
+		decoded, err := snappy.Decode(nil, data[1:])
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(decoded, v)
+	case tagGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		decoded, err := io.ReadAll(zr)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(decoded, v)
+	default:
+		return json.Unmarshal(data, v)
+	}
+}
+
+// Current reports whether data was written by this codec, i.e. needs no migration.
+func (c *Codec) Current(data []byte) bool {
+	return payloadTag(data) == c.tag
+}
+
+func payloadTag(data []byte) byte {
+	if len(data) > 0 && data[0] >= tagMsgpack && data[0] <= tagGzip {
+		return data[0]
+	}
+	return tagJSON
+}
+
+// MigrateValue replaces the value at key with its re-encoding, keeping the TTL, provided it still
+// holds old. It returns whether the value was replaced.
+func MigrateValue(ctx context.Context, key string, old, encoded []byte) (bool, error) {
+	start := time.Now()
+	n, err := migrateValueScript.Run(ctx, RedisClient, []string{key}, old, encoded).Int()
+	metrics.RedisOperationDuration.WithLabelValues("migrate_value").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("migrate_value").Inc()
+		return false, NewCacheError("migrate_value", err, true)
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// IncrementCoreLogicUsage counts one CoreLogic call against the given UTC day and returns the day's
+// total so far. Counters expire after two days.
+func IncrementCoreLogicUsage(ctx context.Context, day string) (int64, error) {
+	key := CoreLogicUsageKey(day)
+	start := time.Now()
+	pipe := RedisClient.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, 48*time.Hour)
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("increment_corelogic_usage").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("increment_corelogic_usage").Inc()
+		return 0, NewCacheError("increment_corelogic_usage", err, true)
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// DeprecatedCaller is one client's recorded usage of a deprecated endpoint or parameter.
+type DeprecatedCaller struct {
+	Calls    int64
+	LastSeen time.Time
+}
+
+// RecordDeprecatedCall counts a client's call to the deprecation with the given ID.
+func RecordDeprecatedCall(ctx context.Context, id, client string, at time.Time) error {
+	start := time.Now()
+	pipe := RedisClient.TxPipeline()
+	pipe.HIncrBy(ctx, DeprecationCallsKey(id), client, 1)
+	pipe.HSet(ctx, DeprecationLastSeenKey(id), client, at.Unix())
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("record_deprecated_call").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("record_deprecated_call").Inc()
+		return NewCacheError("record_deprecated_call", err, true)
+	}
+	return nil
+}
+
+// DeprecatedCallers returns the recorded usage of the deprecation with the given ID, keyed by client.
+func DeprecatedCallers(ctx context.Context, id string) (map[string]DeprecatedCaller, error) {
+	start := time.Now()
+	pipe := RedisClient.Pipeline()
+	callsCmd := pipe.HGetAll(ctx, DeprecationCallsKey(id))
+	lastSeenCmd := pipe.HGetAll(ctx, DeprecationLastSeenKey(id))
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("deprecated_callers").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("deprecated_callers").Inc()
+		return nil, NewCacheError("deprecated_callers", err, true)
+	}
+
+	callers := make(map[string]DeprecatedCaller, len(callsCmd.Val()))
+	for client, raw := range callsCmd.Val() {
+		calls, _ := strconv.ParseInt(raw, 10, 64)
+		caller := DeprecatedCaller{Calls: calls}
+		if seen, err := strconv.ParseInt(lastSeenCmd.Val()[client], 10, 64); err == nil {
+			caller.LastSeen = time.Unix(seen, 0).UTC()
+		}
+		callers[client] = caller
+	}
+	return callers, nil
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetHazardRisk returns the cached hazard lookup for a point, or nil when it hasn't been looked up
+// recently.
+func GetHazardRisk(ctx context.Context, point string) ([]byte, error) {
+	start := time.Now()
+	data, err := RedisClient.Get(ctx, HazardRiskKey(point)).Bytes()
+	metrics.RedisOperationDuration.WithLabelValues("get_hazard_risk").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_hazard_risk").Inc()
+		return nil, NewCacheError("get_hazard_risk", err, true)
+	}
+	return data, nil
+}
+
+// SetHazardRisk caches the hazard lookup for a point for ttl.
+func SetHazardRisk(ctx context.Context, point string, data []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := RedisClient.Set(ctx, HazardRiskKey(point), data, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_hazard_risk").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_hazard_risk").Inc()
+		return NewCacheError("set_hazard_risk", err, true)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// IdempotentResponse is the record kept for an Idempotency-Key: the fingerprint of the request that
+// claimed it and, once that request finished, the response replayed to retries.
+type IdempotentResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Completed   bool   `json:"completed"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// ReserveIdempotencyKey claims key for a request with the given fingerprint. It returns nil when the
+// key was claimed, or the existing record when another request already holds it.
+func ReserveIdempotencyKey(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotentResponse, error) {
+	data, err := json.Marshal(&IdempotentResponse{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	defer func() {
+		metrics.RedisOperationDuration.WithLabelValues("reserve_idempotency_key").Observe(time.Since(start).Seconds())
+	}()
+	// A record can expire between SETNX and GET; claiming again then succeeds
+	for attempt := 0; attempt < 2; attempt++ {
+		claimed, err := RedisClient.SetNX(ctx, key, data, ttl).Result()
+		if err != nil {
+			metrics.RedisErrorsTotal.WithLabelValues("reserve_idempotency_key").Inc()
+			return nil, NewCacheError("reserve_idempotency_key", err, true)
+		}
+		if claimed {
+			return nil, nil
+		}
+
+		stored, err := RedisClient.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			metrics.RedisErrorsTotal.WithLabelValues("reserve_idempotency_key").Inc()
+			return nil, NewCacheError("reserve_idempotency_key", err, true)
+		}
+		var existing IdempotentResponse
+		if err := json.Unmarshal(stored, &existing); err != nil {
+			return nil, NewCacheError("reserve_idempotency_key", err, false)
+		}
+		return &existing, nil
+	}
+	return nil, NewCacheError("reserve_idempotency_key", redis.Nil, true)
+}
+
+// CompleteIdempotencyKey stores the finished response for key, replacing the in-progress record.
+func CompleteIdempotencyKey(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	response.Completed = true
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = RedisClient.Set(ctx, key, data, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("complete_idempotency_key").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("complete_idempotency_key").Inc()
+		return NewCacheError("complete_idempotency_key", err, true)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey drops an in-progress claim so the request can be retried with the same key.
+func ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	start := time.Now()
+	err := RedisClient.Del(ctx, key).Err()
+	metrics.RedisOperationDuration.WithLabelValues("release_idempotency_key").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("release_idempotency_key").Inc()
+		return NewCacheError("release_idempotency_key", err, true)
+	}
+	return nil
+}
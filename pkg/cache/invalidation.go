@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// instanceID marks invalidations published by this process, so it doesn't act on its own messages.
+var instanceID = newInstanceID()
+
+func newInstanceID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+type invalidationMessage struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys,omitempty"`
+	All    bool     `json:"all,omitempty"`
+}
+
+// PublishInvalidation tells other instances to drop keys from their local caches, or everything if all is set.
+func PublishInvalidation(ctx context.Context, keys []string, all bool) error {
+	payload, err := json.Marshal(invalidationMessage{Origin: instanceID, Keys: keys, All: all})
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = RedisClient.Publish(ctx, InvalidationChannel(), payload).Err()
+	metrics.RedisOperationDuration.WithLabelValues("publish_invalidation").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("publish_invalidation").Inc()
+		return NewCacheError("publish_invalidation", err, true)
+	}
+	return nil
+}
+
+// SubscribeInvalidations applies invalidations published by other instances to local until the Redis
+// client is closed. Messages sent while disconnected are lost; the local TTL bounds the damage.
+func SubscribeInvalidations(local *LocalCache) {
+	pubsub := RedisClient.Subscribe(context.Background(), InvalidationChannel())
+	defer pubsub.Close()
+
+	for msg := range pubsub.Channel() {
+		var inv invalidationMessage
+		if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+			logger.GlobalLogger.Warnf("Ignoring malformed cache invalidation: error=%v", err)
+			continue
+		}
+		if inv.Origin == instanceID {
+			continue
+		}
+		if inv.All {
+			local.Clear()
+			continue
+		}
+		local.Delete(inv.Keys...)
+	}
+}
@@ -0,0 +1,175 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// EnqueueJob stores a job's fields and appends it to the queue of its type. With a uniqueKey, a job
+// already pending under that key wins: its ID is returned and nothing is enqueued. The key is held
+// for uniqueFor or until ReleaseJobUniqueKey.
+func EnqueueJob(ctx context.Context, jobType, id string, fields map[string]interface{}, uniqueKey string, uniqueFor time.Duration) (string, error) {
+	keys := []string{JobKey(id), JobQueueKey(jobType)}
+	if uniqueKey != "" {
+		keys = append(keys, JobUniqueKey(uniqueKey))
+	}
+	args := []interface{}{id, uniqueFor.Milliseconds()}
+	for field, value := range fields {
+		args = append(args, field, value)
+	}
+
+	start := time.Now()
+	enqueuedID, err := enqueueJobScript.Run(ctx, RedisClient, keys, args...).Text()
+	metrics.RedisOperationDuration.WithLabelValues("enqueue_job").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("enqueue_job").Inc()
+		return "", NewCacheError("enqueue_job", err, true)
+	}
+	return enqueuedID, nil
+}
+
+// ClaimJob takes the next queued job of a type and leases it to the caller until lease runs out.
+// Returns "" when the queue is empty.
+func ClaimJob(ctx context.Context, jobType string, lease time.Duration) (string, error) {
+	deadline := time.Now().Add(lease).UnixMilli()
+
+	start := time.Now()
+	id, err := claimJobScript.Run(ctx, RedisClient, []string{JobQueueKey(jobType), JobInflightKey(jobType)}, deadline).Text()
+	metrics.RedisOperationDuration.WithLabelValues("claim_job").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("claim_job").Inc()
+		return "", NewCacheError("claim_job", err, true)
+	}
+	return id, nil
+}
+
+// AckJob removes a finished job from flight.
+func AckJob(ctx context.Context, jobType, id string) error {
+	start := time.Now()
+	err := RedisClient.ZRem(ctx, JobInflightKey(jobType), id).Err()
+	metrics.RedisOperationDuration.WithLabelValues("ack_job").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("ack_job").Inc()
+		return NewCacheError("ack_job", err, true)
+	}
+	return nil
+}
+
+// RetryJob moves a running job to the delayed set, to be queued again at the given time.
+func RetryJob(ctx context.Context, jobType, id string, at time.Time) error {
+	start := time.Now()
+	err := retryJobScript.Run(ctx, RedisClient, []string{JobInflightKey(jobType), JobDelayedKey(jobType)}, id, at.UnixMilli()).Err()
+	metrics.RedisOperationDuration.WithLabelValues("retry_job").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("retry_job").Inc()
+		return NewCacheError("retry_job", err, true)
+	}
+	return nil
+}
+
+// BuryJob moves a running job to the dead-letter list of its type, which keeps the newest maxLen IDs.
+func BuryJob(ctx context.Context, jobType, id string, maxLen int) error {
+	start := time.Now()
+	err := buryJobScript.Run(ctx, RedisClient, []string{JobInflightKey(jobType), JobDeadLetterKey(jobType)}, id, maxLen).Err()
+	metrics.RedisOperationDuration.WithLabelValues("bury_job").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("bury_job").Inc()
+		return NewCacheError("bury_job", err, true)
+	}
+	return nil
+}
+
+// PromoteJobs queues again up to limit due retries and up to limit jobs whose worker let the lease
+// run out, and returns how many were moved.
+func PromoteJobs(ctx context.Context, jobType string, limit int) (int, error) {
+	keys := []string{JobDelayedKey(jobType), JobInflightKey(jobType), JobQueueKey(jobType)}
+
+	start := time.Now()
+	moved, err := promoteJobsScript.Run(ctx, RedisClient, keys, time.Now().UnixMilli(), limit).Int()
+	metrics.RedisOperationDuration.WithLabelValues("promote_jobs").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("promote_jobs").Inc()
+		return 0, NewCacheError("promote_jobs", err, true)
+	}
+	return moved, nil
+}
+
+// UpdateJob sets fields of a job's status hash. A positive ttl lets the hash expire, for jobs that
+// have finished.
+func UpdateJob(ctx context.Context, id string, fields map[string]interface{}, ttl time.Duration) error {
+	key := JobKey(id)
+
+	start := time.Now()
+	_, err := RedisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, fields)
+		if ttl > 0 {
+			pipe.Expire(ctx, key, ttl)
+		}
+		return nil
+	})
+	metrics.RedisOperationDuration.WithLabelValues("update_job").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("update_job").Inc()
+		return NewCacheError("update_job", err, true)
+	}
+	return nil
+}
+
+// IncrementJobAttempts counts a new attempt at a job and returns the total.
+func IncrementJobAttempts(ctx context.Context, id string) (int, error) {
+	start := time.Now()
+	attempts, err := RedisClient.HIncrBy(ctx, JobKey(id), "attempts", 1).Result()
+	metrics.RedisOperationDuration.WithLabelValues("increment_job_attempts").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("increment_job_attempts").Inc()
+		return 0, NewCacheError("increment_job_attempts", err, true)
+	}
+	return int(attempts), nil
+}
+
+// GetJob returns the fields of a job's status hash, or nil if the job is unknown or has expired.
+func GetJob(ctx context.Context, id string) (map[string]string, error) {
+	start := time.Now()
+	fields, err := RedisClient.HGetAll(ctx, JobKey(id)).Result()
+	metrics.RedisOperationDuration.WithLabelValues("get_job").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_job").Inc()
+		return nil, NewCacheError("get_job", err, true)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// ReleaseJobUniqueKey frees a uniqueness key while it still points at the given job, so the next
+// job under that key can be enqueued.
+func ReleaseJobUniqueKey(ctx context.Context, uniqueKey, id string) error {
+	start := time.Now()
+	err := releaseLockScript.Run(ctx, RedisClient, []string{JobUniqueKey(uniqueKey)}, id).Err()
+	metrics.RedisOperationDuration.WithLabelValues("release_job_unique_key").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("release_job_unique_key").Inc()
+		return NewCacheError("release_job_unique_key", err, true)
+	}
+	return nil
+}
+
+// JobQueueLength returns how many jobs of a type are waiting for a worker.
+func JobQueueLength(ctx context.Context, jobType string) (int64, error) {
+	start := time.Now()
+	length, err := RedisClient.LLen(ctx, JobQueueKey(jobType)).Result()
+	metrics.RedisOperationDuration.WithLabelValues("job_queue_length").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("job_queue_length").Inc()
+		return 0, NewCacheError("job_queue_length", err, true)
+	}
+	return length, nil
+}
@@ -10,9 +10,23 @@ func PropertyListKey() string {
 	return "properties:list"
 }
 
-// cache key for a paginated list of properties.
-func PropertyListPaginatedKey(offset, limit int) string {
-	return fmt.Sprintf("properties:list:offset:%d:limit:%d", offset, limit)
+// cache key for the set of paginated list keys, cleared whenever any property changes.
+func PropertyListKeysSetKey() string {
+	return "properties:list:keys"
+}
+
+// cache key for an organization's paginated list of properties, optionally narrowed by a canonical
+// filter string and ordered by a canonical sort string.
+func PropertyListPaginatedKey(orgID string, offset, limit int, filter, sort string) string {
+	if filter == "" && sort == "" {
+		return fmt.Sprintf("properties:list:org:%s:offset:%d:limit:%d", orgID, offset, limit)
+	}
+	return fmt.Sprintf("properties:list:org:%s:filter:%s:sort:%s:offset:%d:limit:%d", orgID, filter, sort, offset, limit)
+}
+
+// cache key for the number of an organization's properties matching a canonical filter string.
+func PropertyCountKey(orgID, filter string) string {
+	return fmt.Sprintf("properties:count:org:%s:filter:%s", orgID, filter)
 }
 
 // normalize address components by converting to lowercase and abbreviating common terms.
@@ -37,22 +51,284 @@ func NormalizeAddressComponent(s string) string {
 	return s
 }
 
-// cache key for a specific property search based on street and city.
-func PropertySpecificSearchKey(street, city string) string {
-	return fmt.Sprintf("properties:search-specific:street:%s:city:%s", street, city)
+// cache key for an organization's search for a specific property based on street and city.
+func PropertySpecificSearchKey(orgID, street, city string) string {
+	return fmt.Sprintf("properties:search-specific:org:%s:street:%s:city:%s", orgID, street, city)
+}
+
+// cache key for the ranked properties matching an organization's ambiguous address search.
+func PropertyMatchesSearchKey(orgID, street, city, state, zip string) string {
+	return fmt.Sprintf("properties:search-matches:org:%s:street:%s:city:%s:state:%s:zip:%s", orgID, street, city, state, zip)
+}
+
+// cache key for a page of an organization's full-text search results.
+func PropertyFullTextSearchKey(orgID, query string, offset, limit int) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	return fmt.Sprintf("properties:fulltext:org:%s:q:%s:offset:%d:limit:%d", orgID, normalized, offset, limit)
+}
+
+// Property IDs are only unique within an organization, so the keys of a property and of what hangs
+// off it carry the organization too.
+
+// cache key for a specific property of an organization.
+func PropertyKey(orgID, id string) string {
+	return fmt.Sprintf("property:org:%s:id:%s", orgID, id)
+}
+
+// cache key for the set of cache keys associated with an organization's property.
+func PropertyKeysSetKey(orgID, propertyID string) string {
+	return fmt.Sprintf("property:keys:org:%s:id:%s", orgID, propertyID)
+}
+
+// cache key for the latest valuation of an organization's property.
+func ValuationKey(orgID, propertyID string) string {
+	return fmt.Sprintf("valuation:org:%s:property:%s", orgID, propertyID)
+}
+
+// cache key for the latest listing of an organization's property.
+func ListingKey(orgID, propertyID string) string {
+	return fmt.Sprintf("listing:org:%s:property:%s", orgID, propertyID)
+}
+
+// cache key for the latest transactions of an organization's property, as embedded with
+// ?include=transactions.
+func TransactionsKey(orgID, propertyID string) string {
+	return fmt.Sprintf("transactions:org:%s:property:%s", orgID, propertyID)
+}
+
+// cache key for the photos and documents of an organization's property, stored without their signed
+// URLs.
+func MediaKey(orgID, propertyID string) string {
+	return fmt.Sprintf("media:org:%s:property:%s", orgID, propertyID)
+}
+
+// cache key for the market statistics of a zip code.
+func MarketStatsKey(zipCode string) string {
+	return fmt.Sprintf("market:stats:zip:%s", zipCode)
+}
+
+// cache key for the set of zip codes whose market statistics are refreshed nightly.
+func MarketStatsZipsKey() string {
+	return "market:stats:zips"
 }
 
-// cache key for a specific property.
-func PropertyKey(id string) string {
-	return fmt.Sprintf("property:%s", id)
+// cache keys for an organization's location browse hierarchy: its states, a state's cities and a
+// city's zip codes.
+func LocationStatesKey(orgID string) string {
+	return fmt.Sprintf("locations:org:%s:states", orgID)
 }
 
-// cache key for the set of cache keys associated with a property.
-func PropertyKeysSetKey(propertyID string) string {
-	return fmt.Sprintf("property:keys:%s", propertyID)
+func LocationCitiesKey(orgID, state string) string {
+	return fmt.Sprintf("locations:org:%s:state:%s:cities", orgID, state)
+}
+
+func LocationZipsKey(orgID, state, city string) string {
+	return fmt.Sprintf("locations:org:%s:state:%s:city:%s:zips", orgID, state, city)
+}
+
+// cache key for the sorted set of property read counts, used to pick properties to warm on startup.
+func PropertyHitsKey() string {
+	return "stats:property:hits"
+}
+
+// cache key holding the resume token of a collection's change stream watcher.
+func ChangeStreamResumeKey(collection string) string {
+	return fmt.Sprintf("changestream:resume:%s", collection)
+}
+
+// Background job keys share the {jobs} hash tag so the queue scripts can run on Redis Cluster.
+
+// cache key holding a background job's status hash.
+func JobKey(id string) string {
+	return fmt.Sprintf("{jobs}:job:%s", id)
+}
+
+// list of job IDs of one type waiting for a worker.
+func JobQueueKey(jobType string) string {
+	return fmt.Sprintf("{jobs}:queue:%s", jobType)
+}
+
+// sorted set of running job IDs of one type, scored by the unix millisecond their lease expires.
+func JobInflightKey(jobType string) string {
+	return fmt.Sprintf("{jobs}:inflight:%s", jobType)
+}
+
+// sorted set of job IDs of one type waiting to be retried, scored by the unix millisecond they are due.
+func JobDelayedKey(jobType string) string {
+	return fmt.Sprintf("{jobs}:delayed:%s", jobType)
+}
+
+// list of job IDs of one type that ran out of attempts, newest first.
+func JobDeadLetterKey(jobType string) string {
+	return fmt.Sprintf("{jobs}:dead:%s", jobType)
+}
+
+// cache key holding the ID of the pending job enqueued under a uniqueness key.
+func JobUniqueKey(key string) string {
+	return fmt.Sprintf("{jobs}:unique:%s", key)
+}
+
+// cache key holding the standardization result for an address input, which is lowercased with
+// whitespace collapsed so trivially different spellings share it.
+func AddressStandardizationKey(input string) string {
+	return fmt.Sprintf("address:standardized:%s", strings.Join(strings.Fields(strings.ToLower(input)), " "))
+}
+
+// cache key holding the hazard lookup for a point, given as the provider name and rounded
+// coordinates.
+func HazardRiskKey(point string) string {
+	return fmt.Sprintf("hazard:%s", point)
+}
+
+// cache key holding the walkability lookup for a point, given as the provider name and rounded
+// coordinates.
+func WalkabilityKey(point string) string {
+	return fmt.Sprintf("walkability:%s", point)
+}
+
+// cache key holding the census tract containing a point, given as rounded coordinates.
+func CensusTractKey(point string) string {
+	return fmt.Sprintf("census:tract:%s", point)
+}
+
+// cache key holding a census tract's survey estimates for a survey year.
+func CensusStatsKey(year int, geoid string) string {
+	return fmt.Sprintf("census:stats:%d:%s", year, geoid)
 }
 
 // cache key for a specific user.
 func UserKey(id string) string {
 	return fmt.Sprintf("user:%s", id)
 }
+
+// cache key for a named distributed lock.
+func LockKey(name string) string {
+	return fmt.Sprintf("lock:%s", name)
+}
+
+// cache key marking a named piece of work as claimed.
+func ClaimKey(name string) string {
+	return fmt.Sprintf("claim:%s", name)
+}
+
+// cache key counting a lock's fencing tokens, hash-tagged to the lock's cluster slot.
+func LockFenceKey(name string) string {
+	return fmt.Sprintf("{%s}:fence", LockKey(name))
+}
+
+// cache key recording a request nonce already used by a signing partner.
+func NonceKey(keyID, nonce string) string {
+	return fmt.Sprintf("nonce:%s:%s", keyID, nonce)
+}
+
+// cache key holding the stored response for an Idempotency-Key, scoped to the caller and route.
+func IdempotencyKey(scope, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s", scope, key)
+}
+
+// cache key counting CoreLogic calls made on a UTC day (YYYY-MM-DD).
+func CoreLogicUsageKey(day string) string {
+	return fmt.Sprintf("corelogic:usage:%s", day)
+}
+
+// cache key counting a usage metric per organization and API key on a UTC day (YYYY-MM-DD).
+func UsageKey(metric, day string) string {
+	return fmt.Sprintf("usage:%s:%s", metric, day)
+}
+
+// cache key holding the sliding window log of a subject's requests to a route group.
+func RateLimitKey(group, subject string) string {
+	return fmt.Sprintf("ratelimit:%s:%s", group, subject)
+}
+
+// cache key mapping a password reset token hash to the user it was issued to.
+func PasswordResetKey(tokenHash string) string {
+	return fmt.Sprintf("password_reset:token:%s", tokenHash)
+}
+
+// cache key holding the hash of a user's outstanding password reset token.
+func PasswordResetUserKey(userID string) string {
+	return fmt.Sprintf("password_reset:user:%s", userID)
+}
+
+// cache key set while a user's last password reset email is within its resend cooldown.
+func PasswordResetCooldownKey(userID string) string {
+	return fmt.Sprintf("password_reset:cooldown:%s", userID)
+}
+
+// cache key marking a revoked access token by its jti.
+func TokenDenylistKey(jti string) string {
+	return fmt.Sprintf("denylist:jti:%s", jti)
+}
+
+// cache key counting recent failed sign-ins of a subject, an email or client IP.
+func LoginFailuresKey(subject string) string {
+	return fmt.Sprintf("login:failures:%s", subject)
+}
+
+// cache key set while sign-in is locked for a subject.
+func LoginLockKey(subject string) string {
+	return fmt.Sprintf("login:lock:%s", subject)
+}
+
+// cache key counting a subject's recent lockouts, which lengthen each next one.
+func LoginLockoutsKey(subject string) string {
+	return fmt.Sprintf("login:lockouts:%s", subject)
+}
+
+// cache key marking a signed-out session, whose access tokens are all revoked.
+func SessionDenylistKey(sessionID string) string {
+	return fmt.Sprintf("denylist:sid:%s", sessionID)
+}
+
+// cache key holding when a session last made a request.
+func SessionLastUsedKey(sessionID string) string {
+	return fmt.Sprintf("session:last_used:%s", sessionID)
+}
+
+// cache key holding per-client call counts for a deprecated endpoint or parameter.
+func DeprecationCallsKey(id string) string {
+	return fmt.Sprintf("deprecation:calls:%s", id)
+}
+
+// cache key holding the unix time each client last called a deprecated endpoint or parameter.
+func DeprecationLastSeenKey(id string) string {
+	return fmt.Sprintf("deprecation:lastseen:%s", id)
+}
+
+// pub/sub channel carrying local cache invalidations between instances.
+func InvalidationChannel() string {
+	return "cache:invalidations"
+}
+
+// SearchCachePatterns match cached search, full-text and list results, which can be rebuilt from MongoDB.
+var SearchCachePatterns = []string{"properties:*"}
+
+// CachedDataPatterns match every key holding cached data. Other keys (rate limits, revoked tokens,
+// nonces, locks, idempotency records) are state and must survive a cache flush.
+var CachedDataPatterns = []string{
+	"property:*", "properties:*", "valuation:*", "listing:*", "transactions:*", "media:*", "user:*", "address:*",
+	"market:*", "locations:*", "hazard:*", "walkability:*", "census:*",
+}
+
+// Key classes group cache keys with similar access and invalidation patterns for hit-rate SLIs and TTL tuning.
+const (
+	ClassProperty = "property" // a single property document
+	ClassSearch   = "search"   // an address lookup pointing at a property ID
+	ClassList     = "list"     // a page of list, full-text or address match search results
+	ClassOther    = "other"
+)
+
+// KeyClass returns the class a cache key belongs to.
+func KeyClass(key string) string {
+	switch {
+	case strings.HasPrefix(key, "properties:search-specific:"):
+		return ClassSearch
+	case strings.HasPrefix(key, "properties:list"), strings.HasPrefix(key, "properties:fulltext:"), strings.HasPrefix(key, "properties:search-matches:"):
+		return ClassList
+	case strings.HasPrefix(key, "property:") && !strings.HasPrefix(key, "property:keys:"):
+		return ClassProperty
+	default:
+		return ClassOther
+	}
+}
@@ -0,0 +1,114 @@
+package cache_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"strings"
+	"testing"
+
+	"homeinsight-properties/pkg/cache"
+)
+
+// cachedDataKeys are sample keys of every builder in keys.go holding data a cache flush may drop.
+var cachedDataKeys = map[string]string{
+	"PropertyListKey":           cache.PropertyListKey(),
+	"PropertyListKeysSetKey":    cache.PropertyListKeysSetKey(),
+	"PropertyListPaginatedKey":  cache.PropertyListPaginatedKey("org", 0, 10, "city=AUSTIN", "price"),
+	"PropertyCountKey":          cache.PropertyCountKey("org", "city=AUSTIN"),
+	"PropertySpecificSearchKey": cache.PropertySpecificSearchKey("org", "1 MAIN ST", "AUSTIN"),
+	"PropertyMatchesSearchKey":  cache.PropertyMatchesSearchKey("org", "1 MAIN ST", "AUSTIN", "TX", "78701"),
+	"PropertyFullTextSearchKey": cache.PropertyFullTextSearchKey("org", "main st", 0, 10),
+	"PropertyKey":               cache.PropertyKey("org", "123"),
+	"PropertyKeysSetKey":        cache.PropertyKeysSetKey("org", "123"),
+	"ValuationKey":              cache.ValuationKey("org", "123"),
+	"ListingKey":                cache.ListingKey("org", "123"),
+	"TransactionsKey":           cache.TransactionsKey("org", "123"),
+	"MediaKey":                  cache.MediaKey("org", "123"),
+	"MarketStatsKey":            cache.MarketStatsKey("78701"),
+	"MarketStatsZipsKey":        cache.MarketStatsZipsKey(),
+	"LocationStatesKey":         cache.LocationStatesKey("org"),
+	"LocationCitiesKey":         cache.LocationCitiesKey("org", "TX"),
+	"LocationZipsKey":           cache.LocationZipsKey("org", "TX", "AUSTIN"),
+	"AddressStandardizationKey": cache.AddressStandardizationKey("1 Main St, Austin TX"),
+	"HazardRiskKey":             cache.HazardRiskKey("fema:30.27:-97.74"),
+	"WalkabilityKey":            cache.WalkabilityKey("walkscore:30.27:-97.74"),
+	"CensusTractKey":            cache.CensusTractKey("30.27:-97.74"),
+	"CensusStatsKey":            cache.CensusStatsKey(2022, "48453001100"),
+	"UserKey":                   cache.UserKey("user"),
+}
+
+// stateKeys are the builders in keys.go whose keys are state that must survive a cache flush.
+var stateKeys = map[string]string{
+	"PropertyHitsKey":          cache.PropertyHitsKey(),
+	"ChangeStreamResumeKey":    cache.ChangeStreamResumeKey("properties"),
+	"JobKey":                   cache.JobKey("job"),
+	"JobQueueKey":              cache.JobQueueKey("type"),
+	"JobInflightKey":           cache.JobInflightKey("type"),
+	"JobDelayedKey":            cache.JobDelayedKey("type"),
+	"JobDeadLetterKey":         cache.JobDeadLetterKey("type"),
+	"JobUniqueKey":             cache.JobUniqueKey("unique"),
+	"LockKey":                  cache.LockKey("lock"),
+	"ClaimKey":                 cache.ClaimKey("claim"),
+	"LockFenceKey":             cache.LockFenceKey("lock"),
+	"NonceKey":                 cache.NonceKey("key", "nonce"),
+	"IdempotencyKey":           cache.IdempotencyKey("scope", "key"),
+	"CoreLogicUsageKey":        cache.CoreLogicUsageKey("2026-01-01"),
+	"UsageKey":                 cache.UsageKey("requests", "2026-01-01"),
+	"RateLimitKey":             cache.RateLimitKey("group", "ip:127.0.0.1"),
+	"PasswordResetKey":         cache.PasswordResetKey("hash"),
+	"PasswordResetUserKey":     cache.PasswordResetUserKey("user"),
+	"PasswordResetCooldownKey": cache.PasswordResetCooldownKey("user"),
+	"TokenDenylistKey":         cache.TokenDenylistKey("jti"),
+	"LoginFailuresKey":         cache.LoginFailuresKey("subject"),
+	"LoginLockKey":             cache.LoginLockKey("subject"),
+	"LoginLockoutsKey":         cache.LoginLockoutsKey("subject"),
+	"SessionDenylistKey":       cache.SessionDenylistKey("session"),
+	"SessionLastUsedKey":       cache.SessionLastUsedKey("session"),
+	"DeprecationCallsKey":      cache.DeprecationCallsKey("id"),
+	"DeprecationLastSeenKey":   cache.DeprecationLastSeenKey("id"),
+}
+
+func matchesAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCachedDataPatternsCoverCachedKeys(t *testing.T) {
+	for name, key := range cachedDataKeys {
+		if !matchesAny(cache.CachedDataPatterns, key) {
+			t.Errorf("%s key %q isn't matched by CachedDataPatterns, so a cache flush leaves it behind", name, key)
+		}
+	}
+	for name, key := range stateKeys {
+		if matchesAny(cache.CachedDataPatterns, key) {
+			t.Errorf("%s key %q is state but matched by CachedDataPatterns, so a cache flush drops it", name, key)
+		}
+	}
+}
+
+// TestEveryKeyBuilderIsClassified fails when a key builder is added to keys.go without saying whether
+// its keys are cached data or state.
+func TestEveryKeyBuilderIsClassified(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "keys.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse keys.go: %v", err)
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !fn.Name.IsExported() || !strings.HasSuffix(fn.Name.Name, "Key") {
+			continue
+		}
+		name := fn.Name.Name
+		_, cached := cachedDataKeys[name]
+		_, state := stateKeys[name]
+		if !cached && !state {
+			t.Errorf("key builder %s is neither in cachedDataKeys nor in stateKeys", name)
+		}
+	}
+}
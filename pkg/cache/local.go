@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// LocalCache is a size-bounded in-process LRU kept in front of Redis for hot keys. Entries live for a
+// short fixed TTL, which bounds how stale a copy can get if an invalidation message is missed. A nil
+// *LocalCache is valid and never holds anything.
+type LocalCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List // most recently used at the front
+}
+
+type localEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLocalCache returns a cache holding up to capacity entries for ttl each, or nil if either is not positive.
+func NewLocalCache(capacity int, ttl time.Duration) *LocalCache {
+	if capacity <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &LocalCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value stored under key, if present and not expired.
+func (l *LocalCache) Get(key string) ([]byte, bool) {
+	if l == nil {
+		return nil, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	elem, ok := l.entries[key]
+	if !ok {
+		metrics.LocalCacheMissesTotal.Inc()
+		return nil, false
+	}
+	entry := elem.Value.(*localEntry)
+	if time.Now().After(entry.expiresAt) {
+		l.remove(elem)
+		metrics.LocalCacheMissesTotal.Inc()
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+	metrics.LocalCacheHitsTotal.Inc()
+	return entry.value, true
+}
+
+// Set stores value under key, evicting the least recently used entry when full.
+func (l *LocalCache) Set(key string, value []byte) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	expiresAt := time.Now().Add(l.ttl)
+	if elem, ok := l.entries[key]; ok {
+		entry := elem.Value.(*localEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		l.order.MoveToFront(elem)
+		return
+	}
+	l.entries[key] = l.order.PushFront(&localEntry{key: key, value: value, expiresAt: expiresAt})
+	if l.order.Len() > l.capacity {
+		l.remove(l.order.Back())
+	}
+}
+
+// Delete removes keys from the cache.
+func (l *LocalCache) Delete(keys ...string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		if elem, ok := l.entries[key]; ok {
+			l.remove(elem)
+		}
+	}
+}
+
+// Clear removes every entry.
+func (l *LocalCache) Clear() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = make(map[string]*list.Element, l.capacity)
+	l.order.Init()
+}
+
+func (l *LocalCache) remove(elem *list.Element) {
+	l.order.Remove(elem)
+	delete(l.entries, elem.Value.(*localEntry).key)
+}
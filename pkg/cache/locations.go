@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetLocations returns a cached level of the location browse hierarchy, or nil when it isn't cached.
+func GetLocations(ctx context.Context, key string) ([]byte, error) {
+	start := time.Now()
+	data, err := RedisClient.Get(ctx, key).Bytes()
+	metrics.RedisOperationDuration.WithLabelValues("get_locations").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_locations").Inc()
+		return nil, NewCacheError("get_locations", err, true)
+	}
+	return data, nil
+}
+
+// SetLocations caches a level of the location browse hierarchy for ttl.
+func SetLocations(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := RedisClient.Set(ctx, key, data, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_locations").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_locations").Inc()
+		return NewCacheError("set_locations", err, true)
+	}
+	return nil
+}
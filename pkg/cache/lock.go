@@ -0,0 +1,150 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// ErrLockHeld is returned when another instance owns the lock.
+var ErrLockHeld = errors.New("lock is held by another instance")
+
+// ErrLockLost is returned when a lock expired or was taken over before it was extended or released.
+var ErrLockLost = errors.New("lock is no longer owned by this instance")
+
+// lockFenceRetention is how long a lock's fencing token counter outlives its last acquisition.
+const lockFenceRetention = 30 * 24 * time.Hour
+
+// Lock is a Redis-backed mutex shared by all API instances. Ownership is proven by a random token,
+// so an instance can never extend or release a lock that expired and was acquired by someone else.
+type Lock struct {
+	key   string
+	token string
+	ttl   time.Duration
+	fence int64
+}
+
+// AcquireLock takes the named lock for ttl, returning ErrLockHeld if it is already owned.
+func AcquireLock(ctx context.Context, name string, ttl time.Duration) (*Lock, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	lock := &Lock{key: LockKey(name), token: hex.EncodeToString(buf), ttl: ttl}
+
+	start := time.Now()
+	fence, err := acquireLockScript.Run(ctx, RedisClient, []string{lock.key, LockFenceKey(name)},
+		lock.token, ttl.Milliseconds(), time.Now().UnixMilli(), lockFenceRetention.Milliseconds()).Int64()
+	metrics.RedisOperationDuration.WithLabelValues("lock_acquire").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("lock_acquire").Inc()
+		return nil, NewCacheError("lock_acquire", err, true)
+	}
+	if fence == 0 {
+		return nil, ErrLockHeld
+	}
+	lock.fence = fence
+	return lock, nil
+}
+
+// Claim marks the named work as taken for ttl, returning false if another instance already claimed
+// it. Unlike a lock a claim is never released; it keeps work that every instance reaches at about the
+// same time, such as a scheduled run, from being done more than once.
+func Claim(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	start := time.Now()
+	ok, err := RedisClient.SetNX(ctx, ClaimKey(name), time.Now().UTC().Format(time.RFC3339), ttl).Result()
+	metrics.RedisOperationDuration.WithLabelValues("claim").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("claim").Inc()
+		return false, NewCacheError("claim", err, true)
+	}
+	return ok, nil
+}
+
+// WithLock runs fn holding the named lock, extending it every third of ttl for as long as fn runs,
+// and returns ErrLockHeld without running fn if another instance owns it. Should the lock be lost
+// anyway, as when Redis is unreachable for longer than ttl, fn's context is cancelled so two holders
+// never keep working side by side; fn's error is returned, or ErrLockLost if it had none.
+func WithLock(ctx context.Context, name string, ttl time.Duration, fn func(ctx context.Context, lock *Lock) error) error {
+	lock, err := AcquireLock(ctx, name, ttl)
+	if err != nil {
+		return err
+	}
+	defer lock.Release(context.Background())
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	lost := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := lock.Extend(runCtx); err != nil {
+					if runCtx.Err() != nil {
+						return
+					}
+					logger.GlobalLogger.Warnf("Failed to extend lock, stopping its holder: lock=%s, error=%v", name, err)
+					close(lost)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	err = fn(runCtx, lock)
+	select {
+	case <-lost:
+		if err == nil {
+			err = ErrLockLost
+		}
+	default:
+	}
+	return err
+}
+
+// Fence returns the lock's fencing token. Every acquisition of a lock gets a larger token than the
+// last, so a store written under the lock can reject writes carrying a token older than one it has
+// seen, from a holder that was paused while its lock expired and was taken over.
+func (l *Lock) Fence() int64 {
+	return l.fence
+}
+
+// Extend pushes the lock's expiry out by its original ttl. Long-running holders call this periodically.
+func (l *Lock) Extend(ctx context.Context) error {
+	start := time.Now()
+	n, err := extendLockScript.Run(ctx, RedisClient, []string{l.key}, l.token, l.ttl.Milliseconds()).Int()
+	metrics.RedisOperationDuration.WithLabelValues("lock_extend").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("lock_extend").Inc()
+		return NewCacheError("lock_extend", err, true)
+	}
+	if n == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
+
+// Release frees the lock if it is still owned by this holder.
+func (l *Lock) Release(ctx context.Context) error {
+	start := time.Now()
+	n, err := releaseLockScript.Run(ctx, RedisClient, []string{l.key}, l.token).Int()
+	metrics.RedisOperationDuration.WithLabelValues("lock_release").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("lock_release").Inc()
+		return NewCacheError("lock_release", err, true)
+	}
+	if n == 0 {
+		return ErrLockLost
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// LoginLockRemaining returns how long sign-in stays locked for the most restricted of the given
+// subjects, such as an email and a client IP, or zero when none is locked.
+func LoginLockRemaining(ctx context.Context, subjects ...string) (time.Duration, error) {
+	start := time.Now()
+	pipe := RedisClient.Pipeline()
+	cmds := make([]interface{ Val() time.Duration }, 0, len(subjects))
+	for _, subject := range subjects {
+		cmds = append(cmds, pipe.PTTL(ctx, LoginLockKey(subject)))
+	}
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("login_lock_remaining").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("login_lock_remaining").Inc()
+		return 0, NewCacheError("login_lock_remaining", err, true)
+	}
+	var remaining time.Duration
+	for _, cmd := range cmds {
+		// Missing keys report a negative TTL
+		remaining = max(remaining, cmd.Val())
+	}
+	return remaining, nil
+}
+
+// RecordLoginFailure counts a failed sign-in for subject and returns the failures within window of
+// each other.
+func RecordLoginFailure(ctx context.Context, subject string, window time.Duration) (int64, error) {
+	start := time.Now()
+	pipe := RedisClient.TxPipeline()
+	incr := pipe.Incr(ctx, LoginFailuresKey(subject))
+	pipe.Expire(ctx, LoginFailuresKey(subject), window)
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("record_login_failure").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("record_login_failure").Inc()
+		return 0, NewCacheError("record_login_failure", err, true)
+	}
+	return incr.Val(), nil
+}
+
+// LockLogin locks sign-in for subject and starts counting its failures afresh. Each lockout within
+// history of the previous one lasts twice as long, from base up to limit. It returns the lockout.
+func LockLogin(ctx context.Context, subject string, base, limit, history time.Duration) (time.Duration, error) {
+	start := time.Now()
+	pipe := RedisClient.TxPipeline()
+	lockouts := pipe.Incr(ctx, LoginLockoutsKey(subject))
+	pipe.Expire(ctx, LoginLockoutsKey(subject), history)
+	pipe.Del(ctx, LoginFailuresKey(subject))
+	if _, err := pipe.Exec(ctx); err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("lock_login").Inc()
+		return 0, NewCacheError("lock_login", err, true)
+	}
+
+	lockout := base
+	for n := lockouts.Val(); n > 1 && lockout < limit; n-- {
+		lockout *= 2
+	}
+	lockout = min(lockout, limit)
+	err := RedisClient.Set(ctx, LoginLockKey(subject), 1, lockout).Err()
+	metrics.RedisOperationDuration.WithLabelValues("lock_login").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("lock_login").Inc()
+		return 0, NewCacheError("lock_login", err, true)
+	}
+	return lockout, nil
+}
+
+// ClearLoginFailures forgets the failed sign-ins and lockouts of subject after a successful sign-in.
+func ClearLoginFailures(ctx context.Context, subject string) error {
+	start := time.Now()
+	err := RedisClient.Del(ctx, LoginFailuresKey(subject), LoginLockoutsKey(subject)).Err()
+	metrics.RedisOperationDuration.WithLabelValues("clear_login_failures").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("clear_login_failures").Inc()
+		return NewCacheError("clear_login_failures", err, true)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetMarketStats returns the cached statistics of a zip code, or nil when they aren't cached.
+func GetMarketStats(ctx context.Context, zipCode string) ([]byte, error) {
+	start := time.Now()
+	data, err := RedisClient.Get(ctx, MarketStatsKey(zipCode)).Bytes()
+	metrics.RedisOperationDuration.WithLabelValues("get_market_stats").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_market_stats").Inc()
+		return nil, NewCacheError("get_market_stats", err, true)
+	}
+	return data, nil
+}
+
+// SetMarketStats caches the statistics of a zip code for ttl and adds the zip to the set refreshed
+// nightly.
+func SetMarketStats(ctx context.Context, zipCode string, data []byte, ttl time.Duration) error {
+	start := time.Now()
+	pipe := RedisClient.TxPipeline()
+	pipe.Set(ctx, MarketStatsKey(zipCode), data, ttl)
+	pipe.SAdd(ctx, MarketStatsZipsKey(), zipCode)
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("set_market_stats").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_market_stats").Inc()
+		return NewCacheError("set_market_stats", err, true)
+	}
+	return nil
+}
+
+// MarketStatsZips returns the zip codes whose statistics have been requested since the last flush.
+func MarketStatsZips(ctx context.Context) ([]string, error) {
+	start := time.Now()
+	zips, err := RedisClient.SMembers(ctx, MarketStatsZipsKey()).Result()
+	metrics.RedisOperationDuration.WithLabelValues("market_stats_zips").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("market_stats_zips").Inc()
+		return nil, NewCacheError("market_stats_zips", err, true)
+	}
+	return zips, nil
+}
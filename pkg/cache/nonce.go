@@ -0,0 +1,21 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// ClaimNonce records a partner's request nonce for ttl. It returns false if the nonce was already
+// claimed, meaning the request is a replay.
+func ClaimNonce(ctx context.Context, keyID, nonce string, ttl time.Duration) (bool, error) {
+	start := time.Now()
+	ok, err := RedisClient.SetNX(ctx, NonceKey(keyID, nonce), 1, ttl).Result()
+	metrics.RedisOperationDuration.WithLabelValues("claim_nonce").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("claim_nonce").Inc()
+		return false, NewCacheError("claim_nonce", err, true)
+	}
+	return ok, nil
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// StorePasswordResetToken saves a reset token hash for userID for ttl, invalidating any reset token
+// the user was sent before.
+func StorePasswordResetToken(ctx context.Context, userID, tokenHash string, ttl time.Duration) error {
+	start := time.Now()
+	previous, err := RedisClient.GetSet(ctx, PasswordResetUserKey(userID), tokenHash).Result()
+	if err != nil && err != redis.Nil {
+		metrics.RedisErrorsTotal.WithLabelValues("store_password_reset").Inc()
+		return NewCacheError("store_password_reset", err, true)
+	}
+	pipe := RedisClient.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, PasswordResetKey(previous))
+	}
+	pipe.Expire(ctx, PasswordResetUserKey(userID), ttl)
+	pipe.Set(ctx, PasswordResetKey(tokenHash), userID, ttl)
+	_, err = pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("store_password_reset").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("store_password_reset").Inc()
+		return NewCacheError("store_password_reset", err, true)
+	}
+	return nil
+}
+
+// ConsumePasswordResetToken deletes a reset token and returns the user it was issued to, or "" if the
+// token is unknown, expired or already used.
+func ConsumePasswordResetToken(ctx context.Context, tokenHash string) (string, error) {
+	start := time.Now()
+	userID, err := RedisClient.GetDel(ctx, PasswordResetKey(tokenHash)).Result()
+	metrics.RedisOperationDuration.WithLabelValues("consume_password_reset").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("consume_password_reset").Inc()
+		return "", NewCacheError("consume_password_reset", err, true)
+	}
+	if err := RedisClient.Del(ctx, PasswordResetUserKey(userID)).Err(); err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("consume_password_reset").Inc()
+	}
+	return userID, nil
+}
+
+// ClaimPasswordResetSlot allows one reset email per user per cooldown. It returns false while an
+// earlier email's cooldown is still running.
+func ClaimPasswordResetSlot(ctx context.Context, userID string, cooldown time.Duration) (bool, error) {
+	start := time.Now()
+	ok, err := RedisClient.SetNX(ctx, PasswordResetCooldownKey(userID), 1, cooldown).Result()
+	metrics.RedisOperationDuration.WithLabelValues("claim_password_reset_slot").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("claim_password_reset_slot").Inc()
+		return false, NewCacheError("claim_password_reset_slot", err, true)
+	}
+	return ok, nil
+}
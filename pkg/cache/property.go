@@ -9,10 +9,10 @@ import (
 
 )
 
-// add a cache key to the set of keys associated with a property ID.
-func AddCacheKeyToPropertySet(ctx context.Context, propertyID, cacheKey string) error {
+// add a cache key to the set of keys associated with an organization's property ID.
+func AddCacheKeyToPropertySet(ctx context.Context, orgID, propertyID, cacheKey string) error {
 	start := time.Now()
-	setKey := PropertyKeysSetKey(propertyID)
+	setKey := PropertyKeysSetKey(orgID, propertyID)
 	_, err := RedisClient.SAdd(ctx, setKey, cacheKey).Result()
 	duration := time.Since(start).Seconds()
 	metrics.RedisOperationDuration.WithLabelValues("sadd").Observe(duration)
@@ -24,10 +24,10 @@ func AddCacheKeyToPropertySet(ctx context.Context, propertyID, cacheKey string)
 	return nil
 }
 
-// retrieve all cache keys associated with a property ID.
-func GetCacheKeysForProperty(ctx context.Context, propertyID string) ([]string, error) {
+// retrieve all cache keys associated with an organization's property ID.
+func GetCacheKeysForProperty(ctx context.Context, orgID, propertyID string) ([]string, error) {
 	start := time.Now()
-	setKey := PropertyKeysSetKey(propertyID)
+	setKey := PropertyKeysSetKey(orgID, propertyID)
 	cacheKeys, err := RedisClient.SMembers(ctx, setKey).Result()
 	duration := time.Since(start).Seconds()
 	metrics.RedisOperationDuration.WithLabelValues("smembers").Observe(duration)
@@ -39,10 +39,20 @@ func GetCacheKeysForProperty(ctx context.Context, propertyID string) ([]string,
 	return cacheKeys, nil
 }
 
-// invalidate all cache keys associated with a property ID using a Lua script.
-func InvalidatePropertyCacheKeys(ctx context.Context, propertyID string) error {
+// invalidate all cache keys associated with an organization's property ID using a Lua script. On Redis Cluster the
+// keys are spread over other slots than the set, so they are looked up and deleted slot by slot instead.
+func InvalidatePropertyCacheKeys(ctx context.Context, orgID, propertyID string) error {
 	start := time.Now()
-	_, err := invalidatePropertyCacheScript.Run(ctx, RedisClient, []string{}, propertyID).Result()
+	setKey := PropertyKeysSetKey(orgID, propertyID)
+	var err error
+	if isCluster() {
+		var cacheKeys []string
+		if cacheKeys, err = RedisClient.SMembers(ctx, setKey).Result(); err == nil {
+			_, err = UnlinkKeys(ctx, append(cacheKeys, setKey))
+		}
+	} else {
+		err = invalidatePropertyCacheScript.Run(ctx, RedisClient, []string{setKey}).Err()
+	}
 	duration := time.Since(start).Seconds()
 	metrics.RedisOperationDuration.WithLabelValues("invalidate_cache").Observe(duration)
 	if err != nil {
@@ -0,0 +1,32 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// RecordPropertyHit counts a read of the property with the given ID, for picking what to warm on startup.
+func RecordPropertyHit(ctx context.Context, propertyID string) error {
+	start := time.Now()
+	err := RedisClient.ZIncrBy(ctx, PropertyHitsKey(), 1, propertyID).Err()
+	metrics.RedisOperationDuration.WithLabelValues("record_property_hit").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("record_property_hit").Inc()
+		return NewCacheError("record_property_hit", err, true)
+	}
+	return nil
+}
+
+// TopPropertyHits returns the IDs of the n most-read properties, most-read first.
+func TopPropertyHits(ctx context.Context, n int) ([]string, error) {
+	start := time.Now()
+	ids, err := RedisClient.ZRevRange(ctx, PropertyHitsKey(), 0, int64(n-1)).Result()
+	metrics.RedisOperationDuration.WithLabelValues("top_property_hits").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("top_property_hits").Inc()
+		return nil, NewCacheError("top_property_hits", err, true)
+	}
+	return ids, nil
+}
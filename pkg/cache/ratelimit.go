@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// RateLimitResult describes a sliding window after a request was counted against it.
+type RateLimitResult struct {
+	Allowed bool
+	Count   int
+	Reset   time.Time // when the oldest request leaves the window
+}
+
+// AllowRequest counts a request in the sliding window at key if fewer than limit requests were made
+// within window. Windows are shared by every API instance.
+func AllowRequest(ctx context.Context, key string, limit int, window time.Duration) (*RateLimitResult, error) {
+	member, err := windowMember()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	start := time.Now()
+	values, err := slidingWindowScript.Run(ctx, RedisClient, []string{key}, now.UnixMilli(), window.Milliseconds(), limit, member).Int64Slice()
+	metrics.RedisOperationDuration.WithLabelValues("rate_limit_allow").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("rate_limit_allow").Inc()
+		return nil, NewCacheError("rate_limit_allow", err, true)
+	}
+	return &RateLimitResult{
+		Allowed: values[0] == 1,
+		Count:   int(values[1]),
+		Reset:   time.UnixMilli(values[2]),
+	}, nil
+}
+
+// ChargeRequests adds n more requests to the sliding window at key without checking the limit.
+func ChargeRequests(ctx context.Context, key string, n int, window time.Duration) error {
+	member, err := windowMember()
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	err = chargeWindowScript.Run(ctx, RedisClient, []string{key}, time.Now().UnixMilli(), window.Milliseconds(), n, member).Err()
+	metrics.RedisOperationDuration.WithLabelValues("rate_limit_charge").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("rate_limit_charge").Inc()
+		return NewCacheError("rate_limit_charge", err, true)
+	}
+	return nil
+}
+
+// windowMember returns a unique sorted-set member, so requests in the same millisecond are all counted.
+func windowMember() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + hex.EncodeToString(buf), nil
+}
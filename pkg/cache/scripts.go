@@ -8,28 +8,42 @@ import (
 var (
 	setSearchResultScript        *redis.Script
 	invalidatePropertyCacheScript *redis.Script
+	acquireLockScript             *redis.Script
+	extendLockScript              *redis.Script
+	releaseLockScript             *redis.Script
+	slidingWindowScript           *redis.Script
+	chargeWindowScript            *redis.Script
+	migrateValueScript            *redis.Script
+	enqueueJobScript              *redis.Script
+	claimJobScript                *redis.Script
+	promoteJobsScript             *redis.Script
+	retryJobScript                *redis.Script
+	buryJobScript                 *redis.Script
 )
 
 func init() {
-	// store search results and associates the search key with property IDs.
+	// store search results and associates the search key with property IDs. KEYS holds the search key
+	// (ARGV[1]) and/or property key sets, so the script can run once per cluster hash slot.
 	setSearchResultScript = redis.NewScript(`
 		local search_key = ARGV[1]
 		local property_ids_json = ARGV[2]
 		local search_expiration = tonumber(ARGV[3])
-		redis.call('SET', search_key, property_ids_json)
-		redis.call('EXPIRE', search_key, search_expiration)
-		for i = 4, #ARGV do
-			local property_id = ARGV[i]
-			local set_key = 'property:keys:' .. property_id
-			redis.call('SADD', set_key, search_key)
-			redis.call('EXPIRE', set_key, 3600)
+		for i = 1, #KEYS do
+			if KEYS[i] == search_key then
+				redis.call('SET', search_key, property_ids_json)
+				redis.call('EXPIRE', search_key, search_expiration)
+			else
+				redis.call('SADD', KEYS[i], search_key)
+				redis.call('EXPIRE', KEYS[i], 3600)
+			end
 		end
 		return 1
 	`)
 
-	// remove all cache keys associated with a property.
+	// remove all cache keys associated with a property; KEYS[1] is the property's key set. Only usable
+	// where the listed keys are reachable from the set's node, i.e. not on Redis Cluster.
 	invalidatePropertyCacheScript = redis.NewScript(`
-		local set_key = 'property:keys:' .. ARGV[1]
+		local set_key = KEYS[1]
 		local cache_keys = redis.call('SMEMBERS', set_key)
 		if #cache_keys > 0 then
 			redis.call('DEL', unpack(cache_keys))
@@ -37,4 +51,129 @@ func init() {
 		redis.call('DEL', set_key)
 		return 1
 	`)
+
+	// take a lock (KEYS[1]) with the caller's token for ARGV[2] ms and hand out its next fencing token,
+	// counted in KEYS[2]; returns 0 if the lock is held. The counter expires after ARGV[4] ms unused, and
+	// the clock in ARGV[3], in ms, keeps tokens increasing once it has.
+	acquireLockScript = redis.NewScript(`
+		if not redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+			return 0
+		end
+		local fence = math.max(tonumber(redis.call('GET', KEYS[2]) or '0') + 1, tonumber(ARGV[3]))
+		redis.call('SET', KEYS[2], string.format('%d', fence), 'PX', ARGV[4])
+		return fence
+	`)
+
+	// extend a lock's expiry only while it is still owned by the caller's token.
+	extendLockScript = redis.NewScript(`
+		if redis.call('GET', KEYS[1]) == ARGV[1] then
+			return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+		end
+		return 0
+	`)
+
+	// delete a lock only while it is still owned by the caller's token.
+	releaseLockScript = redis.NewScript(`
+		if redis.call('GET', KEYS[1]) == ARGV[1] then
+			return redis.call('DEL', KEYS[1])
+		end
+		return 0
+	`)
+
+	// count a request in a sliding window log if the window still has room; returns whether it was
+	// allowed, the window's count and when its oldest entry expires, in milliseconds.
+	slidingWindowScript = redis.NewScript(`
+		local now = tonumber(ARGV[1])
+		local window = tonumber(ARGV[2])
+		local limit = tonumber(ARGV[3])
+		redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+		local count = redis.call('ZCARD', KEYS[1])
+		local allowed = 0
+		if count < limit then
+			redis.call('ZADD', KEYS[1], now, ARGV[4])
+			count = count + 1
+			allowed = 1
+		end
+		redis.call('PEXPIRE', KEYS[1], window)
+		local reset = now + window
+		local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+		if #oldest > 0 then
+			reset = tonumber(oldest[2]) + window
+		end
+		return {allowed, count, reset}
+	`)
+
+	// add extra entries to a sliding window log, charging an expensive request more than one hit.
+	chargeWindowScript = redis.NewScript(`
+		local now = tonumber(ARGV[1])
+		for i = 1, tonumber(ARGV[3]) do
+			redis.call('ZADD', KEYS[1], now, ARGV[4] .. ':' .. i)
+		end
+		redis.call('PEXPIRE', KEYS[1], ARGV[2])
+		return 1
+	`)
+
+	// replace a value re-encoded in another format, keeping its TTL, unless it changed in the meantime.
+	migrateValueScript = redis.NewScript(`
+		if redis.call('GET', KEYS[1]) == ARGV[1] then
+			redis.call('SET', KEYS[1], ARGV[2], 'KEEPTTL')
+			return 1
+		end
+		return 0
+	`)
+
+	// store a job and append it to its queue. KEYS[1] is the job hash, KEYS[2] the queue and the optional
+	// KEYS[3] a uniqueness key: while it holds a pending job's ID, that ID is returned instead.
+	enqueueJobScript = redis.NewScript(`
+		if #KEYS == 3 then
+			local existing = redis.call('GET', KEYS[3])
+			if existing then
+				return existing
+			end
+			redis.call('SET', KEYS[3], ARGV[1], 'PX', ARGV[2])
+		end
+		redis.call('HSET', KEYS[1], unpack(ARGV, 3))
+		redis.call('RPUSH', KEYS[2], ARGV[1])
+		return ARGV[1]
+	`)
+
+	// pop the next queued job ID and record it as in flight until the lease deadline in ARGV[1].
+	claimJobScript = redis.NewScript(`
+		local id = redis.call('LPOP', KEYS[1])
+		if not id then
+			return false
+		end
+		redis.call('ZADD', KEYS[2], ARGV[1], id)
+		return id
+	`)
+
+	// move retries that are due (KEYS[1]) and jobs whose lease expired (KEYS[2]) back onto the queue.
+	promoteJobsScript = redis.NewScript(`
+		local moved = 0
+		for i = 1, 2 do
+			local ids = redis.call('ZRANGEBYSCORE', KEYS[i], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+			for _, id in ipairs(ids) do
+				redis.call('ZREM', KEYS[i], id)
+				redis.call('RPUSH', KEYS[3], id)
+			end
+			moved = moved + #ids
+		end
+		return moved
+	`)
+
+	// schedule an in-flight job for another attempt, unless its lease was already taken back.
+	retryJobScript = redis.NewScript(`
+		if redis.call('ZREM', KEYS[1], ARGV[1]) == 1 then
+			return redis.call('ZADD', KEYS[2], ARGV[2], ARGV[1])
+		end
+		return 0
+	`)
+
+	// move a job out of flight onto the bounded dead-letter list.
+	buryJobScript = redis.NewScript(`
+		redis.call('ZREM', KEYS[1], ARGV[1])
+		redis.call('LPUSH', KEYS[2], ARGV[1])
+		redis.call('LTRIM', KEYS[2], 0, tonumber(ARGV[2]) - 1)
+		return 1
+	`)
 }
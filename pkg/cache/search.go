@@ -11,9 +11,9 @@ import (
 
 )
 
-// SetSearchResult caches a list of property IDs for a search key with an expiration time.
-// It also associates the search key with each property ID for invalidation purposes.
-func SetSearchResult(ctx context.Context, key string, propertyIDs []string, expiration time.Duration) error {
+// SetSearchResult caches a list of an organization's property IDs for a search key with an expiration
+// time. It also associates the search key with each property ID for invalidation purposes.
+func SetSearchResult(ctx context.Context, key, orgID string, propertyIDs []string, expiration time.Duration) error {
 	start := time.Now()
 	propertyIDsJSON, err := json.Marshal(propertyIDs)
 	if err != nil {
@@ -22,12 +22,12 @@ func SetSearchResult(ctx context.Context, key string, propertyIDs []string, expi
 		return NewCacheError("set_search_marshal", err, true)
 	}
 
-	args := []interface{}{key, string(propertyIDsJSON), strconv.Itoa(int(expiration.Seconds()))}
+	keys := []string{key}
 	for _, id := range propertyIDs {
-		args = append(args, id)
+		keys = append(keys, PropertyKeysSetKey(orgID, id))
 	}
 
-	_, err = setSearchResultScript.Run(ctx, RedisClient, []string{}, args...).Result()
+	err = runScriptBySlot(ctx, setSearchResultScript, keys, key, string(propertyIDsJSON), strconv.Itoa(int(expiration.Seconds())))
 	duration := time.Since(start).Seconds()
 	metrics.RedisOperationDuration.WithLabelValues("set_search_result").Observe(duration)
 	if err != nil {
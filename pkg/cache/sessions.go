@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// sessionLastUsedTTL keeps a session's last request time. The stored session is updated on every
+// refresh, so this only has to outlive the access tokens issued in between.
+const sessionLastUsedTTL = 48 * time.Hour
+
+// DenySession signs out the session with the given ID, rejecting its access tokens until they would
+// have expired anyway.
+func DenySession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	start := time.Now()
+	err := RedisClient.Set(ctx, SessionDenylistKey(sessionID), 1, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("deny_session").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("deny_session").Inc()
+		return NewCacheError("deny_session", err, true)
+	}
+	return nil
+}
+
+// UseSession records a request made with the session's access token and reports whether the session
+// has been signed out, in one round trip.
+func UseSession(ctx context.Context, sessionID string, at time.Time) (denied bool, err error) {
+	start := time.Now()
+	pipe := RedisClient.Pipeline()
+	existsCmd := pipe.Exists(ctx, SessionDenylistKey(sessionID))
+	pipe.Set(ctx, SessionLastUsedKey(sessionID), at.Unix(), sessionLastUsedTTL)
+	_, err = pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("use_session").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("use_session").Inc()
+		return false, NewCacheError("use_session", err, true)
+	}
+	return existsCmd.Val() > 0, nil
+}
+
+// SessionsLastUsed returns when each of the given sessions last made a request, for those seen recently.
+func SessionsLastUsed(ctx context.Context, sessionIDs []string) (map[string]time.Time, error) {
+	lastUsed := make(map[string]time.Time, len(sessionIDs))
+	if len(sessionIDs) == 0 {
+		return lastUsed, nil
+	}
+	keys := make([]string, 0, len(sessionIDs))
+	for _, id := range sessionIDs {
+		keys = append(keys, SessionLastUsedKey(id))
+	}
+
+	start := time.Now()
+	values, err := RedisClient.MGet(ctx, keys...).Result()
+	metrics.RedisOperationDuration.WithLabelValues("sessions_last_used").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("sessions_last_used").Inc()
+		return nil, NewCacheError("sessions_last_used", err, true)
+	}
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if seconds, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			lastUsed[sessionIDs[i]] = time.Unix(seconds, 0).UTC()
+		}
+	}
+	return lastUsed, nil
+}
@@ -0,0 +1,13 @@
+package cache
+
+// Cache states reported for property lookups in the cache_state request metadata.
+const (
+	StateFresh = "fresh"
+	StateStale = "stale" // served immediately while a background refresh runs
+	StateMiss  = "miss"
+)
+
+// RefreshLockName names the lock held by the one instance revalidating a stale key.
+func RefreshLockName(key string) string {
+	return "refresh:" + key
+}
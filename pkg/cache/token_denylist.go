@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// DenyToken revokes the access token with the given jti until it would have expired anyway.
+func DenyToken(ctx context.Context, jti string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	start := time.Now()
+	err := RedisClient.Set(ctx, TokenDenylistKey(jti), 1, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("deny_token").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("deny_token").Inc()
+		return NewCacheError("deny_token", err, true)
+	}
+	return nil
+}
+
+// IsTokenDenied reports whether the access token with the given jti has been revoked.
+func IsTokenDenied(ctx context.Context, jti string) (bool, error) {
+	start := time.Now()
+	n, err := RedisClient.Exists(ctx, TokenDenylistKey(jti)).Result()
+	metrics.RedisOperationDuration.WithLabelValues("is_token_denied").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("is_token_denied").Inc()
+		return false, NewCacheError("is_token_denied", err, true)
+	}
+	return n > 0, nil
+}
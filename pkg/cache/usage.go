@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+)
+
+// usageRetention keeps a day's counters long enough for the rollup after midnight to read them.
+const usageRetention = 72 * time.Hour
+
+// IncrementUsage adds n to a subject's count of a usage metric on the given UTC day and returns the
+// day's total so far.
+func IncrementUsage(ctx context.Context, metric, day, subject string, n int64) (int64, error) {
+	key := UsageKey(metric, day)
+	start := time.Now()
+	pipe := RedisClient.TxPipeline()
+	incr := pipe.HIncrBy(ctx, key, subject, n)
+	pipe.Expire(ctx, key, usageRetention)
+	_, err := pipe.Exec(ctx)
+	metrics.RedisOperationDuration.WithLabelValues("increment_usage").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("increment_usage").Inc()
+		return 0, NewCacheError("increment_usage", err, true)
+	}
+	return incr.Val(), nil
+}
+
+// UsageCounts returns every subject's count of a usage metric on the given UTC day.
+func UsageCounts(ctx context.Context, metric, day string) (map[string]int64, error) {
+	start := time.Now()
+	values, err := RedisClient.HGetAll(ctx, UsageKey(metric, day)).Result()
+	metrics.RedisOperationDuration.WithLabelValues("usage_counts").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("usage_counts").Inc()
+		return nil, NewCacheError("usage_counts", err, true)
+	}
+
+	counts := make(map[string]int64, len(values))
+	for subject, raw := range values {
+		count, _ := strconv.ParseInt(raw, 10, 64)
+		counts[subject] = count
+	}
+	return counts, nil
+}
@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GetWalkability returns the cached walkability lookup for a point, or nil when it hasn't been looked up
+// recently.
+func GetWalkability(ctx context.Context, point string) ([]byte, error) {
+	start := time.Now()
+	data, err := RedisClient.Get(ctx, WalkabilityKey(point)).Bytes()
+	metrics.RedisOperationDuration.WithLabelValues("get_walkability").Observe(time.Since(start).Seconds())
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("get_walkability").Inc()
+		return nil, NewCacheError("get_walkability", err, true)
+	}
+	return data, nil
+}
+
+// SetWalkability caches the walkability lookup for a point for ttl.
+func SetWalkability(ctx context.Context, point string, data []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := RedisClient.Set(ctx, WalkabilityKey(point), data, ttl).Err()
+	metrics.RedisOperationDuration.WithLabelValues("set_walkability").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.RedisErrorsTotal.WithLabelValues("set_walkability").Inc()
+		return NewCacheError("set_walkability", err, true)
+	}
+	return nil
+}
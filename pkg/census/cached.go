@@ -0,0 +1,89 @@
+package census
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// cachedTract is a cached point lookup; an empty GEOID records that no tract contains the point.
+type cachedTract struct {
+	GEOID string `json:"geoid"`
+}
+
+// cachedStats is a cached tract lookup; nil Stats record that the survey has no row for the tract.
+type cachedStats struct {
+	Stats *TractStats `json:"stats"`
+}
+
+// CachedProvider remembers a provider's answers in Redis. Tract boundaries and a survey year's
+// estimates don't change once published, so entries can live for a long time; stats are keyed by
+// survey year so configuring a newer one fetches them afresh. Cache failures fall through to the
+// provider.
+type CachedProvider struct {
+	next Provider
+	year int
+	ttl  time.Duration
+}
+
+func NewCachedProvider(next Provider, year int, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{next: next, year: year, ttl: ttl}
+}
+
+func (p *CachedProvider) TractForPoint(ctx context.Context, lat, lng float64) (string, error) {
+	key := cache.CensusTractKey(fmt.Sprintf("%.5f,%.5f", lat, lng))
+	var cached cachedTract
+	if p.read(ctx, key, &cached) {
+		if cached.GEOID == "" {
+			return "", ErrNotFound
+		}
+		return cached.GEOID, nil
+	}
+
+	geoid, err := p.next.TractForPoint(ctx, lat, lng)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+	p.write(ctx, key, &cachedTract{GEOID: geoid})
+	return geoid, err
+}
+
+func (p *CachedProvider) TractStats(ctx context.Context, geoid string) (*TractStats, error) {
+	key := cache.CensusStatsKey(p.year, geoid)
+	var cached cachedStats
+	if p.read(ctx, key, &cached) {
+		if cached.Stats == nil {
+			return nil, ErrNotFound
+		}
+		return cached.Stats, nil
+	}
+
+	stats, err := p.next.TractStats(ctx, geoid)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	p.write(ctx, key, &cachedStats{Stats: stats})
+	return stats, err
+}
+
+// read decodes the cached entry at key into v, reporting whether there was one.
+func (p *CachedProvider) read(ctx context.Context, key string, v interface{}) bool {
+	data, err := cache.GetCensusData(ctx, key)
+	if err != nil {
+		logger.GlobalLogger.Warnf("Failed to read cached census data: key=%s, error=%v", key, err)
+		return false
+	}
+	return data != nil && json.Unmarshal(data, v) == nil
+}
+
+func (p *CachedProvider) write(ctx context.Context, key string, v interface{}) {
+	data, _ := json.Marshal(v)
+	if err := cache.SetCensusData(ctx, key, data, p.ttl); err != nil {
+		logger.GlobalLogger.Warnf("Failed to cache census data: key=%s, error=%v", key, err)
+	}
+}
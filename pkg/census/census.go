@@ -0,0 +1,69 @@
+package census
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+)
+
+// ErrNotFound is wrapped when no census tract contains a point, or the survey has no row for a tract.
+var ErrNotFound = errors.New("census tract not found")
+
+// TractStats are American Community Survey 5-year estimates for a census tract. Estimates the
+// survey suppresses or can't compute for the tract are nil.
+type TractStats struct {
+	GEOID string `json:"geoid"`
+	Name  string `json:"name"`
+	Year  int    `json:"year"`
+
+	Population            *int     `json:"population,omitempty"`
+	MedianAge             *float64 `json:"medianAge,omitempty"`
+	Households            *int     `json:"households,omitempty"`
+	MedianHouseholdIncome *int     `json:"medianHouseholdIncome,omitempty"`
+	PerCapitaIncome       *int     `json:"perCapitaIncome,omitempty"`
+	// PovertyUniverse is the population whose poverty status is determined; BelowPoverty is the part
+	// of it below the poverty level
+	PovertyUniverse *int `json:"povertyUniverse,omitempty"`
+	BelowPoverty    *int `json:"belowPoverty,omitempty"`
+	HousingUnits    *int `json:"housingUnits,omitempty"`
+	OccupiedUnits   *int `json:"occupiedUnits,omitempty"`
+	VacantUnits     *int `json:"vacantUnits,omitempty"`
+	OwnerOccupied   *int `json:"ownerOccupied,omitempty"`
+	RenterOccupied  *int `json:"renterOccupied,omitempty"`
+	MedianHomeValue *int `json:"medianHomeValue,omitempty"`
+	MedianGrossRent *int `json:"medianGrossRent,omitempty"`
+	MedianYearBuilt *int `json:"medianYearBuilt,omitempty"`
+}
+
+// Provider resolves census tracts and their statistics. Tracts are identified by their 11-digit
+// GEOID: 2 digits of state, 3 of county and 6 of tract.
+type Provider interface {
+	TractForPoint(ctx context.Context, lat, lng float64) (string, error)
+	TractStats(ctx context.Context, geoid string) (*TractStats, error)
+}
+
+// New builds the Census API client, wrapped in the Redis result cache. Returns nil when census
+// demographics are turned off.
+func New(cfg *config.Config) Provider {
+	settings := cfg.Census
+	if !settings.Enabled {
+		return nil
+	}
+	client := NewAPIClient(settings.BaseURL, settings.GeocoderURL, settings.APIKey, settings.Year, time.Duration(settings.TimeoutSeconds)*time.Second)
+	return NewCachedProvider(client, settings.Year, time.Duration(settings.CacheTTLHours)*time.Hour)
+}
+
+// IsGEOID reports whether id is a full tract GEOID rather than a tract number alone.
+func IsGEOID(id string) bool {
+	if len(id) != 11 {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
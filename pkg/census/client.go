@@ -0,0 +1,172 @@
+package census
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// acsVariables are the ACS 5-year detailed table estimates requested for a tract, with where each
+// one goes in TractStats.
+var acsVariables = []struct {
+	name  string
+	store func(*TractStats, float64)
+}{
+	{"B01003_001E", func(s *TractStats, v float64) { s.Population = intValue(v) }},
+	{"B01002_001E", func(s *TractStats, v float64) { s.MedianAge = &v }},
+	{"B11001_001E", func(s *TractStats, v float64) { s.Households = intValue(v) }},
+	{"B19013_001E", func(s *TractStats, v float64) { s.MedianHouseholdIncome = intValue(v) }},
+	{"B19301_001E", func(s *TractStats, v float64) { s.PerCapitaIncome = intValue(v) }},
+	{"B17001_001E", func(s *TractStats, v float64) { s.PovertyUniverse = intValue(v) }},
+	{"B17001_002E", func(s *TractStats, v float64) { s.BelowPoverty = intValue(v) }},
+	{"B25001_001E", func(s *TractStats, v float64) { s.HousingUnits = intValue(v) }},
+	{"B25002_002E", func(s *TractStats, v float64) { s.OccupiedUnits = intValue(v) }},
+	{"B25002_003E", func(s *TractStats, v float64) { s.VacantUnits = intValue(v) }},
+	{"B25003_002E", func(s *TractStats, v float64) { s.OwnerOccupied = intValue(v) }},
+	{"B25003_003E", func(s *TractStats, v float64) { s.RenterOccupied = intValue(v) }},
+	{"B25077_001E", func(s *TractStats, v float64) { s.MedianHomeValue = intValue(v) }},
+	{"B25064_001E", func(s *TractStats, v float64) { s.MedianGrossRent = intValue(v) }},
+	{"B25035_001E", func(s *TractStats, v float64) { s.MedianYearBuilt = intValue(v) }},
+}
+
+func intValue(v float64) *int {
+	n := int(v)
+	return &n
+}
+
+// APIClient reads tract statistics from the Census Data API and finds the tract holding a point
+// with the Census Geocoder.
+type APIClient struct {
+	baseURL     string
+	geocoderURL string
+	apiKey      string
+	year        int
+	client      *http.Client
+}
+
+func NewAPIClient(baseURL, geocoderURL, apiKey string, year int, timeout time.Duration) *APIClient {
+	return &APIClient{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		geocoderURL: strings.TrimRight(geocoderURL, "/"),
+		apiKey:      apiKey,
+		year:        year,
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+type geographiesResponse struct {
+	Result struct {
+		Geographies map[string][]struct {
+			GEOID string `json:"GEOID"`
+		} `json:"geographies"`
+	} `json:"result"`
+	Errors []string `json:"errors"`
+}
+
+func (c *APIClient) TractForPoint(ctx context.Context, lat, lng float64) (string, error) {
+	query := url.Values{}
+	query.Set("x", strconv.FormatFloat(lng, 'f', 6, 64))
+	query.Set("y", strconv.FormatFloat(lat, 'f', 6, 64))
+	query.Set("benchmark", "Public_AR_Current")
+	query.Set("vintage", "Current_Current")
+	query.Set("layers", "Census Tracts")
+	query.Set("format", "json")
+
+	data, status, err := c.get(ctx, c.geocoderURL+"/geographies/coordinates?"+query.Encode())
+	if err != nil {
+		return "", fmt.Errorf("Census geocoder request failed: %v", err)
+	}
+	if status < 200 || status >= 300 {
+		return "", fmt.Errorf("Census geocoder request failed: status %d: %s", status, strings.TrimSpace(string(data)))
+	}
+	var result geographiesResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("Census geocoder request failed: decode response: %v", err)
+	}
+	if len(result.Errors) > 0 {
+		return "", fmt.Errorf("Census geocoder request failed: %s", strings.Join(result.Errors, "; "))
+	}
+	tracts := result.Result.Geographies["Census Tracts"]
+	if len(tracts) == 0 || !IsGEOID(tracts[0].GEOID) {
+		return "", fmt.Errorf("Census geocoder request failed: %w: lat=%f, lng=%f", ErrNotFound, lat, lng)
+	}
+	return tracts[0].GEOID, nil
+}
+
+func (c *APIClient) TractStats(ctx context.Context, geoid string) (*TractStats, error) {
+	if !IsGEOID(geoid) {
+		return nil, fmt.Errorf("Census data request failed: %w: invalid GEOID %q", ErrNotFound, geoid)
+	}
+	names := []string{"NAME"}
+	for _, variable := range acsVariables {
+		names = append(names, variable.name)
+	}
+	query := url.Values{}
+	query.Set("get", strings.Join(names, ","))
+	query.Set("for", "tract:"+geoid[5:])
+	query.Set("in", "state:"+geoid[:2]+" county:"+geoid[2:5])
+	if c.apiKey != "" {
+		query.Set("key", c.apiKey)
+	}
+
+	data, status, err := c.get(ctx, fmt.Sprintf("%s/%d/acs/acs5?%s", c.baseURL, c.year, query.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("Census data request failed: %v", err)
+	}
+	// The API answers a geography it has no row for with an empty 204
+	if status == http.StatusNoContent {
+		return nil, fmt.Errorf("Census data request failed: %w: geoid=%s", ErrNotFound, geoid)
+	}
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("Census data request failed: status %d: %s", status, strings.TrimSpace(string(data)))
+	}
+
+	// The response is a table: a header row of variable names, then one row per geography
+	var rows [][]*string
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("Census data request failed: decode response: %v", err)
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("Census data request failed: %w: geoid=%s", ErrNotFound, geoid)
+	}
+	columns := make(map[string]string, len(rows[0]))
+	for i, header := range rows[0] {
+		if header != nil && i < len(rows[1]) && rows[1][i] != nil {
+			columns[*header] = *rows[1][i]
+		}
+	}
+
+	stats := &TractStats{GEOID: geoid, Name: columns["NAME"], Year: c.year}
+	for _, variable := range acsVariables {
+		value, err := strconv.ParseFloat(columns[variable.name], 64)
+		// Negative values are annotations, such as -666666666 for an estimate that can't be computed
+		if err != nil || value < 0 {
+			continue
+		}
+		variable.store(stats, value)
+	}
+	return stats, nil
+}
+
+func (c *APIClient) get(ctx context.Context, endpoint string) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, 0, fmt.Errorf("read response: %v", err)
+	}
+	return data, resp.StatusCode, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// tokenRefreshMargin renews the access token this long before it expires, so a request doesn't
+// race its expiry.
+const tokenRefreshMargin = 30 * time.Second
+
+type tokenResponse struct {
+	Token        string `json:"token"`
+	ExpiresIn    string `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// accessToken returns a current access token, refreshing it when it's about to expire or force is
+// set. The refresh token is used first; the client signs in again with its password when there is
+// none or the API no longer accepts it.
+func (c *Client) accessToken(ctx context.Context, force bool) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !force && c.token != "" && time.Now().Add(tokenRefreshMargin).Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+	if c.refreshToken != "" {
+		// Never retried: the API treats a second use of a rotated refresh token as theft and ends the session
+		err := c.authenticate(ctx, "/api/token/refresh", map[string]string{"refresh_token": c.refreshToken}, false)
+		if err == nil {
+			return c.token, nil
+		}
+		if !IsUnauthorized(err) {
+			return "", err
+		}
+		c.refreshToken = ""
+	}
+	if c.email == "" {
+		return "", fmt.Errorf("client has no credentials to sign in with")
+	}
+	if err := c.authenticate(ctx, "/api/auth/login", map[string]string{"email": c.email, "password": c.password}, true); err != nil {
+		return "", err
+	}
+	return c.token, nil
+}
+
+// authenticate exchanges credentials or a refresh token at path for new tokens. The caller holds mu.
+func (c *Client) authenticate(ctx context.Context, path string, body interface{}, retryable bool) error {
+	var tokens tokenResponse
+	err := c.do(ctx, request{method: http.MethodPost, path: path, body: body, retryable: retryable}, &tokens)
+	if err != nil {
+		return err
+	}
+	expiresIn, err := strconv.Atoi(tokens.ExpiresIn)
+	if err != nil {
+		return fmt.Errorf("invalid token expiry %q", tokens.ExpiresIn)
+	}
+	c.token = tokens.Token
+	c.tokenExpiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	if tokens.RefreshToken != "" {
+		c.refreshToken = tokens.RefreshToken
+	}
+	return nil
+}
+
+// Logout signs the client's session out and forgets its tokens. The next authenticated call signs in
+// again.
+func (c *Client) Logout(ctx context.Context) error {
+	c.mu.Lock()
+	token, refreshToken := c.token, c.refreshToken
+	c.token, c.tokenExpiry, c.refreshToken = "", time.Time{}, ""
+	c.mu.Unlock()
+
+	if token == "" {
+		return nil
+	}
+	return c.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/api/logout",
+		body:   map[string]string{"refresh_token": refreshToken},
+		header: http.Header{"Authorization": {"Bearer " + token}},
+	}, nil)
+}
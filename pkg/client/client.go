@@ -0,0 +1,259 @@
+// Package client is a Go SDK for the properties API. It signs in with an email and password, keeps
+// the access token fresh with the refresh token, retries transient failures and returns API errors
+// as *APIError.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures a Client. Only BaseURL is required; without an email and password only
+// endpoints that don't need a signed-in user can be called.
+type Options struct {
+	// BaseURL is where the API is served, e.g. https://api.example.com
+	BaseURL  string
+	Email    string
+	Password string
+	// HTTPClient sends the requests; defaults to a client with a 30 second timeout
+	HTTPClient *http.Client
+	// MaxRetries is how many times a request failing with a network error, 429 or 5xx is retried;
+	// defaults to 3. Set it below zero to disable retries.
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubling for each retry after it unless the
+	// API sends Retry-After; defaults to 500ms.
+	RetryBackoff time.Duration
+	// UserAgent identifies the calling service in the API's session list and logs
+	UserAgent string
+}
+
+// Client calls the properties API. It is safe for concurrent use.
+type Client struct {
+	baseURL      *url.URL
+	email        string
+	password     string
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+	userAgent    string
+
+	mu           sync.Mutex
+	token        string
+	tokenExpiry  time.Time
+	refreshToken string
+}
+
+// New returns a client for the API at opts.BaseURL.
+func New(opts Options) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(opts.BaseURL, "/"))
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid base url: %q", opts.BaseURL)
+	}
+	c := &Client{
+		baseURL:      baseURL,
+		email:        opts.Email,
+		password:     opts.Password,
+		httpClient:   opts.HTTPClient,
+		maxRetries:   opts.MaxRetries,
+		retryBackoff: opts.RetryBackoff,
+		userAgent:    opts.UserAgent,
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if c.maxRetries == 0 {
+		c.maxRetries = 3
+	} else if c.maxRetries < 0 {
+		c.maxRetries = 0
+	}
+	if c.retryBackoff <= 0 {
+		c.retryBackoff = 500 * time.Millisecond
+	}
+	if c.userAgent == "" {
+		c.userAgent = "homeinsight-properties-go-client"
+	}
+	return c, nil
+}
+
+// request describes one API call.
+type request struct {
+	method string
+	path   string // relative to the base URL, may carry a query
+	query  url.Values
+	body   interface{}
+	header http.Header
+	// auth sends the access token, signing in or refreshing first when needed
+	auth bool
+	// retryable calls are safe to send again after a failure, because they are reads or carry an
+	// Idempotency-Key
+	retryable bool
+}
+
+// do sends req and decodes a successful response's JSON body into out, which may be nil. Failed
+// responses are returned as *APIError. A 401 on an authenticated call refreshes the token and tries
+// once more.
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return fmt.Errorf("failed to encode request body: %v", err)
+		}
+	}
+
+	reauthenticated := false
+	for attempt := 0; ; attempt++ {
+		var token string
+		if req.auth {
+			var err error
+			if token, err = c.accessToken(ctx, false); err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.send(ctx, req, body, token)
+		if err != nil {
+			if !req.retryable || attempt >= c.maxRetries || ctx.Err() != nil {
+				return err
+			}
+			if err := sleepContext(ctx, c.backoff(attempt, nil)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusUnauthorized && req.auth && !reauthenticated {
+			drain(resp)
+			reauthenticated = true
+			if _, err := c.accessToken(ctx, true); err != nil {
+				return err
+			}
+			attempt--
+			continue
+		}
+		if isRetryableStatus(resp.StatusCode) && req.retryable && attempt < c.maxRetries {
+			wait := c.backoff(attempt, resp)
+			drain(resp)
+			if err := sleepContext(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+		return decodeResponse(resp, out)
+	}
+}
+
+// send makes one attempt at a request.
+func (c *Client) send(ctx context.Context, req request, body []byte, token string) (*http.Response, error) {
+	target, err := c.baseURL.Parse(c.baseURL.Path + req.path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request path %q: %v", req.path, err)
+	}
+	if len(req.query) > 0 {
+		query := target.Query()
+		for key, values := range req.query {
+			query[key] = values
+		}
+		target.RawQuery = query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target.String(), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	for key, values := range req.header {
+		httpReq.Header[key] = values
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %s %s: %v", req.method, req.path, err)
+	}
+	return resp, nil
+}
+
+// decodeResponse reads a response, decoding a successful one's body into out and a failed one's
+// error envelope into an *APIError.
+func decodeResponse(resp *http.Response, out interface{}) error {
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp, data)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response body: %v", err)
+	}
+	return nil
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusBadGateway ||
+		status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
+
+// backoff is the wait before retry attempt+1: the response's Retry-After when it sends one, otherwise
+// RetryBackoff doubled per attempt with up to 20% jitter.
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	wait := time.Duration(float64(c.retryBackoff) * math.Pow(2, float64(attempt)))
+	return wait + time.Duration(mathrand.Int63n(int64(wait)/5+1))
+}
+
+// drain discards the rest of a response so its connection can be reused.
+func drain(resp *http.Response) {
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newIdempotencyKey returns a random key that makes a POST safe to retry.
+func newIdempotencyKey() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+
+	"homeinsight-properties/internal/errors"
+)
+
+// APIError is a failed API response. Code is one of the errors.ErrCode values; branch on it rather
+// than on Message, which may be reworded.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    interface{}
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("api error: status=%d, code=%s, message=%s, request_id=%s", e.StatusCode, e.Code, e.Message, e.RequestID)
+	}
+	return fmt.Sprintf("api error: status=%d, code=%s, message=%s", e.StatusCode, e.Code, e.Message)
+}
+
+// newAPIError builds the error for a failed response from its error envelope, falling back to the
+// status when the body isn't one, as from a proxy in front of the API.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, RequestID: resp.Header.Get("X-Request-ID")}
+	var envelope errors.ErrorResponse
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.Error.Code != "" {
+		apiErr.Code = envelope.Error.Code
+		apiErr.Message = envelope.Error.Message
+		apiErr.Details = envelope.Error.Details
+		if envelope.Error.RequestID != "" {
+			apiErr.RequestID = envelope.Error.RequestID
+		}
+		return apiErr
+	}
+	apiErr.Message = http.StatusText(resp.StatusCode)
+	return apiErr
+}
+
+// ErrorCode returns the API error code of err, or "" if err isn't an *APIError.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if stderrors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsNotFound reports whether err is an API 404, such as an unknown property.
+func IsNotFound(err error) bool {
+	return statusOf(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is an API 401: missing, expired or revoked credentials.
+func IsUnauthorized(err error) bool {
+	return statusOf(err) == http.StatusUnauthorized
+}
+
+func statusOf(err error) int {
+	var apiErr *APIError
+	if stderrors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
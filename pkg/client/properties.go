@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"homeinsight-properties/internal/models"
+)
+
+// SearchProperty returns the property best matching a free-text address query.
+func (c *Client) SearchProperty(ctx context.Context, query string) (*models.Property, error) {
+	var property models.Property
+	err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/api/properties/property-search",
+		query:     url.Values{"q": {query}},
+		auth:      true,
+		retryable: true,
+	}, &property)
+	if err != nil {
+		return nil, err
+	}
+	return &property, nil
+}
+
+// GetProperty returns the property with the given ID. Use IsNotFound to tell a missing property from
+// other failures.
+func (c *Client) GetProperty(ctx context.Context, id string) (*models.Property, error) {
+	var property models.Property
+	err := c.do(ctx, request{
+		method:    http.MethodGet,
+		path:      "/api/properties/property-detail/" + url.PathEscape(id),
+		auth:      true,
+		retryable: true,
+	}, &property)
+	if err != nil {
+		return nil, err
+	}
+	return &property, nil
+}
+
+// ListOptions narrows and orders ListProperties.
+type ListOptions struct {
+	Filter models.PropertyFilter
+	// Sort is in ?sort= form, e.g. "price:desc,beds"
+	Sort string
+	// PageSize is how many properties each request fetches, up to 100; defaults to the API's 10
+	PageSize int
+}
+
+// values renders the options as list query parameters, naming filters by their form tags.
+func (o ListOptions) values() url.Values {
+	query := url.Values{}
+	filter := reflect.ValueOf(o.Filter)
+	for i := 0; i < filter.NumField(); i++ {
+		name := filter.Type().Field(i).Tag.Get("form")
+		switch field := filter.Field(i); field.Kind() {
+		case reflect.String:
+			if field.String() != "" {
+				query.Set(name, field.String())
+			}
+		case reflect.Pointer:
+			if !field.IsNil() {
+				query.Set(name, strconv.FormatInt(field.Elem().Int(), 10))
+			}
+		}
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	if o.PageSize > 0 {
+		query.Set("limit", strconv.Itoa(o.PageSize))
+	}
+	return query
+}
+
+// ListPropertiesPage returns one page of properties starting at offset.
+func (c *Client) ListPropertiesPage(ctx context.Context, opts ListOptions, offset int) (*models.PaginatedPropertiesResponse, error) {
+	query := opts.values()
+	query.Set("offset", strconv.Itoa(offset))
+	return c.listPage(ctx, "/api/properties", query)
+}
+
+func (c *Client) listPage(ctx context.Context, path string, query url.Values) (*models.PaginatedPropertiesResponse, error) {
+	var page models.PaginatedPropertiesResponse
+	err := c.do(ctx, request{method: http.MethodGet, path: path, query: query, auth: true, retryable: true}, &page)
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ListProperties iterates over every property matching opts, fetching pages as it goes by following
+// each page's next link. Iteration stops at the first error, which is yielded with a zero Property.
+//
+//	for property, err := range c.ListProperties(ctx, opts) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) ListProperties(ctx context.Context, opts ListOptions) iter.Seq2[models.Property, error] {
+	return func(yield func(models.Property, error) bool) {
+		page, err := c.ListPropertiesPage(ctx, opts, 0)
+		for {
+			if err != nil {
+				yield(models.Property{}, err)
+				return
+			}
+			for _, property := range page.Data {
+				if !yield(property, nil) {
+					return
+				}
+			}
+			if page.Metadata.Next == nil || len(page.Data) == 0 {
+				return
+			}
+			page, err = c.listPage(ctx, *page.Metadata.Next, nil)
+		}
+	}
+}
+
+// CreateProperty creates a property and returns it as stored. The request carries idempotencyKey, or
+// a generated one when it is empty, so it is retried safely and a retry never creates a duplicate;
+// pass your own key to make retries across separate calls safe too.
+func (c *Client) CreateProperty(ctx context.Context, property *models.Property, idempotencyKey string) (*models.Property, error) {
+	if idempotencyKey == "" {
+		idempotencyKey = newIdempotencyKey()
+	}
+	var created models.Property
+	err := c.do(ctx, request{
+		method:    http.MethodPost,
+		path:      "/api/properties",
+		body:      property,
+		header:    http.Header{"Idempotency-Key": {idempotencyKey}},
+		auth:      true,
+		retryable: true,
+	}, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
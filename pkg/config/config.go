@@ -1,37 +1,466 @@
 package config
 
 import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"homeinsight-properties/pkg/fieldcrypt"
 
 	"gopkg.in/yaml.v3"
 )
 
+// shareLinkKeyPurpose is the HKDF info label of the share link secret derived from the JWT secret
+// when SHARE_LINK_SECRET isn't set.
+const shareLinkKeyPurpose = "homeinsight-properties share links v1"
+
+// EmbedPartner describes a partner site allowed to embed property widgets. Its widgets show the
+// properties of OrgID, or of the default organization if unset.
+type EmbedPartner struct {
+	Name              string     `yaml:"name"`
+	APIKey            string     `yaml:"api_key"`
+	AllowedOrigins    []string   `yaml:"allowed_origins"`
+	RequestsPerMinute int        `yaml:"requests_per_minute" validate:"gte=0"`
+	OrgID             string     `yaml:"org_id"`
+	Quota             UsageQuota `yaml:"quota"`
+}
+
+// SigningPartner is a server-to-server integration that signs mutation requests with a shared secret.
+type SigningPartner struct {
+	Name   string `yaml:"name"`
+	KeyID  string `yaml:"key_id"`
+	Secret string `yaml:"secret"`
+}
+
+// UsageQuota caps the requests and CoreLogic fetches one organization or API key may make per UTC day.
+// A zero limit leaves it unlimited.
+type UsageQuota struct {
+	DailyRequests       int64 `yaml:"daily_requests" validate:"gte=0"`
+	DailyCoreLogicCalls int64 `yaml:"daily_corelogic_calls" validate:"gte=0"`
+}
+
+// RateLimitRule caps the requests one user and one client IP may make to a route group per window.
+// A zero limit leaves that subject unlimited.
+type RateLimitRule struct {
+	PerUser int `yaml:"per_user" validate:"gte=0"`
+	PerIP   int `yaml:"per_ip" validate:"gte=0"`
+}
+
+// HTTPCacheRule sets the caching headers of a read route's successful responses. Public responses
+// may be stored by a CDN or reverse proxy for SurrogateMaxAgeSeconds; responses to signed-in requests
+// are always private to the client. A MaxAgeSeconds of 0 makes clients revalidate on every use.
+type HTTPCacheRule struct {
+	Public                      bool `yaml:"public"`
+	MaxAgeSeconds               int  `yaml:"max_age_seconds" validate:"gte=0"`
+	SurrogateMaxAgeSeconds      int  `yaml:"surrogate_max_age_seconds" validate:"gte=0"`
+	StaleWhileRevalidateSeconds int  `yaml:"stale_while_revalidate_seconds" validate:"gte=0"`
+}
+
+// CacheTTLBounds is the starting TTL for a cache key class and the range adaptive tuning may move it within.
+type CacheTTLBounds struct {
+	BaseMinutes int `yaml:"base_minutes" validate:"gte=0"`
+	MinMinutes  int `yaml:"min_minutes" validate:"gte=0"`
+	MaxMinutes  int `yaml:"max_minutes" validate:"gte=0"`
+}
+
+// DatabaseOperation sets how one class of repository operations reads and writes on a replica set.
+// Empty fields keep the connection string's settings.
+type DatabaseOperation struct {
+	// primary, primaryPreferred, secondary, secondaryPreferred or nearest
+	ReadPreference string `yaml:"read_preference" validate:"omitempty,oneof=primary primaryPreferred secondary secondaryPreferred nearest"`
+	// secondaries lagging further behind the primary are not read from; 0 means no limit, otherwise at least 90
+	MaxStalenessSeconds int `yaml:"max_staleness_seconds" validate:"gte=0"`
+	// local, available, majority or linearizable
+	ReadConcern string `yaml:"read_concern" validate:"omitempty,oneof=local available majority linearizable"`
+	// majority or the number of members to acknowledge a write
+	WriteConcern   string `yaml:"write_concern"`
+	Journal        bool   `yaml:"journal"`
+	WriteTimeoutMS int    `yaml:"write_timeout_ms" validate:"gte=0"`
+}
+
+// PropertyDataProvider is an external source of property records. Providers are tried in the order
+// listed; Fallback decides whether the next one is tried after this one fails.
+type PropertyDataProvider struct {
+	Name     string `yaml:"name" validate:"required,oneof=corelogic attom"`
+	Fallback string `yaml:"fallback" validate:"omitempty,oneof=none errors all"`
+}
+
 type Config struct {
 	Server struct {
-		Port int `yaml:"port" validate:"required,gt=0,lte=65535"`
+		Port                 int `yaml:"port" validate:"required,gt=0,lte=65535"`
+		RequestBudgetMS      int `yaml:"request_budget_ms" validate:"gte=0"`
+		StreamTimeoutMinutes int `yaml:"stream_timeout_minutes" validate:"gte=0"`
+		StreamBatchSize      int `yaml:"stream_batch_size" validate:"gte=0"`
+		MaxBodyKB            int `yaml:"max_body_kb" validate:"gte=0"`
+		MaxImportBodyMB      int `yaml:"max_import_body_mb" validate:"gte=0"`
+		// PublicURL is the absolute URL clients reach the API at, such as https://api.example.com, for
+		// links in responses; empty takes it from each request
+		PublicURL string `yaml:"public_url" validate:"omitempty,url"`
+		// TrustedProxies are the addresses or CIDR ranges of the proxies whose X-Forwarded-* headers
+		// are believed; headers from any other peer are ignored
+		TrustedProxies []string `yaml:"trusted_proxies"`
+		Compression          struct {
+			Enabled      bool     `yaml:"enabled"`
+			Level        int      `yaml:"level" validate:"gte=0,lte=9"`
+			BrotliLevel  int      `yaml:"brotli_level" validate:"gte=0,lte=11"`
+			MinSizeBytes int      `yaml:"min_size_bytes" validate:"gte=0"`
+			ContentTypes []string `yaml:"content_types"`
+		} `yaml:"compression"`
 	} `yaml:"server"`
 	Database struct {
 		URI               string `yaml:"uri"`
 		DBName            string `yaml:"dbname" validate:"required"`
 		StaleThresholdDays int    `yaml:"stale_threshold_days" validate:"required,gte=1"`
+		SlowQueryMS        int    `yaml:"slow_query_ms" validate:"gte=0"`
+		// OperationTimeoutMS bounds each query or write a repository makes, within the caller's deadline
+		OperationTimeoutMS int `yaml:"operation_timeout_ms" validate:"gte=0"`
+		// Read and write settings by operation class: point reads, paged lists and searches, bulk
+		// exports and scans, and writes
+		Operations struct {
+			Read   DatabaseOperation `yaml:"read"`
+			List   DatabaseOperation `yaml:"list"`
+			Export DatabaseOperation `yaml:"export"`
+			Write  DatabaseOperation `yaml:"write"`
+		} `yaml:"operations"`
+		Transactions struct {
+			Enabled     bool `yaml:"enabled"`
+			MaxAttempts int  `yaml:"max_attempts" validate:"gte=0"`
+		} `yaml:"transactions"`
+		// SchemaValidation is the mode of the properties collection's $jsonSchema validator: off, warn or strict
+		SchemaValidation string `yaml:"schema_validation" validate:"omitempty,oneof=off warn strict"`
 	} `yaml:"database"`
 	Redis struct {
+		Mode          string `yaml:"mode" validate:"omitempty,oneof=standalone cluster sentinel"`
 		Host          string `yaml:"host" validate:"required,hostname"`
 		Port          int    `yaml:"port" validate:"required,gt=0,lte=65535"`
 		Password      string `yaml:"password"`
 		DB            int    `yaml:"db" validate:"gte=0"`
 		TLSEnabled    bool   `yaml:"tls_enabled"`
 		CacheTTLDays  int    `yaml:"cache_ttl_days" validate:"required,gte=1"`
+		// Cluster seed nodes, or the Sentinel nodes watching MasterName, as host:port
+		Addrs            []string `yaml:"addrs"`
+		MasterName       string   `yaml:"master_name"`
+		SentinelPassword string   `yaml:"sentinel_password"`
+		Codec            string   `yaml:"codec" validate:"omitempty,oneof=json msgpack snappy gzip"`
+		TimeoutMS        int      `yaml:"timeout_ms" validate:"gte=0"`
 	} `yaml:"redis"`
+	CacheTTL struct {
+		Adaptive             bool           `yaml:"adaptive"`
+		TuneIntervalMinutes  int            `yaml:"tune_interval_minutes" validate:"gte=0"`
+		JitterPercent        int            `yaml:"jitter_percent" validate:"gte=0,lte=50"`
+		Property             CacheTTLBounds `yaml:"property"`
+		Search               CacheTTLBounds `yaml:"search"`
+		List                 CacheTTLBounds `yaml:"list"`
+		StaleWhileRevalidate struct {
+			Enabled      bool `yaml:"enabled"`
+			StaleMinutes int  `yaml:"stale_minutes" validate:"gte=0"`
+		} `yaml:"stale_while_revalidate"`
+	} `yaml:"cache_ttl"`
+	// Sandbox serves every external property data fetch, including valuations, from fixture files
+	// instead of vendors. It can't be enabled with ENV=production.
+	Sandbox struct {
+		Enabled         bool   `yaml:"enabled"`
+		FixturesDir     string `yaml:"fixtures_dir"`
+		GenerateMissing bool   `yaml:"generate_missing"`
+	} `yaml:"sandbox"`
+	CacheWarmup struct {
+		Enabled  bool   `yaml:"enabled"`
+		Strategy string `yaml:"strategy" validate:"omitempty,oneof=recent popular"`
+		Count    int    `yaml:"count" validate:"gte=0"`
+		// ListItems also caches every property of a list page loaded from the database
+		ListItems bool `yaml:"list_items"`
+	} `yaml:"cache_warmup"`
+	Pagination struct {
+		// CountStrategy picks how offset pagination totals are counted: exact, estimated, cached or facet
+		CountStrategy     string `yaml:"count_strategy" validate:"omitempty,oneof=exact estimated cached facet"`
+		CountCacheSeconds int    `yaml:"count_cache_seconds" validate:"gte=0"`
+	} `yaml:"pagination"`
+	LocalCache struct {
+		Enabled    bool `yaml:"enabled"`
+		MaxEntries int  `yaml:"max_entries" validate:"gte=0"`
+		TTLSeconds int  `yaml:"ttl_seconds" validate:"gte=0"`
+	} `yaml:"local_cache"`
+	ChangeStream struct {
+		Enabled      bool `yaml:"enabled"`
+		RetrySeconds int  `yaml:"retry_seconds" validate:"gte=0"`
+	} `yaml:"change_stream"`
 	JWT struct {
-		Secret string `yaml:"secret"`
+		Secret          string            `yaml:"secret"`
+		RefreshTTLHours int               `yaml:"refresh_ttl_hours" validate:"gte=1"`
+		ActiveKeyID     string            `yaml:"active_key_id"`
+		Keys            map[string]string `yaml:"keys"` // PEM key file paths by key id
 	} `yaml:"jwt"`
+	OAuth struct {
+		TimeoutSeconds int `yaml:"timeout_seconds" validate:"gte=0"`
+		Google         struct {
+			ClientIDs []string `yaml:"client_ids"`
+		} `yaml:"google"`
+		Apple struct {
+			ClientIDs []string `yaml:"client_ids"` // Services IDs and app bundle IDs
+		} `yaml:"apple"`
+	} `yaml:"oauth"`
+	LoginProtection struct {
+		MaxFailures       int  `yaml:"max_failures" validate:"gte=0"`
+		IPMaxFailures     int  `yaml:"ip_max_failures" validate:"gte=0"`
+		WindowMinutes     int  `yaml:"window_minutes" validate:"gte=0"`
+		LockoutSeconds    int  `yaml:"lockout_seconds" validate:"gte=0"`
+		MaxLockoutMinutes int  `yaml:"max_lockout_minutes" validate:"gte=0"`
+		NotifyUser        bool `yaml:"notify_user"`
+	} `yaml:"login_protection"`
+	PasswordReset struct {
+		TokenTTLMinutes       int    `yaml:"token_ttl_minutes" validate:"gte=0"`
+		ResendCooldownSeconds int    `yaml:"resend_cooldown_seconds" validate:"gte=0"`
+		ResetURL              string `yaml:"reset_url"`
+	} `yaml:"password_reset"`
+	SMTP struct {
+		Host     string `yaml:"host"`
+		Port     int    `yaml:"port" validate:"gte=0,lte=65535"`
+		Username string `yaml:"username"`
+		Password string `yaml:"password"`
+		From     string `yaml:"from"`
+	} `yaml:"smtp"`
 	CoreLogic struct {
 		ClientKey      string `yaml:"client_key"`
 		ClientSecret   string `yaml:"client_secret"`
 		DeveloperEmail string `yaml:"developer_email"`
+		CircuitBreaker struct {
+			FailureThreshold int `yaml:"failure_threshold" validate:"gte=0"`
+			CooldownSeconds  int `yaml:"cooldown_seconds" validate:"gte=0"`
+		} `yaml:"circuit_breaker"`
+		DailyRequestLimit int64 `yaml:"daily_request_limit" validate:"gte=0"`
+		TimeoutSeconds    int   `yaml:"timeout_seconds" validate:"gte=0"`
 	} `yaml:"corelogic"`
+	PropertyData struct {
+		Providers []PropertyDataProvider `yaml:"providers"`
+		ATTOM     struct {
+			BaseURL        string `yaml:"base_url"`
+			APIKey         string `yaml:"api_key"`
+			TimeoutSeconds int    `yaml:"timeout_seconds" validate:"gte=0"`
+		} `yaml:"attom"`
+	} `yaml:"property_data"`
+	AddressMatching struct {
+		Fuzzy         bool    `yaml:"fuzzy"`
+		MinConfidence float64 `yaml:"min_confidence" validate:"gte=0,lte=1"`
+		MaxCandidates int     `yaml:"max_candidates" validate:"gte=0"`
+	} `yaml:"address_matching"`
+	AddressStandardization struct {
+		Provider       string `yaml:"provider" validate:"omitempty,oneof=usps smarty google"`
+		TimeoutSeconds int    `yaml:"timeout_seconds" validate:"gte=0"`
+		CacheTTLHours  int    `yaml:"cache_ttl_hours" validate:"gte=0"`
+		USPS           struct {
+			BaseURL      string `yaml:"base_url"`
+			ClientID     string `yaml:"client_id"`
+			ClientSecret string `yaml:"client_secret"`
+		} `yaml:"usps"`
+		Smarty struct {
+			BaseURL   string `yaml:"base_url"`
+			AuthID    string `yaml:"auth_id"`
+			AuthToken string `yaml:"auth_token"`
+		} `yaml:"smarty"`
+		Google struct {
+			BaseURL string `yaml:"base_url"`
+			APIKey  string `yaml:"api_key"`
+		} `yaml:"google"`
+	} `yaml:"address_standardization"`
+	Hazard struct {
+		Provider       string `yaml:"provider" validate:"omitempty,oneof=fema"`
+		TimeoutSeconds int    `yaml:"timeout_seconds" validate:"gte=0"`
+		CacheTTLHours  int    `yaml:"cache_ttl_hours" validate:"gte=0"`
+		FEMA           struct {
+			FloodBaseURL    string `yaml:"flood_base_url"`
+			WildfireBaseURL string `yaml:"wildfire_base_url"`
+		} `yaml:"fema"`
+	} `yaml:"hazard"`
+	Neighborhood struct {
+		Provider       string `yaml:"provider" validate:"omitempty,oneof=osm"`
+		TimeoutSeconds int    `yaml:"timeout_seconds" validate:"gte=0"`
+		CacheTTLHours  int    `yaml:"cache_ttl_hours" validate:"gte=0"`
+		RadiusMeters   int    `yaml:"radius_meters" validate:"gte=0"`
+		OSM            struct {
+			BaseURL string `yaml:"base_url"`
+		} `yaml:"osm"`
+	} `yaml:"neighborhood"`
+	Census struct {
+		Enabled        bool   `yaml:"enabled"`
+		BaseURL        string `yaml:"base_url"`
+		GeocoderURL    string `yaml:"geocoder_url"`
+		APIKey         string `yaml:"api_key"`
+		Year           int    `yaml:"year" validate:"omitempty,gte=2009"`
+		TimeoutSeconds int    `yaml:"timeout_seconds" validate:"gte=0"`
+		CacheTTLHours  int    `yaml:"cache_ttl_hours" validate:"gte=0"`
+	} `yaml:"census"`
+	Media struct {
+		Enabled              bool     `yaml:"enabled"`
+		Storage              string   `yaml:"storage" validate:"omitempty,oneof=s3 gcs"`
+		Endpoint             string   `yaml:"endpoint"`
+		Region               string   `yaml:"region"`
+		Bucket               string   `yaml:"bucket"`
+		AccessKeyID          string   `yaml:"access_key_id"`
+		SecretAccessKey      string   `yaml:"secret_access_key"`
+		TimeoutSeconds       int      `yaml:"timeout_seconds" validate:"gte=0"`
+		SignedURLTTLMinutes  int      `yaml:"signed_url_ttl_minutes" validate:"gte=0"`
+		MaxUploadMB          int      `yaml:"max_upload_mb" validate:"gte=0"`
+		ThumbnailSize        int      `yaml:"thumbnail_size" validate:"gte=0"`
+		DocumentContentTypes []string `yaml:"document_content_types"`
+	} `yaml:"media"`
+	ShareLinks struct {
+		Secret          string `yaml:"secret"`
+		DefaultTTLHours int    `yaml:"default_ttl_hours" validate:"gte=1"`
+		MaxTTLHours     int    `yaml:"max_ttl_hours" validate:"gte=1"`
+	} `yaml:"share_links"`
+	Embed struct {
+		CacheMaxAgeSeconds int            `yaml:"cache_max_age_seconds" validate:"gte=0"`
+		Partners           []EmbedPartner `yaml:"partners"`
+	} `yaml:"embed"`
+	PIIEncryption struct {
+		ActiveKeyID string            `yaml:"active_key_id"`
+		Keys        map[string]string `yaml:"keys"`
+		IndexKey    string            `yaml:"index_key"`
+	} `yaml:"pii_encryption"`
+	RequestCost struct {
+		CacheReadUnits         int64 `yaml:"cache_read_units" validate:"gte=0"`
+		MongoQueryUnits        int64 `yaml:"mongo_query_units" validate:"gte=0"`
+		CoreLogicCallUnits     int64 `yaml:"corelogic_call_units" validate:"gte=0"`
+		UnitsPerRateLimitToken int64 `yaml:"units_per_rate_limit_token" validate:"gte=0"`
+	} `yaml:"request_cost"`
+	RateLimit struct {
+		WindowSeconds int                      `yaml:"window_seconds" validate:"gte=0"`
+		Default       RateLimitRule            `yaml:"default"`
+		Groups        map[string]RateLimitRule `yaml:"groups"`
+	} `yaml:"rate_limit"`
+	CORS struct {
+		// Origins browser frontends may call the API from, e.g. https://app.example.com; a * in an
+		// origin matches any subdomain, and "*" alone allows every origin. Empty allows none.
+		AllowedOrigins   []string `yaml:"allowed_origins"`
+		AllowedMethods   []string `yaml:"allowed_methods"`
+		AllowedHeaders   []string `yaml:"allowed_headers"`
+		AllowCredentials bool     `yaml:"allow_credentials"`
+		MaxAgeSeconds    int      `yaml:"max_age_seconds" validate:"gte=0"`
+	} `yaml:"cors"`
+	HTTPCache struct {
+		Routes map[string]HTTPCacheRule `yaml:"routes" validate:"dive"` // by route path
+	} `yaml:"http_cache"`
+	Usage struct {
+		RollupIntervalMinutes int                   `yaml:"rollup_interval_minutes" validate:"gte=0"`
+		Default               UsageQuota            `yaml:"default"`
+		Organizations         map[string]UsageQuota `yaml:"organizations"`
+	} `yaml:"usage"`
+	RequestSigning struct {
+		TimestampToleranceSeconds int              `yaml:"timestamp_tolerance_seconds" validate:"gte=0"`
+		Partners                  []SigningPartner `yaml:"partners"`
+	} `yaml:"request_signing"`
+	Idempotency struct {
+		TTLHours int `yaml:"ttl_hours" validate:"gte=0"`
+	} `yaml:"idempotency"`
+	Notifications struct {
+		DailyDigestHourUTC int `yaml:"daily_digest_hour_utc" validate:"gte=0,lte=23"`
+		RetentionDays      int `yaml:"retention_days" validate:"gte=0"`
+	} `yaml:"notifications"`
+	SavedSearches struct {
+		RunIntervalMinutes int `yaml:"run_interval_minutes" validate:"gte=0"`
+		MaxPerUser         int `yaml:"max_per_user" validate:"gte=0"`
+		MaxMatchesPerRun   int `yaml:"max_matches_per_run" validate:"gte=0"`
+	} `yaml:"saved_searches"`
+	Valuations struct {
+		CacheTTLHours    int `yaml:"cache_ttl_hours" validate:"gte=0"`
+		RefreshAfterDays int `yaml:"refresh_after_days" validate:"gte=0"`
+	} `yaml:"valuations"`
+	Markets struct {
+		StatsCacheTTLHours  int `yaml:"stats_cache_ttl_hours" validate:"gte=0"`
+		StatsRefreshHourUTC int `yaml:"stats_refresh_hour_utc" validate:"gte=0,lte=23"`
+	} `yaml:"markets"`
+	Reconciliation struct {
+		Enabled bool `yaml:"enabled"`
+		// Schedule is a cron expression evaluated in UTC
+		Schedule             string   `yaml:"schedule"`
+		Tasks                []string `yaml:"tasks"`
+		RefreshOlderThanDays int      `yaml:"refresh_older_than_days" validate:"gte=0"`
+		RefreshBatchSize     int      `yaml:"refresh_batch_size" validate:"gte=0"`
+	} `yaml:"reconciliation"`
+	Locations struct {
+		CacheTTLHours int `yaml:"cache_ttl_hours" validate:"gte=0"`
+	} `yaml:"locations"`
+	OwnerSearch struct {
+		Roles []string `yaml:"roles"`
+	} `yaml:"owner_search"`
+	Webhooks struct {
+		MaxAttempts           int `yaml:"max_attempts" validate:"gte=0"`
+		InitialBackoffSeconds int `yaml:"initial_backoff_seconds" validate:"gte=0"`
+		MaxBackoffSeconds     int `yaml:"max_backoff_seconds" validate:"gte=0"`
+		TimeoutSeconds        int `yaml:"timeout_seconds" validate:"gte=0"`
+		MaxConcurrent         int `yaml:"max_concurrent" validate:"gte=0"`
+	} `yaml:"webhooks"`
+	Events struct {
+		Enabled               bool   `yaml:"enabled"`
+		Broker                string `yaml:"broker" validate:"omitempty,oneof=kafka nats"`
+		RelayIntervalSeconds  int    `yaml:"relay_interval_seconds" validate:"gte=0"`
+		BatchSize             int    `yaml:"batch_size" validate:"gte=0"`
+		PublishTimeoutSeconds int    `yaml:"publish_timeout_seconds" validate:"gte=0"`
+		InitialBackoffSeconds int    `yaml:"initial_backoff_seconds" validate:"gte=0"`
+		MaxBackoffSeconds     int    `yaml:"max_backoff_seconds" validate:"gte=0"`
+		RetentionHours        int    `yaml:"retention_hours" validate:"gte=0"`
+		Kafka                 struct {
+			RestProxyURL string `yaml:"rest_proxy_url"`
+			Topic        string `yaml:"topic"`
+			Username     string `yaml:"username"`
+			Password     string `yaml:"password"`
+		} `yaml:"kafka"`
+		NATS struct {
+			URL             string `yaml:"url"`
+			SubjectPrefix   string `yaml:"subject_prefix"`
+			Token           string `yaml:"token"`
+			CredentialsFile string `yaml:"credentials_file"`
+		} `yaml:"nats"`
+	} `yaml:"events"`
+	SearchIndex struct {
+		Enabled        bool   `yaml:"enabled"`
+		URL            string `yaml:"url"`
+		Index          string `yaml:"index"`
+		Username       string `yaml:"username"`
+		Password       string `yaml:"password"`
+		TimeoutSeconds int    `yaml:"timeout_seconds" validate:"gte=0"`
+		BulkSize       int    `yaml:"bulk_size" validate:"gte=0"`
+	} `yaml:"search_index"`
+	Jobs struct {
+		Workers               int `yaml:"workers" validate:"gte=0"`
+		MaxAttempts           int `yaml:"max_attempts" validate:"gte=0"`
+		InitialBackoffSeconds int `yaml:"initial_backoff_seconds" validate:"gte=0"`
+		MaxBackoffSeconds     int `yaml:"max_backoff_seconds" validate:"gte=0"`
+		TimeoutSeconds        int `yaml:"timeout_seconds" validate:"gte=0"`
+		PollIntervalMS        int `yaml:"poll_interval_ms" validate:"gte=0"`
+		RetentionHours        int `yaml:"retention_hours" validate:"gte=0"`
+		DeadLetterMax         int `yaml:"dead_letter_max" validate:"gte=0"`
+		ImportMaxProperties   int `yaml:"import_max_properties" validate:"gte=0"`
+		BulkMaxProperties     int `yaml:"bulk_max_properties" validate:"gte=0"`
+		BulkBatchSize         int `yaml:"bulk_batch_size" validate:"gte=0"`
+	} `yaml:"jobs"`
+	Health struct {
+		CheckTimeoutSeconds int   `yaml:"check_timeout_seconds" validate:"gte=0"`
+		MaxQueueDepth       int64 `yaml:"max_queue_depth" validate:"gte=0"`
+	} `yaml:"health"`
+	Migrations struct {
+		BatchSize    int `yaml:"batch_size" validate:"gte=0"`
+		SliceSeconds int `yaml:"slice_seconds" validate:"gte=0"`
+	} `yaml:"migrations"`
+	Feeds struct {
+		Storage      string `yaml:"storage" validate:"omitempty,oneof=local media"`
+		SpoolDir     string `yaml:"spool_dir"`
+		MaxUploadMB  int    `yaml:"max_upload_mb" validate:"gte=0"`
+		BatchSize    int    `yaml:"batch_size" validate:"gte=0"`
+		SliceSeconds int    `yaml:"slice_seconds" validate:"gte=0"`
+		MaxRowErrors int    `yaml:"max_row_errors" validate:"gte=0"`
+	} `yaml:"feeds"`
+	OpenAPIValidation struct {
+		Enabled   bool `yaml:"enabled"`
+		Responses bool `yaml:"responses"`
+	} `yaml:"openapi_validation"`
 	ErrorHandling struct {
 		LogTechnicalDetails bool   `yaml:"log_technical_details"`
 		UserMessageLanguage string `yaml:"user_message_language" validate:"required,oneof=en es fr"`
@@ -54,6 +483,12 @@ func LoadConfig(path string) (*Config, error) {
 		}
 	}
 
+	// Settings are layered: the environment overrides the YAML file, and defaults below fill in
+	// whatever neither sets
+	if err := applyEnvOverrides(cfg); err != nil {
+		return nil, err
+	}
+
 	// Override with environment variables for sensitive fields
 	if mongoURI := os.Getenv("MONGO_URI"); mongoURI != "" {
 		cfg.Database.URI = mongoURI
@@ -64,9 +499,31 @@ func LoadConfig(path string) (*Config, error) {
 	if redisPassword := os.Getenv("REDIS_PASSWORD"); redisPassword != "" {
 		cfg.Redis.Password = redisPassword
 	}
+	if redisMode := os.Getenv("REDIS_MODE"); redisMode != "" {
+		cfg.Redis.Mode = redisMode
+	}
+	if redisAddrs := os.Getenv("REDIS_ADDRS"); redisAddrs != "" {
+		cfg.Redis.Addrs = strings.Split(redisAddrs, ",")
+	}
+	if redisMasterName := os.Getenv("REDIS_MASTER_NAME"); redisMasterName != "" {
+		cfg.Redis.MasterName = redisMasterName
+	}
+	if sentinelPassword := os.Getenv("REDIS_SENTINEL_PASSWORD"); sentinelPassword != "" {
+		cfg.Redis.SentinelPassword = sentinelPassword
+	}
 	if jwtSecret := os.Getenv("JWT_SECRET"); jwtSecret != "" {
 		cfg.JWT.Secret = jwtSecret
 	}
+	if jwtKeys := os.Getenv("JWT_SIGNING_KEYS"); jwtKeys != "" {
+		keys, err := fieldcrypt.ParseKeyList(jwtKeys)
+		if err != nil {
+			return nil, fmt.Errorf("JWT_SIGNING_KEYS: %v", err)
+		}
+		cfg.JWT.Keys = keys
+	}
+	if jwtActiveKey := os.Getenv("JWT_ACTIVE_KEY"); jwtActiveKey != "" {
+		cfg.JWT.ActiveKeyID = jwtActiveKey
+	}
 	if corelogicUsername := os.Getenv("CORELOGIC_USERNAME"); corelogicUsername != "" {
 		cfg.CoreLogic.ClientKey = corelogicUsername
 	}
@@ -76,6 +533,52 @@ func LoadConfig(path string) (*Config, error) {
 	if corelogicDeveloperEmail := os.Getenv("CORELOGIC_DEVELOPER_EMAIL"); corelogicDeveloperEmail != "" {
 		cfg.CoreLogic.DeveloperEmail = corelogicDeveloperEmail
 	}
+	if attomAPIKey := os.Getenv("ATTOM_API_KEY"); attomAPIKey != "" {
+		cfg.PropertyData.ATTOM.APIKey = attomAPIKey
+	}
+	if uspsClientSecret := os.Getenv("USPS_CLIENT_SECRET"); uspsClientSecret != "" {
+		cfg.AddressStandardization.USPS.ClientSecret = uspsClientSecret
+	}
+	if smartyAuthToken := os.Getenv("SMARTY_AUTH_TOKEN"); smartyAuthToken != "" {
+		cfg.AddressStandardization.Smarty.AuthToken = smartyAuthToken
+	}
+	if googleAPIKey := os.Getenv("GOOGLE_GEOCODING_API_KEY"); googleAPIKey != "" {
+		cfg.AddressStandardization.Google.APIKey = googleAPIKey
+	}
+	if mediaSecretKey := os.Getenv("MEDIA_SECRET_ACCESS_KEY"); mediaSecretKey != "" {
+		cfg.Media.SecretAccessKey = mediaSecretKey
+	}
+	if smtpPassword := os.Getenv("SMTP_PASSWORD"); smtpPassword != "" {
+		cfg.SMTP.Password = smtpPassword
+	}
+	if shareLinkSecret := os.Getenv("SHARE_LINK_SECRET"); shareLinkSecret != "" {
+		cfg.ShareLinks.Secret = shareLinkSecret
+	}
+	if kafkaPassword := os.Getenv("EVENTS_KAFKA_PASSWORD"); kafkaPassword != "" {
+		cfg.Events.Kafka.Password = kafkaPassword
+	}
+	if natsToken := os.Getenv("EVENTS_NATS_TOKEN"); natsToken != "" {
+		cfg.Events.NATS.Token = natsToken
+	}
+	if censusAPIKey := os.Getenv("CENSUS_API_KEY"); censusAPIKey != "" {
+		cfg.Census.APIKey = censusAPIKey
+	}
+	if searchIndexPassword := os.Getenv("SEARCH_INDEX_PASSWORD"); searchIndexPassword != "" {
+		cfg.SearchIndex.Password = searchIndexPassword
+	}
+	if piiKeys := os.Getenv("PII_ENCRYPTION_KEYS"); piiKeys != "" {
+		keys, err := fieldcrypt.ParseKeyList(piiKeys)
+		if err != nil {
+			return nil, fmt.Errorf("PII_ENCRYPTION_KEYS: %v", err)
+		}
+		cfg.PIIEncryption.Keys = keys
+	}
+	if piiActiveKey := os.Getenv("PII_ENCRYPTION_ACTIVE_KEY"); piiActiveKey != "" {
+		cfg.PIIEncryption.ActiveKeyID = piiActiveKey
+	}
+	if piiIndexKey := os.Getenv("PII_INDEX_KEY"); piiIndexKey != "" {
+		cfg.PIIEncryption.IndexKey = piiIndexKey
+	}
 
 	// Set tls_enabled based on ENV
 	if env := os.Getenv("ENV"); env == "production" {
@@ -83,6 +586,24 @@ func LoadConfig(path string) (*Config, error) {
 	} else {
 		cfg.Redis.TLSEnabled = false
 	}
+	if sandbox := os.Getenv("SANDBOX"); sandbox != "" {
+		cfg.Sandbox.Enabled = sandbox == "true"
+	}
+	if cfg.Sandbox.Enabled && os.Getenv("ENV") == "production" {
+		return nil, fmt.Errorf("sandbox mode cannot be enabled with ENV=production")
+	}
+	for _, proxy := range cfg.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("server.trusted_proxies: %q is not an IP address or CIDR range", proxy)
+		}
+	}
+	if cfg.Server.PublicURL == "" && len(cfg.Server.TrustedProxies) == 0 && os.Getenv("ENV") == "production" {
+		// links would otherwise be built from the Host header of whoever sent the request
+		return nil, fmt.Errorf("server.public_url is required with ENV=production unless server.trusted_proxies is set")
+	}
+	if cfg.Sandbox.FixturesDir == "" {
+		cfg.Sandbox.FixturesDir = "data/sandbox"
+	}
 
 	// Validation
 	if cfg.Server.Port <= 0 || cfg.Server.Port > 65535 {
@@ -94,17 +615,51 @@ func LoadConfig(path string) (*Config, error) {
 	if cfg.Database.DBName == "" {
 		return nil, fmt.Errorf("DB_NAME is required")
 	}
-	if cfg.Redis.Host == "" {
-		return nil, fmt.Errorf("REDIS_HOST is required")
+	if cfg.Redis.Mode == "" {
+		cfg.Redis.Mode = "standalone"
+	}
+	switch cfg.Redis.Mode {
+	case "standalone":
+		if cfg.Redis.Host == "" {
+			return nil, fmt.Errorf("REDIS_HOST is required")
+		}
+		if cfg.Redis.Port <= 0 || cfg.Redis.Port > 65535 {
+			return nil, fmt.Errorf("REDIS_PORT must be between 1 and 65535")
+		}
+	case "cluster", "sentinel":
+		if len(cfg.Redis.Addrs) == 0 {
+			return nil, fmt.Errorf("REDIS_ADDRS is required in %s mode", cfg.Redis.Mode)
+		}
+		if cfg.Redis.Mode == "sentinel" && cfg.Redis.MasterName == "" {
+			return nil, fmt.Errorf("REDIS_MASTER_NAME is required in sentinel mode")
+		}
+		if cfg.Redis.Mode == "cluster" && cfg.Redis.DB != 0 {
+			return nil, fmt.Errorf("REDIS_DB must be 0 in cluster mode")
+		}
+	default:
+		return nil, fmt.Errorf("REDIS_MODE must be standalone, cluster or sentinel")
+	}
+	if cfg.Redis.Codec == "" {
+		cfg.Redis.Codec = "json"
 	}
-	if cfg.Redis.Port <= 0 || cfg.Redis.Port > 65535 {
-		return nil, fmt.Errorf("REDIS_PORT must be between 1 and 65535")
+	switch cfg.Redis.Codec {
+	case "json", "msgpack", "snappy", "gzip":
+	default:
+		return nil, fmt.Errorf("redis.codec must be json, msgpack, snappy or gzip")
 	}
 	if cfg.Redis.DB < 0 {
 		return nil, fmt.Errorf("REDIS_DB must be non-negative")
 	}
-	if cfg.JWT.Secret == "" {
-		return nil, fmt.Errorf("JWT_SECRET is required")
+	if cfg.JWT.Secret == "" && len(cfg.JWT.Keys) == 0 {
+		return nil, fmt.Errorf("JWT_SECRET or JWT_SIGNING_KEYS is required")
+	}
+	if len(cfg.JWT.Keys) > 0 && cfg.JWT.ActiveKeyID == "" {
+		if len(cfg.JWT.Keys) > 1 {
+			return nil, fmt.Errorf("JWT_ACTIVE_KEY is required when multiple signing keys are configured")
+		}
+		for id := range cfg.JWT.Keys {
+			cfg.JWT.ActiveKeyID = id
+		}
 	}
 	if cfg.CoreLogic.ClientKey == "" {
 		return nil, fmt.Errorf("CORELOGIC_USERNAME is required")
@@ -118,6 +673,562 @@ func LoadConfig(path string) (*Config, error) {
 	if cfg.ErrorHandling.UserMessageLanguage == "" {
 		cfg.ErrorHandling.UserMessageLanguage = "en" // Default to English
 	}
+	if cfg.LoginProtection.WindowMinutes <= 0 {
+		cfg.LoginProtection.WindowMinutes = 15
+	}
+	if cfg.LoginProtection.LockoutSeconds <= 0 {
+		cfg.LoginProtection.LockoutSeconds = 60
+	}
+	if cfg.LoginProtection.MaxLockoutMinutes <= 0 {
+		cfg.LoginProtection.MaxLockoutMinutes = 60
+	}
+	if cfg.OAuth.TimeoutSeconds <= 0 {
+		cfg.OAuth.TimeoutSeconds = 5
+	}
+	if cfg.JWT.RefreshTTLHours <= 0 {
+		cfg.JWT.RefreshTTLHours = 720
+	}
+	if cfg.ShareLinks.Secret == "" && cfg.JWT.Secret != "" {
+		// Derived rather than shared, so a share link token can never pass for an access token
+		key, err := hkdf.Key(sha256.New, []byte(cfg.JWT.Secret), nil, shareLinkKeyPurpose, 32)
+		if err != nil {
+			return nil, fmt.Errorf("derive share link secret failed: %v", err)
+		}
+		cfg.ShareLinks.Secret = hex.EncodeToString(key)
+	}
+	if cfg.ShareLinks.Secret == "" {
+		return nil, fmt.Errorf("SHARE_LINK_SECRET is required when JWT_SECRET is not set")
+	}
+	if cfg.ShareLinks.DefaultTTLHours <= 0 {
+		cfg.ShareLinks.DefaultTTLHours = 72
+	}
+	if cfg.ShareLinks.MaxTTLHours <= 0 {
+		cfg.ShareLinks.MaxTTLHours = 720
+	}
+	if cfg.Embed.CacheMaxAgeSeconds <= 0 {
+		cfg.Embed.CacheMaxAgeSeconds = 300
+	}
+	for i := range cfg.Embed.Partners {
+		if cfg.Embed.Partners[i].RequestsPerMinute <= 0 {
+			cfg.Embed.Partners[i].RequestsPerMinute = 60
+		}
+	}
+	if len(cfg.PIIEncryption.Keys) > 0 && cfg.PIIEncryption.ActiveKeyID == "" {
+		if len(cfg.PIIEncryption.Keys) > 1 {
+			return nil, fmt.Errorf("PII_ENCRYPTION_ACTIVE_KEY is required when multiple keys are configured")
+		}
+		for id := range cfg.PIIEncryption.Keys {
+			cfg.PIIEncryption.ActiveKeyID = id
+		}
+	}
+	if len(cfg.PIIEncryption.Keys) > 0 && cfg.PIIEncryption.IndexKey == "" {
+		return nil, fmt.Errorf("PII_INDEX_KEY is required when PII encryption keys are configured")
+	}
+	if cfg.Server.RequestBudgetMS <= 0 {
+		cfg.Server.RequestBudgetMS = 30000
+	}
+	if cfg.Database.OperationTimeoutMS <= 0 {
+		cfg.Database.OperationTimeoutMS = 5000
+	}
+	if cfg.Redis.TimeoutMS <= 0 {
+		cfg.Redis.TimeoutMS = 3000
+	}
+	if cfg.CoreLogic.TimeoutSeconds <= 0 {
+		cfg.CoreLogic.TimeoutSeconds = 30
+	}
+	if cfg.Server.StreamTimeoutMinutes <= 0 {
+		cfg.Server.StreamTimeoutMinutes = 60
+	}
+	if cfg.Server.StreamBatchSize <= 0 {
+		cfg.Server.StreamBatchSize = 500
+	}
+	if cfg.Server.MaxBodyKB <= 0 {
+		cfg.Server.MaxBodyKB = 1024
+	}
+	if cfg.Server.MaxImportBodyMB <= 0 {
+		cfg.Server.MaxImportBodyMB = 16
+	}
+	cfg.Server.PublicURL = strings.TrimSuffix(cfg.Server.PublicURL, "/")
+	if cfg.Server.Compression.Level <= 0 {
+		cfg.Server.Compression.Level = 5
+	}
+	if cfg.Server.Compression.BrotliLevel <= 0 {
+		cfg.Server.Compression.BrotliLevel = 4
+	}
+	if cfg.Server.Compression.MinSizeBytes <= 0 {
+		cfg.Server.Compression.MinSizeBytes = 1024
+	}
+	if len(cfg.Server.Compression.ContentTypes) == 0 {
+		cfg.Server.Compression.ContentTypes = []string{"application/json", "application/x-ndjson", "application/problem+json", "text/"}
+	}
+	if cfg.CacheTTL.TuneIntervalMinutes <= 0 {
+		cfg.CacheTTL.TuneIntervalMinutes = 15
+	}
+	cacheTTLMinutes := cfg.Redis.CacheTTLDays * 24 * 60
+	applyCacheTTLDefaults(&cfg.CacheTTL.Property, cacheTTLMinutes, 60, 2*cacheTTLMinutes)
+	applyCacheTTLDefaults(&cfg.CacheTTL.Search, cacheTTLMinutes, 60, 2*cacheTTLMinutes)
+	applyCacheTTLDefaults(&cfg.CacheTTL.List, 10, 1, 60)
+	for class, bounds := range map[string]CacheTTLBounds{"property": cfg.CacheTTL.Property, "search": cfg.CacheTTL.Search, "list": cfg.CacheTTL.List} {
+		if bounds.MinMinutes > bounds.BaseMinutes || bounds.BaseMinutes > bounds.MaxMinutes {
+			return nil, fmt.Errorf("cache_ttl.%s must satisfy min_minutes <= base_minutes <= max_minutes", class)
+		}
+	}
+	if cfg.CacheTTL.JitterPercent <= 0 {
+		cfg.CacheTTL.JitterPercent = 10
+	}
+	if cfg.CacheTTL.JitterPercent > 50 {
+		return nil, fmt.Errorf("cache_ttl.jitter_percent must be at most 50")
+	}
+	if cfg.CacheTTL.StaleWhileRevalidate.StaleMinutes <= 0 {
+		cfg.CacheTTL.StaleWhileRevalidate.StaleMinutes = 1440
+	}
+	if cfg.CacheWarmup.Strategy == "" {
+		cfg.CacheWarmup.Strategy = "recent"
+	}
+	if cfg.CacheWarmup.Strategy != "recent" && cfg.CacheWarmup.Strategy != "popular" {
+		return nil, fmt.Errorf("cache_warmup.strategy must be recent or popular")
+	}
+	if cfg.CacheWarmup.Count <= 0 {
+		cfg.CacheWarmup.Count = 500
+	}
+	if cfg.Pagination.CountStrategy == "" {
+		cfg.Pagination.CountStrategy = "exact"
+	}
+	if cfg.Pagination.CountCacheSeconds <= 0 {
+		cfg.Pagination.CountCacheSeconds = 60
+	}
+	if cfg.Reconciliation.Schedule == "" {
+		cfg.Reconciliation.Schedule = "0 4 * * *"
+	}
+	if len(cfg.Reconciliation.Tasks) == 0 {
+		cfg.Reconciliation.Tasks = []string{"refresh_stale", "prune_cache_key_sets", "rebuild_stats"}
+	}
+	for _, task := range cfg.Reconciliation.Tasks {
+		if task != "refresh_stale" && task != "prune_cache_key_sets" && task != "rebuild_stats" {
+			return nil, fmt.Errorf("reconciliation.tasks: unknown task %q", task)
+		}
+	}
+	if cfg.Reconciliation.RefreshOlderThanDays <= 0 {
+		cfg.Reconciliation.RefreshOlderThanDays = cfg.Database.StaleThresholdDays
+	}
+	if cfg.Reconciliation.RefreshOlderThanDays < cfg.Database.StaleThresholdDays {
+		return nil, fmt.Errorf("reconciliation.refresh_older_than_days must be at least database.stale_threshold_days")
+	}
+	if cfg.Reconciliation.RefreshBatchSize <= 0 {
+		cfg.Reconciliation.RefreshBatchSize = 1000
+	}
+	if cfg.LocalCache.MaxEntries <= 0 {
+		cfg.LocalCache.MaxEntries = 10000
+	}
+	if cfg.LocalCache.TTLSeconds <= 0 {
+		cfg.LocalCache.TTLSeconds = 30
+	}
+	if cfg.RequestSigning.TimestampToleranceSeconds <= 0 {
+		cfg.RequestSigning.TimestampToleranceSeconds = 300
+	}
+	if cfg.PasswordReset.TokenTTLMinutes <= 0 {
+		cfg.PasswordReset.TokenTTLMinutes = 30
+	}
+	if cfg.PasswordReset.ResendCooldownSeconds <= 0 {
+		cfg.PasswordReset.ResendCooldownSeconds = 60
+	}
+	if cfg.SMTP.Port <= 0 {
+		cfg.SMTP.Port = 587
+	}
+	if cfg.RateLimit.WindowSeconds <= 0 {
+		cfg.RateLimit.WindowSeconds = 60
+	}
+	if cfg.RateLimit.Default == (RateLimitRule{}) {
+		cfg.RateLimit.Default = RateLimitRule{PerUser: 100, PerIP: 100}
+	}
+	if cfg.Usage.RollupIntervalMinutes <= 0 {
+		cfg.Usage.RollupIntervalMinutes = 60
+	}
+	if cfg.CoreLogic.CircuitBreaker.FailureThreshold <= 0 {
+		cfg.CoreLogic.CircuitBreaker.FailureThreshold = 5
+	}
+	if cfg.CoreLogic.CircuitBreaker.CooldownSeconds <= 0 {
+		cfg.CoreLogic.CircuitBreaker.CooldownSeconds = 30
+	}
+	if cfg.Idempotency.TTLHours <= 0 {
+		cfg.Idempotency.TTLHours = 24
+	}
+	if cfg.Notifications.RetentionDays <= 0 {
+		cfg.Notifications.RetentionDays = 90
+	}
+	if cfg.SavedSearches.RunIntervalMinutes <= 0 {
+		cfg.SavedSearches.RunIntervalMinutes = 60
+	}
+	if cfg.SavedSearches.MaxPerUser <= 0 {
+		cfg.SavedSearches.MaxPerUser = 25
+	}
+	if cfg.SavedSearches.MaxMatchesPerRun <= 0 {
+		cfg.SavedSearches.MaxMatchesPerRun = 200
+	}
+	if cfg.Valuations.CacheTTLHours <= 0 {
+		cfg.Valuations.CacheTTLHours = 24
+	}
+	if cfg.Valuations.RefreshAfterDays <= 0 {
+		cfg.Valuations.RefreshAfterDays = 30
+	}
+	if cfg.Markets.StatsCacheTTLHours <= 0 {
+		cfg.Markets.StatsCacheTTLHours = 36
+	}
+	if cfg.Locations.CacheTTLHours <= 0 {
+		cfg.Locations.CacheTTLHours = 24
+	}
+	if len(cfg.OwnerSearch.Roles) == 0 {
+		cfg.OwnerSearch.Roles = []string{"admin"}
+	}
+	if cfg.Webhooks.MaxAttempts <= 0 {
+		cfg.Webhooks.MaxAttempts = 6
+	}
+	if cfg.Webhooks.InitialBackoffSeconds <= 0 {
+		cfg.Webhooks.InitialBackoffSeconds = 2
+	}
+	if cfg.Webhooks.MaxBackoffSeconds <= 0 {
+		cfg.Webhooks.MaxBackoffSeconds = 300
+	}
+	if cfg.Webhooks.TimeoutSeconds <= 0 {
+		cfg.Webhooks.TimeoutSeconds = 10
+	}
+	if cfg.Webhooks.MaxConcurrent <= 0 {
+		cfg.Webhooks.MaxConcurrent = 8
+	}
+	if len(cfg.PropertyData.Providers) == 0 {
+		cfg.PropertyData.Providers = []PropertyDataProvider{{Name: "corelogic"}}
+	}
+	for i := range cfg.PropertyData.Providers {
+		provider := &cfg.PropertyData.Providers[i]
+		switch provider.Name {
+		case "corelogic":
+		case "attom":
+			if cfg.PropertyData.ATTOM.APIKey == "" {
+				return nil, fmt.Errorf("ATTOM_API_KEY is required when the attom provider is enabled")
+			}
+		default:
+			return nil, fmt.Errorf("property_data.providers[%d].name must be one of corelogic, attom; made-up data is served by sandbox mode only", i)
+		}
+		if provider.Fallback == "" {
+			provider.Fallback = "errors"
+		}
+		if provider.Fallback != "none" && provider.Fallback != "errors" && provider.Fallback != "all" {
+			return nil, fmt.Errorf("property_data.providers[%d].fallback must be one of none, errors, all", i)
+		}
+	}
+	if cfg.PropertyData.ATTOM.BaseURL == "" {
+		cfg.PropertyData.ATTOM.BaseURL = "https://api.gateway.attomdata.com/propertyapi/v1.0.0"
+	}
+	if cfg.PropertyData.ATTOM.TimeoutSeconds <= 0 {
+		cfg.PropertyData.ATTOM.TimeoutSeconds = 15
+	}
+	if cfg.AddressMatching.MinConfidence <= 0 {
+		cfg.AddressMatching.MinConfidence = 0.7
+	}
+	if cfg.AddressMatching.MinConfidence > 1 {
+		return nil, fmt.Errorf("address_matching.min_confidence must be between 0 and 1")
+	}
+	if cfg.AddressMatching.MaxCandidates <= 0 {
+		cfg.AddressMatching.MaxCandidates = 50
+	}
+	switch cfg.AddressStandardization.Provider {
+	case "":
+	case "usps":
+		if cfg.AddressStandardization.USPS.ClientID == "" || cfg.AddressStandardization.USPS.ClientSecret == "" {
+			return nil, fmt.Errorf("address_standardization.usps.client_id and USPS_CLIENT_SECRET are required when the usps provider is enabled")
+		}
+	case "smarty":
+		if cfg.AddressStandardization.Smarty.AuthID == "" || cfg.AddressStandardization.Smarty.AuthToken == "" {
+			return nil, fmt.Errorf("address_standardization.smarty.auth_id and SMARTY_AUTH_TOKEN are required when the smarty provider is enabled")
+		}
+	case "google":
+		if cfg.AddressStandardization.Google.APIKey == "" {
+			return nil, fmt.Errorf("GOOGLE_GEOCODING_API_KEY is required when the google provider is enabled")
+		}
+	default:
+		return nil, fmt.Errorf("address_standardization.provider must be one of usps, smarty, google")
+	}
+	if cfg.AddressStandardization.TimeoutSeconds <= 0 {
+		cfg.AddressStandardization.TimeoutSeconds = 5
+	}
+	if cfg.AddressStandardization.CacheTTLHours <= 0 {
+		cfg.AddressStandardization.CacheTTLHours = 720
+	}
+	if cfg.AddressStandardization.USPS.BaseURL == "" {
+		cfg.AddressStandardization.USPS.BaseURL = "https://apis.usps.com"
+	}
+	if cfg.AddressStandardization.Smarty.BaseURL == "" {
+		cfg.AddressStandardization.Smarty.BaseURL = "https://us-street.api.smarty.com"
+	}
+	if cfg.AddressStandardization.Google.BaseURL == "" {
+		cfg.AddressStandardization.Google.BaseURL = "https://maps.googleapis.com"
+	}
+	switch cfg.Hazard.Provider {
+	case "", "fema":
+	default:
+		return nil, fmt.Errorf("hazard.provider must be fema")
+	}
+	if cfg.Hazard.TimeoutSeconds <= 0 {
+		cfg.Hazard.TimeoutSeconds = 10
+	}
+	if cfg.Hazard.CacheTTLHours <= 0 {
+		cfg.Hazard.CacheTTLHours = 2160
+	}
+	if cfg.Hazard.FEMA.FloodBaseURL == "" {
+		cfg.Hazard.FEMA.FloodBaseURL = "https://hazards.fema.gov/arcgis/rest/services/public/NFHL/MapServer/28"
+	}
+	switch cfg.Neighborhood.Provider {
+	case "", "osm":
+	default:
+		return nil, fmt.Errorf("neighborhood.provider must be osm")
+	}
+	if cfg.Neighborhood.TimeoutSeconds <= 0 {
+		cfg.Neighborhood.TimeoutSeconds = 10
+	}
+	if cfg.Neighborhood.CacheTTLHours <= 0 {
+		cfg.Neighborhood.CacheTTLHours = 2160
+	}
+	if cfg.Neighborhood.RadiusMeters <= 0 {
+		cfg.Neighborhood.RadiusMeters = 800
+	}
+	if cfg.Neighborhood.OSM.BaseURL == "" {
+		cfg.Neighborhood.OSM.BaseURL = "https://overpass-api.de/api/interpreter"
+	}
+	if cfg.Census.BaseURL == "" {
+		cfg.Census.BaseURL = "https://api.census.gov/data"
+	}
+	if cfg.Census.GeocoderURL == "" {
+		cfg.Census.GeocoderURL = "https://geocoding.geo.census.gov/geocoder"
+	}
+	if cfg.Census.Year == 0 {
+		cfg.Census.Year = 2023
+	}
+	if cfg.Census.TimeoutSeconds <= 0 {
+		cfg.Census.TimeoutSeconds = 10
+	}
+	if cfg.Census.CacheTTLHours <= 0 {
+		cfg.Census.CacheTTLHours = 8760
+	}
+	if cfg.Media.Enabled {
+		switch cfg.Media.Storage {
+		case "s3":
+			if cfg.Media.Region == "" {
+				cfg.Media.Region = "us-east-1"
+			}
+			if cfg.Media.Endpoint == "" {
+				cfg.Media.Endpoint = "https://s3." + cfg.Media.Region + ".amazonaws.com"
+			}
+		case "gcs":
+			if cfg.Media.Region == "" {
+				cfg.Media.Region = "auto"
+			}
+			if cfg.Media.Endpoint == "" {
+				cfg.Media.Endpoint = "https://storage.googleapis.com"
+			}
+		default:
+			return nil, fmt.Errorf("media.storage must be one of s3, gcs")
+		}
+		if cfg.Media.Bucket == "" || cfg.Media.AccessKeyID == "" || cfg.Media.SecretAccessKey == "" {
+			return nil, fmt.Errorf("media.bucket, media.access_key_id and MEDIA_SECRET_ACCESS_KEY are required when media is enabled")
+		}
+	}
+	if cfg.Media.TimeoutSeconds <= 0 {
+		cfg.Media.TimeoutSeconds = 30
+	}
+	if cfg.Media.SignedURLTTLMinutes <= 0 {
+		cfg.Media.SignedURLTTLMinutes = 60
+	}
+	if cfg.Media.MaxUploadMB <= 0 {
+		cfg.Media.MaxUploadMB = 20
+	}
+	if cfg.Media.ThumbnailSize <= 0 {
+		cfg.Media.ThumbnailSize = 320
+	}
+	if len(cfg.Media.DocumentContentTypes) == 0 {
+		cfg.Media.DocumentContentTypes = []string{"application/pdf"}
+	}
+	if cfg.ChangeStream.RetrySeconds <= 0 {
+		cfg.ChangeStream.RetrySeconds = 15
+	}
+	if cfg.Events.RelayIntervalSeconds <= 0 {
+		cfg.Events.RelayIntervalSeconds = 5
+	}
+	if cfg.Events.BatchSize <= 0 {
+		cfg.Events.BatchSize = 100
+	}
+	if cfg.Events.PublishTimeoutSeconds <= 0 {
+		cfg.Events.PublishTimeoutSeconds = 10
+	}
+	if cfg.Events.InitialBackoffSeconds <= 0 {
+		cfg.Events.InitialBackoffSeconds = 5
+	}
+	if cfg.Events.MaxBackoffSeconds <= 0 {
+		cfg.Events.MaxBackoffSeconds = 600
+	}
+	if cfg.Events.RetentionHours <= 0 {
+		cfg.Events.RetentionHours = 72
+	}
+	if cfg.Events.Kafka.Topic == "" {
+		cfg.Events.Kafka.Topic = "property-events"
+	}
+	if cfg.Events.NATS.SubjectPrefix == "" {
+		cfg.Events.NATS.SubjectPrefix = "homeinsight"
+	}
+	if cfg.Events.Enabled {
+		switch cfg.Events.Broker {
+		case "kafka":
+			if cfg.Events.Kafka.RestProxyURL == "" {
+				return nil, fmt.Errorf("events.kafka.rest_proxy_url is required for the kafka broker")
+			}
+		case "nats":
+			if cfg.Events.NATS.URL == "" {
+				return nil, fmt.Errorf("events.nats.url is required for the nats broker")
+			}
+		default:
+			return nil, fmt.Errorf("events.broker must be one of kafka, nats")
+		}
+	}
+	if cfg.SearchIndex.Index == "" {
+		cfg.SearchIndex.Index = "properties"
+	}
+	if cfg.SearchIndex.TimeoutSeconds <= 0 {
+		cfg.SearchIndex.TimeoutSeconds = 5
+	}
+	if cfg.SearchIndex.BulkSize <= 0 {
+		cfg.SearchIndex.BulkSize = 500
+	}
+	if cfg.SearchIndex.Enabled && cfg.SearchIndex.URL == "" {
+		return nil, fmt.Errorf("search_index.url is required when the search index is enabled")
+	}
+	if cfg.Jobs.Workers <= 0 {
+		cfg.Jobs.Workers = 4
+	}
+	if cfg.Jobs.MaxAttempts <= 0 {
+		cfg.Jobs.MaxAttempts = 5
+	}
+	if cfg.Jobs.InitialBackoffSeconds <= 0 {
+		cfg.Jobs.InitialBackoffSeconds = 5
+	}
+	if cfg.Jobs.MaxBackoffSeconds <= 0 {
+		cfg.Jobs.MaxBackoffSeconds = 600
+	}
+	if cfg.Jobs.TimeoutSeconds <= 0 {
+		cfg.Jobs.TimeoutSeconds = 300
+	}
+	if cfg.Jobs.PollIntervalMS <= 0 {
+		cfg.Jobs.PollIntervalMS = 1000
+	}
+	if cfg.Jobs.RetentionHours <= 0 {
+		cfg.Jobs.RetentionHours = 24
+	}
+	if cfg.Jobs.DeadLetterMax <= 0 {
+		cfg.Jobs.DeadLetterMax = 1000
+	}
+	if cfg.Jobs.ImportMaxProperties <= 0 {
+		cfg.Jobs.ImportMaxProperties = 1000
+	}
+	if cfg.Jobs.BulkMaxProperties <= 0 {
+		cfg.Jobs.BulkMaxProperties = 10000
+	}
+	if cfg.Jobs.BulkBatchSize <= 0 {
+		cfg.Jobs.BulkBatchSize = 200
+	}
+	if cfg.Database.SlowQueryMS <= 0 {
+		cfg.Database.SlowQueryMS = 200
+	}
+	if cfg.Database.Transactions.MaxAttempts <= 0 {
+		cfg.Database.Transactions.MaxAttempts = 3
+	}
+	if cfg.Database.SchemaValidation == "" {
+		cfg.Database.SchemaValidation = "warn"
+	}
+	for class, operation := range map[string]DatabaseOperation{
+		"read":   cfg.Database.Operations.Read,
+		"list":   cfg.Database.Operations.List,
+		"export": cfg.Database.Operations.Export,
+		"write":  cfg.Database.Operations.Write,
+	} {
+		if operation.MaxStalenessSeconds > 0 && operation.MaxStalenessSeconds < 90 {
+			return nil, fmt.Errorf("database.operations.%s.max_staleness_seconds must be 0 or at least 90", class)
+		}
+		if operation.MaxStalenessSeconds > 0 && (operation.ReadPreference == "" || operation.ReadPreference == "primary") {
+			return nil, fmt.Errorf("database.operations.%s.max_staleness_seconds needs a read_preference other than primary", class)
+		}
+		if w := operation.WriteConcern; w != "" && w != "majority" {
+			if n, err := strconv.Atoi(w); err != nil || n < 1 {
+				return nil, fmt.Errorf("database.operations.%s.write_concern must be majority or a number of at least 1", class)
+			}
+		}
+	}
+	// writes read the documents they change, which only the primary has up to date
+	if pref := cfg.Database.Operations.Write.ReadPreference; pref != "" && pref != "primary" {
+		return nil, fmt.Errorf("database.operations.write.read_preference must be primary")
+	}
+	if cfg.Health.CheckTimeoutSeconds <= 0 {
+		cfg.Health.CheckTimeoutSeconds = 3
+	}
+	if cfg.Health.MaxQueueDepth <= 0 {
+		cfg.Health.MaxQueueDepth = 1000
+	}
+	if cfg.Migrations.BatchSize <= 0 {
+		cfg.Migrations.BatchSize = 500
+	}
+	if cfg.Migrations.SliceSeconds <= 0 {
+		cfg.Migrations.SliceSeconds = 240
+	}
+	if cfg.Feeds.Storage == "" {
+		cfg.Feeds.Storage = "local"
+	}
+	if cfg.Feeds.Storage == "media" && !cfg.Media.Enabled {
+		return nil, fmt.Errorf("feeds.storage media requires media to be enabled")
+	}
+	if cfg.Feeds.SpoolDir == "" {
+		cfg.Feeds.SpoolDir = filepath.Join(os.TempDir(), "homeinsight-feeds")
+	}
+	if cfg.Feeds.MaxUploadMB <= 0 {
+		cfg.Feeds.MaxUploadMB = 512
+	}
+	if cfg.Feeds.BatchSize <= 0 {
+		cfg.Feeds.BatchSize = 200
+	}
+	if cfg.Feeds.SliceSeconds <= 0 {
+		cfg.Feeds.SliceSeconds = 240
+	}
+	if cfg.Feeds.MaxRowErrors <= 0 {
+		cfg.Feeds.MaxRowErrors = 1000
+	}
+	if cfg.Notifications.DailyDigestHourUTC < 0 || cfg.Notifications.DailyDigestHourUTC > 23 {
+		return nil, fmt.Errorf("notifications.daily_digest_hour_utc must be between 0 and 23")
+	}
+	if cfg.Markets.StatsRefreshHourUTC < 0 || cfg.Markets.StatsRefreshHourUTC > 23 {
+		return nil, fmt.Errorf("markets.stats_refresh_hour_utc must be between 0 and 23")
+	}
+	if len(cfg.CORS.AllowedMethods) == 0 {
+		cfg.CORS.AllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	}
+	if cfg.CORS.MaxAgeSeconds <= 0 {
+		cfg.CORS.MaxAgeSeconds = 12 * 60 * 60
+	}
+	// Browsers refuse credentialed responses that allow every origin
+	if cfg.CORS.AllowCredentials && slices.Contains(cfg.CORS.AllowedOrigins, "*") {
+		return nil, fmt.Errorf("cors.allow_credentials cannot be used when cors.allowed_origins contains \"*\"")
+	}
+	if err := validateSchema(cfg); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
+
+// fill unset cache TTL bounds with defaults, in minutes
+func applyCacheTTLDefaults(bounds *CacheTTLBounds, base, min, max int) {
+	if bounds.BaseMinutes <= 0 {
+		bounds.BaseMinutes = base
+	}
+	if bounds.MinMinutes <= 0 {
+		bounds.MinMinutes = min
+	}
+	if bounds.MaxMinutes <= 0 {
+		bounds.MaxMinutes = max
+	}
+}
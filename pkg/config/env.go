@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// EnvPrefix starts the environment variable overriding a setting. The rest of the name is the
+// setting's YAML path in upper case, joined by underscores: cache_ttl.property.base_minutes is set
+// by APP_CACHE_TTL_PROPERTY_BASE_MINUTES. Strings, numbers, booleans, durations and comma-separated
+// string lists can be set this way; maps and lists of sections only from YAML.
+const EnvPrefix = "APP_"
+
+// applyEnvOverrides sets every setting whose environment variable is present, so the environment
+// takes precedence over the YAML file. Defaults are filled in afterwards for settings neither sets.
+func applyEnvOverrides(cfg *Config) error {
+	return applyEnvTo(reflect.ValueOf(cfg).Elem(), strings.TrimSuffix(EnvPrefix, "_"))
+}
+
+func applyEnvTo(value reflect.Value, name string) error {
+	if value.Kind() == reflect.Struct {
+		t := value.Type()
+		for i := 0; i < t.NumField(); i++ {
+			key := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+			if key == "" || key == "-" {
+				continue
+			}
+			if err := applyEnvTo(value.Field(i), name+"_"+strings.ToUpper(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	raw, ok := os.LookupEnv(name)
+	if !ok {
+		return nil
+	}
+	if err := setFromString(value, raw); err != nil {
+		return fmt.Errorf("%s: %v", name, err)
+	}
+	return nil
+}
+
+func setFromString(value reflect.Value, raw string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if value.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(raw)
+			if err != nil {
+				return err
+			}
+			value.SetInt(int64(d))
+			return nil
+		}
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		value.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("only lists of strings can be set from the environment")
+		}
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("%s settings can't be set from the environment", value.Kind())
+	}
+	return nil
+}
+
+// validateSchema checks the validate tags of every setting once defaults are filled in, reporting
+// each failure by its YAML path.
+func validateSchema(cfg *Config) error {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("yaml"), ",")[0]
+	})
+	err := v.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+	fieldErrors, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return err
+	}
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fieldErr := range fieldErrors {
+		// Namespaces start with the struct name, Config
+		path := strings.TrimPrefix(fieldErr.Namespace(), "Config.")
+		rule := fieldErr.Tag()
+		if fieldErr.Param() != "" {
+			rule += "=" + fieldErr.Param()
+		}
+		messages = append(messages, fmt.Sprintf("%s fails %s", path, rule))
+	}
+	return fmt.Errorf("invalid config: %s", strings.Join(messages, "; "))
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"homeinsight-properties/pkg/logger"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce collapses the burst of events an editor or a config map update makes into one reload.
+const reloadDebounce = 500 * time.Millisecond
+
+var (
+	current     atomic.Pointer[Config]
+	listenersMu sync.Mutex
+	listeners   []func(cfg *Config)
+)
+
+// Load reads the configuration at path and makes it the one Current returns.
+func Load(path string) (*Config, error) {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	current.Store(cfg)
+	return cfg, nil
+}
+
+// Current returns the configuration in effect, including hot-reloaded settings. It is nil until Load
+// is called. Read it each time a reloadable setting is used rather than holding on to it.
+func Current() *Config {
+	return current.Load()
+}
+
+// OnReload registers fn to be called with the new configuration after each reload, for components
+// that copy reloadable settings at startup.
+func OnReload(fn func(cfg *Config)) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+	listeners = append(listeners, fn)
+}
+
+// reloadable copies the settings that take effect without a restart from one configuration to
+// another: cache TTL bounds, rate limits, login lockouts, staleness thresholds, caching windows and
+// address matching.
+// Everything else, such as connection settings and secrets, is read once at startup.
+func reloadable(dst, src *Config) {
+	dst.CacheTTL.JitterPercent = src.CacheTTL.JitterPercent
+	dst.CacheTTL.Property = src.CacheTTL.Property
+	dst.CacheTTL.Search = src.CacheTTL.Search
+	dst.CacheTTL.List = src.CacheTTL.List
+	dst.RateLimit = src.RateLimit
+	dst.HTTPCache = src.HTTPCache
+	dst.LoginProtection = src.LoginProtection
+	dst.Database.StaleThresholdDays = src.Database.StaleThresholdDays
+	dst.Valuations.RefreshAfterDays = src.Valuations.RefreshAfterDays
+	dst.Markets.StatsCacheTTLHours = src.Markets.StatsCacheTTLHours
+	dst.Locations.CacheTTLHours = src.Locations.CacheTTLHours
+	dst.AddressMatching = src.AddressMatching
+}
+
+// Live returns the configuration in effect when one was loaded with Load, and c otherwise. Components
+// handed a configuration at startup read reloadable settings through it.
+func (c *Config) Live() *Config {
+	if cfg := Current(); cfg != nil {
+		return cfg
+	}
+	return c
+}
+
+// Watch reloads the configuration whenever the file at path changes, applying its reloadable
+// settings. A file that no longer loads or validates is logged and ignored. The directory is
+// watched rather than the file, so replacing the file, as editors and Kubernetes config maps do,
+// is noticed too. Call the returned function to stop watching.
+func Watch(path string) (func(), error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	name := filepath.Clean(path)
+	done := make(chan struct{})
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				// Config maps swap a symlinked ..data directory rather than writing the file
+				if filepath.Clean(event.Name) != name && filepath.Base(event.Name) != "..data" {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(reloadDebounce, func() { reload(path) })
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.GlobalLogger.Warnf("Config watcher error: path=%s, error=%v", path, err)
+			case <-done:
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			watcher.Close()
+		})
+	}, nil
+}
+
+// reload loads the file again and makes a copy of the running configuration with the new reloadable
+// settings current. Changes to other settings are reported as waiting for a restart.
+func reload(path string) {
+	running := Current()
+	if running == nil {
+		return
+	}
+	loaded, err := LoadConfig(path)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Config reload failed, keeping the running config: path=%s, error=%v", path, err)
+		return
+	}
+
+	next := *running
+	reloadable(&next, loaded)
+	if pending := changedSections(&next, loaded); len(pending) > 0 {
+		logger.GlobalLogger.Warnf("Config changes need a restart to take effect: sections=%v", pending)
+	}
+	if reflect.DeepEqual(&next, running) {
+		return
+	}
+	current.Store(&next)
+	logger.GlobalLogger.Printf("Config reloaded: path=%s", path)
+
+	listenersMu.Lock()
+	fns := append([]func(cfg *Config){}, listeners...)
+	listenersMu.Unlock()
+	for _, fn := range fns {
+		fn(&next)
+	}
+}
+
+// changedSections lists the top-level sections that differ between two configurations.
+func changedSections(a, b *Config) []string {
+	var sections []string
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := 0; i < va.NumField(); i++ {
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			sections = append(sections, va.Type().Field(i).Tag.Get("yaml"))
+		}
+	}
+	return sections
+}
@@ -1,7 +1,9 @@
 package corelogic
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
 )
 
 // TokenResponse represents the OAuth token response from CoreLogic
@@ -24,8 +27,8 @@ func (c *Client) isTokenValid() bool {
 }
 
 // buildTokenRequest constructs the HTTP request for the token endpoint
-func (c *Client) buildTokenRequest(tokenURL string) (*http.Request, error) {
-	req, err := http.NewRequest("POST", tokenURL, nil)
+func (c *Client) buildTokenRequest(ctx context.Context, tokenURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, nil)
 	if err != nil {
 		logger.GlobalLogger.Errorf("Failed to create token request: url=%s, error=%v", tokenURL, err)
 		return nil, fmt.Errorf("failed to create token request: %v", err)
@@ -38,13 +41,15 @@ func (c *Client) buildTokenRequest(tokenURL string) (*http.Request, error) {
 // executeTokenRequest sends the HTTP request with retry logic
 func (c *Client) executeTokenRequest(req *http.Request, tokenURL string, maxRetries int) (*http.Response, error) {
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		resp, err := c.httpClient.Do(req)
+		resp, err := c.do(req, opToken, false)
 		if err != nil {
 			logger.GlobalLogger.Errorf("Failed to send token request (attempt %d/%d): url=%s, error=%v", attempt, maxRetries, tokenURL, err)
-			if attempt == maxRetries {
-				return nil, fmt.Errorf("failed to send token request after %d attempts: %v", maxRetries, err)
+			if attempt == maxRetries || req.Context().Err() != nil || errors.Is(err, ErrCircuitOpen) {
+				return nil, fmt.Errorf("failed to send token request after %d attempts: %v", attempt, err)
+			}
+			if err := sleepContext(req.Context(), time.Duration(attempt)*time.Second); err != nil {
+				return nil, fmt.Errorf("failed to send token request after %d attempts: %v", attempt, err)
 			}
-			time.Sleep(time.Duration(attempt) * time.Second)
 			continue
 		}
 		if resp.StatusCode != http.StatusOK {
@@ -54,7 +59,9 @@ func (c *Client) executeTokenRequest(req *http.Request, tokenURL string, maxRetr
 			if attempt == maxRetries {
 				return nil, fmt.Errorf("failed to get token after %d attempts: %s, response: %s", maxRetries, resp.Status, string(body))
 			}
-			time.Sleep(time.Duration(attempt) * time.Second)
+			if err := sleepContext(req.Context(), time.Duration(attempt)*time.Second); err != nil {
+				return nil, fmt.Errorf("failed to get token after %d attempts: %v", attempt, err)
+			}
 			continue
 		}
 		return resp, nil
@@ -90,18 +97,41 @@ func (c *Client) updateTokenState(tokenResp TokenResponse, tokenURL string) erro
 	return nil
 }
 
+// sleepContext waits between retries, returning early if ctx ends
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // getToken retrieves or refreshes the access token
-func (c *Client) getToken() (string, error) {
+func (c *Client) getToken(ctx context.Context) (string, error) {
 	if c.isTokenValid() {
 		return c.token, nil
 	}
 
+	token, err := c.refreshToken(ctx)
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+	}
+	metrics.CoreLogicTokenRefreshesTotal.WithLabelValues(outcome).Inc()
+	return token, err
+}
+
+// refreshToken requests a new access token and stores it with its expiry
+func (c *Client) refreshToken(ctx context.Context) (string, error) {
 	data := url.Values{}
 	data.Set("grant_type", "client_credentials")
-	tokenURL := "https://api-prod.corelogic.com/oauth/token?" + data.Encode()
+	tokenURL := c.tokenURL + "?" + data.Encode()
 	maxRetries := 3
 
-	req, err := c.buildTokenRequest(tokenURL)
+	req, err := c.buildTokenRequest(ctx, tokenURL)
 	if err != nil {
 		return "", err
 	}
@@ -123,3 +153,10 @@ func (c *Client) getToken() (string, error) {
 
 	return c.token, nil
 }
+
+// CheckToken makes sure the client holds an unexpired access token, fetching a new one if needed, so
+// health checks can tell whether CoreLogic accepts our credentials without making a paid call.
+func (c *Client) CheckToken(ctx context.Context) error {
+	_, err := c.getToken(ctx)
+	return err
+}
@@ -0,0 +1,138 @@
+package corelogic
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/logger"
+)
+
+// ErrCircuitOpen is returned without calling CoreLogic while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("CoreLogic circuit breaker open")
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker stops calls to CoreLogic after a run of consecutive failures. Once the cooldown has
+// passed it lets a single probe through: success closes the circuit, failure opens it again.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+}
+
+// NewCircuitBreaker opens after threshold consecutive failures and probes again after cooldown.
+// A threshold of 0 disables the breaker.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow reports whether a call may be made now. Every allowed call must be followed by exactly one
+// of Success, Failure or Abandon.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerOpen {
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.transition(breakerHalfOpen)
+	}
+	if b.state == breakerHalfOpen {
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success records a call CoreLogic answered.
+func (b *CircuitBreaker) Success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+	b.transition(breakerClosed)
+}
+
+// Failure records a call CoreLogic failed to answer.
+func (b *CircuitBreaker) Failure() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		b.transition(breakerOpen)
+	}
+}
+
+// Abandon records a call cut short by our caller, which says nothing about CoreLogic's health.
+func (b *CircuitBreaker) Abandon() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State returns "closed", "open" or "half-open". A disabled breaker is always closed.
+func (b *CircuitBreaker) State() string {
+	if b == nil {
+		return breakerClosed.String()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state.String()
+}
+
+func (b *CircuitBreaker) transition(to breakerState) {
+	if b.state == to {
+		return
+	}
+	logger.GlobalLogger.Warnf("CoreLogic circuit breaker %s -> %s: consecutive_failures=%d", b.state, to, b.failures)
+	b.state = to
+}
+
+// isVendorFailure reports whether a response status means CoreLogic itself is struggling, as opposed
+// to rejecting or not finding what we asked for.
+func isVendorFailure(status int) bool {
+	return status >= http.StatusInternalServerError || status == http.StatusTooManyRequests
+}
@@ -2,10 +2,24 @@ package corelogic
 
 import (
 	"net/http"
+	"os"
+	"strconv"
 	"time"
 
+	"homeinsight-properties/pkg/metrics"
 )
 
+// Operations CoreLogic calls are labeled with in metrics.
+const (
+	opToken           = "token"
+	opPropertySearch  = "property_search"
+	opPropertyDetails = "property_details"
+	opValuation       = "valuation"
+)
+
+// DefaultTokenURL is CoreLogic's OAuth token endpoint.
+const DefaultTokenURL = "https://api-prod.corelogic.com/oauth/token"
+
 // Client manages CoreLogic API authentication and requests
 type Client struct {
 	username       string
@@ -14,16 +28,97 @@ type Client struct {
 	token          string
 	tokenExpiry    time.Time
 	httpClient     *http.Client
+	timeout        time.Duration
+	breaker        *CircuitBreaker
+	quota          *DailyQuota
+	tokenURL       string
+	proxyURL       string
 }
 
-// NewClient creates a new CoreLogic client
-func NewClient(username, password, developerEmail string) *Client {
+// NewClient creates a new CoreLogic client. Calls go through breaker and paid calls are counted
+// against quota; either may be nil to disable it. A property or valuation fetch gives up after timeout.
+func NewClient(username, password, developerEmail string, timeout time.Duration, breaker *CircuitBreaker, quota *DailyQuota) *Client {
 	return &Client{
 		username:       username,
 		password:       password,
 		developerEmail: developerEmail,
+		timeout:        timeout,
 		httpClient:     &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: timeout,
 		},
+		breaker:  breaker,
+		quota:    quota,
+		tokenURL: DefaultTokenURL,
+	}
+}
+
+// WithEndpoints points the client at another token endpoint and cloud function proxy, such as a fake
+// server in tests. An empty proxyURL keeps reading CORELOGIC_PROXY_URL.
+func (c *Client) WithEndpoints(tokenURL, proxyURL string) *Client {
+	c.tokenURL = tokenURL
+	c.proxyURL = proxyURL
+	return c
+}
+
+// proxyEndpoint returns the URL of the cloud function proxy running search, detail and avm tasks.
+func (c *Client) proxyEndpoint() string {
+	if c.proxyURL != "" {
+		return c.proxyURL
+	}
+	return os.Getenv("CORELOGIC_PROXY_URL")
+}
+
+// do sends a request to CoreLogic through the circuit breaker, first charging billable requests to
+// the daily quota. Server errors and throttling count as failures; calls our caller abandoned don't.
+// Every call is timed and every failed or refused one counted under operation.
+func (c *Client) do(req *http.Request, operation string, billable bool) (*http.Response, error) {
+	if err := c.breaker.Allow(); err != nil {
+		metrics.CoreLogicErrorsTotal.WithLabelValues(operation, "circuit_open").Inc()
+		return nil, err
 	}
+	if billable {
+		if err := c.quota.Take(req.Context()); err != nil {
+			c.breaker.Abandon()
+			metrics.CoreLogicErrorsTotal.WithLabelValues(operation, "quota_exhausted").Inc()
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	metrics.CoreLogicRequestDuration.WithLabelValues(operation, status).Observe(time.Since(start).Seconds())
+
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		c.breaker.Abandon()
+		metrics.CoreLogicErrorsTotal.WithLabelValues(operation, "canceled").Inc()
+	case err != nil:
+		c.breaker.Failure()
+		metrics.CoreLogicErrorsTotal.WithLabelValues(operation, "transport").Inc()
+	case isVendorFailure(resp.StatusCode):
+		c.breaker.Failure()
+		metrics.CoreLogicErrorsTotal.WithLabelValues(operation, vendorFailureReason(resp.StatusCode)).Inc()
+	default:
+		c.breaker.Success()
+		if resp.StatusCode >= http.StatusBadRequest {
+			metrics.CoreLogicErrorsTotal.WithLabelValues(operation, "client_error").Inc()
+		}
+	}
+	return resp, err
+}
+
+func vendorFailureReason(status int) string {
+	if status == http.StatusTooManyRequests {
+		return "throttled"
+	}
+	return "server_error"
+}
+
+// BreakerState returns the state of the client's circuit breaker.
+func (c *Client) BreakerState() string {
+	return c.breaker.State()
 }
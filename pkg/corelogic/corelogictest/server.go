@@ -0,0 +1,285 @@
+// Package corelogictest provides a fake CoreLogic for service and integration tests: an OAuth token
+// endpoint and a cloud function proxy answering search, detail and avm tasks from programmed data,
+// with injectable errors and latency to exercise retries, timeouts and the circuit breaker.
+package corelogictest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/corelogic"
+)
+
+// Tasks the fake answers; TaskToken is the token endpoint, the others proxy tasks.
+const (
+	TaskToken  = "token"
+	TaskSearch = "search"
+	TaskDetail = "detail"
+	TaskAVM    = "avm"
+)
+
+// Paths of the token endpoint and the proxy on the server.
+const (
+	tokenPath = "/oauth/token"
+	proxyPath = "/proxy"
+)
+
+// Response is a programmed reply. A zero Status sends the reply the fake would have sent anyway,
+// after Delay; otherwise Body is sent with Status. Delay ends early when the client gives up.
+type Response struct {
+	Status int
+	Body   string
+	Delay  time.Duration
+}
+
+// Request is a call the fake received.
+type Request struct {
+	Task          string
+	Body          map[string]interface{}
+	Authorization string
+}
+
+// Server is a running fake CoreLogic. Its zero state issues tokens to any credentials, finds no
+// addresses and fails nothing; program it with AddProperty, AddValuation, Enqueue and SetLatency.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	tokens     int
+	tokenTTL   time.Duration
+	issued     map[string]bool
+	clips      map[string]string // search address -> CLIP
+	details    map[string]map[string]interface{}
+	valuations map[string]corelogic.AVMResult
+	queued     map[string][]Response
+	latency    map[string]time.Duration
+	requests   []Request
+}
+
+// NewServer starts a fake CoreLogic. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		tokenTTL:   time.Hour,
+		issued:     make(map[string]bool),
+		clips:      make(map[string]string),
+		details:    make(map[string]map[string]interface{}),
+		valuations: make(map[string]corelogic.AVMResult),
+		queued:     make(map[string][]Response),
+		latency:    make(map[string]time.Duration),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(tokenPath, s.handleToken)
+	mux.HandleFunc(proxyPath, s.handleProxy)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// TokenURL is the fake's OAuth token endpoint.
+func (s *Server) TokenURL() string {
+	return s.URL + tokenPath
+}
+
+// ProxyURL is the fake's cloud function proxy.
+func (s *Server) ProxyURL() string {
+	return s.URL + proxyPath
+}
+
+// Client returns a CoreLogic client talking to the fake. breaker and quota may be nil.
+func (s *Server) Client(timeout time.Duration, breaker *corelogic.CircuitBreaker, quota *corelogic.DailyQuota) *corelogic.Client {
+	return corelogic.NewClient("corelogictest", "secret", "dev@example.com", timeout, breaker, quota).
+		WithEndpoints(s.TokenURL(), s.ProxyURL())
+}
+
+// AddProperty makes an address searchable, answering it with clip, and serves detail as the
+// property detail response for clip.
+func (s *Server) AddProperty(street, city, state, zip, clip string, detail map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clips[fullAddress(street, city, state, zip)] = clip
+	s.details[clip] = detail
+}
+
+// AddValuation serves result for avm tasks on clip.
+func (s *Server) AddValuation(clip string, result corelogic.AVMResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valuations[clip] = result
+}
+
+// Enqueue programs the next calls of a task to get responses, one each in order, before the task
+// goes back to its normal replies.
+func (s *Server) Enqueue(task string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queued[task] = append(s.queued[task], responses...)
+}
+
+// SetLatency delays every reply to a task by d.
+func (s *Server) SetLatency(task string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency[task] = d
+}
+
+// SetTokenTTL sets the lifetime of tokens issued from now on.
+func (s *Server) SetTokenTTL(ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokenTTL = ttl
+}
+
+// RevokeTokens invalidates every token issued so far; proxy calls using one get 401.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issued = make(map[string]bool)
+}
+
+// Requests returns the calls received for a task, or every call when task is empty.
+func (s *Server) Requests(task string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []Request
+	for _, r := range s.requests {
+		if task == "" || r.Task == task {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// Reset forgets programmed data, responses, latency and recorded calls.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clips = make(map[string]string)
+	s.details = make(map[string]map[string]interface{})
+	s.valuations = make(map[string]corelogic.AVMResult)
+	s.queued = make(map[string][]Response)
+	s.latency = make(map[string]time.Duration)
+	s.requests = nil
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := r.BasicAuth(); !ok || r.Method != http.MethodPost {
+		s.record(Request{Task: TaskToken})
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+	if s.programmed(w, r, Request{Task: TaskToken}) {
+		return
+	}
+
+	s.mu.Lock()
+	s.tokens++
+	token := "corelogictest-token-" + strconv.Itoa(s.tokens)
+	s.issued[token] = true
+	ttl := s.tokenTTL
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": token,
+		"expires_in":   strconv.Itoa(int(ttl.Seconds())),
+	})
+}
+
+func (s *Server) handleProxy(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, fmt.Sprintf(`{"error":"invalid body: %v"}`, err), http.StatusBadRequest)
+		return
+	}
+	task, _ := body["task"].(string)
+	call := Request{Task: task, Body: body, Authorization: r.Header.Get("Authorization")}
+	if s.programmed(w, r, call) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.issued[strings.TrimPrefix(call.Authorization, "Bearer ")] {
+		http.Error(w, `{"error":"invalid_token"}`, http.StatusUnauthorized)
+		return
+	}
+	switch task {
+	case TaskSearch:
+		items := []map[string]string{}
+		address, _ := body["fullAddress"].(string)
+		if clip, ok := s.clips[address]; ok {
+			items = append(items, map[string]string{"clip": clip, "v1PropertyId": clip})
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+	case TaskDetail:
+		clip, _ := body["clipId"].(string)
+		detail, ok := s.details[clip]
+		if !ok {
+			http.Error(w, `{"error":"Clip not found"}`, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, detail)
+	case TaskAVM:
+		clip, _ := body["clipId"].(string)
+		result, ok := s.valuations[clip]
+		if !ok {
+			http.Error(w, `{"error":"Clip not found"}`, http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, result)
+	default:
+		http.Error(w, fmt.Sprintf(`{"error":"unknown task %q"}`, task), http.StatusBadRequest)
+	}
+}
+
+// programmed records a call and waits out its latency, then sends its queued response if it has
+// one, reporting whether it did. It also reports true when the client gave up while waiting.
+func (s *Server) programmed(w http.ResponseWriter, r *http.Request, call Request) bool {
+	s.mu.Lock()
+	s.requests = append(s.requests, call)
+	delay := s.latency[call.Task]
+	var response *Response
+	if queue := s.queued[call.Task]; len(queue) > 0 {
+		response = &queue[0]
+		s.queued[call.Task] = queue[1:]
+		delay += response.Delay
+	}
+	s.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			return true
+		}
+	}
+	if response == nil || response.Status == 0 {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.Status)
+	w.Write([]byte(response.Body))
+	return true
+}
+
+func (s *Server) record(call Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, call)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// fullAddress formats an address as the client sends it in a search task.
+func fullAddress(street, city, state, zip string) string {
+	return fmt.Sprintf("%s, %s, %s %s", street, city, state, zip)
+}
@@ -0,0 +1,60 @@
+package corelogic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BudgetHeader tells the proxy how many milliseconds remain before the caller stops waiting, so it
+// can give up on the vendor call instead of finishing work nobody will read.
+const BudgetHeader = "X-Request-Budget-Ms"
+
+// budgetMargin is held back from the advertised budget to leave time for the response to reach us.
+const budgetMargin = 250 * time.Millisecond
+
+// ErrBudgetExhausted is returned when too little of the request budget is left to make a call.
+var ErrBudgetExhausted = errors.New("CoreLogic request budget exhausted")
+
+// requestContext returns the context outbound calls are bound to, cut off after the client's
+// timeout. For a gin context that is the underlying HTTP request's context, which is cancelled when
+// the client disconnects and carries the request budget deadline.
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ginCtx, ok := ctx.(*gin.Context); ok {
+		ctx = context.Background()
+		if ginCtx.Request != nil {
+			ctx = ginCtx.Request.Context()
+		}
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// applyBudget advertises the remaining budget of req's context to the proxy.
+func applyBudget(req *http.Request) error {
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		return nil
+	}
+	remaining := time.Until(deadline) - budgetMargin
+	if remaining <= 0 {
+		return ErrBudgetExhausted
+	}
+	req.Header.Set(BudgetHeader, strconv.FormatInt(remaining.Milliseconds(), 10))
+	return nil
+}
+
+// abortReason describes why ctx ended, for logging outbound calls cut short.
+func abortReason(ctx context.Context) string {
+	switch ctx.Err() {
+	case context.Canceled:
+		return "client disconnected"
+	case context.DeadlineExceeded:
+		return "timed out"
+	default:
+		return ""
+	}
+}
@@ -3,13 +3,14 @@ package corelogic
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "io"
     "net/http"
-    "os"
 
     "homeinsight-properties/pkg/logger"
+    "homeinsight-properties/pkg/requestid"
 )
 
 // structure for the detail task payload.
@@ -19,8 +20,8 @@ type DetailRequest struct {
 }
 
 // retrieve detailed property information using the cloud function proxy.
-func (c *Client) GetPropertyDetails(token, propertyId string) (map[string]interface{}, error) {
-    proxyURL := os.Getenv("CORELOGIC_PROXY_URL")
+func (c *Client) GetPropertyDetails(ctx context.Context, token, propertyId string) (map[string]interface{}, error) {
+    proxyURL := c.proxyEndpoint()
     if proxyURL == "" {
         return nil, fmt.Errorf("CORELOGIC_PROXY_URL environment variable is not set")
     }
@@ -39,18 +40,24 @@ func (c *Client) GetPropertyDetails(token, propertyId string) (map[string]interf
     }
 
     // Create the HTTP POST request
-    req, err := http.NewRequest("POST", proxyURL, bytes.NewBuffer(jsonBody))
+    req, err := http.NewRequestWithContext(ctx, "POST", proxyURL, bytes.NewBuffer(jsonBody))
     if err != nil {
         logger.GlobalLogger.Errorf("Failed to create detail request: error=%v", err)
         return nil, err
     }
+    if err := applyBudget(req); err != nil {
+        return nil, err
+    }
 
     // Set headers (Authorization and Content-Type)
     req.Header.Set("Authorization", "Bearer "+token)
     req.Header.Set("Content-Type", "application/json")
+    if id := requestid.FromContext(ctx); id != "" {
+        req.Header.Set(requestid.Header, id)
+    }
 
     // Send the HTTP request
-    resp, err := c.httpClient.Do(req)
+    resp, err := c.do(req, opPropertyDetails, true)
     if err != nil {
         logger.GlobalLogger.Errorf("Failed to send detail request to proxy: url=%s, error=%v", proxyURL, err)
         return nil, fmt.Errorf("failed to send detail request to proxy: %v", err)
@@ -82,11 +89,11 @@ func (c *Client) GetPropertyDetails(token, propertyId string) (map[string]interf
 }
 
 // retrieve detailed property information using clip.
-func (c *Client) GetPropertyDetailsByClip(token, clip string) (map[string]interface{}, error) {
-    return c.GetPropertyDetails(token, clip)
+func (c *Client) GetPropertyDetailsByClip(ctx context.Context, token, clip string) (map[string]interface{}, error) {
+    return c.GetPropertyDetails(ctx, token, clip)
 }
 
 // retrieve detailed property information using v1PropertyId.
-func (c *Client) GetPropertyDetailsByV1PropertyId(token, v1PropertyId string) (map[string]interface{}, error) {
-    return c.GetPropertyDetails(token, v1PropertyId)
+func (c *Client) GetPropertyDetailsByV1PropertyId(ctx context.Context, token, v1PropertyId string) (map[string]interface{}, error) {
+    return c.GetPropertyDetails(ctx, token, v1PropertyId)
 }
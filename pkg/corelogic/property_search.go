@@ -3,13 +3,14 @@ package corelogic
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "io"
     "net/http"
-    "os"
 
     "homeinsight-properties/pkg/logger"
+    "homeinsight-properties/pkg/requestid"
 )
 
 // structure for the search task payload.
@@ -27,8 +28,8 @@ type PropertySearchResponse struct {
 }
 
 // search for a property by address using the cloud function proxy.
-func (c *Client) SearchPropertyByAddress(token, street, city, state, zip string) (string, string, error) {
-    proxyURL := os.Getenv("CORELOGIC_PROXY_URL")
+func (c *Client) SearchPropertyByAddress(ctx context.Context, token, street, city, state, zip string) (string, string, error) {
+    proxyURL := c.proxyEndpoint()
     if proxyURL == "" {
         return "", "", fmt.Errorf("CORELOGIC_PROXY_URL environment variable is not set")
     }
@@ -48,18 +49,24 @@ func (c *Client) SearchPropertyByAddress(token, street, city, state, zip string)
     }
 
     // Create the HTTP POST request
-    req, err := http.NewRequest("POST", proxyURL, bytes.NewBuffer(jsonBody))
+    req, err := http.NewRequestWithContext(ctx, "POST", proxyURL, bytes.NewBuffer(jsonBody))
     if err != nil {
         logger.GlobalLogger.Errorf("Failed to create search request: error=%v", err)
         return "", "", err
     }
+    if err := applyBudget(req); err != nil {
+        return "", "", err
+    }
 
     // Set headers (Authorization and Content-Type)
     req.Header.Set("Authorization", "Bearer "+token)
     req.Header.Set("Content-Type", "application/json")
+    if id := requestid.FromContext(ctx); id != "" {
+        req.Header.Set(requestid.Header, id)
+    }
 
     // Send the HTTP request
-    resp, err := c.httpClient.Do(req)
+    resp, err := c.do(req, opPropertySearch, true)
     if err != nil {
         logger.GlobalLogger.Errorf("Failed to send search request to proxy: url=%s, error=%v", proxyURL, err)
         return "", "", fmt.Errorf("failed to send search request to proxy: %v", err)
@@ -0,0 +1,47 @@
+package corelogic
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// ErrDailyQuotaExhausted is returned without calling CoreLogic once the day's request quota is used up.
+var ErrDailyQuotaExhausted = errors.New("CoreLogic daily request quota exhausted")
+
+// DailyQuota caps the paid CoreLogic calls made per UTC day across all instances, counted in Redis.
+type DailyQuota struct {
+	limit int64
+}
+
+// NewDailyQuota allows limit calls per UTC day. A limit of 0 disables the quota.
+func NewDailyQuota(limit int64) *DailyQuota {
+	if limit <= 0 {
+		return nil
+	}
+	return &DailyQuota{limit: limit}
+}
+
+// Take counts one call against today's quota, failing once the quota is used up. If Redis is
+// unavailable the call is allowed, so a cache outage doesn't also take down property lookups.
+func (q *DailyQuota) Take(ctx context.Context) error {
+	if q == nil {
+		return nil
+	}
+	day := time.Now().UTC().Format("2006-01-02")
+	used, err := cache.IncrementCoreLogicUsage(ctx, day)
+	if err != nil {
+		logger.GlobalLogger.Warnf("CoreLogic quota not tracked: day=%s, error=%v", day, err)
+		return nil
+	}
+	if used > q.limit {
+		if used == q.limit+1 {
+			logger.GlobalLogger.Errorf("CoreLogic daily quota exhausted: day=%s, limit=%d", day, q.limit)
+		}
+		return ErrDailyQuotaExhausted
+	}
+	return nil
+}
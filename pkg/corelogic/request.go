@@ -20,22 +20,35 @@ func (c *Client) RequestCoreLogic(ctx context.Context, street, city, state, zip
 
     ginCtx.Set("data_source", "CORELOGIC_API")
 
+    // Bind vendor calls to the client's request so they stop when nobody is waiting
+    reqCtx, cancel := c.requestContext(ctx)
+    defer cancel()
+
     // Get the authentication token
-    token, err := c.getToken()
+    token, err := c.getToken(reqCtx)
     if err != nil {
+        if reason := abortReason(reqCtx); reason != "" {
+            logger.GlobalLogger.Warnf("CoreLogic call aborted: step=token, reason=%s", reason)
+        }
         logger.GlobalLogger.Errorf("Failed to get token: error=%v", err)
         return nil, fmt.Errorf("failed to get authentication token: %v", err)
     }
 
     // Search for property by address
-    clip, v1PropertyId, err := c.SearchPropertyByAddress(token, street, city, state, zip)
+    clip, v1PropertyId, err := c.SearchPropertyByAddress(reqCtx, token, street, city, state, zip)
     if err != nil {
+        if reason := abortReason(reqCtx); reason != "" {
+            logger.GlobalLogger.Warnf("CoreLogic call aborted: step=search, reason=%s", reason)
+        }
         return nil, fmt.Errorf("failed to search property: %v", err)
     }
 
     // Get property details
-    details, err := c.GetPropertyDetails(token, clip)
+    details, err := c.GetPropertyDetails(reqCtx, token, clip)
     if err != nil {
+        if reason := abortReason(reqCtx); reason != "" {
+            logger.GlobalLogger.Warnf("CoreLogic call aborted: step=detail, clip=%s, reason=%s", clip, reason)
+        }
         logger.GlobalLogger.Errorf("CoreLogic details failed: clip=%s, error=%v", clip, err)
         return nil, fmt.Errorf("failed to get property details: %v", err)
     }
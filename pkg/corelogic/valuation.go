@@ -0,0 +1,115 @@
+package corelogic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/requestid"
+)
+
+// AVMRequest is the payload for the proxy's avm task.
+type AVMRequest struct {
+	Task          string `json:"task"`
+	ClipId        string `json:"clipId"`
+	AVMPropertyId string `json:"avmPropertyId,omitempty"`
+}
+
+// AVMResult is the automated valuation returned by the avm task.
+type AVMResult struct {
+	Clip string `json:"clip"`
+	AVM  struct {
+		ValuationDate             string  `json:"valuationDate"`
+		EstimatedValue            float64 `json:"estimatedValue"`
+		EstimatedValueLow         float64 `json:"estimatedValueLow"`
+		EstimatedValueHigh        float64 `json:"estimatedValueHigh"`
+		ConfidenceScore           float64 `json:"confidenceScore"`
+		ForecastStandardDeviation float64 `json:"forecastStandardDeviation"`
+	} `json:"avm"`
+}
+
+// RequestValuation fetches the current automated valuation for a property, bound to the caller's request.
+func (c *Client) RequestValuation(ctx context.Context, clip, avmPropertyId string) (*AVMResult, error) {
+	reqCtx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	token, err := c.getToken(reqCtx)
+	if err != nil {
+		if reason := abortReason(reqCtx); reason != "" {
+			logger.GlobalLogger.Warnf("CoreLogic call aborted: step=token, reason=%s", reason)
+		}
+		return nil, fmt.Errorf("failed to get authentication token: %v", err)
+	}
+
+	result, err := c.GetValuation(reqCtx, token, clip, avmPropertyId)
+	if err != nil {
+		if reason := abortReason(reqCtx); reason != "" {
+			logger.GlobalLogger.Warnf("CoreLogic call aborted: step=avm, clip=%s, reason=%s", clip, reason)
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetValuation runs the avm task through the cloud function proxy.
+func (c *Client) GetValuation(ctx context.Context, token, clip, avmPropertyId string) (*AVMResult, error) {
+	proxyURL := c.proxyEndpoint()
+	if proxyURL == "" {
+		return nil, fmt.Errorf("CORELOGIC_PROXY_URL environment variable is not set")
+	}
+
+	jsonBody, err := json.Marshal(AVMRequest{
+		Task:          "avm",
+		ClipId:        clip,
+		AVMPropertyId: avmPropertyId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", proxyURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to create avm request: error=%v", err)
+		return nil, err
+	}
+	if err := applyBudget(req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+
+	resp, err := c.do(req, opValuation, true)
+	if err != nil {
+		logger.GlobalLogger.Errorf("Failed to send avm request to proxy: url=%s, error=%v", proxyURL, err)
+		return nil, fmt.Errorf("failed to send CoreLogic avm request to proxy: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CoreLogic avm response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		logger.GlobalLogger.Errorf("AVM request to proxy failed: url=%s, status=%s, response=%s", proxyURL, resp.Status, string(body))
+		return nil, fmt.Errorf("CoreLogic avm request failed: %s, response: %s", resp.Status, string(body))
+	}
+
+	var result AVMResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		logger.GlobalLogger.Errorf("Failed to decode avm response: url=%s, response=%s, error=%v", proxyURL, string(body), err)
+		return nil, fmt.Errorf("failed to decode CoreLogic avm response: %v", err)
+	}
+	if result.AVM.EstimatedValue <= 0 {
+		return nil, fmt.Errorf("CoreLogic avm returned no estimate: clip=%s", clip)
+	}
+
+	logger.GlobalLogger.Printf("Property valuation retrieved successfully: clip=%s", clip)
+	return &result, nil
+}
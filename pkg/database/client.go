@@ -9,6 +9,7 @@ import (
 	"homeinsight-properties/pkg/logger"
 	"homeinsight-properties/pkg/metrics"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -16,14 +17,27 @@ import (
 var MongoClient *mongo.Client
 var DB *mongo.Database
 
+// transactionsSupported is whether the deployment is a replica set or sharded cluster, the only
+// topologies that run multi-document transactions.
+var transactionsSupported bool
+
+// operationTimeout bounds each repository call; see WithTimeout.
+var operationTimeout time.Duration
+
 // initialize the MongoDB client and database connection.
 func InitDB(cfg *config.Config) error {
+	if err := setOperationOptions(cfg); err != nil {
+		return err
+	}
+	operationTimeout = time.Duration(cfg.Database.OperationTimeoutMS) * time.Millisecond
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	clientOptions := options.Client().ApplyURI(cfg.Database.URI).
 		SetConnectTimeout(10 * time.Second).
-		SetMaxPoolSize(100)
+		SetMaxPoolSize(100).
+		SetMonitor(newSlowQueryMonitor(time.Duration(cfg.Database.SlowQueryMS) * time.Millisecond))
 
 	start := time.Now()
 	client, err := mongo.Connect(ctx, clientOptions)
@@ -45,6 +59,14 @@ func InitDB(cfg *config.Config) error {
 		return fmt.Errorf("failed to ping MongoDB: %v", err)
 	}
 
+	var hello bson.M
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		logger.GlobalLogger.Warnf("Failed to detect MongoDB topology, transactions disabled: %v", err)
+	} else {
+		_, replicaSet := hello["setName"]
+		transactionsSupported = replicaSet || hello["msg"] == "isdbgrid"
+	}
+
 	MongoClient = client
 	DB = client.Database(cfg.Database.DBName)
 
@@ -52,6 +74,20 @@ func InitDB(cfg *config.Config) error {
 	return nil
 }
 
+// WithTimeout bounds one repository call by the configured operation timeout, or by ctx's own
+// deadline when that comes first, so a slow query gives up instead of holding its caller.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if operationTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, operationTimeout)
+}
+
+// SupportsTransactions reports whether the connected deployment can run multi-document transactions.
+func SupportsTransactions() bool {
+	return transactionsSupported
+}
+
 // close the MongoDB client connection.
 func CloseDB() {
 	if MongoClient != nil {
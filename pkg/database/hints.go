@@ -0,0 +1,48 @@
+package database
+
+import "sync"
+
+// Query hints pin named repository queries to a specific index. They are swapped by the reindex
+// workflow once a replacement index is ready, and refreshed periodically so every instance converges.
+var (
+	hintsMu    sync.RWMutex
+	queryHints = map[string]string{}
+)
+
+// QueryHint returns the index name a named query should use, if one has been set.
+func QueryHint(query string) (string, bool) {
+	hintsMu.RLock()
+	defer hintsMu.RUnlock()
+	hint, ok := queryHints[query]
+	return hint, ok
+}
+
+// SetQueryHints replaces the full set of query hints.
+func SetQueryHints(hints map[string]string) {
+	next := make(map[string]string, len(hints))
+	for query, index := range hints {
+		next[query] = index
+	}
+	hintsMu.Lock()
+	queryHints = next
+	hintsMu.Unlock()
+}
+
+// SetQueryHint points a single named query at an index.
+func SetQueryHint(query, index string) {
+	hintsMu.Lock()
+	queryHints[query] = index
+	hintsMu.Unlock()
+}
+
+// ClearQueryHintsForIndex removes every hint that points at the given index, so queries fall back to
+// the planner before the index is dropped.
+func ClearQueryHintsForIndex(index string) {
+	hintsMu.Lock()
+	for query, hinted := range queryHints {
+		if hinted == index {
+			delete(queryHints, query)
+		}
+	}
+	hintsMu.Unlock()
+}
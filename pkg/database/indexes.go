@@ -1,7 +1,11 @@
 package database
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"homeinsight-properties/pkg/logger"
@@ -12,39 +16,518 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// create indexes for the properties collection to optimize search performance.
-func CreatePropertyIndexes(db *mongo.Database) error {
-	collection := db.Collection("properties")
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// AddressCollation compares property addresses case-insensitively. Queries must use it to be served
+// by the unique address index.
+var AddressCollation = &options.Collation{Locale: "en", Strength: 2}
 
-	start := time.Now()
-	_, err := collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
-		{
-			Keys:    bson.D{{Key: "propertyId", Value: 1}},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.D{{Key: "address.streetAddress", Value: 1}},
-		},
-		{
-			Keys: bson.D{{Key: "address.city", Value: 1}},
+// Server error codes for index builds. An index of the same name or keys existing with another
+// definition is a conflict; so is a changed key pattern under an existing name.
+const (
+	indexNotFoundErrorCode         = 27
+	indexOptionsConflictErrorCode  = 85
+	indexKeySpecsConflictErrorCode = 86
+)
+
+// Outcomes of ensuring an index.
+const (
+	IndexStatusCreated  = "created"
+	IndexStatusExists   = "exists"
+	IndexStatusRebuilt  = "rebuilt"
+	IndexStatusDropped  = "dropped"
+	IndexStatusSkipped  = "skipped"
+	IndexStatusConflict = "conflict"
+	IndexStatusFailed   = "failed"
+)
+
+// IndexSpec is an index the application relies on. Unnamed indexes get the server's default name,
+// built from their keys, so existing ones are recognized.
+type IndexSpec struct {
+	Collection string
+	Keys       bson.D
+	Options    *options.IndexOptions
+	// SkipOnDuplicates reports a unique index that existing duplicates keep from building instead of
+	// failing, so they can be merged first
+	SkipOnDuplicates bool
+}
+
+// Name is the index's name on the server.
+func (s IndexSpec) Name() string {
+	if s.Options != nil && s.Options.Name != nil {
+		return *s.Options.Name
+	}
+	parts := make([]string, 0, len(s.Keys))
+	for _, key := range s.Keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
+	}
+	return strings.Join(parts, "_")
+}
+
+func (s IndexSpec) model() mongo.IndexModel {
+	return mongo.IndexModel{Keys: s.Keys, Options: s.Options}
+}
+
+// replacedIndex is an index a registered one replaced; it is dropped when still there.
+type replacedIndex struct {
+	Collection string
+	Name       string
+}
+
+// indexRegistry lists every index of every collection, in the order they are built. Indexes an admin
+// builds through the reindex endpoint aren't listed and are left alone.
+var indexRegistry = []IndexSpec{
+	// properties
+	{
+		// Property IDs are unique within an organization; each organization keeps its own copy
+		Collection: "properties",
+		Keys:       bson.D{{Key: "orgId", Value: 1}, {Key: "propertyId", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{
+		// One document per address in each organization, compared case-insensitively
+		Collection: "properties",
+		Keys: bson.D{
+			{Key: "orgId", Value: 1},
+			{Key: "address.streetAddress", Value: 1},
+			{Key: "address.city", Value: 1},
+			{Key: "address.state", Value: 1},
+			{Key: "address.zipCode", Value: 1},
 		},
-		{
-			Keys: bson.D{{Key: "address.state", Value: 1}},
+		Options: options.Index().
+			SetName("property_org_address_unique").
+			SetUnique(true).
+			SetCollation(AddressCollation),
+		SkipOnDuplicates: true,
+	},
+	{Collection: "properties", Keys: bson.D{{Key: "address.streetAddress", Value: 1}}},
+	{
+		// Backs cursor pagination on GET /api/properties
+		Collection: "properties",
+		Keys:       bson.D{{Key: "address.streetAddress", Value: 1}, {Key: "_id", Value: 1}},
+	},
+	{Collection: "properties", Keys: bson.D{{Key: "address.city", Value: 1}}},
+	{Collection: "properties", Keys: bson.D{{Key: "address.state", Value: 1}}},
+	{Collection: "properties", Keys: bson.D{{Key: "address.zipCode", Value: 1}}},
+	{
+		// Nearby and polygon searches
+		Collection: "properties",
+		Keys:       bson.D{{Key: "location.coordinates.parcelPoint", Value: "2dsphere"}},
+	},
+	{Collection: "properties", Keys: bson.D{{Key: "updatedAt", Value: 1}}},
+	{
+		// Only properties in the trash carry deletedAt
+		Collection: "properties",
+		Keys:       bson.D{{Key: "deletedAt", Value: -1}},
+		Options:    options.Index().SetSparse(true),
+	},
+	{
+		// Sortable list fields; _id breaks ties so skip pages stay stable
+		Collection: "properties",
+		Keys:       bson.D{{Key: "address.city", Value: 1}, {Key: "_id", Value: 1}},
+	},
+	{Collection: "properties", Keys: bson.D{{Key: "address.zipCode", Value: 1}, {Key: "_id", Value: 1}}},
+	{Collection: "properties", Keys: bson.D{{Key: "building.summary.bedroomsCount", Value: 1}, {Key: "_id", Value: 1}}},
+	{Collection: "properties", Keys: bson.D{{Key: "building.summary.bathroomsCount", Value: 1}, {Key: "_id", Value: 1}}},
+	{Collection: "properties", Keys: bson.D{{Key: "building.details.construction.yearBuilt", Value: 1}, {Key: "_id", Value: 1}}},
+	{Collection: "properties", Keys: bson.D{{Key: "taxAssessment.assessedValue.totalValue", Value: 1}, {Key: "_id", Value: 1}}},
+	{Collection: "properties", Keys: bson.D{{Key: "lastMarketSale.amount", Value: 1}, {Key: "_id", Value: 1}}},
+	{
+		// Only properties with a hazard lookup carry a flood zone or wildfire score
+		Collection: "properties",
+		Keys:       bson.D{{Key: "hazard.floodZone", Value: 1}},
+		Options:    options.Index().SetSparse(true),
+	},
+	{
+		Collection: "properties",
+		Keys:       bson.D{{Key: "hazard.wildfireRiskScore", Value: 1}},
+		Options:    options.Index().SetSparse(true),
+	},
+	{
+		// Full-text search; MongoDB allows only one text index per collection
+		Collection: "properties",
+		Keys: bson.D{
+			{Key: "address.streetAddress", Value: "text"},
+			{Key: "address.city", Value: "text"},
+			{Key: "ownership.currentOwners.fullName", Value: "text"},
+			{Key: "location.legal.subdivisionName", Value: "text"},
+			{Key: "taxAssessment.schoolDistrict.name", Value: "text"},
 		},
-		{
-			Keys: bson.D{{Key: "address.zipCode", Value: 1}},
+		Options: options.Index().
+			SetName("property_text_search").
+			SetWeights(bson.D{
+				{Key: "address.streetAddress", Value: 10},
+				{Key: "address.city", Value: 5},
+				{Key: "ownership.currentOwners.fullName", Value: 5},
+				{Key: "location.legal.subdivisionName", Value: 3},
+				{Key: "taxAssessment.schoolDistrict.name", Value: 2},
+			}),
+	},
+
+	// owner_entities, used by portfolio lookups
+	{
+		Collection: "owner_entities",
+		Keys:       bson.D{{Key: "orgId", Value: 1}, {Key: "entityId", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{Collection: "owner_entities", Keys: bson.D{{Key: "propertyIds", Value: 1}}},
+	{
+		// Owner-name search, matching blind indexes of the name and its leading words since the
+		// name itself may be sealed
+		Collection: "owner_entities",
+		Keys:       bson.D{{Key: "orgId", Value: 1}, {Key: "nameIndex", Value: 1}},
+	},
+
+	// share_links
+	{
+		Collection: "share_links",
+		Keys:       bson.D{{Key: "linkId", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{Collection: "share_links", Keys: bson.D{{Key: "propertyId", Value: 1}, {Key: "createdBy", Value: 1}}},
+
+	// refresh_tokens; expired tokens are removed by the TTL index
+	{
+		Collection: "refresh_tokens",
+		Keys:       bson.D{{Key: "tokenHash", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{Collection: "refresh_tokens", Keys: bson.D{{Key: "familyId", Value: 1}}},
+	{Collection: "refresh_tokens", Keys: bson.D{{Key: "userId", Value: 1}}},
+	{
+		Collection: "refresh_tokens",
+		Keys:       bson.D{{Key: "expiresAt", Value: 1}},
+		Options:    options.Index().SetExpireAfterSeconds(0),
+	},
+
+	// users; a provider account can be linked to one user only
+	{
+		Collection: "users",
+		Keys:       bson.D{{Key: "identities.provider", Value: 1}, {Key: "identities.subject", Value: 1}},
+		Options: options.Index().SetUnique(true).
+			SetPartialFilterExpression(bson.M{"identities": bson.M{"$exists": true}}),
+	},
+
+	// sessions, listed per user by last use and dropped once expired
+	{Collection: "sessions", Keys: bson.D{{Key: "userId", Value: 1}, {Key: "lastUsedAt", Value: -1}}},
+	{
+		Collection: "sessions",
+		Keys:       bson.D{{Key: "expiresAt", Value: 1}},
+		Options:    options.Index().SetExpireAfterSeconds(0),
+	},
+
+	// notification preferences, the pending alert queue and in-app notifications; expired
+	// notifications are removed by the TTL index
+	{
+		Collection: "notification_preferences",
+		Keys:       bson.D{{Key: "userId", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{Collection: "property_alerts", Keys: bson.D{{Key: "userId", Value: 1}, {Key: "deliveredAt", Value: 1}, {Key: "createdAt", Value: 1}}},
+	{Collection: "notifications", Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}},
+	{
+		Collection: "notifications",
+		Keys:       bson.D{{Key: "expiresAt", Value: 1}},
+		Options:    options.Index().SetExpireAfterSeconds(0),
+	},
+
+	// saved searches and their recorded matches
+	{Collection: "saved_searches", Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	{Collection: "saved_searches", Keys: bson.D{{Key: "lastAttemptAt", Value: 1}}},
+	{
+		Collection: "saved_search_matches",
+		Keys:       bson.D{{Key: "savedSearchId", Value: 1}, {Key: "propertyId", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{Collection: "saved_search_matches", Keys: bson.D{{Key: "savedSearchId", Value: 1}, {Key: "matchedAt", Value: -1}, {Key: "_id", Value: -1}}},
+	{Collection: "saved_search_matches", Keys: bson.D{{Key: "propertyId", Value: 1}}},
+
+	// property change history and refresh diffs, read newest first per property
+	{Collection: "property_audit", Keys: bson.D{{Key: "propertyId", Value: 1}, {Key: "timestamp", Value: -1}}},
+	{Collection: "property_diffs", Keys: bson.D{{Key: "propertyId", Value: 1}, {Key: "refreshedAt", Value: -1}}},
+
+	// security audit log, queried newest first by date range, optionally per event type or actor
+	{Collection: "audit_events", Keys: bson.D{{Key: "occurredAt", Value: -1}}},
+	{Collection: "audit_events", Keys: bson.D{{Key: "type", Value: 1}, {Key: "occurredAt", Value: -1}}},
+	{Collection: "audit_events", Keys: bson.D{{Key: "actorId", Value: 1}, {Key: "occurredAt", Value: -1}}},
+
+	// valuation history, read newest first per property
+	{Collection: "valuations", Keys: bson.D{{Key: "propertyId", Value: 1}, {Key: "retrievedAt", Value: -1}}},
+
+	// webhooks, looked up by organization and subscribed event on every property change
+	{Collection: "webhooks", Keys: bson.D{{Key: "orgId", Value: 1}, {Key: "events", Value: 1}}},
+
+	// event outbox, polled for due pending events; published events are removed by the TTL index
+	{Collection: "event_outbox", Keys: bson.D{{Key: "status", Value: 1}, {Key: "nextAttemptAt", Value: 1}, {Key: "_id", Value: 1}}},
+	{
+		Collection: "event_outbox",
+		Keys:       bson.D{{Key: "expiresAt", Value: 1}},
+		Options:    options.Index().SetExpireAfterSeconds(0),
+	},
+
+	// property_media
+	{Collection: "property_media", Keys: bson.D{{Key: "propertyId", Value: 1}, {Key: "createdAt", Value: 1}}},
+
+	// listings; the partial unique index allows one open (active or pending) listing per property of
+	// an organization while keeping any number of sold ones
+	{Collection: "listings", Keys: bson.D{{Key: "propertyId", Value: 1}, {Key: "listedAt", Value: -1}}},
+	{
+		Collection: "listings",
+		Keys:       bson.D{{Key: "orgId", Value: 1}, {Key: "propertyId", Value: 1}},
+		Options: options.Index().
+			SetName("org_propertyId_open_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"status": bson.M{"$in": bson.A{"active", "pending"}}}),
+	},
+
+	// transactions; the unique deed key keeps repeated fetches of a property's history from
+	// duplicating records and serves date-ordered reads
+	{
+		Collection: "transactions",
+		Keys: bson.D{
+			{Key: "orgId", Value: 1},
+			{Key: "propertyId", Value: 1},
+			{Key: "date", Value: -1},
+			{Key: "recordingDate", Value: -1},
+			{Key: "documentNumber", Value: 1},
 		},
-	})
-	duration := time.Since(start).Seconds()
-	metrics.MongoOperationDuration.WithLabelValues("create_indexes", "properties").Observe(duration)
+		Options: options.Index().SetUnique(true),
+	},
+
+	// organizations and memberships; a user belongs to a single organization
+	{
+		Collection: "organizations",
+		Keys:       bson.D{{Key: "slug", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{
+		Collection: "memberships",
+		Keys:       bson.D{{Key: "userId", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{Collection: "memberships", Keys: bson.D{{Key: "orgId", Value: 1}, {Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}},
+
+	// usage_daily, one record per UTC day and organization or API key
+	{
+		Collection: "usage_daily",
+		Keys:       bson.D{{Key: "day", Value: 1}, {Key: "subjectType", Value: 1}, {Key: "subject", Value: 1}},
+		Options:    options.Index().SetUnique(true),
+	},
+	{Collection: "usage_daily", Keys: bson.D{{Key: "subjectType", Value: 1}, {Key: "subject", Value: 1}, {Key: "day", Value: 1}}},
+
+	// migrations, one record per run of an admin-launched data migration
+	{Collection: "migrations", Keys: bson.D{{Key: "name", Value: 1}, {Key: "startedAt", Value: -1}}},
+
+	// feed_runs, one record per file uploaded to a data feed
+	{Collection: "feed_runs", Keys: bson.D{{Key: "orgId", Value: 1}, {Key: "uploadedAt", Value: -1}}},
+	{Collection: "feed_runs", Keys: bson.D{{Key: "orgId", Value: 1}, {Key: "provider", Value: 1}, {Key: "uploadedAt", Value: -1}}},
+
+	// duplicate_candidates, the property pairs found by the latest duplicate scan of each organization
+	{Collection: "duplicate_candidates", Keys: bson.D{{Key: "orgId", Value: 1}, {Key: "detectedAt", Value: -1}}},
+	{Collection: "duplicate_candidates", Keys: bson.D{{Key: "propertyIds", Value: 1}}},
+}
+
+// replacedIndexes were superseded by registered indexes, mostly the unique indexes from before
+// organizations, which would keep two organizations from holding the same property.
+var replacedIndexes = []replacedIndex{
+	{Collection: "properties", Name: "propertyId_1"},
+	{Collection: "properties", Name: "property_address_unique"},
+	{Collection: "owner_entities", Name: "entityId_1"},
+	{Collection: "listings", Name: "propertyId_open_unique"},
+	{Collection: "transactions", Name: "propertyId_1_date_-1_recordingDate_-1_documentNumber_1"},
+}
+
+// IsRegisteredIndex reports whether the registry defines the named index on a collection.
+func IsRegisteredIndex(collection, name string) bool {
+	for _, spec := range indexRegistry {
+		if spec.Collection == collection && spec.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureIndexOptions tune EnsureIndexes.
+type EnsureIndexOptions struct {
+	// Rebuild drops and builds again an index whose definition changed, instead of reporting the
+	// conflict. A unique index doesn't enforce uniqueness while it is rebuilt.
+	Rebuild bool
+	// Timeout bounds building each index; zero leaves it to ctx
+	Timeout time.Duration
+}
+
+// IndexResult is the outcome of ensuring one index.
+type IndexResult struct {
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// IndexReport counts the outcomes of EnsureIndexes, with the result for every index.
+type IndexReport struct {
+	Created   int           `json:"created"`
+	Existing  int           `json:"existing"`
+	Rebuilt   int           `json:"rebuilt"`
+	Dropped   int           `json:"dropped"`
+	Skipped   int           `json:"skipped"`
+	Conflicts int           `json:"conflicts"`
+	Failed    int           `json:"failed"`
+	Indexes   []IndexResult `json:"indexes"`
+}
+
+func (r *IndexReport) add(result IndexResult) {
+	switch result.Status {
+	case IndexStatusCreated:
+		r.Created++
+	case IndexStatusExists:
+		r.Existing++
+	case IndexStatusRebuilt:
+		r.Rebuilt++
+	case IndexStatusDropped:
+		r.Dropped++
+	case IndexStatusSkipped:
+		r.Skipped++
+	case IndexStatusConflict:
+		r.Conflicts++
+	case IndexStatusFailed:
+		r.Failed++
+	}
+	r.Indexes = append(r.Indexes, result)
+}
+
+// EnsureIndexes builds the registered indexes that don't exist yet and drops the ones they replaced.
+// Building an index that already exists as defined does nothing, so it runs at every startup and
+// can be run again at any time. Every index is attempted; the error reports the ones that failed or
+// conflict with an existing definition.
+func EnsureIndexes(ctx context.Context, db *mongo.Database, opts EnsureIndexOptions) (*IndexReport, error) {
+	report := &IndexReport{Indexes: make([]IndexResult, 0, len(indexRegistry)+len(replacedIndexes))}
+	existing := make(map[string]map[string]bool)
+	for _, spec := range indexRegistry {
+		names, ok := existing[spec.Collection]
+		if !ok {
+			var err error
+			if names, err = listIndexNames(ctx, db.Collection(spec.Collection)); err != nil {
+				report.add(IndexResult{Collection: spec.Collection, Name: spec.Name(), Status: IndexStatusFailed, Error: err.Error()})
+				continue
+			}
+			existing[spec.Collection] = names
+		}
+		report.add(ensureIndex(ctx, db.Collection(spec.Collection), spec, names[spec.Name()], opts))
+	}
+	for _, replaced := range replacedIndexes {
+		dropped, err := dropReplacedIndex(ctx, db.Collection(replaced.Collection), replaced.Name)
+		switch {
+		case err != nil:
+			logger.GlobalLogger.Errorf("Failed to drop replaced index: collection=%s, name=%s, error=%v", replaced.Collection, replaced.Name, err)
+			report.add(IndexResult{Collection: replaced.Collection, Name: replaced.Name, Status: IndexStatusFailed, Error: err.Error()})
+		case dropped:
+			report.add(IndexResult{Collection: replaced.Collection, Name: replaced.Name, Status: IndexStatusDropped})
+		}
+	}
+
+	logger.GlobalLogger.Printf("MongoDB indexes ensured: created=%d, existing=%d, rebuilt=%d, dropped=%d, skipped=%d, conflicts=%d, failed=%d",
+		report.Created, report.Existing, report.Rebuilt, report.Dropped, report.Skipped, report.Conflicts, report.Failed)
+	if report.Failed > 0 || report.Conflicts > 0 {
+		return report, fmt.Errorf("ensure indexes failed: failed=%d, conflicts=%d", report.Failed, report.Conflicts)
+	}
+	return report, nil
+}
+
+// ensureIndex builds one registered index. exists is whether an index of the same name was there
+// before, in which case a successful build changed nothing.
+func ensureIndex(ctx context.Context, collection *mongo.Collection, spec IndexSpec, exists bool, opts EnsureIndexOptions) IndexResult {
+	result := IndexResult{Collection: spec.Collection, Name: spec.Name(), Status: IndexStatusCreated}
+	if exists {
+		result.Status = IndexStatusExists
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	err := createIndex(ctx, collection, spec)
+	if isIndexConflict(err) && opts.Rebuild {
+		if err = dropConflictingIndexes(ctx, collection, spec); err == nil {
+			err = createIndex(ctx, collection, spec)
+			result.Status = IndexStatusRebuilt
+		}
+	}
+	switch {
+	case err == nil:
+		if result.Status != IndexStatusExists {
+			logger.GlobalLogger.Printf("Index %s: collection=%s, name=%s", result.Status, result.Collection, result.Name)
+		}
+	case spec.SkipOnDuplicates && mongo.IsDuplicateKeyError(err):
+		logger.GlobalLogger.Warnf("Index not created, duplicate keys exist: collection=%s, name=%s, error=%v", result.Collection, result.Name, err)
+		result.Status, result.Error = IndexStatusSkipped, err.Error()
+	case isIndexConflict(err):
+		logger.GlobalLogger.Errorf("Index conflicts with an existing definition: collection=%s, name=%s, error=%v", result.Collection, result.Name, err)
+		result.Status, result.Error = IndexStatusConflict, err.Error()
+	default:
+		logger.GlobalLogger.Errorf("Failed to create index: collection=%s, name=%s, error=%v", result.Collection, result.Name, err)
+		result.Status, result.Error = IndexStatusFailed, err.Error()
+	}
+	return result
+}
+
+func createIndex(ctx context.Context, collection *mongo.Collection, spec IndexSpec) error {
+	start := time.Now()
+	_, err := collection.Indexes().CreateOne(ctx, spec.model())
+	metrics.MongoOperationDuration.WithLabelValues("create_indexes", spec.Collection).Observe(time.Since(start).Seconds())
 	if err != nil {
-		metrics.MongoErrorsTotal.WithLabelValues("create_indexes", "properties").Inc()
-		logger.GlobalLogger.Errorf("Failed to create indexes: %v", err)
-		return err
+		metrics.MongoErrorsTotal.WithLabelValues("create_indexes", spec.Collection).Inc()
 	}
+	return err
+}
 
-	logger.GlobalLogger.Println("MongoDB indexes created successfully.")
+func isIndexConflict(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && (cmdErr.Code == indexOptionsConflictErrorCode || cmdErr.Code == indexKeySpecsConflictErrorCode)
+}
+
+// dropConflictingIndexes drops the indexes standing in the way of a registered one: the index of the
+// same name and any index on the same keys.
+func dropConflictingIndexes(ctx context.Context, collection *mongo.Collection, spec IndexSpec) error {
+	keys, err := bson.Marshal(spec.Keys)
+	if err != nil {
+		return err
+	}
+	specs, err := collection.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return err
+	}
+	for _, existing := range specs {
+		if existing.Name != spec.Name() && !bytes.Equal(existing.KeysDocument, keys) {
+			continue
+		}
+		if _, err := dropReplacedIndex(ctx, collection, existing.Name); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func listIndexNames(ctx context.Context, collection *mongo.Collection) (map[string]bool, error) {
+	start := time.Now()
+	specs, err := collection.Indexes().ListSpecifications(ctx)
+	metrics.MongoOperationDuration.WithLabelValues("list_indexes", collection.Name()).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("list_indexes", collection.Name()).Inc()
+		return nil, err
+	}
+	names := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		names[spec.Name] = true
+	}
+	return names, nil
+}
+
+// dropReplacedIndex removes an index if it is still there, reporting whether it was.
+func dropReplacedIndex(ctx context.Context, collection *mongo.Collection, name string) (bool, error) {
+	_, err := collection.Indexes().DropOne(ctx, name)
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == indexNotFoundErrorCode {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -9,7 +9,7 @@ import (
 // interface for MongoDB operations.
 type Database interface {
 	GetCollection(name string) *mongo.Collection
-	CreatePropertyIndexes(ctx context.Context) error
+	EnsureIndexes(ctx context.Context, opts EnsureIndexOptions) (*IndexReport, error)
 }
 
 // Database interface using a MongoDB database.
@@ -27,7 +27,7 @@ func (m *MongoDatabase) GetCollection(name string) *mongo.Collection {
 	return m.db.Collection(name)
 }
 
-// create indexes for the properties collection.
-func (m *MongoDatabase) CreatePropertyIndexes(ctx context.Context) error {
-	return CreatePropertyIndexes(m.db)
+// create the registered indexes of every collection.
+func (m *MongoDatabase) EnsureIndexes(ctx context.Context, opts EnsureIndexOptions) (*IndexReport, error) {
+	return EnsureIndexes(ctx, m.db, opts)
 }
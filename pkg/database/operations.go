@@ -0,0 +1,95 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// Operation classes a repository sorts its calls into, each read and written with the settings
+// configured under database.operations. Point reads and writes stay on the primary by default so
+// a client reads its own writes; lists and exports can be sent to secondaries.
+const (
+	OperationRead   = "read"
+	OperationList   = "list"
+	OperationExport = "export"
+	OperationWrite  = "write"
+)
+
+var operationOptions = map[string]*options.CollectionOptions{}
+
+// Collection returns a handle on a collection that uses the settings of an operation class.
+func Collection(name, operation string) *mongo.Collection {
+	if opts, ok := operationOptions[operation]; ok {
+		return DB.Collection(name, opts)
+	}
+	return DB.Collection(name)
+}
+
+// setOperationOptions builds the collection options of every operation class from the config.
+func setOperationOptions(cfg *config.Config) error {
+	classes := map[string]config.DatabaseOperation{
+		OperationRead:   cfg.Database.Operations.Read,
+		OperationList:   cfg.Database.Operations.List,
+		OperationExport: cfg.Database.Operations.Export,
+		OperationWrite:  cfg.Database.Operations.Write,
+	}
+	built := make(map[string]*options.CollectionOptions, len(classes))
+	for class, operation := range classes {
+		opts, err := collectionOptions(operation)
+		if err != nil {
+			return fmt.Errorf("database.operations.%s: %v", class, err)
+		}
+		built[class] = opts
+	}
+	operationOptions = built
+	return nil
+}
+
+func collectionOptions(operation config.DatabaseOperation) (*options.CollectionOptions, error) {
+	opts := options.Collection()
+	if operation.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(operation.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
+		var prefOpts []readpref.Option
+		if operation.MaxStalenessSeconds > 0 {
+			prefOpts = append(prefOpts, readpref.WithMaxStaleness(time.Duration(operation.MaxStalenessSeconds)*time.Second))
+		}
+		pref, err := readpref.New(mode, prefOpts...)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetReadPreference(pref)
+	}
+	if operation.ReadConcern != "" {
+		opts.SetReadConcern(&readconcern.ReadConcern{Level: operation.ReadConcern})
+	}
+	if operation.WriteConcern != "" || operation.Journal || operation.WriteTimeoutMS > 0 {
+		wc := &writeconcern.WriteConcern{WTimeout: time.Duration(operation.WriteTimeoutMS) * time.Millisecond}
+		if operation.WriteConcern == "majority" {
+			wc.W = "majority"
+		} else if operation.WriteConcern != "" {
+			n, err := strconv.Atoi(operation.WriteConcern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid write concern %q", operation.WriteConcern)
+			}
+			wc.W = n
+		}
+		if operation.Journal {
+			journal := true
+			wc.Journal = &journal
+		}
+		opts.SetWriteConcern(wc)
+	}
+	return opts, nil
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BuildIndex creates an index and blocks until the server finishes building it. Since MongoDB 4.2
+// builds only hold exclusive locks briefly at the start and end, so reads and writes continue meanwhile.
+func BuildIndex(ctx context.Context, collection string, model mongo.IndexModel) error {
+	start := time.Now()
+	_, err := DB.Collection(collection).Indexes().CreateOne(ctx, model)
+	metrics.MongoOperationDuration.WithLabelValues("build_index", collection).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("build_index", collection).Inc()
+		return err
+	}
+	return nil
+}
+
+// IndexBuildProgress reports the percentage complete of an in-flight createIndexes on the collection,
+// read from $currentOp. ok is false when no build is visible, e.g. before it starts or after it ends.
+func IndexBuildProgress(ctx context.Context, collection string) (percent float64, ok bool, err error) {
+	ns := DB.Name() + "." + collection
+	pipeline := mongo.Pipeline{
+		{{Key: "$currentOp", Value: bson.D{{Key: "allUsers", Value: true}, {Key: "idleConnections", Value: false}}}},
+		{{Key: "$match", Value: bson.D{
+			{Key: "ns", Value: ns},
+			{Key: "command.createIndexes", Value: bson.D{{Key: "$exists", Value: true}}},
+		}}},
+	}
+
+	start := time.Now()
+	cursor, err := MongoClient.Database("admin").Aggregate(ctx, pipeline)
+	metrics.MongoOperationDuration.WithLabelValues("current_op", collection).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("current_op", collection).Inc()
+		return 0, false, err
+	}
+	defer cursor.Close(ctx)
+
+	var ops []struct {
+		Progress struct {
+			Done  float64 `bson:"done"`
+			Total float64 `bson:"total"`
+		} `bson:"progress"`
+	}
+	if err := cursor.All(ctx, &ops); err != nil {
+		return 0, false, err
+	}
+	for _, op := range ops {
+		if op.Progress.Total > 0 {
+			return op.Progress.Done / op.Progress.Total * 100, true, nil
+		}
+	}
+	return 0, len(ops) > 0, nil
+}
+
+// IndexNames lists the names of all indexes on the collection.
+func IndexNames(ctx context.Context, collection string) ([]string, error) {
+	start := time.Now()
+	specs, err := DB.Collection(collection).Indexes().ListSpecifications(ctx)
+	metrics.MongoOperationDuration.WithLabelValues("list_indexes", collection).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("list_indexes", collection).Inc()
+		return nil, err
+	}
+	names := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		names = append(names, spec.Name)
+	}
+	return names, nil
+}
+
+// DropIndex removes an index by name.
+func DropIndex(ctx context.Context, collection, name string) error {
+	start := time.Now()
+	_, err := DB.Collection(collection).Indexes().DropOne(ctx, name)
+	metrics.MongoOperationDuration.WithLabelValues("drop_index", collection).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("drop_index", collection).Inc()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Schema validation modes. Warn lets a document that breaks the schema through and has the server
+// log it; strict rejects the write. Off removes a validator set before.
+const (
+	SchemaValidationOff    = "off"
+	SchemaValidationWarn   = "warn"
+	SchemaValidationStrict = "strict"
+)
+
+// namespaceNotFoundErrorCode is returned by collMod on a collection that doesn't exist yet.
+const namespaceNotFoundErrorCode = 26
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+)
+
+// JSONSchema derives a $jsonSchema from the BSON form of v's type: field names from bson tags,
+// BSON types from Go types, and required fields from validate:"required" tags. Fields it doesn't
+// know about are allowed, so documents may carry more than the model.
+func JSONSchema(v interface{}) bson.M {
+	return typeSchema(reflect.TypeOf(v))
+}
+
+func typeSchema(t reflect.Type) bson.M {
+	switch t {
+	case timeType:
+		return bson.M{"bsonType": "date"}
+	case objectIDType:
+		return bson.M{"bsonType": "objectId"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := typeSchema(t.Elem())
+		schema["bsonType"] = bson.A{schema["bsonType"], "null"}
+		return schema
+	case reflect.String:
+		return bson.M{"bsonType": "string"}
+	case reflect.Bool:
+		return bson.M{"bsonType": "bool"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		// "number" also takes int, long and decimal, so other tools' numeric types pass
+		return bson.M{"bsonType": "number"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return bson.M{"bsonType": "binData"}
+		}
+		// nil slices are stored as null
+		return bson.M{"bsonType": bson.A{"array", "null"}, "items": typeSchema(t.Elem())}
+	case reflect.Array:
+		return bson.M{"bsonType": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return bson.M{"bsonType": bson.A{"object", "null"}}
+	case reflect.Struct:
+		properties := bson.M{}
+		required := addStructFields(t, properties, nil)
+		schema := bson.M{"bsonType": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	}
+	return bson.M{}
+}
+
+// addStructFields adds the schemas of t's fields to properties, including those of inlined
+// structs, and returns required with the names of t's required fields added.
+func addStructFields(t reflect.Type, properties bson.M, required []string) []string {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(field.Tag.Get("bson"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if hasTagOption(tag[1:], "inline") && field.Type.Kind() == reflect.Struct {
+			required = addStructFields(field.Type, properties, required)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		schema := typeSchema(field.Type)
+		if hasTagOption(strings.Split(field.Tag.Get("validate"), ","), "required") {
+			required = append(required, name)
+			if field.Type.Kind() == reflect.String {
+				schema["minLength"] = 1
+			}
+		}
+		properties[name] = schema
+	}
+	return required
+}
+
+func hasTagOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplySchemaValidator sets schema as the validator of a collection, creating the collection when
+// it doesn't exist yet. Validation is moderate: documents already breaking the schema, such as
+// those stored in an older shape, can still be updated, and come out valid once rewritten.
+func ApplySchemaValidator(db *mongo.Database, collection string, schema bson.M, mode string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	validator := bson.M{"$jsonSchema": schema}
+	action := "error"
+	switch mode {
+	case SchemaValidationOff:
+		validator = bson.M{}
+	case SchemaValidationWarn:
+		action = "warn"
+	}
+
+	start := time.Now()
+	err := db.RunCommand(ctx, bson.D{
+		{Key: "collMod", Value: collection},
+		{Key: "validator", Value: validator},
+		{Key: "validationLevel", Value: "moderate"},
+		{Key: "validationAction", Value: action},
+	}).Err()
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Code == namespaceNotFoundErrorCode {
+		err = nil
+		if mode != SchemaValidationOff {
+			err = db.CreateCollection(ctx, collection, options.CreateCollection().
+				SetValidator(validator).
+				SetValidationLevel("moderate").
+				SetValidationAction(action))
+		}
+	}
+	metrics.MongoOperationDuration.WithLabelValues("set_validator", collection).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.MongoErrorsTotal.WithLabelValues("set_validator", collection).Inc()
+		return err
+	}
+
+	logger.GlobalLogger.Printf("MongoDB schema validation set: collection=%s, mode=%s", collection, mode)
+	return nil
+}
@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// slowQueryCommands are the commands timed by the slow query log; the rest are driver housekeeping.
+var slowQueryCommands = map[string]bool{
+	"find":          true,
+	"getMore":       true,
+	"aggregate":     true,
+	"count":         true,
+	"distinct":      true,
+	"insert":        true,
+	"update":        true,
+	"delete":        true,
+	"findAndModify": true,
+}
+
+// slowQueryOmitted are command fields left out of the log: session and cluster bookkeeping, and the
+// documents being inserted.
+var slowQueryOmitted = map[string]bool{
+	"lsid":            true,
+	"txnNumber":       true,
+	"$clusterTime":    true,
+	"$db":             true,
+	"$readPreference": true,
+	"documents":       true,
+	"apiVersion":      true,
+}
+
+// slowQueryMonitor logs every monitored command that takes longer than threshold, with its query
+// shape: field names and operators are kept, every value is replaced by "?", so the log carries no
+// addresses or owner data.
+type slowQueryMonitor struct {
+	threshold time.Duration
+	started   sync.Map // request ID -> slowQueryCommand
+}
+
+type slowQueryCommand struct {
+	collection string
+	command    bson.Raw
+}
+
+func newSlowQueryMonitor(threshold time.Duration) *event.CommandMonitor {
+	m := &slowQueryMonitor{threshold: threshold}
+	return &event.CommandMonitor{
+		Started: m.commandStarted,
+		Succeeded: func(ctx context.Context, e *event.CommandSucceededEvent) {
+			m.commandFinished(e.CommandFinishedEvent, "")
+		},
+		Failed: func(ctx context.Context, e *event.CommandFailedEvent) {
+			m.commandFinished(e.CommandFinishedEvent, e.Failure)
+		},
+	}
+}
+
+func (m *slowQueryMonitor) commandStarted(ctx context.Context, e *event.CommandStartedEvent) {
+	if !slowQueryCommands[e.CommandName] {
+		return
+	}
+	collection, _ := e.Command.Lookup(e.CommandName).StringValueOK()
+	if e.CommandName == "getMore" {
+		collection, _ = e.Command.Lookup("collection").StringValueOK()
+	}
+	// The driver reuses the command's buffer once the event returns
+	command := make(bson.Raw, len(e.Command))
+	copy(command, e.Command)
+	m.started.Store(e.RequestID, slowQueryCommand{collection: collection, command: command})
+}
+
+func (m *slowQueryMonitor) commandFinished(e event.CommandFinishedEvent, failure string) {
+	value, ok := m.started.LoadAndDelete(e.RequestID)
+	if !ok || e.Duration < m.threshold {
+		return
+	}
+	started := value.(slowQueryCommand)
+	metrics.MongoSlowOperationsTotal.WithLabelValues(e.CommandName, started.collection).Inc()
+
+	shape, err := bson.MarshalExtJSON(redactCommand(started.command), false, false)
+	if err != nil {
+		shape = []byte("unavailable")
+	}
+	if failure != "" {
+		logger.GlobalLogger.Warnf("Slow MongoDB operation: command=%s, collection=%s, duration_ms=%d, query=%s, error=%s",
+			e.CommandName, started.collection, e.Duration.Milliseconds(), shape, failure)
+		return
+	}
+	logger.GlobalLogger.Warnf("Slow MongoDB operation: command=%s, collection=%s, duration_ms=%d, query=%s",
+		e.CommandName, started.collection, e.Duration.Milliseconds(), shape)
+}
+
+// redactCommand returns the shape of a command, keeping the collection it targets.
+func redactCommand(command bson.Raw) bson.D {
+	elements, err := command.Elements()
+	if err != nil {
+		return nil
+	}
+	shape := make(bson.D, 0, len(elements))
+	for i, element := range elements {
+		if slowQueryOmitted[element.Key()] {
+			continue
+		}
+		// The first field names the command and holds the collection
+		if i == 0 {
+			shape = append(shape, bson.E{Key: element.Key(), Value: element.Value()})
+			continue
+		}
+		shape = append(shape, bson.E{Key: element.Key(), Value: redactValue(element.Value())})
+	}
+	return shape
+}
+
+// redactValue keeps the structure of documents and of arrays of documents, such as $or clauses and
+// aggregation stages, and replaces everything else with "?".
+func redactValue(value bson.RawValue) interface{} {
+	switch value.Type {
+	case bsontype.EmbeddedDocument:
+		elements, err := value.Document().Elements()
+		if err != nil {
+			return "?"
+		}
+		doc := make(bson.D, 0, len(elements))
+		for _, element := range elements {
+			doc = append(doc, bson.E{Key: element.Key(), Value: redactValue(element.Value())})
+		}
+		return doc
+	case bsontype.Array:
+		values, err := value.Array().Values()
+		if err != nil {
+			return "?"
+		}
+		items := bson.A{}
+		for _, item := range values {
+			if item.Type == bsontype.EmbeddedDocument || item.Type == bsontype.Array {
+				items = append(items, redactValue(item))
+			}
+		}
+		if len(items) == 0 {
+			return "?"
+		}
+		return items
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+)
+
+// Message is one property change published to the broker. ID is unique per event and Key (the
+// property ID) keeps changes to the same property in order.
+type Message struct {
+	ID   string
+	Key  string
+	Type string
+	Body []byte
+}
+
+// Publisher delivers messages to the event broker. Publish returns nil only once the broker has
+// acknowledged the message, so callers can retry anything that errored without losing events.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// New returns a publisher for the configured broker.
+func New(cfg *config.Config) (Publisher, error) {
+	timeout := time.Duration(cfg.Events.PublishTimeoutSeconds) * time.Second
+	switch cfg.Events.Broker {
+	case "kafka":
+		return NewKafkaPublisher(cfg.Events.Kafka.RestProxyURL, cfg.Events.Kafka.Topic, cfg.Events.Kafka.Username, cfg.Events.Kafka.Password, timeout), nil
+	case "nats":
+		return NewNATSPublisher(cfg.Events.NATS.URL, cfg.Events.NATS.SubjectPrefix, cfg.Events.NATS.Token, cfg.Events.NATS.CredentialsFile, timeout)
+	}
+	return nil, fmt.Errorf("unsupported event broker: %q", cfg.Events.Broker)
+}
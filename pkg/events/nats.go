@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// EventTypeHeader carries the event type on NATS messages so consumers can filter without decoding.
+const EventTypeHeader = "Event-Type"
+
+// NATSPublisher publishes messages to JetStream on <prefix>.<event type>, e.g.
+// homeinsight.property.updated. A stream must be bound to those subjects; JetStream acknowledges a
+// message once it is stored and drops redelivered IDs within the stream's duplicate window.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	prefix  string
+	timeout time.Duration
+}
+
+// NewNATSPublisher connects to the NATS server. The connection keeps retrying in the background when
+// the server is down at startup; publishes fail until it is reachable.
+func NewNATSPublisher(url, subjectPrefix, token, credentialsFile string, timeout time.Duration) (*NATSPublisher, error) {
+	opts := []nats.Option{
+		nats.Name("homeinsight-properties"),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	}
+	if token != "" {
+		opts = append(opts, nats.Token(token))
+	}
+	if credentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(credentialsFile))
+	}
+
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &NATSPublisher{conn: conn, js: js, prefix: subjectPrefix, timeout: timeout}, nil
+}
+
+func (p *NATSPublisher) Publish(ctx context.Context, msg Message) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	m := nats.NewMsg(p.prefix + "." + msg.Type)
+	m.Data = msg.Body
+	m.Header.Set(EventTypeHeader, msg.Type)
+	_, err := p.js.PublishMsg(m, nats.MsgId(msg.ID), nats.Context(ctx))
+	return err
+}
+
+// Close flushes pending messages and closes the connection.
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
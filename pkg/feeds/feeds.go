@@ -0,0 +1,191 @@
+// Package feeds reads the records of bulk data files sent by data providers, such as MLS exports and
+// county assessor rolls, one at a time so files of any size are parsed in constant memory.
+package feeds
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Feed file formats.
+const (
+	FormatCSV = "csv"
+	FormatXML = "xml"
+)
+
+// Record is one record of a feed. Row counts records from 1 in file order; Fields maps column names
+// (CSV) or element paths below the record element (XML), such as "Address.City", to their text.
+type Record struct {
+	Row    int
+	Fields map[string]string
+}
+
+// Reader returns the records of a feed in order. Next returns io.EOF after the last record. A record
+// that can't be parsed is returned as a *RowError; reading continues with the next record when the
+// format allows it.
+type Reader interface {
+	Next() (*Record, error)
+}
+
+// RowError reports a record of a feed that couldn't be parsed.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// FormatFromContentType returns the feed format of a Content-Type, or "" if it names neither CSV nor XML.
+func FormatFromContentType(contentType string) string {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	switch strings.TrimSpace(mediaType) {
+	case "text/csv", "application/csv":
+		return FormatCSV
+	case "application/xml", "text/xml":
+		return FormatXML
+	}
+	return ""
+}
+
+// NewReader returns a reader for a feed in the given format.
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r)
+	case FormatXML:
+		return &xmlReader{decoder: xml.NewDecoder(r)}, nil
+	default:
+		return nil, fmt.Errorf("unknown feed format: %q", format)
+	}
+}
+
+// csvReader reads a CSV file whose first line names the columns.
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+	row    int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty csv feed")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %v", err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+	}
+	return &csvReader{reader: reader, header: columns}, nil
+}
+
+func (r *csvReader) Next() (*Record, error) {
+	values, err := r.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	r.row++
+	if err != nil {
+		return nil, &RowError{Row: r.row, Err: err}
+	}
+	if len(values) != len(r.header) {
+		return nil, &RowError{Row: r.row, Err: fmt.Errorf("has %d fields, header has %d", len(values), len(r.header))}
+	}
+	fields := make(map[string]string, len(values))
+	for i, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			fields[r.header[i]] = value
+		}
+	}
+	return &Record{Row: r.row, Fields: fields}, nil
+}
+
+// xmlReader reads an XML file whose root element holds one child element per record. Nested elements
+// of a record are named by their path, and attributes by their element's path, "@" and their name.
+type xmlReader struct {
+	decoder *xml.Decoder
+	depth   int
+	row     int
+}
+
+func (r *xmlReader) Next() (*Record, error) {
+	for {
+		token, err := r.decoder.Token()
+		if err == io.EOF {
+			if r.depth > 0 {
+				return nil, fmt.Errorf("invalid xml feed: unexpected end of file")
+			}
+			return nil, io.EOF
+		}
+		if err != nil {
+			// The decoder can't resynchronize after a syntax error, so the rest of the file is lost
+			return nil, fmt.Errorf("invalid xml feed after row %d: %v", r.row, err)
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			r.depth++
+			if r.depth == 2 {
+				r.row++
+				return r.record(element)
+			}
+		case xml.EndElement:
+			r.depth--
+		}
+	}
+}
+
+// record collects the fields of the record element just opened, up to and including its end.
+func (r *xmlReader) record(start xml.StartElement) (*Record, error) {
+	fields := make(map[string]string)
+	var path []string
+	var text strings.Builder
+	addAttrs := func(prefix string, attrs []xml.Attr) {
+		for _, attr := range attrs {
+			if value := strings.TrimSpace(attr.Value); value != "" {
+				fields[prefix+"@"+attr.Name.Local] = value
+			}
+		}
+	}
+	addAttrs("", start.Attr)
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = fmt.Errorf("unexpected end of file")
+			}
+			return nil, fmt.Errorf("invalid xml feed at row %d: %v", r.row, err)
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			path = append(path, element.Name.Local)
+			text.Reset()
+			addAttrs(strings.Join(path, "."), element.Attr)
+		case xml.CharData:
+			text.Write(element)
+		case xml.EndElement:
+			if len(path) == 0 {
+				r.depth--
+				return &Record{Row: r.row, Fields: fields}, nil
+			}
+			if value := strings.TrimSpace(text.String()); value != "" {
+				fields[strings.Join(path, ".")] = value
+			}
+			text.Reset()
+			path = path[:len(path)-1]
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// prefix marks an encrypted value; the full format is enc:<keyId>:<base64(nonce|ciphertext)>.
+const prefix = "enc:"
+
+// KeyProvider supplies encryption keys, e.g. from configuration or a secrets manager.
+// The active key encrypts new values; every returned key can decrypt.
+type KeyProvider interface {
+	Keys() (activeKeyID string, keys map[string][]byte, err error)
+}
+
+// Cipher encrypts and decrypts individual string fields.
+type Cipher interface {
+	Enabled() bool
+	Encrypt(plaintext string) (string, error)
+	Decrypt(value string) (string, error)
+	// NeedsRotation reports whether a stored value is plaintext or sealed with a retired key.
+	NeedsRotation(value string) bool
+}
+
+type aesGCMCipher struct {
+	activeKeyID string
+	aeads       map[string]cipher.AEAD
+}
+
+// New builds an AES-GCM cipher from the provider's keys; an empty key set yields a pass-through cipher.
+func New(provider KeyProvider) (Cipher, error) {
+	activeKeyID, keys, err := provider.Keys()
+	if err != nil {
+		return nil, fmt.Errorf("load encryption keys: %v", err)
+	}
+	if len(keys) == 0 {
+		return noopCipher{}, nil
+	}
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active encryption key %q not found", activeKeyID)
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for id, key := range keys {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("encryption key id %q must not contain ':'", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %v", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %v", id, err)
+		}
+		aeads[id] = aead
+	}
+	return &aesGCMCipher{activeKeyID: activeKeyID, aeads: aeads}, nil
+}
+
+func (c *aesGCMCipher) Enabled() bool {
+	return true
+}
+
+func (c *aesGCMCipher) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	aead := c.aeads[c.activeKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %v", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(c.activeKeyID))
+	return prefix + c.activeKeyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens an encrypted value; plaintext written before encryption was enabled passes through.
+func (c *aesGCMCipher) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+	keyID, payload, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", fmt.Errorf("decrypt field: malformed value")
+	}
+	aead, ok := c.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("decrypt field: unknown key %q", keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("decrypt field: malformed payload")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("decrypt field: %v", err)
+	}
+	return string(plaintext), nil
+}
+
+func (c *aesGCMCipher) NeedsRotation(value string) bool {
+	if value == "" {
+		return false
+	}
+	return !strings.HasPrefix(value, prefix+c.activeKeyID+":")
+}
+
+// noopCipher leaves values untouched when no keys are configured.
+type noopCipher struct{}
+
+func (noopCipher) Enabled() bool                            { return false }
+func (noopCipher) Encrypt(plaintext string) (string, error) { return plaintext, nil }
+func (noopCipher) Decrypt(value string) (string, error)     { return value, nil }
+func (noopCipher) NeedsRotation(value string) bool          { return false }
+
+// StaticKeyProvider serves keys held in configuration, encoded as base64 AES-128/192/256 keys.
+type StaticKeyProvider struct {
+	ActiveKeyID string
+	EncodedKeys map[string]string
+}
+
+func (p StaticKeyProvider) Keys() (string, map[string][]byte, error) {
+	keys := make(map[string][]byte, len(p.EncodedKeys))
+	for id, encoded := range p.EncodedKeys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", nil, fmt.Errorf("decode key %q: %v", id, err)
+		}
+		keys[id] = key
+	}
+	return p.ActiveKeyID, keys, nil
+}
+
+// ParseKeyList parses "id1:base64key1,id2:base64key2" as used by the PII_ENCRYPTION_KEYS variable.
+func ParseKeyList(list string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, key, ok := strings.Cut(entry, ":")
+		if !ok || id == "" || key == "" {
+			return nil, fmt.Errorf("invalid key entry %q, expected id:base64key", entry)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
@@ -0,0 +1,58 @@
+package hazard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// cachedResult is a cached lookup; a nil Risk records that the provider had no data.
+type cachedResult struct {
+	Risk *Risk `json:"risk"`
+}
+
+// CachedProvider remembers a provider's answers in Redis, so refreshing a property, or its
+// neighbours on the same parcel, doesn't call the provider again. Points are rounded to about 10
+// meters. Cache failures fall through to the provider.
+type CachedProvider struct {
+	next Provider
+	ttl  time.Duration
+}
+
+func NewCachedProvider(next Provider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{next: next, ttl: ttl}
+}
+
+func (p *CachedProvider) Name() string {
+	return p.next.Name()
+}
+
+func (p *CachedProvider) Lookup(ctx context.Context, lat, lng float64) (*Risk, error) {
+	point := fmt.Sprintf("%s|%.4f,%.4f", p.next.Name(), lat, lng)
+	if data, err := cache.GetHazardRisk(ctx, point); err != nil {
+		logger.GlobalLogger.Warnf("Failed to read cached hazard risk: error=%v", err)
+	} else if data != nil {
+		var result cachedResult
+		if err := json.Unmarshal(data, &result); err == nil {
+			if result.Risk == nil {
+				return nil, ErrNoData
+			}
+			return result.Risk, nil
+		}
+	}
+
+	risk, err := p.next.Lookup(ctx, lat, lng)
+	if err != nil && !errors.Is(err, ErrNoData) {
+		return nil, err
+	}
+	data, _ := json.Marshal(&cachedResult{Risk: risk})
+	if cacheErr := cache.SetHazardRisk(ctx, point, data, p.ttl); cacheErr != nil {
+		logger.GlobalLogger.Warnf("Failed to cache hazard risk: error=%v", cacheErr)
+	}
+	return risk, err
+}
@@ -0,0 +1,148 @@
+package hazard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FEMAProvider reads flood zones from FEMA's National Flood Hazard Layer and, when a wildfire
+// service is configured, wildfire hazard potential classes from the USDA Forest Service, both
+// through their public ArcGIS REST services.
+type FEMAProvider struct {
+	floodURL    string
+	wildfireURL string
+	client      *http.Client
+}
+
+func NewFEMAProvider(floodURL, wildfireURL string, timeout time.Duration) *FEMAProvider {
+	return &FEMAProvider{
+		floodURL:    strings.TrimRight(floodURL, "/"),
+		wildfireURL: strings.TrimRight(wildfireURL, "/"),
+		client:      &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *FEMAProvider) Name() string {
+	return "fema"
+}
+
+func (p *FEMAProvider) Lookup(ctx context.Context, lat, lng float64) (*Risk, error) {
+	risk := &Risk{}
+	if err := p.lookupFlood(ctx, lat, lng, risk); err != nil {
+		return nil, err
+	}
+	if p.wildfireURL != "" {
+		if err := p.lookupWildfire(ctx, lat, lng, risk); err != nil {
+			return nil, err
+		}
+	}
+	if risk.FloodZone == "" && risk.WildfireRiskScore == 0 {
+		return nil, fmt.Errorf("FEMA hazard request failed: %w: lat=%f, lng=%f", ErrNoData, lat, lng)
+	}
+	return risk, nil
+}
+
+type arcgisError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type nfhlQueryResponse struct {
+	Error    *arcgisError `json:"error"`
+	Features []struct {
+		Attributes struct {
+			FloodZone string `json:"FLD_ZONE"`
+			Subtype   string `json:"ZONE_SUBTY"`
+			SFHA      string `json:"SFHA_TF"`
+		} `json:"attributes"`
+	} `json:"features"`
+}
+
+// lookupFlood sets the flood zone of the NFHL polygon containing the point. Unmapped areas have
+// none and leave the flood fields empty.
+func (p *FEMAProvider) lookupFlood(ctx context.Context, lat, lng float64, risk *Risk) error {
+	query := url.Values{}
+	query.Set("geometry", fmt.Sprintf("%f,%f", lng, lat))
+	query.Set("geometryType", "esriGeometryPoint")
+	query.Set("inSR", "4326")
+	query.Set("spatialRel", "esriSpatialRelIntersects")
+	query.Set("outFields", "FLD_ZONE,ZONE_SUBTY,SFHA_TF")
+	query.Set("returnGeometry", "false")
+	query.Set("f", "json")
+
+	var result nfhlQueryResponse
+	if err := p.get(ctx, p.floodURL+"/query?"+query.Encode(), &result); err != nil {
+		return fmt.Errorf("FEMA flood zone request failed: %v", err)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("FEMA flood zone request failed: code %d: %s", result.Error.Code, result.Error.Message)
+	}
+	if len(result.Features) == 0 {
+		return nil
+	}
+	attributes := result.Features[0].Attributes
+	risk.FloodZone = strings.ToUpper(strings.TrimSpace(attributes.FloodZone))
+	risk.FloodZoneSubtype = strings.ToUpper(strings.TrimSpace(attributes.Subtype))
+	risk.SpecialFloodHazardArea = attributes.SFHA == "T"
+	risk.FloodRiskScore = FloodRiskScore(risk.FloodZone, risk.FloodZoneSubtype)
+	return nil
+}
+
+type whpIdentifyResponse struct {
+	Error *arcgisError `json:"error"`
+	Value string       `json:"value"`
+}
+
+// lookupWildfire sets the wildfire hazard potential class of the raster cell holding the point.
+// Classes 1 to 5 run from very low to very high; non-burnable land and water (6 and 7) have no
+// score.
+func (p *FEMAProvider) lookupWildfire(ctx context.Context, lat, lng float64, risk *Risk) error {
+	query := url.Values{}
+	query.Set("geometry", fmt.Sprintf(`{"x":%f,"y":%f,"spatialReference":{"wkid":4326}}`, lng, lat))
+	query.Set("geometryType", "esriGeometryPoint")
+	query.Set("returnGeometry", "false")
+	query.Set("returnCatalogItems", "false")
+	query.Set("f", "json")
+
+	var result whpIdentifyResponse
+	if err := p.get(ctx, p.wildfireURL+"/identify?"+query.Encode(), &result); err != nil {
+		return fmt.Errorf("Wildfire hazard request failed: %v", err)
+	}
+	if result.Error != nil {
+		return fmt.Errorf("Wildfire hazard request failed: code %d: %s", result.Error.Code, result.Error.Message)
+	}
+	if class, err := strconv.Atoi(result.Value); err == nil && class >= 1 && class <= 5 {
+		risk.WildfireRiskScore = class
+	}
+	return nil
+}
+
+func (p *FEMAProvider) get(ctx context.Context, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("read response: %v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decode response: %v", err)
+	}
+	return nil
+}
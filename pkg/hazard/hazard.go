@@ -0,0 +1,69 @@
+package hazard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+)
+
+// ErrNoData is wrapped by providers that have no hazard data for a location, e.g. outside their
+// mapped area.
+var ErrNoData = errors.New("no hazard data for location")
+
+// Risk is the natural hazard exposure of a location. Scores run from 1 (lowest) to 5 (highest) and
+// are 0 when the provider has no rating.
+type Risk struct {
+	// FloodZone is the FEMA flood zone designation, such as AE, VE or X
+	FloodZone        string `json:"floodZone,omitempty"`
+	FloodZoneSubtype string `json:"floodZoneSubtype,omitempty"`
+	// SpecialFloodHazardArea is set in zones with a 1% annual chance of flooding
+	SpecialFloodHazardArea bool `json:"specialFloodHazardArea"`
+	FloodRiskScore         int  `json:"floodRiskScore,omitempty"`
+	WildfireRiskScore      int  `json:"wildfireRiskScore,omitempty"`
+}
+
+// Provider looks up the hazard exposure at a point.
+type Provider interface {
+	Name() string
+	Lookup(ctx context.Context, lat, lng float64) (*Risk, error)
+}
+
+// New builds the configured provider, wrapped in the Redis result cache. Returns nil when hazard
+// enrichment is turned off.
+func New(cfg *config.Config) (Provider, error) {
+	settings := cfg.Hazard
+	timeout := time.Duration(settings.TimeoutSeconds) * time.Second
+
+	var provider Provider
+	switch settings.Provider {
+	case "":
+		return nil, nil
+	case "fema":
+		provider = NewFEMAProvider(settings.FEMA.FloodBaseURL, settings.FEMA.WildfireBaseURL, timeout)
+	default:
+		return nil, fmt.Errorf("unknown hazard provider: %q", settings.Provider)
+	}
+	return NewCachedProvider(provider, time.Duration(settings.CacheTTLHours)*time.Hour), nil
+}
+
+// FloodRiskScore ranks a FEMA flood zone: 5 for coastal high hazard areas (V zones), 4 for the
+// other 1% annual chance zones (A zones), 2 for the 0.2% annual chance and levee-protected areas
+// (shaded X, formerly B) and 1 for minimal hazard. Undetermined (D) and unknown zones score 0.
+func FloodRiskScore(zone, subtype string) int {
+	switch {
+	case zone == "":
+		return 0
+	case zone[0] == 'V':
+		return 5
+	case zone[0] == 'A':
+		return 4
+	case zone == "B", zone == "X" && subtype != "" && subtype != "AREA OF MINIMAL FLOOD HAZARD":
+		return 2
+	case zone == "X" || zone == "C":
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,543 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/fieldcrypt"
+	"homeinsight-properties/pkg/logger"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// Job statuses. A job is retrying between failed attempts and failed once it is dead-lettered.
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusRetrying  = "retrying"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+const (
+	// leaseMargin lets a lease outlast the attempt timeout, so a slow attempt isn't handed to a second
+	// worker while it is still running
+	leaseMargin = 30 * time.Second
+	// promoteBatch bounds the retries and expired leases moved back onto a queue per poll
+	promoteBatch = 100
+	// bookkeepingTimeout bounds the Redis writes recording an attempt's outcome
+	bookkeepingTimeout = 5 * time.Second
+)
+
+// Job is the status of a background job as reported to API callers. The payload stays internal.
+type Job struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	MaxAttempts   int             `json:"maxAttempts"`
+	Progress      json.RawMessage `json:"progress,omitempty"`
+	Result        json.RawMessage `json:"result,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	CreatedBy     string          `json:"createdBy,omitempty"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+	NextAttemptAt *time.Time      `json:"nextAttemptAt,omitempty"`
+	FinishedAt    *time.Time      `json:"finishedAt,omitempty"`
+	Payload       json.RawMessage `json:"-"`
+	uniqueKey     string
+}
+
+// Decode unmarshals the job's payload into v. A payload that doesn't decode never will, so the
+// error is permanent.
+func (j *Job) Decode(v interface{}) error {
+	if err := json.Unmarshal(j.Payload, v); err != nil {
+		return Permanent(fmt.Errorf("decode %s job payload: %v", j.Type, err))
+	}
+	return nil
+}
+
+// LastAttempt reports whether a failure of the running attempt dead-letters the job.
+func (j *Job) LastAttempt() bool {
+	return j.Attempts >= j.MaxAttempts
+}
+
+// Handler runs one attempt at a job. The result is encoded as JSON and reported with the job's
+// status once it succeeds. Handlers must tolerate running more than once for the same job: a worker
+// that dies mid-attempt leaves the job to be retried after its lease runs out.
+type Handler func(ctx context.Context, job *Job) (interface{}, error)
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a handler error that retrying can't fix; the job fails without further attempts.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// Options tune a job type. Zero values take the defaults from the jobs config section.
+type Options struct {
+	Workers        int
+	MaxAttempts    int
+	Timeout        time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// EnqueueOptions describe a single job. While a job enqueued with a UniqueKey is pending, enqueueing
+// another one under the same key returns the pending job instead, for at most UniqueFor (by default
+// the retention).
+type EnqueueOptions struct {
+	CreatedBy string
+	UniqueKey string
+	UniqueFor time.Duration
+}
+
+type registration struct {
+	handler Handler
+	opts    Options
+}
+
+// Queue runs background jobs from Redis-backed queues, one per job type, with a pool of workers per
+// type on every instance. Failed attempts are retried with a backoff that doubles up to a maximum;
+// jobs that run out of attempts move to their type's dead-letter list.
+type Queue struct {
+	mu           sync.Mutex
+	types        map[string]*registration
+	defaults     Options
+	pollInterval time.Duration
+	retention    time.Duration
+	deadLetter   int
+	pii          fieldcrypt.Cipher
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	started      bool
+}
+
+// New returns a job queue. Payloads may carry owner PII (imported properties, webhook events), so
+// they are sealed with pii while stored in Redis.
+func New(cfg *config.Config, pii fieldcrypt.Cipher) *Queue {
+	return &Queue{
+		types: make(map[string]*registration),
+		defaults: Options{
+			Workers:        cfg.Jobs.Workers,
+			MaxAttempts:    cfg.Jobs.MaxAttempts,
+			Timeout:        time.Duration(cfg.Jobs.TimeoutSeconds) * time.Second,
+			InitialBackoff: time.Duration(cfg.Jobs.InitialBackoffSeconds) * time.Second,
+			MaxBackoff:     time.Duration(cfg.Jobs.MaxBackoffSeconds) * time.Second,
+		},
+		pollInterval: time.Duration(cfg.Jobs.PollIntervalMS) * time.Millisecond,
+		retention:    time.Duration(cfg.Jobs.RetentionHours) * time.Hour,
+		deadLetter:   cfg.Jobs.DeadLetterMax,
+		pii:          pii,
+	}
+}
+
+// Register sets the handler for a job type. Types must be registered before Start.
+func (q *Queue) Register(jobType string, handler Handler, opts Options) {
+	if opts.Workers <= 0 {
+		opts.Workers = q.defaults.Workers
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = q.defaults.MaxAttempts
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = q.defaults.Timeout
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = q.defaults.InitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = q.defaults.MaxBackoff
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.types[jobType] = &registration{handler: handler, opts: opts}
+}
+
+// Enqueue queues a job of a registered type with a JSON-encodable payload and returns its status.
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}, opts EnqueueOptions) (*Job, error) {
+	q.mu.Lock()
+	reg, ok := q.types[jobType]
+	q.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown job type: %s", jobType)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s job payload: %v", jobType, err)
+	}
+	sealed, err := q.pii.Encrypt(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("encrypt %s job payload: %v", jobType, err)
+	}
+	if opts.UniqueKey != "" && opts.UniqueFor <= 0 {
+		opts.UniqueFor = q.retention
+	}
+	now := time.Now().UTC()
+	job := &Job{
+		ID:          newJobID(),
+		Type:        jobType,
+		Status:      StatusQueued,
+		MaxAttempts: reg.opts.MaxAttempts,
+		CreatedBy:   opts.CreatedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Payload:     body,
+		uniqueKey:   opts.UniqueKey,
+	}
+	fields := map[string]interface{}{
+		"id":          job.ID,
+		"type":        job.Type,
+		"status":      job.Status,
+		"attempts":    0,
+		"maxAttempts": job.MaxAttempts,
+		"payload":     sealed,
+		"createdBy":   job.CreatedBy,
+		"createdAt":   formatTime(now),
+		"updatedAt":   formatTime(now),
+		"uniqueKey":   opts.UniqueKey,
+	}
+
+	id, err := cache.EnqueueJob(ctx, jobType, job.ID, fields, opts.UniqueKey, opts.UniqueFor)
+	if err != nil {
+		return nil, err
+	}
+	if id == job.ID {
+		return job, nil
+	}
+	// a job is already pending under the uniqueness key
+	existing, err := q.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return &Job{ID: id, Type: jobType, Status: StatusQueued, MaxAttempts: reg.opts.MaxAttempts}, nil
+	}
+	return existing, nil
+}
+
+// Get returns a job's status, or nil if the job is unknown or finished longer ago than the retention.
+func (q *Queue) Get(ctx context.Context, id string) (*Job, error) {
+	fields, err := cache.GetJob(ctx, id)
+	if err != nil || fields == nil {
+		return nil, err
+	}
+
+	payload, err := q.pii.Decrypt(fields["payload"])
+	if err != nil {
+		return nil, fmt.Errorf("decrypt job payload: jobId=%s, error=%v", id, err)
+	}
+	job := &Job{
+		ID:        fields["id"],
+		Type:      fields["type"],
+		Status:    fields["status"],
+		Error:     fields["error"],
+		CreatedBy: fields["createdBy"],
+		Payload:   json.RawMessage(payload),
+		uniqueKey: fields["uniqueKey"],
+	}
+	job.Attempts, _ = strconv.Atoi(fields["attempts"])
+	job.MaxAttempts, _ = strconv.Atoi(fields["maxAttempts"])
+	if progress := fields["progress"]; progress != "" {
+		job.Progress = json.RawMessage(progress)
+	}
+	if result := fields["result"]; result != "" {
+		job.Result = json.RawMessage(result)
+	}
+	job.CreatedAt = parseTime(fields["createdAt"])
+	job.UpdatedAt = parseTime(fields["updatedAt"])
+	if t := parseTime(fields["nextAttemptAt"]); !t.IsZero() {
+		job.NextAttemptAt = &t
+	}
+	if t := parseTime(fields["finishedAt"]); !t.IsZero() {
+		job.FinishedAt = &t
+	}
+	return job, nil
+}
+
+// ReportProgress records how far a running job has got, reported with its status until it finishes.
+// Handlers retried after a failed attempt can read it back from the job to resume where it stopped.
+func (q *Queue) ReportProgress(ctx context.Context, job *Job, progress interface{}) error {
+	body, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("encode %s job progress: %v", job.Type, err)
+	}
+	job.Progress = body
+	return cache.UpdateJob(ctx, job.ID, map[string]interface{}{
+		"progress":  string(body),
+		"updatedAt": formatTime(time.Now().UTC()),
+	}, 0)
+}
+
+// Depth returns how many jobs of each registered type are waiting for a worker, across all instances.
+func (q *Queue) Depth(ctx context.Context) (map[string]int64, error) {
+	q.mu.Lock()
+	jobTypes := make([]string, 0, len(q.types))
+	for jobType := range q.types {
+		jobTypes = append(jobTypes, jobType)
+	}
+	q.mu.Unlock()
+
+	depth := make(map[string]int64, len(jobTypes))
+	for _, jobType := range jobTypes {
+		length, err := cache.JobQueueLength(ctx, jobType)
+		if err != nil {
+			return nil, err
+		}
+		depth[jobType] = length
+	}
+	return depth, nil
+}
+
+// Start launches the workers of every registered job type.
+func (q *Queue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.started {
+		return
+	}
+	q.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q.cancel = cancel
+	workers := 0
+	for jobType, reg := range q.types {
+		q.wg.Add(1)
+		go q.promote(ctx, jobType)
+		for i := 0; i < reg.opts.Workers; i++ {
+			q.wg.Add(1)
+			go q.work(ctx, jobType, reg)
+		}
+		workers += reg.opts.Workers
+	}
+	logger.GlobalLogger.Printf("Job queue started: types=%d, workers=%d", len(q.types), workers)
+}
+
+// Stop cancels running attempts, which are retried later, and waits for the workers to exit.
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	if !q.started {
+		q.mu.Unlock()
+		return
+	}
+	q.started = false
+	q.cancel()
+	q.mu.Unlock()
+	q.wg.Wait()
+}
+
+// promote moves due retries and jobs abandoned by dead workers back onto the queue of a job type.
+func (q *Queue) promote(ctx context.Context, jobType string) {
+	defer q.wg.Done()
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := cache.PromoteJobs(ctx, jobType, promoteBatch); err != nil && ctx.Err() == nil {
+			logger.GlobalLogger.Warnf("Failed to promote jobs: type=%s, error=%v", jobType, err)
+		}
+	}
+}
+
+func (q *Queue) work(ctx context.Context, jobType string, reg *registration) {
+	defer q.wg.Done()
+	lease := reg.opts.Timeout + leaseMargin
+	for ctx.Err() == nil {
+		id, err := cache.ClaimJob(ctx, jobType, lease)
+		if err != nil && ctx.Err() == nil {
+			logger.GlobalLogger.Warnf("Failed to claim job: type=%s, error=%v", jobType, err)
+		}
+		if id == "" {
+			select {
+			case <-ctx.Done():
+			case <-time.After(q.pollInterval):
+			}
+			continue
+		}
+		q.run(ctx, jobType, reg, id)
+	}
+}
+
+// run makes one attempt at a claimed job and records the outcome.
+func (q *Queue) run(ctx context.Context, jobType string, reg *registration, id string) {
+	job, err := q.get(id)
+	if err != nil {
+		// the lease runs out and the job is claimed again
+		logger.GlobalLogger.Warnf("Failed to load job: jobId=%s, error=%v", id, err)
+		return
+	}
+	if job == nil {
+		// the status expired while the job was queued; there is nothing left to run
+		q.bookkeep(id, "ack_job", func(ctx context.Context) error { return cache.AckJob(ctx, jobType, id) })
+		return
+	}
+	if job.Attempts, err = q.incrementAttempts(id); err != nil {
+		logger.GlobalLogger.Warnf("Failed to count job attempt: jobId=%s, error=%v", id, err)
+		return
+	}
+	if job.Attempts > job.MaxAttempts {
+		// earlier attempts never reported back, e.g. because they crashed the worker
+		q.fail(job, fmt.Errorf("job abandoned after %d attempts", job.MaxAttempts))
+		return
+	}
+	q.update(job.ID, map[string]interface{}{"status": StatusRunning, "updatedAt": formatTime(time.Now().UTC())}, 0)
+
+	start := time.Now()
+	attemptCtx, cancel := context.WithTimeout(ctx, reg.opts.Timeout)
+	result, err := call(attemptCtx, reg.handler, job)
+	cancel()
+	metrics.JobDuration.WithLabelValues(job.Type).Observe(time.Since(start).Seconds())
+
+	if err == nil {
+		q.succeed(job, result)
+		return
+	}
+	var permanent *permanentError
+	if errors.As(err, &permanent) || job.LastAttempt() {
+		q.fail(job, err)
+		return
+	}
+	q.retry(job, reg.opts, err)
+}
+
+// call runs a handler, turning a panic into an error so one bad job can't take the worker down.
+func call(ctx context.Context, handler Handler, job *Job) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+func (q *Queue) succeed(job *Job, result interface{}) {
+	metrics.JobsProcessedTotal.WithLabelValues(job.Type, StatusSucceeded).Inc()
+	now := time.Now().UTC()
+	fields := map[string]interface{}{
+		"status":     StatusSucceeded,
+		"error":      "",
+		"updatedAt":  formatTime(now),
+		"finishedAt": formatTime(now),
+	}
+	if result != nil {
+		body, err := json.Marshal(result)
+		if err != nil {
+			logger.GlobalLogger.Warnf("Failed to encode job result: jobId=%s, type=%s, error=%v", job.ID, job.Type, err)
+		} else {
+			fields["result"] = string(body)
+		}
+	}
+	q.update(job.ID, fields, q.retention)
+	q.bookkeep(job.ID, "ack_job", func(ctx context.Context) error { return cache.AckJob(ctx, job.Type, job.ID) })
+	q.releaseUniqueKey(job)
+	logger.GlobalLogger.Printf("Job succeeded: jobId=%s, type=%s, attempt=%d", job.ID, job.Type, job.Attempts)
+}
+
+func (q *Queue) retry(job *Job, opts Options, jobErr error) {
+	metrics.JobsProcessedTotal.WithLabelValues(job.Type, StatusRetrying).Inc()
+	now := time.Now().UTC()
+	next := now.Add(backoff(opts, job.Attempts))
+	logger.GlobalLogger.Warnf("Job attempt failed: jobId=%s, type=%s, attempt=%d/%d, retryAt=%s, error=%v",
+		job.ID, job.Type, job.Attempts, job.MaxAttempts, next.Format(time.RFC3339), jobErr)
+	q.update(job.ID, map[string]interface{}{
+		"status":        StatusRetrying,
+		"error":         jobErr.Error(),
+		"updatedAt":     formatTime(now),
+		"nextAttemptAt": formatTime(next),
+	}, 0)
+	q.bookkeep(job.ID, "retry_job", func(ctx context.Context) error { return cache.RetryJob(ctx, job.Type, job.ID, next) })
+}
+
+func (q *Queue) fail(job *Job, jobErr error) {
+	metrics.JobsProcessedTotal.WithLabelValues(job.Type, StatusFailed).Inc()
+	now := time.Now().UTC()
+	logger.GlobalLogger.Errorf("Job failed: jobId=%s, type=%s, attempts=%d, error=%v", job.ID, job.Type, job.Attempts, jobErr)
+	q.update(job.ID, map[string]interface{}{
+		"status":        StatusFailed,
+		"error":         jobErr.Error(),
+		"updatedAt":     formatTime(now),
+		"finishedAt":    formatTime(now),
+		"nextAttemptAt": "",
+	}, q.retention)
+	q.bookkeep(job.ID, "bury_job", func(ctx context.Context) error { return cache.BuryJob(ctx, job.Type, job.ID, q.deadLetter) })
+	q.releaseUniqueKey(job)
+}
+
+func (q *Queue) releaseUniqueKey(job *Job) {
+	if job.uniqueKey == "" {
+		return
+	}
+	q.bookkeep(job.ID, "release_job_unique_key", func(ctx context.Context) error {
+		return cache.ReleaseJobUniqueKey(ctx, job.uniqueKey, job.ID)
+	})
+}
+
+// The bookkeeping helpers run detached from the worker context, so an attempt interrupted by Stop
+// still has its outcome recorded.
+
+func (q *Queue) get(id string) (*Job, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer cancel()
+	return q.Get(ctx, id)
+}
+
+func (q *Queue) incrementAttempts(id string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer cancel()
+	return cache.IncrementJobAttempts(ctx, id)
+}
+
+func (q *Queue) update(id string, fields map[string]interface{}, ttl time.Duration) {
+	q.bookkeep(id, "update_job", func(ctx context.Context) error { return cache.UpdateJob(ctx, id, fields, ttl) })
+}
+
+func (q *Queue) bookkeep(id, op string, fn func(ctx context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer cancel()
+	if err := fn(ctx); err != nil {
+		logger.GlobalLogger.Warnf("Job bookkeeping failed: jobId=%s, op=%s, error=%v", id, op, err)
+	}
+}
+
+// backoff is the wait after the given number of failed attempts.
+func backoff(opts Options, attempts int) time.Duration {
+	wait := opts.InitialBackoff
+	for i := 1; i < attempts && wait < opts.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, opts.MaxBackoff)
+}
+
+func newJobID() string {
+	id := make([]byte, 12)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339Nano, value)
+	return t
+}
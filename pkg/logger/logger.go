@@ -1,37 +1,26 @@
-
 package logger
 
 import (
+	"context"
+	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
+	"time"
 
-	"github.com/fatih/color"
+	"homeinsight-properties/pkg/requestid"
 )
 
-// Logger struct to hold leveled loggers and configuration
+// Logger writes one JSON object per line with time, level, msg and caller, followed by any fields
+// attached with With or WithContext.
 type Logger struct {
-	infoLogger  *log.Logger
-	warnLogger  *log.Logger
-	errorLogger *log.Logger
-	debugLogger *log.Logger
-	output      io.Writer
-	level       LogLevel
-	mutex       sync.Mutex
+	sl *slog.Logger
 }
 
-// LogLevel defines the logging levels
-type LogLevel int
-
-const (
-	DEBUG LogLevel = iota
-	INFO
-	WARN
-	ERROR
-)
-
 // Global logger instance
 var GlobalLogger *Logger
 var once sync.Once
@@ -43,97 +32,110 @@ func InitLogger(output io.Writer, level string) {
 			output = os.Stdout
 		}
 
-		logLevel := INFO
+		logLevel := slog.LevelInfo
 		switch strings.ToUpper(level) {
 		case "DEBUG":
-			logLevel = DEBUG
+			logLevel = slog.LevelDebug
 		case "INFO":
-			logLevel = INFO
+			logLevel = slog.LevelInfo
 		case "WARN":
-			logLevel = WARN
+			logLevel = slog.LevelWarn
 		case "ERROR":
-			logLevel = ERROR
+			logLevel = slog.LevelError
 		}
 
-		GlobalLogger = &Logger{
-			infoLogger:  log.New(output, color.GreenString("INFO: "), log.Ldate|log.Ltime|log.Lshortfile),
-			warnLogger:  log.New(output, color.YellowString("WARN: "), log.Ldate|log.Ltime|log.Lshortfile),
-			errorLogger: log.New(output, color.RedString("ERROR: "), log.Ldate|log.Ltime|log.Lshortfile),
-			debugLogger: log.New(output, color.BlueString("DEBUG: "), log.Ldate|log.Ltime|log.Lshortfile),
-			output:      output,
-			level:       logLevel,
-		}
+		handler := slog.NewJSONHandler(output, &slog.HandlerOptions{
+			AddSource:   true,
+			Level:       logLevel,
+			ReplaceAttr: replaceAttr,
+		})
+		GlobalLogger = &Logger{sl: slog.New(handler)}
 	})
 }
 
+// replaceAttr shortens the source location to a "dir/file.go:line" caller field and lowercases levels.
+func replaceAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	switch a.Key {
+	case slog.SourceKey:
+		if src, ok := a.Value.Any().(*slog.Source); ok {
+			file := filepath.Join(filepath.Base(filepath.Dir(src.File)), filepath.Base(src.File))
+			return slog.String("caller", fmt.Sprintf("%s:%d", file, src.Line))
+		}
+	case slog.LevelKey:
+		return slog.String(slog.LevelKey, strings.ToLower(a.Value.String()))
+	}
+	return a
+}
+
+// With returns a logger that adds the given key/value pairs to every entry.
+func (l *Logger) With(keysAndValues ...interface{}) *Logger {
+	return &Logger{sl: l.sl.With(keysAndValues...)}
+}
+
+// WithContext returns a logger that tags every entry with the request ID carried by ctx, if any.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return l.With("request_id", id)
+	}
+	return l
+}
+
+// log records the caller of the exported method that called it.
+func (l *Logger) log(level slog.Level, msg string) {
+	ctx := context.Background()
+	if !l.sl.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:]) // skip Callers, log and the exported method
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	_ = l.sl.Handler().Handle(ctx, record)
+}
+
+// sprintln formats like fmt.Println without the trailing newline.
+func sprintln(v ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(v...), "\n")
+}
+
 // Println logs a message at the INFO level
 func (l *Logger) Println(v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= INFO {
-		l.infoLogger.Println(v...)
-	}
+	l.log(slog.LevelInfo, sprintln(v...))
 }
 
 // Printf logs a formatted message at the INFO level
 func (l *Logger) Printf(format string, v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= INFO {
-		l.infoLogger.Printf(format, v...)
-	}
+	l.log(slog.LevelInfo, fmt.Sprintf(format, v...))
 }
 
 // Warn logs a message at the WARN level
 func (l *Logger) Warn(v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= WARN {
-		l.warnLogger.Println(v...)
-	}
+	l.log(slog.LevelWarn, sprintln(v...))
 }
 
 // Warnf logs a formatted message at the WARN level
 func (l *Logger) Warnf(format string, v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= WARN {
-		l.warnLogger.Printf(format, v...)
-	}
+	l.log(slog.LevelWarn, fmt.Sprintf(format, v...))
 }
 
 // Error logs a message at the ERROR level
 func (l *Logger) Error(v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= ERROR {
-		l.errorLogger.Println(v...)
-	}
+	l.log(slog.LevelError, sprintln(v...))
 }
 
 // Errorf logs a formatted message at the ERROR level
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= ERROR {
-		l.errorLogger.Printf(format, v...)
-	}
+	l.log(slog.LevelError, fmt.Sprintf(format, v...))
 }
 
 // Debug logs a message at the DEBUG level
 func (l *Logger) Debug(v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= DEBUG {
-		l.debugLogger.Println(v...)
-	}
+	l.log(slog.LevelDebug, sprintln(v...))
 }
 
 // Debugf logs a formatted message at the DEBUG level
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-	if l.level <= DEBUG {
-		l.debugLogger.Printf(format, v...)
-	}
+	l.log(slog.LevelDebug, fmt.Sprintf(format, v...))
 }
@@ -9,17 +9,166 @@ var (
 	HTTPRequestsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_requests_total",
-			Help: "Total number of HTTP requests",
+			Help: "Total number of HTTP requests by method, route template and status",
 		},
-		[]string{"method", "endpoint", "status"},
+		[]string{"method", "route", "status"},
 	)
 	HTTPRequestDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "http_request_duration_seconds",
-			Help:    "HTTP request duration in seconds",
+			Help:    "HTTP request duration in seconds by method, route template and status",
 			Buckets: prometheus.DefBuckets,
 		},
-		[]string{"method", "endpoint", "status"},
+		[]string{"method", "route", "status"},
+	)
+	RequestCostUnitsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_request_cost_units_total",
+			Help: "Total cost units consumed by HTTP requests",
+		},
+		[]string{"method", "route"},
+	)
+	DeprecatedRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_deprecated_requests_total",
+			Help: "Total number of requests using a deprecated endpoint or parameter",
+		},
+		[]string{"deprecation"},
+	)
+	UsageQuotaRejectionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "usage_quota_rejections_total",
+			Help: "Total number of requests and CoreLogic fetches refused by a daily usage quota",
+		},
+		[]string{"metric"},
+	)
+	LoginFailuresTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "auth_login_failures_total",
+			Help: "Total number of failed password sign-ins",
+		},
+	)
+	LoginLockoutsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_login_lockouts_total",
+			Help: "Total number of temporary sign-in lockouts by scope (email or ip)",
+		},
+		[]string{"scope"},
+	)
+	WebhookDeliveriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts by event and outcome",
+		},
+		[]string{"event", "outcome"},
+	)
+	EventsPublishedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "events_published_total",
+			Help: "Total number of event broker publish attempts by event and outcome",
+		},
+		[]string{"event", "outcome"},
+	)
+	JobsProcessedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "jobs_processed_total",
+			Help: "Total number of background job attempts by job type and outcome",
+		},
+		[]string{"type", "outcome"},
+	)
+	JobDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "job_duration_seconds",
+			Help:    "Duration of background job attempts in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"type"},
+	)
+	ReconciliationItemsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "reconciliation_items_total",
+			Help: "Total number of items handled by reconciliation runs by task, e.g. refreshes queued",
+		},
+		[]string{"task"},
+	)
+	ReconciliationLastRunTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "reconciliation_last_run_timestamp_seconds",
+			Help: "Unix time of the last reconciliation run of each task, by outcome",
+		},
+		[]string{"task", "outcome"},
+	)
+
+	PropertyProviderRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "property_provider_requests_total",
+			Help: "Total number of external property data provider fetches by provider and outcome",
+		},
+		[]string{"provider", "outcome"},
+	)
+
+	// CoreLogic Metrics
+	CoreLogicRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "corelogic_request_duration_seconds",
+			Help:    "Duration of CoreLogic API calls in seconds by operation and response status",
+			Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"operation", "status"},
+	)
+	CoreLogicErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "corelogic_errors_total",
+			Help: "Total number of failed or refused CoreLogic calls by operation and reason",
+		},
+		[]string{"operation", "reason"},
+	)
+	CoreLogicTokenRefreshesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "corelogic_token_refreshes_total",
+			Help: "Total number of CoreLogic access token refreshes by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	AddressStandardizationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "address_standardizations_total",
+			Help: "Total number of address standardization lookups by provider and outcome",
+		},
+		[]string{"provider", "outcome"},
+	)
+
+	HazardLookupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hazard_lookups_total",
+			Help: "Total number of flood and wildfire risk lookups by provider and outcome",
+		},
+		[]string{"provider", "outcome"},
+	)
+
+	WalkabilityLookupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "walkability_lookups_total",
+			Help: "Total number of walkability and points of interest lookups by provider and outcome",
+		},
+		[]string{"provider", "outcome"},
+	)
+
+	DemographicsLookupsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "demographics_lookups_total",
+			Help: "Total number of census tract demographics lookups by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	SearchIndexRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "search_index_requests_total",
+			Help: "Total number of Elasticsearch/OpenSearch requests by operation and outcome",
+		},
+		[]string{"operation", "outcome"},
 	)
 
 	// Redis Metrics
@@ -35,6 +184,46 @@ var (
 			Help: "Total number of Redis cache misses",
 		},
 	)
+	CacheClassHitsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_cache_class_hits_total",
+			Help: "Total number of Redis cache hits by key class",
+		},
+		[]string{"class"},
+	)
+	CacheClassMissesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_cache_class_misses_total",
+			Help: "Total number of Redis cache misses by key class",
+		},
+		[]string{"class"},
+	)
+	CacheInvalidationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "redis_cache_invalidations_total",
+			Help: "Total number of cache keys invalidated by key class",
+		},
+		[]string{"class"},
+	)
+	LocalCacheHitsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "local_cache_hits_total",
+			Help: "Total number of in-process cache hits",
+		},
+	)
+	LocalCacheMissesTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "local_cache_misses_total",
+			Help: "Total number of in-process cache misses",
+		},
+	)
+	CacheTTLSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "redis_cache_ttl_seconds",
+			Help: "Current TTL applied to newly cached keys by key class",
+		},
+		[]string{"class"},
+	)
 	RedisOperationDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "redis_operation_duration_seconds",
@@ -43,6 +232,14 @@ var (
 		},
 		[]string{"operation"},
 	)
+	RedisBatchSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "redis_batch_size",
+			Help:    "Number of keys in pipelined multi-key Redis operations",
+			Buckets: []float64{1, 5, 10, 25, 50, 100, 250, 500},
+		},
+		[]string{"operation"},
+	)
 	RedisErrorsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "redis_errors_total",
@@ -67,15 +264,66 @@ var (
 		},
 		[]string{"operation", "collection"},
 	)
+	MongoSlowOperationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mongodb_slow_operations_total",
+			Help: "Total number of MongoDB commands slower than the slow query threshold",
+		},
+		[]string{"operation", "collection"},
+	)
+	SchemaMigratedReadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mongodb_schema_migrated_reads_total",
+			Help: "Total number of documents upgraded from an older schema version on read",
+		},
+		[]string{"collection", "from_version"},
+	)
+	SchemaViolationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openapi_schema_violations_total",
+			Help: "Total number of requests and responses that didn't match the API spec",
+		},
+		[]string{"route", "direction"},
+	)
 )
 
 func Init() {
 	prometheus.MustRegister(HTTPRequestsTotal)
 	prometheus.MustRegister(HTTPRequestDuration)
+	prometheus.MustRegister(RequestCostUnitsTotal)
+	prometheus.MustRegister(DeprecatedRequestsTotal)
+	prometheus.MustRegister(UsageQuotaRejectionsTotal)
+	prometheus.MustRegister(LoginFailuresTotal)
+	prometheus.MustRegister(LoginLockoutsTotal)
+	prometheus.MustRegister(WebhookDeliveriesTotal)
+	prometheus.MustRegister(EventsPublishedTotal)
+	prometheus.MustRegister(JobsProcessedTotal)
+	prometheus.MustRegister(JobDuration)
+	prometheus.MustRegister(ReconciliationItemsTotal)
+	prometheus.MustRegister(ReconciliationLastRunTimestamp)
+	prometheus.MustRegister(PropertyProviderRequestsTotal)
+	prometheus.MustRegister(CoreLogicRequestDuration)
+	prometheus.MustRegister(CoreLogicErrorsTotal)
+	prometheus.MustRegister(CoreLogicTokenRefreshesTotal)
+	prometheus.MustRegister(AddressStandardizationsTotal)
+	prometheus.MustRegister(HazardLookupsTotal)
+	prometheus.MustRegister(WalkabilityLookupsTotal)
+	prometheus.MustRegister(DemographicsLookupsTotal)
+	prometheus.MustRegister(SearchIndexRequestsTotal)
 	prometheus.MustRegister(CacheHitsTotal)
 	prometheus.MustRegister(CacheMissesTotal)
+	prometheus.MustRegister(CacheClassHitsTotal)
+	prometheus.MustRegister(CacheClassMissesTotal)
+	prometheus.MustRegister(CacheInvalidationsTotal)
+	prometheus.MustRegister(LocalCacheHitsTotal)
+	prometheus.MustRegister(LocalCacheMissesTotal)
+	prometheus.MustRegister(CacheTTLSeconds)
 	prometheus.MustRegister(RedisOperationDuration)
+	prometheus.MustRegister(RedisBatchSize)
 	prometheus.MustRegister(RedisErrorsTotal)
 	prometheus.MustRegister(MongoOperationDuration)
 	prometheus.MustRegister(MongoErrorsTotal)
+	prometheus.MustRegister(MongoSlowOperationsTotal)
+	prometheus.MustRegister(SchemaMigratedReadsTotal)
+	prometheus.MustRegister(SchemaViolationsTotal)
 }
@@ -0,0 +1,49 @@
+package neighborhood
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// CachedProvider remembers a provider's answers in Redis, so refreshing a property, or its
+// neighbours on the same block, doesn't call the provider again. Points are rounded to about 10
+// meters. Cache failures fall through to the provider.
+type CachedProvider struct {
+	next Provider
+	ttl  time.Duration
+}
+
+func NewCachedProvider(next Provider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{next: next, ttl: ttl}
+}
+
+func (p *CachedProvider) Name() string {
+	return p.next.Name()
+}
+
+func (p *CachedProvider) Lookup(ctx context.Context, lat, lng float64) (*Walkability, error) {
+	point := fmt.Sprintf("%s|%.4f,%.4f", p.next.Name(), lat, lng)
+	if data, err := cache.GetWalkability(ctx, point); err != nil {
+		logger.GlobalLogger.Warnf("Failed to read cached walkability: error=%v", err)
+	} else if data != nil {
+		var walkability Walkability
+		if err := json.Unmarshal(data, &walkability); err == nil {
+			return &walkability, nil
+		}
+	}
+
+	walkability, err := p.next.Lookup(ctx, lat, lng)
+	if err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(walkability)
+	if cacheErr := cache.SetWalkability(ctx, point, data, p.ttl); cacheErr != nil {
+		logger.GlobalLogger.Warnf("Failed to cache walkability: error=%v", cacheErr)
+	}
+	return walkability, nil
+}
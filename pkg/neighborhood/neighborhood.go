@@ -0,0 +1,102 @@
+package neighborhood
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+)
+
+// PointsOfInterest counts the amenities within walking distance of a location, by category.
+type PointsOfInterest struct {
+	Grocery     int `json:"grocery"`
+	Restaurants int `json:"restaurants"`
+	Shopping    int `json:"shopping"`
+	Schools     int `json:"schools"`
+	Parks       int `json:"parks"`
+	Transit     int `json:"transit"`
+	Healthcare  int `json:"healthcare"`
+}
+
+// Total is the number of points of interest in every category.
+func (p PointsOfInterest) Total() int {
+	return p.Grocery + p.Restaurants + p.Shopping + p.Schools + p.Parks + p.Transit + p.Healthcare
+}
+
+// Walkability is how well a location is served on foot: the amenities within RadiusMeters and a
+// walk score from 0 (car-dependent) to 100 (daily errands don't need a car).
+type Walkability struct {
+	WalkScore        int              `json:"walkScore"`
+	RadiusMeters     int              `json:"radiusMeters"`
+	PointsOfInterest PointsOfInterest `json:"pointsOfInterest"`
+}
+
+// Provider looks up the walkability of a point.
+type Provider interface {
+	Name() string
+	Lookup(ctx context.Context, lat, lng float64) (*Walkability, error)
+}
+
+// New builds the configured provider, wrapped in the Redis result cache. Returns nil when
+// neighborhood enrichment is turned off.
+func New(cfg *config.Config) (Provider, error) {
+	settings := cfg.Neighborhood
+	timeout := time.Duration(settings.TimeoutSeconds) * time.Second
+
+	var provider Provider
+	switch settings.Provider {
+	case "":
+		return nil, nil
+	case "osm":
+		provider = NewOverpassProvider(settings.OSM.BaseURL, settings.RadiusMeters, timeout)
+	default:
+		return nil, fmt.Errorf("unknown neighborhood provider: %q", settings.Provider)
+	}
+	return NewCachedProvider(provider, time.Duration(settings.CacheTTLHours)*time.Hour), nil
+}
+
+// walkWeights weigh each category's share of the walk score. A category counts in full once it
+// has enough amenities that another one wouldn't change daily errands.
+var walkWeights = []struct {
+	weight, enough int
+	count          func(PointsOfInterest) int
+}{
+	{3, 3, func(p PointsOfInterest) int { return p.Grocery }},
+	{3, 10, func(p PointsOfInterest) int { return p.Restaurants }},
+	{2, 10, func(p PointsOfInterest) int { return p.Shopping }},
+	{2, 5, func(p PointsOfInterest) int { return p.Transit }},
+	{1, 2, func(p PointsOfInterest) int { return p.Schools }},
+	{1, 2, func(p PointsOfInterest) int { return p.Parks }},
+	{1, 2, func(p PointsOfInterest) int { return p.Healthcare }},
+}
+
+// WalkScore rates points of interest from 0 to 100 by the weighted share of each category that is
+// within walking distance.
+func WalkScore(p PointsOfInterest) int {
+	var score, total float64
+	for _, w := range walkWeights {
+		count := w.count(p)
+		if count > w.enough {
+			count = w.enough
+		}
+		score += float64(w.weight) * float64(count) / float64(w.enough)
+		total += float64(w.weight)
+	}
+	return int(score/total*100 + 0.5)
+}
+
+// Describe names the band a walk score falls in.
+func Describe(walkScore int) string {
+	switch {
+	case walkScore >= 90:
+		return "Walker's Paradise"
+	case walkScore >= 70:
+		return "Very Walkable"
+	case walkScore >= 50:
+		return "Somewhat Walkable"
+	case walkScore >= 25:
+		return "Car-Dependent"
+	}
+	return "Almost All Errands Require a Car"
+}
@@ -0,0 +1,118 @@
+package neighborhood
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// overpassCategories are the OpenStreetMap tag filters counted for each category, in the order
+// the counts come back.
+var overpassCategories = []struct {
+	filters []string
+	count   func(*PointsOfInterest) *int
+}{
+	{[]string{`nwr["shop"~"^(supermarket|convenience|greengrocer)$"]`}, func(p *PointsOfInterest) *int { return &p.Grocery }},
+	{[]string{`nwr["amenity"~"^(restaurant|cafe|fast_food|bar|pub)$"]`}, func(p *PointsOfInterest) *int { return &p.Restaurants }},
+	{[]string{`nwr["shop"]["shop"!~"^(supermarket|convenience|greengrocer)$"]`}, func(p *PointsOfInterest) *int { return &p.Shopping }},
+	{[]string{`nwr["amenity"~"^(school|kindergarten)$"]`}, func(p *PointsOfInterest) *int { return &p.Schools }},
+	{[]string{`nwr["leisure"~"^(park|playground)$"]`}, func(p *PointsOfInterest) *int { return &p.Parks }},
+	{[]string{`node["highway"="bus_stop"]`, `nwr["railway"~"^(station|halt|tram_stop|subway_entrance)$"]`}, func(p *PointsOfInterest) *int { return &p.Transit }},
+	{[]string{`nwr["amenity"~"^(pharmacy|clinic|doctors|hospital)$"]`}, func(p *PointsOfInterest) *int { return &p.Healthcare }},
+}
+
+// OverpassProvider counts OpenStreetMap points of interest around a location through the Overpass
+// API, and scores walkability from the counts.
+type OverpassProvider struct {
+	baseURL      string
+	radiusMeters int
+	timeout      time.Duration
+	client       *http.Client
+}
+
+func NewOverpassProvider(baseURL string, radiusMeters int, timeout time.Duration) *OverpassProvider {
+	return &OverpassProvider{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		radiusMeters: radiusMeters,
+		timeout:      timeout,
+		client:       &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *OverpassProvider) Name() string {
+	return "osm"
+}
+
+type overpassResponse struct {
+	Remark   string `json:"remark"`
+	Elements []struct {
+		Type string `json:"type"`
+		Tags struct {
+			Total string `json:"total"`
+		} `json:"tags"`
+	} `json:"elements"`
+}
+
+func (p *OverpassProvider) Lookup(ctx context.Context, lat, lng float64) (*Walkability, error) {
+	around := fmt.Sprintf("(around:%d,%f,%f)", p.radiusMeters, lat, lng)
+	var query strings.Builder
+	// The server gives up when the client would, rather than finishing a query nobody waits for
+	fmt.Fprintf(&query, "[out:json][timeout:%d];\n", int(p.timeout.Seconds()))
+	for _, category := range overpassCategories {
+		query.WriteString("(")
+		for _, filter := range category.filters {
+			query.WriteString(filter + around + ";")
+		}
+		query.WriteString(");out count;\n")
+	}
+
+	form := url.Values{}
+	form.Set("data", query.String())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("Overpass request failed: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Overpass request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("Overpass request failed: read response: %v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Overpass request failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result overpassResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("Overpass request failed: decode response: %v", err)
+	}
+	// Overpass answers a query that ran out of time or memory with a remark and partial results
+	if result.Remark != "" {
+		return nil, fmt.Errorf("Overpass request failed: %s", result.Remark)
+	}
+	if len(result.Elements) != len(overpassCategories) {
+		return nil, fmt.Errorf("Overpass request failed: got %d counts, want %d", len(result.Elements), len(overpassCategories))
+	}
+
+	walkability := &Walkability{RadiusMeters: p.radiusMeters}
+	for i, category := range overpassCategories {
+		count, err := strconv.Atoi(result.Elements[i].Tags.Total)
+		if err != nil {
+			return nil, fmt.Errorf("Overpass request failed: invalid count %q", result.Elements[i].Tags.Total)
+		}
+		*category.count(&walkability.PointsOfInterest) = count
+	}
+	walkability.WalkScore = WalkScore(walkability.PointsOfInterest)
+	return walkability, nil
+}
@@ -0,0 +1,81 @@
+package notifications
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"homeinsight-properties/pkg/mailer"
+)
+
+// Template names. Alert templates are named after the alert type they describe.
+const (
+	TemplateAlertDigest      = "alert_digest"
+	TemplateSavedSearchMatch = "saved_search_match"
+	TemplateOwnershipChange  = "ownership_change"
+	TemplatePasswordReset    = "password_reset"
+	TemplatePasswordChanged  = "password_changed"
+	TemplateAccountLocked    = "account_locked"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// templates holds one parsed template set per file, each defining a "subject" and a "body".
+var templates = mustParseTemplates()
+
+func mustParseTemplates() map[string]*template.Template {
+	entries, err := templateFiles.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+	parsed := make(map[string]*template.Template, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		parsed[name] = template.Must(template.New(name).ParseFS(templateFiles, "templates/"+entry.Name()))
+	}
+	return parsed
+}
+
+// Content is a rendered notification: the subject doubles as the in-app title.
+type Content struct {
+	Subject string
+	Body    string
+}
+
+// Render fills in the named template with data.
+func Render(name string, data interface{}) (*Content, error) {
+	tmpl, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown notification template: %q", name)
+	}
+	var subject, body strings.Builder
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("render notification template %s failed: %v", name, err)
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, fmt.Errorf("render notification template %s failed: %v", name, err)
+	}
+	return &Content{Subject: strings.TrimSpace(subject.String()), Body: strings.TrimSpace(body.String())}, nil
+}
+
+// Sender renders templates and delivers them by email through any Mailer, so the delivery channel
+// (SMTP, a provider API or the log) is chosen where the sender is built.
+type Sender struct {
+	mailer mailer.Mailer
+}
+
+func NewSender(m mailer.Mailer) *Sender {
+	return &Sender{mailer: m}
+}
+
+// Email renders the named template with data and sends it to one recipient.
+func (s *Sender) Email(ctx context.Context, to, name string, data interface{}) error {
+	content, err := Render(name, data)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(ctx, mailer.Message{To: to, Subject: content.Subject, Body: content.Body})
+}
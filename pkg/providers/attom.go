@@ -0,0 +1,201 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+)
+
+// attomIDPrefix keeps ATTOM IDs apart from CoreLogic CLIPs in the shared propertyId space.
+const attomIDPrefix = "attom-"
+
+// ATTOMProvider fetches properties from the ATTOM Property API detail endpoint.
+type ATTOMProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewATTOMProvider(baseURL, apiKey string, timeout time.Duration) *ATTOMProvider {
+	return &ATTOMProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (p *ATTOMProvider) Name() string {
+	return "attom"
+}
+
+type attomDetailResponse struct {
+	Status struct {
+		Code  int    `json:"code"`
+		Msg   string `json:"msg"`
+		Total int    `json:"total"`
+	} `json:"status"`
+	Property []attomProperty `json:"property"`
+}
+
+type attomProperty struct {
+	Identifier struct {
+		AttomID int64  `json:"attomId"`
+		FIPS    string `json:"fips"`
+		APN     string `json:"apn"`
+	} `json:"identifier"`
+	Lot struct {
+		LotSize1 float64 `json:"lotSize1"`
+		LotSize2 float64 `json:"lotSize2"`
+	} `json:"lot"`
+	Area struct {
+		CountrySecSubd string `json:"countrysecsubd"`
+		SubdName       string `json:"subdname"`
+	} `json:"area"`
+	Address struct {
+		Postal2 string `json:"postal2"`
+	} `json:"address"`
+	Location struct {
+		Latitude  string `json:"latitude"`
+		Longitude string `json:"longitude"`
+	} `json:"location"`
+	Summary struct {
+		PropType    string `json:"proptype"`
+		PropLandUse string `json:"propLandUse"`
+		YearBuilt   int    `json:"yearbuilt"`
+	} `json:"summary"`
+	Utilities struct {
+		HeatingFuel string `json:"heatingfuel"`
+		HeatingType string `json:"heatingtype"`
+	} `json:"utilities"`
+	Building struct {
+		Size struct {
+			UniversalSize float64 `json:"universalsize"`
+			LivingSize    float64 `json:"livingsize"`
+			GroundFloor   float64 `json:"groundfloorsize"`
+		} `json:"size"`
+		Rooms struct {
+			BathsTotal float64 `json:"bathstotal"`
+			BathsFull  int     `json:"bathsfull"`
+			BathsHalf  int     `json:"bathshalf"`
+			Beds       int     `json:"beds"`
+		} `json:"rooms"`
+		Interior struct {
+			BsmtSize  float64 `json:"bsmtsize"`
+			BsmtType  string  `json:"bsmttype"`
+			FplcCount int     `json:"fplccount"`
+		} `json:"interior"`
+		Construction struct {
+			Condition        string `json:"condition"`
+			WallType         string `json:"wallType"`
+			ConstructionType string `json:"constructiontype"`
+			FoundationType   string `json:"foundationtype"`
+			RoofCover        string `json:"roofcover"`
+		} `json:"construction"`
+		Parking struct {
+			GarageType string `json:"garagetype"`
+			PrkgSpaces string `json:"prkgSpaces"`
+		} `json:"parking"`
+		Summary struct {
+			Levels             int `json:"levels"`
+			YearBuiltEffective int `json:"yearbuilteffective"`
+			BldgsNum           int `json:"bldgsNum"`
+		} `json:"summary"`
+	} `json:"building"`
+}
+
+func (p *ATTOMProvider) FetchProperty(ctx context.Context, street, city, state, zip string) (*models.Property, error) {
+	query := url.Values{}
+	query.Set("address1", street)
+	query.Set("address2", strings.TrimSpace(fmt.Sprintf("%s, %s %s", city, state, zip)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/property/detail?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("ATTOM fetch failed: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("apikey", p.apiKey)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ATTOM fetch failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, fmt.Errorf("ATTOM fetch failed: read response: %v", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("ATTOM fetch failed: %w: address=%s", ErrNotFound, street)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("ATTOM fetch failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var detail attomDetailResponse
+	if err := json.Unmarshal(body, &detail); err != nil {
+		return nil, fmt.Errorf("ATTOM fetch failed: decode response: %v", err)
+	}
+	if len(detail.Property) == 0 || detail.Property[0].Identifier.AttomID == 0 {
+		return nil, fmt.Errorf("ATTOM fetch failed: %w: address=%s, status=%s", ErrNotFound, street, detail.Status.Msg)
+	}
+	return transformATTOMProperty(&detail.Property[0]), nil
+}
+
+// transformATTOMProperty maps the fields ATTOM shares with the CoreLogic-shaped property model.
+func transformATTOMProperty(src *attomProperty) *models.Property {
+	id := attomIDPrefix + strconv.FormatInt(src.Identifier.AttomID, 10)
+	property := &models.Property{
+		PropertyID:    id,
+		AVMPropertyID: id,
+		UpdatedAt:     time.Now().UTC(),
+	}
+	property.Address.ZipPlus4 = src.Address.Postal2
+	property.Address.County = src.Area.CountrySecSubd
+	property.Location.Legal.SubdivisionName = src.Area.SubdName
+	lat, _ := strconv.ParseFloat(src.Location.Latitude, 64)
+	lng, _ := strconv.ParseFloat(src.Location.Longitude, 64)
+	property.Location.Coordinates.Parcel = models.CoordinatesPoint{Lat: lat, Lng: lng}
+
+	property.Lot.AreaAcres = src.Lot.LotSize1
+	property.Lot.AreaSquareFeet = int(src.Lot.LotSize2)
+	property.LandUseAndZoning.PropertyTypeCode = src.Summary.PropType
+	property.LandUseAndZoning.LandUseCode = src.Summary.PropLandUse
+	property.Utilities.FuelTypeCode = src.Utilities.HeatingFuel
+
+	building := &property.Building
+	building.Summary.BuildingsCount = src.Building.Summary.BldgsNum
+	building.Summary.BathroomsCount = int(src.Building.Rooms.BathsTotal)
+	building.Summary.FullBathroomsCount = src.Building.Rooms.BathsFull
+	building.Summary.HalfBathroomsCount = src.Building.Rooms.BathsHalf
+	building.Summary.BedroomsCount = src.Building.Rooms.Beds
+	building.Summary.FireplacesCount = src.Building.Interior.FplcCount
+	building.Summary.LivingAreaSquareFeet = int(src.Building.Size.LivingSize)
+	building.Summary.TotalAreaSquareFeet = int(src.Building.Size.UniversalSize)
+
+	details := &building.Details
+	details.VerticalProfile.StoriesCount = src.Building.Summary.Levels
+	details.Construction.YearBuilt = src.Summary.YearBuilt
+	details.Construction.EffectiveYearBuilt = src.Building.Summary.YearBuiltEffective
+	details.Construction.FrameTypeCode = src.Building.Construction.ConstructionType
+	details.Construction.FoundationTypeCode = src.Building.Construction.FoundationType
+	details.Construction.BuildingImprovementConditionCode = src.Building.Construction.Condition
+	details.Exterior.Walls.TypeCode = src.Building.Construction.WallType
+	details.Exterior.Roof.CoverTypeCode = src.Building.Construction.RoofCover
+	details.Exterior.Parking.TypeCode = src.Building.Parking.GarageType
+	details.Exterior.Parking.ParkingSpacesCount, _ = strconv.Atoi(src.Building.Parking.PrkgSpaces)
+	details.Interior.Area.UniversalBuildingAreaSquareFeet = int(src.Building.Size.UniversalSize)
+	details.Interior.Area.LivingAreaSquareFeet = int(src.Building.Size.LivingSize)
+	details.Interior.Area.GroundFloorAreaSquareFeet = int(src.Building.Size.GroundFloor)
+	details.Interior.Area.BasementAreaSquareFeet = int(src.Building.Interior.BsmtSize)
+	details.Interior.Basement.TypeCode = src.Building.Interior.BsmtType
+	details.Interior.Features.Heating.TypeCode = src.Utilities.HeatingType
+	details.Interior.Features.Fireplaces.Count = src.Building.Interior.FplcCount
+	return property
+}
@@ -0,0 +1,36 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/corelogic"
+)
+
+// CoreLogicProvider fetches properties through the CoreLogic proxy.
+type CoreLogicProvider struct {
+	client *corelogic.Client
+}
+
+func NewCoreLogicProvider(client *corelogic.Client) *CoreLogicProvider {
+	return &CoreLogicProvider{client: client}
+}
+
+func (p *CoreLogicProvider) Name() string {
+	return "corelogic"
+}
+
+func (p *CoreLogicProvider) FetchProperty(ctx context.Context, street, city, state, zip string) (*models.Property, error) {
+	property, err := p.client.RequestCoreLogic(ctx, street, city, state, zip)
+	if err != nil {
+		// The client reports a missing address only in its error text
+		message := err.Error()
+		if strings.Contains(message, "no property found") || strings.Contains(message, "404 Not Found") || strings.Contains(message, "Clip not found") {
+			return nil, fmt.Errorf("CoreLogic fetch failed: %w: %v", ErrNotFound, err)
+		}
+		return nil, fmt.Errorf("CoreLogic fetch failed: %w", err)
+	}
+	return property, nil
+}
@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+)
+
+// MockProvider makes up a plausible property for any address, for sandbox requests without a fixture.
+// The same address always yields the same property.
+type MockProvider struct{}
+
+func NewMockProvider() *MockProvider {
+	return &MockProvider{}
+}
+
+func (p *MockProvider) Name() string {
+	return "mock"
+}
+
+func (p *MockProvider) FetchProperty(ctx context.Context, street, city, state, zip string) (*models.Property, error) {
+	h := fnv.New64a()
+	h.Write([]byte(strings.ToLower(strings.Join([]string{street, city, state, zip}, "|"))))
+	seed := h.Sum64()
+	// pick draws a value in [low, low+span) from the next bits of the seed
+	pick := func(low, span int) int {
+		v := low + int(seed%uint64(span))
+		seed /= uint64(span)
+		return v
+	}
+
+	id := fmt.Sprintf("mock-%016x", h.Sum64())
+	livingArea := pick(900, 2600)
+	property := &models.Property{
+		PropertyID:    id,
+		AVMPropertyID: id,
+		UpdatedAt:     time.Now().UTC(),
+	}
+	property.Lot.AreaSquareFeet = livingArea * pick(2, 5)
+	property.Lot.AreaAcres = float64(property.Lot.AreaSquareFeet) / 43560
+	property.LandUseAndZoning.PropertyTypeCode = "SFR"
+	property.Building.Summary.BuildingsCount = 1
+	property.Building.Summary.BedroomsCount = pick(1, 5)
+	property.Building.Summary.BathroomsCount = pick(1, 3)
+	property.Building.Summary.FullBathroomsCount = property.Building.Summary.BathroomsCount
+	property.Building.Summary.LivingAreaSquareFeet = livingArea
+	property.Building.Summary.TotalAreaSquareFeet = livingArea
+	property.Building.Details.VerticalProfile.StoriesCount = pick(1, 2)
+	property.Building.Details.Construction.YearBuilt = pick(1920, 100)
+	property.Building.Details.Interior.Area.LivingAreaSquareFeet = livingArea
+	return property, nil
+}
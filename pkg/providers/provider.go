@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/corelogic"
+)
+
+// ErrNotFound is wrapped by providers that have no record for the requested address.
+var ErrNotFound = errors.New("property not found by data provider")
+
+// Fallback policies: whether the next provider is tried after this one fails.
+const (
+	FallbackNone   = "none"   // never; this provider's answer is final
+	FallbackErrors = "errors" // when the provider is unavailable, but not when it has no record
+	FallbackAll    = "all"    // on any failure, including no record for the address
+)
+
+// PropertyDataProvider fetches a property record for an address from an external vendor. The returned
+// property carries the vendor's IDs; callers fill in the address and document ID.
+type PropertyDataProvider interface {
+	Name() string
+	FetchProperty(ctx context.Context, street, city, state, zip string) (*models.Property, error)
+}
+
+// ValuationProvider fetches the automated valuation of a property by its vendor IDs.
+type ValuationProvider interface {
+	RequestValuation(ctx context.Context, clip, avmPropertyID string) (*corelogic.AVMResult, error)
+}
+
+// Source is a configured provider and its fallback policy, in priority order.
+type Source struct {
+	Provider PropertyDataProvider
+	Fallback string
+}
+
+// FallsBack reports whether the next source should be tried after this one failed with err.
+func (s Source) FallsBack(err error) bool {
+	switch s.Fallback {
+	case FallbackNone:
+		return false
+	case FallbackAll:
+		return true
+	default:
+		return !errors.Is(err, ErrNotFound)
+	}
+}
+
+// New builds the configured providers in priority order. corelogicClient is shared with the other
+// CoreLogic features so the circuit breaker and daily quota cover every call. In sandbox mode the
+// fixture provider is the only one.
+func New(cfg *config.Config, corelogicClient *corelogic.Client) ([]Source, error) {
+	if cfg.Sandbox.Enabled {
+		return []Source{{Provider: newSandboxProvider(cfg), Fallback: FallbackNone}}, nil
+	}
+	sources := make([]Source, 0, len(cfg.PropertyData.Providers))
+	for _, entry := range cfg.PropertyData.Providers {
+		var provider PropertyDataProvider
+		switch entry.Name {
+		case "corelogic":
+			provider = NewCoreLogicProvider(corelogicClient)
+		case "attom":
+			provider = NewATTOMProvider(cfg.PropertyData.ATTOM.BaseURL, cfg.PropertyData.ATTOM.APIKey, time.Duration(cfg.PropertyData.ATTOM.TimeoutSeconds)*time.Second)
+		default:
+			return nil, fmt.Errorf("unknown property data provider: %q", entry.Name)
+		}
+		sources = append(sources, Source{Provider: provider, Fallback: entry.Fallback})
+	}
+	return sources, nil
+}
+
+// NewValuationProvider returns the source of automated valuations: CoreLogic, or the fixture
+// provider in sandbox mode.
+func NewValuationProvider(cfg *config.Config, corelogicClient *corelogic.Client) ValuationProvider {
+	if cfg.Sandbox.Enabled {
+		return newSandboxProvider(cfg)
+	}
+	return corelogicClient
+}
+
+func newSandboxProvider(cfg *config.Config) *MockPropertyDataProvider {
+	return NewMockPropertyDataProvider(cfg.Sandbox.FixturesDir, cfg.Sandbox.GenerateMissing)
+}
@@ -0,0 +1,119 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"homeinsight-properties/internal/models"
+	"homeinsight-properties/internal/transformers"
+	"homeinsight-properties/pkg/corelogic"
+)
+
+var nonFixtureChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// MockPropertyDataProvider serves sandbox mode from a library of fixture files instead of a vendor.
+// Properties are CoreLogic property detail responses under properties/, named by FixtureName;
+// valuations are AVM results under valuations/, named by CLIP. With generate set, requests without a
+// fixture get a made-up but stable answer rather than none.
+type MockPropertyDataProvider struct {
+	dir         string
+	generate    bool
+	generator   *MockProvider
+	transformer transformers.PropertyTransformer
+}
+
+func NewMockPropertyDataProvider(dir string, generate bool) *MockPropertyDataProvider {
+	return &MockPropertyDataProvider{
+		dir:         dir,
+		generate:    generate,
+		generator:   NewMockProvider(),
+		transformer: transformers.NewPropertyTransformer(),
+	}
+}
+
+func (p *MockPropertyDataProvider) Name() string {
+	return "sandbox"
+}
+
+// FixtureName is the file name of an address's property fixture: the lowercased street, city, state
+// and zip code with other characters replaced by hyphens, joined by underscores, e.g.
+// "1050-horseshoe-dr_nashville_tn_37216.json".
+func FixtureName(street, city, state, zip string) string {
+	parts := []string{street, city, state, zip}
+	for i, part := range parts {
+		parts[i] = strings.Trim(nonFixtureChars.ReplaceAllString(strings.ToLower(part), "-"), "-")
+	}
+	return strings.Join(parts, "_") + ".json"
+}
+
+func (p *MockPropertyDataProvider) FetchProperty(ctx context.Context, street, city, state, zip string) (*models.Property, error) {
+	name := FixtureName(street, city, state, zip)
+	var response map[string]interface{}
+	found, err := p.readFixture(filepath.Join("properties", name), &response)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if p.generate {
+			return p.generator.FetchProperty(ctx, street, city, state, zip)
+		}
+		return nil, fmt.Errorf("no sandbox fixture %s: %w", name, ErrNotFound)
+	}
+	property, err := p.transformer.TransformAPIResponse(response)
+	if err != nil {
+		return nil, fmt.Errorf("sandbox fixture %s: %v", name, err)
+	}
+	property.UpdatedAt = time.Now().UTC()
+	return property, nil
+}
+
+// RequestValuation returns the valuation fixture for a property's CLIP.
+func (p *MockPropertyDataProvider) RequestValuation(ctx context.Context, clip, avmPropertyID string) (*corelogic.AVMResult, error) {
+	var result corelogic.AVMResult
+	found, err := p.readFixture(filepath.Join("valuations", clip+".json"), &result)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return &result, nil
+	}
+	if !p.generate {
+		return nil, fmt.Errorf("no sandbox valuation fixture for clip %s: %w", clip, ErrNotFound)
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(clip))
+	value := float64(150000 + h.Sum64()%850000)
+	result.Clip = clip
+	result.AVM.ValuationDate = time.Now().UTC().Format("2006-01-02")
+	result.AVM.EstimatedValue = value
+	result.AVM.EstimatedValueLow = value * 0.92
+	result.AVM.EstimatedValueHigh = value * 1.08
+	result.AVM.ConfidenceScore = 80
+	result.AVM.ForecastStandardDeviation = 8
+	return &result, nil
+}
+
+// readFixture decodes a fixture file into v, reporting false when there is none.
+func (p *MockPropertyDataProvider) readFixture(name string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(p.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read sandbox fixture %s: %v", name, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("decode sandbox fixture %s: %v", name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,48 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header carries the request ID on inbound requests, responses and calls to other services.
+const Header = "X-Request-ID"
+
+// GinKey is where the request ID is stored on the gin context.
+const GinKey = "request_id"
+
+type contextKey struct{}
+
+// New returns a random 128-bit request ID.
+func New() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" if there is none. A gin context, or one
+// derived from it, is checked first, then the context of its request.
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if ginCtx, ok := ctx.Value(gin.ContextKey).(*gin.Context); ok {
+		if id := ginCtx.GetString(GinKey); id != "" {
+			return id
+		}
+		if ginCtx.Request == nil {
+			return ""
+		}
+		ctx = ginCtx.Request.Context()
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
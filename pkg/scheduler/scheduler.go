@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"homeinsight-properties/pkg/logger"
+)
+
+// JobFunc is the unit of work executed by the scheduler.
+type JobFunc func(ctx context.Context) error
+
+type job struct {
+	name string
+	next func(now time.Time) time.Time
+	run  JobFunc
+}
+
+// RunGuard decides whether and how a job runs for one of its scheduled times, slot, where next is
+// the job's time after that. Replicas each running a scheduler set one that lets a single replica run
+// each slot, calling run or returning ErrSkipped.
+type RunGuard func(ctx context.Context, job string, slot, next time.Time, run JobFunc) error
+
+// ErrSkipped is returned by a RunGuard that didn't run a job, such as when another replica did.
+var ErrSkipped = errors.New("scheduled run skipped")
+
+// Scheduler runs registered jobs in the background until stopped.
+type Scheduler struct {
+	mu      sync.Mutex
+	jobs    []job
+	guard   RunGuard
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers a job that runs on each interval boundary (e.g. the top of every hour).
+func (s *Scheduler) Every(name string, interval time.Duration, run JobFunc) {
+	s.add(job{
+		name: name,
+		next: func(now time.Time) time.Time {
+			return now.Truncate(interval).Add(interval)
+		},
+		run: run,
+	})
+}
+
+// DailyAt registers a job that runs once a day at the given UTC hour.
+func (s *Scheduler) DailyAt(name string, hourUTC int, run JobFunc) {
+	s.add(job{
+		name: name,
+		next: func(now time.Time) time.Time {
+			now = now.UTC()
+			next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+			if !next.After(now) {
+				next = next.AddDate(0, 0, 1)
+			}
+			return next
+		},
+		run: run,
+	})
+}
+
+// Cron registers a job that runs whenever a cron expression, evaluated in UTC, matches; see
+// ParseCron for the syntax.
+func (s *Scheduler) Cron(name, spec string, run JobFunc) error {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return err
+	}
+	if schedule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("cron expression %q never matches", spec)
+	}
+	s.add(job{name: name, next: schedule.Next, run: run})
+	return nil
+}
+
+// SetRunGuard wraps every run of every job in guard. Call it before Start.
+func (s *Scheduler) SetRunGuard(guard RunGuard) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.guard = guard
+}
+
+func (s *Scheduler) add(j job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, j)
+}
+
+// Start launches one goroutine per registered job.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j, s.guard)
+	}
+	logger.GlobalLogger.Printf("Scheduler started: jobs=%d", len(s.jobs))
+}
+
+// Stop cancels all jobs and waits for in-flight runs to finish.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started = false
+	s.cancel()
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job, guard RunGuard) {
+	defer s.wg.Done()
+	for {
+		slot := j.next(time.Now())
+		timer := time.NewTimer(time.Until(slot))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		start := time.Now()
+		var err error
+		if guard != nil {
+			err = guard(ctx, j.name, slot, j.next(slot), j.run)
+		} else {
+			err = j.run(ctx)
+		}
+		if err == ErrSkipped {
+			logger.GlobalLogger.Debugf("Scheduled job skipped: job=%s", j.name)
+			continue
+		}
+		if err != nil {
+			logger.GlobalLogger.Errorf("Scheduled job failed: job=%s, error=%v", j.name, err)
+			continue
+		}
+		logger.GlobalLogger.Printf("Scheduled job completed: job=%s, duration=%s", j.name, time.Since(start))
+	}
+}
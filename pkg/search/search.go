@@ -0,0 +1,384 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+	"homeinsight-properties/pkg/metrics"
+)
+
+// Document is the searchable part of a property as stored in the index. Only the fields searches
+// match on are kept; results are loaded from MongoDB by property ID.
+type Document struct {
+	PropertyID     string    `json:"propertyId"`
+	OrgID          string    `json:"orgId"`
+	StreetAddress  string    `json:"streetAddress,omitempty"`
+	City           string    `json:"city,omitempty"`
+	State          string    `json:"state,omitempty"`
+	ZipCode        string    `json:"zipCode,omitempty"`
+	County         string    `json:"county,omitempty"`
+	Owners         []string  `json:"owners,omitempty"`
+	Subdivision    string    `json:"subdivision,omitempty"`
+	SchoolDistrict string    `json:"schoolDistrict,omitempty"`
+	Location       *GeoPoint `json:"location,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Hit is one matching property. DistanceMeters is only set by Nearby.
+type Hit struct {
+	PropertyID     string
+	DistanceMeters float64
+}
+
+// Result is a page of hits in ranked order and the total number of matches.
+type Result struct {
+	Hits  []Hit
+	Total int64
+}
+
+// indexMapping types the fields that dynamic mapping would get wrong: IDs and codes are matched
+// exactly and the parcel point is a geo_point.
+const indexMapping = `{
+  "mappings": {
+    "dynamic": false,
+    "properties": {
+      "propertyId":     {"type": "keyword"},
+      "orgId":          {"type": "keyword"},
+      "streetAddress":  {"type": "text"},
+      "city":           {"type": "text", "fields": {"raw": {"type": "keyword"}}},
+      "state":          {"type": "keyword"},
+      "zipCode":        {"type": "keyword"},
+      "county":         {"type": "text"},
+      "owners":         {"type": "text"},
+      "subdivision":    {"type": "text"},
+      "schoolDistrict": {"type": "text"},
+      "location":       {"type": "geo_point"},
+      "updatedAt":      {"type": "date"}
+    }
+  }
+}`
+
+// Client indexes and queries properties in an Elasticsearch or OpenSearch index over the REST API
+// both share. Documents are keyed by organization and property ID, as property IDs are only unique
+// within an organization, and queries are limited to the organization they are made for.
+type Client struct {
+	endpoint string
+	username string
+	password string
+	client   *http.Client
+}
+
+func New(cfg *config.Config) *Client {
+	return &Client{
+		endpoint: strings.TrimRight(cfg.SearchIndex.URL, "/") + "/" + url.PathEscape(cfg.SearchIndex.Index),
+		username: cfg.SearchIndex.Username,
+		password: cfg.SearchIndex.Password,
+		client:   &http.Client{Timeout: time.Duration(cfg.SearchIndex.TimeoutSeconds) * time.Second},
+	}
+}
+
+// EnsureIndex creates the index with its mapping unless it already exists.
+func (c *Client) EnsureIndex(ctx context.Context) error {
+	status, body, err := c.do(ctx, "create_index", http.MethodPut, "", "application/json", []byte(indexMapping))
+	if err != nil {
+		return err
+	}
+	if status == http.StatusBadRequest && strings.Contains(string(body), "resource_already_exists_exception") {
+		return nil
+	}
+	return statusError("create index", status, body)
+}
+
+// Index stores a property's document. Documents are versioned by their UpdatedAt, so a stale
+// document written after a newer one is ignored rather than overwriting it.
+func (c *Client) Index(ctx context.Context, doc *Document) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	path := "/_doc/" + url.PathEscape(documentID(doc.OrgID, doc.PropertyID)) + versionQuery(doc.UpdatedAt)
+	status, data, err := c.do(ctx, "index", http.MethodPut, path, "application/json", body)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusConflict {
+		return nil
+	}
+	return statusError("index document", status, data)
+}
+
+// Delete removes a property's document; deleting one that isn't indexed is not an error.
+func (c *Client) Delete(ctx context.Context, orgID, propertyID string) error {
+	status, data, err := c.do(ctx, "delete", http.MethodDelete, "/_doc/"+url.PathEscape(documentID(orgID, propertyID)), "", nil)
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return nil
+	}
+	return statusError("delete document", status, data)
+}
+
+// Bulk indexes documents in one request. It fails if any of them wasn't stored, other than for
+// being older than the indexed version.
+func (c *Client) Bulk(ctx context.Context, docs []Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for i := range docs {
+		action := map[string]interface{}{"_id": documentID(docs[i].OrgID, docs[i].PropertyID)}
+		if !docs[i].UpdatedAt.IsZero() {
+			action["version"] = docs[i].UpdatedAt.UnixMilli()
+			action["version_type"] = "external_gte"
+		}
+		if err := encoder.Encode(map[string]interface{}{"index": action}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(&docs[i]); err != nil {
+			return err
+		}
+	}
+
+	status, data, err := c.do(ctx, "bulk", http.MethodPost, "/_bulk", "application/x-ndjson", buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := statusError("bulk index", status, data); err != nil {
+		return err
+	}
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string `json:"_id"`
+			Status int    `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return fmt.Errorf("decode bulk response: %v", err)
+	}
+	if !response.Errors {
+		return nil
+	}
+	for _, item := range response.Items {
+		for _, result := range item {
+			if result.Status >= 300 && result.Status != http.StatusConflict {
+				return fmt.Errorf("bulk index failed: id=%s, status=%d, error=%s: %s", result.ID, result.Status, result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+// Count returns the number of documents in the index.
+func (c *Client) Count(ctx context.Context) (int64, error) {
+	status, data, err := c.do(ctx, "count", http.MethodGet, "/_count", "", nil)
+	if err != nil {
+		return 0, err
+	}
+	if err := statusError("count documents", status, data); err != nil {
+		return 0, err
+	}
+	var response struct {
+		Count int64 `json:"count"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return 0, fmt.Errorf("decode count response: %v", err)
+	}
+	return response.Count, nil
+}
+
+// FullText ranks properties against a free-text query over address, owner names, subdivision and
+// school district, tolerating typos.
+func (c *Client) FullText(ctx context.Context, orgID, query string, offset, limit int) (*Result, error) {
+	return c.search(ctx, "full_text", map[string]interface{}{
+		"from": offset,
+		"size": limit,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":     query,
+						"fields":    []string{"streetAddress^3", "city^2", "owners^2", "subdivision", "schoolDistrict", "county"},
+						"fuzziness": "AUTO",
+					},
+				},
+				"filter": tenantFilter(orgID),
+			},
+		},
+		"sort": []interface{}{"_score", map[string]string{"propertyId": "asc"}},
+	})
+}
+
+// AddressCandidates returns up to limit properties whose street address resembles street, in the
+// given city and, when set, state and ZIP code, best match first.
+func (c *Client) AddressCandidates(ctx context.Context, orgID, street, city, state, zip string, limit int) (*Result, error) {
+	filter := tenantFilter(orgID)
+	filter = append(filter, map[string]interface{}{"term": map[string]string{"city.raw": city}})
+	if state != "" {
+		filter = append(filter, map[string]interface{}{"term": map[string]string{"state": state}})
+	}
+	if zip != "" {
+		filter = append(filter, map[string]interface{}{"term": map[string]string{"zipCode": zip}})
+	}
+	return c.search(ctx, "address_candidates", map[string]interface{}{
+		"size": limit,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"match": map[string]interface{}{
+						"streetAddress": map[string]interface{}{"query": street, "fuzziness": "AUTO"},
+					},
+				},
+				"filter": filter,
+			},
+		},
+	})
+}
+
+// Nearby returns properties within radiusMeters of a point, nearest first, with their distance.
+func (c *Client) Nearby(ctx context.Context, orgID string, lat, lng, radiusMeters float64, offset, limit int) (*Result, error) {
+	point := GeoPoint{Lat: lat, Lon: lng}
+	filter := tenantFilter(orgID)
+	filter = append(filter, map[string]interface{}{
+		"geo_distance": map[string]interface{}{"distance": strconv.FormatFloat(radiusMeters, 'f', -1, 64) + "m", "location": point},
+	})
+	return c.search(ctx, "nearby", map[string]interface{}{
+		"from":  offset,
+		"size":  limit,
+		"query": map[string]interface{}{"bool": map[string]interface{}{"filter": filter}},
+		"sort": []interface{}{
+			map[string]interface{}{"_geo_distance": map[string]interface{}{"location": point, "order": "asc", "unit": "m"}},
+			map[string]string{"propertyId": "asc"},
+		},
+	})
+}
+
+func (c *Client) search(ctx context.Context, operation string, query map[string]interface{}) (*Result, error) {
+	query["track_total_hits"] = true
+	query["_source"] = []string{"propertyId"}
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	status, data, err := c.do(ctx, operation, http.MethodPost, "/_search", "application/json", body)
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError("search", status, data); err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source struct {
+					PropertyID string `json:"propertyId"`
+				} `json:"_source"`
+				Sort []interface{} `json:"sort"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("decode search response: %v", err)
+	}
+	result := &Result{Total: response.Hits.Total.Value, Hits: make([]Hit, 0, len(response.Hits.Hits))}
+	for _, hit := range response.Hits.Hits {
+		h := Hit{PropertyID: hit.Source.PropertyID}
+		if operation == "nearby" && len(hit.Sort) > 0 {
+			h.DistanceMeters, _ = hit.Sort[0].(float64)
+		}
+		result.Hits = append(result.Hits, h)
+	}
+	return result, nil
+}
+
+// do sends a request to the index and returns the response status and body. Transport errors and
+// 5xx responses are counted as failed; anything else is left to the caller to judge.
+func (c *Client) do(ctx context.Context, operation, method, path, contentType string, body []byte) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		metrics.SearchIndexRequestsTotal.WithLabelValues(operation, "failed").Inc()
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		metrics.SearchIndexRequestsTotal.WithLabelValues(operation, "failed").Inc()
+		return 0, nil, err
+	}
+	if resp.StatusCode >= 500 {
+		metrics.SearchIndexRequestsTotal.WithLabelValues(operation, "failed").Inc()
+	} else {
+		metrics.SearchIndexRequestsTotal.WithLabelValues(operation, "succeeded").Inc()
+	}
+	return resp.StatusCode, data, nil
+}
+
+func statusError(action string, status int, body []byte) error {
+	if status >= 200 && status < 300 {
+		return nil
+	}
+	if len(body) > 1024 {
+		body = body[:1024]
+	}
+	return fmt.Errorf("search index %s returned status %d: %s", action, status, strings.TrimSpace(string(body)))
+}
+
+// tenantFilter limits a query to an organization's documents; an empty orgID matches every
+// organization, as for unscoped database queries.
+func tenantFilter(orgID string) []interface{} {
+	if orgID == "" {
+		return []interface{}{}
+	}
+	return []interface{}{map[string]interface{}{"term": map[string]string{"orgId": orgID}}}
+}
+
+func documentID(orgID, propertyID string) string {
+	return orgID + ":" + propertyID
+}
+
+func versionQuery(updatedAt time.Time) string {
+	if updatedAt.IsZero() {
+		return ""
+	}
+	return "?version_type=external_gte&version=" + strconv.FormatInt(updatedAt.UnixMilli(), 10)
+}
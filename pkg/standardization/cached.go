@@ -0,0 +1,57 @@
+package standardization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"homeinsight-properties/pkg/cache"
+	"homeinsight-properties/pkg/logger"
+)
+
+// cachedResult is a cached standardization; a nil Address records that the provider had no match.
+type cachedResult struct {
+	Address *Address `json:"address"`
+}
+
+// CachedStandardizer remembers a standardizer's answers in Redis, so repeated searches for an
+// address don't each cost a vendor call. Cache failures fall through to the vendor.
+type CachedStandardizer struct {
+	next Standardizer
+	ttl  time.Duration
+}
+
+func NewCachedStandardizer(next Standardizer, ttl time.Duration) *CachedStandardizer {
+	return &CachedStandardizer{next: next, ttl: ttl}
+}
+
+func (s *CachedStandardizer) Name() string {
+	return s.next.Name()
+}
+
+func (s *CachedStandardizer) Standardize(ctx context.Context, street, city, state, zip string) (*Address, error) {
+	input := strings.Join([]string{s.next.Name(), street, city, state, zip}, "|")
+	if data, err := cache.GetStandardizedAddress(ctx, input); err != nil {
+		logger.GlobalLogger.Warnf("Failed to read cached address standardization: error=%v", err)
+	} else if data != nil {
+		var result cachedResult
+		if err := json.Unmarshal(data, &result); err == nil {
+			if result.Address == nil {
+				return nil, ErrNoMatch
+			}
+			return result.Address, nil
+		}
+	}
+
+	address, err := s.next.Standardize(ctx, street, city, state, zip)
+	if err != nil && !errors.Is(err, ErrNoMatch) {
+		return nil, err
+	}
+	data, _ := json.Marshal(&cachedResult{Address: address})
+	if cacheErr := cache.SetStandardizedAddress(ctx, input, data, s.ttl); cacheErr != nil {
+		logger.GlobalLogger.Warnf("Failed to cache address standardization: error=%v", cacheErr)
+	}
+	return address, err
+}
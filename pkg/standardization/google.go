@@ -0,0 +1,112 @@
+package standardization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GoogleStandardizer geocodes addresses with the Google Geocoding API. Only street-level results
+// count as a match; Google answers unknown streets with the surrounding city or ZIP code.
+type GoogleStandardizer struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+func NewGoogleStandardizer(baseURL, apiKey string, timeout time.Duration) *GoogleStandardizer {
+	return &GoogleStandardizer{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *GoogleStandardizer) Name() string {
+	return "google"
+}
+
+type googleGeocodeResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Results      []struct {
+		AddressComponents []struct {
+			ShortName string   `json:"short_name"`
+			Types     []string `json:"types"`
+		} `json:"address_components"`
+		Geometry struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"geometry"`
+	} `json:"results"`
+}
+
+func (s *GoogleStandardizer) Standardize(ctx context.Context, street, city, state, zip string) (*Address, error) {
+	query := url.Values{}
+	query.Set("address", strings.TrimSpace(fmt.Sprintf("%s, %s, %s %s", street, city, state, zip)))
+	query.Set("components", "country:US")
+	query.Set("key", s.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/maps/api/geocode/json?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Google geocode request failed: %v", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Google geocode request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("Google geocode request failed: read response: %v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Google geocode request failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result googleGeocodeResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("Google geocode request failed: decode response: %v", err)
+	}
+	switch result.Status {
+	case "OK":
+	case "ZERO_RESULTS":
+		return nil, fmt.Errorf("Google geocode request failed: %w: street=%s", ErrNoMatch, street)
+	default:
+		return nil, fmt.Errorf("Google geocode request failed: status %s: %s", result.Status, result.ErrorMessage)
+	}
+
+	first := result.Results[0]
+	address := &Address{Lat: first.Geometry.Location.Lat, Lng: first.Geometry.Location.Lng}
+	var route string
+	for _, component := range first.AddressComponents {
+		for _, componentType := range component.Types {
+			switch componentType {
+			case "street_number":
+				address.HouseNumber = component.ShortName
+			case "route":
+				route = component.ShortName
+			case "locality":
+				address.City = component.ShortName
+			case "administrative_area_level_1":
+				address.State = component.ShortName
+			case "postal_code":
+				address.Zip = component.ShortName
+			case "postal_code_suffix":
+				address.ZipPlus4 = component.ShortName
+			}
+		}
+	}
+	if address.HouseNumber == "" || route == "" {
+		return nil, fmt.Errorf("Google geocode request failed: %w: street=%s", ErrNoMatch, street)
+	}
+	address.Street = address.HouseNumber + " " + route
+	return address, nil
+}
@@ -0,0 +1,105 @@
+package standardization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// SmartyStandardizer validates and geocodes addresses with the Smarty US Street Address API.
+type SmartyStandardizer struct {
+	baseURL   string
+	authID    string
+	authToken string
+	client    *http.Client
+}
+
+func NewSmartyStandardizer(baseURL, authID, authToken string, timeout time.Duration) *SmartyStandardizer {
+	return &SmartyStandardizer{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		authID:    authID,
+		authToken: authToken,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *SmartyStandardizer) Name() string {
+	return "smarty"
+}
+
+type smartyCandidate struct {
+	DeliveryLine1 string `json:"delivery_line_1"`
+	Components    struct {
+		PrimaryNumber      string `json:"primary_number"`
+		StreetPredirection string `json:"street_predirection"`
+		StreetName         string `json:"street_name"`
+		StreetSuffix       string `json:"street_suffix"`
+		CityName           string `json:"city_name"`
+		StateAbbreviation  string `json:"state_abbreviation"`
+		Zipcode            string `json:"zipcode"`
+		Plus4Code          string `json:"plus4_code"`
+	} `json:"components"`
+	Metadata struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	} `json:"metadata"`
+}
+
+func (s *SmartyStandardizer) Standardize(ctx context.Context, street, city, state, zip string) (*Address, error) {
+	query := url.Values{}
+	query.Set("auth-id", s.authID)
+	query.Set("auth-token", s.authToken)
+	query.Set("street", street)
+	query.Set("city", city)
+	query.Set("state", state)
+	query.Set("zipcode", zip)
+	query.Set("candidates", "1")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/street-address?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("Smarty address request failed: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Smarty address request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("Smarty address request failed: read response: %v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("Smarty address request failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var candidates []smartyCandidate
+	if err := json.Unmarshal(data, &candidates); err != nil {
+		return nil, fmt.Errorf("Smarty address request failed: decode response: %v", err)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("Smarty address request failed: %w: street=%s", ErrNoMatch, street)
+	}
+	candidate := &candidates[0]
+	streetName := candidate.Components.StreetName
+	if candidate.Components.StreetPredirection != "" {
+		streetName = candidate.Components.StreetPredirection + " " + streetName
+	}
+	return &Address{
+		Street:       candidate.DeliveryLine1,
+		City:         candidate.Components.CityName,
+		State:        candidate.Components.StateAbbreviation,
+		Zip:          candidate.Components.Zipcode,
+		ZipPlus4:     candidate.Components.Plus4Code,
+		HouseNumber:  candidate.Components.PrimaryNumber,
+		StreetName:   streetName,
+		StreetSuffix: candidate.Components.StreetSuffix,
+		Lat:          candidate.Metadata.Latitude,
+		Lng:          candidate.Metadata.Longitude,
+	}, nil
+}
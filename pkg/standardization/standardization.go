@@ -0,0 +1,61 @@
+package standardization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+)
+
+// ErrNoMatch is wrapped by standardizers that can't resolve the input to a deliverable address.
+var ErrNoMatch = errors.New("address not matched by standardization provider")
+
+// Address is a postal address in the provider's canonical spelling. Lat and Lng are zero when the
+// provider doesn't geocode; the parsed street fields are empty when it doesn't return components.
+type Address struct {
+	Street       string  `json:"street"`
+	City         string  `json:"city"`
+	State        string  `json:"state"`
+	Zip          string  `json:"zip"`
+	ZipPlus4     string  `json:"zipPlus4,omitempty"`
+	HouseNumber  string  `json:"houseNumber,omitempty"`
+	StreetName   string  `json:"streetName,omitempty"`
+	StreetSuffix string  `json:"streetSuffix,omitempty"`
+	Lat          float64 `json:"lat,omitempty"`
+	Lng          float64 `json:"lng,omitempty"`
+}
+
+// HasCoordinates reports whether the provider geocoded the address.
+func (a *Address) HasCoordinates() bool {
+	return a.Lat != 0 || a.Lng != 0
+}
+
+// Standardizer resolves free-form address input to its canonical postal form.
+type Standardizer interface {
+	Name() string
+	Standardize(ctx context.Context, street, city, state, zip string) (*Address, error)
+}
+
+// New builds the configured standardizer, wrapped in the Redis result cache. Returns nil when
+// address standardization is turned off.
+func New(cfg *config.Config) (Standardizer, error) {
+	settings := cfg.AddressStandardization
+	timeout := time.Duration(settings.TimeoutSeconds) * time.Second
+
+	var standardizer Standardizer
+	switch settings.Provider {
+	case "":
+		return nil, nil
+	case "usps":
+		standardizer = NewUSPSStandardizer(settings.USPS.BaseURL, settings.USPS.ClientID, settings.USPS.ClientSecret, timeout)
+	case "smarty":
+		standardizer = NewSmartyStandardizer(settings.Smarty.BaseURL, settings.Smarty.AuthID, settings.Smarty.AuthToken, timeout)
+	case "google":
+		standardizer = NewGoogleStandardizer(settings.Google.BaseURL, settings.Google.APIKey, timeout)
+	default:
+		return nil, fmt.Errorf("unknown address standardization provider: %q", settings.Provider)
+	}
+	return NewCachedStandardizer(standardizer, time.Duration(settings.CacheTTLHours)*time.Hour), nil
+}
@@ -0,0 +1,158 @@
+package standardization
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// USPSStandardizer validates addresses against the USPS Addresses API v3. USPS doesn't geocode, so
+// its addresses carry no coordinates.
+type USPSStandardizer struct {
+	baseURL      string
+	clientID     string
+	clientSecret string
+	client       *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+func NewUSPSStandardizer(baseURL, clientID, clientSecret string, timeout time.Duration) *USPSStandardizer {
+	return &USPSStandardizer{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		client:       &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *USPSStandardizer) Name() string {
+	return "usps"
+}
+
+type uspsTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type uspsAddressResponse struct {
+	Address struct {
+		StreetAddress    string `json:"streetAddress"`
+		SecondaryAddress string `json:"secondaryAddress"`
+		City             string `json:"city"`
+		State            string `json:"state"`
+		ZIPCode          string `json:"ZIPCode"`
+		ZIPPlus4         string `json:"ZIPPlus4"`
+	} `json:"address"`
+}
+
+// accessToken returns the cached OAuth token, requesting a new one a minute before it expires.
+func (s *USPSStandardizer) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Now().Before(s.tokenExpiry) {
+		return s.token, nil
+	}
+
+	body, _ := json.Marshal(map[string]string{
+		"grant_type":    "client_credentials",
+		"client_id":     s.clientID,
+		"client_secret": s.clientSecret,
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/oauth2/v3/token", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("USPS token request failed: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("USPS token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("USPS token request failed: read response: %v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("USPS token request failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var token uspsTokenResponse
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", fmt.Errorf("USPS token request failed: decode response: %v", err)
+	}
+	s.token = token.AccessToken
+	s.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return s.token, nil
+}
+
+func (s *USPSStandardizer) Standardize(ctx context.Context, street, city, state, zip string) (*Address, error) {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("streetAddress", street)
+	query.Set("city", city)
+	query.Set("state", state)
+	if zip != "" {
+		query.Set("ZIPCode", zip)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/addresses/v3/address?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("USPS address request failed: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("USPS address request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("USPS address request failed: read response: %v", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		s.mu.Lock()
+		s.token = ""
+		s.mu.Unlock()
+		return nil, fmt.Errorf("USPS address request failed: token rejected")
+	case resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("USPS address request failed: %w: street=%s", ErrNoMatch, street)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("USPS address request failed: status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result uspsAddressResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("USPS address request failed: decode response: %v", err)
+	}
+	if result.Address.StreetAddress == "" {
+		return nil, fmt.Errorf("USPS address request failed: %w: street=%s", ErrNoMatch, street)
+	}
+	streetAddress := result.Address.StreetAddress
+	if result.Address.SecondaryAddress != "" {
+		streetAddress += " " + result.Address.SecondaryAddress
+	}
+	return &Address{
+		Street:   streetAddress,
+		City:     result.Address.City,
+		State:    result.Address.State,
+		Zip:      result.Address.ZIPCode,
+		ZipPlus4: result.Address.ZIPPlus4,
+	}, nil
+}
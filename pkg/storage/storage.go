@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"homeinsight-properties/pkg/config"
+)
+
+// ObjectStorage stores uploaded files in a bucket. Objects are private; clients read them through
+// short-lived signed URLs.
+type ObjectStorage interface {
+	Name() string
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// PutStream stores size bytes read from body, for files too large to hold in memory
+	PutStream(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	// Open streams an object's content; the caller closes it
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(key string, ttl time.Duration) (string, error)
+}
+
+// New builds the configured object storage. GCS is reached through its S3-compatible XML API with
+// HMAC keys, so both backends share the SigV4 client.
+func New(cfg *config.Config) (ObjectStorage, error) {
+	settings := cfg.Media
+	timeout := time.Duration(settings.TimeoutSeconds) * time.Second
+	switch settings.Storage {
+	case "s3":
+		return NewS3Storage("s3", settings.Endpoint, settings.Region, settings.Bucket, settings.AccessKeyID, settings.SecretAccessKey, timeout), nil
+	case "gcs":
+		return NewS3Storage("gcs", settings.Endpoint, settings.Region, settings.Bucket, settings.AccessKeyID, settings.SecretAccessKey, timeout), nil
+	default:
+		return nil, fmt.Errorf("unknown media storage: %q", settings.Storage)
+	}
+}